@@ -56,6 +56,9 @@ export interface ContentMatch {
     chunkMatches?: ChunkMatch[]
     hunks?: DecoratedHunk[]
     debug?: string
+    /** Set for matches of a search over a revision range (rev:a..b). */
+    introducedCommit?: string
+    removedCommit?: string
 }
 
 export interface DecoratedHunk {
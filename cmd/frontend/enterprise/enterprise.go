@@ -41,6 +41,21 @@ type Services struct {
 	// Handler for exporting code insights data.
 	CodeInsightsDataExportHandler http.Handler
 
+	// Handler for streaming the output of running executor jobs.
+	ExecutorLogStreamHandler http.Handler
+
+	// Handler for reading and writing the cache archives of executor jobs.
+	ExecutorCacheHandler http.Handler
+
+	// Handler for serving precise code navigation over the Language Server Protocol.
+	CodeIntelLSPHandler http.Handler
+
+	// Handler for exporting the processed SCIP index of an upload.
+	CodeIntelSCIPExportHandler http.Handler
+
+	// Handler for exporting the code intel coverage report as CSV.
+	CodeIntelCoverageExportHandler http.Handler
+
 	PermissionsGitHubWebhook    webhooks.Registerer
 	NewCodeIntelUploadHandler   NewCodeIntelUploadHandler
 	RankingService              RankingService
@@ -70,6 +85,7 @@ type RankingService interface {
 	LastUpdatedAt(ctx context.Context, repoIDs []api.RepoID) (map[api.RepoID]time.Time, error)
 	GetRepoRank(ctx context.Context, repoName api.RepoName) (_ []float64, err error)
 	GetDocumentRanks(ctx context.Context, repoName api.RepoName) (_ map[string][]float64, err error)
+	ExplainDocumentRank(ctx context.Context, repoName api.RepoName, path string) (_ *api.DocumentRankExplanation, err error)
 }
 
 // NewExecutorProxyHandler creates a new proxy handler for routes accessible to the
@@ -106,6 +122,11 @@ func DefaultServices() Services {
 		NewGitHubAppSetupHandler:        func() http.Handler { return makeNotFoundHandler("Sourcegraph GitHub App setup") },
 		NewComputeStreamHandler:         func() http.Handler { return makeNotFoundHandler("compute streaming endpoint") },
 		CodeInsightsDataExportHandler:   makeNotFoundHandler("code insights data export handler"),
+		ExecutorLogStreamHandler:        makeNotFoundHandler("executor log stream handler"),
+		ExecutorCacheHandler:            makeNotFoundHandler("executor cache handler"),
+		CodeIntelLSPHandler:             makeNotFoundHandler("code intel LSP handler"),
+		CodeIntelSCIPExportHandler:      makeNotFoundHandler("code intel SCIP export handler"),
+		CodeIntelCoverageExportHandler:  makeNotFoundHandler("code intel coverage export handler"),
 	}
 }
 
@@ -211,3 +232,7 @@ func (s stubRankingService) GetRepoRank(ctx context.Context, repoName api.RepoNa
 func (s stubRankingService) GetDocumentRanks(ctx context.Context, repoName api.RepoName) (_ map[string][]float64, err error) {
 	return nil, nil
 }
+
+func (s stubRankingService) ExplainDocumentRank(ctx context.Context, repoName api.RepoName, path string) (_ *api.DocumentRankExplanation, err error) {
+	return nil, nil
+}
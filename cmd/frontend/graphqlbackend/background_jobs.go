@@ -10,6 +10,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/auth"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine/recorder"
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/jobgraph"
 )
 
 // dayCountForStats is hard-coded for now. This signifies the number of days to use for generating the stats for each routine.
@@ -44,6 +45,10 @@ type RoutineStatsResolver struct {
 	stats recorder.RoutineRunStats
 }
 
+type BackgroundJobDependencyResolver struct {
+	dependency jobgraph.Dependency
+}
+
 // backgroundJobConnectionResolver resolves a list of access tokens.
 //
 // 🚨 SECURITY: When instantiating a backgroundJobConnectionResolver value, the caller MUST check
@@ -99,6 +104,27 @@ func (r *schemaResolver) backgroundJobByID(ctx context.Context, id graphql.ID) (
 	return &BackgroundJobResolver{jobInfo: item}, nil
 }
 
+func (r *schemaResolver) BackgroundJobDependencies(ctx context.Context, args *struct {
+	Table string
+	ID    int32
+}) ([]*BackgroundJobDependencyResolver, error) {
+	// 🚨 SECURITY: Only site admins may view background job dependencies.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	dependencies, err := jobgraph.NewStore(r.db.Handle()).Graph(ctx, jobgraph.Job{Table: args.Table, ID: int(args.ID)})
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*BackgroundJobDependencyResolver, 0, len(dependencies))
+	for _, dependency := range dependencies {
+		resolvers = append(resolvers, &BackgroundJobDependencyResolver{dependency: dependency})
+	}
+	return resolvers, nil
+}
+
 func (r *backgroundJobConnectionResolver) Nodes(context.Context) ([]*BackgroundJobResolver, error) {
 	resolvers, err := r.compute()
 	if err != nil {
@@ -242,3 +268,21 @@ func (r *RoutineStatsResolver) MinDurationMs() int32 { return r.stats.MinDuratio
 func (r *RoutineStatsResolver) AvgDurationMs() int32 { return r.stats.AvgDurationMs }
 
 func (r *RoutineStatsResolver) MaxDurationMs() int32 { return r.stats.MaxDurationMs }
+
+func (r *BackgroundJobDependencyResolver) JobTable() string { return r.dependency.Job.Table }
+
+func (r *BackgroundJobDependencyResolver) JobID() int32 { return int32(r.dependency.Job.ID) }
+
+func (r *BackgroundJobDependencyResolver) DependencyTable() string {
+	return r.dependency.DependsOn.Table
+}
+
+func (r *BackgroundJobDependencyResolver) DependencyID() int32 {
+	return int32(r.dependency.DependsOn.ID)
+}
+
+func (r *BackgroundJobDependencyResolver) State() string { return r.dependency.State }
+
+func (r *BackgroundJobDependencyResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.dependency.CreatedAt}
+}
@@ -210,6 +210,10 @@ type ListBatchChangeBulkOperationArgs struct {
 	CreatedAfter *gqlutil.DateTime
 }
 
+type BatchSpecExecutionCacheStatsArgs struct {
+	Since *gqlutil.DateTime
+}
+
 type CreateChangesetCommentsArgs struct {
 	BulkOperationBaseArgs
 	Body string
@@ -233,6 +237,28 @@ type PublishChangesetsArgs struct {
 	Draft bool
 }
 
+type StartChangesetRolloutArgs struct {
+	BatchChange graphql.ID
+	Waves       []ChangesetRolloutWaveInput
+	SoakTime    int32
+	Squash      bool
+}
+
+type ChangesetRolloutWaveInput struct {
+	Changesets        *[]graphql.ID
+	Label             *string
+	RepoMetadataKey   *string
+	RepoMetadataValue *string
+}
+
+type PauseChangesetRolloutArgs struct {
+	Rollout graphql.ID
+}
+
+type ResumeChangesetRolloutArgs struct {
+	Rollout graphql.ID
+}
+
 type ResolveWorkspacesForBatchSpecArgs struct {
 	BatchSpec string
 }
@@ -284,12 +310,16 @@ type BatchChangesResolver interface {
 	MergeChangesets(ctx context.Context, args *MergeChangesetsArgs) (BulkOperationResolver, error)
 	CloseChangesets(ctx context.Context, args *CloseChangesetsArgs) (BulkOperationResolver, error)
 	PublishChangesets(ctx context.Context, args *PublishChangesetsArgs) (BulkOperationResolver, error)
+	StartChangesetRollout(ctx context.Context, args *StartChangesetRolloutArgs) (ChangesetRolloutResolver, error)
+	PauseChangesetRollout(ctx context.Context, args *PauseChangesetRolloutArgs) (ChangesetRolloutResolver, error)
+	ResumeChangesetRollout(ctx context.Context, args *ResumeChangesetRolloutArgs) (ChangesetRolloutResolver, error)
 
 	// Queries
 	BatchChange(ctx context.Context, args *BatchChangeArgs) (BatchChangeResolver, error)
 	BatchChanges(cx context.Context, args *ListBatchChangesArgs) (BatchChangesConnectionResolver, error)
 
 	GlobalChangesetsStats(cx context.Context) (GlobalChangesetsStatsResolver, error)
+	BatchSpecExecutionCacheStats(ctx context.Context, args *BatchSpecExecutionCacheStatsArgs) (BatchSpecExecutionCacheStatsResolver, error)
 
 	BatchChangesCodeHosts(ctx context.Context, args *ListBatchChangesCodeHostsArgs) (BatchChangesCodeHostConnectionResolver, error)
 	RepoChangesetsStats(ctx context.Context, repo *graphql.ID) (RepoChangesetsStatsResolver, error)
@@ -325,6 +355,20 @@ type BulkOperationResolver interface {
 	FinishedAt() *gqlutil.DateTime
 }
 
+type ChangesetRolloutResolver interface {
+	ID() graphql.ID
+	BatchChange(ctx context.Context) (BatchChangeResolver, error)
+	State() string
+	CurrentWave() int32
+	WaveCount() int32
+	SoakTime() int32
+	Squash() bool
+	FailureMessage() *string
+	Initiator(ctx context.Context) (*UserResolver, error)
+	CreatedAt() gqlutil.DateTime
+	UpdatedAt() gqlutil.DateTime
+}
+
 type ChangesetJobErrorResolver interface {
 	Changeset() ChangesetResolver
 	Error() *string
@@ -723,6 +767,12 @@ type GlobalChangesetsStatsResolver interface {
 	CommonChangesetsStatsResolver
 }
 
+type BatchSpecExecutionCacheStatsResolver interface {
+	Steps() int32
+	CachedSteps() int32
+	HitRate() float64
+}
+
 type ChangesetsStatsResolver interface {
 	CommonChangesetsStatsResolver
 	Retrying() int32
@@ -786,6 +836,9 @@ type ExternalChangesetResolver interface {
 	ReviewState(context.Context) *string
 	// CheckState returns a value of type *btypes.ChangesetCheckState.
 	CheckState() *string
+	// ConflictState returns a value of type *btypes.ChangesetConflictState.
+	ConflictState() *string
+	ConflictCheckedAt() *gqlutil.DateTime
 	Repository(ctx context.Context) *RepositoryResolver
 
 	Events(ctx context.Context, args *ChangesetEventsConnectionArgs) (ChangesetEventsConnectionResolver, error)
@@ -0,0 +1,224 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/deadletter"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// DeadLetterQueues resolves the background job queues whose failed records can be managed.
+func (r *schemaResolver) DeadLetterQueues(ctx context.Context) ([]*deadLetterQueueResolver, error) {
+	// 🚨 SECURITY: Only site admins may view failed records of background job queues
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	store := deadletter.NewStore(r.db.Handle())
+
+	resolvers := make([]*deadLetterQueueResolver, 0, len(deadletter.Queues))
+	for _, queue := range deadletter.Queues {
+		resolvers = append(resolvers, &deadLetterQueueResolver{store: store, queue: queue})
+	}
+
+	return resolvers, nil
+}
+
+// DeadLetterRecord resolves a single failed record of a background job queue.
+func (r *schemaResolver) DeadLetterRecord(ctx context.Context, args *struct {
+	Queue string
+	ID    int32
+}) (*deadLetterRecordResolver, error) {
+	// 🚨 SECURITY: Only site admins may view failed records of background job queues
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	queue, err := deadLetterQueueByName(args.Queue)
+	if err != nil {
+		return nil, err
+	}
+
+	record, exists, err := deadletter.NewStore(r.db.Handle()).GetByID(ctx, queue, int(args.ID))
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	return &deadLetterRecordResolver{queue: queue, record: record}, nil
+}
+
+type deadLetterRecordsArgs struct {
+	Queue string
+	IDs   []int32
+}
+
+// RequeueDeadLetterRecords moves failed records of a background job queue back into the queued state.
+func (r *schemaResolver) RequeueDeadLetterRecords(ctx context.Context, args *deadLetterRecordsArgs) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may requeue failed records of background job queues
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	queue, err := deadLetterQueueByName(args.Queue)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := deadletter.NewStore(r.db.Handle()).Requeue(ctx, queue, toInts(args.IDs)); err != nil {
+		return nil, err
+	}
+
+	return &EmptyResponse{}, nil
+}
+
+// DiscardDeadLetterRecords discards failed records of a background job queue.
+func (r *schemaResolver) DiscardDeadLetterRecords(ctx context.Context, args *deadLetterRecordsArgs) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may discard failed records of background job queues
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	queue, err := deadLetterQueueByName(args.Queue)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := deadletter.NewStore(r.db.Handle()).Discard(ctx, queue, toInts(args.IDs)); err != nil {
+		return nil, err
+	}
+
+	return &EmptyResponse{}, nil
+}
+
+func deadLetterQueueByName(name string) (deadletter.Queue, error) {
+	queue, ok := deadletter.QueueByName(name)
+	if !ok {
+		return deadletter.Queue{}, errors.Newf("unknown queue %q", name)
+	}
+
+	return queue, nil
+}
+
+func toInts(values []int32) []int {
+	ints := make([]int, 0, len(values))
+	for _, value := range values {
+		ints = append(ints, int(value))
+	}
+
+	return ints
+}
+
+// deadLetterQueueResolver implements the GraphQL type DeadLetterQueue.
+type deadLetterQueueResolver struct {
+	store *deadletter.Store
+	queue deadletter.Queue
+}
+
+func (r *deadLetterQueueResolver) Name() string { return r.queue.Name }
+
+func (r *deadLetterQueueResolver) FailedCount(ctx context.Context) (int32, error) {
+	count, err := r.store.CountFailed(ctx, r.queue)
+	return int32(count), err
+}
+
+// maxDeadLetterRecordsPageSize is the maximum number of failed records returned per page.
+const maxDeadLetterRecordsPageSize = 500
+
+func (r *deadLetterQueueResolver) Records(ctx context.Context, args *struct {
+	First int32
+	After *string
+}) (*deadLetterRecordConnectionResolver, error) {
+	if args.First < 0 {
+		return nil, errors.Newf("expected non-negative 'first', got %d", args.First)
+	}
+	opts := deadletter.ListOptions{Limit: int(args.First)}
+	if opts.Limit > maxDeadLetterRecordsPageSize {
+		opts.Limit = maxDeadLetterRecordsPageSize
+	}
+	if args.After != nil {
+		offset, err := strconv.Atoi(*args.After)
+		if err != nil || offset < 0 {
+			return nil, errors.Newf("cannot parse offset %q", *args.After)
+		}
+		opts.Offset = offset
+	}
+
+	return &deadLetterRecordConnectionResolver{ctx: ctx, store: r.store, queue: r.queue, opts: opts}, nil
+}
+
+// deadLetterRecordConnectionResolver implements the GraphQL type DeadLetterRecordConnection.
+type deadLetterRecordConnectionResolver struct {
+	ctx   context.Context
+	store *deadletter.Store
+	queue deadletter.Queue
+	opts  deadletter.ListOptions
+
+	once       sync.Once
+	records    []deadletter.Record
+	totalCount int
+	err        error
+}
+
+func (r *deadLetterRecordConnectionResolver) compute() ([]deadletter.Record, int, error) {
+	r.once.Do(func() {
+		r.records, r.totalCount, r.err = r.store.List(r.ctx, r.queue, r.opts)
+	})
+	return r.records, r.totalCount, r.err
+}
+
+func (r *deadLetterRecordConnectionResolver) Nodes() ([]*deadLetterRecordResolver, error) {
+	records, _, err := r.compute()
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*deadLetterRecordResolver, 0, len(records))
+	for _, record := range records {
+		resolvers = append(resolvers, &deadLetterRecordResolver{queue: r.queue, record: record})
+	}
+
+	return resolvers, nil
+}
+
+func (r *deadLetterRecordConnectionResolver) TotalCount() (int32, error) {
+	_, totalCount, err := r.compute()
+	return int32(totalCount), err
+}
+
+func (r *deadLetterRecordConnectionResolver) PageInfo() (*graphqlutil.PageInfo, error) {
+	records, totalCount, err := r.compute()
+	if err != nil {
+		return nil, err
+	}
+
+	if next := r.opts.Offset + len(records); next < totalCount {
+		return graphqlutil.NextPageCursor(strconv.Itoa(next)), nil
+	}
+	return graphqlutil.HasNextPage(false), nil
+}
+
+// deadLetterRecordResolver implements the GraphQL type DeadLetterRecord.
+type deadLetterRecordResolver struct {
+	queue  deadletter.Queue
+	record deadletter.Record
+}
+
+func (r *deadLetterRecordResolver) Queue() string           { return r.queue.Name }
+func (r *deadLetterRecordResolver) ID() int32               { return int32(r.record.ID) }
+func (r *deadLetterRecordResolver) FailureMessage() *string { return r.record.FailureMessage }
+func (r *deadLetterRecordResolver) NumFailures() int32      { return int32(r.record.NumFailures) }
+func (r *deadLetterRecordResolver) NumResets() int32        { return int32(r.record.NumResets) }
+func (r *deadLetterRecordResolver) QueuedAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.record.QueuedAt)
+}
+func (r *deadLetterRecordResolver) StartedAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.record.StartedAt)
+}
+func (r *deadLetterRecordResolver) FinishedAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.record.FinishedAt)
+}
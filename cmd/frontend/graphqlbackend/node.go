@@ -253,6 +253,11 @@ func (r *NodeResolver) ToBulkOperation() (BulkOperationResolver, bool) {
 	return n, ok
 }
 
+func (r *NodeResolver) ToChangesetRollout() (ChangesetRolloutResolver, bool) {
+	n, ok := r.Node.(ChangesetRolloutResolver)
+	return n, ok
+}
+
 func (r *NodeResolver) ToHiddenBatchSpecWorkspace() (HiddenBatchSpecWorkspaceResolver, bool) {
 	n, ok := r.Node.(BatchSpecWorkspaceResolver)
 	if !ok {
@@ -416,6 +416,10 @@ func (r *RepositoryResolver) CodeIntelligenceCommitGraph(ctx context.Context) (r
 	return EnterpriseResolvers.codeIntelResolver.CommitGraph(ctx, r.ID())
 }
 
+func (r *RepositoryResolver) CodeIntelUnreferencedSymbols(ctx context.Context, args *resolverstubs.CodeIntelUnreferencedSymbolsArgs) (resolverstubs.CodeIntelUnreferencedSymbolConnectionResolver, error) {
+	return EnterpriseResolvers.codeIntelResolver.UnreferencedSymbols(ctx, r.ID(), args)
+}
+
 func (r *RepositoryResolver) CodeIntelSummary(ctx context.Context) (resolverstubs.CodeIntelRepositorySummaryResolver, error) {
 	return EnterpriseResolvers.codeIntelResolver.RepositorySummary(ctx, r.ID())
 }
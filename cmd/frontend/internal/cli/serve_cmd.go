@@ -285,8 +285,12 @@ func makeExternalAPI(db database.DB, logger sglog.Logger, schema *graphql.Schema
 			BatchesChangesFileUploadHandler: enterprise.BatchesChangesFileUploadHandler,
 			SCIMHandler:                     enterprise.SCIMHandler,
 			NewCodeIntelUploadHandler:       enterprise.NewCodeIntelUploadHandler,
+			CodeIntelLSPHandler:             enterprise.CodeIntelLSPHandler,
+			CodeIntelSCIPExportHandler:      enterprise.CodeIntelSCIPExportHandler,
+			CodeIntelCoverageExportHandler:  enterprise.CodeIntelCoverageExportHandler,
 			NewComputeStreamHandler:         enterprise.NewComputeStreamHandler,
 			CodeInsightsDataExportHandler:   enterprise.CodeInsightsDataExportHandler,
+			ExecutorLogStreamHandler:        enterprise.ExecutorLogStreamHandler,
 		},
 		enterprise.NewExecutorProxyHandler,
 		enterprise.NewGitHubAppSetupHandler,
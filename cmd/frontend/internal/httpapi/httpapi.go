@@ -61,13 +61,19 @@ type Handlers struct {
 	SCIMHandler http.Handler
 
 	// Code intel
-	NewCodeIntelUploadHandler enterprise.NewCodeIntelUploadHandler
+	NewCodeIntelUploadHandler      enterprise.NewCodeIntelUploadHandler
+	CodeIntelLSPHandler            http.Handler
+	CodeIntelSCIPExportHandler     http.Handler
+	CodeIntelCoverageExportHandler http.Handler
 
 	// Compute
 	NewComputeStreamHandler enterprise.NewComputeStreamHandler
 
 	// Code Insights
 	CodeInsightsDataExportHandler http.Handler
+
+	// Executors
+	ExecutorLogStreamHandler http.Handler
 }
 
 // NewHandler returns a new API handler that uses the provided API
@@ -141,10 +147,15 @@ func NewHandler(
 	m.Get(apirouter.LSIFUpload).Handler(trace.Route(handlers.NewCodeIntelUploadHandler(true)))
 	m.Get(apirouter.SCIPUpload).Handler(trace.Route(handlers.NewCodeIntelUploadHandler(true)))
 	m.Get(apirouter.SCIPUploadExists).Handler(trace.Route(noopHandler))
+	m.Get(apirouter.SCIPExport).Handler(trace.Route(handlers.CodeIntelSCIPExportHandler))
+	m.Get(apirouter.CodeIntelLSP).Handler(trace.Route(handlers.CodeIntelLSPHandler))
+	m.Get(apirouter.CodeIntelCoverageExport).Handler(trace.Route(handlers.CodeIntelCoverageExportHandler))
 	m.Get(apirouter.ComputeStream).Handler(trace.Route(handlers.NewComputeStreamHandler()))
 
 	m.Get(apirouter.CodeInsightsDataExport).Handler(trace.Route(handlers.CodeInsightsDataExportHandler))
 
+	m.Get(apirouter.ExecutorLogStream).Handler(trace.Route(handlers.ExecutorLogStreamHandler))
+
 	if envvar.SourcegraphDotComMode() {
 		m.Path("/updates").Methods("GET", "POST").Name("updatecheck").Handler(trace.Route(http.HandlerFunc(updatecheck.HandlerWithLog(logger))))
 	}
@@ -222,6 +233,7 @@ func NewInternalHandler(
 	m.Get(apirouter.ReposIndex).Handler(trace.Route(handler(indexer.serveList)))
 	m.Get(apirouter.RepoRank).Handler(trace.Route(handler(indexer.serveRepoRank)))
 	m.Get(apirouter.DocumentRanks).Handler(trace.Route(handler(indexer.serveDocumentRanks)))
+	m.Get(apirouter.DocumentRankExplain).Handler(trace.Route(handler(indexer.serveDocumentRankExplain)))
 	m.Get(apirouter.UpdateIndexStatus).Handler(trace.Route(handler(indexer.handleIndexStatusUpdate)))
 
 	m.Get(apirouter.ExternalURL).Handler(trace.Route(handler(serveExternalURL)))
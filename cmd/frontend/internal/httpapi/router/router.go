@@ -10,9 +10,12 @@ import (
 const (
 	GraphQL = "graphql"
 
-	LSIFUpload       = "lsif.upload"
-	SCIPUpload       = "scip.upload"
-	SCIPUploadExists = "scip.upload.exists"
+	LSIFUpload              = "lsif.upload"
+	SCIPUpload              = "scip.upload"
+	SCIPUploadExists        = "scip.upload.exists"
+	SCIPExport              = "scip.export"
+	CodeIntelLSP            = "codeintel.lsp"
+	CodeIntelCoverageExport = "codeintel.coverage.export"
 
 	SearchStream   = "search.stream"
 	ComputeStream  = "compute.stream"
@@ -41,6 +44,8 @@ const (
 
 	CodeInsightsDataExport = "insights.data.export"
 
+	ExecutorLogStream = "executors.log-stream"
+
 	ExternalURL            = "internal.app-url"
 	SendEmail              = "internal.send-email"
 	GitInfoRefs            = "internal.git.info-refs"
@@ -52,6 +57,7 @@ const (
 	StreamingSearch        = "internal.stream-search"
 	RepoRank               = "internal.repo-rank"
 	DocumentRanks          = "internal.document-ranks"
+	DocumentRankExplain    = "internal.document-rank-explain"
 	UpdateIndexStatus      = "internal.update-index-status"
 )
 
@@ -78,12 +84,16 @@ func New(base *mux.Router) *mux.Router {
 	base.Path("/lsif/upload").Methods("POST").Name(LSIFUpload)
 	base.Path("/scip/upload").Methods("POST").Name(SCIPUpload)
 	base.Path("/scip/upload").Methods("HEAD").Name(SCIPUploadExists)
+	base.Path("/scip/export").Methods("GET").Name(SCIPExport)
+	base.Path("/codeintel/lsp").Methods("GET").Name(CodeIntelLSP)
+	base.Path("/codeintel/coverage/export").Methods("GET").Name(CodeIntelCoverageExport)
 	base.Path("/search/stream").Methods("GET").Name(SearchStream)
 	base.Path("/compute/stream").Methods("GET", "POST").Name(ComputeStream)
 	base.Path("/blame/" + routevar.Repo + routevar.RepoRevSuffix + "/stream/{Path:.*}").Methods("GET").Name(GitBlameStream)
 	base.Path("/src-cli/versions/{rest:.*}").Methods("GET", "POST").Name(SrcCliVersionCache)
 	base.Path("/src-cli/{rest:.*}").Methods("GET").Name(SrcCli)
 	base.Path("/insights/export/{id}").Methods("GET").Name(CodeInsightsDataExport)
+	base.Path("/executors/{queueName}/jobs/{id:[0-9]+}/logs/stream").Methods("GET").Name(ExecutorLogStream)
 
 	// repo contains routes that are NOT specific to a revision. In these routes, the URL may not contain a revspec after the repo (that is, no "github.com/foo/bar@myrevspec").
 	repoPath := `/repos/` + routevar.Repo
@@ -112,6 +122,7 @@ func NewInternal(base *mux.Router) *mux.Router {
 	base.Path("/external-services/configs").Methods("POST").Name(ExternalServiceConfigs)
 	base.Path("/repos/index").Methods("POST").Name(ReposIndex)
 	base.Path("/configuration").Methods("POST").Name(Configuration)
+	base.Path("/ranks/{RepoName:.*}/documents/explain").Methods("GET").Name(DocumentRankExplain)
 	base.Path("/ranks/{RepoName:.*}/documents").Methods("GET").Name(DocumentRanks)
 	base.Path("/ranks/{RepoName:.*}").Methods("GET").Name(RepoRank)
 	base.Path("/search/configuration").Methods("GET", "POST").Name(SearchConfiguration)
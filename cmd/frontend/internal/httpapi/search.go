@@ -301,6 +301,31 @@ func (h *searchIndexerServer) serveDocumentRanks(w http.ResponseWriter, r *http.
 	return serveRank(h.Ranking.GetDocumentRanks, w, r)
 }
 
+// serveDocumentRankExplain describes how the rank vector of the document given by the path
+// query parameter was computed. This is intended for debugging document ranks.
+func (h *searchIndexerServer) serveDocumentRankExplain(w http.ResponseWriter, r *http.Request) error {
+	path := r.URL.Query().Get("path")
+	if path == "" {
+		http.Error(w, "missing path query parameter", http.StatusBadRequest)
+		return nil
+	}
+
+	explanation, err := h.Ranking.ExplainDocumentRank(r.Context(), api.RepoName(mux.Vars(r)["RepoName"]), path)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return nil
+		}
+		return err
+	}
+	if explanation == nil {
+		http.Error(w, fmt.Sprintf("no rank for path %q", path), http.StatusNotFound)
+		return nil
+	}
+
+	return json.NewEncoder(w).Encode(explanation)
+}
+
 func serveRank[T []float64 | map[string][]float64](
 	f func(ctx context.Context, name api.RepoName) (r T, err error),
 	w http.ResponseWriter,
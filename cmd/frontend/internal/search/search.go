@@ -414,6 +414,11 @@ func fromContentMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Sear
 		contentEvent.Debug = *fm.Debug
 	}
 
+	if fm.History != nil {
+		contentEvent.IntroducedCommit = string(fm.History.Introduced)
+		contentEvent.RemovedCommit = string(fm.History.Removed)
+	}
+
 	return contentEvent
 }
 
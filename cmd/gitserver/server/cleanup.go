@@ -238,6 +238,11 @@ func (s *Server) cleanupRepos(ctx context.Context, gitServerAddrs gitserver.GitS
 			wrongShardRepoSize += size
 
 			if knownGitServerShard && wrongShardReposDeleteLimit > 0 && wrongShardReposDeleted < int64(wrongShardReposDeleteLimit) {
+				// The new owner may still be copying the repo from us.
+				if s.shardCopyStillNeeded(bCtx, name) {
+					return false, nil
+				}
+
 				logger.Info(
 					"removing repo cloned on the wrong shard",
 					log.String("dir", string(dir)),
@@ -249,6 +254,12 @@ func (s *Server) cleanupRepos(ctx context.Context, gitServerAddrs gitserver.GitS
 					return false, err
 				}
 				wrongShardReposDeleted++
+
+				if rebalancePeerCopy {
+					if err := s.DB.GitserverRepos().SetShardMigrationState(bCtx, name, types.ShardMigrationStateNone, ""); err != nil {
+						logger.Warn("failed to clear shard migration state", log.String("repo", string(name)), log.Error(err))
+					}
+				}
 			}
 		}
 		return false, nil
@@ -583,6 +594,15 @@ func checkRepoDirCorrupt(dir GitDir) (bool, string, error) {
 		return true, "non-bare", nil
 	}
 
+	// Objects missing from a partial clone are only expected if git knows
+	// where to fetch them from. Without the promisor remote every read of a
+	// missing object fails, so we treat such a repository as corrupt.
+	if isPartialClone(dir) {
+		if promisor, err := gitConfigGet(dir, "remote.origin.promisor"); err == nil && promisor != "true" {
+			return true, "missing-promisor-remote", nil
+		}
+	}
+
 	return false, "", nil
 }
 
@@ -1278,13 +1298,17 @@ func pruneIfNeeded(dir GitDir, limit int) (err error) {
 
 func needsMaintenance(dir GitDir) (bool, string, error) {
 	// Bitmaps store reachability information about the set of objects in a
-	// packfile which speeds up clone and fetch operations.
-	hasBm, err := hasBitmap(dir)
-	if err != nil {
-		return false, "", err
-	}
-	if !hasBm {
-		return true, "bitmap", nil
+	// packfile which speeds up clone and fetch operations. Git does not write
+	// bitmaps for partial clones because not all reachable objects are
+	// present, so we don't ask for one.
+	if !isPartialClone(dir) {
+		hasBm, err := hasBitmap(dir)
+		if err != nil {
+			return false, "", err
+		}
+		if !hasBm {
+			return true, "bitmap", nil
+		}
 	}
 
 	// The commit-graph file is a supplemental data structure that accelerates
@@ -211,11 +211,13 @@ func TestCleanupWrongShard(t *testing.T) {
 			t.Fatal(err)
 		}
 
+		db := database.NewMockDB()
+		db.GitserverReposFunc.SetDefaultReturn(database.NewMockGitserverRepoStore())
 		s := &Server{
 			ReposDir:       root,
 			Logger:         logtest.Scoped(t),
 			ObservationCtx: observation.TestContextTB(t),
-			DB:             database.NewMockDB(),
+			DB:             db,
 		}
 		s.testSetup(t)
 		s.Hostname = "gitserver-0"
@@ -228,6 +230,54 @@ func TestCleanupWrongShard(t *testing.T) {
 			t.Error("expected repoD assigned to different shard to be removed")
 		}
 	})
+	t.Run("shardMigrationInProgress", func(t *testing.T) {
+		root := t.TempDir()
+		// should be allocated to shard gitserver-1
+		testRepoD := "testrepo-D"
+
+		repoD := path.Join(root, testRepoD, ".git")
+		cmdD := exec.Command("git", "--bare", "init", repoD)
+		if err := cmdD.Run(); err != nil {
+			t.Fatal(err)
+		}
+
+		gitserverRepos := database.NewMockGitserverRepoStore()
+		gitserverRepos.GetByNameFunc.SetDefaultReturn(&types.GitserverRepo{
+			ShardID:              "gitserver-1",
+			ShardMigrationState:  types.ShardMigrationStateCopying,
+			ShardMigrationSource: "gitserver-0",
+		}, nil)
+		db := database.NewMockDB()
+		db.GitserverReposFunc.SetDefaultReturn(gitserverRepos)
+		s := &Server{
+			ReposDir:       root,
+			Logger:         logtest.Scoped(t),
+			ObservationCtx: observation.TestContextTB(t),
+			DB:             db,
+		}
+		s.testSetup(t)
+		s.Hostname = "gitserver-0"
+		s.cleanupRepos(context.Background(), gitserver.GitServerAddresses{Addresses: []string{"gitserver-0.cluster.local:3178", "gitserver-1.cluster.local:3178"}})
+
+		if _, err := os.Stat(repoD); err != nil {
+			t.Error("expected repoD still being copied by its new shard not to be removed")
+		}
+
+		// Once the new owner confirms the copy we can remove ours.
+		gitserverRepos.GetByNameFunc.SetDefaultReturn(&types.GitserverRepo{
+			ShardID:              "gitserver-1",
+			ShardMigrationState:  types.ShardMigrationStateCopied,
+			ShardMigrationSource: "gitserver-0",
+		}, nil)
+		s.cleanupRepos(context.Background(), gitserver.GitServerAddresses{Addresses: []string{"gitserver-0.cluster.local:3178", "gitserver-1.cluster.local:3178"}})
+
+		if _, err := os.Stat(repoD); !os.IsNotExist(err) {
+			t.Error("expected repoD copied by its new shard to be removed")
+		}
+		if calls := gitserverRepos.SetShardMigrationStateFunc.History(); len(calls) != 1 || calls[0].Arg2 != types.ShardMigrationStateNone {
+			t.Errorf("expected shard migration state to be cleared, got %v", calls)
+		}
+	})
 	t.Run("cleanupDisabled", func(t *testing.T) {
 		root := t.TempDir()
 		// should be allocated to shard gitserver-1
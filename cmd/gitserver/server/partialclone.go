@@ -0,0 +1,166 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/grafana/regexp"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type partialCloneRule struct {
+	pattern *regexp.Regexp
+	filter  string
+}
+
+var partialCloneRules = conf.Cached(func() []partialCloneRule {
+	return buildPartialCloneRules(conf.ExperimentalFeatures().GitPartialClone)
+})
+
+func buildPartialCloneRules(c []*schema.PartialCloneRule) []partialCloneRule {
+	rules := make([]partialCloneRule, 0, len(c))
+	for _, rule := range c {
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			log.Scoped("partialclone", "").Warn("error compiling gitPartialClone pattern", log.String("pattern", rule.Pattern), log.Error(err))
+			continue
+		}
+		rules = append(rules, partialCloneRule{pattern: re, filter: rule.Filter})
+	}
+	return rules
+}
+
+// PartialCloneFilter returns the git object filter (e.g. "blob:none") the
+// repository should be cloned with, or an empty string if the repository
+// should be cloned in full. The first matching rule of the gitPartialClone
+// experimental feature wins.
+func PartialCloneFilter(repo api.RepoName) string {
+	for _, rule := range partialCloneRules() {
+		if rule.pattern.MatchString(string(repo)) {
+			return rule.filter
+		}
+	}
+	return ""
+}
+
+// isPartialClone returns true if the repository was cloned with an object
+// filter. Objects fetched from a promisor remote are stored in packs marked by
+// a .promisor file. Git keeps these packs separate when repacking, so they are
+// present for as long as the repository is a partial clone.
+func isPartialClone(dir GitDir) bool {
+	promisorPacks, err := filepath.Glob(dir.Path("objects", "pack", "*.promisor"))
+	return err == nil && len(promisorPacks) > 0
+}
+
+// redactedRemoteURL returns remoteURL without the credentials it may contain.
+// The username of SSH URLs is kept, since it selects the account of the key
+// rather than authenticating the request.
+func redactedRemoteURL(remoteURL *vcs.URL) *vcs.URL {
+	u := *remoteURL
+	if u.User != nil && u.IsSSH() {
+		u.User = url.User(u.User.Username())
+	} else {
+		u.User = nil
+	}
+	return &u
+}
+
+// configurePromisorRemote configures the remote git lazily fetches the objects
+// missing from the partial clone in dir from. We don't store credentials on
+// disk, so the remote URL is stored without them and remoteCredentialEnv
+// passes them to each git command instead.
+func configurePromisorRemote(dir GitDir, remoteURL *vcs.URL, filter string) error {
+	config := [][2]string{
+		{"remote.origin.url", redactedRemoteURL(remoteURL).String()},
+		{"remote.origin.promisor", "true"},
+	}
+	if filter != "" {
+		config = append(config,
+			[2]string{"remote.origin.partialclonefilter", filter},
+			[2]string{"core.repositoryformatversion", "1"},
+			[2]string{"extensions.partialclone", "origin"},
+		)
+	}
+	for _, kv := range config {
+		if err := gitConfigSet(dir, kv[0], kv[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// remoteCredentialEnv returns env with the git configuration making git use
+// remoteURL, including its credentials, in place of the remote URL stored by
+// configurePromisorRemote. The configuration is passed in the environment, as
+// command arguments are visible to other processes and recorded with commands.
+func remoteCredentialEnv(env []string, remoteURL *vcs.URL) []string {
+	redacted := redactedRemoteURL(remoteURL).String()
+	credentialed := remoteURL.String()
+	if redacted == credentialed {
+		return env
+	}
+	return appendGitConfigEnv(env, "url."+credentialed+".insteadOf", redacted)
+}
+
+// appendGitConfigEnv returns env with the environment variables setting the
+// given git configuration, in addition to the configuration env already sets.
+func appendGitConfigEnv(env []string, key, value string) []string {
+	count := 0
+	for _, kv := range env {
+		if strings.HasPrefix(kv, "GIT_CONFIG_COUNT=") {
+			// Commands use the last value of an environment variable set twice.
+			count, _ = strconv.Atoi(strings.TrimPrefix(kv, "GIT_CONFIG_COUNT="))
+		}
+	}
+	return append(env,
+		fmt.Sprintf("GIT_CONFIG_KEY_%d=%s", count, key),
+		fmt.Sprintf("GIT_CONFIG_VALUE_%d=%s", count, value),
+		fmt.Sprintf("GIT_CONFIG_COUNT=%d", count+1),
+	)
+}
+
+// promisorRemoteURL returns the remote URL of the partial clone of repo.
+// Commands reading from partial clones run far more often than the remote URL
+// changes, so the URL is remembered when the repository is cloned or fetched
+// rather than looked up for every command.
+func (s *Server) promisorRemoteURL(ctx context.Context, repo api.RepoName) (*vcs.URL, error) {
+	if remoteURL, ok := s.promisorRemoteURLs.Load(repo); ok {
+		return remoteURL.(*vcs.URL), nil
+	}
+
+	remoteURL, err := s.getRemoteURL(actor.WithInternalActor(ctx), repo)
+	if err != nil {
+		return nil, err
+	}
+	s.promisorRemoteURLs.Store(repo, remoteURL)
+	return remoteURL, nil
+}
+
+// configureLazyFetch prepares cmd to fetch objects missing from the partial
+// clone of repo on demand from the remote configured by
+// configurePromisorRemote.
+func (s *Server) configureLazyFetch(ctx context.Context, repo api.RepoName, cmd *exec.Cmd) error {
+	remoteURL, err := s.promisorRemoteURL(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	configureRemoteGitCommand(cmd, tlsExternal())
+	cmd.Env = remoteCredentialEnv(cmd.Env, remoteURL)
+	return nil
+}
@@ -65,6 +65,7 @@ func (s *Server) handleCreateCommitFromPatch(w http.ResponseWriter, r *http.Requ
 			CommitInfo:   req.CommitInfo,
 			Push:         req.Push,
 			GitApplyArgs: req.GitApplyArgs,
+			DryRun:       req.DryRun,
 		}
 		status, resp = s.createCommitFromPatch(r.Context(), binaryReq)
 	}
@@ -226,6 +227,10 @@ func (s *Server) createCommitFromPatch(ctx context.Context, req protocol.CreateC
 		return http.StatusBadRequest, resp
 	}
 
+	if req.DryRun {
+		return http.StatusOK, resp
+	}
+
 	message := req.CommitInfo.Message
 	if message == "" {
 		message = "<Sourcegraph> Creating commit from patch"
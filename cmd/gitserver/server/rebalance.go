@@ -0,0 +1,241 @@
+package server
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/sync/semaphore"
+	"golang.org/x/time/rate"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+var (
+	// Controls whether a repo that moves to this shard after the set of gitserver
+	// shards changed is copied from its previous owner instead of being re-cloned
+	// from the code host.
+	rebalancePeerCopy, _ = strconv.ParseBool(env.Get("SRC_GITSERVER_REBALANCE_PEER_COPY", "true", "copy repos from their previous gitserver shard instead of the code host when the set of shards changes"))
+
+	// The maximum number of repos copied from other gitserver shards at the same time.
+	rebalanceMaxConcurrentCopies, _ = strconv.Atoi(env.Get("SRC_GITSERVER_REBALANCE_MAX_CONCURRENT_COPIES", "2", "the maximum number of repos copied from other gitserver shards at the same time"))
+
+	// The maximum number of repos copied from other gitserver shards per minute.
+	rebalanceCopiesPerMinute, _ = strconv.Atoi(env.Get("SRC_GITSERVER_REBALANCE_COPIES_PER_MINUTE", "30", "the maximum number of repos copied from other gitserver shards per minute"))
+)
+
+var (
+	rebalanceCopiesQueued = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "src_gitserver_rebalance_copies_queued",
+		Help: "The number of repos waiting to be copied from their previous gitserver shard",
+	})
+	rebalanceCopiesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_gitserver_rebalance_copies_total",
+		Help: "The number of repos copied from their previous gitserver shard, by outcome",
+	}, []string{"success"})
+	rebalanceCopyDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "src_gitserver_rebalance_copy_duration_seconds",
+		Help:    "Time taken to copy a repo from its previous gitserver shard",
+		Buckets: []float64{1, 10, 60, 300, 900, 3600, 7200},
+	})
+)
+
+// rebalancer copies repos which were cloned on another gitserver shard before
+// the set of shards changed. Copies are fetched peer-to-peer over the /git/
+// endpoint of the previous owner, which keeps the load off the code host.
+//
+// The progress of each copy is recorded in the gitserver_repos table. The
+// previous owner only removes its copy once the new owner has marked the copy
+// as done (see cleanupRepos).
+type rebalancer struct {
+	s      *Server
+	logger log.Logger
+
+	sem     *semaphore.Weighted
+	limiter *ratelimit.InstrumentedLimiter
+
+	mu     sync.Mutex
+	queued map[api.RepoName]string // repo -> source shard
+}
+
+func newRebalancer(s *Server) *rebalancer {
+	concurrency := rebalanceMaxConcurrentCopies
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	perMinute := rebalanceCopiesPerMinute
+	if perMinute <= 0 {
+		perMinute = 1
+	}
+
+	return &rebalancer{
+		s:       s,
+		logger:  s.Logger.Scoped("rebalancer", "copies repos from their previous gitserver shard"),
+		sem:     semaphore.NewWeighted(int64(concurrency)),
+		limiter: ratelimit.NewInstrumentedLimiter("RebalanceCopies", rate.NewLimiter(rate.Every(time.Minute/time.Duration(perMinute)), 1)),
+		queued:  make(map[api.RepoName]string),
+	}
+}
+
+// sourceShard returns the shard a repo assigned to this gitserver should be
+// copied from, or false if the repo should be cloned from the code host as
+// usual.
+func (r *rebalancer) sourceShard(repo *types.GitserverRepo, hostname string) (string, bool) {
+	if repo.ShardMigrationState.InProgress() && repo.ShardMigrationSource != "" {
+		// We restarted while copying the repo, pick up where we left off.
+		return repo.ShardMigrationSource, true
+	}
+	if repo.ShardID == "" || repo.ShardID == hostname || repo.CloneStatus != types.CloneStatusCloned {
+		return "", false
+	}
+	return repo.ShardID, true
+}
+
+// maybeEnqueue queues a copy of the repo from its previous shard if the repo
+// was cloned on another gitserver before it was assigned to this one. It
+// returns true if a copy was queued.
+func (r *rebalancer) maybeEnqueue(ctx context.Context, repo types.RepoGitserverStatus, addrs []string) bool {
+	if r == nil || !rebalancePeerCopy {
+		return false
+	}
+
+	sourceShard, ok := r.sourceShard(repo.GitserverRepo, r.s.Hostname)
+	if !ok {
+		return false
+	}
+	sourceAddr, ok := addrForShard(sourceShard, addrs)
+	if !ok {
+		// The previous owner is no longer part of the cluster, so there is
+		// nothing to copy from. The repo will be cloned from the code host.
+		if repo.ShardMigrationState.InProgress() {
+			r.setState(ctx, repo.Name, types.ShardMigrationStateFailed, sourceShard)
+		}
+		return false
+	}
+
+	r.mu.Lock()
+	if _, ok := r.queued[repo.Name]; ok {
+		r.mu.Unlock()
+		return true
+	}
+	r.queued[repo.Name] = sourceShard
+	rebalanceCopiesQueued.Inc()
+	r.mu.Unlock()
+
+	r.setState(ctx, repo.Name, types.ShardMigrationStatePending, sourceShard)
+
+	go r.copy(repo.Name, sourceShard, sourceAddr)
+	return true
+}
+
+// queuedFrom returns the shard the repo is waiting to be copied from.
+func (r *rebalancer) queuedFrom(repo api.RepoName) (string, bool) {
+	if r == nil {
+		return "", false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	shard, ok := r.queued[repo]
+	return shard, ok
+}
+
+func (r *rebalancer) dequeue(repo api.RepoName) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.queued[repo]; ok {
+		delete(r.queued, repo)
+		rebalanceCopiesQueued.Dec()
+	}
+}
+
+// copy fetches the repo from sourceAddr, respecting the copy concurrency and
+// rate limits. If the copy fails the repo is cloned from the code host instead.
+func (r *rebalancer) copy(repo api.RepoName, sourceShard, sourceAddr string) {
+	defer r.dequeue(repo)
+
+	logger := r.logger.With(log.String("repo", string(repo)), log.String("source-shard", sourceShard))
+
+	ctx, cancel := r.s.serverContext()
+	defer cancel()
+
+	if err := r.sem.Acquire(ctx, 1); err != nil {
+		return
+	}
+	defer r.sem.Release(1)
+
+	if err := r.limiter.Wait(ctx); err != nil {
+		return
+	}
+
+	r.setState(ctx, repo, types.ShardMigrationStateCopying, sourceShard)
+
+	start := time.Now()
+	_, err := r.s.cloneRepo(ctx, repo, &cloneOptions{Block: true, CloneFromShard: "http://" + sourceAddr})
+	rebalanceCopyDuration.Observe(time.Since(start).Seconds())
+
+	if err == nil && repoCloned(r.s.dir(repo)) {
+		logger.Info("copied repo from previous shard", log.Duration("duration", time.Since(start)))
+		rebalanceCopiesTotal.WithLabelValues("true").Inc()
+		r.setState(ctx, repo, types.ShardMigrationStateCopied, sourceShard)
+		return
+	}
+
+	rebalanceCopiesTotal.WithLabelValues("false").Inc()
+	// Either the copy failed or someone else is already cloning the repo from the
+	// code host. Either way we no longer need the previous owner's copy.
+	r.setState(ctx, repo, types.ShardMigrationStateFailed, sourceShard)
+	if err != nil {
+		logger.Warn("failed to copy repo from previous shard, cloning from code host instead", log.Error(err))
+		if _, err := r.s.cloneRepo(ctx, repo, nil); err != nil {
+			logger.Error("failed to clone repo from code host", log.Error(err))
+		}
+	}
+}
+
+func (r *rebalancer) setState(ctx context.Context, repo api.RepoName, state types.ShardMigrationState, sourceShard string) {
+	if err := r.s.DB.GitserverRepos().SetShardMigrationState(ctx, repo, state, sourceShard); err != nil {
+		r.logger.Warn("failed to set shard migration state", log.String("repo", string(repo)), log.String("state", string(state)), log.Error(err))
+	}
+}
+
+// shardCopyStillNeeded returns true if another gitserver still needs our copy
+// of a repo that is no longer assigned to this shard. We keep the copy until the
+// new owner has recorded that it finished copying it, or that it gave up.
+func (s *Server) shardCopyStillNeeded(ctx context.Context, name api.RepoName) bool {
+	if !rebalancePeerCopy {
+		return false
+	}
+
+	repo, err := s.DB.GitserverRepos().GetByName(ctx, name)
+	if err != nil || repo == nil {
+		// Without a record there is nobody waiting for our copy.
+		return false
+	}
+	// The new owner has not synced its state yet and may still want to copy the
+	// repo from us.
+	if repo.ShardID == s.Hostname && repo.ShardMigrationState == types.ShardMigrationStateNone {
+		return true
+	}
+	return repo.ShardMigrationState.InProgress()
+}
+
+// addrForShard returns the gitserver address belonging to the shard with the
+// given hostname.
+func addrForShard(shardID string, addrs []string) (string, bool) {
+	for _, addr := range addrs {
+		if addr == shardID || strings.HasPrefix(addr, shardID+".") || strings.HasPrefix(addr, shardID+":") {
+			return addr, true
+		}
+	}
+	return "", false
+}
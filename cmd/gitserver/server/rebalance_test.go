@@ -0,0 +1,90 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestAddrForShard(t *testing.T) {
+	addrs := []string{"gitserver-1.cluster.local:3178", "gitserver-10.cluster.local:3178", "gitserver-2:3178"}
+
+	for _, tc := range []struct {
+		shardID string
+		want    string
+		found   bool
+	}{
+		{shardID: "gitserver-1", want: "gitserver-1.cluster.local:3178", found: true},
+		{shardID: "gitserver-10", want: "gitserver-10.cluster.local:3178", found: true},
+		{shardID: "gitserver-2", want: "gitserver-2:3178", found: true},
+		{shardID: "gitserver-3", found: false},
+	} {
+		t.Run(tc.shardID, func(t *testing.T) {
+			got, found := addrForShard(tc.shardID, addrs)
+			if found != tc.found {
+				t.Fatalf("unexpected found. want=%v have=%v", tc.found, found)
+			}
+			if got != tc.want {
+				t.Errorf("unexpected addr. want=%q have=%q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestRebalancerSourceShard(t *testing.T) {
+	r := &rebalancer{}
+
+	for _, tc := range []struct {
+		name  string
+		repo  types.GitserverRepo
+		want  string
+		found bool
+	}{
+		{
+			name: "cloned on previous shard",
+			repo: types.GitserverRepo{ShardID: "gitserver-0", CloneStatus: types.CloneStatusCloned},
+			want: "gitserver-0", found: true,
+		},
+		{
+			name: "never cloned",
+			repo: types.GitserverRepo{ShardID: "gitserver-0", CloneStatus: types.CloneStatusNotCloned},
+		},
+		{
+			name: "no shard yet",
+			repo: types.GitserverRepo{CloneStatus: types.CloneStatusCloned},
+		},
+		{
+			name: "already ours",
+			repo: types.GitserverRepo{ShardID: "gitserver-1", CloneStatus: types.CloneStatusCloned},
+		},
+		{
+			name: "resume interrupted copy",
+			repo: types.GitserverRepo{
+				ShardID:              "gitserver-1",
+				CloneStatus:          types.CloneStatusNotCloned,
+				ShardMigrationState:  types.ShardMigrationStateCopying,
+				ShardMigrationSource: "gitserver-0",
+			},
+			want: "gitserver-0", found: true,
+		},
+		{
+			name: "copy already failed",
+			repo: types.GitserverRepo{
+				ShardID:              "gitserver-1",
+				CloneStatus:          types.CloneStatusNotCloned,
+				ShardMigrationState:  types.ShardMigrationStateFailed,
+				ShardMigrationSource: "gitserver-0",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, found := r.sourceShard(&tc.repo, "gitserver-1")
+			if found != tc.found {
+				t.Fatalf("unexpected found. want=%v have=%v", tc.found, found)
+			}
+			if got != tc.want {
+				t.Errorf("unexpected shard. want=%q have=%q", tc.want, got)
+			}
+		})
+	}
+}
@@ -33,11 +33,19 @@ func (s *Server) handleReposStats(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) repoCloneProgress(repo api.RepoName) *protocol.RepoCloneProgress {
-	dir := s.dir(repo)
+	// Virtual repositories are cloned along with their parent.
+	dir := s.contentDir(repo)
 	resp := protocol.RepoCloneProgress{
 		Cloned: repoCloned(dir),
 	}
 	resp.CloneProgress, resp.CloneInProgress = s.locker.Status(dir)
+	if shard, ok := s.rebalancer.queuedFrom(repo); ok {
+		resp.MigratingFromShard = shard
+		if !resp.CloneInProgress && !resp.Cloned {
+			resp.CloneInProgress = true
+			resp.CloneProgress = fmt.Sprintf("queued for copy from gitserver %s", shard)
+		}
+	}
 	if isAlwaysCloningTest(repo) {
 		resp.CloneInProgress = true
 		resp.CloneProgress = "This will never finish cloning"
@@ -83,7 +91,9 @@ func (s *Server) handleRepoDelete(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) deleteRepo(ctx context.Context, repo api.RepoName) error {
 	// The repo may be deleted in the database, in this case we need to get the
-	// original name in order to find it on disk
+	// original name in order to find it on disk. Virtual repositories don't have
+	// a directory of their own, so deleting them leaves the clone of their parent
+	// untouched.
 	err := s.removeRepoDirectory(s.dir(api.UndeletedRepoName(repo)), true)
 	if err != nil {
 		return errors.Wrap(err, "removing repo directory")
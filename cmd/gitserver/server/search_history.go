@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/grafana/regexp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/log"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/casetransform"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var historySearchDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "src_gitserver_search_history_duration_seconds",
+	Help:    "gitserver.SearchHistory duration in seconds.",
+	Buckets: []float64{0.1, 0.5, 1, 2, 5, 10, 30, 60, 120},
+}, []string{"error"})
+
+// handleSearchHistory streams the lines of files matching a
+// protocol.HistorySearchRequest across a revision range.
+func (s *Server) handleSearchHistory(w http.ResponseWriter, r *http.Request) {
+	logger := s.Logger.Scoped("handleSearchHistory", "http handler for history search")
+	tr, ctx := trace.New(r.Context(), "searchHistory", "")
+	defer tr.Finish()
+
+	var args protocol.HistorySearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	tr.SetAttributes(
+		attribute.String("repo", string(args.Repo)),
+		attribute.String("range", args.Range),
+		attribute.String("pattern", args.Pattern),
+		attribute.Int("limit", args.Limit),
+	)
+
+	start := time.Now()
+	eventWriter, err := streamhttp.NewWriter(w)
+	if err != nil {
+		tr.SetError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	matchesBuf := streamhttp.NewJSONArrayBuf(8*1024, func(data []byte) error {
+		return eventWriter.EventBytes("matches", data)
+	})
+
+	limitHit, searchErr := s.searchHistory(ctx, &args, matchesBuf)
+	if writeErr := eventWriter.Event("done", protocol.NewSearchEventDone(limitHit, searchErr)); writeErr != nil {
+		if !errors.Is(writeErr, syscall.EPIPE) {
+			logger.Error("failed to send done event", log.Error(writeErr))
+		}
+	}
+	tr.AddEvent("done", attribute.Bool("limit_hit", limitHit))
+	tr.SetError(searchErr)
+	historySearchDuration.
+		WithLabelValues(strconv.FormatBool(searchErr != nil)).
+		Observe(time.Since(start).Seconds())
+}
+
+func (s *Server) searchHistory(ctx context.Context, args *protocol.HistorySearchRequest, matchesBuf *streamhttp.JSONArrayBuf) (limitHit bool, err error) {
+	args.Repo = protocol.NormalizeRepo(args.Repo)
+	if args.Limit == 0 {
+		args.Limit = math.MaxInt32
+	}
+
+	// Virtual repositories are searched in the clone of their parent, restricted to
+	// their paths.
+	repo, virtual := resolveVirtualRepo(args.Repo)
+	dir := s.dir(repo)
+	if !repoCloned(dir) {
+		cloneProgress, cloneInProgress := s.locker.Status(dir)
+		return false, &gitdomain.RepoNotExistError{
+			Repo:            args.Repo,
+			CloneInProgress: cloneInProgress,
+			CloneProgress:   cloneProgress,
+		}
+	}
+
+	searcher := &search.HistorySearcher{
+		RepoDir: dir.Path(),
+		Range:   args.Range,
+		Limit:   args.Limit,
+	}
+	if searcher.Pattern, err = casetransform.CompileRegexp(args.Pattern, args.IgnoreCase); err != nil {
+		return false, err
+	}
+	for _, p := range args.IncludePatterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return false, err
+		}
+		searcher.IncludePaths = append(searcher.IncludePaths, re)
+	}
+	if virtual != nil {
+		searcher.IncludePaths = append(searcher.IncludePaths, virtualRepoPathPattern(virtual))
+	}
+	if args.ExcludePattern != "" {
+		if searcher.ExcludePath, err = regexp.Compile(args.ExcludePattern); err != nil {
+			return false, err
+		}
+	}
+
+	if from, to, ok := strings.Cut(args.Range, ".."); ok {
+		for _, rev := range []string{from, to} {
+			if rev != "" {
+				_ = s.ensureRevision(ctx, repo, rev, dir)
+			}
+		}
+	}
+
+	defer matchesBuf.Flush()
+
+	return searcher.Search(ctx, func(match *protocol.HistoryMatch) {
+		_ = matchesBuf.Append(match) // EOF only
+	})
+}
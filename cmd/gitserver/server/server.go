@@ -359,10 +359,18 @@ type Server struct {
 	// dereferencs.
 	operations *operations
 
+	// rebalancer copies repos from their previous gitserver shard after the set
+	// of shards changed.
+	rebalancer *rebalancer
+
 	// recordingCommandFactory is a factory that creates recordable commands by wrapping os/exec.Commands.
 	// The factory creates recordable commands with a set predicate, which is used to determine whether a
 	// particular command should be recorded or not.
 	recordingCommandFactory *wrexec.RecordingCommandFactory
+
+	// promisorRemoteURLs maps the names of partially cloned repositories to
+	// their remote URL. See promisorRemoteURL.
+	promisorRemoteURLs sync.Map
 }
 
 type locks struct {
@@ -496,6 +504,7 @@ func (s *Server) Handler() http.Handler {
 	s.ctx, s.cancel = context.WithCancel(context.Background())
 	s.locker = &RepositoryLocker{}
 	s.repoUpdateLocks = make(map[api.RepoName]*locks)
+	s.rebalancer = newRebalancer(s)
 
 	s.recordingCommandFactory = wrexec.NewRecordingCommandFactory(nil, 0)
 	conf.Watch(func() {
@@ -559,6 +568,7 @@ func (s *Server) Handler() http.Handler {
 		s.handleExec,
 	)))
 	mux.HandleFunc("/search", trace.WithRouteName("search", s.handleSearch))
+	mux.HandleFunc("/search-history", trace.WithRouteName("search-history", s.handleSearchHistory))
 	mux.HandleFunc("/batch-log", trace.WithRouteName("batch-log", s.handleBatchLog))
 	mux.HandleFunc("/p4-exec", trace.WithRouteName("p4-exec", accesslog.HTTPMiddleware(
 		s.Logger.Scoped("p4-exec.accesslog", "p4-exec endpoint access log"),
@@ -897,10 +907,24 @@ func (s *Server) syncRepoState(gitServerAddrs gitserver.GitServerAddresses, batc
 			}
 			repoSyncStateCounter.WithLabelValues("this_shard").Inc()
 
-			dir := s.dir(repo.Name)
+			// Virtual repositories are cloned along with their parent.
+			_, virtual := resolveVirtualRepo(repo.Name)
+			dir := s.contentDir(repo.Name)
 			cloned := repoCloned(dir)
 			_, cloning := s.locker.Status(dir)
 
+			if !cloned && !cloning && virtual == nil {
+				// The repo may have been cloned on another shard before the set of
+				// shards changed, in which case we copy it from there rather than
+				// cloning it from the code host again.
+				s.rebalancer.maybeEnqueue(ctx, repo, addrs)
+			} else if cloned && repo.ShardMigrationState.InProgress() {
+				// We finished copying the repo but didn't get to record it.
+				if err := store.SetShardMigrationState(ctx, repo.Name, types.ShardMigrationStateCopied, repo.ShardMigrationSource); err != nil {
+					s.Logger.Warn("failed to set shard migration state", log.String("repo", string(repo.Name)), log.Error(err))
+				}
+			}
+
 			var shouldUpdate bool
 			if repo.ShardID != s.Hostname {
 				repo.ShardID = s.Hostname
@@ -1093,6 +1117,16 @@ func (s *Server) handleRepoUpdate(w http.ResponseWriter, r *http.Request) {
 	req.Repo = protocol.NormalizeRepo(req.Repo)
 	dir := s.dir(req.Repo)
 
+	if _, virtual := resolveVirtualRepo(req.Repo); virtual != nil {
+		// Virtual repositories share the clone of their parent, which is cloned and
+		// updated on its own, so we only report the status of that clone.
+		s.virtualRepoUpdateStatus(req.Repo, &resp)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
 	// despite the existence of a context on the request, we don't want to
 	// cancel the git commands partway through if the request terminates.
 	ctx, cancel1 := s.serverContext()
@@ -1165,6 +1199,15 @@ func (s *Server) handleRepoClone(w http.ResponseWriter, r *http.Request) {
 	var resp protocol.RepoCloneResponse
 	req.Repo = protocol.NormalizeRepo(req.Repo)
 
+	// Virtual repositories share the clone of their parent, so there is nothing
+	// to clone.
+	if _, virtual := resolveVirtualRepo(req.Repo); virtual != nil {
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
 	_, err := s.cloneRepo(context.Background(), req.Repo, &cloneOptions{Block: false})
 	if err != nil {
 		logger.Warn("error cloning repo", log.String("repo", string(req.Repo)), log.Error(err))
@@ -1325,7 +1368,10 @@ func (s *Server) search(ctx context.Context, args *protocol.SearchRequest, match
 		args.Limit = math.MaxInt32
 	}
 
-	dir := s.dir(args.Repo)
+	// Virtual repositories are searched in the clone of their parent, restricted to
+	// their paths.
+	repo, virtual := resolveVirtualRepo(args.Repo)
+	dir := s.dir(repo)
 	if !repoCloned(dir) {
 		if conf.Get().DisableAutoGitUpdates {
 			s.Logger.Debug("not cloning on demand as DisableAutoGitUpdates is set")
@@ -1343,7 +1389,7 @@ func (s *Server) search(ctx context.Context, args *protocol.SearchRequest, match
 			}
 		}
 
-		cloneProgress, err := s.cloneRepo(ctx, args.Repo, nil)
+		cloneProgress, err := s.cloneRepo(ctx, repo, nil)
 		if err != nil {
 			s.Logger.Debug("error starting repo clone", log.String("repo", string(args.Repo)), log.Error(err))
 			return false, &gitdomain.RepoNotExistError{
@@ -1362,9 +1408,9 @@ func (s *Server) search(ctx context.Context, args *protocol.SearchRequest, match
 	for _, rev := range args.Revisions {
 		// TODO add result to trace
 		if rev.RevSpec != "" {
-			_ = s.ensureRevision(ctx, args.Repo, rev.RevSpec, dir)
+			_ = s.ensureRevision(ctx, repo, rev.RevSpec, dir)
 		} else if rev.RefGlob != "" {
-			_ = s.ensureRevision(ctx, args.Repo, rev.RefGlob, dir)
+			_ = s.ensureRevision(ctx, repo, rev.RefGlob, dir)
 		}
 	}
 
@@ -1402,6 +1448,9 @@ func (s *Server) search(ctx context.Context, args *protocol.SearchRequest, match
 			IncludeDiff:          args.IncludeDiff,
 			IncludeModifiedFiles: args.IncludeModifiedFiles || hasDiffModifiesFile,
 		}
+		if virtual != nil {
+			searcher.Paths = virtualRepoPathspecs(virtual)
+		}
 
 		return searcher.Search(ctx, func(match *protocol.CommitMatch) {
 			select {
@@ -1490,7 +1539,8 @@ func (s *Server) handleBatchLog(w http.ResponseWriter, r *http.Request) {
 			}})
 		}()
 
-		dir := s.dir(repoCommit.Repo)
+		repo, virtual := resolveVirtualRepo(protocol.NormalizeRepo(repoCommit.Repo))
+		dir := s.dir(repo)
 		if !repoCloned(dir) {
 			return "", false, nil
 		}
@@ -1503,6 +1553,11 @@ func (s *Server) handleBatchLog(w http.ResponseWriter, r *http.Request) {
 			return "", true, errors.New("commit ID starting with - is not allowed")
 		}
 
+		if virtual != nil {
+			output, err := s.virtualRepoLogCommand(ctx, dir, virtual, format, commitId)
+			return output, true, err
+		}
+
 		cmd := s.recordingCommandFactory.Command(ctx, s.Logger, "git", "log", "-n", "1", "--name-only", format, commitId)
 		dir.Set(cmd.Unwrap())
 		cmd.Unwrap().Stdout = &buf
@@ -1778,7 +1833,20 @@ func (s *Server) exec(ctx context.Context, logger log.Logger, req *protocol.Exec
 		}()
 	}
 
-	if notFoundPayload, cloned := s.maybeStartClone(ctx, logger, req.Repo); !cloned {
+	// Virtual repositories are read from the clone of their parent, restricted to
+	// their paths.
+	repo, virtual := resolveVirtualRepo(req.Repo)
+	var virtualRevs []string
+	if virtual != nil {
+		var err error
+		if virtualRevs, err = checkVirtualRepoArgs(virtual, req.Args); err != nil {
+			status = "virtual-repo-blocked"
+			return execStatus{}, err
+		}
+		w = virtualRepoOutputFilter(virtual, req.Args, w)
+	}
+
+	if notFoundPayload, cloned := s.maybeStartClone(ctx, logger, repo); !cloned {
 		if notFoundPayload.CloneInProgress {
 			status = "clone-in-progress"
 		} else {
@@ -1788,11 +1856,16 @@ func (s *Server) exec(ctx context.Context, logger log.Logger, req *protocol.Exec
 		return execStatus{}, &NotFoundError{notFoundPayload}
 	}
 
-	dir := s.dir(req.Repo)
-	if s.ensureRevision(ctx, req.Repo, req.EnsureRevision, dir) {
+	dir := s.dir(repo)
+	if s.ensureRevision(ctx, repo, req.EnsureRevision, dir) {
 		ensureRevisionStatus = "fetched"
 	}
 
+	if err := s.checkVirtualRepoRevisions(ctx, repo, dir, virtualRevs); err != nil {
+		status = "virtual-repo-blocked"
+		return execStatus{}, err
+	}
+
 	// Special-case `git rev-parse HEAD` requests. These are invoked by search queries for every repo in scope.
 	// For searches over large repo sets (> 1k), this leads to too many child process execs, which can lead
 	// to a persistent failure mode where every exec takes > 10s, which is disastrous for gitserver performance.
@@ -1824,6 +1897,14 @@ func (s *Server) exec(ctx context.Context, logger log.Logger, req *protocol.Exec
 	cmd.Unwrap().Stderr = stderrW
 	cmd.Unwrap().Stdin = bytes.NewReader(req.Stdin)
 
+	// Commands reading file contents, such as show, archive or blame, need to
+	// fetch the blobs missing from a partial clone.
+	if isPartialClone(dir) {
+		if err := s.configureLazyFetch(ctx, repo, cmd.Unwrap()); err != nil {
+			logger.Warn("failed to configure lazy fetching for partial clone", log.Error(err))
+		}
+	}
+
 	exitStatus, execErr = runCommand(ctx, cmd)
 
 	status = strconv.Itoa(exitStatus)
@@ -1831,7 +1912,7 @@ func (s *Server) exec(ctx context.Context, logger log.Logger, req *protocol.Exec
 	stderrN = stderrW.n
 
 	stderr := stderrBuf.String()
-	s.logIfCorrupt(ctx, req.Repo, dir, stderr)
+	s.logIfCorrupt(ctx, repo, dir, stderr)
 
 	return execStatus{
 		Err:        execErr,
@@ -2159,6 +2240,12 @@ func (s *Server) cloneRepo(ctx context.Context, repo api.RepoName, opts *cloneOp
 		return "This will never finish cloning", nil
 	}
 
+	// Virtual repositories must never be cloned on their own, as that would clone
+	// the whole of their parent.
+	if _, virtual := resolveVirtualRepo(repo); virtual != nil {
+		return "", errors.Errorf("%s is a virtual repository and shares the clone of its parent", repo)
+	}
+
 	// We always want to store whether there was an error cloning the repo
 	defer func() {
 		// Use a different context in case we failed because the original context failed.
@@ -2375,6 +2462,9 @@ func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir GitDir, syn
 	if err := fileutil.RenameAndSync(tmpPath, dstPath); err != nil {
 		return err
 	}
+	if isPartialClone(dir) {
+		s.promisorRemoteURLs.Store(repo, remoteURL)
+	}
 
 	// Successfully updated, best-effort updating of db fetch state based on
 	// disk state.
@@ -2707,6 +2797,9 @@ func (s *Server) doBackgroundRepoUpdate(repo api.RepoName, revspec string) error
 	if err != nil {
 		return errors.Wrapf(err, "failed to fetch repo %q", repo)
 	}
+	if isPartialClone(dir) {
+		s.promisorRemoteURLs.Store(repo, remoteURL)
+	}
 
 	removeBadRefs(ctx, dir)
 
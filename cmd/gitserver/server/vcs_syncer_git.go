@@ -29,7 +29,11 @@ func (e *GitCommandError) Unwrap() error {
 }
 
 // GitRepoSyncer is a syncer for Git repositories.
-type GitRepoSyncer struct{}
+type GitRepoSyncer struct {
+	// PartialCloneFilter is the object filter used when cloning the repository,
+	// e.g. "blob:none". If empty, the repository is cloned in full.
+	PartialCloneFilter string
+}
 
 func (s *GitRepoSyncer) Type() string {
 	return "git"
@@ -74,14 +78,28 @@ func (s *GitRepoSyncer) CloneCommand(ctx context.Context, remoteURL *vcs.URL, tm
 		return nil, errors.Wrapf(&GitCommandError{Err: err}, "clone setup failed")
 	}
 
-	cmd, _ = s.fetchCommand(ctx, remoteURL)
+	if s.PartialCloneFilter != "" {
+		if err := configurePromisorRemote(GitDir(tmpPath), remoteURL, s.PartialCloneFilter); err != nil {
+			return nil, errors.Wrapf(err, "clone setup failed")
+		}
+	}
+
+	cmd, _ = s.fetchCommand(ctx, remoteURL, s.PartialCloneFilter != "")
 	cmd.Dir = tmpPath
 	return cmd, nil
 }
 
 // Fetch tries to fetch updates of a Git repository.
 func (s *GitRepoSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir GitDir, revspec string) error {
-	cmd, configRemoteOpts := s.fetchCommand(ctx, remoteURL)
+	partial := isPartialClone(dir)
+	if partial {
+		// The remote URL may have changed since the repository was cloned.
+		if err := configurePromisorRemote(dir, remoteURL, ""); err != nil {
+			return errors.Wrap(err, "failed to update promisor remote")
+		}
+	}
+
+	cmd, configRemoteOpts := s.fetchCommand(ctx, remoteURL, partial)
 	dir.Set(cmd)
 	if output, err := runWith(ctx, wrexec.Wrap(ctx, log.NoOp(), cmd), configRemoteOpts, nil); err != nil {
 		return errors.Wrapf(&GitCommandError{Err: err, Output: newURLRedactor(remoteURL).redact(string(output))}, "failed to update")
@@ -94,7 +112,10 @@ func (s *GitRepoSyncer) RemoteShowCommand(ctx context.Context, remoteURL *vcs.UR
 	return exec.CommandContext(ctx, "git", "remote", "show", remoteURL.String()), nil
 }
 
-func (s *GitRepoSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, configRemoteOpts bool) {
+// fetchCommand returns the command to fetch all refs from remoteURL. If
+// partial is true, the command fetches into a partial clone. Only the default
+// fetch command supports partial clones.
+func (s *GitRepoSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL, partial bool) (cmd *exec.Cmd, configRemoteOpts bool) {
 	configRemoteOpts = true
 	if customCmd := customFetchCmd(ctx, remoteURL); customCmd != nil {
 		cmd = customCmd
@@ -102,11 +123,22 @@ func (s *GitRepoSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL) (c
 	} else if useRefspecOverrides() {
 		cmd = refspecOverridesFetchCmd(ctx, remoteURL)
 	} else {
-		cmd = exec.CommandContext(ctx, "git", "fetch",
+		remote := remoteURL.String()
+		if partial {
+			// Git only records a filter for, and lazily fetches missing objects
+			// from, a named remote.
+			remote = "origin"
+		}
+		args := []string{"fetch",
 			// We already have janitor jobs that run git gc. We disable git gc here to avoid
 			// a possible corruption of repositories by competing gc processes.
 			"--no-auto-gc",
-			"--progress", "--prune", remoteURL.String(),
+			"--progress", "--prune"}
+		if partial && s.PartialCloneFilter != "" {
+			// Subsequent fetches reuse the filter git stored for the remote.
+			args = append(args, "--filter="+s.PartialCloneFilter)
+		}
+		args = append(args, remote,
 			// Normal git refs
 			"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*",
 			// GitHub pull requests
@@ -119,6 +151,12 @@ func (s *GitRepoSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL) (c
 			"+refs/changes/*:refs/changes/*",
 			// Possibly deprecated refs for sourcegraph zap experiment?
 			"+refs/sourcegraph/*:refs/sourcegraph/*")
+		cmd = exec.CommandContext(ctx, "git", args...)
+		if partial {
+			// The remote is stored without credentials by configurePromisorRemote,
+			// so we pass them in the environment.
+			cmd.Env = remoteCredentialEnv(os.Environ(), remoteURL)
+		}
 	}
 	return cmd, configRemoteOpts
 }
@@ -0,0 +1,401 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Virtual repositories (see types.VirtualRepo) do not have a clone of their
+// own. Requests reading from a virtual repository are served from the clone of
+// its parent and are restricted to the paths of the virtual repository here,
+// so that clients talking to gitserver directly can't read the rest of the
+// parent. Requests maintaining clones, such as clone, update and delete
+// requests, are never forwarded to the parent.
+
+// resolveVirtualRepo returns the name of the repository whose clone contains
+// the contents of repo, and the definition of repo if it is a virtual
+// repository.
+func resolveVirtualRepo(repo api.RepoName) (api.RepoName, *types.VirtualRepo) {
+	if v := conf.VirtualRepo(repo); v != nil {
+		return protocol.NormalizeRepo(v.Parent), v
+	}
+	return repo, nil
+}
+
+// contentDir returns the GIT_DIR containing the contents of name. Unlike dir,
+// it resolves virtual repositories to the GIT_DIR of their parent, so it must
+// only be used to read from name.
+func (s *Server) contentDir(name api.RepoName) GitDir {
+	parent, _ := resolveVirtualRepo(protocol.NormalizeRepo(name))
+	return s.dir(parent)
+}
+
+// virtualRepoPathspecs returns the literal pathspecs selecting the paths of v.
+func virtualRepoPathspecs(v *types.VirtualRepo) []string {
+	pathspecs := make([]string, 0, len(v.Paths))
+	for _, p := range v.Paths {
+		pathspecs = append(pathspecs, literalPathspecMagic+p)
+	}
+	return pathspecs
+}
+
+const literalPathspecMagic = ":(literal)"
+
+// virtualRepoPathPattern returns a pattern matching the paths included in v.
+func virtualRepoPathPattern(v *types.VirtualRepo) *regexp.Regexp {
+	quoted := make([]string, 0, len(v.Paths))
+	for _, p := range v.Paths {
+		quoted = append(quoted, regexp.QuoteMeta(p))
+	}
+	return regexp.MustCompile(`^(?:` + strings.Join(quoted, "|") + `)(?:/|$)`)
+}
+
+// errVirtualRepoCommand is returned for git commands which could read files
+// outside of the paths of a virtual repository.
+var errVirtualRepoCommand = errors.Wrap(ErrInvalidCommand, "command not supported on virtual repositories")
+
+// virtualRepoFileFlags are the flags which make log and show output the paths
+// or contents of the files changed by commits.
+var virtualRepoFileFlags = []string{
+	"-p", "-u", "-W", "--patch", "--unified", "--function-context", "--stat",
+	"--name-only", "--name-status", "--numstat", "--raw", "--follow", "-S", "-G", "-L",
+}
+
+// virtualRepoRevisionFlags are the flags which make commands list refs or
+// objects other than the commits given as arguments, or read revisions from
+// stdin.
+var virtualRepoRevisionFlags = []string{
+	"--all", "--branches", "--tags", "--remotes", "--glob", "--reflog", "--alternate-refs",
+	"--indexed-objects", "--objects", "--objects-edge", "--objects-edge-aggressive",
+	"--missing", "--filter-print-omitted", "--disambiguate", "--stdin",
+}
+
+// checkVirtualRepoArgs returns an error if the git command args could read
+// files outside of the paths of v. Otherwise, it returns the revisions in args
+// which must be checked with checkVirtualRepoRevisions before running the
+// command, as a revision naming a tree or blob of the parent would give access
+// to files outside of v.
+func checkVirtualRepoArgs(v *types.VirtualRepo, args []string) (revs []string, err error) {
+	if len(args) == 0 {
+		return nil, errVirtualRepoCommand
+	}
+	cmd, args := args[0], args[1:]
+
+	// Every pathspec must be included in the virtual repository. Pathspec magic
+	// other than literal pathspecs could select other paths, so it is rejected.
+	var pathspecs []string
+	for i, arg := range args {
+		if arg == "--" {
+			pathspecs = args[i+1:]
+			args = args[:i]
+			break
+		}
+	}
+	for _, pathspec := range pathspecs {
+		path := strings.TrimPrefix(pathspec, literalPathspecMagic)
+		if strings.HasPrefix(path, ":") || !v.Includes(path) {
+			return nil, errVirtualRepoCommand
+		}
+	}
+
+	switch cmd {
+	case "rev-parse", "rev-list":
+		// These commands output the object names of their arguments, which must
+		// therefore name commits. Trees and blobs, e.g. HEAD:README.md, could
+		// be read with other commands by their object name.
+		for _, arg := range args {
+			if hasVirtualRepoRevisionFlag(arg) {
+				return nil, errVirtualRepoCommand
+			}
+			if strings.HasPrefix(arg, "-") {
+				continue
+			}
+			if strings.Contains(arg, ":") {
+				return nil, errVirtualRepoCommand
+			}
+			revs = append(revs, arg)
+		}
+		return revs, nil
+
+	case "symbolic-ref", "for-each-ref", "show-ref", "merge-base", "branch", "tag", "shortlog":
+		// These commands only output refs and commits, which are shared with the parent.
+		return nil, nil
+
+	case "ls-tree", "ls-files":
+		// The output of these commands is filtered by virtualRepoOutputFilter.
+		return nil, nil
+
+	case "log", "show":
+		showsCommit := false
+		for _, arg := range args {
+			if arg == "--stdin" {
+				return nil, errVirtualRepoCommand
+			}
+			if strings.HasPrefix(arg, "-") {
+				continue
+			}
+			// Blobs may be read by name, e.g. git show HEAD:README.md.
+			if _, path, ok := strings.Cut(arg, ":"); !ok {
+				showsCommit = true
+				revs = append(revs, arg)
+			} else if !v.Includes(path) {
+				return nil, errVirtualRepoCommand
+			}
+		}
+		if len(pathspecs) > 0 || !hasVirtualRepoFileFlag(args, cmd == "show" && showsCommit) {
+			return revs, nil
+		}
+		return nil, errVirtualRepoCommand
+
+	case "diff", "blame", "archive":
+		if len(pathspecs) > 0 {
+			return nil, nil
+		}
+		return nil, errVirtualRepoCommand
+
+	case "lfs":
+		// git lfs smudge <path>
+		if len(args) == 2 && args[0] == "smudge" && v.Includes(args[1]) {
+			return nil, nil
+		}
+		return nil, errVirtualRepoCommand
+	}
+
+	// Other commands, such as cat-file, or the commands modifying the clone, are
+	// not supported on virtual repositories.
+	return nil, errVirtualRepoCommand
+}
+
+// hasVirtualRepoRevisionFlag returns true if arg is one of
+// virtualRepoRevisionFlags.
+func hasVirtualRepoRevisionFlag(arg string) bool {
+	flag := strings.Split(arg, "=")[0]
+	for _, f := range virtualRepoRevisionFlags {
+		if flag == f {
+			return true
+		}
+	}
+	return false
+}
+
+// virtualRepoRevisionEndpoints returns the revisions a revision argument, such
+// as a range or an exclusion, is made of.
+func virtualRepoRevisionEndpoints(rev string) []string {
+	rev = strings.TrimPrefix(rev, "^")
+	for _, suffix := range []string{"^@", "^!"} {
+		rev = strings.TrimSuffix(rev, suffix)
+	}
+	if i := strings.LastIndex(rev, "^-"); i >= 0 && strings.Trim(rev[i+2:], "0123456789") == "" {
+		rev = rev[:i]
+	}
+
+	var endpoints []string
+	for _, sep := range []string{"...", ".."} {
+		if from, to, ok := strings.Cut(rev, sep); ok {
+			endpoints = append(endpoints, from, to)
+			break
+		}
+	}
+	if endpoints == nil {
+		endpoints = []string{rev}
+	}
+
+	nonEmpty := endpoints[:0]
+	for _, endpoint := range endpoints {
+		// An empty endpoint of a range stands for HEAD.
+		if endpoint != "" {
+			nonEmpty = append(nonEmpty, endpoint)
+		}
+	}
+	return nonEmpty
+}
+
+// checkVirtualRepoRevisions returns an error if one of the revisions returned
+// by checkVirtualRepoArgs names an object other than a commit, or a tag of a
+// commit, in the clone in dir. Revisions which don't name an object are
+// rejected by the command itself.
+func (s *Server) checkVirtualRepoRevisions(ctx context.Context, repo api.RepoName, dir GitDir, revs []string) error {
+	var endpoints []string
+	for _, rev := range revs {
+		endpoints = append(endpoints, virtualRepoRevisionEndpoints(rev)...)
+	}
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	// Every endpoint is checked twice: for its type, and for whether it peels
+	// to a commit.
+	var stdin, stdout bytes.Buffer
+	for _, endpoint := range endpoints {
+		if strings.ContainsAny(endpoint, "\n\r") {
+			return errVirtualRepoCommand
+		}
+		stdin.WriteString(endpoint + "\n" + endpoint + "^{commit}\n")
+	}
+
+	cmd := s.recordingCommandFactory.Command(ctx, s.Logger, "git", "cat-file", "--batch-check=%(objecttype)")
+	dir.Set(cmd.Unwrap())
+	cmd.Unwrap().Stdin = &stdin
+	cmd.Unwrap().Stdout = &stdout
+	// The objects of partial clones may be missing until they are fetched.
+	if isPartialClone(dir) {
+		if err := s.configureLazyFetch(ctx, repo, cmd.Unwrap()); err != nil {
+			return err
+		}
+	}
+	if _, err := runCommand(ctx, cmd); err != nil {
+		return errors.Wrap(err, "checking revisions of virtual repository")
+	}
+
+	lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+	if len(lines) != 2*len(endpoints) {
+		return errors.Newf("unexpected output of cat-file for %d revisions", len(endpoints))
+	}
+	for i := range endpoints {
+		objectType, peeled := lines[2*i], lines[2*i+1]
+		switch {
+		case objectType == "commit":
+		case objectType == "tag" && peeled == "commit":
+		case strings.HasSuffix(objectType, " missing"):
+		default:
+			return errVirtualRepoCommand
+		}
+	}
+	return nil
+}
+
+// hasVirtualRepoFileFlag returns true if args contain a flag making log or show
+// output the files changed by commits. Unless told otherwise with -s or
+// --no-patch, show outputs the patch of commits.
+func hasVirtualRepoFileFlag(args []string, patchByDefault bool) bool {
+	hasFileFlag := patchByDefault
+	for _, arg := range args {
+		if arg == "-s" || arg == "--no-patch" {
+			hasFileFlag = false
+			continue
+		}
+		flag := strings.Split(arg, "=")[0]
+		for _, f := range virtualRepoFileFlags {
+			if flag == f || (len(f) == 2 && strings.HasPrefix(flag, f)) {
+				return true
+			}
+		}
+	}
+	return hasFileFlag
+}
+
+// virtualRepoOutputFilter returns a writer filtering the output of the git
+// command args to the paths visible in v, or w if the output of args doesn't
+// need to be filtered.
+func virtualRepoOutputFilter(v *types.VirtualRepo, args []string, w io.Writer) io.Writer {
+	if len(args) == 0 || (args[0] != "ls-tree" && args[0] != "ls-files") {
+		return w
+	}
+
+	sep := byte('\n')
+	for _, arg := range args {
+		if arg == "-z" {
+			sep = 0
+		}
+		if arg == "--" {
+			break
+		}
+	}
+	return &pathFilterWriter{w: w, sep: sep, visible: v.Visible}
+}
+
+// pathFilterWriter writes the records of the output of ls-tree or ls-files
+// whose path is visible. Records are separated by sep, and the path of a record
+// follows its last tab, if any.
+type pathFilterWriter struct {
+	w       io.Writer
+	sep     byte
+	visible func(path string) bool
+	buf     []byte
+}
+
+func (f *pathFilterWriter) Write(p []byte) (int, error) {
+	f.buf = append(f.buf, p...)
+	for {
+		i := bytes.IndexByte(f.buf, f.sep)
+		if i < 0 {
+			break
+		}
+		record := f.buf[:i+1]
+		path := record[:i]
+		if j := bytes.LastIndexByte(path, '\t'); j >= 0 {
+			path = path[j+1:]
+		}
+		// Paths with special characters are quoted unless -z is used. They are
+		// not visible, as they don't match the paths of the virtual repository.
+		if f.visible(string(path)) {
+			if _, err := f.w.Write(record); err != nil {
+				return 0, err
+			}
+		}
+		f.buf = f.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// virtualRepoLogCommand returns the output of git log -n 1 --name-only for the
+// given commit of the virtual repository v, whose clone is in dir. Passing the
+// paths of v to git log would select the closest commit modifying them instead,
+// so the files modified by the commit are listed separately.
+func (s *Server) virtualRepoLogCommand(ctx context.Context, dir GitDir, v *types.VirtualRepo, format, commit string) (string, error) {
+	var header, files bytes.Buffer
+
+	cmd := s.recordingCommandFactory.Command(ctx, s.Logger, "git", "log", "-n", "1", format, commit)
+	dir.Set(cmd.Unwrap())
+	cmd.Unwrap().Stdout = &header
+	if _, err := runCommand(ctx, cmd); err != nil {
+		return "", err
+	}
+
+	args := append([]string{"diff-tree", "--root", "-r", "--name-only", "--no-commit-id", commit, "--"}, virtualRepoPathspecs(v)...)
+	cmd = s.recordingCommandFactory.Command(ctx, s.Logger, "git", args...)
+	dir.Set(cmd.Unwrap())
+	cmd.Unwrap().Stdout = &files
+	if _, err := runCommand(ctx, cmd); err != nil {
+		return "", err
+	}
+
+	if files.Len() == 0 {
+		return header.String(), nil
+	}
+	return header.String() + "\n" + files.String(), nil
+}
+
+// virtualRepoUpdateStatus sets the status of the clone of the parent of the
+// virtual repository repo on resp.
+func (s *Server) virtualRepoUpdateStatus(repo api.RepoName, resp *protocol.RepoUpdateResponse) {
+	dir := s.contentDir(repo)
+	if !repoCloned(dir) {
+		return
+	}
+
+	var statusErr error
+	if lastFetched, err := repoLastFetched(dir); err != nil {
+		statusErr = err
+	} else {
+		resp.LastFetched = &lastFetched
+	}
+	if lastChanged, err := repoLastChanged(dir); err != nil {
+		statusErr = err
+	} else {
+		resp.LastChanged = &lastChanged
+	}
+	if statusErr != nil {
+		resp.Error = statusErr.Error()
+	}
+}
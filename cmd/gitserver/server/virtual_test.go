@@ -0,0 +1,170 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/wrexec"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestCheckVirtualRepoArgs(t *testing.T) {
+	v := types.NewVirtualRepo("monorepo-foo", "monorepo", []string{"services/foo"})
+
+	allowed := [][]string{
+		{"rev-parse", "HEAD"},
+		{"rev-list", "--max-count=1", "HEAD~1..HEAD"},
+		{"symbolic-ref", "HEAD"},
+		{"log", "--format=%H", "HEAD"},
+		{"log", "--name-only", "HEAD", "--", ":(literal)services/foo"},
+		{"show", "-s", "--format=%H:%cI", "HEAD"},
+		{"show", "HEAD:services/foo/main.go"},
+		{"diff", "HEAD~1", "HEAD", "--", "services/foo/main.go"},
+		{"blame", "-w", "--porcelain", "HEAD", "--", "services/foo/main.go"},
+		{"archive", "--format=zip", "HEAD", "--", ":(literal)services/foo"},
+		{"ls-tree", "--name-only", "-r", "HEAD", "--"},
+		{"lfs", "smudge", "services/foo/model.bin"},
+	}
+	for _, args := range allowed {
+		if _, err := checkVirtualRepoArgs(v, args); err != nil {
+			t.Errorf("unexpected error for %q: %s", args, err)
+		}
+	}
+
+	blocked := [][]string{
+		{"log", "--name-only", "HEAD"},
+		{"log", "-Sfoo", "HEAD"},
+		{"log", "HEAD", "--", "services/bar"},
+		{"show", "HEAD"},
+		{"show", "HEAD:README.md"},
+		{"show", "HEAD:"},
+		{"diff", "HEAD~1", "HEAD"},
+		{"diff", "HEAD~1", "HEAD", "--", ":(glob)**"},
+		{"diff", "HEAD~1", "HEAD", "--", "services/foo/../bar"},
+		{"archive", "--format=zip", "HEAD", "--"},
+		{"rev-parse", "HEAD:README.md"},
+		{"rev-parse", "HEAD:services/foo"},
+		{"rev-list", "--objects", "HEAD"},
+		{"rev-list", "--all"},
+		{"rev-list", "--glob=refs/*"},
+		{"log", "--stdin"},
+		{"cat-file", "-p", "deadbeef"},
+		{"lfs", "smudge", "README.md"},
+		{"commit", "-m", "foo"},
+		{"push", "--force"},
+	}
+	for _, args := range blocked {
+		if _, err := checkVirtualRepoArgs(v, args); !errors.Is(err, ErrInvalidCommand) {
+			t.Errorf("unexpected error for %q. want=%q have=%v", args, ErrInvalidCommand, err)
+		}
+	}
+}
+
+func TestCheckVirtualRepoArgsRevisions(t *testing.T) {
+	v := types.NewVirtualRepo("monorepo-foo", "monorepo", []string{"services/foo"})
+
+	revs, err := checkVirtualRepoArgs(v, []string{"show", "-s", "--format=%H", "HEAD", "--", "services/foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"HEAD"}, revs); diff != "" {
+		t.Errorf("unexpected revisions (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]string{"HEAD~1", "HEAD"}, virtualRepoRevisionEndpoints("^HEAD~1...HEAD^@")); diff != "" {
+		t.Errorf("unexpected endpoints (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"main"}, virtualRepoRevisionEndpoints("main..")); diff != "" {
+		t.Errorf("unexpected endpoints (-want +got):\n%s", diff)
+	}
+}
+
+func TestCheckVirtualRepoRevisions(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	root := t.TempDir()
+	cmd := func(name string, arg ...string) string {
+		return strings.TrimSpace(runCmd(t, root, name, arg...))
+	}
+	head := makeSingleCommitRepo(cmd)
+	cmd("git", "tag", "-a", "-m", "v1", "v1")
+	blob := cmd("git", "rev-parse", "HEAD:hello.txt")
+	tree := cmd("git", "rev-parse", "HEAD^{tree}")
+	cmd("git", "tag", "-a", "-m", "blob", "blob-tag", blob)
+
+	s := &Server{
+		Logger:                  logtest.Scoped(t),
+		recordingCommandFactory: wrexec.NewRecordingCommandFactory(nil, 0),
+	}
+	dir := GitDir(filepath.Join(root, ".git"))
+	ctx := context.Background()
+
+	for _, revs := range [][]string{
+		{"HEAD"},
+		{head},
+		{"HEAD~0..HEAD", "^HEAD^@"},
+		{"v1"},
+		{"does-not-exist"},
+	} {
+		if err := s.checkVirtualRepoRevisions(ctx, "monorepo", dir, revs); err != nil {
+			t.Errorf("unexpected error for %q: %s", revs, err)
+		}
+	}
+
+	// rev-parse HEAD:<path> is rejected by checkVirtualRepoArgs, but the
+	// object names it returns for the parent must not be readable either.
+	for _, revs := range [][]string{
+		{blob},
+		{tree},
+		{"HEAD^{tree}"},
+		{"HEAD.." + blob},
+		{"blob-tag"},
+	} {
+		if err := s.checkVirtualRepoRevisions(ctx, "monorepo", dir, revs); !errors.Is(err, ErrInvalidCommand) {
+			t.Errorf("unexpected error for %q. want=%q have=%v", revs, ErrInvalidCommand, err)
+		}
+	}
+}
+
+func TestVirtualRepoOutputFilter(t *testing.T) {
+	v := types.NewVirtualRepo("monorepo-foo", "monorepo", []string{"services/foo"})
+
+	var buf bytes.Buffer
+	w := virtualRepoOutputFilter(v, []string{"ls-tree", "--long", "HEAD"}, &buf)
+	for _, chunk := range []string{
+		"100644 blob 1111111 12\tREADME.md\n040000 tree 2222222    -\tserv",
+		"ices\n100644 blob 3333333 34\tservices/foo/main.go\n040000 tree 4444444    -\tservices/bar\n",
+	} {
+		if _, err := w.Write([]byte(chunk)); err != nil {
+			t.Fatalf("unexpected error writing output: %s", err)
+		}
+	}
+
+	expected := "040000 tree 2222222    -\tservices\n100644 blob 3333333 34\tservices/foo/main.go\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected output. want=%q have=%q", expected, buf.String())
+	}
+
+	buf.Reset()
+	w = virtualRepoOutputFilter(v, []string{"ls-files", "-z", "--with-tree", "HEAD"}, &buf)
+	if _, err := w.Write([]byte("README.md\x00services/foo/main.go\x00")); err != nil {
+		t.Fatalf("unexpected error writing output: %s", err)
+	}
+	if expected := "services/foo/main.go\x00"; buf.String() != expected {
+		t.Errorf("unexpected output. want=%q have=%q", expected, buf.String())
+	}
+
+	if w := virtualRepoOutputFilter(v, []string{"log", "HEAD"}, &buf); w != &buf {
+		t.Errorf("unexpected filter of log output")
+	}
+}
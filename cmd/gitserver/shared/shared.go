@@ -517,7 +517,7 @@ func getVCSSyncer(
 		cli := rubygems.NewClient(urn, c.Repository, httpcli.ExternalDoer)
 		return server.NewRubyPackagesSyncer(&c, depsSvc, cli), nil
 	}
-	return &server.GitRepoSyncer{}, nil
+	return &server.GitRepoSyncer{PartialCloneFilter: server.PartialCloneFilter(repo)}, nil
 }
 
 func syncExternalServiceRateLimiters(ctx context.Context, store database.ExternalServiceStore) error {
@@ -10,6 +10,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strconv"
 	"sync"
 	"time"
 
@@ -25,6 +27,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/diskcache"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/internal/mutablelimiter"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -69,6 +72,12 @@ type Store struct {
 	// FilterTar returns a FilterFunc that filters out files we don't want to write to disk
 	FilterTar func(ctx context.Context, client gitserver.Client, repo api.RepoName, commit api.CommitID) (FilterFunc, error)
 
+	// FetchLFS returns an io.ReadCloser of the Git LFS object the pointer
+	// file at path in repo at commit points to. It is only called for
+	// repositories enabled by the site configuration search.lfs. If nil, the
+	// archive always contains the pointer files.
+	FetchLFS func(ctx context.Context, repo api.RepoName, commit api.CommitID, path string) (io.ReadCloser, error)
+
 	// Path is the directory to store the cache
 	Path string
 
@@ -90,6 +99,11 @@ type Store struct {
 	// cache is the disk backed cache.
 	cache diskcache.Store
 
+	// lfsCache is the disk backed cache of LFS objects, keyed by their OID.
+	// It is shared by all repositories and lives below Path, so it is evicted
+	// together with cache.
+	lfsCache diskcache.Store
+
 	// fetchLimiter limits concurrent calls to FetchTar.
 	fetchLimiter *mutablelimiter.Limiter
 
@@ -113,6 +127,10 @@ func (s *Store) Start() {
 			diskcache.WithBeforeEvict(s.zipCache.delete),
 			diskcache.WithobservationCtx(s.ObservationCtx),
 		)
+		s.lfsCache = diskcache.NewStore(filepath.Join(s.Path, "lfs"), "lfs",
+			diskcache.WithBackgroundTimeout(10*time.Minute),
+			diskcache.WithobservationCtx(s.ObservationCtx),
+		)
 		_ = os.MkdirAll(s.Path, 0o700)
 		metrics.MustRegisterDiskMonitor(s.Path)
 
@@ -159,7 +177,13 @@ func (s *Store) PrepareZipPaths(ctx context.Context, repo api.RepoName, commit a
 		return "", errors.Errorf("commit must be resolved (repo=%q, commit=%q)", repo, commit)
 	}
 
-	filter := newSearchableFilter(&conf.Get().SiteConfiguration)
+	c := &conf.Get().SiteConfiguration
+	filter := newSearchableFilter(c)
+
+	var lfsMaxFileSize int64
+	if s.FetchLFS != nil {
+		lfsMaxFileSize = conf.SearchLFSMaxFileSize(repo)
+	}
 
 	// key is a sha256 hash since we want to use it for the disk name
 	h := sha256.New()
@@ -170,6 +194,9 @@ func (s *Store) PrepareZipPaths(ctx context.Context, repo api.RepoName, commit a
 		_, _ = h.Write([]byte{0})
 		_, _ = io.WriteString(h, p)
 	}
+	if lfsMaxFileSize > 0 {
+		_, _ = fmt.Fprintf(h, "\x00LFS %d", lfsMaxFileSize)
+	}
 	key := hex.EncodeToString(h.Sum(nil))
 	span.LogKV("key", key)
 
@@ -189,7 +216,7 @@ func (s *Store) PrepareZipPaths(ctx context.Context, repo api.RepoName, commit a
 		bgctx := opentracing.ContextWithSpan(context.Background(), opentracing.SpanFromContext(ctx))
 		f, err := s.cache.Open(bgctx, []string{key}, func(ctx context.Context) (io.ReadCloser, error) {
 			cacheHit = false
-			return s.fetch(ctx, repo, commit, filter, paths)
+			return s.fetch(ctx, repo, commit, filter, paths, lfsMaxFileSize)
 		})
 		var path string
 		if f != nil {
@@ -220,7 +247,7 @@ func (s *Store) PrepareZipPaths(ctx context.Context, repo api.RepoName, commit a
 // fetch fetches an archive from the network and stores it on disk. It does
 // not populate the in-memory cache. You should probably be calling
 // prepareZip.
-func (s *Store) fetch(ctx context.Context, repo api.RepoName, commit api.CommitID, filter *searchableFilter, paths []string) (rc io.ReadCloser, err error) {
+func (s *Store) fetch(ctx context.Context, repo api.RepoName, commit api.CommitID, filter *searchableFilter, paths []string, lfsMaxFileSize int64) (rc io.ReadCloser, err error) {
 	metricFetchQueueSize.Inc()
 	ctx, releaseFetchLimiter, err := s.fetchLimiter.Acquire(ctx) // Acquire concurrent fetches semaphore
 	if err != nil {
@@ -282,6 +309,16 @@ func (s *Store) fetch(ctx context.Context, repo api.RepoName, commit api.CommitI
 		}
 	}
 
+	var lfs *lfsFetcher
+	if lfsMaxFileSize > 0 {
+		lfs = &lfsFetcher{
+			maxFileSize: lfsMaxFileSize,
+			fetch: func(path string, pointer *gitdomain.LFSPointer) ([]byte, error) {
+				return s.fetchLFS(ctx, repo, commit, path, pointer)
+			},
+		}
+	}
+
 	pr, pw := io.Pipe()
 
 	// After this point we are not allowed to return an error. Instead we can
@@ -294,7 +331,7 @@ func (s *Store) fetch(ctx context.Context, repo api.RepoName, commit api.CommitI
 		defer r.Close()
 		tr := tar.NewReader(r)
 		zw := zip.NewWriter(pw)
-		err := copySearchable(tr, zw, filter)
+		err := copySearchable(tr, zw, filter, lfs)
 		if err1 := zw.Close(); err == nil {
 			err = err1
 		}
@@ -306,9 +343,80 @@ func (s *Store) fetch(ctx context.Context, repo api.RepoName, commit api.CommitI
 	return pr, nil
 }
 
+// fetchLFS returns the content of the LFS object pointer points to, fetching
+// it via FetchLFS if it is not cached yet.
+func (s *Store) fetchLFS(ctx context.Context, repo api.RepoName, commit api.CommitID, path string, pointer *gitdomain.LFSPointer) (_ []byte, err error) {
+	cacheHit := true
+	f, err := s.lfsCache.Open(ctx, []string{pointer.OID}, func(ctx context.Context) (io.ReadCloser, error) {
+		cacheHit = false
+		r, err := s.FetchLFS(ctx, repo, commit, path)
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+
+		// The cache is shared between repositories, so only store content
+		// matching the OID.
+		b, err := io.ReadAll(io.LimitReader(r, pointer.Size+1))
+		metricLFSFetchedBytes.Add(float64(len(b)))
+		if err != nil {
+			return nil, err
+		}
+		if sum := sha256.Sum256(b); hex.EncodeToString(sum[:]) != pointer.OID {
+			return nil, errors.Errorf("content of LFS object %s for %q does not match its OID", pointer.OID, path)
+		}
+		return io.NopCloser(bytes.NewReader(b)), nil
+	})
+	metricLFSAccess.WithLabelValues(strconv.FormatBool(cacheHit)).Inc()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch LFS object for %q", path)
+	}
+	defer f.File.Close()
+	return io.ReadAll(f.File)
+}
+
+// lfsFetcher replaces Git LFS pointer files with the content of the LFS
+// objects they point to.
+type lfsFetcher struct {
+	// maxFileSize is the maximum size of LFS objects to fetch.
+	maxFileSize int64
+
+	// fetch returns the content of the LFS object pointer points to.
+	fetch func(path string, pointer *gitdomain.LFSPointer) ([]byte, error)
+}
+
+// smudge reads the file hdr describes from r. If it is a pointer to an LFS
+// object no larger than maxFileSize whose content would be searched, it
+// returns the content of the object instead and updates hdr.Size.
+func (l *lfsFetcher) smudge(hdr *tar.Header, r io.Reader, filter *searchableFilter) ([]byte, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	pointer := gitdomain.ParseLFSPointer(b)
+	if pointer == nil || pointer.Size > l.maxFileSize {
+		return b, nil
+	}
+	smudged := *hdr
+	smudged.Size = pointer.Size
+	if filter.Ignore(&smudged) || filter.SkipContent(&smudged) {
+		return b, nil
+	}
+
+	content, err := l.fetch(hdr.Name, pointer)
+	if err != nil {
+		return nil, err
+	}
+	hdr.Size = int64(len(content))
+	return content, nil
+}
+
 // copySearchable copies searchable files from tr to zw. A searchable file is
 // any file that is under size limit, non-binary, and not matching the filter.
-func copySearchable(tr *tar.Reader, zw *zip.Writer, filter *searchableFilter) error {
+// If lfs is non-nil, LFS pointer files are replaced by the content of the LFS
+// objects they point to.
+func copySearchable(tr *tar.Reader, zw *zip.Writer, filter *searchableFilter, lfs *lfsFetcher) error {
 	// 32*1024 is the same size used by io.Copy
 	buf := make([]byte, 32*1024)
 	for {
@@ -334,6 +442,15 @@ func copySearchable(tr *tar.Reader, zw *zip.Writer, filter *searchableFilter) er
 				continue
 			}
 
+			var content io.Reader = tr
+			if lfs != nil && hdr.Size < gitdomain.LFSPointerMaxSize {
+				b, err := lfs.smudge(hdr, tr, filter)
+				if err != nil {
+					return err
+				}
+				content = bytes.NewReader(b)
+			}
+
 			// We are happy with the file, so we can write it to zw.
 			w, err := zw.CreateHeader(&zip.FileHeader{
 				Name:   hdr.Name,
@@ -349,7 +466,7 @@ func copySearchable(tr *tar.Reader, zw *zip.Writer, filter *searchableFilter) er
 				continue
 			}
 
-			n, err := tr.Read(buf)
+			n, err := content.Read(buf)
 			switch err {
 			case io.EOF:
 				if n == 0 {
@@ -375,7 +492,7 @@ func copySearchable(tr *tar.Reader, zw *zip.Writer, filter *searchableFilter) er
 				return io.ErrShortWrite
 			}
 
-			_, err = io.CopyBuffer(w, tr, buf)
+			_, err = io.CopyBuffer(w, content, buf)
 			if err != nil {
 				return err
 			}
@@ -474,6 +591,14 @@ var (
 		Help:    "Observes the duration to prepare the zip file for searching.",
 		Buckets: prometheus.DefBuckets,
 	}, []string{"cache_hit"})
+	metricLFSAccess = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "searcher_store_lfs_access_total",
+		Help: "The total number of LFS objects read when preparing archives.",
+	}, []string{"cache_hit"})
+	metricLFSFetchedBytes = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "searcher_store_lfs_fetched_bytes_total",
+		Help: "The total number of bytes of LFS objects fetched from gitserver.",
+	})
 )
 
 // temporaryError wraps an error but adds the Temporary method. It does not
@@ -5,6 +5,9 @@ import (
 	"archive/zip"
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"io"
 	"net"
 	"os"
@@ -15,9 +18,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/sourcegraph/log/logtest"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -136,6 +141,99 @@ func TestPrepareZip_errHeader(t *testing.T) {
 	}
 }
 
+func TestPrepareZip_lfs(t *testing.T) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		ExperimentalFeatures: &schema.ExperimentalFeatures{
+			SearchLfs: []*schema.SearchLFSRule{{Name: "^foo$"}},
+		},
+	}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	content := "large fixture\n"
+	sum := sha256.Sum256([]byte(content))
+	oid := hex.EncodeToString(sum[:])
+	pointer := fmt.Sprintf("version https://git-lfs.github.com/spec/v1\noid sha256:%s\nsize %d\n", oid, len(content))
+	tooLarge := fmt.Sprintf("version https://git-lfs.github.com/spec/v1\noid sha256:%s\nsize %d\n", oid, maxFileSize+1)
+
+	s := tmpStore(t)
+	s.FetchTar = func(ctx context.Context, repo api.RepoName, commit api.CommitID) (io.ReadCloser, error) {
+		buf := new(bytes.Buffer)
+		w := tar.NewWriter(buf)
+		for name, body := range map[string]string{
+			"fixture.json": pointer,
+			"large.json":   tooLarge,
+			"README.md":    "readme\n",
+		} {
+			if err := w.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(body))}); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := w.Write([]byte(body)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return io.NopCloser(bytes.NewReader(buf.Bytes())), nil
+	}
+	var fetchLFSCalled int64
+	s.FetchLFS = func(ctx context.Context, repo api.RepoName, commit api.CommitID, path string) (io.ReadCloser, error) {
+		atomic.AddInt64(&fetchLFSCalled, 1)
+		if path != "fixture.json" {
+			t.Errorf("unexpected FetchLFS of %q", path)
+		}
+		return io.NopCloser(strings.NewReader(content)), nil
+	}
+
+	readZip := func(repo api.RepoName, commit api.CommitID) map[string]string {
+		path, err := s.PrepareZip(context.Background(), repo, commit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		zr, err := zip.OpenReader(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer zr.Close()
+		files := map[string]string{}
+		for _, f := range zr.File {
+			r, err := f.Open()
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			files[f.Name] = string(b)
+		}
+		return files
+	}
+
+	want := map[string]string{
+		"fixture.json": content,
+		"large.json":   tooLarge,
+		"README.md":    "readme\n",
+	}
+	if diff := cmp.Diff(want, readZip("foo", "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef")); diff != "" {
+		t.Fatalf("unexpected archive (-want +got):\n%s", diff)
+	}
+
+	// LFS objects are cached independently of the commit.
+	if diff := cmp.Diff(want, readZip("foo", "cafebabecafebabecafebabecafebabecafebabe")); diff != "" {
+		t.Fatalf("unexpected archive (-want +got):\n%s", diff)
+	}
+	if got := atomic.LoadInt64(&fetchLFSCalled); got != 1 {
+		t.Fatalf("expected FetchLFS to be called once, got %d", got)
+	}
+
+	// Repositories not matching search.lfs contain the pointer files.
+	want["fixture.json"] = pointer
+	if diff := cmp.Diff(want, readZip("bar", "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef")); diff != "" {
+		t.Fatalf("unexpected archive (-want +got):\n%s", diff)
+	}
+}
+
 func TestSearchLargeFiles(t *testing.T) {
 	filter := newSearchableFilter(&schema.SiteConfiguration{
 		SearchLargeFiles: []string{
@@ -217,7 +315,7 @@ func TestSymlink(t *testing.T) {
 	filter.CommitIgnore = func(hdr *tar.Header) bool {
 		return false
 	}
-	if err := copySearchable(tarReader, zw, filter); err != nil {
+	if err := copySearchable(tarReader, zw, filter, nil); err != nil {
 		t.Fatal(err)
 	}
 	zw.Close()
@@ -149,6 +149,11 @@ func Start(ctx context.Context, observationCtx *observation.Context, ready servi
 					Pathspecs: pathspecs,
 				})
 			},
+			FetchLFS: func(ctx context.Context, repo api.RepoName, commit api.CommitID, path string) (io.ReadCloser, error) {
+				// As this is an internal service call, we need an internal actor.
+				ctx = actor.WithInternalActor(ctx)
+				return git.LFSSmudge(ctx, nil, repo, commit, path)
+			},
 			FilterTar:         search.NewFilter,
 			Path:              filepath.Join(cacheDir, "searcher-archives"),
 			MaxCacheSizeBytes: cacheSizeBytes,
@@ -0,0 +1,40 @@
+package jobdependencies
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/jobgraph"
+)
+
+type scheduler struct{}
+
+var _ job.Job = &scheduler{}
+
+func NewScheduler() job.Job {
+	return &scheduler{}
+}
+
+func (j *scheduler) Description() string {
+	return "jobgraph.Scheduler resolves dependencies between background jobs, releasing jobs whose dependencies completed and failing jobs whose dependencies failed."
+}
+
+func (j *scheduler) Config() []env.Config {
+	return nil
+}
+
+func (j *scheduler) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	db, err := workerdb.InitDB(observationCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	return []goroutine.BackgroundRoutine{
+		jobgraph.NewScheduler(observationCtx, jobgraph.NewStore(db.Handle()), 5*time.Second),
+	}, nil
+}
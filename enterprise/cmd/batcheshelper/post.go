@@ -92,7 +92,7 @@ func execPost(ctx context.Context, stepIdx int, executionInput batcheslib.Worksp
 		return errors.Wrap(err, "failed to write step result file")
 	}
 
-	key := cache.KeyForWorkspace(
+	key := cache.KeyForStep(
 		&executionInput.BatchChangeAttributes,
 		batcheslib.Repository{
 			ID:          executionInput.Repository.ID,
@@ -104,8 +104,8 @@ func execPost(ctx context.Context, stepIdx int, executionInput batcheslib.Worksp
 		executionInput.Path,
 		os.Environ(),
 		executionInput.OnlyFetchWorkspace,
-		executionInput.Steps,
-		stepIdx,
+		step,
+		previousResult,
 		nil, // todo: should not be nil.
 	)
 
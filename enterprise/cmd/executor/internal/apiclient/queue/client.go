@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	otlog "github.com/opentracing/opentracing-go/log"
@@ -34,6 +36,11 @@ type Client struct {
 	logger          log.Logger
 	metricsGatherer prometheus.Gatherer
 	operations      *operations
+
+	// runningJobIDs holds the IDs of the jobs being processed by the name of the
+	// queue they were dequeued from. It is only used when processing several queues.
+	runningJobIDsMu sync.Mutex
+	runningJobIDs   map[string]map[int]struct{}
 }
 
 // Compile time validation.
@@ -51,6 +58,7 @@ func New(observationCtx *observation.Context, options Options, metricsGatherer p
 		logger:          log.Scoped("executor-api-queue-client", "The API client adapter for executors to use dbworkers over HTTP"),
 		metricsGatherer: metricsGatherer,
 		operations:      newOperations(observationCtx),
+		runningJobIDs:   map[string]map[int]struct{}{},
 	}, nil
 }
 
@@ -60,35 +68,129 @@ func (c *Client) QueuedCount(ctx context.Context) (int, error) {
 
 func (c *Client) Dequeue(ctx context.Context, workerHostname string, extraArguments any) (job executor.Job, _ bool, err error) {
 	ctx, _, endObservation := c.operations.dequeue.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
-		otlog.String("queueName", c.options.QueueName),
+		otlog.String("queueName", c.queueNames()),
 	}})
 	defer endObservation(1, observation.Args{})
 
-	req, err := c.client.NewJSONRequest(http.MethodPost, fmt.Sprintf("%s/dequeue", c.options.QueueName), executor.DequeueRequest{
+	if c.isMultiQueue() {
+		return c.dequeueMultiQueue(ctx)
+	}
+
+	req, err := c.client.NewJSONRequest(http.MethodPost, fmt.Sprintf("%s/dequeue", c.options.QueueName), c.dequeueRequest())
+	if err != nil {
+		return job, false, err
+	}
+
+	decoded, err := c.client.DoAndDecode(ctx, req, &job)
+	return job, decoded, err
+}
+
+// dequeueMultiQueue requests a job from any of the queues that have not reached
+// their maximum number of concurrent jobs. The frontend picks the queue.
+func (c *Client) dequeueMultiQueue(ctx context.Context) (job executor.Job, _ bool, err error) {
+	c.runningJobIDsMu.Lock()
+	runningIDs := make(map[string][]int, len(c.runningJobIDs))
+	for queueName, ids := range c.runningJobIDs {
+		for id := range ids {
+			runningIDs[queueName] = append(runningIDs[queueName], id)
+		}
+		sort.Ints(runningIDs[queueName])
+	}
+	c.runningJobIDsMu.Unlock()
+
+	var queues []executor.WeightedQueue
+	for _, queue := range c.options.Queues {
+		if queue.MaxNumJobs > 0 && len(runningIDs[queue.Name]) >= queue.MaxNumJobs {
+			continue
+		}
+		queues = append(queues, executor.WeightedQueue{Name: queue.Name, Weight: queue.Weight})
+	}
+	if len(queues) == 0 {
+		return job, false, nil
+	}
+
+	req, err := c.client.NewJSONRequest(http.MethodPost, "dequeue", executor.MultiQueueDequeueRequest{
+		DequeueRequest: c.dequeueRequest(),
+		Queues:         queues,
+		RunningJobIDs:  runningIDs,
+	})
+	if err != nil {
+		return job, false, err
+	}
+
+	decoded, err := c.client.DoAndDecode(ctx, req, &job)
+	if err != nil || !decoded {
+		return job, false, err
+	}
+	if job.Queue == "" {
+		return job, false, errors.Newf("job %d was dequeued without a queue", job.ID)
+	}
+
+	c.runningJobIDsMu.Lock()
+	if c.runningJobIDs[job.Queue] == nil {
+		c.runningJobIDs[job.Queue] = map[int]struct{}{}
+	}
+	c.runningJobIDs[job.Queue][job.ID] = struct{}{}
+	c.runningJobIDsMu.Unlock()
+
+	return job, true, nil
+}
+
+func (c *Client) dequeueRequest() executor.DequeueRequest {
+	return executor.DequeueRequest{
 		Version:      version.Version(),
 		ExecutorName: c.options.ExecutorName,
 		NumCPUs:      c.options.ResourceOptions.NumCPUs,
 		Memory:       c.options.ResourceOptions.Memory,
 		DiskSpace:    c.options.ResourceOptions.DiskSpace,
-	})
-	if err != nil {
-		return job, false, err
 	}
+}
 
-	decoded, err := c.client.DoAndDecode(ctx, req, &job)
-	return job, decoded, err
+func (c *Client) isMultiQueue() bool {
+	return len(c.options.Queues) > 0
 }
 
-func (c *Client) MarkComplete(ctx context.Context, id int) (_ bool, err error) {
+// queueNames returns the names of the processed queues, for use in log fields.
+func (c *Client) queueNames() string {
+	if !c.isMultiQueue() {
+		return c.options.QueueName
+	}
+
+	names := make([]string, 0, len(c.options.Queues))
+	for _, queue := range c.options.Queues {
+		names = append(names, queue.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+// queueName returns the name of the queue the given job was dequeued from.
+func (c *Client) queueName(job executor.Job) string {
+	if !c.isMultiQueue() {
+		return c.options.QueueName
+	}
+	return job.Queue
+}
+
+// forgetJob stops tracking a job after it has been marked as finished.
+func (c *Client) forgetJob(job executor.Job) {
+	c.runningJobIDsMu.Lock()
+	delete(c.runningJobIDs[job.Queue], job.ID)
+	c.runningJobIDsMu.Unlock()
+}
+
+func (c *Client) MarkComplete(ctx context.Context, job executor.Job) (_ bool, err error) {
+	queueName := c.queueName(job)
+	defer c.forgetJob(job)
+
 	ctx, _, endObservation := c.operations.markComplete.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
-		otlog.String("queueName", c.options.QueueName),
-		otlog.Int("jobID", id),
+		otlog.String("queueName", queueName),
+		otlog.Int("jobID", job.ID),
 	}})
 	defer endObservation(1, observation.Args{})
 
-	req, err := c.client.NewJSONRequest(http.MethodPost, fmt.Sprintf("%s/markComplete", c.options.QueueName), executor.MarkCompleteRequest{
+	req, err := c.client.NewJSONRequest(http.MethodPost, fmt.Sprintf("%s/markComplete", queueName), executor.MarkCompleteRequest{
 		ExecutorName: c.options.ExecutorName,
-		JobID:        id,
+		JobID:        job.ID,
 	})
 	if err != nil {
 		return false, err
@@ -100,16 +202,19 @@ func (c *Client) MarkComplete(ctx context.Context, id int) (_ bool, err error) {
 	return true, nil
 }
 
-func (c *Client) MarkErrored(ctx context.Context, id int, failureMessage string) (_ bool, err error) {
+func (c *Client) MarkErrored(ctx context.Context, job executor.Job, failureMessage string) (_ bool, err error) {
+	queueName := c.queueName(job)
+	defer c.forgetJob(job)
+
 	ctx, _, endObservation := c.operations.markErrored.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
-		otlog.String("queueName", c.options.QueueName),
-		otlog.Int("jobID", id),
+		otlog.String("queueName", queueName),
+		otlog.Int("jobID", job.ID),
 	}})
 	defer endObservation(1, observation.Args{})
 
-	req, err := c.client.NewJSONRequest(http.MethodPost, fmt.Sprintf("%s/markErrored", c.options.QueueName), executor.MarkErroredRequest{
+	req, err := c.client.NewJSONRequest(http.MethodPost, fmt.Sprintf("%s/markErrored", queueName), executor.MarkErroredRequest{
 		ExecutorName: c.options.ExecutorName,
-		JobID:        id,
+		JobID:        job.ID,
 		ErrorMessage: failureMessage,
 	})
 	if err != nil {
@@ -122,16 +227,19 @@ func (c *Client) MarkErrored(ctx context.Context, id int, failureMessage string)
 	return true, nil
 }
 
-func (c *Client) MarkFailed(ctx context.Context, id int, failureMessage string) (_ bool, err error) {
+func (c *Client) MarkFailed(ctx context.Context, job executor.Job, failureMessage string) (_ bool, err error) {
+	queueName := c.queueName(job)
+	defer c.forgetJob(job)
+
 	ctx, _, endObservation := c.operations.markFailed.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
-		otlog.String("queueName", c.options.QueueName),
-		otlog.Int("jobID", id),
+		otlog.String("queueName", queueName),
+		otlog.Int("jobID", job.ID),
 	}})
 	defer endObservation(1, observation.Args{})
 
-	req, err := c.client.NewJSONRequest(http.MethodPost, fmt.Sprintf("%s/markFailed", c.options.QueueName), executor.MarkErroredRequest{
+	req, err := c.client.NewJSONRequest(http.MethodPost, fmt.Sprintf("%s/markFailed", queueName), executor.MarkErroredRequest{
 		ExecutorName: c.options.ExecutorName,
-		JobID:        id,
+		JobID:        job.ID,
 		ErrorMessage: failureMessage,
 	})
 	if err != nil {
@@ -144,9 +252,49 @@ func (c *Client) MarkFailed(ctx context.Context, id int, failureMessage string)
 	return true, nil
 }
 
-func (c *Client) Heartbeat(ctx context.Context, jobIDs []int) (knownIDs, cancelIDs []int, err error) {
+func (c *Client) Heartbeat(ctx context.Context, jobIDs []string) (knownIDs, cancelIDs []string, err error) {
+	jobIDsByQueue := map[string][]int{}
+	for _, jobID := range jobIDs {
+		id, queueName, err := executor.ParseJobUID(jobID)
+		if err != nil {
+			return nil, nil, err
+		}
+		jobIDsByQueue[queueName] = append(jobIDsByQueue[queueName], id)
+	}
+
+	if !c.isMultiQueue() {
+		knownIntIDs, cancelIntIDs, err := c.heartbeat(ctx, c.options.QueueName, jobIDsByQueue[""])
+		return jobUIDs("", knownIntIDs), jobUIDs("", cancelIntIDs), err
+	}
+
+	// Every queue only knows about the jobs dequeued from it, so we send one heartbeat
+	// per queue. Queues without running jobs are included, so that the executor is
+	// still reported as active.
+	for _, queue := range c.options.Queues {
+		queueKnownIDs, queueCancelIDs, err := c.heartbeat(ctx, queue.Name, jobIDsByQueue[queue.Name])
+		if err != nil {
+			return nil, nil, err
+		}
+		knownIDs = append(knownIDs, jobUIDs(queue.Name, queueKnownIDs)...)
+		cancelIDs = append(cancelIDs, jobUIDs(queue.Name, queueCancelIDs)...)
+	}
+
+	return knownIDs, cancelIDs, nil
+}
+
+// jobUIDs returns the identifiers of the jobs with the given IDs that were dequeued
+// from the given queue, as returned by executor.Job.RecordUID.
+func jobUIDs(queueName string, ids []int) []string {
+	uids := make([]string, 0, len(ids))
+	for _, id := range ids {
+		uids = append(uids, executor.Job{ID: id, Queue: queueName}.RecordUID())
+	}
+	return uids
+}
+
+func (c *Client) heartbeat(ctx context.Context, queueName string, jobIDs []int) (knownIDs, cancelIDs []int, err error) {
 	ctx, _, endObservation := c.operations.heartbeat.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
-		otlog.String("queueName", c.options.QueueName),
+		otlog.String("queueName", queueName),
 		otlog.String("jobIDs", intsToString(jobIDs)),
 	}})
 	defer endObservation(1, observation.Args{})
@@ -157,7 +305,7 @@ func (c *Client) Heartbeat(ctx context.Context, jobIDs []int) (knownIDs, cancelI
 		// Continue, no metric errors should prevent heartbeats.
 	}
 
-	req, err := c.client.NewJSONRequest(http.MethodPost, fmt.Sprintf("%s/heartbeat", c.options.QueueName), executor.HeartbeatRequest{
+	req, err := c.client.NewJSONRequest(http.MethodPost, fmt.Sprintf("%s/heartbeat", queueName), executor.HeartbeatRequest{
 		// Request the new-fashioned payload.
 		Version: executor.ExecutorAPIVersion2,
 
@@ -209,7 +357,7 @@ func (c *Client) Heartbeat(ctx context.Context, jobIDs []int) (knownIDs, cancelI
 	// are talking to a pre-4.3 Sourcegraph API and that doesn't return canceled
 	// jobs as part of heartbeats.
 
-	cancelIDs, err = c.CanceledJobs(ctx, c.options.QueueName, jobIDs)
+	cancelIDs, err = c.CanceledJobs(ctx, queueName, jobIDs)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -252,7 +400,7 @@ func gatherMetrics(logger log.Logger, gatherer prometheus.Gatherer) (string, err
 
 // TODO: Remove this in Sourcegraph 4.4.
 func (c *Client) CanceledJobs(ctx context.Context, queueName string, knownIDs []int) (canceledIDs []int, err error) {
-	req, err := c.client.NewJSONRequest(http.MethodPost, fmt.Sprintf("%s/canceledJobs", c.options.QueueName), executor.CanceledJobsRequest{
+	req, err := c.client.NewJSONRequest(http.MethodPost, fmt.Sprintf("%s/canceledJobs", queueName), executor.CanceledJobsRequest{
 		KnownJobIDs:  knownIDs,
 		ExecutorName: c.options.ExecutorName,
 	})
@@ -268,7 +416,11 @@ func (c *Client) CanceledJobs(ctx context.Context, queueName string, knownIDs []
 }
 
 func (c *Client) Ping(ctx context.Context, queueName string, jobIDs []int) (err error) {
-	req, err := c.client.NewJSONRequest(http.MethodPost, fmt.Sprintf("%s/heartbeat", c.options.QueueName), executor.HeartbeatRequest{
+	if c.isMultiQueue() {
+		queueName = c.options.Queues[0].Name
+	}
+
+	req, err := c.client.NewJSONRequest(http.MethodPost, fmt.Sprintf("%s/heartbeat", queueName), executor.HeartbeatRequest{
 		ExecutorName: c.options.ExecutorName,
 	})
 	if err != nil {
@@ -278,16 +430,17 @@ func (c *Client) Ping(ctx context.Context, queueName string, jobIDs []int) (err
 	return c.client.DoAndDrop(ctx, req)
 }
 
-func (c *Client) AddExecutionLogEntry(ctx context.Context, jobID int, entry internalexecutor.ExecutionLogEntry) (entryID int, err error) {
+func (c *Client) AddExecutionLogEntry(ctx context.Context, job executor.Job, entry internalexecutor.ExecutionLogEntry) (entryID int, err error) {
+	queueName := c.queueName(job)
 	ctx, _, endObservation := c.operations.addExecutionLogEntry.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
-		otlog.String("queueName", c.options.QueueName),
-		otlog.Int("jobID", jobID),
+		otlog.String("queueName", queueName),
+		otlog.Int("jobID", job.ID),
 	}})
 	defer endObservation(1, observation.Args{})
 
-	req, err := c.client.NewJSONRequest(http.MethodPost, fmt.Sprintf("%s/addExecutionLogEntry", c.options.QueueName), executor.AddExecutionLogEntryRequest{
+	req, err := c.client.NewJSONRequest(http.MethodPost, fmt.Sprintf("%s/addExecutionLogEntry", queueName), executor.AddExecutionLogEntryRequest{
 		ExecutorName:      c.options.ExecutorName,
-		JobID:             jobID,
+		JobID:             job.ID,
 		ExecutionLogEntry: entry,
 	})
 	if err != nil {
@@ -298,17 +451,18 @@ func (c *Client) AddExecutionLogEntry(ctx context.Context, jobID int, entry inte
 	return entryID, err
 }
 
-func (c *Client) UpdateExecutionLogEntry(ctx context.Context, jobID, entryID int, entry internalexecutor.ExecutionLogEntry) (err error) {
+func (c *Client) UpdateExecutionLogEntry(ctx context.Context, job executor.Job, entryID int, entry internalexecutor.ExecutionLogEntry) (err error) {
+	queueName := c.queueName(job)
 	ctx, _, endObservation := c.operations.updateExecutionLogEntry.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
-		otlog.String("queueName", c.options.QueueName),
-		otlog.Int("jobID", jobID),
+		otlog.String("queueName", queueName),
+		otlog.Int("jobID", job.ID),
 		otlog.Int("entryID", entryID),
 	}})
 	defer endObservation(1, observation.Args{})
 
-	req, err := c.client.NewJSONRequest(http.MethodPost, fmt.Sprintf("%s/updateExecutionLogEntry", c.options.QueueName), executor.UpdateExecutionLogEntryRequest{
+	req, err := c.client.NewJSONRequest(http.MethodPost, fmt.Sprintf("%s/updateExecutionLogEntry", queueName), executor.UpdateExecutionLogEntryRequest{
 		ExecutorName:      c.options.ExecutorName,
-		JobID:             jobID,
+		JobID:             job.ID,
 		EntryID:           entryID,
 		ExecutionLogEntry: entry,
 	})
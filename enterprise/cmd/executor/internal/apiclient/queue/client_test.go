@@ -17,6 +17,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/apiclient"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/apiclient/queue"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
 	internalexecutor "github.com/sourcegraph/sourcegraph/internal/executor"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
@@ -98,7 +99,7 @@ func TestMarkComplete(t *testing.T) {
 	}
 
 	testRoute(t, spec, func(client *queue.Client) {
-		if marked, err := client.MarkComplete(context.Background(), 42); err != nil {
+		if marked, err := client.MarkComplete(context.Background(), executor.Job{ID: 42}); err != nil {
 			t.Fatalf("unexpected error completing job: %s", err)
 		} else if !marked {
 			t.Fatalf("expecting job to be marked")
@@ -118,7 +119,7 @@ func TestMarkCompleteBadResponse(t *testing.T) {
 	}
 
 	testRoute(t, spec, func(client *queue.Client) {
-		if marked, err := client.MarkComplete(context.Background(), 42); err == nil {
+		if marked, err := client.MarkComplete(context.Background(), executor.Job{ID: 42}); err == nil {
 			t.Fatalf("expected an error")
 		} else if marked {
 			t.Fatalf("expecting job to not be marked")
@@ -138,7 +139,7 @@ func TestMarkErrored(t *testing.T) {
 	}
 
 	testRoute(t, spec, func(client *queue.Client) {
-		if marked, err := client.MarkErrored(context.Background(), 42, "OH NO"); err != nil {
+		if marked, err := client.MarkErrored(context.Background(), executor.Job{ID: 42}, "OH NO"); err != nil {
 			t.Fatalf("unexpected error completing job: %s", err)
 		} else if !marked {
 			t.Fatalf("expecting job to be marked")
@@ -158,7 +159,7 @@ func TestMarkErroredBadResponse(t *testing.T) {
 	}
 
 	testRoute(t, spec, func(client *queue.Client) {
-		if marked, err := client.MarkErrored(context.Background(), 42, "OH NO"); err == nil {
+		if marked, err := client.MarkErrored(context.Background(), executor.Job{ID: 42}, "OH NO"); err == nil {
 			t.Fatalf("expected an error")
 		} else if marked {
 			t.Fatalf("expecting job to not be marked")
@@ -178,7 +179,7 @@ func TestMarkFailed(t *testing.T) {
 	}
 
 	testRoute(t, spec, func(client *queue.Client) {
-		if marked, err := client.MarkFailed(context.Background(), 42, "OH NO"); err != nil {
+		if marked, err := client.MarkFailed(context.Background(), executor.Job{ID: 42}, "OH NO"); err != nil {
 			t.Fatalf("unexpected error completing job: %s", err)
 		} else if !marked {
 			t.Fatalf("expecting job to be marked")
@@ -232,16 +233,16 @@ func TestHeartbeat(t *testing.T) {
 	}
 
 	testRoute(t, spec, func(client *queue.Client) {
-		unknownIDs, cancelIDs, err := client.Heartbeat(context.Background(), []int{1, 2, 3})
+		unknownIDs, cancelIDs, err := client.Heartbeat(context.Background(), []string{"1", "2", "3"})
 		if err != nil {
 			t.Fatalf("unexpected error performing heartbeat: %s", err)
 		}
 
-		if diff := cmp.Diff([]int{1}, unknownIDs); diff != "" {
+		if diff := cmp.Diff([]string{"1"}, unknownIDs); diff != "" {
 			t.Errorf("unexpected unknown ids (-want +got):\n%s", diff)
 		}
 
-		if diff := cmp.Diff([]int{1}, cancelIDs); diff != "" {
+		if diff := cmp.Diff([]string{"1"}, cancelIDs); diff != "" {
 			t.Errorf("unexpected unknown cancel ids (-want +got):\n%s", diff)
 		}
 	})
@@ -273,7 +274,7 @@ func TestHeartbeatBadResponse(t *testing.T) {
 	}
 
 	testRoute(t, spec, func(client *queue.Client) {
-		if _, _, err := client.Heartbeat(context.Background(), []int{1, 2, 3}); err == nil {
+		if _, _, err := client.Heartbeat(context.Background(), []string{"1", "2", "3"}); err == nil {
 			t.Fatalf("expected an error")
 		}
 	})
@@ -309,7 +310,7 @@ func TestAddExecutionLogEntry(t *testing.T) {
 	}
 
 	testRoute(t, spec, func(client *queue.Client) {
-		entryID, err := client.AddExecutionLogEntry(context.Background(), 42, entry)
+		entryID, err := client.AddExecutionLogEntry(context.Background(), executor.Job{ID: 42}, entry)
 		if err != nil {
 			t.Fatalf("unexpected error updating log contents: %s", err)
 		}
@@ -349,7 +350,7 @@ func TestAddExecutionLogEntryBadResponse(t *testing.T) {
 	}
 
 	testRoute(t, spec, func(client *queue.Client) {
-		if _, err := client.AddExecutionLogEntry(context.Background(), 42, entry); err == nil {
+		if _, err := client.AddExecutionLogEntry(context.Background(), executor.Job{ID: 42}, entry); err == nil {
 			t.Fatalf("expected an error")
 		}
 	})
@@ -386,7 +387,7 @@ func TestUpdateExecutionLogEntry(t *testing.T) {
 	}
 
 	testRoute(t, spec, func(client *queue.Client) {
-		if err := client.UpdateExecutionLogEntry(context.Background(), 42, 99, entry); err != nil {
+		if err := client.UpdateExecutionLogEntry(context.Background(), executor.Job{ID: 42}, 99, entry); err != nil {
 			t.Fatalf("unexpected error updating log contents: %s", err)
 		}
 	})
@@ -423,12 +424,111 @@ func TestUpdateExecutionLogEntryBadResponse(t *testing.T) {
 	}
 
 	testRoute(t, spec, func(client *queue.Client) {
-		if err := client.UpdateExecutionLogEntry(context.Background(), 42, 99, entry); err == nil {
+		if err := client.UpdateExecutionLogEntry(context.Background(), executor.Job{ID: 42}, 99, entry); err == nil {
 			t.Fatalf("expected an error")
 		}
 	})
 }
 
+func TestMultiQueue(t *testing.T) {
+	type request struct {
+		path    string
+		payload string
+	}
+	var requests []request
+	// Both queues hand out a job with the same ID.
+	dequeueResponses := []string{
+		`{"version": 2, "id": 42, "queue": "codeintel"}`,
+		`{"version": 2, "id": 42, "queue": "batches"}`,
+	}
+	responses := map[string]string{
+		"/.executors/queue/codeintel/heartbeat": `{"knownIDs": [42], "cancelIDs": []}`,
+		"/.executors/queue/batches/heartbeat":   `{"knownIDs": [42], "cancelIDs": [42]}`,
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("unexpected error reading payload: %s", err)
+		}
+		requests = append(requests, request{path: r.URL.Path, payload: string(content)})
+
+		if r.URL.Path == "/.executors/queue/dequeue" && len(dequeueResponses) > 0 {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(dequeueResponses[0]))
+			dequeueResponses = dequeueResponses[1:]
+		} else if response, ok := responses[r.URL.Path]; ok {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(response))
+		} else {
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer ts.Close()
+
+	options := queue.Options{
+		ExecutorName: "deadbeef",
+		Queues: []queue.WeightedQueue{
+			{Name: "codeintel", Weight: 3, MaxNumJobs: 1},
+			{Name: "batches", Weight: 1},
+		},
+		BaseClientOptions: apiclient.BaseClientOptions{
+			EndpointOptions: apiclient.EndpointOptions{
+				URL:        ts.URL,
+				PathPrefix: "/.executors/queue",
+				Token:      "hunter2",
+			},
+		},
+	}
+	client, err := queue.New(&observation.TestContext, options, prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) { return nil, nil }))
+	require.NoError(t, err)
+
+	codeintelJob, dequeued, err := client.Dequeue(context.Background(), "worker", nil)
+	require.NoError(t, err)
+	require.True(t, dequeued)
+	require.Equal(t, 42, codeintelJob.ID)
+
+	// The codeintel queue reached its maximum number of jobs.
+	batchesJob, dequeued, err := client.Dequeue(context.Background(), "worker", nil)
+	require.NoError(t, err)
+	require.True(t, dequeued)
+	require.Equal(t, 42, batchesJob.ID)
+	require.NotEqual(t, codeintelJob.RecordUID(), batchesJob.RecordUID())
+
+	knownIDs, cancelIDs, err := client.Heartbeat(context.Background(), []string{codeintelJob.RecordUID(), batchesJob.RecordUID()})
+	require.NoError(t, err)
+	require.Equal(t, []string{"42-codeintel", "42-batches"}, knownIDs)
+	require.Equal(t, []string{"42-batches"}, cancelIDs)
+
+	_, err = client.MarkComplete(context.Background(), codeintelJob)
+	require.NoError(t, err)
+
+	// The codeintel job is not running anymore.
+	_, dequeued, err = client.Dequeue(context.Background(), "worker", nil)
+	require.NoError(t, err)
+	require.False(t, dequeued)
+
+	want := []request{
+		{"/.executors/queue/dequeue", `{"executorName": "deadbeef", "version": "0.0.0+dev", "queues": [{"name": "codeintel", "weight": 3}, {"name": "batches", "weight": 1}]}`},
+		{"/.executors/queue/dequeue", `{"executorName": "deadbeef", "version": "0.0.0+dev", "queues": [{"name": "batches", "weight": 1}], "runningJobIds": {"codeintel": [42]}}`},
+		// Heartbeats carry the telemetry options, only the paths are compared.
+		{"/.executors/queue/codeintel/heartbeat", ""},
+		{"/.executors/queue/batches/heartbeat", ""},
+		{"/.executors/queue/codeintel/markComplete", `{"executorName": "deadbeef", "jobId": 42}`},
+		{"/.executors/queue/dequeue", `{"executorName": "deadbeef", "version": "0.0.0+dev", "queues": [{"name": "codeintel", "weight": 3}, {"name": "batches", "weight": 1}], "runningJobIds": {"batches": [42]}}`},
+	}
+	require.Len(t, requests, len(want))
+	for i := range want {
+		require.Equal(t, want[i].path, requests[i].path)
+		if want[i].payload == "" {
+			continue
+		}
+		if diff := cmp.Diff(normalizeJSON([]byte(want[i].payload)), normalizeJSON([]byte(requests[i].payload))); diff != "" {
+			t.Errorf("unexpected payload of request %d (-want +got):\n%s", i, diff)
+		}
+	}
+}
+
 type routeSpec struct {
 	expectedMethod   string
 	expectedPath     string
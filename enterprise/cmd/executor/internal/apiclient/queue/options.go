@@ -11,6 +11,10 @@ type Options struct {
 	// QueueName is the name of the queue being processed.
 	QueueName string
 
+	// Queues are the queues being processed by an executor listening on several
+	// queues. If set, QueueName is ignored.
+	Queues []WeightedQueue
+
 	// BaseClientOptions are the underlying HTTP client options.
 	BaseClientOptions apiclient.BaseClientOptions
 
@@ -23,6 +27,19 @@ type Options struct {
 	ResourceOptions ResourceOptions
 }
 
+type WeightedQueue struct {
+	// Name is the name of the queue.
+	Name string
+
+	// Weight determines how likely a job is dequeued from this queue compared to
+	// the other queues with available jobs.
+	Weight int
+
+	// MaxNumJobs is the maximum number of jobs of this queue processed at once. A
+	// value of zero sets no limit apart from the total number of handlers.
+	MaxNumJobs int
+}
+
 type ResourceOptions struct {
 	// NumCPUs is the number of virtual CPUs a job can safely utilize.
 	NumCPUs int
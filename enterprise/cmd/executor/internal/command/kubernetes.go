@@ -0,0 +1,416 @@
+package command
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/sourcegraph/log"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// KubernetesManagedByLabel is set on all pods created by executors.
+	KubernetesManagedByLabel = "app.kubernetes.io/managed-by"
+	// KubernetesManagedByValue is the value of KubernetesManagedByLabel on all pods
+	// created by executors.
+	KubernetesManagedByValue = "sourcegraph-executor"
+	// KubernetesJobLabel is set on all pods created by executors to the name of the
+	// job (see Options.ExecutorName) the pod runs a step of, shortened by
+	// kubernetesName if necessary.
+	KubernetesJobLabel = "executor.sourcegraph.com/job"
+	// KubernetesJobAnnotation is set on all pods created by executors to the full
+	// name of the job the pod runs a step of.
+	KubernetesJobAnnotation = "executor.sourcegraph.com/job-name"
+
+	kubernetesContainerName = "step"
+	kubernetesVolumeName    = "workspace"
+
+	// maxKubernetesNameLength is the maximum length of pod names and label values.
+	maxKubernetesNameLength = 63
+)
+
+// NewKubernetesClientset creates a Kubernetes clientset from the kubeconfig file at
+// configPath. If configPath is empty, the in-cluster configuration is used.
+func NewKubernetesClientset(configPath string) (kubernetes.Interface, error) {
+	var (
+		config *rest.Config
+		err    error
+	)
+	if configPath == "" {
+		config, err = rest.InClusterConfig()
+	} else {
+		config, err = clientcmd.BuildConfigFromFlags("", configPath)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load Kubernetes configuration")
+	}
+
+	return kubernetes.NewForConfig(config)
+}
+
+// NewKubernetesRunner creates a runner which runs each command as a pod in a
+// Kubernetes cluster. The workspace directory dir must be located on the persistent
+// volume claim mounted at KubernetesOptions.WorkspaceMountPath, so that it can be
+// mounted into the pods.
+func NewKubernetesRunner(client kubernetes.Interface, dir string, logger Logger, options Options) Runner {
+	return &kubernetesRunner{
+		client:       client,
+		dir:          dir,
+		logger:       log.Scoped("kubernetes-runner", ""),
+		cmdLogger:    logger,
+		options:      options,
+		pollInterval: time.Second,
+	}
+}
+
+type kubernetesRunner struct {
+	client    kubernetes.Interface
+	dir       string
+	logger    log.Logger
+	cmdLogger Logger
+	options   Options
+	// subPath is the path of the workspace relative to the root of the volume.
+	subPath string
+	// numPods is the number of pods created so far, used to name pods uniquely.
+	numPods      int
+	pollInterval time.Duration
+}
+
+var _ Runner = &kubernetesRunner{}
+
+func (r *kubernetesRunner) Setup(ctx context.Context) error {
+	subPath, err := filepath.Rel(r.options.KubernetesOptions.WorkspaceMountPath, r.dir)
+	if err != nil || subPath == ".." || strings.HasPrefix(subPath, "../") {
+		return errors.Newf("workspace %q is not located in %q", r.dir, r.options.KubernetesOptions.WorkspaceMountPath)
+	}
+	r.subPath = subPath
+	return nil
+}
+
+// Teardown deletes all pods of the job which still exist, e.g. because deleting
+// them after the command finished failed.
+func (r *kubernetesRunner) Teardown(ctx context.Context) error {
+	pods, err := r.pods().List(ctx, metav1.ListOptions{
+		LabelSelector: labels.Set{KubernetesJobLabel: kubernetesName(r.options.ExecutorName)}.String(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list pods")
+	}
+
+	for _, pod := range pods.Items {
+		if deleteErr := r.deletePod(ctx, pod.Name); deleteErr != nil {
+			err = errors.Append(err, deleteErr)
+		}
+	}
+	return err
+}
+
+func (r *kubernetesRunner) Run(ctx context.Context, spec CommandSpec) (err error) {
+	// Commands without an image are run on the host, like with the docker runner.
+	if spec.Image == "" {
+		return runCommand(ctx, formatRawOrDockerCommand(spec, r.dir, r.options, ""), r.cmdLogger)
+	}
+
+	ctx, _, endObservation := spec.Operation.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	pod, err := r.newPod(spec)
+	if err != nil {
+		return err
+	}
+
+	handle := r.cmdLogger.Log(spec.Key, pod.Spec.Containers[0].Command)
+	defer handle.Close()
+
+	// The log entry is finalized as failed unless the pod finishes.
+	exitCode := 1
+	defer func() { handle.Finalize(exitCode) }()
+
+	// The pod may have been created even if creating it returned an error, e.g.
+	// because the request timed out, so it is deleted in any case.
+	name := pod.Name
+	defer func() {
+		// Delete the pod outside of the job context, so we clean up after
+		// cancellations and timeouts as well.
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if deleteErr := r.deletePod(ctx, name); deleteErr != nil && !apierrors.IsNotFound(deleteErr) {
+			r.logger.Error("Failed to delete pod", log.String("name", name), log.Error(deleteErr))
+		}
+	}()
+
+	if _, err := r.pods().Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+		return errors.Wrap(err, "failed to create pod")
+	}
+
+	if _, err := r.waitForPod(ctx, name, podStarted); err != nil {
+		return err
+	}
+
+	if err := r.readLogs(ctx, name, handle); err != nil {
+		return err
+	}
+
+	pod, err = r.waitForPod(ctx, name, podFinished)
+	if err != nil {
+		return err
+	}
+
+	exitCode = podExitCode(pod)
+	if exitCode != 0 {
+		return errors.New("command failed")
+	}
+	return nil
+}
+
+// newPod returns the pod running the given command. The pod mounts the workspace
+// at /data, like the docker runner does.
+func (r *kubernetesRunner) newPod(spec CommandSpec) (*corev1.Pod, error) {
+	resources, err := kubernetesResources(r.options.ResourceOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	env := make([]corev1.EnvVar, 0, len(spec.Env))
+	for _, e := range spec.Env {
+		name, value, _ := strings.Cut(e, "=")
+		env = append(env, corev1.EnvVar{Name: name, Value: value})
+	}
+
+	r.numPods++
+	name := kubernetesName(fmt.Sprintf("%s-%d", r.options.ExecutorName, r.numPods))
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return nil, errors.Newf("invalid pod name %q: %s", name, strings.Join(errs, ", "))
+	}
+	jobLabel := kubernetesName(r.options.ExecutorName)
+	if errs := validation.IsValidLabelValue(jobLabel); len(errs) > 0 {
+		return nil, errors.Newf("invalid job label %q: %s", jobLabel, strings.Join(errs, ", "))
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: r.options.KubernetesOptions.Namespace,
+			Labels: map[string]string{
+				KubernetesManagedByLabel: KubernetesManagedByValue,
+				KubernetesJobLabel:       jobLabel,
+			},
+			Annotations: map[string]string{
+				KubernetesJobAnnotation: r.options.ExecutorName,
+			},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{{
+				Name:       kubernetesContainerName,
+				Image:      spec.Image,
+				Command:    []string{"/bin/sh", filepath.Join("/data", ScriptsPath, spec.ScriptPath)},
+				WorkingDir: filepath.Join("/data", spec.Dir),
+				Env:        env,
+				Resources:  resources,
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      kubernetesVolumeName,
+					MountPath: "/data",
+					SubPath:   r.subPath,
+				}},
+			}},
+			Volumes: []corev1.Volume{{
+				Name: kubernetesVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: r.options.KubernetesOptions.PersistentVolumeClaimName,
+					},
+				},
+			}},
+		},
+	}, nil
+}
+
+// kubernetesName returns name if it can be used as a pod name or label value.
+// Longer names are truncated and end with a hash of the full name instead, so
+// that they remain unique.
+func kubernetesName(name string) string {
+	if len(name) <= maxKubernetesNameLength {
+		return name
+	}
+	sum := sha256.Sum256([]byte(name))
+	hash := hex.EncodeToString(sum[:])[:10]
+	// Names must begin and end with an alphanumeric character.
+	prefix := strings.TrimRight(name[:maxKubernetesNameLength-len(hash)-1], "-.")
+	return prefix + "-" + hash
+}
+
+// kubernetesResources returns the resource requirements of job pods. Requests and
+// limits are identical, so that jobs get the same resources as in docker.
+func kubernetesResources(options ResourceOptions) (corev1.ResourceRequirements, error) {
+	resources := corev1.ResourceList{}
+	if options.NumCPUs != 0 {
+		resources[corev1.ResourceCPU] = *resource.NewQuantity(int64(options.NumCPUs), resource.DecimalSI)
+	}
+	if options.Memory != "0" && options.Memory != "" {
+		memory, err := datasize.ParseString(options.Memory)
+		if err != nil {
+			return corev1.ResourceRequirements{}, errors.Wrapf(err, "invalid memory %q", options.Memory)
+		}
+		resources[corev1.ResourceMemory] = *resource.NewQuantity(int64(memory.Bytes()), resource.BinarySI)
+	}
+
+	if len(resources) == 0 {
+		return corev1.ResourceRequirements{}, nil
+	}
+	return corev1.ResourceRequirements{Requests: resources, Limits: resources}, nil
+}
+
+// readLogs streams the logs of the pod into handle until the pod's container
+// terminates. Kubernetes does not separate the output and error streams, so all
+// output is logged as stdout.
+func (r *kubernetesRunner) readLogs(ctx context.Context, name string, handle LogEntry) error {
+	stream, err := r.pods().GetLogs(name, &corev1.PodLogOptions{
+		Container: kubernetesContainerName,
+		Follow:    true,
+	}).Stream(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to stream pod logs")
+	}
+	defer stream.Close()
+
+	scanner := bufio.NewScanner(stream)
+	// Allocate an initial buffer of 4k, and allow tokens of up to 100M like we do
+	// for commands run on the host.
+	scanner.Buffer(make([]byte, 4*1024), 100*1024*1024)
+	for scanner.Scan() {
+		if _, err := fmt.Fprintf(handle, "stdout: %s\n", scanner.Text()); err != nil {
+			return err
+		}
+	}
+	return errors.Wrap(scanner.Err(), "reading pod logs")
+}
+
+// waitForPod polls the pod until done returns true or an error.
+func (r *kubernetesRunner) waitForPod(ctx context.Context, name string, done func(*corev1.Pod) (bool, error)) (*corev1.Pod, error) {
+	for {
+		pod, err := r.pods().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get pod")
+		}
+		if ok, err := done(pod); err != nil || ok {
+			return pod, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(r.pollInterval):
+		}
+	}
+}
+
+func (r *kubernetesRunner) deletePod(ctx context.Context, name string) error {
+	return DeleteKubernetesPod(ctx, r.client, r.options.KubernetesOptions.Namespace, name)
+}
+
+func (r *kubernetesRunner) pods() typedcorev1.PodInterface {
+	return r.client.CoreV1().Pods(r.options.KubernetesOptions.Namespace)
+}
+
+// DeleteKubernetesPod deletes the pod with the given name without waiting for a
+// graceful shutdown.
+func DeleteKubernetesPod(ctx context.Context, client kubernetes.Interface, namespace, name string) error {
+	gracePeriod := int64(0)
+	propagation := metav1.DeletePropagationBackground
+	return client.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{
+		GracePeriodSeconds: &gracePeriod,
+		PropagationPolicy:  &propagation,
+	})
+}
+
+// podFailedReasons are the reasons for which a container can be waiting that
+// prevent it from ever starting.
+var podFailedReasons = map[string]struct{}{
+	"ErrImagePull":               {},
+	"ImagePullBackOff":           {},
+	"InvalidImageName":           {},
+	"CreateContainerConfigError": {},
+	"CreateContainerError":       {},
+}
+
+// podStarted returns true if the container of the pod started running. It returns
+// an error if the container will never start.
+func podStarted(pod *corev1.Pod) (bool, error) {
+	switch pod.Status.Phase {
+	case corev1.PodRunning, corev1.PodSucceeded, corev1.PodFailed:
+		return true, nil
+	}
+
+	for _, status := range pod.Status.ContainerStatuses {
+		if waiting := status.State.Waiting; waiting != nil {
+			if _, ok := podFailedReasons[waiting.Reason]; ok {
+				return false, errors.Newf("pod %s failed to start: %s: %s", pod.Name, waiting.Reason, waiting.Message)
+			}
+		}
+	}
+	return false, nil
+}
+
+// podFinished returns true if the pod ran to completion.
+func podFinished(pod *corev1.Pod) (bool, error) {
+	return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed, nil
+}
+
+// podExitCode returns the exit code of the container of a finished pod.
+func podExitCode(pod *corev1.Pod) int {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == kubernetesContainerName && status.State.Terminated != nil {
+			return int(status.State.Terminated.ExitCode)
+		}
+	}
+	if pod.Status.Phase == corev1.PodSucceeded {
+		return 0
+	}
+	return 1
+}
+
+// KubernetesPodsByJob returns the names of the pods created by executors for jobs
+// whose name begins with the given prefix, keyed by the name of the job.
+func KubernetesPodsByJob(ctx context.Context, client kubernetes.Interface, namespace, prefix string) (map[string][]string, error) {
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.Set{KubernetesManagedByLabel: KubernetesManagedByValue}.String(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list pods")
+	}
+
+	podsByJob := map[string][]string{}
+	for _, pod := range pods.Items {
+		job, ok := pod.Annotations[KubernetesJobAnnotation]
+		if !ok {
+			// Pods created by older executors are only labeled with the job.
+			job = pod.Labels[KubernetesJobLabel]
+		}
+		if !strings.HasPrefix(job, prefix+"-") {
+			continue
+		}
+		podsByJob[job] = append(podsByJob[job], pod.Name)
+	}
+	return podsByJob, nil
+}
@@ -0,0 +1,341 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestKubernetesRunner(t *testing.T) {
+	options := Options{
+		ExecutorName: "executor-1234",
+		KubernetesOptions: KubernetesOptions{
+			Enabled:                   true,
+			Namespace:                 "executors",
+			PersistentVolumeClaimName: "executor-workspaces",
+			WorkspaceMountPath:        "/workspaces",
+		},
+		ResourceOptions: ResourceOptions{
+			NumCPUs: 4,
+			Memory:  "12G",
+		},
+	}
+	spec := CommandSpec{
+		Key:        "step.docker.0",
+		Image:      "alpine:latest",
+		ScriptPath: "step.0.sh",
+		Dir:        "subdir",
+		Env:        []string{"FOO=bar=baz"},
+		Operation:  makeTestOperation(),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		client := newFakeKubernetesClient(corev1.PodSucceeded, 0, "")
+		var logs bytes.Buffer
+		logger, entry := newKubernetesTestLogger(&logs)
+
+		runner := newTestKubernetesRunner(client, "/workspaces/workspace-1", logger, options)
+		if err := runner.Setup(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		var created *corev1.Pod
+		client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			created = action.(k8stesting.CreateAction).GetObject().(*corev1.Pod).DeepCopy()
+			return false, nil, nil
+		})
+
+		if err := runner.Run(context.Background(), spec); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		resources := corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("4"),
+			corev1.ResourceMemory: resource.MustParse("12Gi"),
+		}
+		want := corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{{
+				Name:       "step",
+				Image:      "alpine:latest",
+				Command:    []string{"/bin/sh", "/data/.sourcegraph-executor/step.0.sh"},
+				WorkingDir: "/data/subdir",
+				Env:        []corev1.EnvVar{{Name: "FOO", Value: "bar=baz"}},
+				Resources:  corev1.ResourceRequirements{Requests: resources, Limits: resources},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "workspace",
+					MountPath: "/data",
+					SubPath:   "workspace-1",
+				}},
+			}},
+			Volumes: []corev1.Volume{{
+				Name: "workspace",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "executor-workspaces"},
+				},
+			}},
+		}
+		if created == nil {
+			t.Fatal("expected pod to be created")
+		}
+		if created.Name != "executor-1234-1" || created.Namespace != "executors" {
+			t.Errorf("unexpected pod %s/%s", created.Namespace, created.Name)
+		}
+		if diff := cmp.Diff(map[string]string{KubernetesManagedByLabel: KubernetesManagedByValue, KubernetesJobLabel: "executor-1234"}, created.Labels); diff != "" {
+			t.Errorf("unexpected labels (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff(map[string]string{KubernetesJobAnnotation: "executor-1234"}, created.Annotations); diff != "" {
+			t.Errorf("unexpected annotations (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff(want, created.Spec); diff != "" {
+			t.Errorf("unexpected pod spec (-want +got):\n%s", diff)
+		}
+
+		if got := logs.String(); got != "stdout: fake logs\n" {
+			t.Errorf("unexpected logs %q", got)
+		}
+		if history := entry.FinalizeFunc.History(); len(history) != 1 || history[0].Arg0 != 0 {
+			t.Errorf("expected entry to be finalized with exit code 0, got %v", history)
+		}
+
+		pods, err := client.CoreV1().Pods("executors").List(context.Background(), metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(pods.Items) != 0 {
+			t.Errorf("expected pod to be deleted, got %d pods", len(pods.Items))
+		}
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		client := newFakeKubernetesClient(corev1.PodFailed, 3, "")
+		logger, entry := newKubernetesTestLogger(&bytes.Buffer{})
+
+		runner := newTestKubernetesRunner(client, "/workspaces/workspace-1", logger, options)
+		if err := runner.Setup(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := runner.Run(context.Background(), spec); err == nil || err.Error() != "command failed" {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if history := entry.FinalizeFunc.History(); len(history) != 1 || history[0].Arg0 != 3 {
+			t.Errorf("expected entry to be finalized with exit code 3, got %v", history)
+		}
+	})
+
+	t.Run("image pull failure", func(t *testing.T) {
+		client := newFakeKubernetesClient(corev1.PodPending, 0, "ErrImagePull")
+		logger, entry := newKubernetesTestLogger(&bytes.Buffer{})
+
+		runner := newTestKubernetesRunner(client, "/workspaces/workspace-1", logger, options)
+		if err := runner.Setup(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := runner.Run(context.Background(), spec); err == nil || !strings.Contains(err.Error(), "ErrImagePull") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if history := entry.FinalizeFunc.History(); len(history) != 1 || history[0].Arg0 != 1 {
+			t.Errorf("expected entry to be finalized with exit code 1, got %v", history)
+		}
+
+		pods, err := client.CoreV1().Pods("executors").List(context.Background(), metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(pods.Items) != 0 {
+			t.Errorf("expected pod to be deleted, got %d pods", len(pods.Items))
+		}
+	})
+
+	t.Run("long executor name", func(t *testing.T) {
+		client := newFakeKubernetesClient(corev1.PodSucceeded, 0, "")
+		logger, _ := newKubernetesTestLogger(&bytes.Buffer{})
+
+		longOptions := options
+		longOptions.ExecutorName = "sourcegraph-executors-production-us-central1-8a1c4f0e-2b8d-4e5f-9a6b-7c3d2e1f0a9b"
+		runner := newTestKubernetesRunner(client, "/workspaces/workspace-1", logger, longOptions)
+		if err := runner.Setup(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		var created []*corev1.Pod
+		client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			created = append(created, action.(k8stesting.CreateAction).GetObject().(*corev1.Pod).DeepCopy())
+			return false, nil, nil
+		})
+
+		for i := 0; i < 2; i++ {
+			if err := runner.Run(context.Background(), spec); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		}
+
+		if len(created) != 2 {
+			t.Fatalf("expected 2 pods to be created, got %d", len(created))
+		}
+		if created[0].Name == created[1].Name {
+			t.Errorf("expected unique pod names, got %q twice", created[0].Name)
+		}
+		for _, pod := range created {
+			if len(pod.Name) > 63 || !strings.HasPrefix(pod.Name, "sourcegraph-executors-production-") {
+				t.Errorf("unexpected pod name %q", pod.Name)
+			}
+			if label := pod.Labels[KubernetesJobLabel]; len(label) > 63 {
+				t.Errorf("job label %q is too long", label)
+			}
+			if job := pod.Annotations[KubernetesJobAnnotation]; job != longOptions.ExecutorName {
+				t.Errorf("unexpected job annotation %q", job)
+			}
+		}
+	})
+
+	t.Run("workspace outside of volume", func(t *testing.T) {
+		runner := newTestKubernetesRunner(fake.NewSimpleClientset(), "/tmp/workspace-1", NewMockLogger(), options)
+		if err := runner.Setup(context.Background()); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestKubernetesRunnerTeardown(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		kubernetesTestPod("executor-1234-1", "executor-1234"),
+		kubernetesTestPod("executor-5678-1", "executor-5678"),
+	)
+	runner := newTestKubernetesRunner(client, "/workspaces/workspace-1", NewMockLogger(), Options{
+		ExecutorName:      "executor-1234",
+		KubernetesOptions: KubernetesOptions{Namespace: "executors"},
+	})
+
+	if err := runner.Teardown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	podsByJob, err := KubernetesPodsByJob(context.Background(), client, "executors", "executor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string][]string{"executor-5678": {"executor-5678-1"}}, podsByJob); diff != "" {
+		t.Errorf("unexpected pods (-want +got):\n%s", diff)
+	}
+}
+
+func TestKubernetesPodsByJob(t *testing.T) {
+	unmanaged := kubernetesTestPod("other", "executor-1234")
+	delete(unmanaged.Labels, KubernetesManagedByLabel)
+
+	// Pods of jobs with long names are labeled with a shortened name.
+	longJob := "executor-" + strings.Repeat("a", 60)
+	long := kubernetesTestPod(kubernetesName(longJob+"-1"), kubernetesName(longJob))
+	long.Annotations = map[string]string{KubernetesJobAnnotation: longJob}
+
+	client := fake.NewSimpleClientset(
+		kubernetesTestPod("executor-1234-1", "executor-1234"),
+		kubernetesTestPod("executor-1234-2", "executor-1234"),
+		kubernetesTestPod("executor-5678-1", "executor-5678"),
+		kubernetesTestPod("dev-1234-1", "dev-1234"),
+		long,
+		unmanaged,
+	)
+
+	podsByJob, err := KubernetesPodsByJob(context.Background(), client, "executors", "executor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{
+		"executor-1234": {"executor-1234-1", "executor-1234-2"},
+		"executor-5678": {"executor-5678-1"},
+		longJob:         {long.Name},
+	}
+	if diff := cmp.Diff(want, podsByJob); diff != "" {
+		t.Errorf("unexpected pods (-want +got):\n%s", diff)
+	}
+}
+
+func TestKubernetesName(t *testing.T) {
+	if name := kubernetesName("executor-1234-1"); name != "executor-1234-1" {
+		t.Errorf("unexpected name %q", name)
+	}
+
+	long := strings.Repeat("a", 51) + "-" + strings.Repeat("b", 20)
+	name := kubernetesName(long)
+	if len(name) > 63 {
+		t.Errorf("name %q is longer than 63 characters", name)
+	}
+	// The truncated name must not end in a dash before the hash is appended.
+	if strings.Contains(name, "--") {
+		t.Errorf("unexpected name %q", name)
+	}
+	if other := kubernetesName(long + "c"); other == name {
+		t.Errorf("expected different names for different long names, got %q", name)
+	}
+}
+
+func newTestKubernetesRunner(client *fake.Clientset, dir string, logger Logger, options Options) *kubernetesRunner {
+	runner := NewKubernetesRunner(client, dir, logger, options).(*kubernetesRunner)
+	runner.pollInterval = time.Millisecond
+	return runner
+}
+
+// newFakeKubernetesClient returns a fake client in which every pod immediately has
+// the given phase. If waitingReason is set, the container is waiting for that reason,
+// otherwise it terminated with the given exit code.
+func newFakeKubernetesClient(phase corev1.PodPhase, exitCode int32, waitingReason string) *fake.Clientset {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("get", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		get, ok := action.(k8stesting.GetAction)
+		if !ok {
+			// Requests for pod logs are get actions of the log subresource.
+			return false, nil, nil
+		}
+		obj, err := client.Tracker().Get(corev1.SchemeGroupVersion.WithResource("pods"), get.GetNamespace(), get.GetName())
+		if err != nil {
+			return true, nil, err
+		}
+
+		pod := obj.(*corev1.Pod).DeepCopy()
+		pod.Status.Phase = phase
+		status := corev1.ContainerStatus{Name: "step"}
+		if waitingReason != "" {
+			status.State.Waiting = &corev1.ContainerStateWaiting{Reason: waitingReason}
+		} else {
+			status.State.Terminated = &corev1.ContainerStateTerminated{ExitCode: exitCode}
+		}
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{status}
+		return true, pod, nil
+	})
+	return client
+}
+
+func newKubernetesTestLogger(logs *bytes.Buffer) (*MockLogger, *MockLogEntry) {
+	entry := NewMockLogEntry()
+	entry.WriteFunc.SetDefaultHook(logs.Write)
+	logger := NewMockLogger()
+	logger.LogFunc.SetDefaultReturn(entry)
+	return logger, entry
+}
+
+func kubernetesTestPod(name, job string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "executors",
+			Labels: map[string]string{
+				KubernetesManagedByLabel: KubernetesManagedByValue,
+				KubernetesJobLabel:       job,
+			},
+		},
+	}
+}
@@ -13,13 +13,13 @@ func TestLogger(t *testing.T) {
 	s := NewMockExecutionLogEntryStore()
 
 	doneAdding := make(chan struct{})
-	s.AddExecutionLogEntryFunc.SetDefaultHook(func(_ context.Context, _ int, _ internalexecutor.ExecutionLogEntry) (int, error) {
+	s.AddExecutionLogEntryFunc.SetDefaultHook(func(_ context.Context, _ executor.Job, _ internalexecutor.ExecutionLogEntry) (int, error) {
 		doneAdding <- struct{}{}
 		return 1, nil
 	})
 
 	job := executor.Job{}
-	l := NewLogger(s, job, 1, map[string]string{})
+	l := NewLogger(s, job, map[string]string{})
 
 	e := l.Log("the_key", []string{"cmd", "arg1"})
 
@@ -55,7 +55,7 @@ func TestLogger(t *testing.T) {
 func TestLogger_Failure(t *testing.T) {
 	s := NewMockExecutionLogEntryStore()
 	doneAdding := make(chan struct{})
-	s.AddExecutionLogEntryFunc.SetDefaultHook(func(_ context.Context, _ int, _ internalexecutor.ExecutionLogEntry) (int, error) {
+	s.AddExecutionLogEntryFunc.SetDefaultHook(func(_ context.Context, _ executor.Job, _ internalexecutor.ExecutionLogEntry) (int, error) {
 		doneAdding <- struct{}{}
 		return 1, nil
 	})
@@ -64,7 +64,7 @@ func TestLogger_Failure(t *testing.T) {
 	s.UpdateExecutionLogEntryFunc.SetDefaultReturn(errors.New("failure!!"))
 
 	job := executor.Job{}
-	l := NewLogger(s, job, 1, map[string]string{})
+	l := NewLogger(s, job, map[string]string{})
 
 	e := l.Log("the_key", []string{"cmd", "arg1"})
 
@@ -51,6 +51,9 @@ type Options struct {
 	// FirecrackerOptions configures the behavior of Firecracker virtual machine creation.
 	FirecrackerOptions FirecrackerOptions
 
+	// KubernetesOptions configures the behavior of Kubernetes pod creation.
+	KubernetesOptions KubernetesOptions
+
 	// ResourceOptions configures the resource limits of docker container and Firecracker
 	// virtual machines running on the executor.
 	ResourceOptions ResourceOptions
@@ -91,6 +94,26 @@ type FirecrackerOptions struct {
 	DockerRegistryMirrorURLs []string
 }
 
+type KubernetesOptions struct {
+	// Enabled determines if commands will be run in Kubernetes pods.
+	Enabled bool
+
+	// ConfigPath is the path to the kubeconfig file used to access the cluster. If
+	// empty, the in-cluster configuration is used.
+	ConfigPath string
+
+	// Namespace is the namespace in which pods are created.
+	Namespace string
+
+	// PersistentVolumeClaimName is the name of the persistent volume claim shared by
+	// the executor and the pods running the job steps.
+	PersistentVolumeClaimName string
+
+	// WorkspaceMountPath is the path at which the persistent volume claim is mounted
+	// in the executor container. Workspaces are created in this directory.
+	WorkspaceMountPath string
+}
+
 type ResourceOptions struct {
 	// NumCPUs is the number of virtual CPUs a container or VM can use.
 	NumCPUs int
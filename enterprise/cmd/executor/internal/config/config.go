@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"runtime"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/c2h5oh/datasize"
@@ -23,6 +24,7 @@ type Config struct {
 	FrontendURL                    string
 	FrontendAuthorizationToken     string
 	QueueName                      string
+	Queues                         []WeightedQueue
 	QueuePollInterval              time.Duration
 	MaximumNumJobs                 int
 	FirecrackerImage               string
@@ -33,6 +35,11 @@ type Config struct {
 	KeepWorkspaces                 bool
 	DockerHostMountPath            string
 	UseFirecracker                 bool
+	UseKubernetes                  bool
+	KubernetesConfigPath           string
+	KubernetesNamespace            string
+	KubernetesPVCName              string
+	KubernetesWorkspaceMountPath   string
 	JobNumCPUs                     int
 	JobMemory                      string
 	FirecrackerDiskSpace           string
@@ -49,6 +56,15 @@ type Config struct {
 	DockerAuthConfig               executor.DockerAuthConfig
 	dockerAuthConfigStr            string
 	dockerAuthConfigUnmarshalError error
+	queuesStr                      string
+	queuesParseError               error
+}
+
+// WeightedQueue is a queue processed by an executor listening on several queues.
+type WeightedQueue struct {
+	Name       string
+	Weight     int
+	MaxNumJobs int
 }
 
 func (c *Config) Load() {
@@ -60,9 +76,15 @@ func (c *Config) Load() {
 		c.FrontendAuthorizationToken = confdefaults.SingleProgramInMemoryExecutorPassword
 	}
 	c.QueueName = c.Get("EXECUTOR_QUEUE_NAME", "", "The name of the queue to listen to.")
+	c.queuesStr = c.GetOptional("EXECUTOR_QUEUE_NAMES", "A comma-separated list of queues to listen to instead of EXECUTOR_QUEUE_NAME. Each entry has the form name[:weight[:maxNumJobs]], where the weight (default 1) sets how often the queue is picked relative to the others, and maxNumJobs (default unlimited) caps the number of its jobs that run at once.")
 	c.QueuePollInterval = c.GetInterval("EXECUTOR_QUEUE_POLL_INTERVAL", "1s", "Interval between dequeue requests.")
 	c.MaximumNumJobs = c.GetInt("EXECUTOR_MAXIMUM_NUM_JOBS", "1", "Number of virtual machines or containers that can be running at once.")
 	c.UseFirecracker = c.GetBool("EXECUTOR_USE_FIRECRACKER", strconv.FormatBool(runtime.GOOS == "linux"), "Whether to isolate commands in virtual machines. Requires ignite and firecracker. Linux hosts only.")
+	c.UseKubernetes = c.GetBool("EXECUTOR_USE_KUBERNETES", "false", "Whether to run commands as pods in a Kubernetes cluster. Cannot be combined with EXECUTOR_USE_FIRECRACKER.")
+	c.KubernetesConfigPath = c.GetOptional("EXECUTOR_KUBERNETES_CONFIG_PATH", "The path to a kubeconfig file used to connect to the Kubernetes cluster. If unset, the in-cluster configuration of the executor pod is used.")
+	c.KubernetesNamespace = c.Get("EXECUTOR_KUBERNETES_NAMESPACE", "default", "The namespace in which job pods are created. Executors sharing a namespace must use distinct values of EXECUTOR_VM_PREFIX.")
+	c.KubernetesPVCName = c.GetOptional("EXECUTOR_KUBERNETES_PERSISTENT_VOLUME_CLAIM_NAME", "The name of the persistent volume claim holding the job workspaces, which must also be mounted into the executor.")
+	c.KubernetesWorkspaceMountPath = c.Get("EXECUTOR_KUBERNETES_WORKSPACE_MOUNT_PATH", "/data", "The path at which the persistent volume claim holding the job workspaces is mounted into the executor.")
 	c.FirecrackerImage = c.Get("EXECUTOR_FIRECRACKER_IMAGE", DefaultFirecrackerImage, "The base image to use for virtual machines.")
 	c.FirecrackerKernelImage = c.Get("EXECUTOR_FIRECRACKER_KERNEL_IMAGE", DefaultFirecrackerKernelImage, "The base image containing the kernel binary to use for virtual machines.")
 	c.FirecrackerSandboxImage = c.Get("EXECUTOR_FIRECRACKER_SANDBOX_IMAGE", DefaultFirecrackerSandboxImage, "The OCI image for the ignite VM sandbox.")
@@ -84,6 +106,10 @@ func (c *Config) Load() {
 	c.DockerRegistryMirrorURL = c.GetOptional("EXECUTOR_DOCKER_REGISTRY_MIRROR_URL", "The address of a docker registry mirror to use in firecracker VMs. Supports multiple values, separated with a comma.")
 	c.dockerAuthConfigStr = c.GetOptional("EXECUTOR_DOCKER_AUTH_CONFIG", "The content of the docker config file including auth for services. If using firecracker, only static credentials are supported, not credential stores nor credential helpers.")
 
+	if c.queuesStr != "" {
+		c.Queues, c.queuesParseError = parseQueues(c.queuesStr)
+	}
+
 	if c.dockerAuthConfigStr != "" {
 		c.dockerAuthConfigUnmarshalError = json.Unmarshal([]byte(c.dockerAuthConfigStr), &c.DockerAuthConfig)
 	}
@@ -98,6 +124,18 @@ func (c *Config) Validate() error {
 		c.AddError(errors.New("EXECUTOR_QUEUE_NAME must be set to 'batches' or 'codeintel'"))
 	}
 
+	if c.queuesParseError != nil {
+		c.AddError(errors.Wrap(c.queuesParseError, "invalid EXECUTOR_QUEUE_NAMES"))
+	}
+	if len(c.Queues) > 0 && c.QueueName != "" {
+		c.AddError(errors.New("only one of EXECUTOR_QUEUE_NAME and EXECUTOR_QUEUE_NAMES can be set"))
+	}
+	for _, queue := range c.Queues {
+		if queue.Name != "batches" && queue.Name != "codeintel" {
+			c.AddError(errors.Newf("EXECUTOR_QUEUE_NAMES contains unknown queue %q, must be 'batches' or 'codeintel'", queue.Name))
+		}
+	}
+
 	if c.dockerAuthConfigUnmarshalError != nil {
 		c.AddError(errors.Wrap(c.dockerAuthConfigUnmarshalError, "invalid EXECUTOR_DOCKER_AUTH_CONFIG, failed to parse"))
 	}
@@ -123,5 +161,51 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.UseKubernetes {
+		if c.UseFirecracker {
+			c.AddError(errors.New("EXECUTOR_USE_KUBERNETES cannot be combined with EXECUTOR_USE_FIRECRACKER"))
+		}
+		if c.KubernetesPVCName == "" {
+			c.AddError(errors.New("EXECUTOR_KUBERNETES_PERSISTENT_VOLUME_CLAIM_NAME must be set when EXECUTOR_USE_KUBERNETES is enabled"))
+		}
+	}
+
 	return c.BaseConfig.Validate()
 }
+
+// parseQueues parses a comma-separated list of name[:weight[:maxNumJobs]] entries.
+func parseQueues(value string) ([]WeightedQueue, error) {
+	var queues []WeightedQueue
+	seen := map[string]struct{}{}
+	for _, entry := range strings.Split(value, ",") {
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if len(parts) > 3 || parts[0] == "" {
+			return nil, errors.Newf("invalid queue %q, expected name[:weight[:maxNumJobs]]", entry)
+		}
+
+		queue := WeightedQueue{Name: parts[0], Weight: 1}
+		if _, ok := seen[queue.Name]; ok {
+			return nil, errors.Newf("duplicate queue %q", queue.Name)
+		}
+		seen[queue.Name] = struct{}{}
+
+		if len(parts) > 1 {
+			weight, err := strconv.Atoi(parts[1])
+			if err != nil || weight < 1 {
+				return nil, errors.Newf("invalid weight %q for queue %q, must be a positive integer", parts[1], queue.Name)
+			}
+			queue.Weight = weight
+		}
+		if len(parts) > 2 {
+			maxNumJobs, err := strconv.Atoi(parts[2])
+			if err != nil || maxNumJobs < 0 {
+				return nil, errors.Newf("invalid maximum number of jobs %q for queue %q, must be a non-negative integer", parts[2], queue.Name)
+			}
+			queue.MaxNumJobs = maxNumJobs
+		}
+
+		queues = append(queues, queue)
+	}
+
+	return queues, nil
+}
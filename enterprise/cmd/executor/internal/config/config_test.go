@@ -0,0 +1,34 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseQueues(t *testing.T) {
+	queues, err := parseQueues("codeintel:3:2, batches")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []WeightedQueue{
+		{Name: "codeintel", Weight: 3, MaxNumJobs: 2},
+		{Name: "batches", Weight: 1},
+	}
+	if diff := cmp.Diff(want, queues); diff != "" {
+		t.Errorf("unexpected queues (-want +got):\n%s", diff)
+	}
+
+	for _, value := range []string{
+		"",
+		"codeintel:0",
+		"codeintel:x",
+		"codeintel:1:-1",
+		"codeintel:1:1:1",
+		"codeintel,codeintel",
+	} {
+		if _, err := parseQueues(value); err == nil {
+			t.Errorf("expected error for %q", value)
+		}
+	}
+}
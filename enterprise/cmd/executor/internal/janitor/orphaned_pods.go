@@ -0,0 +1,111 @@
+package janitor
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/command"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type orphanedPodJanitor struct {
+	client    kubernetes.Interface
+	namespace string
+	prefix    string
+	names     *NameSet
+	metrics   *metrics
+}
+
+var (
+	_ goroutine.Handler      = &orphanedPodJanitor{}
+	_ goroutine.ErrorHandler = &orphanedPodJanitor{}
+)
+
+// NewOrphanedPodJanitor returns a background routine that periodically removes all job
+// pods in the namespace that are not known by the worker running within this executor
+// instance.
+func NewOrphanedPodJanitor(
+	client kubernetes.Interface,
+	namespace string,
+	prefix string,
+	names *NameSet,
+	interval time.Duration,
+	metrics *metrics,
+) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(context.Background(), "executors.orphaned-pod-janitor", "deletes pods from a previous executor instance",
+		interval, newOrphanedPodJanitor(
+			client,
+			namespace,
+			prefix,
+			names,
+			metrics,
+		),
+	)
+}
+
+func newOrphanedPodJanitor(
+	client kubernetes.Interface,
+	namespace string,
+	prefix string,
+	names *NameSet,
+	metrics *metrics,
+) *orphanedPodJanitor {
+	return &orphanedPodJanitor{
+		client:    client,
+		namespace: namespace,
+		prefix:    prefix,
+		names:     names,
+		metrics:   metrics,
+	}
+}
+
+func (j *orphanedPodJanitor) Handle(ctx context.Context) (err error) {
+	podsByJob, err := command.KubernetesPodsByJob(ctx, j.client, j.namespace, j.prefix)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range findOrphanedPods(podsByJob, j.names.Slice()) {
+		log15.Info("Removing orphaned pod", "name", name)
+
+		if removeErr := command.DeleteKubernetesPod(ctx, j.client, j.namespace, name); removeErr != nil {
+			err = errors.Append(err, removeErr)
+		} else {
+			j.metrics.numPodsRemoved.Inc()
+		}
+	}
+
+	return err
+}
+
+func (j *orphanedPodJanitor) HandleError(err error) {
+	j.metrics.numErrors.Inc()
+	log15.Error("Failed to remove orphaned pods", "error", err)
+}
+
+// findOrphanedPods returns the names of the pods belonging to jobs that are absent
+// from the expected jobs. The podsByJob argument is expected to be a map from job
+// names to the names of their pods.
+func findOrphanedPods(podsByJob map[string][]string, expectedJobs []string) []string {
+	expectedMap := make(map[string]struct{}, len(expectedJobs))
+	for _, job := range expectedJobs {
+		expectedMap[job] = struct{}{}
+	}
+
+	names := make([]string, 0, len(podsByJob))
+	for job, pods := range podsByJob {
+		if _, ok := expectedMap[job]; ok {
+			continue
+		}
+
+		names = append(names, pods...)
+	}
+	sort.Strings(names)
+
+	return names
+}
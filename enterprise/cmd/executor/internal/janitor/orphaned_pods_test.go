@@ -0,0 +1,25 @@
+package janitor
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFindOrphanedPods(t *testing.T) {
+	orphans := findOrphanedPods(
+		map[string][]string{
+			"a": {"a-1", "a-2"},
+			"b": {"b-1"},
+			"c": {"c-1"},
+			"d": {"d-1", "d-2"},
+		},
+		[]string{
+			"c", "d",
+			"x", "y",
+		},
+	)
+	if diff := cmp.Diff([]string{"a-1", "a-2", "b-1"}, orphans); diff != "" {
+		t.Fatalf("unexpected orphans (-want +got):\n%s", diff)
+	}
+}
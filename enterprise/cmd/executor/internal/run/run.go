@@ -11,12 +11,14 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/apiclient"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/apiclient/queue"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/command"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/config"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/ignite"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/janitor"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func RunRun(cliCtx *cli.Context, logger log.Logger, cfg *config.Config) error {
@@ -105,6 +107,22 @@ func StandaloneRunRun(ctx context.Context, logger log.Logger, cfg *config.Config
 		mustRegisterVMCountMetric(logger, observationCtx, cfg.VMPrefix)
 	}
 
+	if cfg.UseKubernetes {
+		client, err := command.NewKubernetesClientset(cfg.KubernetesConfigPath)
+		if err != nil {
+			cancel()
+			return errors.Wrap(err, "building kubernetes client")
+		}
+		routines = append(routines, janitor.NewOrphanedPodJanitor(
+			client,
+			cfg.KubernetesNamespace,
+			cfg.VMPrefix,
+			nameSet,
+			cfg.CleanupTaskInterval,
+			janitor.NewMetrics(observationCtx),
+		))
+	}
+
 	go func() {
 		// Block until the worker has exited. The executor worker is unique
 		// in that we want a maximum runtime and/or number of jobs to be
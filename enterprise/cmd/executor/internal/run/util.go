@@ -101,6 +101,7 @@ func apiWorkerOptions(c *config.Config, queueTelemetryOptions queue.TelemetryOpt
 		WorkerOptions:      workerOptions(c),
 		DockerOptions:      dockerOptions(c),
 		FirecrackerOptions: firecrackerOptions(c),
+		KubernetesOptions:  kubernetesOptions(c),
 		ResourceOptions:    resourceOptions(c),
 		GitServicePath:     "/.executors/git",
 		QueueOptions:       queueOptions(c, queueTelemetryOptions),
@@ -118,11 +119,11 @@ func apiWorkerOptions(c *config.Config, queueTelemetryOptions queue.TelemetryOpt
 
 func workerOptions(c *config.Config) workerutil.WorkerOptions {
 	return workerutil.WorkerOptions{
-		Name:                 fmt.Sprintf("executor_%s_worker", c.QueueName),
+		Name:                 fmt.Sprintf("executor_%s_worker", queueLabel(c)),
 		NumHandlers:          c.MaximumNumJobs,
 		Interval:             c.QueuePollInterval,
 		HeartbeatInterval:    5 * time.Second,
-		Metrics:              makeWorkerMetrics(c.QueueName),
+		Metrics:              makeWorkerMetrics(queueLabel(c)),
 		NumTotalJobs:         c.NumTotalJobs,
 		MaxActiveTime:        c.MaxActiveTime,
 		WorkerHostname:       c.WorkerHostname,
@@ -156,6 +157,16 @@ func firecrackerOptions(c *config.Config) command.FirecrackerOptions {
 	}
 }
 
+func kubernetesOptions(c *config.Config) command.KubernetesOptions {
+	return command.KubernetesOptions{
+		Enabled:                   c.UseKubernetes,
+		ConfigPath:                c.KubernetesConfigPath,
+		Namespace:                 c.KubernetesNamespace,
+		PersistentVolumeClaimName: c.KubernetesPVCName,
+		WorkspaceMountPath:        c.KubernetesWorkspaceMountPath,
+	}
+}
+
 func resourceOptions(c *config.Config) command.ResourceOptions {
 	return command.ResourceOptions{
 		NumCPUs:             c.JobNumCPUs,
@@ -171,6 +182,7 @@ func queueOptions(c *config.Config, telemetryOptions queue.TelemetryOptions) que
 	return queue.Options{
 		ExecutorName:      c.WorkerHostname,
 		QueueName:         c.QueueName,
+		Queues:            weightedQueues(c),
 		BaseClientOptions: baseClientOptions(c, "/.executors/queue"),
 		TelemetryOptions:  telemetryOptions,
 		ResourceOptions: queue.ResourceOptions{
@@ -181,6 +193,32 @@ func queueOptions(c *config.Config, telemetryOptions queue.TelemetryOptions) que
 	}
 }
 
+func weightedQueues(c *config.Config) []queue.WeightedQueue {
+	queues := make([]queue.WeightedQueue, 0, len(c.Queues))
+	for _, q := range c.Queues {
+		queues = append(queues, queue.WeightedQueue{
+			Name:       q.Name,
+			Weight:     q.Weight,
+			MaxNumJobs: q.MaxNumJobs,
+		})
+	}
+	return queues
+}
+
+// queueLabel returns the name of the processed queue, or the names of all processed
+// queues joined by underscores, for use in worker and metric names.
+func queueLabel(c *config.Config) string {
+	if len(c.Queues) == 0 {
+		return c.QueueName
+	}
+
+	names := make([]string, 0, len(c.Queues))
+	for _, q := range c.Queues {
+		names = append(names, q.Name)
+	}
+	return strings.Join(names, "_")
+}
+
 func filesOptions(c *config.Config) apiclient.BaseClientOptions {
 	return apiclient.BaseClientOptions{
 		EndpointOptions: endpointOptions(c, "/.executors/files"),
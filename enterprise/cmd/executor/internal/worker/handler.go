@@ -80,7 +80,7 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, job executor.Jo
 	// interpolate into the command. No command that we run on the host leaks environment
 	// variables, and the user-specified commands (which could leak their environment) are
 	// run in a clean VM.
-	commandLogger := command.NewLogger(h.logStore, job, job.RecordID(), union(h.options.RedactedValues, job.RedactedValues))
+	commandLogger := command.NewLogger(h.logStore, job, union(h.options.RedactedValues, job.RedactedValues))
 	defer func() {
 		if flushErr := commandLogger.Flush(); flushErr != nil {
 			err = errors.Append(err, flushErr)
@@ -99,6 +99,15 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, job executor.Jo
 	}
 	defer ws.Remove(ctx, h.options.KeepWorkspaces)
 
+	// Save the caches of the job if all steps succeeded. This is deferred so that it
+	// happens after the runner was torn down, since the workspace of a Firecracker VM
+	// can only be read from the host once the VM is gone.
+	defer func() {
+		if err == nil {
+			ws.SaveCaches(ctx)
+		}
+	}()
+
 	vmNameSuffix, err := uuid.NewRandom()
 	if err != nil {
 		return err
@@ -120,6 +129,7 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, job executor.Jo
 		ExecutorName:       name,
 		DockerOptions:      h.options.DockerOptions,
 		FirecrackerOptions: h.options.FirecrackerOptions,
+		KubernetesOptions:  h.options.KubernetesOptions,
 		ResourceOptions:    h.options.ResourceOptions,
 	}
 	// If the job has docker auth config set, prioritize that over the env var.
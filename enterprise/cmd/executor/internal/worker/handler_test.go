@@ -276,3 +276,89 @@ func TestHandle_WorkspaceFile(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, workspace.ScriptPreamble+"\n\nyarn\ninstall\n", string(dockerScriptFile2Content))
 }
+
+func TestHandle_Caches(t *testing.T) {
+	archives := map[string][]byte{}
+	filesStore := NewMockFilesStore()
+	filesStore.ExistsFunc.SetDefaultHook(func(_ context.Context, bucket, key string) (bool, error) {
+		_, ok := archives[bucket+"/"+key]
+		return ok, nil
+	})
+	filesStore.GetFunc.SetDefaultHook(func(_ context.Context, bucket, key string) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(archives[bucket+"/"+key])), nil
+	})
+	filesStore.UploadFunc.SetDefaultHook(func(_ context.Context, bucket, key string, r io.Reader) error {
+		content, err := io.ReadAll(r)
+		archives[bucket+"/"+key] = content
+		return err
+	})
+
+	job := executor.Job{
+		ID:          42,
+		DockerSteps: []executor.DockerStep{{Image: "go", Commands: []string{"go", "mod", "download"}}},
+		Caches: []executor.CacheSpec{
+			{Key: "1/go-mod", KeyFiles: []string{"go.sum"}, Paths: []string{".cache/go-mod"}},
+		},
+	}
+
+	// run handles the job in a fresh workspace containing the given go.sum, and
+	// returns the workspace directory.
+	run := func(goSum string, step func(dir string)) string {
+		testDir := t.TempDir()
+		workspace.MakeTempDirectory = func(string) (string, error) { return testDir, nil }
+		t.Cleanup(func() {
+			workspace.MakeTempDirectory = workspace.MakeTemporaryDirectory
+		})
+		require.NoError(t, os.WriteFile(filepath.Join(testDir, "go.sum"), []byte(goSum), os.ModePerm))
+
+		runner := NewMockRunner()
+		runner.RunFunc.SetDefaultHook(func(context.Context, command.CommandSpec) error {
+			step(testDir)
+			return nil
+		})
+
+		h := &handler{
+			logStore:   NewMockExecutionLogEntryStore(),
+			filesStore: filesStore,
+			nameSet:    janitor.NewNameSet(),
+			options:    Options{KeepWorkspaces: true},
+			operations: command.NewOperations(&observation.TestContext),
+			runnerFactory: func(dir string, logger command.Logger, options command.Options, operations *command.Operations) command.Runner {
+				if dir == "" {
+					return NewMockRunner()
+				}
+				return runner
+			},
+		}
+		require.NoError(t, h.Handle(context.Background(), logtest.Scoped(t), job))
+		return testDir
+	}
+
+	cachedFile := filepath.Join(".cache", "go-mod", "cache", "download", "mod.zip")
+
+	// The first job populates the cache.
+	run("v1", func(dir string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, cachedFile)), os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, cachedFile), []byte("module"), 0444))
+	})
+	uploads := filesStore.UploadFunc.History()
+	require.Len(t, uploads, 1)
+	assert.Equal(t, "caches", uploads[0].Arg1)
+	assert.Regexp(t, `^1/go-mod-[0-9a-f]{16}$`, uploads[0].Arg2)
+
+	// A job with the same key files finds the cached files before its steps run.
+	run("v1", func(dir string) {
+		content, err := os.ReadFile(filepath.Join(dir, cachedFile))
+		require.NoError(t, err)
+		assert.Equal(t, "module", string(content))
+	})
+
+	// Changing a key file results in a new cache.
+	run("v2", func(dir string) {
+		_, err := os.Stat(filepath.Join(dir, cachedFile))
+		assert.True(t, os.IsNotExist(err), "expected cache to be missed")
+	})
+	uploads = filesStore.UploadFunc.History()
+	require.Len(t, uploads, 3)
+	assert.NotEqual(t, uploads[0].Arg2, uploads[2].Arg2)
+}
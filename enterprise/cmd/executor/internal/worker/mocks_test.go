@@ -13,7 +13,8 @@ import (
 
 	command "github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/command"
 	workspace "github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/workspace"
-	executor "github.com/sourcegraph/sourcegraph/internal/executor"
+	executor "github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
+	executor1 "github.com/sourcegraph/sourcegraph/internal/executor"
 )
 
 // MockExecutionLogEntryStore is a mock implementation of the
@@ -35,12 +36,12 @@ type MockExecutionLogEntryStore struct {
 func NewMockExecutionLogEntryStore() *MockExecutionLogEntryStore {
 	return &MockExecutionLogEntryStore{
 		AddExecutionLogEntryFunc: &ExecutionLogEntryStoreAddExecutionLogEntryFunc{
-			defaultHook: func(context.Context, int, executor.ExecutionLogEntry) (r0 int, r1 error) {
+			defaultHook: func(context.Context, executor.Job, executor1.ExecutionLogEntry) (r0 int, r1 error) {
 				return
 			},
 		},
 		UpdateExecutionLogEntryFunc: &ExecutionLogEntryStoreUpdateExecutionLogEntryFunc{
-			defaultHook: func(context.Context, int, int, executor.ExecutionLogEntry) (r0 error) {
+			defaultHook: func(context.Context, executor.Job, int, executor1.ExecutionLogEntry) (r0 error) {
 				return
 			},
 		},
@@ -53,12 +54,12 @@ func NewMockExecutionLogEntryStore() *MockExecutionLogEntryStore {
 func NewStrictMockExecutionLogEntryStore() *MockExecutionLogEntryStore {
 	return &MockExecutionLogEntryStore{
 		AddExecutionLogEntryFunc: &ExecutionLogEntryStoreAddExecutionLogEntryFunc{
-			defaultHook: func(context.Context, int, executor.ExecutionLogEntry) (int, error) {
+			defaultHook: func(context.Context, executor.Job, executor1.ExecutionLogEntry) (int, error) {
 				panic("unexpected invocation of MockExecutionLogEntryStore.AddExecutionLogEntry")
 			},
 		},
 		UpdateExecutionLogEntryFunc: &ExecutionLogEntryStoreUpdateExecutionLogEntryFunc{
-			defaultHook: func(context.Context, int, int, executor.ExecutionLogEntry) error {
+			defaultHook: func(context.Context, executor.Job, int, executor1.ExecutionLogEntry) error {
 				panic("unexpected invocation of MockExecutionLogEntryStore.UpdateExecutionLogEntry")
 			},
 		},
@@ -83,15 +84,15 @@ func NewMockExecutionLogEntryStoreFrom(i command.ExecutionLogEntryStore) *MockEx
 // when the AddExecutionLogEntry method of the parent
 // MockExecutionLogEntryStore instance is invoked.
 type ExecutionLogEntryStoreAddExecutionLogEntryFunc struct {
-	defaultHook func(context.Context, int, executor.ExecutionLogEntry) (int, error)
-	hooks       []func(context.Context, int, executor.ExecutionLogEntry) (int, error)
+	defaultHook func(context.Context, executor.Job, executor1.ExecutionLogEntry) (int, error)
+	hooks       []func(context.Context, executor.Job, executor1.ExecutionLogEntry) (int, error)
 	history     []ExecutionLogEntryStoreAddExecutionLogEntryFuncCall
 	mutex       sync.Mutex
 }
 
 // AddExecutionLogEntry delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockExecutionLogEntryStore) AddExecutionLogEntry(v0 context.Context, v1 int, v2 executor.ExecutionLogEntry) (int, error) {
+func (m *MockExecutionLogEntryStore) AddExecutionLogEntry(v0 context.Context, v1 executor.Job, v2 executor1.ExecutionLogEntry) (int, error) {
 	r0, r1 := m.AddExecutionLogEntryFunc.nextHook()(v0, v1, v2)
 	m.AddExecutionLogEntryFunc.appendCall(ExecutionLogEntryStoreAddExecutionLogEntryFuncCall{v0, v1, v2, r0, r1})
 	return r0, r1
@@ -100,7 +101,7 @@ func (m *MockExecutionLogEntryStore) AddExecutionLogEntry(v0 context.Context, v1
 // SetDefaultHook sets function that is called when the AddExecutionLogEntry
 // method of the parent MockExecutionLogEntryStore instance is invoked and
 // the hook queue is empty.
-func (f *ExecutionLogEntryStoreAddExecutionLogEntryFunc) SetDefaultHook(hook func(context.Context, int, executor.ExecutionLogEntry) (int, error)) {
+func (f *ExecutionLogEntryStoreAddExecutionLogEntryFunc) SetDefaultHook(hook func(context.Context, executor.Job, executor1.ExecutionLogEntry) (int, error)) {
 	f.defaultHook = hook
 }
 
@@ -109,7 +110,7 @@ func (f *ExecutionLogEntryStoreAddExecutionLogEntryFunc) SetDefaultHook(hook fun
 // instance invokes the hook at the front of the queue and discards it.
 // After the queue is empty, the default hook function is invoked for any
 // future action.
-func (f *ExecutionLogEntryStoreAddExecutionLogEntryFunc) PushHook(hook func(context.Context, int, executor.ExecutionLogEntry) (int, error)) {
+func (f *ExecutionLogEntryStoreAddExecutionLogEntryFunc) PushHook(hook func(context.Context, executor.Job, executor1.ExecutionLogEntry) (int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -118,19 +119,19 @@ func (f *ExecutionLogEntryStoreAddExecutionLogEntryFunc) PushHook(hook func(cont
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *ExecutionLogEntryStoreAddExecutionLogEntryFunc) SetDefaultReturn(r0 int, r1 error) {
-	f.SetDefaultHook(func(context.Context, int, executor.ExecutionLogEntry) (int, error) {
+	f.SetDefaultHook(func(context.Context, executor.Job, executor1.ExecutionLogEntry) (int, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *ExecutionLogEntryStoreAddExecutionLogEntryFunc) PushReturn(r0 int, r1 error) {
-	f.PushHook(func(context.Context, int, executor.ExecutionLogEntry) (int, error) {
+	f.PushHook(func(context.Context, executor.Job, executor1.ExecutionLogEntry) (int, error) {
 		return r0, r1
 	})
 }
 
-func (f *ExecutionLogEntryStoreAddExecutionLogEntryFunc) nextHook() func(context.Context, int, executor.ExecutionLogEntry) (int, error) {
+func (f *ExecutionLogEntryStoreAddExecutionLogEntryFunc) nextHook() func(context.Context, executor.Job, executor1.ExecutionLogEntry) (int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -170,10 +171,10 @@ type ExecutionLogEntryStoreAddExecutionLogEntryFuncCall struct {
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int
+	Arg1 executor.Job
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 executor.ExecutionLogEntry
+	Arg2 executor1.ExecutionLogEntry
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 int
@@ -198,15 +199,15 @@ func (c ExecutionLogEntryStoreAddExecutionLogEntryFuncCall) Results() []interfac
 // when the UpdateExecutionLogEntry method of the parent
 // MockExecutionLogEntryStore instance is invoked.
 type ExecutionLogEntryStoreUpdateExecutionLogEntryFunc struct {
-	defaultHook func(context.Context, int, int, executor.ExecutionLogEntry) error
-	hooks       []func(context.Context, int, int, executor.ExecutionLogEntry) error
+	defaultHook func(context.Context, executor.Job, int, executor1.ExecutionLogEntry) error
+	hooks       []func(context.Context, executor.Job, int, executor1.ExecutionLogEntry) error
 	history     []ExecutionLogEntryStoreUpdateExecutionLogEntryFuncCall
 	mutex       sync.Mutex
 }
 
 // UpdateExecutionLogEntry delegates to the next hook function in the queue
 // and stores the parameter and result values of this invocation.
-func (m *MockExecutionLogEntryStore) UpdateExecutionLogEntry(v0 context.Context, v1 int, v2 int, v3 executor.ExecutionLogEntry) error {
+func (m *MockExecutionLogEntryStore) UpdateExecutionLogEntry(v0 context.Context, v1 executor.Job, v2 int, v3 executor1.ExecutionLogEntry) error {
 	r0 := m.UpdateExecutionLogEntryFunc.nextHook()(v0, v1, v2, v3)
 	m.UpdateExecutionLogEntryFunc.appendCall(ExecutionLogEntryStoreUpdateExecutionLogEntryFuncCall{v0, v1, v2, v3, r0})
 	return r0
@@ -215,7 +216,7 @@ func (m *MockExecutionLogEntryStore) UpdateExecutionLogEntry(v0 context.Context,
 // SetDefaultHook sets function that is called when the
 // UpdateExecutionLogEntry method of the parent MockExecutionLogEntryStore
 // instance is invoked and the hook queue is empty.
-func (f *ExecutionLogEntryStoreUpdateExecutionLogEntryFunc) SetDefaultHook(hook func(context.Context, int, int, executor.ExecutionLogEntry) error) {
+func (f *ExecutionLogEntryStoreUpdateExecutionLogEntryFunc) SetDefaultHook(hook func(context.Context, executor.Job, int, executor1.ExecutionLogEntry) error) {
 	f.defaultHook = hook
 }
 
@@ -224,7 +225,7 @@ func (f *ExecutionLogEntryStoreUpdateExecutionLogEntryFunc) SetDefaultHook(hook
 // instance invokes the hook at the front of the queue and discards it.
 // After the queue is empty, the default hook function is invoked for any
 // future action.
-func (f *ExecutionLogEntryStoreUpdateExecutionLogEntryFunc) PushHook(hook func(context.Context, int, int, executor.ExecutionLogEntry) error) {
+func (f *ExecutionLogEntryStoreUpdateExecutionLogEntryFunc) PushHook(hook func(context.Context, executor.Job, int, executor1.ExecutionLogEntry) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -233,19 +234,19 @@ func (f *ExecutionLogEntryStoreUpdateExecutionLogEntryFunc) PushHook(hook func(c
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *ExecutionLogEntryStoreUpdateExecutionLogEntryFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int, int, executor.ExecutionLogEntry) error {
+	f.SetDefaultHook(func(context.Context, executor.Job, int, executor1.ExecutionLogEntry) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *ExecutionLogEntryStoreUpdateExecutionLogEntryFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int, int, executor.ExecutionLogEntry) error {
+	f.PushHook(func(context.Context, executor.Job, int, executor1.ExecutionLogEntry) error {
 		return r0
 	})
 }
 
-func (f *ExecutionLogEntryStoreUpdateExecutionLogEntryFunc) nextHook() func(context.Context, int, int, executor.ExecutionLogEntry) error {
+func (f *ExecutionLogEntryStoreUpdateExecutionLogEntryFunc) nextHook() func(context.Context, executor.Job, int, executor1.ExecutionLogEntry) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -285,13 +286,13 @@ type ExecutionLogEntryStoreUpdateExecutionLogEntryFuncCall struct {
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int
+	Arg1 executor.Job
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
 	Arg2 int
 	// Arg3 is the value of the 4th argument passed to this method
 	// invocation.
-	Arg3 executor.ExecutionLogEntry
+	Arg3 executor1.ExecutionLogEntry
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -702,6 +703,9 @@ type MockFilesStore struct {
 	// GetFunc is an instance of a mock function object controlling the
 	// behavior of the method Get.
 	GetFunc *FilesStoreGetFunc
+	// UploadFunc is an instance of a mock function object controlling the
+	// behavior of the method Upload.
+	UploadFunc *FilesStoreUploadFunc
 }
 
 // NewMockFilesStore creates a new mock of the FilesStore interface. All
@@ -718,6 +722,11 @@ func NewMockFilesStore() *MockFilesStore {
 				return
 			},
 		},
+		UploadFunc: &FilesStoreUploadFunc{
+			defaultHook: func(context.Context, string, string, io.Reader) (r0 error) {
+				return
+			},
+		},
 	}
 }
 
@@ -735,6 +744,11 @@ func NewStrictMockFilesStore() *MockFilesStore {
 				panic("unexpected invocation of MockFilesStore.Get")
 			},
 		},
+		UploadFunc: &FilesStoreUploadFunc{
+			defaultHook: func(context.Context, string, string, io.Reader) error {
+				panic("unexpected invocation of MockFilesStore.Upload")
+			},
+		},
 	}
 }
 
@@ -748,6 +762,9 @@ func NewMockFilesStoreFrom(i workspace.FilesStore) *MockFilesStore {
 		GetFunc: &FilesStoreGetFunc{
 			defaultHook: i.Get,
 		},
+		UploadFunc: &FilesStoreUploadFunc{
+			defaultHook: i.Upload,
+		},
 	}
 }
 
@@ -970,3 +987,113 @@ func (c FilesStoreGetFuncCall) Args() []interface{} {
 func (c FilesStoreGetFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
+
+// FilesStoreUploadFunc describes the behavior when the Upload method of the
+// parent MockFilesStore instance is invoked.
+type FilesStoreUploadFunc struct {
+	defaultHook func(context.Context, string, string, io.Reader) error
+	hooks       []func(context.Context, string, string, io.Reader) error
+	history     []FilesStoreUploadFuncCall
+	mutex       sync.Mutex
+}
+
+// Upload delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockFilesStore) Upload(v0 context.Context, v1 string, v2 string, v3 io.Reader) error {
+	r0 := m.UploadFunc.nextHook()(v0, v1, v2, v3)
+	m.UploadFunc.appendCall(FilesStoreUploadFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Upload method of the
+// parent MockFilesStore instance is invoked and the hook queue is empty.
+func (f *FilesStoreUploadFunc) SetDefaultHook(hook func(context.Context, string, string, io.Reader) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Upload method of the parent MockFilesStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *FilesStoreUploadFunc) PushHook(hook func(context.Context, string, string, io.Reader) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *FilesStoreUploadFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string, string, io.Reader) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *FilesStoreUploadFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string, string, io.Reader) error {
+		return r0
+	})
+}
+
+func (f *FilesStoreUploadFunc) nextHook() func(context.Context, string, string, io.Reader) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *FilesStoreUploadFunc) appendCall(r0 FilesStoreUploadFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of FilesStoreUploadFuncCall objects describing
+// the invocations of this function.
+func (f *FilesStoreUploadFunc) History() []FilesStoreUploadFuncCall {
+	f.mutex.Lock()
+	history := make([]FilesStoreUploadFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// FilesStoreUploadFuncCall is an object that describes an invocation of
+// method Upload on an instance of MockFilesStore.
+type FilesStoreUploadFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 io.Reader
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c FilesStoreUploadFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c FilesStoreUploadFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
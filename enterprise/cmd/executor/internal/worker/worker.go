@@ -65,6 +65,9 @@ type Options struct {
 	// FirecrackerOptions configures the behavior of Firecracker virtual machine creation.
 	FirecrackerOptions command.FirecrackerOptions
 
+	// KubernetesOptions configures the behavior of Kubernetes pod creation.
+	KubernetesOptions command.KubernetesOptions
+
 	// ResourceOptions configures the resource limits of docker container and Firecracker
 	// virtual machines running on the executor.
 	ResourceOptions command.ResourceOptions
@@ -97,13 +100,29 @@ func NewWorker(observationCtx *observation.Context, nameSet *janitor.NameSet, op
 		return nil, errors.Wrap(err, "building files store")
 	}
 
+	runnerFactory := command.NewRunner
+	if options.KubernetesOptions.Enabled {
+		client, err := command.NewKubernetesClientset(options.KubernetesOptions.ConfigPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "building kubernetes client")
+		}
+		runnerFactory = func(dir string, logger command.Logger, options command.Options, operations *command.Operations) command.Runner {
+			// Commands on the host, such as cloning the repository into the
+			// workspace, are not run with the Kubernetes options set.
+			if !options.KubernetesOptions.Enabled {
+				return command.NewRunner(dir, logger, options, operations)
+			}
+			return command.NewKubernetesRunner(client, dir, logger, options)
+		}
+	}
+
 	h := &handler{
 		nameSet:       nameSet,
 		logStore:      queueClient,
 		filesStore:    filesClient,
 		options:       options,
 		operations:    command.NewOperations(observationCtx),
-		runnerFactory: command.NewRunner,
+		runnerFactory: runnerFactory,
 	}
 
 	ctx := context.Background()
@@ -0,0 +1,287 @@
+package workspace
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/command"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// cacheBucket is the bucket in the files store that holds cache archives.
+const cacheBucket = "caches"
+
+// restoreCaches extracts the archives of the caches declared by the job into the
+// workspace. Caches only speed up jobs, so failing to restore one is logged but
+// does not fail the job.
+func restoreCaches(ctx context.Context, store FilesStore, job executor.Job, workspaceDir string, logger command.Logger) {
+	if store == nil || len(job.Caches) == 0 {
+		return
+	}
+
+	handle := logger.Log("setup.cache.restore", nil)
+	defer func() {
+		handle.Finalize(0)
+		_ = handle.Close()
+	}()
+
+	for _, cache := range job.Caches {
+		key, err := cacheKey(workspaceDir, cache)
+		if err != nil {
+			fmt.Fprintf(handle, "Skipping cache %s: %s\n", cache.Key, err)
+			continue
+		}
+
+		exists, err := store.Exists(ctx, cacheBucket, key)
+		if err != nil {
+			fmt.Fprintf(handle, "Failed to look up cache %s: %s\n", key, err)
+			continue
+		}
+		if !exists {
+			fmt.Fprintf(handle, "Cache miss for %s\n", key)
+			continue
+		}
+
+		rc, err := store.Get(ctx, cacheBucket, key)
+		if err != nil {
+			fmt.Fprintf(handle, "Failed to download cache %s: %s\n", key, err)
+			continue
+		}
+		err = extractArchive(rc, workspaceDir)
+		_ = rc.Close()
+		if err != nil {
+			fmt.Fprintf(handle, "Failed to restore cache %s: %s\n", key, err)
+			continue
+		}
+
+		fmt.Fprintf(handle, "Restored cache %s\n", key)
+	}
+}
+
+// saveCaches archives the cached paths of the job and writes them to the files
+// store, replacing the previous archive. This refreshes the age of the archive
+// in the store, so caches that are in use are not expired. Failing to save a
+// cache is logged but does not fail the job.
+func saveCaches(ctx context.Context, store FilesStore, job executor.Job, workspaceDir string, logger command.Logger) {
+	if store == nil || len(job.Caches) == 0 {
+		return
+	}
+
+	handle := logger.Log("teardown.cache.save", nil)
+	defer func() {
+		handle.Finalize(0)
+		_ = handle.Close()
+	}()
+
+	for _, cache := range job.Caches {
+		key, err := cacheKey(workspaceDir, cache)
+		if err != nil {
+			fmt.Fprintf(handle, "Skipping cache %s: %s\n", cache.Key, err)
+			continue
+		}
+
+		pr, pw := io.Pipe()
+		go func() {
+			pw.CloseWithError(writeArchive(pw, workspaceDir, cache.Paths))
+		}()
+		err = store.Upload(ctx, cacheBucket, key, pr)
+		_ = pr.CloseWithError(err)
+		if err != nil {
+			fmt.Fprintf(handle, "Failed to save cache %s: %s\n", key, err)
+			continue
+		}
+
+		fmt.Fprintf(handle, "Saved cache %s\n", key)
+	}
+}
+
+// cacheKey returns the key of the archive of the given cache. The contents of the
+// key files are part of the key, so a change to any of them results in a new cache.
+func cacheKey(workspaceDir string, cache executor.CacheSpec) (string, error) {
+	if cache.Key == "" {
+		return "", errors.New("no key")
+	}
+
+	h := sha256.New()
+	for _, keyFile := range cache.KeyFiles {
+		path, err := workspacePath(workspaceDir, keyFile)
+		if err != nil {
+			return "", err
+		}
+
+		// Missing key files are hashed as such, so that creating them changes the key.
+		fmt.Fprintf(h, "%s\x00", keyFile)
+		f, err := os.Open(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return "", err
+		}
+		_, err = io.Copy(h, f)
+		_ = f.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return cache.Key + "-" + hex.EncodeToString(h.Sum(nil))[:16], nil
+}
+
+// workspacePath returns the absolute path of the given workspace-relative path,
+// and fails if it points outside of the workspace.
+func workspacePath(workspaceDir, relativePath string) (string, error) {
+	path := filepath.Join(workspaceDir, relativePath)
+	if path != workspaceDir && !strings.HasPrefix(path, workspaceDir+string(filepath.Separator)) {
+		return "", errors.Errorf("refusing to access %q outside of the workspace", relativePath)
+	}
+	return path, nil
+}
+
+// checkNoSymlinks fails if any existing parent of the given path below the
+// workspace is a symlink. The repository is cloned into the workspace, so a
+// symlink could otherwise be used to read or write files outside of it.
+func checkNoSymlinks(workspaceDir, path string) error {
+	for dir := filepath.Dir(path); dir != workspaceDir && strings.HasPrefix(dir, workspaceDir); dir = filepath.Dir(dir) {
+		info, err := os.Lstat(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return errors.Errorf("refusing to access %q through a symlink", path)
+		}
+	}
+	return nil
+}
+
+// writeArchive writes a gzipped tarball of the regular files and directories
+// below the given workspace-relative paths. Paths that do not exist are skipped.
+func writeArchive(w io.Writer, workspaceDir string, paths []string) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	for _, p := range paths {
+		root, err := workspacePath(workspaceDir, p)
+		if err != nil {
+			return err
+		}
+		if err := checkNoSymlinks(workspaceDir, root); err != nil {
+			return err
+		}
+
+		err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) && path == root {
+					return nil
+				}
+				return err
+			}
+			if !info.IsDir() && !info.Mode().IsRegular() {
+				return nil
+			}
+
+			name, err := filepath.Rel(workspaceDir, path)
+			if err != nil {
+				return err
+			}
+			header, err := tar.FileInfoHeader(info, "")
+			if err != nil {
+				return err
+			}
+			header.Name = filepath.ToSlash(name)
+			if err := tw.WriteHeader(header); err != nil {
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			_, err = io.Copy(tw, f)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// extractArchive extracts a gzipped tarball written by writeArchive into the
+// workspace. Entries other than regular files and directories are ignored.
+func extractArchive(r io.Reader, workspaceDir string) error {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// 🚨 SECURITY: Archives are written by jobs, so entries must not escape the workspace.
+		path, err := workspacePath(workspaceDir, header.Name)
+		if err != nil {
+			return err
+		}
+		if err := checkNoSymlinks(workspaceDir, path); err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			// Directories are made writable, so that restored files can be written into
+			// them, even if the tool that created them made them read-only.
+			if err := os.MkdirAll(path, 0755); err != nil {
+				return err
+			}
+
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return err
+			}
+			// Replace existing files rather than writing through them, in case they
+			// are symlinks.
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, os.FileMode(header.Mode).Perm()|0200)
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(f, tr); err != nil {
+				return errors.Append(err, f.Close())
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -26,6 +26,22 @@ func NewDockerWorkspace(
 		return nil, err
 	}
 
+	return newHostWorkspace(ctx, workspaceDir, filesStore, job, commandRunner, logger, cloneOpts, operations)
+}
+
+// newHostWorkspace clones the repo, restores the caches and writes the script files
+// of the job into the given directory on the host, removing the directory if that
+// fails.
+func newHostWorkspace(
+	ctx context.Context,
+	workspaceDir string,
+	filesStore FilesStore,
+	job executor.Job,
+	commandRunner command.Runner,
+	logger command.Logger,
+	cloneOpts CloneOptions,
+	operations *command.Operations,
+) (Workspace, error) {
 	if job.RepositoryName != "" {
 		if err := cloneRepo(ctx, workspaceDir, job, commandRunner, cloneOpts, operations); err != nil {
 			_ = os.RemoveAll(workspaceDir)
@@ -33,6 +49,8 @@ func NewDockerWorkspace(
 		}
 	}
 
+	restoreCaches(ctx, filesStore, job, workspaceDir, logger)
+
 	scriptPaths, err := prepareScripts(ctx, filesStore, job, workspaceDir, logger)
 	if err != nil {
 		_ = os.RemoveAll(workspaceDir)
@@ -43,6 +61,8 @@ func NewDockerWorkspace(
 		path:            workspaceDir,
 		scriptFilenames: scriptPaths,
 		workspaceDir:    workspaceDir,
+		filesStore:      filesStore,
+		job:             job,
 		logger:          logger,
 	}, nil
 }
@@ -51,6 +71,8 @@ type dockerWorkspace struct {
 	path            string
 	scriptFilenames []string
 	workspaceDir    string
+	filesStore      FilesStore
+	job             executor.Job
 	logger          command.Logger
 }
 
@@ -62,6 +84,10 @@ func (w dockerWorkspace) ScriptFilenames() []string {
 	return w.scriptFilenames
 }
 
+func (w dockerWorkspace) SaveCaches(ctx context.Context) {
+	saveCaches(ctx, w.filesStore, w.job, w.workspaceDir, w.logger)
+}
+
 func (w dockerWorkspace) Remove(ctx context.Context, keepWorkspace bool) {
 	handle := w.logger.Log("teardown.fs", nil)
 	defer func() {
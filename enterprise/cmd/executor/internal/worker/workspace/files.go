@@ -21,6 +21,8 @@ type FilesStore interface {
 	Exists(ctx context.Context, bucket string, key string) (bool, error)
 	// Get retrieves the file.
 	Get(ctx context.Context, bucket string, key string) (io.ReadCloser, error)
+	// Upload writes the content of the given reader to the file.
+	Upload(ctx context.Context, bucket string, key string, r io.Reader) error
 }
 
 func prepareScripts(
@@ -60,6 +60,8 @@ func NewFirecrackerWorkspace(
 		}
 	}
 
+	restoreCaches(ctx, filesStore, job, tmpMountDir, logger)
+
 	scriptPaths, err := prepareScripts(ctx, filesStore, job, tmpMountDir, logger)
 	if err != nil {
 		return nil, err
@@ -69,6 +71,8 @@ func NewFirecrackerWorkspace(
 		scriptFilenames: scriptPaths,
 		blockDeviceFile: blockDeviceFile,
 		blockDevice:     blockDevice,
+		filesStore:      filesStore,
+		job:             job,
 		logger:          logger,
 	}, err
 }
@@ -77,6 +81,8 @@ type firecrackerWorkspace struct {
 	scriptFilenames []string
 	blockDeviceFile string
 	blockDevice     string
+	filesStore      FilesStore
+	job             executor.Job
 	logger          command.Logger
 }
 
@@ -88,6 +94,36 @@ func (w firecrackerWorkspace) ScriptFilenames() []string {
 	return w.scriptFilenames
 }
 
+// SaveCaches mounts the workspace device on the host to read the caches from it.
+// The device must not be mounted by the VM anymore, so this must only be called
+// once the VM was torn down.
+func (w firecrackerWorkspace) SaveCaches(ctx context.Context) {
+	if w.filesStore == nil || len(w.job.Caches) == 0 {
+		return
+	}
+
+	handle := w.logger.Log("teardown.cache.mount", nil)
+	mountDir, err := mountLoopDevice(ctx, w.blockDevice, handle)
+	if err != nil {
+		fmt.Fprintf(handle, "stderr: Failed to mount workspace device %q: %s\n", w.blockDevice, err)
+	}
+	// Saving caches doesn't fail the execution job, so this always finishes with exit code 0.
+	handle.Finalize(0)
+	handle.Close()
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if err := syscall.Unmount(mountDir, 0); err != nil {
+			return
+		}
+		_ = os.RemoveAll(mountDir)
+	}()
+
+	saveCaches(ctx, w.filesStore, w.job, mountDir, w.logger)
+}
+
 func (w firecrackerWorkspace) Remove(ctx context.Context, keepWorkspace bool) {
 	handle := w.logger.Log("teardown.fs", nil)
 	defer func() {
@@ -14,6 +14,9 @@ type Workspace interface {
 	Path() string
 	// ScriptFilenames holds the ordered set of script filenames to be invoked.
 	ScriptFilenames() []string
+	// SaveCaches writes the caches of the job to the files store. It must only be
+	// called once the commands of the job finished running in the workspace.
+	SaveCaches(ctx context.Context)
 	// Remove cleans up the workspace post execution. If keep workspace is true,
 	// the implementation will only clean up additional resources, while keeping
 	// the workspace contents on disk for debugging purposes.
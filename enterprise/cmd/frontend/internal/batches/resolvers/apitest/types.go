@@ -142,6 +142,7 @@ type Changeset struct {
 	ForkNamespace      string
 	ReviewState        string
 	CheckState         string
+	ConflictState      string
 	Events             ChangesetEventConnection
 
 	Diff Comparison
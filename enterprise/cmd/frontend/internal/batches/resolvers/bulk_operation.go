@@ -118,7 +118,7 @@ func changesetJobTypeToBulkOperationType(t btypes.ChangesetJobType) (string, err
 		return "DETACH", nil
 	case btypes.ChangesetJobTypeReenqueue:
 		return "REENQUEUE", nil
-	case btypes.ChangesetJobTypeMerge:
+	case btypes.ChangesetJobTypeMerge, btypes.ChangesetJobTypeRolloutMerge:
 		return "MERGE", nil
 	case btypes.ChangesetJobTypeClose:
 		return "CLOSE", nil
@@ -348,6 +348,26 @@ func (r *changesetResolver) CheckState() *string {
 	return &checkState
 }
 
+func (r *changesetResolver) ConflictState() *string {
+	if !r.changeset.Published() {
+		return nil
+	}
+
+	conflictState := string(r.changeset.ConflictState)
+	if conflictState == "" || conflictState == string(btypes.ChangesetConflictStateUnknown) {
+		return nil
+	}
+
+	return &conflictState
+}
+
+func (r *changesetResolver) ConflictCheckedAt() *gqlutil.DateTime {
+	if r.changeset.ConflictCheckedAt.IsZero() {
+		return nil
+	}
+	return &gqlutil.DateTime{Time: r.changeset.ConflictCheckedAt}
+}
+
 func (r *changesetResolver) Error() *string { return r.changeset.FailureMessage }
 
 func (r *changesetResolver) SyncerError() *string { return r.changeset.SyncErrorMessage }
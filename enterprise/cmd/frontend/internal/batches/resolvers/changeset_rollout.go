@@ -0,0 +1,86 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	bgql "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/graphql"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+)
+
+const changesetRolloutIDKind = "ChangesetRollout"
+
+func unmarshalChangesetRolloutID(id graphql.ID) (rolloutID int64, err error) {
+	err = relay.UnmarshalSpec(id, &rolloutID)
+	return
+}
+
+type changesetRolloutResolver struct {
+	store           *store.Store
+	gitserverClient gitserver.Client
+	rollout         *btypes.ChangesetRollout
+}
+
+var _ graphqlbackend.ChangesetRolloutResolver = &changesetRolloutResolver{}
+
+func (r *changesetRolloutResolver) ID() graphql.ID {
+	return bgql.MarshalChangesetRolloutID(r.rollout.ID)
+}
+
+func (r *changesetRolloutResolver) BatchChange(ctx context.Context) (graphqlbackend.BatchChangeResolver, error) {
+	batchChange, err := r.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: r.rollout.BatchChangeID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &batchChangeResolver{store: r.store, gitserverClient: r.gitserverClient, batchChange: batchChange}, nil
+}
+
+func (r *changesetRolloutResolver) State() string {
+	return string(r.rollout.State)
+}
+
+func (r *changesetRolloutResolver) CurrentWave() int32 {
+	return int32(r.rollout.CurrentWave)
+}
+
+func (r *changesetRolloutResolver) WaveCount() int32 {
+	return int32(r.rollout.WaveCount)
+}
+
+func (r *changesetRolloutResolver) SoakTime() int32 {
+	return int32(r.rollout.SoakTime.Seconds())
+}
+
+func (r *changesetRolloutResolver) Squash() bool {
+	return r.rollout.Squash
+}
+
+func (r *changesetRolloutResolver) FailureMessage() *string {
+	return r.rollout.FailureMessage
+}
+
+func (r *changesetRolloutResolver) Initiator(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.store.DatabaseDB(), r.rollout.UserID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *changesetRolloutResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.rollout.CreatedAt}
+}
+
+func (r *changesetRolloutResolver) UpdatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.rollout.UpdatedAt}
+}
@@ -165,6 +165,11 @@ func TestChangesetResolver(t *testing.T) {
 			UpdatedAt: now,
 		},
 	})
+	syncedGitHubChangeset.ConflictState = btypes.ChangesetConflictStateConflicting
+	syncedGitHubChangeset.ConflictCheckedAt = now
+	if err := bstore.UpdateChangesetConflictState(ctx, syncedGitHubChangeset); err != nil {
+		t.Fatal(err)
+	}
 	events, err := syncedGitHubChangeset.Events()
 	if err != nil {
 		t.Fatal(err)
@@ -310,6 +315,7 @@ func TestChangesetResolver(t *testing.T) {
 				Body:               "GitHub PR Body",
 				ExternalID:         "12345",
 				CheckState:         "PENDING",
+				ConflictState:      "CONFLICTING",
 				ReviewState:        "CHANGES_REQUESTED",
 				NextSyncAt:         marshalDateTime(t, now.Add(8*time.Hour)),
 				ScheduleEstimateAt: "",
@@ -435,6 +441,7 @@ query($changeset: ID!) {
       state
       reviewState
       checkState
+      conflictState
       externalURL { url, serviceKind, serviceType }
       nextSyncAt
       scheduleEstimateAt
@@ -97,3 +97,19 @@ func (r *globalChangesetsStatsResolver) Closed() int32 {
 func (r *globalChangesetsStatsResolver) Total() int32 {
 	return r.stats.Total
 }
+
+type batchSpecExecutionCacheStatsResolver struct {
+	stats btypes.BatchSpecExecutionCacheStats
+}
+
+var _ graphqlbackend.BatchSpecExecutionCacheStatsResolver = &batchSpecExecutionCacheStatsResolver{}
+
+func (r *batchSpecExecutionCacheStatsResolver) Steps() int32 {
+	return r.stats.Steps
+}
+func (r *batchSpecExecutionCacheStatsResolver) CachedSteps() int32 {
+	return r.stats.CachedSteps
+}
+func (r *batchSpecExecutionCacheStatsResolver) HitRate() float64 {
+	return r.stats.HitRate()
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/graph-gophers/graphql-go"
 
@@ -113,6 +114,9 @@ func (r *Resolver) NodeResolvers() map[string]graphqlbackend.NodeByIDFunc {
 		bulkOperationIDKind: func(ctx context.Context, id graphql.ID) (graphqlbackend.Node, error) {
 			return r.bulkOperationByID(ctx, id)
 		},
+		changesetRolloutIDKind: func(ctx context.Context, id graphql.ID) (graphqlbackend.Node, error) {
+			return r.changesetRolloutByID(ctx, id)
+		},
 		batchSpecWorkspaceIDKind: func(ctx context.Context, id graphql.ID) (graphqlbackend.Node, error) {
 			return r.batchSpecWorkspaceByID(ctx, id)
 		},
@@ -375,6 +379,31 @@ func (r *Resolver) bulkOperationByIDString(ctx context.Context, id string) (grap
 	return &bulkOperationResolver{store: r.store, gitserverClient: r.gitserverClient, bulkOperation: bulkOperation}, nil
 }
 
+func (r *Resolver) changesetRolloutByID(ctx context.Context, id graphql.ID) (graphqlbackend.ChangesetRolloutResolver, error) {
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	rolloutID, err := unmarshalChangesetRolloutID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if rolloutID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	rollout, err := r.store.GetChangesetRollout(ctx, store.GetChangesetRolloutOpts{ID: rolloutID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &changesetRolloutResolver{store: r.store, gitserverClient: r.gitserverClient, rollout: rollout}, nil
+}
+
 func (r *Resolver) batchSpecWorkspaceByID(ctx context.Context, gqlID graphql.ID) (graphqlbackend.BatchSpecWorkspaceResolver, error) {
 	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
 		return nil, err
@@ -844,6 +873,30 @@ func (r *Resolver) GlobalChangesetsStats(
 	return &globalChangesetsStatsResolver{stats: *stats}, nil
 }
 
+func (r *Resolver) BatchSpecExecutionCacheStats(
+	ctx context.Context,
+	args *graphqlbackend.BatchSpecExecutionCacheStatsArgs,
+) (graphqlbackend.BatchSpecExecutionCacheStatsResolver, error) {
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: Only site admins can see the cache statistics of the instance.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	var since time.Time
+	if args.Since != nil {
+		since = args.Since.Time
+	}
+
+	stats, err := r.store.GetBatchSpecExecutionCacheStats(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	return &batchSpecExecutionCacheStatsResolver{stats: stats}, nil
+}
+
 func (r *Resolver) RepoDiffStat(ctx context.Context, repo *graphql.ID) (*graphqlbackend.DiffStat, error) {
 	repoID, err := graphqlbackend.UnmarshalRepositoryID(*repo)
 	if err != nil {
@@ -1509,6 +1562,123 @@ func (r *Resolver) PublishChangesets(ctx context.Context, args *graphqlbackend.P
 	return r.bulkOperationByIDString(ctx, bulkGroupID)
 }
 
+func (r *Resolver) StartChangesetRollout(ctx context.Context, args *graphqlbackend.StartChangesetRolloutArgs) (_ graphqlbackend.ChangesetRolloutResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.StartChangesetRollout", fmt.Sprintf("BatchChange: %q, len(Waves): %d", args.BatchChange, len(args.Waves)))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	batchChangeID, err := unmarshalBatchChangeID(args.BatchChange)
+	if err != nil {
+		return nil, err
+	}
+
+	if batchChangeID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	waves := make([]service.ChangesetRolloutWave, 0, len(args.Waves))
+	for _, w := range args.Waves {
+		var wave service.ChangesetRolloutWave
+		if w.Changesets != nil {
+			for _, raw := range *w.Changesets {
+				id, err := unmarshalChangesetID(raw)
+				if err != nil {
+					return nil, err
+				}
+				if id == 0 {
+					return nil, ErrIDIsZero{}
+				}
+				wave.ChangesetIDs = append(wave.ChangesetIDs, id)
+			}
+		}
+		if w.Label != nil {
+			wave.Label = *w.Label
+		}
+		if w.RepoMetadataKey != nil {
+			wave.RepoMetadataKey = *w.RepoMetadataKey
+		}
+		wave.RepoMetadataValue = w.RepoMetadataValue
+		waves = append(waves, wave)
+	}
+
+	// 🚨 SECURITY: StartChangesetRollout checks whether current user is authorized.
+	svc := service.New(r.store)
+	rollout, err := svc.StartChangesetRollout(ctx, service.StartChangesetRolloutOpts{
+		BatchChangeID: batchChangeID,
+		Waves:         waves,
+		SoakTime:      time.Duration(args.SoakTime) * time.Second,
+		Squash:        args.Squash,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &changesetRolloutResolver{store: r.store, gitserverClient: r.gitserverClient, rollout: rollout}, nil
+}
+
+func (r *Resolver) PauseChangesetRollout(ctx context.Context, args *graphqlbackend.PauseChangesetRolloutArgs) (_ graphqlbackend.ChangesetRolloutResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.PauseChangesetRollout", fmt.Sprintf("Rollout: %q", args.Rollout))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	rolloutID, err := unmarshalChangesetRolloutID(args.Rollout)
+	if err != nil {
+		return nil, err
+	}
+
+	if rolloutID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	// 🚨 SECURITY: PauseChangesetRollout checks whether current user is authorized.
+	svc := service.New(r.store)
+	rollout, err := svc.PauseChangesetRollout(ctx, rolloutID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &changesetRolloutResolver{store: r.store, gitserverClient: r.gitserverClient, rollout: rollout}, nil
+}
+
+func (r *Resolver) ResumeChangesetRollout(ctx context.Context, args *graphqlbackend.ResumeChangesetRolloutArgs) (_ graphqlbackend.ChangesetRolloutResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.ResumeChangesetRollout", fmt.Sprintf("Rollout: %q", args.Rollout))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	rolloutID, err := unmarshalChangesetRolloutID(args.Rollout)
+	if err != nil {
+		return nil, err
+	}
+
+	if rolloutID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	// 🚨 SECURITY: ResumeChangesetRollout checks whether current user is authorized.
+	svc := service.New(r.store)
+	rollout, err := svc.ResumeChangesetRollout(ctx, rolloutID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &changesetRolloutResolver{store: r.store, gitserverClient: r.gitserverClient, rollout: rollout}, nil
+}
+
 func (r *Resolver) BatchSpecs(ctx context.Context, args *graphqlbackend.ListBatchSpecArgs) (_ graphqlbackend.BatchSpecConnectionResolver, err error) {
 	tr, ctx := trace.New(ctx, "Resolver.BatchSpecs", fmt.Sprintf("First: %d, After: %v", args.First, args.After))
 	defer func() {
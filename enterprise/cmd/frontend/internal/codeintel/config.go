@@ -1,6 +1,9 @@
 package codeintel
 
 import (
+	"strconv"
+
+	executorcache "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/executorqueue/cache"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/lsifuploadstore"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -9,9 +12,11 @@ import (
 type config struct {
 	env.BaseConfig
 
-	LSIFUploadStoreConfig          *lsifuploadstore.Config
-	HunkCacheSize                  int
-	MaximumIndexesPerMonikerSearch int
+	LSIFUploadStoreConfig            *lsifuploadstore.Config
+	HunkCacheSize                    int
+	MaximumIndexesPerMonikerSearch   int
+	ExecutorCacheMaxSize             int
+	IndexMaxConcurrencyPerRepository int
 }
 
 var ConfigInst = &config{}
@@ -22,6 +27,8 @@ func (c *config) Load() {
 
 	c.HunkCacheSize = c.GetInt("PRECISE_CODE_INTEL_HUNK_CACHE_SIZE", "1000", "The capacity of the git diff hunk cache.")
 	c.MaximumIndexesPerMonikerSearch = c.GetInt("PRECISE_CODE_INTEL_MAXIMUM_INDEXES_PER_MONIKER_SEARCH", "500", "The maximum number of indexes to search at once when doing cross-index code navigation.")
+	c.ExecutorCacheMaxSize = c.GetInt("EXECUTOR_CACHE_MAX_SIZE", strconv.Itoa(executorcache.DefaultMaxSize), "The maximum size in bytes of a single executor cache archive.")
+	c.IndexMaxConcurrencyPerRepository = c.GetInt("PRECISE_CODE_INTEL_INDEX_MAX_CONCURRENCY_PER_REPOSITORY", "2", "The maximum number of auto-indexing jobs of the same repository that executors can process concurrently. Zero disables the limit.")
 }
 
 func (c *config) Validate() error {
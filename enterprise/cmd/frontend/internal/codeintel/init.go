@@ -7,9 +7,11 @@ import (
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
+	executorcache "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/executorqueue/cache"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel"
 	autoindexinggraphql "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindexing/transport/graphql"
 	codenavgraphql "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/transport/graphql"
+	codenavlsp "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/transport/lsp"
 	policiesgraphql "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/policies/transport/graphql"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/gitserver"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/lsifuploadstore"
@@ -67,6 +69,19 @@ func Init(
 		return err
 	}
 
+	codenavLSPHandler, err := codenavlsp.NewHandler(
+		observation.NewContext(log.Scoped("codenav.transport.lsp", "codeintel codenav LSP transport")),
+		codeIntelServices.CodenavService,
+		db.Repos(),
+		gitserverClient,
+		func() string { return conf.SiteConfig().ExternalURL },
+		ConfigInst.MaximumIndexesPerMonikerSearch,
+		ConfigInst.HunkCacheSize,
+	)
+	if err != nil {
+		return err
+	}
+
 	policyRootResolver := policiesgraphql.NewRootResolver(
 		scopedContext("policies"),
 		codeIntelServices.PoliciesService,
@@ -86,6 +101,10 @@ func Init(
 		uploadRootResolver,
 	)
 	enterpriseServices.NewCodeIntelUploadHandler = newUploadHandler
+	enterpriseServices.CodeIntelLSPHandler = codenavLSPHandler
+	enterpriseServices.CodeIntelSCIPExportHandler = uploadshttp.GetExportHandler(codeIntelServices.UploadsService)
+	enterpriseServices.CodeIntelCoverageExportHandler = uploadshttp.GetCoverageExportHandler(codeIntelServices.UploadsService, db)
+	enterpriseServices.ExecutorCacheHandler = executorcache.NewHandler(uploadStore, int64(ConfigInst.ExecutorCacheMaxSize))
 	enterpriseServices.RankingService = codeIntelServices.RankingService
 	return nil
 }
@@ -123,6 +123,14 @@ func (r *Resolver) CommitGraph(ctx context.Context, id graphql.ID) (_ resolverst
 	return r.uploadsRootResolver.CommitGraph(ctx, id)
 }
 
+func (r *Resolver) UnreferencedSymbols(ctx context.Context, repositoryID graphql.ID, args *resolverstubs.CodeIntelUnreferencedSymbolsArgs) (_ resolverstubs.CodeIntelUnreferencedSymbolConnectionResolver, err error) {
+	return r.uploadsRootResolver.UnreferencedSymbols(ctx, repositoryID, args)
+}
+
+func (r *Resolver) CodeIntelCoverage(ctx context.Context, args *resolverstubs.CodeIntelCoverageArgs) (_ resolverstubs.CodeIntelCoverageConnectionResolver, err error) {
+	return r.uploadsRootResolver.CodeIntelCoverage(ctx, args)
+}
+
 func (r *Resolver) QueueAutoIndexJobsForRepo(ctx context.Context, args *resolverstubs.QueueAutoIndexJobsForRepoArgs) (_ []resolverstubs.LSIFIndexResolver, err error) {
 	return r.autoIndexingRootResolver.QueueAutoIndexJobsForRepo(ctx, args)
 }
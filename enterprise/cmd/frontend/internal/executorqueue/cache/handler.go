@@ -0,0 +1,159 @@
+package cache
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// Prefix is the prefix of all cache archives in the upload store. Archives are
+	// expired by age, and every successful job writes its caches again, so caches
+	// that are in use are kept while unused ones eventually disappear.
+	Prefix = "executor-caches/"
+
+	// DefaultMaxSize is the maximum size of a single cache archive in bytes.
+	DefaultMaxSize = 2 * 1024 * 1024 * 1024
+)
+
+type cacheHandler struct {
+	logger  log.Logger
+	store   uploadstore.Store
+	maxSize int64
+}
+
+// NewHandler returns a handler that reads and writes cache archives of executor
+// jobs. The handler expects the key route variable to be set. Archives larger than
+// maxSize are rejected.
+func NewHandler(store uploadstore.Store, maxSize int64) http.Handler {
+	return &cacheHandler{
+		logger:  log.Scoped("executor-cache", "Stores the cache archives of executor jobs"),
+		store:   store,
+		maxSize: maxSize,
+	}
+}
+
+// GET, HEAD, PUT /.executors/files/caches/{key}
+func (h *cacheHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := mux.Vars(r)["key"]
+	if !validKey(key) {
+		http.Error(w, "invalid cache key", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		h.get(w, r, key)
+	case http.MethodPut:
+		h.put(w, r, key)
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *cacheHandler) get(w http.ResponseWriter, r *http.Request, key string) {
+	rc, err := h.store.Get(r.Context(), Prefix+key)
+	if err != nil {
+		h.readError(w, key, err)
+		return
+	}
+	defer rc.Close()
+
+	// Some upload stores only report errors, including missing objects, once the
+	// content is read.
+	br := bufio.NewReader(rc)
+	if _, err := br.Peek(1); err != nil && err != io.EOF {
+		h.readError(w, key, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodHead {
+		return
+	}
+	if _, err := io.Copy(w, br); err != nil {
+		h.logger.Error("failed to write cache to client", log.String("key", key), log.Error(err))
+	}
+}
+
+// readError responds to a request for a cache that could not be read. Only
+// missing caches are reported as such, so that executors don't mistake other
+// errors for a cache miss and overwrite a cache that still exists.
+func (h *cacheHandler) readError(w http.ResponseWriter, key string, err error) {
+	if uploadstore.IsNotFound(err) {
+		http.Error(w, "cache not found", http.StatusNotFound)
+		return
+	}
+
+	h.logger.Error("failed to read cache", log.String("key", key), log.Error(err))
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
+func (h *cacheHandler) put(w http.ResponseWriter, r *http.Request, key string) {
+	if r.ContentLength > h.maxSize {
+		http.Error(w, "cache exceeds maximum size", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	body := &limitedReader{r: r.Body, remaining: h.maxSize}
+	if _, err := h.store.Upload(r.Context(), Prefix+key, body); err != nil {
+		// Blob store clients do not reliably wrap the errors of the reader, so the
+		// reader records whether it was the cause.
+		if body.exceeded {
+			// Do not leave a partial archive behind for the next job to restore.
+			if err := h.store.Delete(r.Context(), Prefix+key); err != nil {
+				h.logger.Warn("failed to delete partial cache", log.String("key", key), log.Error(err))
+			}
+			http.Error(w, "cache exceeds maximum size", http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		h.logger.Error("failed to write cache", log.String("key", key), log.Error(err))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validKey returns true if the given key is a relative path without any empty,
+// . or .. segments, so that a key can not address objects outside of the prefix.
+func validKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+var errTooLarge = errors.New("cache exceeds maximum size")
+
+// limitedReader fails with errTooLarge once more than the given number of bytes
+// were read from the underlying reader.
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+	exceeded  bool
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		l.exceeded = true
+		return n, errTooLarge
+	}
+	return n, err
+}
@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/gorilla/mux"
+
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestHandler(t *testing.T) {
+	objects := map[string][]byte{}
+	store := mocks.NewMockStore()
+	store.GetFunc.SetDefaultHook(func(_ context.Context, key string) (io.ReadCloser, error) {
+		if key == Prefix+"1/unavailable" {
+			return io.NopCloser(errReader{errors.New("connection reset by peer")}), nil
+		}
+		content, ok := objects[key]
+		if !ok {
+			// Mirrors the S3 store, which reports missing objects on read.
+			return io.NopCloser(errReader{errors.Wrap(&s3types.NoSuchKey{}, "failed to get object")}), nil
+		}
+		return io.NopCloser(bytes.NewReader(content)), nil
+	})
+	store.UploadFunc.SetDefaultHook(func(_ context.Context, key string, r io.Reader) (int64, error) {
+		content, err := io.ReadAll(r)
+		if err != nil {
+			return 0, errors.Wrap(err, "uploading")
+		}
+		objects[key] = content
+		return int64(len(content)), nil
+	})
+
+	router := mux.NewRouter()
+	router.Path("/caches/{key:.*}").Handler(NewHandler(store, 8))
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(method, path, strings.NewReader(body))
+		r.ContentLength = -1
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	if w := serve("GET", "/caches/1/go-mod-abc", ""); w.Code != http.StatusNotFound {
+		t.Errorf("unexpected status for missing cache. want=%d have=%d", http.StatusNotFound, w.Code)
+	}
+	if w := serve("HEAD", "/caches/1/unavailable", ""); w.Code != http.StatusInternalServerError {
+		t.Errorf("unexpected status for unreadable cache. want=%d have=%d", http.StatusInternalServerError, w.Code)
+	}
+	if w := serve("PUT", "/caches/1/go-mod-abc", "archive"); w.Code != http.StatusNoContent {
+		t.Errorf("unexpected status for upload. want=%d have=%d", http.StatusNoContent, w.Code)
+	}
+	if _, ok := objects[Prefix+"1/go-mod-abc"]; !ok {
+		t.Errorf("expected archive to be stored under the cache prefix")
+	}
+	if w := serve("HEAD", "/caches/1/go-mod-abc", ""); w.Code != http.StatusOK || w.Body.Len() != 0 {
+		t.Errorf("unexpected response for HEAD. status=%d body=%q", w.Code, w.Body.String())
+	}
+	if w := serve("GET", "/caches/1/go-mod-abc", ""); w.Code != http.StatusOK || w.Body.String() != "archive" {
+		t.Errorf("unexpected response for GET. status=%d body=%q", w.Code, w.Body.String())
+	}
+
+	if w := serve("PUT", "/caches/1/too-large", "0123456789"); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("unexpected status for oversized upload. want=%d have=%d", http.StatusRequestEntityTooLarge, w.Code)
+	}
+	if len(store.DeleteFunc.History()) != 1 {
+		t.Errorf("expected partial archive to be deleted")
+	}
+
+	if w := serve("GET", "/caches/", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("unexpected status for empty key. want=%d have=%d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestValidKey(t *testing.T) {
+	for key, want := range map[string]bool{
+		"1/go-mod-abc":  true,
+		"":              false,
+		"../secret":     false,
+		"1/./go-mod":    false,
+		"1//go-mod":     false,
+		"1/go-mod/..":   false,
+		"/1/go-mod-abc": false,
+	} {
+		if have := validKey(key); have != want {
+			t.Errorf("unexpected result for %q. want=%v have=%v", key, want, have)
+		}
+	}
+}
+
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 	"github.com/sourcegraph/log"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/executorqueue/logstream"
 	apiclient "github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/executor"
@@ -20,6 +23,7 @@ import (
 
 type ExecutorHandler interface {
 	Name() string
+	dequeue(ctx context.Context, metadata executorMetadata, excludedIDs []int) (apiclient.Job, bool, error)
 	handleDequeue(w http.ResponseWriter, r *http.Request)
 	handleAddExecutionLogEntry(w http.ResponseWriter, r *http.Request)
 	handleUpdateExecutionLogEntry(w http.ResponseWriter, r *http.Request)
@@ -49,6 +53,10 @@ type QueueOptions[T workerutil.Record] struct {
 	// RecordTransformer is a required hook for each registered queue that transforms a generic
 	// record from that queue into the job to be given to an executor.
 	RecordTransformer func(ctx context.Context, version string, record T, resourceMetadata ResourceMetadata) (apiclient.Job, error)
+
+	// LogStream is an optional store that receives the output of jobs while they are running,
+	// so that it can be streamed to clients before the job completes.
+	LogStream *logstream.Store
 }
 
 func NewHandler[T workerutil.Record](executorStore database.ExecutorStore, metricsStore metricsstore.DistributedStore, queueOptions QueueOptions[T]) *handler[T] {
@@ -77,9 +85,9 @@ type executorMetadata struct {
 func (h *handler[T]) Name() string { return h.QueueOptions.Name }
 
 // dequeue selects a job record from the database and stashes metadata including
-// the job record and the locking transaction. If no job is available for processing,
-// a false-valued flag is returned.
-func (h *handler[T]) dequeue(ctx context.Context, metadata executorMetadata) (_ apiclient.Job, dequeued bool, _ error) {
+// the job record and the locking transaction. Records with one of the excluded IDs
+// are skipped. If no job is available for processing, a false-valued flag is returned.
+func (h *handler[T]) dequeue(ctx context.Context, metadata executorMetadata, excludedIDs []int) (_ apiclient.Job, dequeued bool, _ error) {
 	if err := validateWorkerHostname(metadata.Name); err != nil {
 		return apiclient.Job{}, false, err
 	}
@@ -93,8 +101,13 @@ func (h *handler[T]) dequeue(ctx context.Context, metadata executorMetadata) (_
 		}
 	}
 
+	var conditions []*sqlf.Query
+	if len(excludedIDs) > 0 {
+		conditions = append(conditions, sqlf.Sprintf("NOT (id = ANY (%s))", pq.Array(excludedIDs)))
+	}
+
 	// executorName is supposed to be unique.
-	record, dequeued, err := h.Store.Dequeue(ctx, metadata.Name, nil)
+	record, dequeued, err := h.Store.Dequeue(ctx, metadata.Name, conditions)
 	if err != nil {
 		return apiclient.Job{}, false, errors.Wrap(err, "dbworkerstore.Dequeue")
 	}
@@ -120,6 +133,13 @@ func (h *handler[T]) dequeue(ctx context.Context, metadata executorMetadata) (_
 		job.Version = 2
 	}
 
+	// Drop the output of previous attempts of this job.
+	if h.LogStream != nil {
+		if err := h.LogStream.Reset(ctx, h.Name(), job.ID); err != nil {
+			logger.Warn("Failed to reset log stream", log.Int("jobID", job.ID), log.Error(err))
+		}
+	}
+
 	return job, true, nil
 }
 
@@ -140,7 +160,12 @@ func (h *handler[T]) addExecutionLogEntry(ctx context.Context, executorName stri
 	if err == store.ErrExecutionLogEntryNotUpdated {
 		return 0, ErrUnknownJob
 	}
-	return entryID, errors.Wrap(err, "dbworkerstore.AddExecutionLogEntry")
+	if err != nil {
+		return 0, errors.Wrap(err, "dbworkerstore.AddExecutionLogEntry")
+	}
+
+	h.appendLogStream(ctx, jobID, entryID, entry)
+	return entryID, nil
 }
 
 // updateExecutionLogEntry calls UpdateExecutionLogEntry for the given job and entry.
@@ -160,7 +185,12 @@ func (h *handler[T]) updateExecutionLogEntry(ctx context.Context, executorName s
 	if err == store.ErrExecutionLogEntryNotUpdated {
 		return ErrUnknownJob
 	}
-	return errors.Wrap(err, "dbworkerstore.UpdateExecutionLogEntry")
+	if err != nil {
+		return errors.Wrap(err, "dbworkerstore.UpdateExecutionLogEntry")
+	}
+
+	h.appendLogStream(ctx, jobID, entryID, entry)
+	return nil
 }
 
 // markComplete calls MarkComplete for the given job.
@@ -181,6 +211,8 @@ func (h *handler[T]) markComplete(ctx context.Context, executorName string, jobI
 	if !ok {
 		return ErrUnknownJob
 	}
+
+	h.finishLogStream(ctx, jobID)
 	return nil
 }
 
@@ -202,6 +234,8 @@ func (h *handler[T]) markErrored(ctx context.Context, executorName string, jobID
 	if !ok {
 		return ErrUnknownJob
 	}
+
+	h.finishLogStream(ctx, jobID)
 	return nil
 }
 
@@ -223,6 +257,8 @@ func (h *handler[T]) markFailed(ctx context.Context, executorName string, jobID
 	if !ok {
 		return ErrUnknownJob
 	}
+
+	h.finishLogStream(ctx, jobID)
 	return nil
 }
 
@@ -266,6 +302,27 @@ func (h *handler[T]) canceled(ctx context.Context, executorName string, knownIDs
 	return canceledIDs, errors.Wrap(err, "dbworkerstore.CanceledJobs")
 }
 
+// appendLogStream records new output of the given log entry in the log stream of the job.
+// Failures are only logged, as the output is also stored in the job record.
+func (h *handler[T]) appendLogStream(ctx context.Context, jobID, entryID int, entry executor.ExecutionLogEntry) {
+	if h.LogStream == nil {
+		return
+	}
+	if err := h.LogStream.Append(ctx, h.Name(), jobID, entryID, entry); err != nil {
+		h.logger.Warn("Failed to append to log stream", log.Int("jobID", jobID), log.Error(err))
+	}
+}
+
+// finishLogStream marks the log stream of the given job as complete.
+func (h *handler[T]) finishLogStream(ctx context.Context, jobID int) {
+	if h.LogStream == nil {
+		return
+	}
+	if err := h.LogStream.Finish(ctx, h.Name(), jobID); err != nil {
+		h.logger.Warn("Failed to finish log stream", log.Int("jobID", jobID), log.Error(err))
+	}
+}
+
 // validateWorkerHostname validates the WorkerHostname field sent for all the endpoints.
 // We don't allow empty hostnames, as it would bypass the hostname verification, which
 // could lead to stray workers updating records they no longer own.
@@ -6,10 +6,12 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/executorqueue/logstream"
 	apiclient "github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/executor"
 	metricsstore "github.com/sourcegraph/sourcegraph/internal/metrics/store"
+	"github.com/sourcegraph/sourcegraph/internal/redispool"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
 	dbworkerstoremocks "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store/mocks"
@@ -42,7 +44,7 @@ func TestDequeue(t *testing.T) {
 
 	handler := NewHandler(executorStore, metricsStore, QueueOptions[testRecord]{Store: store, RecordTransformer: recordTransformer})
 
-	job, dequeued, err := handler.dequeue(context.Background(), executorMetadata{Name: "deadbeef"})
+	job, dequeued, err := handler.dequeue(context.Background(), executorMetadata{Name: "deadbeef"}, nil)
 	if err != nil {
 		t.Fatalf("unexpected error dequeueing job: %s", err)
 	}
@@ -63,7 +65,7 @@ func TestDequeueNoRecord(t *testing.T) {
 
 	handler := NewHandler(executorStore, metricsStore, QueueOptions[testRecord]{Store: dbworkerstoremocks.NewMockStore[testRecord]()})
 
-	_, dequeued, err := handler.dequeue(context.Background(), executorMetadata{Name: "deadbeef"})
+	_, dequeued, err := handler.dequeue(context.Background(), executorMetadata{Name: "deadbeef"}, nil)
 	if err != nil {
 		t.Fatalf("unexpected error dequeueing job: %s", err)
 	}
@@ -86,7 +88,7 @@ func TestAddExecutionLogEntry(t *testing.T) {
 
 	handler := NewHandler(executorStore, metricsStore, QueueOptions[testRecord]{Store: store, RecordTransformer: recordTransformer})
 
-	job, dequeued, err := handler.dequeue(context.Background(), executorMetadata{Name: "deadbeef"})
+	job, dequeued, err := handler.dequeue(context.Background(), executorMetadata{Name: "deadbeef"}, nil)
 	if err != nil {
 		t.Fatalf("unexpected error dequeueing job: %s", err)
 	}
@@ -146,7 +148,7 @@ func TestUpdateExecutionLogEntry(t *testing.T) {
 
 	handler := NewHandler(executorStore, metricsStore, QueueOptions[testRecord]{Store: store, RecordTransformer: recordTransformer})
 
-	job, dequeued, err := handler.dequeue(context.Background(), executorMetadata{Name: "deadbeef"})
+	job, dequeued, err := handler.dequeue(context.Background(), executorMetadata{Name: "deadbeef"}, nil)
 	if err != nil {
 		t.Fatalf("unexpected error dequeueing job: %s", err)
 	}
@@ -194,6 +196,53 @@ func TestUpdateExecutionLogEntryUnknownJob(t *testing.T) {
 	}
 }
 
+func TestLogStream(t *testing.T) {
+	store := dbworkerstoremocks.NewMockStore[testRecord]()
+	store.DequeueFunc.SetDefaultReturn(testRecord{ID: 42}, true, nil)
+	store.AddExecutionLogEntryFunc.SetDefaultReturn(99, nil)
+	store.MarkCompleteFunc.SetDefaultReturn(true, nil)
+	recordTransformer := func(ctx context.Context, _ string, record testRecord, _ ResourceMetadata) (apiclient.Job, error) {
+		return apiclient.Job{ID: 42}, nil
+	}
+
+	logStream := logstream.NewStore(redispool.MemoryKeyValue())
+	handler := NewHandler(database.NewMockExecutorStore(), metricsstore.NewMockDistributedStore(), QueueOptions[testRecord]{
+		Name:              "test",
+		Store:             store,
+		RecordTransformer: recordTransformer,
+		LogStream:         logStream,
+	})
+
+	ctx := context.Background()
+	job, _, err := handler.dequeue(ctx, executorMetadata{Name: "deadbeef"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error dequeueing job: %s", err)
+	}
+	entryID, err := handler.addExecutionLogEntry(ctx, "deadbeef", job.ID, executor.ExecutionLogEntry{Key: "step", Out: "hello\n"})
+	if err != nil {
+		t.Fatalf("unexpected error adding log entry: %s", err)
+	}
+	if err := handler.updateExecutionLogEntry(ctx, "deadbeef", job.ID, entryID, executor.ExecutionLogEntry{Key: "step", Out: "hello\nworld\n"}); err != nil {
+		t.Fatalf("unexpected error updating log entry: %s", err)
+	}
+	if err := handler.markComplete(ctx, "deadbeef", job.ID); err != nil {
+		t.Fatalf("unexpected error completing job: %s", err)
+	}
+
+	chunks, err := logStream.Read(ctx, "test", 42, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []logstream.Chunk{
+		{Seq: 0, Key: "step", Out: "hello\n"},
+		{Seq: 1, Key: "step", Out: "world\n"},
+		{Seq: 2, Done: true},
+	}
+	if diff := cmp.Diff(want, chunks); diff != "" {
+		t.Errorf("unexpected chunks (-want +got):\n%s", diff)
+	}
+}
+
 func TestMarkComplete(t *testing.T) {
 	store := dbworkerstoremocks.NewMockStore[testRecord]()
 	store.DequeueFunc.SetDefaultReturn(testRecord{ID: 42}, true, nil)
@@ -207,7 +256,7 @@ func TestMarkComplete(t *testing.T) {
 
 	handler := NewHandler(executorStore, metricsStore, QueueOptions[testRecord]{Store: store, RecordTransformer: recordTransformer})
 
-	job, dequeued, err := handler.dequeue(context.Background(), executorMetadata{Name: "deadbeef"})
+	job, dequeued, err := handler.dequeue(context.Background(), executorMetadata{Name: "deadbeef"}, nil)
 	if err != nil {
 		t.Fatalf("unexpected error dequeueing job: %s", err)
 	}
@@ -266,7 +315,7 @@ func TestMarkErrored(t *testing.T) {
 
 	handler := NewHandler(executorStore, metricsStore, QueueOptions[testRecord]{Store: store, RecordTransformer: recordTransformer})
 
-	job, dequeued, err := handler.dequeue(context.Background(), executorMetadata{Name: "deadbeef"})
+	job, dequeued, err := handler.dequeue(context.Background(), executorMetadata{Name: "deadbeef"}, nil)
 	if err != nil {
 		t.Fatalf("unexpected error dequeueing job: %s", err)
 	}
@@ -328,7 +377,7 @@ func TestMarkFailed(t *testing.T) {
 
 	handler := NewHandler(executorStore, metricsStore, QueueOptions[testRecord]{Store: store, RecordTransformer: recordTransformer})
 
-	job, dequeued, err := handler.dequeue(context.Background(), executorMetadata{Name: "deadbeef"})
+	job, dequeued, err := handler.dequeue(context.Background(), executorMetadata{Name: "deadbeef"}, nil)
 	if err != nil {
 		t.Fatalf("unexpected error dequeueing job: %s", err)
 	}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	apiclient "github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var multiQueueDequeues = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "src_executor_multi_queue_dequeues_total",
+	Help: "The number of jobs handed out by the multi-queue dequeue endpoint, by the queue they were dequeued from.",
+}, []string{"queue"})
+
+// multiQueueHandler hands out jobs from one of several queues to executors that
+// process more than one queue.
+type multiQueueHandler struct {
+	handlers map[string]ExecutorHandler
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+func newMultiQueueHandler(handlers []ExecutorHandler) *multiQueueHandler {
+	handlersByName := make(map[string]ExecutorHandler, len(handlers))
+	for _, h := range handlers {
+		handlersByName[h.Name()] = h
+	}
+
+	return &multiQueueHandler{
+		handlers: handlersByName,
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// POST /dequeue
+func (m *multiQueueHandler) handleDequeue(w http.ResponseWriter, r *http.Request) {
+	var payload apiclient.MultiQueueDequeueRequest
+
+	wrapHandler(w, r, &payload, func() (int, any, error) {
+		job, dequeued, err := m.dequeue(r.Context(), executorMetadata{
+			Name:    payload.ExecutorName,
+			Version: payload.Version,
+			Resources: ResourceMetadata{
+				NumCPUs:   payload.NumCPUs,
+				Memory:    payload.Memory,
+				DiskSpace: payload.DiskSpace,
+			},
+		}, payload.Queues, payload.RunningJobIDs)
+		if !dequeued {
+			return http.StatusNoContent, nil, err
+		}
+
+		return http.StatusOK, job, err
+	})
+}
+
+// dequeue tries the given queues in a random order, in which queues with a higher
+// weight are more likely to come first, and returns the first job available. The
+// returned job carries the name of the queue it was dequeued from. The IDs of the
+// jobs already running on the executor are given per queue, as job IDs are only
+// unique within a queue.
+func (m *multiQueueHandler) dequeue(ctx context.Context, metadata executorMetadata, queues []apiclient.WeightedQueue, runningIDs map[string][]int) (_ apiclient.Job, dequeued bool, _ error) {
+	for _, queue := range queues {
+		if _, ok := m.handlers[queue.Name]; !ok {
+			return apiclient.Job{}, false, errors.Newf("unknown queue %q", queue.Name)
+		}
+	}
+
+	m.mu.Lock()
+	order := weightedOrder(queues, m.rand.Float64)
+	m.mu.Unlock()
+
+	for _, name := range order {
+		job, dequeued, err := m.handlers[name].dequeue(ctx, metadata, runningIDs[name])
+		if err != nil {
+			return apiclient.Job{}, false, errors.Wrapf(err, "dequeueing from queue %q", name)
+		}
+		if dequeued {
+			job.Queue = name
+			multiQueueDequeues.WithLabelValues(name).Inc()
+			return job, true, nil
+		}
+	}
+
+	return apiclient.Job{}, false, nil
+}
+
+// weightedOrder returns the names of the given queues in a random order. At each
+// position, every remaining queue is picked with a probability proportional to its
+// weight. Queues without a positive weight are treated as having a weight of one.
+func weightedOrder(queues []apiclient.WeightedQueue, random func() float64) []string {
+	remaining := make([]apiclient.WeightedQueue, 0, len(queues))
+	total := 0
+	for _, queue := range queues {
+		if queue.Weight <= 0 {
+			queue.Weight = 1
+		}
+		remaining = append(remaining, queue)
+		total += queue.Weight
+	}
+
+	order := make([]string, 0, len(queues))
+	for len(remaining) > 0 {
+		target := random() * float64(total)
+
+		i := 0
+		for sum := 0; i < len(remaining)-1; i++ {
+			sum += remaining[i].Weight
+			if target < float64(sum) {
+				break
+			}
+		}
+
+		order = append(order, remaining[i].Name)
+		total -= remaining[i].Weight
+		remaining = append(remaining[:i], remaining[i+1:]...)
+	}
+
+	return order
+}
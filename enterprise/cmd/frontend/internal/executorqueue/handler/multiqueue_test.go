@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	apiclient "github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	metricsstore "github.com/sourcegraph/sourcegraph/internal/metrics/store"
+	dbworkerstoremocks "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store/mocks"
+)
+
+func TestMultiQueueDequeue(t *testing.T) {
+	recordTransformer := func(ctx context.Context, _ string, tr testRecord, _ ResourceMetadata) (apiclient.Job, error) {
+		return apiclient.Job{ID: tr.ID}, nil
+	}
+
+	emptyStore := dbworkerstoremocks.NewMockStore[testRecord]()
+	store := dbworkerstoremocks.NewMockStore[testRecord]()
+	store.DequeueFunc.SetDefaultReturn(testRecord{ID: 42}, true, nil)
+
+	m := newMultiQueueHandler([]ExecutorHandler{
+		NewHandler(database.NewMockExecutorStore(), metricsstore.NewMockDistributedStore(), QueueOptions[testRecord]{Name: "empty", Store: emptyStore, RecordTransformer: recordTransformer}),
+		NewHandler(database.NewMockExecutorStore(), metricsstore.NewMockDistributedStore(), QueueOptions[testRecord]{Name: "full", Store: store, RecordTransformer: recordTransformer}),
+	})
+
+	queues := []apiclient.WeightedQueue{{Name: "empty", Weight: 100}, {Name: "full", Weight: 1}}
+	job, dequeued, err := m.dequeue(context.Background(), executorMetadata{Name: "deadbeef"}, queues, map[string][]int{"full": {7, 8}})
+	if err != nil {
+		t.Fatalf("unexpected error dequeueing job: %s", err)
+	}
+	if !dequeued {
+		t.Fatalf("expected job to be dequeued")
+	}
+	if diff := cmp.Diff(apiclient.Job{ID: 42, Queue: "full"}, job); diff != "" {
+		t.Errorf("unexpected job (-want +got):\n%s", diff)
+	}
+
+	if emptyHistory := emptyStore.DequeueFunc.History(); len(emptyHistory) != 1 {
+		t.Errorf("expected the empty queue to be tried")
+	} else if conds := emptyHistory[0].Arg2; len(conds) != 0 {
+		// The running jobs of other queues do not exclude any records.
+		t.Errorf("unexpected dequeue conditions %v", conds)
+	}
+	history := store.DequeueFunc.History()
+	if len(history) != 1 {
+		t.Fatalf("unexpected number of dequeue calls. want=%d have=%d", 1, len(history))
+	}
+	if conds := history[0].Arg2; len(conds) != 1 || conds[0].Query(sqlf.PostgresBindVar) != "NOT (id = ANY ($1))" {
+		t.Errorf("unexpected dequeue conditions %v", conds)
+	} else if diff := cmp.Diff([]any{pq.Array([]int{7, 8})}, conds[0].Args()); diff != "" {
+		t.Errorf("unexpected dequeue condition args (-want +got):\n%s", diff)
+	}
+}
+
+func TestMultiQueueDequeueUnknownQueue(t *testing.T) {
+	m := newMultiQueueHandler(nil)
+
+	if _, _, err := m.dequeue(context.Background(), executorMetadata{Name: "deadbeef"}, []apiclient.WeightedQueue{{Name: "unknown"}}, nil); err == nil {
+		t.Fatal("expected error for unknown queue")
+	}
+}
+
+func TestWeightedOrder(t *testing.T) {
+	queues := []apiclient.WeightedQueue{
+		{Name: "a", Weight: 1},
+		{Name: "b", Weight: 3},
+		{Name: "c", Weight: 0},
+	}
+
+	testCases := []struct {
+		random []float64
+		want   []string
+	}{
+		// Total weight is 5: a covers [0, 1), b [1, 4) and c [4, 5).
+		{random: []float64{0.1, 0, 0}, want: []string{"a", "b", "c"}},
+		{random: []float64{0.5, 0.9, 0}, want: []string{"b", "c", "a"}},
+		{random: []float64{0.9, 0.5, 0}, want: []string{"c", "b", "a"}},
+	}
+
+	for _, testCase := range testCases {
+		random := testCase.random
+		next := func() float64 {
+			v := random[0]
+			random = random[1:]
+			return v
+		}
+
+		if diff := cmp.Diff(testCase.want, weightedOrder(queues, next)); diff != "" {
+			t.Errorf("unexpected order (-want +got):\n%s", diff)
+		}
+	}
+}
@@ -44,13 +44,17 @@ func SetupRoutes(executorStore database.ExecutorStore, metricsStore metricsstore
 			subRouter.Path(fmt.Sprintf("/%s", path)).Methods("POST").HandlerFunc(handler)
 		}
 	}
+
+	// Executors processing several queues dequeue through a single endpoint, which
+	// picks the queue to dequeue from. All other requests use the queue-specific routes.
+	router.Path("/dequeue").Methods("POST").HandlerFunc(newMultiQueueHandler(handlers).handleDequeue)
 }
 
 // POST /{queueName}/dequeue
 func (h *handler[T]) handleDequeue(w http.ResponseWriter, r *http.Request) {
 	var payload apiclient.DequeueRequest
 
-	h.wrapHandler(w, r, &payload, func() (int, any, error) {
+	wrapHandler(w, r, &payload, func() (int, any, error) {
 		job, dequeued, err := h.dequeue(r.Context(), executorMetadata{
 			Name:    payload.ExecutorName,
 			Version: payload.Version,
@@ -59,7 +63,7 @@ func (h *handler[T]) handleDequeue(w http.ResponseWriter, r *http.Request) {
 				Memory:    payload.Memory,
 				DiskSpace: payload.DiskSpace,
 			},
-		})
+		}, nil)
 		if !dequeued {
 			return http.StatusNoContent, nil, err
 		}
@@ -72,7 +76,7 @@ func (h *handler[T]) handleDequeue(w http.ResponseWriter, r *http.Request) {
 func (h *handler[T]) handleAddExecutionLogEntry(w http.ResponseWriter, r *http.Request) {
 	var payload apiclient.AddExecutionLogEntryRequest
 
-	h.wrapHandler(w, r, &payload, func() (int, any, error) {
+	wrapHandler(w, r, &payload, func() (int, any, error) {
 		id, err := h.addExecutionLogEntry(r.Context(), payload.ExecutorName, payload.JobID, payload.ExecutionLogEntry)
 		return http.StatusOK, id, err
 	})
@@ -82,7 +86,7 @@ func (h *handler[T]) handleAddExecutionLogEntry(w http.ResponseWriter, r *http.R
 func (h *handler[T]) handleUpdateExecutionLogEntry(w http.ResponseWriter, r *http.Request) {
 	var payload apiclient.UpdateExecutionLogEntryRequest
 
-	h.wrapHandler(w, r, &payload, func() (int, any, error) {
+	wrapHandler(w, r, &payload, func() (int, any, error) {
 		err := h.updateExecutionLogEntry(r.Context(), payload.ExecutorName, payload.JobID, payload.EntryID, payload.ExecutionLogEntry)
 		return http.StatusNoContent, nil, err
 	})
@@ -92,7 +96,7 @@ func (h *handler[T]) handleUpdateExecutionLogEntry(w http.ResponseWriter, r *htt
 func (h *handler[T]) handleMarkComplete(w http.ResponseWriter, r *http.Request) {
 	var payload apiclient.MarkCompleteRequest
 
-	h.wrapHandler(w, r, &payload, func() (int, any, error) {
+	wrapHandler(w, r, &payload, func() (int, any, error) {
 		err := h.markComplete(r.Context(), payload.ExecutorName, payload.JobID)
 		if err == ErrUnknownJob {
 			return http.StatusNotFound, nil, nil
@@ -106,7 +110,7 @@ func (h *handler[T]) handleMarkComplete(w http.ResponseWriter, r *http.Request)
 func (h *handler[T]) handleMarkErrored(w http.ResponseWriter, r *http.Request) {
 	var payload apiclient.MarkErroredRequest
 
-	h.wrapHandler(w, r, &payload, func() (int, any, error) {
+	wrapHandler(w, r, &payload, func() (int, any, error) {
 		err := h.markErrored(r.Context(), payload.ExecutorName, payload.JobID, payload.ErrorMessage)
 		if err == ErrUnknownJob {
 			return http.StatusNotFound, nil, nil
@@ -120,7 +124,7 @@ func (h *handler[T]) handleMarkErrored(w http.ResponseWriter, r *http.Request) {
 func (h *handler[T]) handleMarkFailed(w http.ResponseWriter, r *http.Request) {
 	var payload apiclient.MarkErroredRequest
 
-	h.wrapHandler(w, r, &payload, func() (int, any, error) {
+	wrapHandler(w, r, &payload, func() (int, any, error) {
 		err := h.markFailed(r.Context(), payload.ExecutorName, payload.JobID, payload.ErrorMessage)
 		if err == ErrUnknownJob {
 			return http.StatusNotFound, nil, nil
@@ -134,7 +138,7 @@ func (h *handler[T]) handleMarkFailed(w http.ResponseWriter, r *http.Request) {
 func (h *handler[T]) handleHeartbeat(w http.ResponseWriter, r *http.Request) {
 	var payload apiclient.HeartbeatRequest
 
-	h.wrapHandler(w, r, &payload, func() (int, any, error) {
+	wrapHandler(w, r, &payload, func() (int, any, error) {
 		executor := types.Executor{
 			Hostname:        payload.ExecutorName,
 			QueueName:       h.QueueOptions.Name,
@@ -179,7 +183,7 @@ func (h *handler[T]) handleHeartbeat(w http.ResponseWriter, r *http.Request) {
 func (h *handler[T]) handleCanceledJobs(w http.ResponseWriter, r *http.Request) {
 	var payload apiclient.CanceledJobsRequest
 
-	h.wrapHandler(w, r, &payload, func() (int, any, error) {
+	wrapHandler(w, r, &payload, func() (int, any, error) {
 		canceledIDs, err := h.canceled(r.Context(), payload.ExecutorName, payload.KnownJobIDs)
 		return http.StatusOK, canceledIDs, err
 	})
@@ -195,7 +199,7 @@ type errorResponse struct {
 // is returned. Otherwise, the response status will match the status code value returned from the
 // handler, and the payload value returned from the handler is encoded and written to the
 // response body.
-func (h *handler[T]) wrapHandler(w http.ResponseWriter, r *http.Request, payload any, handler func() (int, any, error)) {
+func wrapHandler(w http.ResponseWriter, r *http.Request, payload any, handler func() (int, any, error)) {
 	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
 		http.Error(w, fmt.Sprintf("Failed to unmarshal payload: %s", err.Error()), http.StatusBadRequest)
 		return
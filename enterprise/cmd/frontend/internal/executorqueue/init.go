@@ -11,9 +11,12 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	metricsstore "github.com/sourcegraph/sourcegraph/internal/metrics/store"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/redispool"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/executorqueue/handler"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/executorqueue/logstream"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/executorqueue/queues/batches"
 	codeintelqueue "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/executorqueue/queues/codeintel"
 )
@@ -46,8 +49,14 @@ func Init(
 	// in the worker.
 	//
 	// Note: In order register a new queue type please change the validate() check code in enterprise/cmd/executor/config.go
-	codeintelHandler := handler.NewHandler(executorStore, metricsStore, codeintelqueue.QueueOptions(observationCtx, db, accessToken))
-	batchesHandler := handler.NewHandler(executorStore, metricsStore, batches.QueueOptions(observationCtx, db, accessToken))
+	logStream := logstream.NewStore(redispool.Store)
+	codeintelOptions := codeintelqueue.QueueOptions(observationCtx, db, accessToken, codeintel.ConfigInst.IndexMaxConcurrencyPerRepository)
+	codeintelOptions.LogStream = logStream
+	batchesOptions := batches.QueueOptions(observationCtx, db, accessToken)
+	batchesOptions.LogStream = logStream
+
+	codeintelHandler := handler.NewHandler(executorStore, metricsStore, codeintelOptions)
+	batchesHandler := handler.NewHandler(executorStore, metricsStore, batchesOptions)
 	queueOptions := []handler.ExecutorHandler{codeintelHandler, batchesHandler}
 
 	queueHandler := newExecutorQueueHandler(
@@ -58,8 +67,16 @@ func Init(
 		codeintelUploadHandler,
 		batchesWorkspaceFileGetHandler,
 		batchesWorkspaceFileExistsHandler,
+		enterpriseServices.ExecutorCacheHandler,
 	)
 
 	enterpriseServices.NewExecutorProxyHandler = queueHandler
+
+	// Users follow the output of running jobs through the regular API, so every queue
+	// needs to decide who may read the output of its jobs.
+	enterpriseServices.ExecutorLogStreamHandler = logstream.NewHandler(logStream, map[string]logstream.Authorizer{
+		codeintelHandler.Name(): codeintelqueue.AuthorizeLogStream(db),
+		batchesHandler.Name():   batches.AuthorizeLogStream(observationCtx, db),
+	})
 	return nil
 }
@@ -0,0 +1,147 @@
+package logstream
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ErrNotFound is returned by an Authorizer if the requested job does not exist.
+var ErrNotFound = errors.New("job not found")
+
+// Authorizer returns a nil error if the current user may read the output of the
+// job with the given ID.
+type Authorizer func(ctx context.Context, jobID int) error
+
+const (
+	// pollInterval is how often the store is checked for new output.
+	pollInterval = 500 * time.Millisecond
+
+	// maxEventBytes bounds the amount of output sent in a single event.
+	maxEventBytes = 256 * 1024
+)
+
+type streamHandler struct {
+	logger       log.Logger
+	store        *Store
+	authorizers  map[string]Authorizer
+	pollInterval time.Duration
+}
+
+// NewHandler returns a handler that streams the output of a running job to the
+// client as server-sent events. The handler expects the queueName and id route
+// variables to be set. Only queues with an authorizer can be streamed from.
+//
+// Every "chunks" event carries a list of chunks. Clients that reconnect pass the
+// sequence number of the next chunk they expect in the after query parameter. A
+// "done" event is sent once the job finished.
+//
+// Output is read from the store at the pace of the client, so a slow client only
+// falls behind, and does not cause output to be buffered in the frontend.
+func NewHandler(store *Store, authorizers map[string]Authorizer) http.Handler {
+	return &streamHandler{
+		logger:       log.Scoped("executor-log-stream", "Streams the output of running executor jobs"),
+		store:        store,
+		authorizers:  authorizers,
+		pollInterval: pollInterval,
+	}
+}
+
+// GET /.api/executors/{queueName}/jobs/{id}/logs/stream
+func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	queueName := mux.Vars(r)["queueName"]
+	authorize, ok := h.authorizers[queueName]
+	if !ok {
+		http.Error(w, "unknown queue", http.StatusNotFound)
+		return
+	}
+	jobID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "invalid job id", http.StatusBadRequest)
+		return
+	}
+	after := 0
+	if v := r.URL.Query().Get("after"); v != "" {
+		if after, err = strconv.Atoi(v); err != nil || after < 0 {
+			http.Error(w, "invalid after parameter", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// 🚨 SECURITY: Job output may contain secrets of the user who owns the job, so
+	// each queue decides who may read it.
+	if err := authorize(ctx, jobID); err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, ErrNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, auth.ErrNotAuthenticated):
+			status = http.StatusUnauthorized
+		case errors.Is(err, auth.ErrMustBeSiteAdmin) || errcode.IsUnauthorized(err):
+			status = http.StatusForbidden
+		default:
+			h.logger.Error("failed to authorize log stream", log.String("queue", queueName), log.Int("jobID", jobID), log.Error(err))
+		}
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	eventWriter, err := streamhttp.NewWriter(w)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.stream(ctx, eventWriter, queueName, jobID, after); err != nil && ctx.Err() == nil && !errors.Is(err, syscall.EPIPE) {
+		h.logger.Error("failed to stream job output", log.String("queue", queueName), log.Int("jobID", jobID), log.Error(err))
+	}
+}
+
+// stream sends the chunks of the given job starting at the given sequence number
+// until the final chunk was sent or the context is canceled.
+func (h *streamHandler) stream(ctx context.Context, eventWriter *streamhttp.Writer, queueName string, jobID, after int) error {
+	ticker := time.NewTicker(h.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		chunks, err := h.store.Read(ctx, queueName, jobID, after)
+		if err != nil {
+			return err
+		}
+
+		for len(chunks) > 0 {
+			n, size := 0, 0
+			for ; n < len(chunks) && (n == 0 || size+len(chunks[n].Out) <= maxEventBytes); n++ {
+				size += len(chunks[n].Out)
+			}
+
+			if err := eventWriter.Event("chunks", chunks[:n]); err != nil {
+				return err
+			}
+			if chunks[n-1].Done {
+				return eventWriter.Event("done", map[string]any{})
+			}
+
+			after += n
+			chunks = chunks[n:]
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
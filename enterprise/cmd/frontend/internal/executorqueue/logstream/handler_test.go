@@ -0,0 +1,88 @@
+package logstream
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/executor"
+	"github.com/sourcegraph/sourcegraph/internal/redispool"
+)
+
+func TestHandler(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore(redispool.MemoryKeyValue())
+
+	authorizers := map[string]Authorizer{
+		"batches": func(_ context.Context, jobID int) error {
+			switch jobID {
+			case 1:
+				return nil
+			case 2:
+				return auth.ErrMustBeSiteAdmin
+			default:
+				return ErrNotFound
+			}
+		},
+	}
+	h := NewHandler(store, authorizers).(*streamHandler)
+	h.pollInterval = time.Millisecond
+
+	router := mux.NewRouter()
+	router.Path("/{queueName}/jobs/{id}/logs/stream").Handler(h)
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		return w
+	}
+
+	for path, status := range map[string]int{
+		"/codeintel/jobs/1/logs/stream":       http.StatusNotFound,
+		"/batches/jobs/2/logs/stream":         http.StatusForbidden,
+		"/batches/jobs/3/logs/stream":         http.StatusNotFound,
+		"/batches/jobs/1/logs/stream?after=x": http.StatusBadRequest,
+	} {
+		if w := serve(path); w.Code != status {
+			t.Errorf("unexpected status for %s. want=%d have=%d", path, status, w.Code)
+		}
+	}
+
+	if err := store.Append(ctx, "batches", 1, 1, executor.ExecutionLogEntry{Key: "step.0", Out: "hello\n"}); err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		// Output written while the client is connected is picked up.
+		time.Sleep(10 * time.Millisecond)
+		_ = store.Append(ctx, "batches", 1, 1, executor.ExecutionLogEntry{Key: "step.0", Out: "hello\nworld\n"})
+		_ = store.Finish(ctx, "batches", 1)
+	}()
+
+	w := serve("/batches/jobs/1/logs/stream")
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status. want=%d have=%d", http.StatusOK, w.Code)
+	}
+	body := w.Body.String()
+	for _, want := range []string{
+		`{"seq":0,"key":"step.0","out":"hello\n"}`,
+		`{"seq":1,"key":"step.0","out":"world\n"}`,
+		`{"seq":2,"done":true}`,
+		"event: done\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected body to contain %q, got %q", want, body)
+		}
+	}
+
+	// Clients resuming a stream only receive the chunks they have not seen.
+	body = serve("/batches/jobs/1/logs/stream?after=2").Body.String()
+	if strings.Contains(body, `"seq":1`) || !strings.Contains(body, `{"seq":2,"done":true}`) {
+		t.Errorf("unexpected body for resumed stream %q", body)
+	}
+}
@@ -0,0 +1,340 @@
+package logstream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+
+	"github.com/sourcegraph/sourcegraph/internal/executor"
+	"github.com/sourcegraph/sourcegraph/internal/redispool"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// DefaultMaxBytes is the number of bytes of output that is kept for a single job.
+	// Output beyond this limit is dropped from the live stream, but is still stored
+	// in the execution logs of the job record.
+	DefaultMaxBytes = 8 * 1024 * 1024
+
+	// activeTTL is how long the stream of a job is kept without any writes. Every
+	// write extends it, so this only bounds how long abandoned streams linger.
+	activeTTL = time.Hour
+
+	// finishedTTL is how long the stream of a job is kept after it finished, so that
+	// clients connecting late can still replay it.
+	finishedTTL = 5 * time.Minute
+)
+
+// Chunk is a piece of output of a job, in the order it was written.
+type Chunk struct {
+	// Seq is the position of this chunk in the stream of the job. Clients resume a
+	// stream by passing the sequence number of the next chunk they expect.
+	Seq int `json:"seq"`
+
+	// Key is the key of the execution log entry this output belongs to.
+	Key string `json:"key,omitempty"`
+
+	// Out is the output written since the previous chunk of the same entry.
+	Out string `json:"out,omitempty"`
+
+	// ExitCode is set once the command of the entry exited.
+	ExitCode *int `json:"exitCode,omitempty"`
+
+	// Truncated is set on the chunk at which output started to be dropped.
+	Truncated bool `json:"truncated,omitempty"`
+
+	// Done is set on the final chunk of a job.
+	Done bool `json:"done,omitempty"`
+}
+
+// Store keeps the output of running executor jobs in redis, so that it can be
+// streamed to clients while the job is still running. Executors report the full
+// output of a log entry on every update; the store only keeps what was added since
+// the previous update.
+//
+// Updates read the state of the stream before writing to it. They run as scripts
+// on the redis server, so that concurrent updates from several frontend instances
+// do not interleave.
+type Store struct {
+	kv       redispool.KeyValue
+	maxBytes int
+
+	// mu serializes updates when redis is disabled. The store then lives in this
+	// process, and scripts are not available.
+	mu sync.Mutex
+}
+
+func NewStore(kv redispool.KeyValue) *Store {
+	return &Store{
+		kv:       kv,
+		maxBytes: DefaultMaxBytes,
+	}
+}
+
+// appendScript implements Append. KEYS are the chunks and state keys of the job,
+// ARGV are the entry ID, the entry key, the entry output, the exit code of the
+// entry or an empty string, the maximum number of bytes and the TTL in seconds.
+var appendScript = redis.NewScript(2, `
+local chunksKey, stateKey = KEYS[1], KEYS[2]
+local entryID, key, out, exitCode = ARGV[1], ARGV[2], ARGV[3], ARGV[4]
+local maxBytes, ttl = tonumber(ARGV[5]), ARGV[6]
+
+local function getInt(field)
+	return tonumber(redis.call('HGET', stateKey, field) or '0')
+end
+
+if getInt('done') ~= 0 or getInt('truncated') ~= 0 then
+	return 0
+end
+
+local offsetField = 'offset:' .. entryID
+local exitedField = 'exited:' .. entryID
+local offset = getInt(offsetField)
+local written = getInt('bytes')
+
+local chunk = {key = key}
+local added = string.sub(out, offset + 1)
+if #added > maxBytes - written then
+	added = string.sub(added, 1, maxBytes - written)
+	chunk.truncated = true
+end
+if added ~= '' then
+	chunk.out = added
+end
+if exitCode ~= '' and getInt(exitedField) == 0 then
+	chunk.exitCode = tonumber(exitCode)
+end
+if added == '' and chunk.exitCode == nil and not chunk.truncated then
+	return 0
+end
+
+redis.call('LPUSH', chunksKey, cjson.encode(chunk))
+redis.call('HSET', stateKey, offsetField, offset + #added)
+redis.call('HSET', stateKey, 'bytes', written + #added)
+if chunk.exitCode ~= nil then
+	redis.call('HSET', stateKey, exitedField, 1)
+end
+if chunk.truncated then
+	redis.call('HSET', stateKey, 'truncated', 1)
+end
+redis.call('EXPIRE', chunksKey, ttl)
+redis.call('EXPIRE', stateKey, ttl)
+return 1
+`)
+
+// Append records the output added to the given execution log entry of a job since
+// the last call for the same entry.
+func (s *Store) Append(ctx context.Context, queueName string, jobID, entryID int, entry executor.ExecutionLogEntry) error {
+	chunksKey, stateKey := keys(queueName, jobID)
+
+	if pool, ok := s.kv.Pool(); ok {
+		exitCode := ""
+		if entry.ExitCode != nil {
+			exitCode = strconv.Itoa(*entry.ExitCode)
+		}
+		return eval(ctx, pool, appendScript, chunksKey, stateKey, entryID, entry.Key, entry.Out, exitCode, s.maxBytes, int(activeTTL/time.Second))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	kv := s.kv.WithContext(ctx)
+
+	if stopped, err := s.stopped(kv, stateKey); err != nil || stopped {
+		return err
+	}
+
+	offsetField := fmt.Sprintf("offset:%d", entryID)
+	offset, err := getInt(kv, stateKey, offsetField)
+	if err != nil {
+		return err
+	}
+	written, err := getInt(kv, stateKey, "bytes")
+	if err != nil {
+		return err
+	}
+	exitedField := fmt.Sprintf("exited:%d", entryID)
+	exited, err := getInt(kv, stateKey, exitedField)
+	if err != nil {
+		return err
+	}
+
+	chunk := Chunk{Key: entry.Key}
+	if len(entry.Out) > offset {
+		chunk.Out = entry.Out[offset:]
+	}
+	if remaining := s.maxBytes - written; len(chunk.Out) > remaining {
+		chunk.Out = chunk.Out[:remaining]
+		chunk.Truncated = true
+	}
+	if entry.ExitCode != nil && exited == 0 {
+		chunk.ExitCode = entry.ExitCode
+	}
+	if chunk.Out == "" && chunk.ExitCode == nil && !chunk.Truncated {
+		return nil
+	}
+
+	if err := push(kv, chunksKey, chunk); err != nil {
+		return err
+	}
+
+	if err := kv.HSet(stateKey, offsetField, offset+len(chunk.Out)); err != nil {
+		return err
+	}
+	if err := kv.HSet(stateKey, "bytes", written+len(chunk.Out)); err != nil {
+		return err
+	}
+	if chunk.ExitCode != nil {
+		if err := kv.HSet(stateKey, exitedField, 1); err != nil {
+			return err
+		}
+	}
+	if chunk.Truncated {
+		if err := kv.HSet(stateKey, "truncated", 1); err != nil {
+			return err
+		}
+	}
+
+	return expire(kv, activeTTL, chunksKey, stateKey)
+}
+
+// finishScript implements Finish. KEYS are the chunks and state keys of the job,
+// ARGV are the final chunk and the TTL in seconds.
+var finishScript = redis.NewScript(2, `
+local chunksKey, stateKey = KEYS[1], KEYS[2]
+
+if tonumber(redis.call('HGET', stateKey, 'done') or '0') ~= 0 then
+	return 0
+end
+
+redis.call('LPUSH', chunksKey, ARGV[1])
+redis.call('HSET', stateKey, 'done', 1)
+redis.call('EXPIRE', chunksKey, ARGV[2])
+redis.call('EXPIRE', stateKey, ARGV[2])
+return 1
+`)
+
+// Finish marks the stream of the given job as complete. Clients following the
+// stream stop once they have read the final chunk.
+func (s *Store) Finish(ctx context.Context, queueName string, jobID int) error {
+	chunksKey, stateKey := keys(queueName, jobID)
+
+	if pool, ok := s.kv.Pool(); ok {
+		value, err := json.Marshal(Chunk{Done: true})
+		if err != nil {
+			return err
+		}
+		return eval(ctx, pool, finishScript, chunksKey, stateKey, value, int(finishedTTL/time.Second))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	kv := s.kv.WithContext(ctx)
+
+	if done, err := getInt(kv, stateKey, "done"); err != nil || done != 0 {
+		return err
+	}
+	if err := push(kv, chunksKey, Chunk{Done: true}); err != nil {
+		return err
+	}
+	if err := kv.HSet(stateKey, "done", 1); err != nil {
+		return err
+	}
+
+	return expire(kv, finishedTTL, chunksKey, stateKey)
+}
+
+// Reset drops the stream of the given job. This is called when a job is handed to
+// an executor, so that the output of a retried job does not mix with that of its
+// previous attempt.
+func (s *Store) Reset(ctx context.Context, queueName string, jobID int) error {
+	kv := s.kv.WithContext(ctx)
+	chunksKey, stateKey := keys(queueName, jobID)
+
+	if err := kv.Del(chunksKey); err != nil {
+		return err
+	}
+	return kv.Del(stateKey)
+}
+
+// Read returns the chunks of the given job with a sequence number of at least
+// after, in order.
+func (s *Store) Read(ctx context.Context, queueName string, jobID, after int) ([]Chunk, error) {
+	chunksKey, _ := keys(queueName, jobID)
+
+	// Chunks are pushed to the head of the list, so the chunk with sequence number n
+	// is at index -(n+1). Reading up to that index in a single command returns a
+	// consistent view, even while chunks are being pushed concurrently.
+	values, err := s.kv.WithContext(ctx).LRange(chunksKey, 0, -(after + 1)).ByteSlices()
+	if err != nil {
+		return nil, errors.Wrap(err, "reading log chunks")
+	}
+
+	chunks := make([]Chunk, len(values))
+	for i, value := range values {
+		j := len(values) - 1 - i
+		if err := json.Unmarshal(value, &chunks[j]); err != nil {
+			return nil, errors.Wrap(err, "decoding log chunk")
+		}
+		chunks[j].Seq = after + j
+	}
+
+	return chunks, nil
+}
+
+// stopped returns true if no further output is recorded for the job, either
+// because it finished or because it reached the output limit.
+func (s *Store) stopped(kv redispool.KeyValue, stateKey string) (bool, error) {
+	for _, field := range []string{"done", "truncated"} {
+		v, err := getInt(kv, stateKey, field)
+		if err != nil || v != 0 {
+			return v != 0, err
+		}
+	}
+	return false, nil
+}
+
+func keys(queueName string, jobID int) (chunksKey, stateKey string) {
+	prefix := "executor-logs:" + queueName + ":" + strconv.Itoa(jobID)
+	return prefix + ":chunks", prefix + ":state"
+}
+
+func eval(ctx context.Context, pool *redis.Pool, script *redis.Script, keysAndArgs ...any) error {
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = script.Do(conn, keysAndArgs...)
+	return errors.Wrap(err, "updating log stream")
+}
+
+func push(kv redispool.KeyValue, chunksKey string, chunk Chunk) error {
+	value, err := json.Marshal(chunk)
+	if err != nil {
+		return err
+	}
+	return kv.LPush(chunksKey, value)
+}
+
+func getInt(kv redispool.KeyValue, key, field string) (int, error) {
+	v, err := kv.HGet(key, field).Int()
+	if err == redis.ErrNil {
+		return 0, nil
+	}
+	return v, err
+}
+
+func expire(kv redispool.KeyValue, ttl time.Duration, keys ...string) error {
+	for _, key := range keys {
+		if err := kv.Expire(key, int(ttl/time.Second)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+package logstream
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/executor"
+	"github.com/sourcegraph/sourcegraph/internal/redispool"
+)
+
+func TestStore(t *testing.T) {
+	forEachKeyValue(t, func(t *testing.T, kv redispool.KeyValue) {
+		ctx := context.Background()
+		store := NewStore(kv)
+
+		exitCode := 0
+		appends := []struct {
+			entryID int
+			entry   executor.ExecutionLogEntry
+		}{
+			{1, executor.ExecutionLogEntry{Key: "setup"}},
+			{1, executor.ExecutionLogEntry{Key: "setup", Out: "cloning\n"}},
+			{1, executor.ExecutionLogEntry{Key: "setup", Out: "cloning\ndone\n", ExitCode: &exitCode}},
+			{1, executor.ExecutionLogEntry{Key: "setup", Out: "cloning\ndone\n", ExitCode: &exitCode}},
+			{2, executor.ExecutionLogEntry{Key: "step.0", Out: "hello\n"}},
+		}
+		for _, a := range appends {
+			if err := store.Append(ctx, "batches", 42, a.entryID, a.entry); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := store.Finish(ctx, "batches", 42); err != nil {
+			t.Fatal(err)
+		}
+		// Output reported after the job finished is ignored.
+		if err := store.Append(ctx, "batches", 42, 2, executor.ExecutionLogEntry{Key: "step.0", Out: "hello\nlate\n"}); err != nil {
+			t.Fatal(err)
+		}
+
+		chunks, err := store.Read(ctx, "batches", 42, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []Chunk{
+			{Seq: 0, Key: "setup", Out: "cloning\n"},
+			{Seq: 1, Key: "setup", Out: "done\n", ExitCode: &exitCode},
+			{Seq: 2, Key: "step.0", Out: "hello\n"},
+			{Seq: 3, Done: true},
+		}
+		if diff := cmp.Diff(want, chunks); diff != "" {
+			t.Errorf("unexpected chunks (-want +got):\n%s", diff)
+		}
+
+		chunks, err = store.Read(ctx, "batches", 42, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want[2:], chunks); diff != "" {
+			t.Errorf("unexpected chunks (-want +got):\n%s", diff)
+		}
+
+		chunks, err = store.Read(ctx, "batches", 42, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(chunks) != 0 {
+			t.Errorf("expected no chunks, got %v", chunks)
+		}
+
+		// Other jobs and queues have their own streams.
+		chunks, err = store.Read(ctx, "codeintel", 42, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(chunks) != 0 {
+			t.Errorf("expected no chunks, got %v", chunks)
+		}
+
+		if err := store.Reset(ctx, "batches", 42); err != nil {
+			t.Fatal(err)
+		}
+		chunks, err = store.Read(ctx, "batches", 42, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(chunks) != 0 {
+			t.Errorf("expected no chunks after reset, got %v", chunks)
+		}
+	})
+}
+
+func TestStoreTruncation(t *testing.T) {
+	forEachKeyValue(t, func(t *testing.T, kv redispool.KeyValue) {
+		ctx := context.Background()
+		store := NewStore(kv)
+		store.maxBytes = 10
+
+		outs := []string{"0123456", "0123456789abc", "0123456789abcdef"}
+		for _, out := range outs {
+			if err := store.Append(ctx, "codeintel", 1, 1, executor.ExecutionLogEntry{Key: "step", Out: out}); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		chunks, err := store.Read(ctx, "codeintel", 1, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []Chunk{
+			{Seq: 0, Key: "step", Out: "0123456"},
+			{Seq: 1, Key: "step", Out: "789", Truncated: true},
+		}
+		if diff := cmp.Diff(want, chunks); diff != "" {
+			t.Errorf("unexpected chunks (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestStoreConcurrentAppends(t *testing.T) {
+	forEachKeyValue(t, func(t *testing.T, kv redispool.KeyValue) {
+		ctx := context.Background()
+		store := NewStore(yieldingKeyValue{kv})
+		store.maxBytes = 505
+
+		// Entries of the same job are updated concurrently. Every update must see the
+		// bytes written by the others, or the output limit is exceeded.
+		start := make(chan struct{})
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func(entryID int) {
+				defer wg.Done()
+				<-start
+				if err := store.Append(ctx, "batches", 42, entryID, executor.ExecutionLogEntry{Key: "step", Out: "0123456789"}); err != nil {
+					t.Error(err)
+				}
+			}(i)
+		}
+		close(start)
+		wg.Wait()
+
+		chunks, err := store.Read(ctx, "batches", 42, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		written, truncated := 0, 0
+		for _, chunk := range chunks {
+			written += len(chunk.Out)
+			if chunk.Truncated {
+				truncated++
+			}
+		}
+		if written != 505 {
+			t.Errorf("unexpected number of bytes written. want=%d have=%d", 505, written)
+		}
+		if truncated != 1 {
+			t.Errorf("unexpected number of truncated chunks. want=%d have=%d", 1, truncated)
+		}
+	})
+}
+
+// yieldingKeyValue yields to other goroutines before every read, so that
+// concurrent updates interleave.
+type yieldingKeyValue struct {
+	redispool.KeyValue
+}
+
+func (kv yieldingKeyValue) HGet(key, field string) redispool.Value {
+	runtime.Gosched()
+	return kv.KeyValue.HGet(key, field)
+}
+
+func (kv yieldingKeyValue) WithContext(ctx context.Context) redispool.KeyValue {
+	return yieldingKeyValue{kv.KeyValue.WithContext(ctx)}
+}
+
+// forEachKeyValue runs the given test against an in memory store and, if it is
+// available, against redis.
+func forEachKeyValue(t *testing.T, test func(t *testing.T, kv redispool.KeyValue)) {
+	t.Run("memory", func(t *testing.T) { test(t, redispool.MemoryKeyValue()) })
+	t.Run("redis", func(t *testing.T) { test(t, redisKeyValueForTest(t)) })
+}
+
+// redisKeyValueForTest returns a KeyValue backed by a local redis, in which the
+// streams used by the tests of this package are cleared.
+func redisKeyValueForTest(t *testing.T) redispool.KeyValue {
+	t.Helper()
+
+	pool := &redis.Pool{
+		MaxIdle:     3,
+		IdleTimeout: 240 * time.Second,
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", "127.0.0.1:6379")
+		},
+		TestOnBorrow: func(c redis.Conn, t time.Time) error {
+			_, err := c.Do("PING")
+			return err
+		},
+	}
+
+	c := pool.Get()
+	defer c.Close()
+
+	// If we are not on CI, skip the test if our redis connection fails.
+	if os.Getenv("CI") == "" {
+		_, err := c.Do("PING")
+		if err != nil {
+			t.Skip("could not connect to redis", err)
+		}
+	}
+
+	for _, queueName := range []string{"batches", "codeintel"} {
+		for _, jobID := range []int{1, 42} {
+			chunksKey, stateKey := keys(queueName, jobID)
+			if _, err := c.Do("DEL", chunksKey, stateKey); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	return redispool.RedisKeyValue(pool)
+}
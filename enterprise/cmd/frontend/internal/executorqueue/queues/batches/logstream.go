@@ -0,0 +1,32 @@
+package batches
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/executorqueue/logstream"
+	bstore "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// AuthorizeLogStream allows the user who created a batch spec execution, and site
+// admins, to follow the output of its workspace jobs.
+func AuthorizeLogStream(observationCtx *observation.Context, db database.DB) logstream.Authorizer {
+	return func(ctx context.Context, jobID int) error {
+		batchesStore := bstore.New(db, observationCtx, nil)
+		job, err := batchesStore.GetBatchSpecWorkspaceExecutionJob(ctx, bstore.GetBatchSpecWorkspaceExecutionJobOpts{
+			ID:          int64(jobID),
+			ExcludeRank: true,
+		})
+		if err != nil {
+			if err == bstore.ErrNoResults {
+				return logstream.ErrNotFound
+			}
+			return errors.Wrap(err, "getting batch spec workspace execution job")
+		}
+
+		return auth.CheckSiteAdminOrSameUser(ctx, db, job.UserID)
+	}
+}
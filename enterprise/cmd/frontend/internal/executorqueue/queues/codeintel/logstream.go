@@ -0,0 +1,17 @@
+package codeintel
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/executorqueue/logstream"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+// AuthorizeLogStream allows site admins to follow the output of auto-indexing jobs.
+// This matches the visibility of execution logs of indexes in the API.
+func AuthorizeLogStream(db database.DB) logstream.Authorizer {
+	return func(ctx context.Context, _ int) error {
+		return auth.CheckCurrentUserIsSiteAdmin(ctx, db)
+	}
+}
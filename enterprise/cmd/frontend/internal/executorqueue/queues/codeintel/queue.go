@@ -12,12 +12,17 @@ import (
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
 )
 
-func QueueOptions(observationCtx *observation.Context, db database.DB, accessToken func() string) handler.QueueOptions[types.Index] {
+// QueueOptions returns the options of the queue of auto-indexing jobs. At most
+// maxConcurrencyPerRepository jobs of the same repository are dequeued at once,
+// so that a repository with many queued jobs doesn't hold up other repositories.
+func QueueOptions(observationCtx *observation.Context, db database.DB, accessToken func() string, maxConcurrencyPerRepository int) handler.QueueOptions[types.Index] {
 	recordTransformer := func(ctx context.Context, _ string, record types.Index, resourceMetadata handler.ResourceMetadata) (apiclient.Job, error) {
 		return transformRecord(ctx, db, record, resourceMetadata, accessToken())
 	}
 
-	store := dbworkerstore.New(observationCtx, db.Handle(), autoindexing.IndexWorkerStoreOptions)
+	storeOptions := autoindexing.IndexWorkerStoreOptions
+	storeOptions.MaxConcurrencyPerKey = maxConcurrencyPerRepository
+	store := dbworkerstore.New(observationCtx, db.Handle(), storeOptions)
 
 	return handler.QueueOptions[types.Index]{
 		Name:              "codeintel",
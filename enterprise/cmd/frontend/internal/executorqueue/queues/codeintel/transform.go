@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path"
 	"strconv"
 	"strings"
 
@@ -157,6 +158,7 @@ func transformRecord(ctx context.Context, db database.DB, index types.Index, res
 		ShallowClone:   true,
 		FetchTags:      fetchTags,
 		DockerSteps:    dockerSteps,
+		Caches:         transformCaches(index),
 		RedactedValues: allRedactedValues,
 	}
 
@@ -184,6 +186,35 @@ func transformRecord(ctx context.Context, db database.DB, index types.Index, res
 	return aj, nil
 }
 
+// transformCaches converts the caches declared by the index job into paths relative
+// to the workspace. Cache keys are scoped to the repository, so that jobs of one
+// repository never restore files written by the jobs of another.
+func transformCaches(index types.Index) []apiclient.CacheSpec {
+	if len(index.Caches) == 0 {
+		return nil
+	}
+
+	caches := make([]apiclient.CacheSpec, 0, len(index.Caches))
+	for _, cache := range index.Caches {
+		keyFiles := make([]string, 0, len(cache.KeyFiles))
+		for _, keyFile := range cache.KeyFiles {
+			keyFiles = append(keyFiles, path.Join(index.Root, keyFile))
+		}
+		paths := make([]string, 0, len(cache.Paths))
+		for _, p := range cache.Paths {
+			paths = append(paths, path.Join(index.Root, p))
+		}
+
+		caches = append(caches, apiclient.CacheSpec{
+			Key:      fmt.Sprintf("%d/%s", index.RepositoryID, cache.Key),
+			KeyFiles: keyFiles,
+			Paths:    paths,
+		})
+	}
+
+	return caches
+}
+
 const (
 	defaultMemory    = "12G"
 	defaultDiskSpace = "20G"
@@ -424,3 +424,20 @@ func TestTransformRecordDockerAuthConfig(t *testing.T) {
 		t.Errorf("unexpected job (-want +got):\n%s", diff)
 	}
 }
+
+func TestTransformCaches(t *testing.T) {
+	index := types.Index{
+		RepositoryID: 50,
+		Root:         "lib",
+		Caches: []types.IndexCache{
+			{Key: "go-mod", KeyFiles: []string{"go.sum"}, Paths: []string{".sourcegraph-cache/go-mod"}},
+		},
+	}
+
+	expected := []apiclient.CacheSpec{
+		{Key: "50/go-mod", KeyFiles: []string{"lib/go.sum"}, Paths: []string{"lib/.sourcegraph-cache/go-mod"}},
+	}
+	if diff := cmp.Diff(expected, transformCaches(index)); diff != "" {
+		t.Errorf("unexpected caches (-want +got):\n%s", diff)
+	}
+}
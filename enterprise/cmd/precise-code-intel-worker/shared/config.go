@@ -11,11 +11,12 @@ import (
 type Config struct {
 	env.BaseConfig
 
-	WorkerPollInterval    time.Duration
-	WorkerConcurrency     int
-	WorkerBudget          int64
-	MaximumRuntimePerJob  time.Duration
-	LSIFUploadStoreConfig *lsifuploadstore.Config
+	WorkerPollInterval                time.Duration
+	WorkerConcurrency                 int
+	WorkerMaxConcurrencyPerRepository int
+	WorkerBudget                      int64
+	MaximumRuntimePerJob              time.Duration
+	LSIFUploadStoreConfig             *lsifuploadstore.Config
 }
 
 func (c *Config) Load() {
@@ -24,6 +25,7 @@ func (c *Config) Load() {
 
 	c.WorkerPollInterval = c.GetInterval("PRECISE_CODE_INTEL_WORKER_POLL_INTERVAL", "1s", "Interval between queries to the upload queue.")
 	c.WorkerConcurrency = c.GetInt("PRECISE_CODE_INTEL_WORKER_CONCURRENCY", "1", "The maximum number of indexes that can be processed concurrently.")
+	c.WorkerMaxConcurrencyPerRepository = c.GetInt("PRECISE_CODE_INTEL_WORKER_MAX_CONCURRENCY_PER_REPOSITORY", "2", "The maximum number of uploads of the same repository that can be processed concurrently. Zero disables the limit.")
 	c.WorkerBudget = int64(c.GetInt("PRECISE_CODE_INTEL_WORKER_BUDGET", "0", "The amount of compressed input data (in bytes) a worker can process concurrently. Zero acts as an infinite budget."))
 	c.MaximumRuntimePerJob = c.GetInterval("PRECISE_CODE_INTEL_WORKER_MAXIMUM_RUNTIME_PER_JOB", "25m", "The maximum time a single LSIF processing job can take.")
 }
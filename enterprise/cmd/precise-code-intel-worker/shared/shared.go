@@ -86,6 +86,7 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 		db,
 		uploadStore,
 		config.WorkerConcurrency,
+		config.WorkerMaxConcurrencyPerRepository,
 		config.WorkerBudget,
 		config.WorkerPollInterval,
 		config.MaximumRuntimePerJob,
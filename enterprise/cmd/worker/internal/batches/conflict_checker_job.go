@@ -0,0 +1,48 @@
+package batches
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/reconciler"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type conflictCheckerJob struct{}
+
+func NewConflictCheckerJob() job.Job {
+	return &conflictCheckerJob{}
+}
+
+func (j *conflictCheckerJob) Description() string {
+	return ""
+}
+
+func (j *conflictCheckerJob) Config() []env.Config {
+	return []env.Config{}
+}
+
+func (j *conflictCheckerJob) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	observationCtx = observation.NewContext(observationCtx.Logger.Scoped("routines", "conflict checker job routines"))
+	workCtx := actor.WithInternalActor(context.Background())
+
+	bstore, err := InitStore()
+	if err != nil {
+		return nil, err
+	}
+
+	routines := []goroutine.BackgroundRoutine{
+		reconciler.NewConflictChecker(
+			workCtx,
+			observationCtx.Logger.Scoped("ConflictChecker", "checks open changesets for conflicts with their base branch"),
+			bstore,
+			gitserver.NewClient(),
+		),
+	}
+
+	return routines, nil
+}
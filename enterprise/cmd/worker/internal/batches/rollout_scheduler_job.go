@@ -0,0 +1,46 @@
+package batches
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/scheduler"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type rolloutSchedulerJob struct{}
+
+func NewRolloutSchedulerJob() job.Job {
+	return &rolloutSchedulerJob{}
+}
+
+func (j *rolloutSchedulerJob) Description() string {
+	return ""
+}
+
+func (j *rolloutSchedulerJob) Config() []env.Config {
+	return []env.Config{}
+}
+
+func (j *rolloutSchedulerJob) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	observationCtx = observation.NewContext(observationCtx.Logger.Scoped("routines", "rollout scheduler job routines"))
+	workCtx := actor.WithInternalActor(context.Background())
+
+	bstore, err := InitStore()
+	if err != nil {
+		return nil, err
+	}
+
+	routines := []goroutine.BackgroundRoutine{
+		scheduler.NewRolloutScheduler(
+			workCtx,
+			observationCtx.Logger.Scoped("RolloutScheduler", "merges the changesets of changeset rollouts wave by wave"),
+			bstore,
+		),
+	}
+
+	return routines, nil
+}
@@ -47,16 +47,17 @@ func (r *batchSpecWorkspaceCreator) HandlerFunc() workerutil.HandlerFunc[*btypes
 	}
 }
 
-type stepCacheKey struct {
-	index int
-	key   string
-}
-
 type workspaceCacheKey struct {
-	dbWorkspace   *btypes.BatchSpecWorkspace
-	repo          batcheslib.Repository
-	stepCacheKeys []stepCacheKey
-	skippedSteps  map[int]struct{}
+	dbWorkspace  *btypes.BatchSpecWorkspace
+	repo         batcheslib.Repository
+	skippedSteps map[int]struct{}
+
+	// steps are the indexes of the steps that are not statically skipped.
+	steps []int
+	// previousResult is the result of the last step found in the cache.
+	previousResult execution.AfterStepResult
+	// nextKey is the cache key of the step steps[0].
+	nextKey string
 }
 
 // process runs one workspace creation run for the given job utilizing the given
@@ -114,9 +115,8 @@ func (r *batchSpecWorkspaceCreator) process(
 
 	// Build DB workspaces and check for cache entries.
 	ws := make([]*btypes.BatchSpecWorkspace, 0, len(workspaces))
-	// Collect all cache keys so we can look them up in a single query.
-	cacheKeyWorkspaces := make([]workspaceCacheKey, 0, len(workspaces))
-	allStepCacheKeys := make([]string, 0, len(workspaces))
+	cacheKeyWorkspaces := make([]*workspaceCacheKey, 0, len(workspaces))
+	cacheStats := &btypes.BatchSpecExecutionCacheStats{BatchSpecID: spec.ID}
 	// load the mounts from the DB up front to avoid duplicate calls with no difference in data
 	mounts, err := listBatchSpecMounts(ctx, r.store, spec.ID)
 	if err != nil {
@@ -163,62 +163,108 @@ func (r *batchSpecWorkspaceCreator) process(
 			return err
 		}
 
-		stepCacheKeys := make([]stepCacheKey, 0, len(spec.Spec.Steps))
-		// Generate cache keys for all the steps.
+		steps := make([]int, 0, len(spec.Spec.Steps))
 		for i := 0; i < len(spec.Spec.Steps); i++ {
-			if _, ok := skippedSteps[i]; ok {
+			if _, ok := skippedSteps[i]; !ok {
+				steps = append(steps, i)
+			}
+		}
+
+		cacheKeyWorkspaces = append(cacheKeyWorkspaces, &workspaceCacheKey{
+			dbWorkspace:  workspace,
+			repo:         repo,
+			skippedSteps: skippedSteps,
+			steps:        steps,
+		})
+		cacheStats.Steps += int32(len(steps))
+	}
+
+	// Collect all IDs of used cache entries to mark them as recently used later.
+	usedCacheEntries := []int64{}
+
+	// The cache key of a step is derived from the result of the step before it,
+	// so the cache is queried for one step of all workspaces at a time, until no
+	// more cached results are found.
+	pending := cacheKeyWorkspaces
+	for len(pending) > 0 {
+		keys := make([]string, 0, len(pending))
+		lookups := pending[:0:0]
+		for _, workspace := range pending {
+			if len(workspace.steps) == 0 {
 				continue
 			}
 
-			key := cache.KeyForWorkspace(
+			key, err := cache.KeyForStep(
 				&template.BatchChangeAttributes{
 					Name:        spec.Spec.Name,
 					Description: spec.Spec.Description,
 				},
-				repo,
-				w.Path,
+				workspace.repo,
+				workspace.dbWorkspace.Path,
 				envVars,
-				w.OnlyFetchWorkspace,
-				spec.Spec.Steps,
-				i,
+				workspace.dbWorkspace.OnlyFetchWorkspace,
+				spec.Spec.Steps[workspace.steps[0]],
+				workspace.previousResult,
 				retriever,
-			)
-
-			rawStepKey, err := key.Key()
+			).Key()
 			if err != nil {
 				return err
 			}
 
-			stepCacheKeys = append(stepCacheKeys, stepCacheKey{index: i, key: rawStepKey})
-			allStepCacheKeys = append(allStepCacheKeys, rawStepKey)
+			workspace.nextKey = key
+			keys = append(keys, key)
+			lookups = append(lookups, workspace)
+		}
+		if len(keys) == 0 {
+			break
 		}
 
-		cacheKeyWorkspaces = append(cacheKeyWorkspaces, workspaceCacheKey{
-			dbWorkspace:   workspace,
-			repo:          repo,
-			stepCacheKeys: stepCacheKeys,
-			skippedSteps:  skippedSteps,
-		})
-	}
-
-	stepEntriesByCacheKey := make(map[string]*btypes.BatchSpecExecutionCacheEntry, len(allStepCacheKeys))
-	if len(allStepCacheKeys) > 0 {
+		// Content addressed keys can only be produced with access to the
+		// repository and secrets the cached result was derived from, so entries
+		// are shared between users.
 		entries, err := r.store.ListBatchSpecExecutionCacheEntries(ctx, store.ListBatchSpecExecutionCacheEntriesOpts{
-			UserID: spec.UserID,
-			Keys:   allStepCacheKeys,
+			Keys:    keys,
+			AnyUser: true,
 		})
 		if err != nil {
 			return err
 		}
+		entriesByKey := make(map[string]*btypes.BatchSpecExecutionCacheEntry, len(entries))
 		for _, entry := range entries {
-			stepEntriesByCacheKey[entry.Key] = entry
+			entriesByKey[entry.Key] = entry
+		}
+
+		pending = lookups[:0]
+		for _, workspace := range lookups {
+			c, ok := entriesByKey[workspace.nextKey]
+			if !ok {
+				// Only add cache entries up until we don't have the cache entry
+				// for the previous step anymore.
+				continue
+			}
+
+			var res execution.AfterStepResult
+			if err := json.Unmarshal([]byte(c.Value), &res); err != nil {
+				return err
+			}
+			// The entry may have been created by a step at a different position
+			// in another batch spec.
+			idx := workspace.steps[0]
+			res.StepIndex = idx
+			workspace.dbWorkspace.SetStepCacheResult(idx+1, btypes.StepCacheResult{Key: c.Key, Value: &res})
+
+			// Mark the cache entry as used.
+			usedCacheEntries = append(usedCacheEntries, c.ID)
+			cacheStats.CachedSteps++
+
+			workspace.previousResult = res
+			workspace.steps = workspace.steps[1:]
+			pending = append(pending, workspace)
 		}
 	}
 
 	// All changeset specs to be created.
 	cs := []*btypes.ChangesetSpec{}
-	// Collect all IDs of used cache entries to mark them as recently used later.
-	usedCacheEntries := []int64{}
 	changesetsByWorkspace := make(map[*btypes.BatchSpecWorkspace][]*btypes.ChangesetSpec)
 
 	changesetAuthor, err := author.GetChangesetAuthorForUser(ctx, database.UsersWith(r.logger, r.store), spec.UserID)
@@ -226,27 +272,8 @@ func (r *batchSpecWorkspaceCreator) process(
 		return err
 	}
 
-	// Check for an existing cache entry for each of the workspaces.
+	// Build changeset specs for the workspaces whose results are all cached.
 	for _, workspace := range cacheKeyWorkspaces {
-		for _, ck := range workspace.stepCacheKeys {
-			key := ck.key
-			idx := ck.index
-			if c, ok := stepEntriesByCacheKey[key]; ok {
-				var res execution.AfterStepResult
-				if err := json.Unmarshal([]byte(c.Value), &res); err != nil {
-					return err
-				}
-				workspace.dbWorkspace.SetStepCacheResult(idx+1, btypes.StepCacheResult{Key: key, Value: &res})
-
-				// Mark the cache entry as used.
-				usedCacheEntries = append(usedCacheEntries, c.ID)
-			} else {
-				// Only add cache entries up until we don't have the cache entry
-				// for the previous step anymore.
-				break
-			}
-		}
-
 		// Validate there is anything to run. If not, we skip execution.
 		// TODO: In the future, move this to a separate field, so we can
 		// tell the two cases apart.
@@ -322,6 +349,10 @@ func (r *batchSpecWorkspaceCreator) process(
 		return err
 	}
 
+	if err := tx.UpsertBatchSpecExecutionCacheStats(ctx, cacheStats); err != nil {
+		return err
+	}
+
 	if err = tx.CreateChangesetSpec(ctx, cs...); err != nil {
 		return err
 	}
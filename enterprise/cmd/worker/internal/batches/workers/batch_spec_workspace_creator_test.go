@@ -249,7 +249,7 @@ func TestBatchSpecWorkspaceCreatorProcess_Caching(t *testing.T) {
 	createCacheEntry := func(t *testing.T, batchSpec *btypes.BatchSpec, workspace *service.RepoWorkspace, result *execution.AfterStepResult, envVarValue string, mounts []*btypes.BatchSpecWorkspaceFile) *btypes.BatchSpecExecutionCacheEntry {
 		t.Helper()
 
-		key := cache.KeyForWorkspace(
+		key := cache.KeyForStep(
 			&template.BatchChangeAttributes{
 				Name:        batchSpec.Spec.Name,
 				Description: batchSpec.Spec.Description,
@@ -264,8 +264,8 @@ func TestBatchSpecWorkspaceCreatorProcess_Caching(t *testing.T) {
 			workspace.Path,
 			[]string{fmt.Sprintf("FOO=%s", envVarValue)},
 			workspace.OnlyFetchWorkspace,
-			batchSpec.Spec.Steps,
-			result.StepIndex,
+			batchSpec.Spec.Steps[result.StepIndex],
+			execution.AfterStepResult{},
 			&remoteFileMetadataRetriever{mounts: mounts},
 		)
 		rawKey, err := key.Key()
@@ -351,6 +351,79 @@ func TestBatchSpecWorkspaceCreatorProcess_Caching(t *testing.T) {
 		}
 	})
 
+	t.Run("cached step shared across batch specs", func(t *testing.T) {
+		workspace := buildWorkspace("caching-shared")
+
+		// The first step was executed as part of another batch spec of another
+		// user before.
+		otherUser := bt.CreateTestUser(t, db, false)
+		otherBatchSpec := createBatchSpec(t, false, bt.TestRawBatchSpecYAML)
+		otherBatchSpec.UserID = otherUser.ID
+		entry := createCacheEntry(t, otherBatchSpec, workspace, executionResult, secretValue, nil)
+
+		spec := `
+name: my-unique-name
+description: My description
+'on':
+- repositoriesMatchingQuery: lang:go func main
+- repository: github.com/sourcegraph/src-cli
+steps:
+- run: echo 'foobar'
+  container: alpine
+  env:
+    - PATH: "/work/foobar:$PATH"
+    - FOO
+- run: echo 'this step changed'
+  container: alpine
+changesetTemplate:
+  title: Hello World
+  body: My first batch change!
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+`
+		batchSpec := createBatchSpec(t, false, spec)
+
+		resolver := &dummyWorkspaceResolver{workspaces: []*service.RepoWorkspace{workspace}}
+		job := &btypes.BatchSpecResolutionJob{BatchSpecID: batchSpec.ID}
+		if err := creator.process(userCtx, resolver.DummyBuilder, job); err != nil {
+			t.Fatalf("proces failed: %s", err)
+		}
+
+		have, _, err := s.ListBatchSpecWorkspaces(context.Background(), store.ListBatchSpecWorkspacesOpts{BatchSpecID: batchSpec.ID})
+		if err != nil {
+			t.Fatalf("listing workspaces failed: %s", err)
+		}
+
+		assertWorkspacesEqual(t, have, []*btypes.BatchSpecWorkspace{
+			{
+				RepoID:             repos[0].ID,
+				BatchSpecID:        batchSpec.ID,
+				ChangesetSpecIDs:   []int64{},
+				Branch:             "refs/heads/main",
+				Commit:             "caching-shared",
+				FileMatches:        []string{},
+				Path:               "",
+				OnlyFetchWorkspace: true,
+				CachedResultFound:  false,
+				StepCacheResults: map[int]btypes.StepCacheResult{
+					1: {
+						Key:   entry.Key,
+						Value: executionResult,
+					},
+				},
+			},
+		})
+
+		stats, err := s.GetBatchSpecExecutionCacheStats(context.Background(), now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stats.CachedSteps == 0 || stats.Steps <= stats.CachedSteps {
+			t.Fatalf("unexpected cache stats: %+v", stats)
+		}
+	})
+
 	t.Run("secret value changed", func(t *testing.T) {
 		workspace := buildWorkspace("secret-value-changed")
 
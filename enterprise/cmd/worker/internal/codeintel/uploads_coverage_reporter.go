@@ -0,0 +1,37 @@
+package codeintel
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/shared/init/codeintel"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type coverageReporterJob struct{}
+
+func NewCoverageReporterJob() job.Job {
+	return &coverageReporterJob{}
+}
+
+func (j *coverageReporterJob) Description() string {
+	return ""
+}
+
+func (j *coverageReporterJob) Config() []env.Config {
+	return []env.Config{
+		uploads.ConfigCodeIntelCoverageInst,
+	}
+}
+
+func (j *coverageReporterJob) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	services, err := codeintel.InitServices(observationCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	return uploads.NewCodeIntelCoverageReporters(observationCtx, services.UploadsService), nil
+}
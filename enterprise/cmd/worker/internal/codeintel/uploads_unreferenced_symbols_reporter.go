@@ -0,0 +1,37 @@
+package codeintel
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/shared/init/codeintel"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type unreferencedSymbolsReporterJob struct{}
+
+func NewUnreferencedSymbolsReporterJob() job.Job {
+	return &unreferencedSymbolsReporterJob{}
+}
+
+func (j *unreferencedSymbolsReporterJob) Description() string {
+	return ""
+}
+
+func (j *unreferencedSymbolsReporterJob) Config() []env.Config {
+	return []env.Config{
+		uploads.ConfigUnreferencedSymbolsInst,
+	}
+}
+
+func (j *unreferencedSymbolsReporterJob) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	services, err := codeintel.InitServices(observationCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	return uploads.NewUnreferencedSymbolsReporters(observationCtx, services.UploadsService), nil
+}
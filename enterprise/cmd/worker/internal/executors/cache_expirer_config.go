@@ -0,0 +1,34 @@
+package executors
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/lsifuploadstore"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type cacheExpirerConfig struct {
+	env.BaseConfig
+
+	MaxAge            time.Duration
+	Interval          time.Duration
+	UploadStoreConfig *lsifuploadstore.Config
+}
+
+var cacheExpirerConfigInst = &cacheExpirerConfig{}
+
+func (c *cacheExpirerConfig) Load() {
+	c.UploadStoreConfig = &lsifuploadstore.Config{}
+	c.UploadStoreConfig.Load()
+
+	c.MaxAge = c.GetInterval("EXECUTOR_CACHE_EXPIRER_MAX_AGE", "72h", "The age after which executor job caches that were not written are removed.")
+	c.Interval = c.GetInterval("EXECUTOR_CACHE_EXPIRER_INTERVAL", "1h", "The frequency at which to expire executor job caches.")
+}
+
+func (c *cacheExpirerConfig) Validate() error {
+	var errs error
+	errs = errors.Append(errs, c.BaseConfig.Validate())
+	errs = errors.Append(errs, c.UploadStoreConfig.Validate())
+	return errs
+}
@@ -0,0 +1,43 @@
+package executors
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/lsifuploadstore"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+)
+
+// cachePrefix is the prefix under which the frontend stores the cache archives of
+// executor jobs.
+const cachePrefix = "executor-caches/"
+
+type cacheExpirerJob struct{}
+
+func NewCacheExpirerJob() job.Job {
+	return &cacheExpirerJob{}
+}
+
+func (j *cacheExpirerJob) Description() string {
+	return "Removes executor job caches that were not written for a while."
+}
+
+func (j *cacheExpirerJob) Config() []env.Config {
+	return []env.Config{cacheExpirerConfigInst}
+}
+
+func (j *cacheExpirerJob) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	store, err := lsifuploadstore.New(context.Background(), observationCtx, cacheExpirerConfigInst.UploadStoreConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// Executors write a cache again after every successful job, so expiring caches
+	// by age removes the least recently used ones.
+	return []goroutine.BackgroundRoutine{
+		uploadstore.NewExpirer(context.Background(), store, cachePrefix, cacheExpirerConfigInst.MaxAge, cacheExpirerConfigInst.Interval),
+	}, nil
+}
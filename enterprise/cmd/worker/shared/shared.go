@@ -33,26 +33,31 @@ var additionalJobs = map[string]job.Job{
 	"insights-data-retention-job":   workerinsights.NewInsightsDataRetentionJob(),
 	"batches-janitor":               batches.NewJanitorJob(),
 	"batches-scheduler":             batches.NewSchedulerJob(),
+	"batches-rollout-scheduler":     batches.NewRolloutSchedulerJob(),
 	"batches-reconciler":            batches.NewReconcilerJob(),
+	"batches-conflict-checker":      batches.NewConflictCheckerJob(),
 	"batches-bulk-processor":        batches.NewBulkOperationProcessorJob(),
 	"batches-workspace-resolver":    batches.NewWorkspaceResolverJob(),
 	"executors-janitor":             executors.NewJanitorJob(),
 	"executors-metricsserver":       executors.NewMetricsServerJob(),
+	"executors-cache-expirer":       executors.NewCacheExpirerJob(),
 	"codemonitors-job":              codemonitors.NewCodeMonitorJob(),
 	"bitbucket-project-permissions": permissions.NewBitbucketProjectPermissionsJob(),
 	"export-usage-telemetry":        telemetry.NewTelemetryJob(),
 
-	"codeintel-policies-repository-matcher":       codeintel.NewPoliciesRepositoryMatcherJob(),
-	"codeintel-autoindexing-dependency-scheduler": codeintel.NewAutoindexingDependencySchedulerJob(),
-	"codeintel-autoindexing-janitor":              codeintel.NewAutoindexingJanitorJob(),
-	"codeintel-autoindexing-scheduler":            codeintel.NewAutoindexingSchedulerJob(),
-	"codeintel-commitgraph-updater":               codeintel.NewCommitGraphUpdaterJob(),
-	"codeintel-metrics-reporter":                  codeintel.NewMetricsReporterJob(),
-	"codeintel-upload-backfiller":                 codeintel.NewUploadBackfillerJob(),
-	"codeintel-upload-expirer":                    codeintel.NewUploadExpirerJob(),
-	"codeintel-upload-janitor":                    codeintel.NewUploadJanitorJob(),
-	"codeintel-upload-graph-exporter":             codeintel.NewGraphExporterJob(),
-	"codeintel-uploadstore-expirer":               codeintel.NewPreciseCodeIntelUploadExpirer(),
+	"codeintel-policies-repository-matcher":          codeintel.NewPoliciesRepositoryMatcherJob(),
+	"codeintel-autoindexing-dependency-scheduler":    codeintel.NewAutoindexingDependencySchedulerJob(),
+	"codeintel-autoindexing-janitor":                 codeintel.NewAutoindexingJanitorJob(),
+	"codeintel-autoindexing-scheduler":               codeintel.NewAutoindexingSchedulerJob(),
+	"codeintel-commitgraph-updater":                  codeintel.NewCommitGraphUpdaterJob(),
+	"codeintel-metrics-reporter":                     codeintel.NewMetricsReporterJob(),
+	"codeintel-upload-backfiller":                    codeintel.NewUploadBackfillerJob(),
+	"codeintel-upload-coverage-reporter":             codeintel.NewCoverageReporterJob(),
+	"codeintel-upload-expirer":                       codeintel.NewUploadExpirerJob(),
+	"codeintel-upload-janitor":                       codeintel.NewUploadJanitorJob(),
+	"codeintel-upload-graph-exporter":                codeintel.NewGraphExporterJob(),
+	"codeintel-upload-unreferenced-symbols-reporter": codeintel.NewUnreferencedSymbolsReporterJob(),
+	"codeintel-uploadstore-expirer":                  codeintel.NewPreciseCodeIntelUploadExpirer(),
 
 	"auth-sourcegraph-operator-cleaner":  auth.NewSourcegraphOperatorCleaner(),
 	"auth-permission-sync-job-cleaner":   auth.NewPermissionSyncJobCleaner(),
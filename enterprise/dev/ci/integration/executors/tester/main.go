@@ -77,7 +77,7 @@ func main() {
 		PreExistingCacheEntries: map[string]execution.AfterStepResult{},
 		BatchSpecInput:          batchSpec,
 		ExpectedCacheEntries: map[string]execution.AfterStepResult{
-			"wHcoEItqNkdJj9k1-1sRCQ-step-0": {
+			"content-tuZySRP_2hPZmqXlVtI8D8i8pGBQ99oiFbUeKzARCqA": {
 				Version: 2,
 				Stdout:  "Hello World\n",
 				Diff:    []byte(expectedDiff),
@@ -20,6 +20,12 @@ func MarshalChangesetID(id int64) graphql.ID {
 	return relay.MarshalID(changesetIDKind, id)
 }
 
+const changesetRolloutIDKind = "ChangesetRollout"
+
+func MarshalChangesetRolloutID(id int64) graphql.ID {
+	return relay.MarshalID(changesetRolloutIDKind, id)
+}
+
 const orgIDKind = "Org"
 
 func MarshalNamespaceID(userID, orgID int32) (graphql.ID, error) {
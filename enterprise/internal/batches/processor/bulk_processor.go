@@ -97,6 +97,8 @@ func (b *bulkProcessor) Process(ctx context.Context, job *btypes.ChangesetJob) (
 		return b.reenqueueChangeset(ctx)
 	case btypes.ChangesetJobTypeMerge:
 		return b.mergeChangeset(ctx, job)
+	case btypes.ChangesetJobTypeRolloutMerge:
+		return b.rolloutMergeChangeset(ctx, job)
 	case btypes.ChangesetJobTypeClose:
 		return b.closeChangeset(ctx)
 	case btypes.ChangesetJobTypePublish:
@@ -168,6 +170,25 @@ func (b *bulkProcessor) mergeChangeset(ctx context.Context, job *btypes.Changese
 		return errors.Errorf("invalid payload type for changeset_job, want=%T have=%T", &btypes.ChangesetJobMergePayload{}, job.Payload)
 	}
 
+	return b.merge(ctx, typedPayload.Squash)
+}
+
+func (b *bulkProcessor) rolloutMergeChangeset(ctx context.Context, job *btypes.ChangesetJob) (err error) {
+	typedPayload, ok := job.Payload.(*btypes.ChangesetJobRolloutMergePayload)
+	if !ok {
+		return errors.Errorf("invalid payload type for changeset_job, want=%T have=%T", &btypes.ChangesetJobRolloutMergePayload{}, job.Payload)
+	}
+
+	// The rollout scheduler only enqueues changesets whose checks have
+	// passed, but they might have been re-run in the meantime.
+	if b.ch.ExternalCheckState == btypes.ChangesetCheckStateFailed {
+		return errcode.MakeNonRetryable(errors.Newf("checks of changeset %d failed", b.ch.ID))
+	}
+
+	return b.merge(ctx, typedPayload.Squash)
+}
+
+func (b *bulkProcessor) merge(ctx context.Context, squash bool) (err error) {
 	remoteRepo, err := sources.GetRemoteRepo(ctx, b.css, b.repo, b.ch, nil)
 	if err != nil {
 		return errors.Wrap(err, "loading remote repo")
@@ -178,7 +199,7 @@ func (b *bulkProcessor) mergeChangeset(ctx context.Context, job *btypes.Changese
 		TargetRepo: b.repo,
 		RemoteRepo: remoteRepo,
 	}
-	if err := b.css.MergeChangeset(ctx, cs, typedPayload.Squash); err != nil {
+	if err := b.css.MergeChangeset(ctx, cs, squash); err != nil {
 		return err
 	}
 
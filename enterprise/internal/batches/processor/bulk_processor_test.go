@@ -204,6 +204,28 @@ func TestBulkProcessor(t *testing.T) {
 		}
 	})
 
+	t.Run("Rollout merge job", func(t *testing.T) {
+		fake := &stesting.FakeChangesetSource{}
+		bp := &bulkProcessor{
+			tx:      bstore,
+			sourcer: stesting.NewFakeSourcer(nil, fake),
+			logger:  logtest.Scoped(t),
+		}
+		job := &types.ChangesetJob{
+			JobType:     types.ChangesetJobTypeRolloutMerge,
+			ChangesetID: changeset.ID,
+			UserID:      user.ID,
+			Payload:     &btypes.ChangesetJobRolloutMergePayload{RolloutID: 1, Squash: true},
+		}
+		err := bp.Process(ctx, job)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !fake.MergeChangesetCalled {
+			t.Fatal("expected MergeChangeset to be called but wasn't")
+		}
+	})
+
 	t.Run("Close job", func(t *testing.T) {
 		fake := &stesting.FakeChangesetSource{FakeMetadata: &github.PullRequest{}}
 		bp := &bulkProcessor{
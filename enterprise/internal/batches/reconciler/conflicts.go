@@ -0,0 +1,180 @@
+package reconciler
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	conflictCheckInterval = 1 * time.Minute
+	// conflictRecheckInterval is the minimum time between two checks of the
+	// same changeset.
+	conflictRecheckInterval = 15 * time.Minute
+	conflictCheckBatchSize  = 50
+)
+
+// NewConflictChecker creates a new goroutine.PeriodicGoroutine that checks
+// whether the diffs of open changesets still apply to the latest revision of
+// their base branch. If batchChanges.autoRebase is enabled in the site
+// configuration, changesets that still apply are enqueued to be rebased onto
+// that revision by the reconciler.
+func NewConflictChecker(ctx context.Context, logger log.Logger, s *store.Store, client gitserver.Client) goroutine.BackgroundRoutine {
+	c := &conflictChecker{
+		logger: logger,
+		store:  s,
+		client: client,
+	}
+
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		"batchchanges.conflict-checker", "checks open changesets for conflicts with their base branch",
+		conflictCheckInterval,
+		goroutine.HandlerFunc(c.handle),
+	)
+}
+
+type conflictChecker struct {
+	logger log.Logger
+	store  *store.Store
+	client gitserver.Client
+}
+
+func (c *conflictChecker) handle(ctx context.Context) error {
+	cs, err := c.store.ListChangesetsForConflictCheck(ctx, store.ListChangesetsForConflictCheckOpts{
+		Limit:         conflictCheckBatchSize,
+		CheckedBefore: c.store.Clock()().Add(-conflictRecheckInterval),
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing changesets")
+	}
+
+	// Get the configuration value when the handler runs to get the latest value.
+	autoRebase := conf.Get().BatchChangesAutoRebase
+
+	var errs error
+	for _, ch := range cs {
+		if err := c.checkChangeset(ctx, ch, autoRebase); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "checking changeset %d", ch.ID))
+		}
+	}
+	return errs
+}
+
+func (c *conflictChecker) checkChangeset(ctx context.Context, ch *btypes.Changeset, autoRebase bool) error {
+	spec, err := c.store.GetChangesetSpecByID(ctx, ch.CurrentSpecID)
+	if err != nil {
+		return errors.Wrap(err, "loading changeset spec")
+	}
+	repo, err := c.store.Repos().Get(ctx, ch.RepoID)
+	if err != nil {
+		return errors.Wrap(err, "loading repository")
+	}
+
+	previousState := ch.ConflictState
+	if err := CheckConflicts(ctx, c.client, repo, spec, ch, autoRebase); err != nil {
+		return err
+	}
+
+	ch.ConflictCheckedAt = c.store.Clock()()
+	if err := c.store.UpdateChangesetConflictState(ctx, ch); err != nil {
+		return errors.Wrap(err, "updating conflict state")
+	}
+
+	switch {
+	case ch.ConflictState == btypes.ChangesetConflictStateConflicting && previousState != btypes.ChangesetConflictStateConflicting:
+		webhooks.EnqueueChangeset(ctx, c.logger, c.store, webhooks.ChangesetConflict, ch)
+	case ch.ConflictState == btypes.ChangesetConflictStateMergeable && previousState == btypes.ChangesetConflictStateConflicting:
+		webhooks.EnqueueChangeset(ctx, c.logger, c.store, webhooks.ChangesetConflictResolved, ch)
+	}
+
+	// Changesets that are currently being processed are picked up again on
+	// the next check.
+	if ch.NeedsRebase() && ch.ReconcilerState == btypes.ReconcilerStateCompleted {
+		if err := c.store.EnqueueChangeset(ctx, ch, btypes.ReconcilerStateQueued, btypes.ReconcilerStateCompleted); err != nil {
+			return errors.Wrap(err, "enqueueing changeset for rebase")
+		}
+	}
+
+	return nil
+}
+
+// CheckConflicts checks whether the diff of the given spec applies to the
+// latest revision of its base branch and updates the conflict state of the
+// changeset accordingly. If the diff applies to a newer revision than the one
+// the changeset was last pushed on and autoRebase is true, a rebase onto that
+// revision is requested.
+func CheckConflicts(ctx context.Context, client gitserver.Client, repo *types.Repo, spec *btypes.ChangesetSpec, ch *btypes.Changeset, autoRebase bool) error {
+	latest, err := client.ResolveRevision(ctx, repo.Name, spec.BaseRef, gitserver.ResolveRevisionOptions{})
+	if err != nil {
+		return errors.Wrap(err, "resolving base revision")
+	}
+
+	alreadyChecked := ch.ConflictSpecID == spec.ID &&
+		ch.ConflictBaseRev == string(latest) &&
+		ch.ConflictState.Valid() &&
+		ch.ConflictState != btypes.ChangesetConflictStateUnknown
+
+	ch.ConflictSpecID = spec.ID
+	ch.ConflictBaseRev = string(latest)
+	ch.RebaseBaseRev = ""
+
+	pushedBase := api.CommitID(spec.BaseRev)
+	if ch.RebasedBaseRev != "" {
+		pushedBase = api.CommitID(ch.RebasedBaseRev)
+	}
+	if latest == pushedBase {
+		ch.ConflictState = btypes.ChangesetConflictStateMergeable
+		return nil
+	}
+
+	mergeBase, err := client.MergeBase(ctx, repo.Name, pushedBase, latest)
+	if err != nil {
+		return errors.Wrap(err, "determining merge base")
+	}
+	if mergeBase == latest {
+		// The base branch didn't move past the revision the changeset was
+		// pushed on, so there's nothing to rebase onto.
+		ch.ConflictState = btypes.ChangesetConflictStateMergeable
+		return nil
+	}
+
+	if !alreadyChecked {
+		opts := buildCommitOpts(repo, spec, nil)
+		opts.BaseCommit = latest
+		opts.DryRun = true
+
+		_, err := client.CreateCommitFromPatch(ctx, opts)
+		var e *protocol.CreateCommitFromPatchError
+		switch {
+		case err == nil:
+			ch.ConflictState = btypes.ChangesetConflictStateMergeable
+		case errors.As(err, &e) && strings.HasPrefix(e.Command, "git apply "):
+			// Whatever the reason git gives, e.g. "patch does not apply" or
+			// "already exists in index", a diff that cannot be applied to the
+			// latest base revision conflicts with it.
+			ch.ConflictState = btypes.ChangesetConflictStateConflicting
+		default:
+			return errors.Wrap(err, "applying diff to base revision")
+		}
+	}
+
+	if autoRebase && ch.ConflictState == btypes.ChangesetConflictStateMergeable {
+		ch.RebaseBaseRev = string(latest)
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestCheckConflicts(t *testing.T) {
+	ctx := context.Background()
+	repo := &types.Repo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+
+	const (
+		specBase = api.CommitID("d34db33f")
+		latest   = api.CommitID("f00b4r")
+	)
+
+	newSpec := func() *btypes.ChangesetSpec {
+		return &btypes.ChangesetSpec{
+			ID:      1,
+			BaseRef: "refs/heads/main",
+			BaseRev: string(specBase),
+			HeadRef: "refs/heads/my-branch",
+			Diff:    []byte("diff"),
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		changeset  btypes.Changeset
+		autoRebase bool
+		latest     api.CommitID
+		mergeBase  api.CommitID
+		applyErr   error
+		wantErr    bool
+
+		wantApply         bool
+		wantState         btypes.ChangesetConflictState
+		wantRebaseBaseRev string
+	}{
+		"base branch unchanged": {
+			autoRebase: true,
+			latest:     specBase,
+			wantState:  btypes.ChangesetConflictStateMergeable,
+		},
+		"base branch behind pushed commit": {
+			autoRebase: true,
+			latest:     latest,
+			mergeBase:  latest,
+			wantState:  btypes.ChangesetConflictStateMergeable,
+		},
+		"base branch moved and diff applies": {
+			latest:    latest,
+			mergeBase: specBase,
+			wantApply: true,
+			wantState: btypes.ChangesetConflictStateMergeable,
+		},
+		"base branch moved and diff applies with auto rebase": {
+			autoRebase:        true,
+			latest:            latest,
+			mergeBase:         specBase,
+			wantApply:         true,
+			wantState:         btypes.ChangesetConflictStateMergeable,
+			wantRebaseBaseRev: string(latest),
+		},
+		"base branch moved and diff conflicts": {
+			autoRebase: true,
+			latest:     latest,
+			mergeBase:  specBase,
+			applyErr: &protocol.CreateCommitFromPatchError{
+				Command:        "git apply --cached -p0",
+				CombinedOutput: "error: patch failed: README.md:1\nerror: README.md: patch does not apply",
+			},
+			wantApply: true,
+			wantState: btypes.ChangesetConflictStateConflicting,
+		},
+		"base branch moved and added file exists": {
+			autoRebase: true,
+			latest:     latest,
+			mergeBase:  specBase,
+			applyErr: &protocol.CreateCommitFromPatchError{
+				Command:        "git apply --cached -p0",
+				CombinedOutput: "error: new.go: already exists in index",
+			},
+			wantApply: true,
+			wantState: btypes.ChangesetConflictStateConflicting,
+		},
+		"base branch moved and changed file was deleted": {
+			autoRebase: true,
+			latest:     latest,
+			mergeBase:  specBase,
+			applyErr: &protocol.CreateCommitFromPatchError{
+				Command:        "git apply --cached -p0",
+				CombinedOutput: "error: README.md: does not exist in index",
+			},
+			wantApply: true,
+			wantState: btypes.ChangesetConflictStateConflicting,
+		},
+		"base branch moved and diff could not be checked": {
+			autoRebase: true,
+			latest:     latest,
+			mergeBase:  specBase,
+			applyErr: &protocol.CreateCommitFromPatchError{
+				Command:        "git reset -q " + string(latest),
+				CombinedOutput: "fatal: Could not parse object",
+			},
+			wantApply: true,
+			wantErr:   true,
+		},
+		"already checked against latest base": {
+			changeset: btypes.Changeset{
+				ConflictState:   btypes.ChangesetConflictStateConflicting,
+				ConflictSpecID:  1,
+				ConflictBaseRev: string(latest),
+			},
+			autoRebase: true,
+			latest:     latest,
+			mergeBase:  specBase,
+			wantState:  btypes.ChangesetConflictStateConflicting,
+		},
+		"already rebased onto latest base": {
+			changeset: btypes.Changeset{
+				ConflictState:   btypes.ChangesetConflictStateMergeable,
+				ConflictSpecID:  1,
+				ConflictBaseRev: string(latest),
+				RebaseBaseRev:   string(latest),
+				RebasedBaseRev:  string(latest),
+			},
+			autoRebase: true,
+			latest:     latest,
+			wantState:  btypes.ChangesetConflictStateMergeable,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			client := gitserver.NewMockClient()
+			client.ResolveRevisionFunc.SetDefaultReturn(tc.latest, nil)
+			client.MergeBaseFunc.SetDefaultReturn(tc.mergeBase, nil)
+
+			var applyReq *protocol.CreateCommitFromPatchRequest
+			client.CreateCommitFromPatchFunc.SetDefaultHook(func(_ context.Context, req protocol.CreateCommitFromPatchRequest) (string, error) {
+				applyReq = &req
+				return "", tc.applyErr
+			})
+
+			ch := tc.changeset
+			err := CheckConflicts(ctx, client, repo, newSpec(), &ch, tc.autoRebase)
+			if have, want := err != nil, tc.wantErr; have != want {
+				t.Fatalf("unexpected error. wantErr=%t, have=%v", want, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if have, want := applyReq != nil, tc.wantApply; have != want {
+				t.Fatalf("wrong CreateCommitFromPatch call. wantCalled=%t, wasCalled=%t", want, have)
+			}
+			if applyReq != nil {
+				if !applyReq.DryRun {
+					t.Fatal("diff was not applied in dry-run mode")
+				}
+				if applyReq.Push != nil {
+					t.Fatal("dry-run request has push config")
+				}
+				if have, want := applyReq.BaseCommit, tc.latest; have != want {
+					t.Fatalf("wrong base commit. want=%s, have=%s", want, have)
+				}
+			}
+
+			if have, want := ch.ConflictState, tc.wantState; have != want {
+				t.Fatalf("wrong conflict state. want=%s, have=%s", want, have)
+			}
+			if have, want := ch.ConflictBaseRev, string(tc.latest); have != want {
+				t.Fatalf("wrong conflict base rev. want=%s, have=%s", want, have)
+			}
+			if have, want := ch.RebaseBaseRev, tc.wantRebaseBaseRev; have != want {
+				t.Fatalf("wrong rebase base rev. want=%q, have=%q", want, have)
+			}
+		})
+	}
+}
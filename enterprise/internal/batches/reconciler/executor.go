@@ -187,6 +187,11 @@ func (e *executor) pushChangesetPatch(ctx context.Context) (err error) {
 		return err
 	}
 	opts := buildCommitOpts(e.targetRepo, e.spec, pushConf)
+	// If the conflict checker found that the diff applies to a newer revision
+	// of the base branch, we create the commit on top of that instead.
+	if e.ch.NeedsRebase() {
+		opts.BaseCommit = api.CommitID(e.ch.RebaseBaseRev)
+	}
 
 	err = e.pushCommit(ctx, opts)
 	var pce pushCommitError
@@ -200,8 +205,17 @@ func (e *executor) pushChangesetPatch(ctx context.Context) (err error) {
 			}
 		}
 	}
+	if err != nil {
+		return err
+	}
 
-	return err
+	if string(opts.BaseCommit) != e.spec.BaseRev {
+		e.ch.RebasedBaseRev = string(opts.BaseCommit)
+	} else {
+		e.ch.RebasedBaseRev = ""
+	}
+
+	return nil
 }
 
 // publishChangeset creates the given changeset on its code host.
@@ -21,6 +21,7 @@ import (
 	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	et "github.com/sourcegraph/sourcegraph/internal/encryption/testing"
@@ -102,6 +103,7 @@ func TestExecutor_ExecutePlan(t *testing.T) {
 		wantReopenOnCodeHost      bool
 
 		wantGitserverCommit bool
+		wantBaseCommit      string
 
 		wantChangeset       bt.ChangesetAssertions
 		wantNonRetryableErr bool
@@ -340,6 +342,37 @@ func TestExecutor_ExecutePlan(t *testing.T) {
 				DiffStat:         state.DiffStat,
 			},
 		},
+		"push rebased commit": {
+			hasCurrentSpec: true,
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalID:       "12345",
+				ExternalBranch:   gitdomain.EnsureRefPrefix("head-ref-on-github"),
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				RebaseBaseRev:    "f00b4r",
+			},
+
+			plan: &Plan{
+				Ops: Operations{
+					btypes.ReconcilerOperationPush,
+					btypes.ReconcilerOperationSleep,
+					btypes.ReconcilerOperationSync,
+				},
+			},
+
+			wantGitserverCommit:  true,
+			wantBaseCommit:       "f00b4r",
+			wantLoadFromCodeHost: true,
+
+			wantChangeset: bt.ChangesetAssertions{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				ExternalID:       githubPR.ID,
+				ExternalBranch:   githubHeadRef,
+				DiffStat:         state.DiffStat,
+				RebasedBaseRev:   "f00b4r",
+			},
+		},
 		"close open changeset": {
 			hasCurrentSpec: true,
 			changeset: bt.TestChangesetOpts{
@@ -594,11 +627,22 @@ func TestExecutor_ExecutePlan(t *testing.T) {
 				changesetOpts.CurrentSpec = changesetSpec.ID
 			}
 			changeset := bt.CreateChangeset(t, ctx, bstore, changesetOpts)
+			if changesetOpts.RebaseBaseRev != "" {
+				// The conflict state isn't written when creating changesets.
+				changeset.ConflictState = btypes.ChangesetConflictStateMergeable
+				changeset.ConflictSpecID = changeset.CurrentSpecID
+				changeset.RebaseBaseRev = changesetOpts.RebaseBaseRev
+				if err := bstore.UpdateChangesetConflictState(ctx, changeset); err != nil {
+					t.Fatal(err)
+				}
+			}
 
 			var response string
 			var createCommitFromPatchCalled bool
+			var baseCommit api.CommitID
 			state.MockClient.CreateCommitFromPatchFunc.SetDefaultHook(func(_ context.Context, req gitprotocol.CreateCommitFromPatchRequest) (string, error) {
 				createCommitFromPatchCalled = true
+				baseCommit = req.BaseCommit
 				if changesetSpec != nil {
 					response = changesetSpec.HeadRef
 				}
@@ -660,6 +704,9 @@ func TestExecutor_ExecutePlan(t *testing.T) {
 			if have, want := createCommitFromPatchCalled, tc.wantGitserverCommit; have != want {
 				t.Fatalf("wrong CreateCommitFromPatch call. wantCalled=%t, wasCalled=%t", want, have)
 			}
+			if tc.wantBaseCommit != "" && string(baseCommit) != tc.wantBaseCommit {
+				t.Fatalf("wrong base commit. want=%s, have=%s", tc.wantBaseCommit, baseCommit)
+			}
 
 			if have, want := fakeSource.CreateDraftChangesetCalled, tc.wantCreateDraftOnCodeHost; have != want {
 				t.Fatalf("wrong CreateDraftChangeset call. wantCalled=%t, wasCalled=%t", want, have)
@@ -218,6 +218,15 @@ func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, currentChang
 			}
 		}
 
+		// The conflict checker requests a rebase when the base branch moved
+		// and the diff still applies to it. In that case we push the commit
+		// again on top of the new base and sync the changeset afterwards.
+		if wantedChangeset.NeedsRebase() {
+			pl.AddOp(btypes.ReconcilerOperationPush)
+			pl.AddOp(btypes.ReconcilerOperationSleep)
+			pl.AddOp(btypes.ReconcilerOperationSync)
+		}
+
 		if delta.AttributesChanged() {
 			if delta.NeedCommitUpdate() {
 				pl.AddOp(btypes.ReconcilerOperationPush)
@@ -255,6 +255,30 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 				btypes.ReconcilerOperationSync,
 			},
 		},
+		{
+			name:        "rebase requested on published changeset",
+			currentSpec: &bt.TestSpecOpts{Published: true},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				RebaseBaseRev:    "deadbeef",
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationPush,
+				btypes.ReconcilerOperationSleep,
+				btypes.ReconcilerOperationSync,
+			},
+		},
+		{
+			name:        "rebase requested on merged changeset",
+			currentSpec: &bt.TestSpecOpts{Published: true},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateMerged,
+				RebaseBaseRev:    "deadbeef",
+			},
+			// should be a noop
+			wantOperations: Operations{},
+		},
 		{
 			name:         "commit diff changed on merge changeset",
 			previousSpec: &bt.TestSpecOpts{Published: true, CommitDiff: []byte("testDiff")},
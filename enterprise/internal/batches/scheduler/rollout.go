@@ -0,0 +1,287 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const rolloutSchedulerInterval = 30 * time.Second
+
+// NewRolloutScheduler creates a new goroutine.PeriodicGoroutine that advances
+// running changeset rollouts: the changesets of a wave are merged once all of
+// their checks have passed, and the next wave is only started after all
+// changesets of the previous wave have been merged and the soak time of the
+// rollout has elapsed. If a wave fails, the rollout is stopped and a webhook is
+// sent.
+func NewRolloutScheduler(ctx context.Context, logger log.Logger, s *store.Store) goroutine.BackgroundRoutine {
+	r := &rolloutScheduler{
+		logger: logger,
+		store:  s,
+	}
+
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		"batchchanges.rollout-scheduler", "merges the changesets of running changeset rollouts wave by wave",
+		rolloutSchedulerInterval,
+		goroutine.HandlerFunc(r.handle),
+	)
+}
+
+type rolloutScheduler struct {
+	logger log.Logger
+	store  *store.Store
+}
+
+func (r *rolloutScheduler) handle(ctx context.Context) error {
+	rollouts, err := r.store.ListChangesetRollouts(ctx, store.ListChangesetRolloutsOpts{
+		States: []btypes.ChangesetRolloutState{btypes.ChangesetRolloutStateRunning},
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing running rollouts")
+	}
+
+	var errs error
+	for _, rollout := range rollouts {
+		if err := r.advance(ctx, rollout); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "advancing rollout %d", rollout.ID))
+		}
+	}
+	return errs
+}
+
+func (r *rolloutScheduler) advance(ctx context.Context, rollout *btypes.ChangesetRollout) (err error) {
+	tx, err := r.store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	wave := rollout.CurrentWave
+	assignments, err := tx.ListChangesetRolloutChangesets(ctx, store.ListChangesetRolloutChangesetsOpts{
+		RolloutID: rollout.ID,
+		Wave:      &wave,
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing changesets of wave")
+	}
+
+	var cs btypes.Changesets
+	if len(assignments) > 0 {
+		ids := make([]int64, 0, len(assignments))
+		for _, a := range assignments {
+			ids = append(ids, a.ChangesetID)
+		}
+		cs, _, err = tx.ListChangesets(ctx, store.ListChangesetsOpts{IDs: ids})
+		if err != nil {
+			return errors.Wrap(err, "loading changesets of wave")
+		}
+	}
+
+	var op *btypes.BulkOperation
+	if rollout.CurrentBulkGroup != "" {
+		op, err = tx.GetBulkOperation(ctx, store.GetBulkOperationOpts{ID: rollout.CurrentBulkGroup})
+		if err != nil && err != store.ErrNoResults {
+			return errors.Wrap(err, "loading merge jobs of wave")
+		}
+	}
+
+	now := tx.Clock()()
+	action := nextRolloutAction(rollout, len(assignments), cs, op, now)
+
+	switch action.kind {
+	case rolloutActionWait:
+		return nil
+
+	case rolloutActionStartWave:
+		bulkGroup, err := store.RandomID()
+		if err != nil {
+			return errors.Wrap(err, "creating bulk group")
+		}
+		jobs := make([]*btypes.ChangesetJob, 0, len(cs))
+		for _, ch := range cs {
+			if ch.ExternalState == btypes.ChangesetExternalStateMerged {
+				continue
+			}
+			jobs = append(jobs, &btypes.ChangesetJob{
+				BulkGroup:     bulkGroup,
+				ChangesetID:   ch.ID,
+				BatchChangeID: rollout.BatchChangeID,
+				UserID:        rollout.UserID,
+				State:         btypes.ChangesetJobStateQueued,
+				JobType:       btypes.ChangesetJobTypeRolloutMerge,
+				Payload: &btypes.ChangesetJobRolloutMergePayload{
+					RolloutID: rollout.ID,
+					Squash:    rollout.Squash,
+				},
+			})
+		}
+		if err := tx.CreateChangesetJob(ctx, jobs...); err != nil {
+			return errors.Wrap(err, "creating merge jobs")
+		}
+		rollout.CurrentBulkGroup = bulkGroup
+		rollout.WaveStartedAt = now
+
+	case rolloutActionWaveMerged:
+		// A wave whose changesets were all merged before it started is never
+		// started, but it still soaks.
+		if rollout.WaveStartedAt.IsZero() {
+			rollout.WaveStartedAt = now
+		}
+		rollout.WaveMergedAt = now
+
+	case rolloutActionNextWave:
+		rollout.CurrentWave++
+		rollout.CurrentBulkGroup = ""
+		rollout.WaveStartedAt = time.Time{}
+		rollout.WaveMergedAt = time.Time{}
+		if rollout.CurrentWave >= rollout.WaveCount {
+			rollout.State = btypes.ChangesetRolloutStateCompleted
+		}
+
+	case rolloutActionFail:
+		rollout.State = btypes.ChangesetRolloutStateFailed
+		rollout.FailureMessage = &action.reason
+	}
+
+	if err := tx.UpdateChangesetRollout(ctx, rollout); err != nil {
+		return errors.Wrap(err, "updating rollout")
+	}
+
+	switch rollout.State {
+	case btypes.ChangesetRolloutStateFailed:
+		r.logger.Warn("changeset rollout failed", log.Int64("rolloutID", rollout.ID), log.String("reason", action.reason))
+		webhooks.EnqueueChangesetRollout(ctx, r.logger, tx, webhooks.BatchChangeRolloutFailed, rollout)
+	case btypes.ChangesetRolloutStateCompleted:
+		webhooks.EnqueueChangesetRollout(ctx, r.logger, tx, webhooks.BatchChangeRolloutCompleted, rollout)
+	}
+
+	return nil
+}
+
+type rolloutActionKind int
+
+const (
+	// rolloutActionWait means that the current wave is not ready to progress.
+	rolloutActionWait rolloutActionKind = iota
+	// rolloutActionStartWave means that the changesets of the current wave
+	// should be merged.
+	rolloutActionStartWave
+	// rolloutActionWaveMerged means that all changesets of the current wave
+	// have been merged and the soak time starts.
+	rolloutActionWaveMerged
+	// rolloutActionNextWave means that the soak time of the current wave has
+	// elapsed and the rollout can move on to the next wave.
+	rolloutActionNextWave
+	// rolloutActionFail means that the rollout should be stopped.
+	rolloutActionFail
+)
+
+type rolloutAction struct {
+	kind rolloutActionKind
+	// reason is set for rolloutActionFail.
+	reason string
+}
+
+func failRollout(format string, args ...any) rolloutAction {
+	return rolloutAction{kind: rolloutActionFail, reason: fmt.Sprintf(format, args...)}
+}
+
+// nextRolloutAction determines how the current wave of the given rollout should
+// progress. want is the number of changesets assigned to the wave, cs are the
+// changesets of the wave that still exist and op is the bulk operation that
+// merges them, if the wave has been started.
+func nextRolloutAction(rollout *btypes.ChangesetRollout, want int, cs []*btypes.Changeset, op *btypes.BulkOperation, now time.Time) rolloutAction {
+	if rollout.CurrentWave >= rollout.WaveCount {
+		return rolloutAction{kind: rolloutActionNextWave}
+	}
+
+	if len(cs) != want {
+		return failRollout("%d changesets of wave %d no longer exist", want-len(cs), rollout.CurrentWave+1)
+	}
+
+	switch {
+	case !rollout.WaveMergedAt.IsZero():
+		// The wave has been merged, so we keep an eye on it until the soak
+		// time has elapsed.
+		for _, ch := range cs {
+			if ch.ExternalCheckState == btypes.ChangesetCheckStateFailed {
+				return failRollout("checks of changeset %d failed after it was merged", ch.ID)
+			}
+		}
+		if now.Sub(rollout.WaveMergedAt) < rollout.SoakTime {
+			return rolloutAction{kind: rolloutActionWait}
+		}
+		return rolloutAction{kind: rolloutActionNextWave}
+
+	case rollout.WaveStartedAt.IsZero():
+		// The wave has not been started yet, so we wait for the checks of all
+		// its open changesets to pass.
+		ready, merged := true, true
+		for _, ch := range cs {
+			switch ch.ExternalState {
+			case btypes.ChangesetExternalStateMerged:
+				continue
+			case btypes.ChangesetExternalStateOpen:
+				merged = false
+			default:
+				return failRollout("changeset %d is %s and cannot be merged", ch.ID, ch.ExternalState)
+			}
+
+			switch ch.ExternalCheckState {
+			case btypes.ChangesetCheckStateFailed:
+				return failRollout("checks of changeset %d failed", ch.ID)
+			case btypes.ChangesetCheckStatePending:
+				ready = false
+			}
+
+			// Changesets that are currently being updated on the code host
+			// cannot be merged yet.
+			if ch.ReconcilerState != btypes.ReconcilerStateCompleted {
+				ready = false
+			}
+		}
+
+		if merged {
+			return rolloutAction{kind: rolloutActionWaveMerged}
+		}
+		if !ready {
+			return rolloutAction{kind: rolloutActionWait}
+		}
+		return rolloutAction{kind: rolloutActionStartWave}
+
+	default:
+		// The changesets of the wave are being merged.
+		if op == nil {
+			return failRollout("merge jobs of wave %d not found", rollout.CurrentWave+1)
+		}
+		switch op.State {
+		case btypes.BulkOperationStateFailed:
+			return failRollout("failed to merge changesets of wave %d", rollout.CurrentWave+1)
+		case btypes.BulkOperationStateCompleted:
+		default:
+			return rolloutAction{kind: rolloutActionWait}
+		}
+
+		// Some code hosts merge asynchronously, so we wait until the syncer
+		// has picked up the merge of all changesets.
+		for _, ch := range cs {
+			switch ch.ExternalState {
+			case btypes.ChangesetExternalStateMerged:
+			case btypes.ChangesetExternalStateOpen:
+				return rolloutAction{kind: rolloutActionWait}
+			default:
+				return failRollout("changeset %d is %s instead of merged", ch.ID, ch.ExternalState)
+			}
+		}
+		return rolloutAction{kind: rolloutActionWaveMerged}
+	}
+}
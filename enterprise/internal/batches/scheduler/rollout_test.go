@@ -0,0 +1,159 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+func TestNextRolloutAction(t *testing.T) {
+	now := time.Now()
+
+	changeset := func(id int64, externalState btypes.ChangesetExternalState, checkState btypes.ChangesetCheckState) *btypes.Changeset {
+		return &btypes.Changeset{
+			ID:                 id,
+			ExternalState:      externalState,
+			ExternalCheckState: checkState,
+			ReconcilerState:    btypes.ReconcilerStateCompleted,
+		}
+	}
+
+	const (
+		open   = btypes.ChangesetExternalStateOpen
+		merged = btypes.ChangesetExternalStateMerged
+		closed = btypes.ChangesetExternalStateClosed
+
+		passed  = btypes.ChangesetCheckStatePassed
+		pending = btypes.ChangesetCheckStatePending
+		failed  = btypes.ChangesetCheckStateFailed
+		unknown = btypes.ChangesetCheckStateUnknown
+	)
+
+	for name, tc := range map[string]struct {
+		rollout btypes.ChangesetRollout
+		want    int
+		cs      []*btypes.Changeset
+		op      *btypes.BulkOperation
+
+		wantKind   rolloutActionKind
+		wantReason string
+	}{
+		"checks passed": {
+			want:     2,
+			cs:       []*btypes.Changeset{changeset(1, open, passed), changeset(2, open, unknown)},
+			wantKind: rolloutActionStartWave,
+		},
+		"checks pending": {
+			want:     2,
+			cs:       []*btypes.Changeset{changeset(1, open, passed), changeset(2, open, pending)},
+			wantKind: rolloutActionWait,
+		},
+		"changeset processing": {
+			want: 1,
+			cs: []*btypes.Changeset{func() *btypes.Changeset {
+				ch := changeset(1, open, passed)
+				ch.ReconcilerState = btypes.ReconcilerStateProcessing
+				return ch
+			}()},
+			wantKind: rolloutActionWait,
+		},
+		"checks failed": {
+			want:       2,
+			cs:         []*btypes.Changeset{changeset(1, open, passed), changeset(2, open, failed)},
+			wantKind:   rolloutActionFail,
+			wantReason: "checks of changeset 2 failed",
+		},
+		"changeset closed": {
+			want:       1,
+			cs:         []*btypes.Changeset{changeset(1, closed, passed)},
+			wantKind:   rolloutActionFail,
+			wantReason: "changeset 1 is CLOSED and cannot be merged",
+		},
+		"changeset deleted": {
+			want:       2,
+			cs:         []*btypes.Changeset{changeset(1, open, passed)},
+			wantKind:   rolloutActionFail,
+			wantReason: "1 changesets of wave 1 no longer exist",
+		},
+		"all changesets already merged": {
+			want:     1,
+			cs:       []*btypes.Changeset{changeset(1, merged, passed)},
+			wantKind: rolloutActionWaveMerged,
+		},
+		"merging": {
+			rollout:  btypes.ChangesetRollout{WaveStartedAt: now},
+			want:     1,
+			cs:       []*btypes.Changeset{changeset(1, open, passed)},
+			op:       &btypes.BulkOperation{State: btypes.BulkOperationStateProcessing},
+			wantKind: rolloutActionWait,
+		},
+		"merge failed": {
+			rollout:    btypes.ChangesetRollout{WaveStartedAt: now},
+			want:       1,
+			cs:         []*btypes.Changeset{changeset(1, open, passed)},
+			op:         &btypes.BulkOperation{State: btypes.BulkOperationStateFailed},
+			wantKind:   rolloutActionFail,
+			wantReason: "failed to merge changesets of wave 1",
+		},
+		"merge completed but not synced": {
+			rollout:  btypes.ChangesetRollout{WaveStartedAt: now},
+			want:     2,
+			cs:       []*btypes.Changeset{changeset(1, merged, passed), changeset(2, open, passed)},
+			op:       &btypes.BulkOperation{State: btypes.BulkOperationStateCompleted},
+			wantKind: rolloutActionWait,
+		},
+		"merge completed": {
+			rollout:  btypes.ChangesetRollout{WaveStartedAt: now},
+			want:     2,
+			cs:       []*btypes.Changeset{changeset(1, merged, passed), changeset(2, merged, passed)},
+			op:       &btypes.BulkOperation{State: btypes.BulkOperationStateCompleted},
+			wantKind: rolloutActionWaveMerged,
+		},
+		"soaking": {
+			rollout:  btypes.ChangesetRollout{WaveStartedAt: now, WaveMergedAt: now.Add(-5 * time.Minute), SoakTime: 10 * time.Minute},
+			want:     1,
+			cs:       []*btypes.Changeset{changeset(1, merged, passed)},
+			wantKind: rolloutActionWait,
+		},
+		"soaking with failed checks": {
+			rollout:    btypes.ChangesetRollout{WaveStartedAt: now, WaveMergedAt: now.Add(-5 * time.Minute), SoakTime: 10 * time.Minute},
+			want:       1,
+			cs:         []*btypes.Changeset{changeset(1, merged, failed)},
+			wantKind:   rolloutActionFail,
+			wantReason: "checks of changeset 1 failed after it was merged",
+		},
+		"soak time elapsed": {
+			rollout:  btypes.ChangesetRollout{WaveStartedAt: now, WaveMergedAt: now.Add(-10 * time.Minute), SoakTime: 10 * time.Minute},
+			want:     1,
+			cs:       []*btypes.Changeset{changeset(1, merged, passed)},
+			wantKind: rolloutActionNextWave,
+		},
+		"soaking a wave that was already merged": {
+			rollout:  btypes.ChangesetRollout{WaveMergedAt: now.Add(-5 * time.Minute), SoakTime: 10 * time.Minute},
+			want:     1,
+			cs:       []*btypes.Changeset{changeset(1, merged, passed)},
+			wantKind: rolloutActionWait,
+		},
+		"soak time of a wave that was already merged elapsed": {
+			rollout:  btypes.ChangesetRollout{WaveMergedAt: now.Add(-10 * time.Minute), SoakTime: 10 * time.Minute},
+			want:     1,
+			cs:       []*btypes.Changeset{changeset(1, merged, passed)},
+			wantKind: rolloutActionNextWave,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rollout := tc.rollout
+			rollout.State = btypes.ChangesetRolloutStateRunning
+			rollout.WaveCount = 2
+
+			have := nextRolloutAction(&rollout, tc.want, tc.cs, tc.op, now)
+			if have.kind != tc.wantKind {
+				t.Fatalf("wrong action. want=%d, have=%d (%q)", tc.wantKind, have.kind, have.reason)
+			}
+			if have.reason != tc.wantReason {
+				t.Fatalf("wrong reason. want=%q, have=%q", tc.wantReason, have.reason)
+			}
+		})
+	}
+}
@@ -88,6 +88,9 @@ type operations struct {
 	fetchUsernameForBitbucketServerToken *observation.Operation
 	validateAuthenticator                *observation.Operation
 	createChangesetJobs                  *observation.Operation
+	startChangesetRollout                *observation.Operation
+	pauseChangesetRollout                *observation.Operation
+	resumeChangesetRollout               *observation.Operation
 	applyBatchChange                     *observation.Operation
 	reconcileBatchChange                 *observation.Operation
 	validateChangesetSpecs               *observation.Operation
@@ -138,6 +141,9 @@ func newOperations(observationCtx *observation.Context) *operations {
 			fetchUsernameForBitbucketServerToken: op("FetchUsernameForBitbucketServerToken"),
 			validateAuthenticator:                op("ValidateAuthenticator"),
 			createChangesetJobs:                  op("CreateChangesetJobs"),
+			startChangesetRollout:                op("StartChangesetRollout"),
+			pauseChangesetRollout:                op("PauseChangesetRollout"),
+			resumeChangesetRollout:               op("ResumeChangesetRollout"),
 			applyBatchChange:                     op("ApplyBatchChange"),
 			reconcileBatchChange:                 op("ReconcileBatchChange"),
 			validateChangesetSpecs:               op("ValidateChangesetSpecs"),
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ErrInvalidChangesetRolloutState is returned when a changeset rollout is
+// paused or resumed in a state that doesn't allow it.
+var ErrInvalidChangesetRolloutState = errors.New("changeset rollout cannot be paused or resumed in its current state")
+
+// ChangesetRolloutWave describes how to select the changesets of a wave of a
+// changeset rollout. Exactly one of the selectors must be set.
+type ChangesetRolloutWave struct {
+	// ChangesetIDs selects the given changesets.
+	ChangesetIDs []int64
+	// Label selects the changesets that have the given label on the code host.
+	Label string
+	// RepoMetadataKey selects the changesets in repositories that have the
+	// given metadata key. If RepoMetadataValue is set, the value of the key
+	// also has to match.
+	RepoMetadataKey   string
+	RepoMetadataValue *string
+}
+
+type StartChangesetRolloutOpts struct {
+	BatchChangeID int64
+	// Waves are merged in order. A changeset that is selected by more than one
+	// wave is merged in the first one, and open changesets that are not
+	// selected by any wave are not merged.
+	Waves    []ChangesetRolloutWave
+	SoakTime time.Duration
+	Squash   bool
+}
+
+// StartChangesetRollout creates a new changeset rollout for the given batch
+// change, checking whether the actor in the context has permission to merge
+// its changesets.
+func (s *Service) StartChangesetRollout(ctx context.Context, opts StartChangesetRolloutOpts) (rollout *btypes.ChangesetRollout, err error) {
+	ctx, _, endObservation := s.operations.startChangesetRollout.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	if len(opts.Waves) == 0 {
+		return nil, errors.New("a rollout needs at least one wave")
+	}
+	if opts.SoakTime < 0 {
+		return nil, errors.New("soak time cannot be negative")
+	}
+
+	batchChange, err := s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: opts.BatchChangeID})
+	if err != nil {
+		return nil, errors.Wrap(err, "loading batch change")
+	}
+
+	// 🚨 SECURITY: Only the author of the batch change can merge its changesets.
+	if err := auth.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchChange.CreatorID); err != nil {
+		return nil, err
+	}
+
+	published := btypes.ChangesetPublicationStatePublished
+	cs, _, err := s.store.ListChangesets(ctx, store.ListChangesetsOpts{
+		BatchChangeID:    opts.BatchChangeID,
+		PublicationState: &published,
+		ExternalStates:   []btypes.ChangesetExternalState{btypes.ChangesetExternalStateOpen},
+		// We only want to allow changesets the user has access to.
+		EnforceAuthz: true,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing changesets")
+	}
+
+	assignments, err := s.assignChangesetsToWaves(ctx, opts.Waves, cs)
+	if err != nil {
+		return nil, err
+	}
+
+	rollout = &btypes.ChangesetRollout{
+		BatchChangeID: opts.BatchChangeID,
+		UserID:        actor.FromContext(ctx).UID,
+		State:         btypes.ChangesetRolloutStateRunning,
+		Squash:        opts.Squash,
+		SoakTime:      opts.SoakTime,
+	}
+	if err := s.store.CreateChangesetRollout(ctx, rollout, assignments...); err != nil {
+		return nil, err
+	}
+
+	return rollout, nil
+}
+
+func (s *Service) assignChangesetsToWaves(ctx context.Context, waves []ChangesetRolloutWave, cs btypes.Changesets) ([]*btypes.ChangesetRolloutChangeset, error) {
+	byID := make(map[int64]*btypes.Changeset, len(cs))
+	for _, ch := range cs {
+		byID[ch.ID] = ch
+	}
+
+	repoMetadata := map[api.RepoID]map[string]*string{}
+	getRepoMetadata := func(id api.RepoID) (map[string]*string, error) {
+		if md, ok := repoMetadata[id]; ok {
+			return md, nil
+		}
+		kvps, err := s.store.DatabaseDB().RepoKVPs().List(ctx, id)
+		if err != nil {
+			return nil, errors.Wrap(err, "loading repository metadata")
+		}
+		md := make(map[string]*string, len(kvps))
+		for _, kvp := range kvps {
+			md[kvp.Key] = kvp.Value
+		}
+		repoMetadata[id] = md
+		return md, nil
+	}
+
+	assigned := map[int64]struct{}{}
+	var assignments []*btypes.ChangesetRolloutChangeset
+	for i, wave := range waves {
+		selectors := 0
+		if len(wave.ChangesetIDs) > 0 {
+			selectors++
+		}
+		if wave.Label != "" {
+			selectors++
+		}
+		if wave.RepoMetadataKey != "" {
+			selectors++
+		}
+		if selectors != 1 {
+			return nil, errors.Newf("wave %d must select changesets by exactly one of changesets, label or repository metadata", i+1)
+		}
+
+		var selected []*btypes.Changeset
+		switch {
+		case len(wave.ChangesetIDs) > 0:
+			for _, id := range wave.ChangesetIDs {
+				ch, ok := byID[id]
+				if !ok {
+					return nil, ErrChangesetsForJobNotFound
+				}
+				selected = append(selected, ch)
+			}
+
+		case wave.Label != "":
+			for _, ch := range cs {
+				for _, l := range ch.Labels() {
+					if l.Name == wave.Label {
+						selected = append(selected, ch)
+						break
+					}
+				}
+			}
+
+		default:
+			for _, ch := range cs {
+				md, err := getRepoMetadata(ch.RepoID)
+				if err != nil {
+					return nil, err
+				}
+				value, ok := md[wave.RepoMetadataKey]
+				if !ok {
+					continue
+				}
+				if wave.RepoMetadataValue != nil && (value == nil || *value != *wave.RepoMetadataValue) {
+					continue
+				}
+				selected = append(selected, ch)
+			}
+		}
+
+		var n int
+		for _, ch := range selected {
+			if _, ok := assigned[ch.ID]; ok {
+				continue
+			}
+			assigned[ch.ID] = struct{}{}
+			assignments = append(assignments, &btypes.ChangesetRolloutChangeset{ChangesetID: ch.ID, Wave: i})
+			n++
+		}
+		if n == 0 {
+			return nil, errors.Newf("wave %d does not select any open changesets", i+1)
+		}
+	}
+
+	return assignments, nil
+}
+
+// PauseChangesetRollout pauses the given running changeset rollout. Changesets
+// of the current wave that are already being merged are not affected, but no
+// further waves are started until the rollout is resumed.
+func (s *Service) PauseChangesetRollout(ctx context.Context, id int64) (rollout *btypes.ChangesetRollout, err error) {
+	ctx, _, endObservation := s.operations.pauseChangesetRollout.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.updateChangesetRolloutState(ctx, id, func(r *btypes.ChangesetRollout) error {
+		if r.State != btypes.ChangesetRolloutStateRunning {
+			return ErrInvalidChangesetRolloutState
+		}
+		r.State = btypes.ChangesetRolloutStatePaused
+		return nil
+	})
+}
+
+// ResumeChangesetRollout resumes the given paused or failed changeset rollout.
+// A failed rollout retries its current wave.
+func (s *Service) ResumeChangesetRollout(ctx context.Context, id int64) (rollout *btypes.ChangesetRollout, err error) {
+	ctx, _, endObservation := s.operations.resumeChangesetRollout.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.updateChangesetRolloutState(ctx, id, func(r *btypes.ChangesetRollout) error {
+		switch r.State {
+		case btypes.ChangesetRolloutStatePaused:
+		case btypes.ChangesetRolloutStateFailed:
+			r.CurrentBulkGroup = ""
+			r.WaveStartedAt = time.Time{}
+			r.WaveMergedAt = time.Time{}
+			r.FailureMessage = nil
+		default:
+			return ErrInvalidChangesetRolloutState
+		}
+		r.State = btypes.ChangesetRolloutStateRunning
+		return nil
+	})
+}
+
+func (s *Service) updateChangesetRolloutState(ctx context.Context, id int64, update func(*btypes.ChangesetRollout) error) (rollout *btypes.ChangesetRollout, err error) {
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	rollout, err = tx.GetChangesetRollout(ctx, store.GetChangesetRolloutOpts{ID: id})
+	if err != nil {
+		return nil, errors.Wrap(err, "loading changeset rollout")
+	}
+
+	batchChange, err := tx.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: rollout.BatchChangeID})
+	if err != nil {
+		return nil, errors.Wrap(err, "loading batch change")
+	}
+
+	// 🚨 SECURITY: Only the author of the batch change can control its rollouts.
+	if err := auth.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchChange.CreatorID); err != nil {
+		return nil, err
+	}
+
+	if err := update(rollout); err != nil {
+		return nil, err
+	}
+
+	if err := tx.UpdateChangesetRollout(ctx, rollout); err != nil {
+		return nil, err
+	}
+
+	return rollout, nil
+}
@@ -1266,6 +1266,126 @@ index e5af166..d44c3fc 100644
 		})
 	})
 
+	t.Run("ChangesetRollouts", func(t *testing.T) {
+		spec := testBatchSpec(admin.ID)
+		if err := s.CreateBatchSpec(ctx, spec); err != nil {
+			t.Fatal(err)
+		}
+
+		batchChange := testBatchChange(admin.ID, spec)
+		if err := s.CreateBatchChange(ctx, batchChange); err != nil {
+			t.Fatal(err)
+		}
+
+		var changesets []*btypes.Changeset
+		for i := 0; i < 3; i++ {
+			changesets = append(changesets, bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+				Repo:             rs[i].ID,
+				ReconcilerState:  btypes.ReconcilerStateCompleted,
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				BatchChange:      batchChange.ID,
+			}))
+		}
+
+		value := "eu"
+		if err := db.RepoKVPs().Create(ctx, rs[1].ID, database.KeyValuePair{Key: "region", Value: &value}); err != nil {
+			t.Fatal(err)
+		}
+
+		t.Run("invalid waves", func(t *testing.T) {
+			_, err := svc.StartChangesetRollout(adminCtx, StartChangesetRolloutOpts{
+				BatchChangeID: batchChange.ID,
+				Waves: []ChangesetRolloutWave{
+					{ChangesetIDs: []int64{changesets[0].ID}, Label: "canary"},
+				},
+			})
+			if err == nil {
+				t.Fatal("no error returned for wave with multiple selectors")
+			}
+
+			_, err = svc.StartChangesetRollout(adminCtx, StartChangesetRolloutOpts{
+				BatchChangeID: batchChange.ID,
+				Waves: []ChangesetRolloutWave{
+					{RepoMetadataKey: "team"},
+				},
+			})
+			if err == nil {
+				t.Fatal("no error returned for wave without changesets")
+			}
+		})
+
+		var rollout *btypes.ChangesetRollout
+		t.Run("start", func(t *testing.T) {
+			var err error
+			rollout, err = svc.StartChangesetRollout(adminCtx, StartChangesetRolloutOpts{
+				BatchChangeID: batchChange.ID,
+				Waves: []ChangesetRolloutWave{
+					{ChangesetIDs: []int64{changesets[0].ID}},
+					{RepoMetadataKey: "region", RepoMetadataValue: &value},
+					// changesets[0] is already part of the first wave.
+					{ChangesetIDs: []int64{changesets[0].ID, changesets[2].ID}},
+				},
+				SoakTime: time.Hour,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have, want := rollout.State, btypes.ChangesetRolloutStateRunning; have != want {
+				t.Fatalf("wrong state. want=%s, have=%s", want, have)
+			}
+			if have, want := rollout.WaveCount, 3; have != want {
+				t.Fatalf("wrong wave count. want=%d, have=%d", want, have)
+			}
+
+			have, err := s.ListChangesetRolloutChangesets(ctx, store.ListChangesetRolloutChangesetsOpts{RolloutID: rollout.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := []*btypes.ChangesetRolloutChangeset{
+				{RolloutID: rollout.ID, ChangesetID: changesets[0].ID, Wave: 0},
+				{RolloutID: rollout.ID, ChangesetID: changesets[1].ID, Wave: 1},
+				{RolloutID: rollout.ID, ChangesetID: changesets[2].ID, Wave: 2},
+			}
+			if diff := cmp.Diff(want, have); diff != "" {
+				t.Fatal(diff)
+			}
+
+			_, err = svc.StartChangesetRollout(adminCtx, StartChangesetRolloutOpts{
+				BatchChangeID: batchChange.ID,
+				Waves:         []ChangesetRolloutWave{{ChangesetIDs: []int64{changesets[0].ID}}},
+			})
+			if err != store.ErrActiveChangesetRolloutExists {
+				t.Fatalf("wrong error. want=%s, have=%s", store.ErrActiveChangesetRolloutExists, err)
+			}
+		})
+
+		t.Run("pause and resume", func(t *testing.T) {
+			_, err := svc.PauseChangesetRollout(userCtx, rollout.ID)
+			assertAuthError(t, err)
+
+			paused, err := svc.PauseChangesetRollout(adminCtx, rollout.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have, want := paused.State, btypes.ChangesetRolloutStatePaused; have != want {
+				t.Fatalf("wrong state. want=%s, have=%s", want, have)
+			}
+
+			if _, err := svc.PauseChangesetRollout(adminCtx, rollout.ID); err != ErrInvalidChangesetRolloutState {
+				t.Fatalf("wrong error. want=%s, have=%s", ErrInvalidChangesetRolloutState, err)
+			}
+
+			resumed, err := svc.ResumeChangesetRollout(adminCtx, rollout.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have, want := resumed.State, btypes.ChangesetRolloutStateRunning; have != want {
+				t.Fatalf("wrong state. want=%s, have=%s", want, have)
+			}
+		})
+	})
+
 	t.Run("ExecuteBatchSpec", func(t *testing.T) {
 		adminCtx := actor.WithActor(ctx, actor.FromUser(admin.ID))
 		t.Run("success", func(t *testing.T) {
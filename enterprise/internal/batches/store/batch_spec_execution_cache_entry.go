@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"github.com/keegancsmith/sqlf"
 	"github.com/lib/pq"
@@ -95,6 +96,11 @@ RETURNING %s
 type ListBatchSpecExecutionCacheEntriesOpts struct {
 	Keys   []string
 	UserID int32
+	// If true, entries matching Keys are returned regardless of the user that
+	// created them. This must only be used with content addressed keys, which
+	// can only be produced with access to everything the cached result was
+	// derived from.
+	AnyUser bool
 	// If true, explicitly return all entires.
 	All bool
 }
@@ -106,7 +112,7 @@ func (s *Store) ListBatchSpecExecutionCacheEntries(ctx context.Context, opts Lis
 	}})
 	defer endObservation(1, observation.Args{})
 
-	if !opts.All && opts.UserID == 0 {
+	if !opts.All && !opts.AnyUser && opts.UserID == 0 {
 		return nil, errors.New("cannot query cache entries without specifying UserID")
 	}
 
@@ -140,7 +146,7 @@ func listBatchSpecExecutionCacheEntriesQuery(opts *ListBatchSpecExecutionCacheEn
 		sqlf.Sprintf("batch_spec_execution_cache_entries.version = %s", btypes.CurrentCacheVersion),
 	}
 
-	if opts.UserID != 0 {
+	if opts.UserID != 0 && !opts.AnyUser {
 		preds = append(preds, sqlf.Sprintf("batch_spec_execution_cache_entries.user_id = %s", opts.UserID))
 	}
 	if len(opts.Keys) > 0 {
@@ -233,3 +239,52 @@ func scanBatchSpecExecutionCacheEntry(wj *btypes.BatchSpecExecutionCacheEntry, s
 		&wj.CreatedAt,
 	)
 }
+
+// UpsertBatchSpecExecutionCacheStats records how many steps of the given batch
+// spec could be served from the execution cache. Resolving the workspaces of a
+// batch spec again replaces the previously recorded statistics.
+func (s *Store) UpsertBatchSpecExecutionCacheStats(ctx context.Context, stats *btypes.BatchSpecExecutionCacheStats) (err error) {
+	ctx, _, endObservation := s.operations.upsertBatchSpecExecutionCacheStats.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int64("BatchSpecID", stats.BatchSpecID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if stats.CreatedAt.IsZero() {
+		stats.CreatedAt = s.now()
+	}
+
+	return s.Exec(ctx, sqlf.Sprintf(
+		upsertBatchSpecExecutionCacheStatsQueryFmtstr,
+		stats.BatchSpecID,
+		stats.Steps,
+		stats.CachedSteps,
+		stats.CreatedAt,
+	))
+}
+
+const upsertBatchSpecExecutionCacheStatsQueryFmtstr = `
+INSERT INTO batch_spec_execution_cache_stats (batch_spec_id, steps, cached_steps, created_at)
+VALUES (%s, %s, %s, %s)
+ON CONFLICT (batch_spec_id) DO UPDATE SET
+	steps = EXCLUDED.steps,
+	cached_steps = EXCLUDED.cached_steps,
+	created_at = EXCLUDED.created_at
+`
+
+// GetBatchSpecExecutionCacheStats sums up the execution cache statistics of all
+// batch specs resolved since the given time.
+func (s *Store) GetBatchSpecExecutionCacheStats(ctx context.Context, since time.Time) (stats btypes.BatchSpecExecutionCacheStats, err error) {
+	ctx, _, endObservation := s.operations.getBatchSpecExecutionCacheStats.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	err = s.QueryRow(ctx, sqlf.Sprintf(getBatchSpecExecutionCacheStatsQueryFmtstr, since)).Scan(&stats.Steps, &stats.CachedSteps)
+	return stats, err
+}
+
+const getBatchSpecExecutionCacheStatsQueryFmtstr = `
+SELECT
+	COALESCE(SUM(steps), 0),
+	COALESCE(SUM(cached_steps), 0)
+FROM batch_spec_execution_cache_stats
+WHERE created_at >= %s
+`
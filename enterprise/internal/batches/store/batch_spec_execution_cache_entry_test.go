@@ -78,6 +78,28 @@ func testStoreBatchSpecExecutionCacheEntries(t *testing.T, ctx context.Context,
 		})
 	})
 
+	t.Run("ListAnyUser", func(t *testing.T) {
+		shared := &btypes.BatchSpecExecutionCacheEntry{
+			UserID: entries[1].UserID,
+			Key:    entries[0].Key,
+			Value:  "shared value",
+		}
+		if err := s.CreateBatchSpecExecutionCacheEntry(ctx, shared); err != nil {
+			t.Fatal(err)
+		}
+
+		cs, err := s.ListBatchSpecExecutionCacheEntries(ctx, ListBatchSpecExecutionCacheEntriesOpts{
+			Keys:    []string{entries[0].Key},
+			AnyUser: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cs) != 2 {
+			t.Fatalf("unexpected number of cache entries. want=%d, have=%d", 2, len(cs))
+		}
+	})
+
 	t.Run("CreateWithConflictingKey", func(t *testing.T) {
 		clock.Add(1 * time.Minute)
 
@@ -144,6 +166,41 @@ func testStoreBatchSpecExecutionCacheEntries(t *testing.T, ctx context.Context,
 	})
 }
 
+func testStoreBatchSpecExecutionCacheStats(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
+	for i, stats := range []*btypes.BatchSpecExecutionCacheStats{
+		{BatchSpecID: 1, Steps: 4, CachedSteps: 3},
+		{BatchSpecID: 2, Steps: 6, CachedSteps: 0},
+		// Resolving a batch spec again replaces its statistics.
+		{BatchSpecID: 2, Steps: 6, CachedSteps: 2},
+	} {
+		if i == 1 {
+			clock.Add(1 * time.Hour)
+		}
+		if err := s.UpsertBatchSpecExecutionCacheStats(ctx, stats); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	have, err := s.GetBatchSpecExecutionCacheStats(ctx, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (btypes.BatchSpecExecutionCacheStats{Steps: 10, CachedSteps: 5}); have != want {
+		t.Fatalf("unexpected stats. want=%+v, have=%+v", want, have)
+	}
+	if have.HitRate() != 0.5 {
+		t.Fatalf("unexpected hit rate %f", have.HitRate())
+	}
+
+	have, err = s.GetBatchSpecExecutionCacheStats(ctx, clock.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (btypes.BatchSpecExecutionCacheStats{Steps: 6, CachedSteps: 2}); have != want {
+		t.Fatalf("unexpected stats. want=%+v, have=%+v", want, have)
+	}
+}
+
 func TestStore_CleanBatchSpecExecutionCacheEntries(t *testing.T) {
 	// Separate test function because we want a clean DB
 
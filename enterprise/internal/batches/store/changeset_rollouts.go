@@ -0,0 +1,365 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ErrActiveChangesetRolloutExists is returned when a rollout is created for a
+// batch change that already has a running or paused rollout.
+var ErrActiveChangesetRolloutExists = errors.New("batch change already has an active rollout")
+
+// changesetRolloutInsertColumns is the list of changeset_rollouts columns that
+// are modified in CreateChangesetRollout.
+var changesetRolloutInsertColumns = []*sqlf.Query{
+	sqlf.Sprintf("batch_change_id"),
+	sqlf.Sprintf("user_id"),
+	sqlf.Sprintf("state"),
+	sqlf.Sprintf("squash"),
+	sqlf.Sprintf("soak_time_seconds"),
+	sqlf.Sprintf("current_wave"),
+	sqlf.Sprintf("current_bulk_group"),
+	sqlf.Sprintf("wave_started_at"),
+	sqlf.Sprintf("wave_merged_at"),
+	sqlf.Sprintf("failure_message"),
+	sqlf.Sprintf("created_at"),
+	sqlf.Sprintf("updated_at"),
+}
+
+// changesetRolloutColumns are used by the changeset rollout related Store
+// methods to query and create changeset rollouts.
+var changesetRolloutColumns = []*sqlf.Query{
+	sqlf.Sprintf("changeset_rollouts.id"),
+	sqlf.Sprintf("changeset_rollouts.batch_change_id"),
+	sqlf.Sprintf("changeset_rollouts.user_id"),
+	sqlf.Sprintf("changeset_rollouts.state"),
+	sqlf.Sprintf("changeset_rollouts.squash"),
+	sqlf.Sprintf("changeset_rollouts.soak_time_seconds"),
+	sqlf.Sprintf("(SELECT COALESCE(MAX(wave) + 1, 0) FROM changeset_rollout_changesets WHERE rollout_id = changeset_rollouts.id) AS wave_count"),
+	sqlf.Sprintf("changeset_rollouts.current_wave"),
+	sqlf.Sprintf("changeset_rollouts.current_bulk_group"),
+	sqlf.Sprintf("changeset_rollouts.wave_started_at"),
+	sqlf.Sprintf("changeset_rollouts.wave_merged_at"),
+	sqlf.Sprintf("changeset_rollouts.failure_message"),
+	sqlf.Sprintf("changeset_rollouts.created_at"),
+	sqlf.Sprintf("changeset_rollouts.updated_at"),
+}
+
+var changesetRolloutChangesetColumns = []string{
+	"rollout_id",
+	"changeset_id",
+	"wave",
+}
+
+// CreateChangesetRollout creates the given changeset rollout and assigns the
+// given changesets to its waves.
+func (s *Store) CreateChangesetRollout(ctx context.Context, r *btypes.ChangesetRollout, cs ...*btypes.ChangesetRolloutChangeset) (err error) {
+	ctx, _, endObservation := s.operations.createChangesetRollout.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("count", len(cs)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	q := s.createChangesetRolloutQuery(r)
+	err = tx.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanChangesetRollout(r, sc)
+	})
+	if err != nil {
+		if isUniqueConstraintViolation(err, "changeset_rollouts_active_batch_change_id") {
+			return ErrActiveChangesetRolloutExists
+		}
+		return err
+	}
+
+	waveCount := 0
+	if err := batch.WithInserter(
+		ctx,
+		tx.Handle(),
+		"changeset_rollout_changesets",
+		batch.MaxNumPostgresParameters,
+		changesetRolloutChangesetColumns,
+		func(inserter *batch.Inserter) error {
+			for _, c := range cs {
+				c.RolloutID = r.ID
+				if c.Wave+1 > waveCount {
+					waveCount = c.Wave + 1
+				}
+				if err := inserter.Insert(ctx, c.RolloutID, c.ChangesetID, c.Wave); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	); err != nil {
+		return err
+	}
+	r.WaveCount = waveCount
+
+	return nil
+}
+
+var createChangesetRolloutQueryFmtstr = `
+INSERT INTO changeset_rollouts (%s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING %s
+`
+
+func (s *Store) createChangesetRolloutQuery(r *btypes.ChangesetRollout) *sqlf.Query {
+	if r.CreatedAt.IsZero() {
+		r.CreatedAt = s.now()
+	}
+
+	if r.UpdatedAt.IsZero() {
+		r.UpdatedAt = r.CreatedAt
+	}
+
+	return sqlf.Sprintf(
+		createChangesetRolloutQueryFmtstr,
+		sqlf.Join(changesetRolloutInsertColumns, ", "),
+		r.BatchChangeID,
+		r.UserID,
+		r.State,
+		r.Squash,
+		int(r.SoakTime/time.Second),
+		r.CurrentWave,
+		dbutil.NullStringColumn(r.CurrentBulkGroup),
+		dbutil.NullTimeColumn(r.WaveStartedAt),
+		dbutil.NullTimeColumn(r.WaveMergedAt),
+		r.FailureMessage,
+		r.CreatedAt,
+		r.UpdatedAt,
+		sqlf.Join(changesetRolloutColumns, ", "),
+	)
+}
+
+// GetChangesetRolloutOpts captures the query options needed for getting a
+// ChangesetRollout.
+type GetChangesetRolloutOpts struct {
+	ID int64
+}
+
+// GetChangesetRollout gets a ChangesetRollout matching the given options.
+func (s *Store) GetChangesetRollout(ctx context.Context, opts GetChangesetRolloutOpts) (r *btypes.ChangesetRollout, err error) {
+	ctx, _, endObservation := s.operations.getChangesetRollout.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(opts.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		getChangesetRolloutQueryFmtstr,
+		sqlf.Join(changesetRolloutColumns, ", "),
+		opts.ID,
+	)
+
+	var c btypes.ChangesetRollout
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanChangesetRollout(&c, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if c.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &c, nil
+}
+
+var getChangesetRolloutQueryFmtstr = `
+SELECT %s FROM changeset_rollouts
+WHERE changeset_rollouts.id = %s
+LIMIT 1
+`
+
+// ListChangesetRolloutsOpts captures the query options needed for listing
+// changeset rollouts.
+type ListChangesetRolloutsOpts struct {
+	BatchChangeID int64
+	States        []btypes.ChangesetRolloutState
+}
+
+// ListChangesetRollouts lists the ChangesetRollouts matching the given
+// options, oldest first.
+func (s *Store) ListChangesetRollouts(ctx context.Context, opts ListChangesetRolloutsOpts) (rs []*btypes.ChangesetRollout, err error) {
+	ctx, _, endObservation := s.operations.listChangesetRollouts.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(opts.BatchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := listChangesetRolloutsQuery(&opts)
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var c btypes.ChangesetRollout
+		if err := scanChangesetRollout(&c, sc); err != nil {
+			return err
+		}
+		rs = append(rs, &c)
+		return nil
+	})
+
+	return rs, err
+}
+
+var listChangesetRolloutsQueryFmtstr = `
+SELECT %s FROM changeset_rollouts
+WHERE %s
+ORDER BY changeset_rollouts.id ASC
+`
+
+func listChangesetRolloutsQuery(opts *ListChangesetRolloutsOpts) *sqlf.Query {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+
+	if opts.BatchChangeID != 0 {
+		preds = append(preds, sqlf.Sprintf("changeset_rollouts.batch_change_id = %s", opts.BatchChangeID))
+	}
+
+	if len(opts.States) > 0 {
+		states := make([]*sqlf.Query, 0, len(opts.States))
+		for _, state := range opts.States {
+			states = append(states, sqlf.Sprintf("%s", state))
+		}
+		preds = append(preds, sqlf.Sprintf("changeset_rollouts.state IN (%s)", sqlf.Join(states, ",")))
+	}
+
+	return sqlf.Sprintf(
+		listChangesetRolloutsQueryFmtstr,
+		sqlf.Join(changesetRolloutColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+// UpdateChangesetRollout updates the progress and state of the given changeset
+// rollout. ErrActiveChangesetRolloutExists is returned if the rollout is
+// resumed while another rollout of the same batch change is active.
+func (s *Store) UpdateChangesetRollout(ctx context.Context, r *btypes.ChangesetRollout) (err error) {
+	ctx, _, endObservation := s.operations.updateChangesetRollout.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(r.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	r.UpdatedAt = s.now()
+
+	q := sqlf.Sprintf(
+		updateChangesetRolloutQueryFmtstr,
+		r.State,
+		r.CurrentWave,
+		dbutil.NullStringColumn(r.CurrentBulkGroup),
+		dbutil.NullTimeColumn(r.WaveStartedAt),
+		dbutil.NullTimeColumn(r.WaveMergedAt),
+		r.FailureMessage,
+		r.UpdatedAt,
+		r.ID,
+		sqlf.Join(changesetRolloutColumns, ", "),
+	)
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanChangesetRollout(r, sc)
+	})
+	if isUniqueConstraintViolation(err, "changeset_rollouts_active_batch_change_id") {
+		return ErrActiveChangesetRolloutExists
+	}
+	return err
+}
+
+var updateChangesetRolloutQueryFmtstr = `
+UPDATE changeset_rollouts
+SET
+	state = %s,
+	current_wave = %s,
+	current_bulk_group = %s,
+	wave_started_at = %s,
+	wave_merged_at = %s,
+	failure_message = %s,
+	updated_at = %s
+WHERE id = %s
+RETURNING %s
+`
+
+// ListChangesetRolloutChangesetsOpts captures the query options needed for
+// listing the changesets of a changeset rollout.
+type ListChangesetRolloutChangesetsOpts struct {
+	RolloutID int64
+	// Wave, if set, only returns the changesets of the given wave.
+	Wave *int
+}
+
+// ListChangesetRolloutChangesets lists the wave assignments of the changesets
+// of a rollout, ordered by wave.
+func (s *Store) ListChangesetRolloutChangesets(ctx context.Context, opts ListChangesetRolloutChangesetsOpts) (cs []*btypes.ChangesetRolloutChangeset, err error) {
+	ctx, _, endObservation := s.operations.listChangesetRolloutChangesets.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("rolloutID", int(opts.RolloutID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	preds := []*sqlf.Query{sqlf.Sprintf("rollout_id = %s", opts.RolloutID)}
+	if opts.Wave != nil {
+		preds = append(preds, sqlf.Sprintf("wave = %s", *opts.Wave))
+	}
+
+	q := sqlf.Sprintf(listChangesetRolloutChangesetsQueryFmtstr, sqlf.Join(preds, "\n AND "))
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var c btypes.ChangesetRolloutChangeset
+		if err := sc.Scan(&c.RolloutID, &c.ChangesetID, &c.Wave); err != nil {
+			return err
+		}
+		cs = append(cs, &c)
+		return nil
+	})
+
+	return cs, err
+}
+
+var listChangesetRolloutChangesetsQueryFmtstr = `
+SELECT rollout_id, changeset_id, wave
+FROM changeset_rollout_changesets
+WHERE %s
+ORDER BY wave ASC, changeset_id ASC
+`
+
+func scanChangesetRollout(r *btypes.ChangesetRollout, s dbutil.Scanner) error {
+	var (
+		soakTimeSeconds int
+		failureMessage  sql.NullString
+	)
+	if err := s.Scan(
+		&r.ID,
+		&r.BatchChangeID,
+		&r.UserID,
+		&r.State,
+		&r.Squash,
+		&soakTimeSeconds,
+		&r.WaveCount,
+		&r.CurrentWave,
+		&dbutil.NullString{S: &r.CurrentBulkGroup},
+		&dbutil.NullTime{Time: &r.WaveStartedAt},
+		&dbutil.NullTime{Time: &r.WaveMergedAt},
+		&failureMessage,
+		&r.CreatedAt,
+		&r.UpdatedAt,
+	); err != nil {
+		return err
+	}
+	r.SoakTime = time.Duration(soakTimeSeconds) * time.Second
+	r.FailureMessage = nil
+	if failureMessage.Valid {
+		r.FailureMessage = &failureMessage.String
+	}
+	return nil
+}
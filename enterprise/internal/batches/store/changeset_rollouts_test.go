@@ -0,0 +1,162 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+func testStoreChangesetRollouts(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
+	rollout := &btypes.ChangesetRollout{
+		BatchChangeID: 910,
+		UserID:        1234,
+		State:         btypes.ChangesetRolloutStateRunning,
+		Squash:        true,
+		SoakTime:      30 * time.Minute,
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		err := s.CreateChangesetRollout(ctx, rollout,
+			&btypes.ChangesetRolloutChangeset{ChangesetID: 1, Wave: 0},
+			&btypes.ChangesetRolloutChangeset{ChangesetID: 2, Wave: 1},
+			&btypes.ChangesetRolloutChangeset{ChangesetID: 3, Wave: 1},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if rollout.ID == 0 {
+			t.Fatal("ID should not be zero")
+		}
+
+		want := &btypes.ChangesetRollout{
+			ID:            rollout.ID,
+			BatchChangeID: 910,
+			UserID:        1234,
+			State:         btypes.ChangesetRolloutStateRunning,
+			Squash:        true,
+			SoakTime:      30 * time.Minute,
+			WaveCount:     2,
+			CreatedAt:     clock.Now(),
+			UpdatedAt:     clock.Now(),
+		}
+		if diff := cmp.Diff(want, rollout); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("Create active rollout for same batch change", func(t *testing.T) {
+		other := &btypes.ChangesetRollout{
+			BatchChangeID: 910,
+			UserID:        1234,
+			State:         btypes.ChangesetRolloutStatePaused,
+		}
+		if have, want := s.CreateChangesetRollout(ctx, other), ErrActiveChangesetRolloutExists; have != want {
+			t.Fatalf("wrong error. want=%v, have=%v", want, have)
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		have, err := s.GetChangesetRollout(ctx, GetChangesetRolloutOpts{ID: rollout.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(rollout, have); diff != "" {
+			t.Fatal(diff)
+		}
+
+		_, err = s.GetChangesetRollout(ctx, GetChangesetRolloutOpts{ID: 0xdeadbeef})
+		if have, want := err, ErrNoResults; have != want {
+			t.Fatalf("wrong error. want=%v, have=%v", want, have)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		clock.Add(1 * time.Second)
+
+		failureMessage := "checks failed"
+		rollout.State = btypes.ChangesetRolloutStateFailed
+		rollout.CurrentWave = 1
+		rollout.CurrentBulkGroup = "bulk-group"
+		rollout.WaveStartedAt = clock.Now()
+		rollout.FailureMessage = &failureMessage
+
+		want := rollout.Clone()
+		want.UpdatedAt = clock.Now()
+
+		if err := s.UpdateChangesetRollout(ctx, rollout); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want, rollout); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		running := &btypes.ChangesetRollout{
+			BatchChangeID: 910,
+			UserID:        1234,
+			State:         btypes.ChangesetRolloutStateRunning,
+		}
+		if err := s.CreateChangesetRollout(ctx, running); err != nil {
+			t.Fatal(err)
+		}
+
+		for name, tc := range map[string]struct {
+			opts ListChangesetRolloutsOpts
+			want []*btypes.ChangesetRollout
+		}{
+			"all": {
+				opts: ListChangesetRolloutsOpts{},
+				want: []*btypes.ChangesetRollout{rollout, running},
+			},
+			"by batch change": {
+				opts: ListChangesetRolloutsOpts{BatchChangeID: 911},
+				want: nil,
+			},
+			"by state": {
+				opts: ListChangesetRolloutsOpts{States: []btypes.ChangesetRolloutState{btypes.ChangesetRolloutStateRunning}},
+				want: []*btypes.ChangesetRollout{running},
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				have, err := s.ListChangesetRollouts(ctx, tc.opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(tc.want, have); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+		}
+	})
+
+	t.Run("ListChangesetRolloutChangesets", func(t *testing.T) {
+		have, err := s.ListChangesetRolloutChangesets(ctx, ListChangesetRolloutChangesetsOpts{RolloutID: rollout.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []*btypes.ChangesetRolloutChangeset{
+			{RolloutID: rollout.ID, ChangesetID: 1, Wave: 0},
+			{RolloutID: rollout.ID, ChangesetID: 2, Wave: 1},
+			{RolloutID: rollout.ID, ChangesetID: 3, Wave: 1},
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatal(diff)
+		}
+
+		wave := 1
+		have, err = s.ListChangesetRolloutChangesets(ctx, ListChangesetRolloutChangesetsOpts{RolloutID: rollout.ID, Wave: &wave})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want[1:], have); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+}
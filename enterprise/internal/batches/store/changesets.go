@@ -68,6 +68,12 @@ var changesetStringColumns = SQLColumns{
 	"closing",
 	"syncer_error",
 	"detached_at",
+	"conflict_state",
+	"conflict_base_rev",
+	"conflict_spec_id",
+	"conflict_checked_at",
+	"rebase_base_rev",
+	"rebased_base_rev",
 }
 
 // changesetColumns are used by the changeset related Store methods and by
@@ -110,6 +116,12 @@ var changesetColumns = []*sqlf.Query{
 	sqlf.Sprintf("changesets.closing"),
 	sqlf.Sprintf("changesets.syncer_error"),
 	sqlf.Sprintf("changesets.detached_at"),
+	sqlf.Sprintf("changesets.conflict_state"),
+	sqlf.Sprintf("changesets.conflict_base_rev"),
+	sqlf.Sprintf("changesets.conflict_spec_id"),
+	sqlf.Sprintf("changesets.conflict_checked_at"),
+	sqlf.Sprintf("changesets.rebase_base_rev"),
+	sqlf.Sprintf("changesets.rebased_base_rev"),
 }
 
 // changesetInsertColumns is the list of changeset columns that are modified in
@@ -151,6 +163,9 @@ var changesetInsertColumns = []*sqlf.Query{
 	// the business logic for determining it is in one place and the field is
 	// indexable for searching.
 	sqlf.Sprintf("external_title"),
+	// The conflict_* columns and rebase_base_rev are owned by the conflict
+	// checker and written in Store.UpdateChangesetConflictState.
+	sqlf.Sprintf("rebased_base_rev"),
 }
 
 // changesetCodeHostStateInsertColumns are the columns that Store.UpdateChangesetCodeHostState uses to update a changeset
@@ -881,6 +896,7 @@ func (s *Store) changesetWriteQuery(q string, includeID bool, c *btypes.Changese
 		c.Closing,
 		c.SyncErrorMessage,
 		dbutil.NullStringColumn(title),
+		dbutil.NullStringColumn(c.RebasedBaseRev),
 	}
 
 	if includeID {
@@ -894,7 +910,7 @@ func (s *Store) changesetWriteQuery(q string, includeID bool, c *btypes.Changese
 
 var updateChangesetQueryFmtstr = `
 UPDATE changesets
-SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING
   %s
@@ -1127,6 +1143,99 @@ RETURNING
   %s
 `
 
+// UpdateChangesetConflictState updates only the columns of the given Changeset
+// that are maintained by the conflict checker. The updated_at column is not
+// touched, since the changeset itself didn't change.
+func (s *Store) UpdateChangesetConflictState(ctx context.Context, cs *btypes.Changeset) (err error) {
+	ctx, _, endObservation := s.operations.updateChangesetConflictState.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(cs.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		updateChangesetConflictStateQueryFmtstr,
+		string(cs.ConflictState),
+		dbutil.NullStringColumn(cs.ConflictBaseRev),
+		dbutil.NullInt64Column(cs.ConflictSpecID),
+		dbutil.NullTimeColumn(cs.ConflictCheckedAt),
+		dbutil.NullStringColumn(cs.RebaseBaseRev),
+		cs.ID,
+		sqlf.Join(changesetColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc dbutil.Scanner) (err error) {
+		return scanChangeset(cs, sc)
+	})
+}
+
+var updateChangesetConflictStateQueryFmtstr = `
+UPDATE changesets
+SET (conflict_state, conflict_base_rev, conflict_spec_id, conflict_checked_at, rebase_base_rev) = (%s, %s, %s, %s, %s)
+WHERE id = %s
+RETURNING
+  %s
+`
+
+// ListChangesetsForConflictCheckOpts captures the query options needed for
+// listing the changesets to check for conflicts.
+type ListChangesetsForConflictCheckOpts struct {
+	Limit int
+	// CheckedBefore excludes changesets that have been checked for conflicts
+	// at or after the given time.
+	CheckedBefore time.Time
+}
+
+// ListChangesetsForConflictCheck lists the open changesets that are owned by a
+// batch change and whose diff should be checked against the latest revision of
+// their base branch, least recently checked first.
+func (s *Store) ListChangesetsForConflictCheck(ctx context.Context, opts ListChangesetsForConflictCheckOpts) (cs btypes.Changesets, err error) {
+	ctx, _, endObservation := s.operations.listChangesetsForConflictCheck.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	q := listChangesetsForConflictCheckQuery(opts)
+
+	cs = make(btypes.Changesets, 0, opts.Limit)
+	err = s.query(ctx, q, func(sc dbutil.Scanner) (err error) {
+		var c btypes.Changeset
+		if err = scanChangeset(&c, sc); err != nil {
+			return err
+		}
+		cs = append(cs, &c)
+		return nil
+	})
+
+	return cs, err
+}
+
+var listChangesetsForConflictCheckQueryFmtstr = `
+SELECT %s FROM changesets
+INNER JOIN repo ON repo.id = changesets.repo_id
+WHERE %s
+ORDER BY changesets.conflict_checked_at ASC NULLS FIRST, changesets.id ASC
+LIMIT %s
+`
+
+func listChangesetsForConflictCheckQuery(opts ListChangesetsForConflictCheckOpts) *sqlf.Query {
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("repo.deleted_at IS NULL"),
+		sqlf.Sprintf("changesets.owned_by_batch_change_id IS NOT NULL"),
+		sqlf.Sprintf("changesets.current_spec_id IS NOT NULL"),
+		sqlf.Sprintf("changesets.publication_state = %s", btypes.ChangesetPublicationStatePublished),
+		sqlf.Sprintf("changesets.external_state IN (%s, %s)", btypes.ChangesetExternalStateOpen, btypes.ChangesetExternalStateDraft),
+	}
+
+	if !opts.CheckedBefore.IsZero() {
+		preds = append(preds, sqlf.Sprintf("(changesets.conflict_checked_at IS NULL OR changesets.conflict_checked_at < %s)", opts.CheckedBefore))
+	}
+
+	return sqlf.Sprintf(
+		listChangesetsForConflictCheckQueryFmtstr,
+		sqlf.Join(changesetColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+		opts.Limit,
+	)
+}
+
 // GetChangesetExternalIDs allows us to find the external ids for pull requests based on
 // a slice of head refs. We need this in order to match incoming webhooks to pull requests as
 // the only information they provide is the remote branch
@@ -1369,6 +1478,7 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		externalState       string
 		externalReviewState string
 		externalCheckState  string
+		conflictState       string
 		failureMessage      string
 		syncErrorMessage    string
 		reconcilerState     string
@@ -1408,6 +1518,12 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		&t.Closing,
 		&dbutil.NullString{S: &syncErrorMessage},
 		&dbutil.NullTime{Time: &t.DetachedAt},
+		&conflictState,
+		&dbutil.NullString{S: &t.ConflictBaseRev},
+		&dbutil.NullInt64{N: &t.ConflictSpecID},
+		&dbutil.NullTime{Time: &t.ConflictCheckedAt},
+		&dbutil.NullString{S: &t.RebaseBaseRev},
+		&dbutil.NullString{S: &t.RebasedBaseRev},
 	)
 	if err != nil {
 		return errors.Wrap(err, "scanning changeset")
@@ -1416,6 +1532,7 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 	t.ExternalState = btypes.ChangesetExternalState(externalState)
 	t.ExternalReviewState = btypes.ChangesetReviewState(externalReviewState)
 	t.ExternalCheckState = btypes.ChangesetCheckState(externalCheckState)
+	t.ConflictState = btypes.ChangesetConflictState(conflictState)
 	if failureMessage != "" {
 		t.FailureMessage = &failureMessage
 	}
@@ -1254,6 +1254,123 @@ func testStoreChangesets(t *testing.T, ctx context.Context, s *Store, clock bt.C
 		}
 	})
 
+	t.Run("UpdateChangesetConflictState", func(t *testing.T) {
+		cs := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+			Repo:               repo.ID,
+			BatchChange:        123,
+			CurrentSpec:        123,
+			OwnedByBatchChange: 123,
+			ExternalState:      btypes.ChangesetExternalStateOpen,
+			PublicationState:   btypes.ChangesetPublicationStatePublished,
+			ReconcilerState:    btypes.ReconcilerStateCompleted,
+		})
+		if cs.ConflictState != btypes.ChangesetConflictStateUnknown {
+			t.Fatalf("wrong default conflict state: %s", cs.ConflictState)
+		}
+
+		cs.ConflictState = btypes.ChangesetConflictStateMergeable
+		cs.ConflictBaseRev = "deadbeef"
+		cs.ConflictSpecID = 123
+		cs.ConflictCheckedAt = clock.Now()
+		cs.RebaseBaseRev = "deadbeef"
+		want := cs.Clone()
+
+		// These should not be updated.
+		cs.ReconcilerState = btypes.ReconcilerStateQueued
+		cs.ExternalState = btypes.ChangesetExternalStateClosed
+		cs.RebasedBaseRev = "deadbeef"
+
+		if err := s.UpdateChangesetConflictState(ctx, cs); err != nil {
+			t.Fatal(err)
+		}
+		have, err := s.GetChangesetByID(ctx, cs.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have, want); diff != "" {
+			t.Fatalf("invalid changeset state in DB: %s", diff)
+		}
+
+		// UpdateChangeset doesn't touch the conflict state, but records the
+		// rebased base revision.
+		have.ConflictState = btypes.ChangesetConflictStateConflicting
+		have.RebasedBaseRev = "deadbeef"
+		if err := s.UpdateChangeset(ctx, have); err != nil {
+			t.Fatal(err)
+		}
+		if have.ConflictState != btypes.ChangesetConflictStateMergeable {
+			t.Fatalf("conflict state was updated: %s", have.ConflictState)
+		}
+		if have.RebasedBaseRev != "deadbeef" {
+			t.Fatalf("rebased base rev was not updated: %q", have.RebasedBaseRev)
+		}
+	})
+
+	t.Run("ListChangesetsForConflictCheck", func(t *testing.T) {
+		baseOpts := bt.TestChangesetOpts{
+			Repo:               otherRepo.ID,
+			BatchChange:        4242,
+			CurrentSpec:        4242,
+			OwnedByBatchChange: 4242,
+			ExternalState:      btypes.ChangesetExternalStateOpen,
+			PublicationState:   btypes.ChangesetPublicationStatePublished,
+			ReconcilerState:    btypes.ReconcilerStateCompleted,
+		}
+
+		open := bt.CreateChangeset(t, ctx, s, baseOpts)
+
+		checkedOpts := baseOpts
+		checkedOpts.ExternalState = btypes.ChangesetExternalStateDraft
+		checked := bt.CreateChangeset(t, ctx, s, checkedOpts)
+		checked.ConflictState = btypes.ChangesetConflictStateMergeable
+		checked.ConflictCheckedAt = clock.Now().Add(-time.Hour)
+		if err := s.UpdateChangesetConflictState(ctx, checked); err != nil {
+			t.Fatal(err)
+		}
+
+		recentlyChecked := bt.CreateChangeset(t, ctx, s, baseOpts)
+		recentlyChecked.ConflictCheckedAt = clock.Now()
+		if err := s.UpdateChangesetConflictState(ctx, recentlyChecked); err != nil {
+			t.Fatal(err)
+		}
+
+		mergedOpts := baseOpts
+		mergedOpts.ExternalState = btypes.ChangesetExternalStateMerged
+		bt.CreateChangeset(t, ctx, s, mergedOpts)
+
+		unpublishedOpts := baseOpts
+		unpublishedOpts.ExternalState = ""
+		unpublishedOpts.PublicationState = btypes.ChangesetPublicationStateUnpublished
+		bt.CreateChangeset(t, ctx, s, unpublishedOpts)
+
+		importedOpts := baseOpts
+		importedOpts.CurrentSpec = 0
+		importedOpts.OwnedByBatchChange = 0
+		bt.CreateChangeset(t, ctx, s, importedOpts)
+
+		have, err := s.ListChangesetsForConflictCheck(ctx, ListChangesetsForConflictCheckOpts{
+			Limit:         10,
+			CheckedBefore: clock.Now().Add(-time.Minute),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Changesets that were never checked come first.
+		want := btypes.Changesets{open, checked}
+		if diff := cmp.Diff(have, want); diff != "" {
+			t.Fatalf("invalid changesets returned: %s", diff)
+		}
+
+		have, err = s.ListChangesetsForConflictCheck(ctx, ListChangesetsForConflictCheckOpts{Limit: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have, btypes.Changesets{open}); diff != "" {
+			t.Fatalf("invalid changesets returned: %s", diff)
+		}
+	})
+
 	t.Run("GetChangesetsStats", func(t *testing.T) {
 		var batchChangeID int64 = 191918
 		currentBatchChangeStats, err := s.GetChangesetsStats(ctx, batchChangeID)
@@ -40,11 +40,13 @@ func TestIntegration(t *testing.T) {
 		t.Run("CodeHosts", storeTest(db, nil, testStoreCodeHost))
 		t.Run("UserDeleteCascades", storeTest(db, nil, testUserDeleteCascades))
 		t.Run("ChangesetJobs", storeTest(db, nil, testStoreChangesetJobs))
+		t.Run("ChangesetRollouts", storeTest(db, nil, testStoreChangesetRollouts))
 		t.Run("BulkOperations", storeTest(db, nil, testStoreBulkOperations))
 		t.Run("BatchSpecWorkspaces", storeTest(db, nil, testStoreBatchSpecWorkspaces))
 		t.Run("BatchSpecWorkspaceExecutionJobs", storeTest(db, nil, testStoreBatchSpecWorkspaceExecutionJobs))
 		t.Run("BatchSpecResolutionJobs", storeTest(db, nil, testStoreBatchSpecResolutionJobs))
 		t.Run("BatchSpecExecutionCacheEntries", storeTest(db, nil, testStoreBatchSpecExecutionCacheEntries))
+		t.Run("BatchSpecExecutionCacheStats", storeTest(db, nil, testStoreBatchSpecExecutionCacheStats))
 
 		for name, key := range map[string]encryption.Key{
 			"no key":   nil,
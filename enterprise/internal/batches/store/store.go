@@ -215,6 +215,12 @@ type operations struct {
 	createChangesetJob *observation.Operation
 	getChangesetJob    *observation.Operation
 
+	createChangesetRollout         *observation.Operation
+	getChangesetRollout            *observation.Operation
+	listChangesetRollouts          *observation.Operation
+	updateChangesetRollout         *observation.Operation
+	listChangesetRolloutChangesets *observation.Operation
+
 	createChangesetSpec                      *observation.Operation
 	updateChangesetSpecBatchSpecID           *observation.Operation
 	deleteChangesetSpec                      *observation.Operation
@@ -238,6 +244,8 @@ type operations struct {
 	updateChangesetBatchChanges       *observation.Operation
 	updateChangesetUIPublicationState *observation.Operation
 	updateChangesetCodeHostState      *observation.Operation
+	updateChangesetConflictState      *observation.Operation
+	listChangesetsForConflictCheck    *observation.Operation
 	getChangesetExternalIDs           *observation.Operation
 	cancelQueuedBatchChangeChangesets *observation.Operation
 	enqueueChangesetsToClose          *observation.Operation
@@ -281,6 +289,9 @@ type operations struct {
 	markUsedBatchSpecExecutionCacheEntries *observation.Operation
 	createBatchSpecExecutionCacheEntry     *observation.Operation
 	cleanBatchSpecExecutionCacheEntries    *observation.Operation
+
+	upsertBatchSpecExecutionCacheStats *observation.Operation
+	getBatchSpecExecutionCacheStats    *observation.Operation
 }
 
 var (
@@ -359,6 +370,12 @@ func newOperations(observationCtx *observation.Context) *operations {
 			createChangesetJob: op("CreateChangesetJob"),
 			getChangesetJob:    op("GetChangesetJob"),
 
+			createChangesetRollout:         op("CreateChangesetRollout"),
+			getChangesetRollout:            op("GetChangesetRollout"),
+			listChangesetRollouts:          op("ListChangesetRollouts"),
+			updateChangesetRollout:         op("UpdateChangesetRollout"),
+			listChangesetRolloutChangesets: op("ListChangesetRolloutChangesets"),
+
 			createChangesetSpec:                      op("CreateChangesetSpec"),
 			updateChangesetSpecBatchSpecID:           op("UpdateChangesetSpecBatchSpecID"),
 			deleteChangesetSpec:                      op("DeleteChangesetSpec"),
@@ -382,6 +399,8 @@ func newOperations(observationCtx *observation.Context) *operations {
 			updateChangesetBatchChanges:       op("UpdateChangesetBatchChanges"),
 			updateChangesetUIPublicationState: op("UpdateChangesetUIPublicationState"),
 			updateChangesetCodeHostState:      op("UpdateChangesetCodeHostState"),
+			updateChangesetConflictState:      op("UpdateChangesetConflictState"),
+			listChangesetsForConflictCheck:    op("ListChangesetsForConflictCheck"),
 			getChangesetExternalIDs:           op("GetChangesetExternalIDs"),
 			cancelQueuedBatchChangeChangesets: op("CancelQueuedBatchChangeChangesets"),
 			enqueueChangesetsToClose:          op("EnqueueChangesetsToClose"),
@@ -426,6 +445,9 @@ func newOperations(observationCtx *observation.Context) *operations {
 			createBatchSpecExecutionCacheEntry:     op("CreateBatchSpecExecutionCacheEntry"),
 
 			cleanBatchSpecExecutionCacheEntries: op("CleanBatchSpecExecutionCacheEntries"),
+
+			upsertBatchSpecExecutionCacheStats: op("UpsertBatchSpecExecutionCacheStats"),
+			getBatchSpecExecutionCacheStats:    op("GetBatchSpecExecutionCacheStats"),
 		}
 	})
 
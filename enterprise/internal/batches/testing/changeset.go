@@ -48,6 +48,11 @@ type TestChangesetOpts struct {
 	IsArchived bool
 	Archive    bool
 
+	// RebaseBaseRev requests a rebase of the current spec onto the given
+	// revision. It's only set by BuildChangeset, since the conflict state isn't
+	// written when creating changesets.
+	RebaseBaseRev string
+
 	Metadata any
 }
 
@@ -130,6 +135,12 @@ func BuildChangeset(opts TestChangesetOpts) *btypes.Changeset {
 		changeset.DiffStatDeleted = &opts.DiffStatDeleted
 	}
 
+	if opts.RebaseBaseRev != "" {
+		changeset.ConflictState = btypes.ChangesetConflictStateMergeable
+		changeset.ConflictSpecID = opts.CurrentSpec
+		changeset.RebaseBaseRev = opts.RebaseBaseRev
+	}
+
 	return changeset
 }
 
@@ -147,6 +158,7 @@ type ChangesetAssertions struct {
 	ExternalForkNamespace string
 	DiffStat              *godiff.Stat
 	Closing               bool
+	RebasedBaseRev        string
 
 	Title string
 	Body  string
@@ -324,6 +336,10 @@ func AssertChangeset(t *testing.T, c *btypes.Changeset, a ChangesetAssertions) {
 		t.Fatalf("changeset ExternalForkNamespace wrong. want=%s, have=%s", want, have)
 	}
 
+	if have, want := c.RebasedBaseRev, a.RebasedBaseRev; have != want {
+		t.Fatalf("changeset RebasedBaseRev wrong. want=%s, have=%s", want, have)
+	}
+
 	if want := a.Title; want != "" {
 		have, err := c.Title()
 		if err != nil {
@@ -7,7 +7,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
 )
 
-const CurrentCacheVersion = 2
+// CurrentCacheVersion is the version of newly created cache entries. Entries of
+// older versions are never used and removed by the cache cleaner. Version 3
+// switched to content addressed step keys.
+const CurrentCacheVersion = 3
 
 type BatchSpecExecutionCacheEntry struct {
 	ID int64
@@ -32,3 +35,24 @@ func NewCacheEntryFromResult(key string, result *execution.AfterStepResult) (*Ba
 	entry := &BatchSpecExecutionCacheEntry{Key: key, Value: string(value)}
 	return entry, nil
 }
+
+// BatchSpecExecutionCacheStats describes how many of the steps of batch specs
+// could be served from the execution cache instead of being executed.
+type BatchSpecExecutionCacheStats struct {
+	BatchSpecID int64
+
+	// Steps is the number of steps to run over all workspaces.
+	Steps int32
+	// CachedSteps is the number of steps for which a cached result was found.
+	CachedSteps int32
+
+	CreatedAt time.Time
+}
+
+// HitRate returns the fraction of steps that could be served from the cache.
+func (s BatchSpecExecutionCacheStats) HitRate() float64 {
+	if s.Steps == 0 {
+		return 0
+	}
+	return float64(s.CachedSteps) / float64(s.Steps)
+}
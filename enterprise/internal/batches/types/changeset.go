@@ -235,6 +235,29 @@ func (s ChangesetCheckState) Valid() bool {
 	}
 }
 
+// ChangesetConflictState defines whether the diff of a changeset still applies
+// to the latest revision of its base branch.
+type ChangesetConflictState string
+
+// ChangesetConflictState constants.
+const (
+	ChangesetConflictStateUnknown     ChangesetConflictState = "UNKNOWN"
+	ChangesetConflictStateMergeable   ChangesetConflictState = "MERGEABLE"
+	ChangesetConflictStateConflicting ChangesetConflictState = "CONFLICTING"
+)
+
+// Valid returns true if the given Changeset conflict state is valid.
+func (s ChangesetConflictState) Valid() bool {
+	switch s {
+	case ChangesetConflictStateUnknown,
+		ChangesetConflictStateMergeable,
+		ChangesetConflictStateConflicting:
+		return true
+	default:
+		return false
+	}
+}
+
 // BatchChangeAssoc stores the details of a association to a BatchChange.
 type BatchChangeAssoc struct {
 	BatchChangeID int64 `json:"-"`
@@ -297,6 +320,24 @@ type Changeset struct {
 
 	// DetachedAt is the time when the changeset became "detached".
 	DetachedAt time.Time
+
+	// The following fields are maintained by the conflict checker and only
+	// written by Store.UpdateChangesetConflictState.
+	//
+	// ConflictState is whether the diff of the ConflictSpecID changeset spec
+	// applies to ConflictBaseRev, the latest revision of the base branch at
+	// ConflictCheckedAt.
+	ConflictState     ChangesetConflictState
+	ConflictBaseRev   string
+	ConflictSpecID    int64
+	ConflictCheckedAt time.Time
+	// RebaseBaseRev is set when the changeset should be rebased onto the given
+	// revision of the base branch the next time it is reconciled.
+	RebaseBaseRev string
+
+	// RebasedBaseRev is the revision the last commit pushed by the reconciler
+	// was based on, if it differs from the base revision of the spec.
+	RebasedBaseRev string
 }
 
 // RecordID is needed to implement the workerutil.Record interface.
@@ -336,6 +377,14 @@ func (c *Changeset) IsImporting() bool { return c.Unpublished() && c.CurrentSpec
 // IsImported returns whether the Changeset is imported
 func (c *Changeset) IsImported() bool { return c.OwnedByBatchChangeID == 0 }
 
+// NeedsRebase returns whether the commit of the current spec should be pushed
+// again on top of RebaseBaseRev.
+func (c *Changeset) NeedsRebase() bool {
+	return c.RebaseBaseRev != "" &&
+		c.ConflictSpecID == c.CurrentSpecID &&
+		c.RebaseBaseRev != c.RebasedBaseRev
+}
+
 // SetCurrentSpec sets the CurrentSpecID field and copies the diff stat over from the spec.
 func (c *Changeset) SetCurrentSpec(spec *ChangesetSpec) {
 	c.CurrentSpecID = spec.ID
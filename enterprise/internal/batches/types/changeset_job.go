@@ -47,6 +47,9 @@ var (
 	ChangesetJobTypeMerge     ChangesetJobType = "merge"
 	ChangesetJobTypeClose     ChangesetJobType = "close"
 	ChangesetJobTypePublish   ChangesetJobType = "publish"
+	// ChangesetJobTypeRolloutMerge merges a changeset as part of a wave of a
+	// ChangesetRollout.
+	ChangesetJobTypeRolloutMerge ChangesetJobType = "rollout_merge"
 )
 
 type ChangesetJobCommentPayload struct {
@@ -67,6 +70,11 @@ type ChangesetJobPublishPayload struct {
 	Draft bool `json:"draft"`
 }
 
+type ChangesetJobRolloutMergePayload struct {
+	RolloutID int64 `json:"rolloutID"`
+	Squash    bool  `json:"squash,omitempty"`
+}
+
 // ChangesetJob describes a one-time action to be taken on a changeset.
 type ChangesetJob struct {
 	ID int64
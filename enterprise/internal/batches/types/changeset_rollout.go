@@ -0,0 +1,78 @@
+package types
+
+import "time"
+
+// ChangesetRolloutState defines the possible states of a changeset rollout.
+type ChangesetRolloutState string
+
+// ChangesetRolloutState constants.
+const (
+	ChangesetRolloutStateRunning   ChangesetRolloutState = "RUNNING"
+	ChangesetRolloutStatePaused    ChangesetRolloutState = "PAUSED"
+	ChangesetRolloutStateFailed    ChangesetRolloutState = "FAILED"
+	ChangesetRolloutStateCompleted ChangesetRolloutState = "COMPLETED"
+)
+
+// Valid returns true if the given ChangesetRolloutState is valid.
+func (s ChangesetRolloutState) Valid() bool {
+	switch s {
+	case ChangesetRolloutStateRunning,
+		ChangesetRolloutStatePaused,
+		ChangesetRolloutStateFailed,
+		ChangesetRolloutStateCompleted:
+		return true
+	default:
+		return false
+	}
+}
+
+// Active returns true if the rollout has not finished yet.
+func (s ChangesetRolloutState) Active() bool {
+	return s == ChangesetRolloutStateRunning || s == ChangesetRolloutStatePaused
+}
+
+// ChangesetRollout merges the changesets of a batch change in waves. The
+// changesets of a wave are only merged once the previous wave has been merged
+// and its soak time has elapsed.
+type ChangesetRollout struct {
+	ID            int64
+	BatchChangeID int64
+	// UserID is the user that started the rollout. The merge jobs of the
+	// rollout are executed on behalf of this user.
+	UserID int32
+	State  ChangesetRolloutState
+	Squash bool
+	// SoakTime is the time to wait after a wave has been merged before the
+	// next wave is started.
+	SoakTime time.Duration
+	// WaveCount is the number of waves of the rollout. It is derived from the
+	// changesets of the rollout and not stored.
+	WaveCount int
+
+	// CurrentWave is the zero-based index of the wave that is currently being
+	// merged.
+	CurrentWave int
+	// CurrentBulkGroup is the bulk group of the changeset jobs that merge the
+	// changesets of the current wave. It is empty until the wave is started.
+	CurrentBulkGroup string
+	WaveStartedAt    time.Time
+	// WaveMergedAt is set once all changesets of the current wave are merged.
+	WaveMergedAt   time.Time
+	FailureMessage *string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Clone returns a clone of a ChangesetRollout.
+func (r *ChangesetRollout) Clone() *ChangesetRollout {
+	rr := *r
+	return &rr
+}
+
+// ChangesetRolloutChangeset assigns a changeset to a wave of a rollout.
+type ChangesetRolloutChangeset struct {
+	RolloutID   int64
+	ChangesetID int64
+	Wave        int
+}
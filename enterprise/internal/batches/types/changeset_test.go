@@ -32,6 +32,36 @@ func TestChangeset_Clone(t *testing.T) {
 	}
 }
 
+func TestChangeset_NeedsRebase(t *testing.T) {
+	for name, tc := range map[string]struct {
+		c    Changeset
+		want bool
+	}{
+		"no rebase requested": {
+			c:    Changeset{CurrentSpecID: 1, ConflictSpecID: 1},
+			want: false,
+		},
+		"rebase requested": {
+			c:    Changeset{CurrentSpecID: 1, ConflictSpecID: 1, RebaseBaseRev: "deadbeef"},
+			want: true,
+		},
+		"already rebased": {
+			c:    Changeset{CurrentSpecID: 1, ConflictSpecID: 1, RebaseBaseRev: "deadbeef", RebasedBaseRev: "deadbeef"},
+			want: false,
+		},
+		"requested for outdated spec": {
+			c:    Changeset{CurrentSpecID: 2, ConflictSpecID: 1, RebaseBaseRev: "deadbeef"},
+			want: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if have := tc.c.NeedsRebase(); have != tc.want {
+				t.Errorf("wrong result: have %t, want %t", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestChangeset_DiffStat(t *testing.T) {
 	var (
 		added   int32 = 77
@@ -31,6 +31,7 @@ type changeset struct {
 	ForkNamespace       *string      `json:"fork_namespace"`
 	ReviewState         *string      `json:"review_state"`
 	CheckState          *string      `json:"check_state"`
+	ConflictState       string       `json:"conflict_state"`
 	Error               *string      `json:"error"`
 	SyncerError         *string      `json:"syncer_error"`
 }
@@ -89,9 +90,17 @@ func MarshalChangeset(ctx context.Context, db basestore.ShareableStore, cs *type
 		ForkNamespace:       nullable(cs.ExternalForkNamespace),
 		ReviewState:         nullable(string(cs.ExternalReviewState)),
 		CheckState:          nullable(string(cs.ExternalCheckState)),
+		ConflictState:       string(conflictState(cs)),
 		Error:               cs.FailureMessage,
 		SyncerError:         cs.SyncErrorMessage,
 	}
 
 	return json.Marshal(&payload)
 }
+
+func conflictState(cs *types.Changeset) types.ChangesetConflictState {
+	if cs.ConflictState == "" {
+		return types.ChangesetConflictStateUnknown
+	}
+	return cs.ConflictState
+}
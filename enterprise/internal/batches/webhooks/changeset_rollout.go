@@ -0,0 +1,40 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+
+	bgql "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/graphql"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+)
+
+// changesetRollout represents a changeset rollout in a webhook payload.
+type changesetRollout struct {
+	ID             graphql.ID `json:"id"`
+	BatchChange    graphql.ID `json:"batch_change_id"`
+	State          string     `json:"state"`
+	CurrentWave    int        `json:"current_wave"`
+	WaveCount      int        `json:"wave_count"`
+	FailureMessage *string    `json:"failure_message"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func MarshalChangesetRollout(ctx context.Context, db basestore.ShareableStore, r *types.ChangesetRollout) ([]byte, error) {
+	payload := changesetRollout{
+		ID:             bgql.MarshalChangesetRolloutID(r.ID),
+		BatchChange:    bgql.MarshalBatchChangeID(r.BatchChangeID),
+		State:          string(r.State),
+		CurrentWave:    r.CurrentWave,
+		WaveCount:      r.WaveCount,
+		FailureMessage: r.FailureMessage,
+		CreatedAt:      r.CreatedAt,
+		UpdatedAt:      r.UpdatedAt,
+	}
+
+	return json.Marshal(&payload)
+}
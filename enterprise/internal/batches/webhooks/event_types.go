@@ -3,14 +3,18 @@ package webhooks
 import "github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
 
 const (
-	BatchChangeApply      = "batch_change:apply"
-	BatchChangeClose      = "batch_change:close"
-	BatchChangeDelete     = "batch_change:delete"
-	ChangesetClose        = "changeset:close"
-	ChangesetPublish      = "changeset:publish"
-	ChangesetPublishError = "changeset:publish_error"
-	ChangesetUpdate       = "changeset:update"
-	ChangesetUpdateError  = "changeset:update_error"
+	BatchChangeApply            = "batch_change:apply"
+	BatchChangeClose            = "batch_change:close"
+	BatchChangeDelete           = "batch_change:delete"
+	BatchChangeRolloutCompleted = "batch_change:rollout_completed"
+	BatchChangeRolloutFailed    = "batch_change:rollout_failed"
+	ChangesetClose              = "changeset:close"
+	ChangesetConflict           = "changeset:conflict"
+	ChangesetConflictResolved   = "changeset:conflict_resolved"
+	ChangesetPublish            = "changeset:publish"
+	ChangesetPublishError       = "changeset:publish_error"
+	ChangesetUpdate             = "changeset:update"
+	ChangesetUpdateError        = "changeset:update_error"
 )
 
 func init() {
@@ -29,11 +33,31 @@ func init() {
 		Description: "sent when a batch change is deleted",
 	})
 
+	outbound.RegisterEventType(outbound.EventType{
+		Key:         BatchChangeRolloutCompleted,
+		Description: "sent when all waves of a changeset rollout have been merged",
+	})
+
+	outbound.RegisterEventType(outbound.EventType{
+		Key:         BatchChangeRolloutFailed,
+		Description: "sent when a changeset rollout is stopped because a wave failed to merge",
+	})
+
 	outbound.RegisterEventType(outbound.EventType{
 		Key:         ChangesetClose,
 		Description: "sent when a changeset is closed",
 	})
 
+	outbound.RegisterEventType(outbound.EventType{
+		Key:         ChangesetConflict,
+		Description: "sent when the diff of a changeset no longer applies to its base branch",
+	})
+
+	outbound.RegisterEventType(outbound.EventType{
+		Key:         ChangesetConflictResolved,
+		Description: "sent when the diff of a conflicting changeset applies to its base branch again",
+	})
+
 	outbound.RegisterEventType(outbound.EventType{
 		Key:         ChangesetPublish,
 		Description: "sent when a changeset is published to the code host",
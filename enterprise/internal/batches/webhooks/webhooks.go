@@ -66,6 +66,13 @@ func EnqueueBatchChange(
 	Enqueue(ctx, logger, db, eventType, MarshalBatchChange, bc)
 }
 
+func EnqueueChangesetRollout(
+	ctx context.Context, logger log.Logger, db basestore.ShareableStore,
+	eventType string, r *types.ChangesetRollout,
+) {
+	Enqueue(ctx, logger, db, eventType, MarshalChangesetRollout, r)
+}
+
 func EnqueueChangeset(
 	ctx context.Context, logger log.Logger, db basestore.ShareableStore,
 	eventType string, ch *types.Changeset,
@@ -7521,6 +7521,9 @@ type MockWorkerStore[T workerutil.Record] struct {
 	// QueuedCountFunc is an instance of a mock function object controlling
 	// the behavior of the method QueuedCount.
 	QueuedCountFunc *WorkerStoreQueuedCountFunc[T]
+	// QueuedCountByFairnessKeyFunc is an instance of a mock function object
+	// controlling the behavior of the method QueuedCountByFairnessKey.
+	QueuedCountByFairnessKeyFunc *WorkerStoreQueuedCountByFairnessKeyFunc[T]
 	// RequeueFunc is an instance of a mock function object controlling the
 	// behavior of the method Requeue.
 	RequeueFunc *WorkerStoreRequeueFunc[T]
@@ -7584,6 +7587,11 @@ func NewMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 				return
 			},
 		},
+		QueuedCountByFairnessKeyFunc: &WorkerStoreQueuedCountByFairnessKeyFunc[T]{
+			defaultHook: func(context.Context, int) (r0 map[string]int, r1 error) {
+				return
+			},
+		},
 		RequeueFunc: &WorkerStoreRequeueFunc[T]{
 			defaultHook: func(context.Context, int, time.Time) (r0 error) {
 				return
@@ -7656,6 +7664,11 @@ func NewStrictMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 				panic("unexpected invocation of MockWorkerStore.QueuedCount")
 			},
 		},
+		QueuedCountByFairnessKeyFunc: &WorkerStoreQueuedCountByFairnessKeyFunc[T]{
+			defaultHook: func(context.Context, int) (map[string]int, error) {
+				panic("unexpected invocation of MockWorkerStore.QueuedCountByFairnessKey")
+			},
+		},
 		RequeueFunc: &WorkerStoreRequeueFunc[T]{
 			defaultHook: func(context.Context, int, time.Time) error {
 				panic("unexpected invocation of MockWorkerStore.Requeue")
@@ -7711,6 +7724,9 @@ func NewMockWorkerStoreFrom[T workerutil.Record](i store1.Store[T]) *MockWorkerS
 		QueuedCountFunc: &WorkerStoreQueuedCountFunc[T]{
 			defaultHook: i.QueuedCount,
 		},
+		QueuedCountByFairnessKeyFunc: &WorkerStoreQueuedCountByFairnessKeyFunc[T]{
+			defaultHook: i.QueuedCountByFairnessKey,
+		},
 		RequeueFunc: &WorkerStoreRequeueFunc[T]{
 			defaultHook: i.Requeue,
 		},
@@ -8723,6 +8739,117 @@ func (c WorkerStoreQueuedCountFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// WorkerStoreQueuedCountByFairnessKeyFunc describes the behavior when the
+// QueuedCountByFairnessKey method of the parent MockWorkerStore instance is
+// invoked.
+type WorkerStoreQueuedCountByFairnessKeyFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, int) (map[string]int, error)
+	hooks       []func(context.Context, int) (map[string]int, error)
+	history     []WorkerStoreQueuedCountByFairnessKeyFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// QueuedCountByFairnessKey delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockWorkerStore[T]) QueuedCountByFairnessKey(v0 context.Context, v1 int) (map[string]int, error) {
+	r0, r1 := m.QueuedCountByFairnessKeyFunc.nextHook()(v0, v1)
+	m.QueuedCountByFairnessKeyFunc.appendCall(WorkerStoreQueuedCountByFairnessKeyFuncCall[T]{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// QueuedCountByFairnessKey method of the parent MockWorkerStore instance is
+// invoked and the hook queue is empty.
+func (f *WorkerStoreQueuedCountByFairnessKeyFunc[T]) SetDefaultHook(hook func(context.Context, int) (map[string]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// QueuedCountByFairnessKey method of the parent MockWorkerStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *WorkerStoreQueuedCountByFairnessKeyFunc[T]) PushHook(hook func(context.Context, int) (map[string]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *WorkerStoreQueuedCountByFairnessKeyFunc[T]) SetDefaultReturn(r0 map[string]int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (map[string]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *WorkerStoreQueuedCountByFairnessKeyFunc[T]) PushReturn(r0 map[string]int, r1 error) {
+	f.PushHook(func(context.Context, int) (map[string]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *WorkerStoreQueuedCountByFairnessKeyFunc[T]) nextHook() func(context.Context, int) (map[string]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *WorkerStoreQueuedCountByFairnessKeyFunc[T]) appendCall(r0 WorkerStoreQueuedCountByFairnessKeyFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of WorkerStoreQueuedCountByFairnessKeyFuncCall
+// objects describing the invocations of this function.
+func (f *WorkerStoreQueuedCountByFairnessKeyFunc[T]) History() []WorkerStoreQueuedCountByFairnessKeyFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]WorkerStoreQueuedCountByFairnessKeyFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// WorkerStoreQueuedCountByFairnessKeyFuncCall is an object that describes
+// an invocation of method QueuedCountByFairnessKey on an instance of
+// MockWorkerStore.
+type WorkerStoreQueuedCountByFairnessKeyFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[string]int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c WorkerStoreQueuedCountByFairnessKeyFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c WorkerStoreQueuedCountByFairnessKeyFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // WorkerStoreRequeueFunc describes the behavior when the Requeue method of
 // the parent MockWorkerStore instance is invoked.
 type WorkerStoreRequeueFunc[T workerutil.Record] struct {
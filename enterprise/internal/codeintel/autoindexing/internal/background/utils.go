@@ -25,14 +25,15 @@ const StalledIndexMaxAge = time.Second * 25
 const IndexMaxNumResets = 3
 
 var IndexWorkerStoreOptions = dbworkerstore.Options[types.Index]{
-	Name:              "codeintel_index",
-	TableName:         "lsif_indexes",
-	ViewName:          "lsif_indexes_with_repository_name u",
-	ColumnExpressions: indexColumnsWithNullRank,
-	Scan:              dbworkerstore.BuildWorkerScan(scanIndex),
-	OrderByExpression: sqlf.Sprintf("u.queued_at, u.id"),
-	StalledMaxAge:     StalledIndexMaxAge,
-	MaxNumResets:      IndexMaxNumResets,
+	Name:                  "codeintel_index",
+	TableName:             "lsif_indexes",
+	ViewName:              "lsif_indexes_with_repository_name u",
+	ColumnExpressions:     indexColumnsWithNullRank,
+	Scan:                  dbworkerstore.BuildWorkerScan(scanIndex),
+	OrderByExpression:     sqlf.Sprintf("u.queued_at, u.id"),
+	FairnessKeyExpression: sqlf.Sprintf("u.repository_id"),
+	StalledMaxAge:         StalledIndexMaxAge,
+	MaxNumResets:          IndexMaxNumResets,
 }
 
 var indexColumnsWithNullRank = []*sqlf.Query{
@@ -59,6 +60,7 @@ var indexColumnsWithNullRank = []*sqlf.Query{
 	sqlf.Sprintf(`(SELECT MAX(id) FROM lsif_uploads WHERE associated_index_id = u.id) AS associated_upload_id`),
 	sqlf.Sprintf(`u.should_reindex`),
 	sqlf.Sprintf(`u.requested_envvars`),
+	sqlf.Sprintf(`u.caches`),
 }
 
 func scanIndex(s dbutil.Scanner) (index types.Index, err error) {
@@ -87,6 +89,7 @@ func scanIndex(s dbutil.Scanner) (index types.Index, err error) {
 		&index.AssociatedUploadID,
 		&index.ShouldReindex,
 		pq.Array(&index.RequestedEnvVars),
+		pq.Array(&index.Caches),
 	); err != nil {
 		return index, err
 	}
@@ -0,0 +1,112 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindexing/internal/inference/libs"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestDotnetGenerator(t *testing.T) {
+	expectedIndexerImage, _ := libs.DefaultIndexerForLang("dotnet")
+
+	job := func(root, target string) config.IndexJob {
+		return config.IndexJob{
+			Steps: []config.DockerStep{
+				{
+					Root:     root,
+					Image:    expectedIndexerImage,
+					Commands: []string{`export NUGET_PACKAGES="$PWD/.sourcegraph-cache/nuget"`, "dotnet restore " + target},
+				},
+			},
+			LocalSteps:  []string{`export NUGET_PACKAGES="$PWD/.sourcegraph-cache/nuget"`},
+			Root:        root,
+			Indexer:     expectedIndexerImage,
+			IndexerArgs: []string{"scip-dotnet", "index", target},
+			Outfile:     "index.scip",
+			Caches: []config.Cache{
+				{Key: "nuget", KeyFiles: []string{target}, Paths: []string{".sourcegraph-cache/nuget"}},
+			},
+		}
+	}
+
+	testGenerators(t,
+		generatorTestCase{
+			description: "dotnet solution",
+			repositoryContents: map[string]string{
+				"App.sln":                      "",
+				"src/App/App.csproj":           "",
+				"src/App/Program.cs":           "",
+				"src/App.Core/App.Core.csproj": "",
+			},
+			expected: []config.IndexJob{
+				job("", "App.sln"),
+			},
+		},
+		generatorTestCase{
+			description: "dotnet projects without solution",
+			repositoryContents: map[string]string{
+				"src/App/App.csproj":           "",
+				"src/App/Program.cs":           "",
+				"lib/Lib/Lib.csproj":           "",
+				"tests/Lib.Tests/Tests.csproj": "", // excluded
+			},
+			expected: []config.IndexJob{
+				job("lib/Lib", "Lib.csproj"),
+				job("src/App", "App.csproj"),
+			},
+		},
+		generatorTestCase{
+			description: "dotnet solution with external project",
+			repositoryContents: map[string]string{
+				"service/Service.sln":            "",
+				"service/Service/Service.csproj": "",
+				"tools/Tool/Tool.csproj":         "",
+			},
+			expected: []config.IndexJob{
+				job("service", "Service.sln"),
+				job("tools/Tool", "Tool.csproj"),
+			},
+		},
+		generatorTestCase{
+			description: "dotnet sources without project (no match)",
+			repositoryContents: map[string]string{
+				"src/Program.cs": "",
+			},
+			expected: []config.IndexJob{},
+		},
+	)
+}
+
+func TestDotnetHinter(t *testing.T) {
+	expectedIndexerImage, _ := libs.DefaultIndexerForLang("dotnet")
+
+	testHinters(t,
+		hinterTestCase{
+			description: "basic hints",
+			repositoryContents: map[string]string{
+				"App.sln":             "",
+				"src/App/App.csproj":  "",
+				"src/App/Program.cs":  "",
+				"scripts/Generate.cs": "",
+			},
+			expected: []config.IndexJobHint{
+				{
+					Root:           "",
+					Indexer:        expectedIndexerImage,
+					HintConfidence: config.HintConfidenceProjectStructureSupported,
+				},
+				{
+					Root:           "scripts",
+					Indexer:        expectedIndexerImage,
+					HintConfidence: config.HintConfidenceLanguageSupport,
+				},
+				{
+					Root:           "src/App",
+					Indexer:        expectedIndexerImage,
+					HintConfidence: config.HintConfidenceProjectStructureSupported,
+				},
+			},
+		},
+	)
+}
@@ -7,6 +7,12 @@ import (
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
 )
 
+const gomodcacheString = `export GOMODCACHE="$PWD/.sourcegraph-cache/go-mod"`
+
+var gomodCaches = []config.Cache{
+	{Key: "go-mod", KeyFiles: []string{"go.sum"}, Paths: []string{".sourcegraph-cache/go-mod"}},
+}
+
 const netrcString = `if [ "$NETRC_DATA" ]; then
   echo "Writing netrc config to $HOME/.netrc"
   echo "$NETRC_DATA" > ~/.netrc
@@ -31,30 +37,58 @@ func TestGoGenerator(t *testing.T) {
 						{
 							Root:     "foo/bar",
 							Image:    expectedIndexerImage,
-							Commands: []string{netrcString, "go mod download"},
+							Commands: []string{netrcString, gomodcacheString, "go mod download"},
 						},
 					},
-					LocalSteps:       []string{netrcString},
+					LocalSteps:       []string{netrcString, gomodcacheString},
 					Root:             "foo/bar",
 					Indexer:          expectedIndexerImage,
 					IndexerArgs:      []string{"lsif-go", "--no-animation"},
 					Outfile:          "",
 					RequestedEnvVars: []string{"GOPRIVATE", "GOPROXY", "GONOPROXY", "GOSUMDB", "GONOSUMDB", "NETRC_DATA"},
+					Caches:           gomodCaches,
 				},
 				{
 					Steps: []config.DockerStep{
 						{
 							Root:     "foo/baz",
 							Image:    expectedIndexerImage,
-							Commands: []string{netrcString, "go mod download"},
+							Commands: []string{netrcString, gomodcacheString, "go mod download"},
 						},
 					},
-					LocalSteps:       []string{netrcString},
+					LocalSteps:       []string{netrcString, gomodcacheString},
 					Root:             "foo/baz",
 					Indexer:          expectedIndexerImage,
 					IndexerArgs:      []string{"lsif-go", "--no-animation"},
 					Outfile:          "",
 					RequestedEnvVars: []string{"GOPRIVATE", "GOPROXY", "GONOPROXY", "GOSUMDB", "GONOSUMDB", "NETRC_DATA"},
+					Caches:           gomodCaches,
+				},
+			},
+		},
+		generatorTestCase{
+			description: "go modules in excluded directories",
+			repositoryContents: map[string]string{
+				"go.mod":                   "",
+				"vendor/foo/bar/go.mod":    "",
+				"internal/testdata/go.mod": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "",
+							Image:    expectedIndexerImage,
+							Commands: []string{netrcString, gomodcacheString, "go mod download"},
+						},
+					},
+					LocalSteps:       []string{netrcString, gomodcacheString},
+					Root:             "",
+					Indexer:          expectedIndexerImage,
+					IndexerArgs:      []string{"lsif-go", "--no-animation"},
+					Outfile:          "",
+					RequestedEnvVars: []string{"GOPRIVATE", "GOPROXY", "GONOPROXY", "GOSUMDB", "GONOSUMDB", "NETRC_DATA"},
+					Caches:           gomodCaches,
 				},
 			},
 		},
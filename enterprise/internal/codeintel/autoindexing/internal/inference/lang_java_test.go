@@ -21,11 +21,72 @@ func TestJavaGenerator(t *testing.T) {
 			expected: []config.IndexJob{
 				{
 					Steps:       nil,
-					LocalSteps:  nil,
+					LocalSteps:  []string{`export COURSIER_CACHE="$PWD/.sourcegraph-cache/coursier"`},
 					Root:        "",
 					Indexer:     expectedIndexerImage,
 					IndexerArgs: []string{"scip-java", "index", "--build-tool=scip"},
 					Outfile:     "index.scip",
+					Caches: []config.Cache{
+						{Key: "scip-java", KeyFiles: []string{"lsif-java.json"}, Paths: []string{".sourcegraph-cache/coursier"}},
+					},
+				},
+			},
+		},
+		generatorTestCase{
+			description: "java project with lsif-java.json and build files",
+			repositoryContents: map[string]string{
+				"lsif-java.json":   "",
+				"build.gradle.kts": "",
+				"sbt/build.sbt":    "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  []string{`export COURSIER_CACHE="$PWD/.sourcegraph-cache/coursier"`},
+					Root:        "",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=scip"},
+					Outfile:     "index.scip",
+					Caches: []config.Cache{
+						{Key: "scip-java", KeyFiles: []string{"lsif-java.json"}, Paths: []string{".sourcegraph-cache/coursier"}},
+					},
+				},
+			},
+		},
+		generatorTestCase{
+			description: "gradle kotlin dsl and sbt projects",
+			repositoryContents: map[string]string{
+				"kotlin/build.gradle.kts":          "",
+				"kotlin/settings.gradle.kts":       "",
+				"kotlin/app/build.gradle.kts":      "", // nested
+				"kotlin/app/src/main/kotlin/A.kt":  "",
+				"scala/build.sbt":                  "",
+				"scala/src/main/scala/A.scala":     "",
+				"groovy/build.gradle":              "", // unsupported build tool
+				"examples/sample/build.gradle.kts": "", // excluded
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  []string{`export COURSIER_CACHE="$PWD/.sourcegraph-cache/coursier"`},
+					Root:        "kotlin",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-java", "index"},
+					Outfile:     "index.scip",
+					Caches: []config.Cache{
+						{Key: "scip-java", KeyFiles: []string{"build.gradle.kts"}, Paths: []string{".sourcegraph-cache/coursier"}},
+					},
+				},
+				{
+					Steps:       nil,
+					LocalSteps:  []string{`export COURSIER_CACHE="$PWD/.sourcegraph-cache/coursier"`},
+					Root:        "scala",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-java", "index"},
+					Outfile:     "index.scip",
+					Caches: []config.Cache{
+						{Key: "scip-java", KeyFiles: []string{"build.sbt"}, Paths: []string{".sourcegraph-cache/coursier"}},
+					},
 				},
 			},
 		},
@@ -50,6 +111,7 @@ func TestJavaHinter(t *testing.T) {
 				"build.gradle":               "",
 				"kt/build.gradle.kts":        "",
 				"maven/pom.xml":              "",
+				"sbt/build.sbt":              "",
 				"subdir/src/java/App.java":   "",
 				"subdir/src/kotlin/App.kt":   "",
 				"subdir/src/scala/App.scala": "",
@@ -70,6 +132,11 @@ func TestJavaHinter(t *testing.T) {
 					Indexer:        expectedIndexerImage,
 					HintConfidence: config.HintConfidenceProjectStructureSupported,
 				},
+				{
+					Root:           "sbt",
+					Indexer:        expectedIndexerImage,
+					HintConfidence: config.HintConfidenceProjectStructureSupported,
+				},
 				{
 					Root:           "subdir/src/java",
 					Indexer:        expectedIndexerImage,
@@ -0,0 +1,91 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindexing/internal/inference/libs"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestPHPGenerator(t *testing.T) {
+	expectedIndexerImage, _ := libs.DefaultIndexerForLang("php")
+
+	job := func(root string) config.IndexJob {
+		return config.IndexJob{
+			Steps: []config.DockerStep{
+				{
+					Root:  root,
+					Image: expectedIndexerImage,
+					Commands: []string{
+						`export COMPOSER_CACHE_DIR="$PWD/.sourcegraph-cache/composer"`,
+						"composer install --no-interaction --no-scripts --ignore-platform-reqs",
+					},
+				},
+			},
+			LocalSteps:  []string{`export COMPOSER_CACHE_DIR="$PWD/.sourcegraph-cache/composer"`},
+			Root:        root,
+			Indexer:     expectedIndexerImage,
+			IndexerArgs: []string{"lsif-php"},
+			Outfile:     "",
+			Caches: []config.Cache{
+				{Key: "composer", KeyFiles: []string{"composer.json"}, Paths: []string{".sourcegraph-cache/composer"}},
+			},
+		}
+	}
+
+	testGenerators(t,
+		generatorTestCase{
+			description: "composer projects",
+			repositoryContents: map[string]string{
+				"composer.json":                 "",
+				"src/App.php":                   "",
+				"packages/http/composer.json":   "",
+				"vendor/acme/lib/composer.json": "", // excluded
+				"tests/fixture/composer.json":   "", // excluded
+			},
+			expected: []config.IndexJob{
+				job(""),
+				job("packages/http"),
+			},
+		},
+		generatorTestCase{
+			description: "php sources without composer.json (no match)",
+			repositoryContents: map[string]string{
+				"index.php": "",
+			},
+			expected: []config.IndexJob{},
+		},
+	)
+}
+
+func TestPHPHinter(t *testing.T) {
+	expectedIndexerImage, _ := libs.DefaultIndexerForLang("php")
+
+	testHinters(t,
+		hinterTestCase{
+			description: "basic hints",
+			repositoryContents: map[string]string{
+				"composer.json":    "",
+				"src/App.php":      "",
+				"public/index.php": "",
+			},
+			expected: []config.IndexJobHint{
+				{
+					Root:           "",
+					Indexer:        expectedIndexerImage,
+					HintConfidence: config.HintConfidenceProjectStructureSupported,
+				},
+				{
+					Root:           "public",
+					Indexer:        expectedIndexerImage,
+					HintConfidence: config.HintConfidenceLanguageSupport,
+				},
+				{
+					Root:           "src",
+					Indexer:        expectedIndexerImage,
+					HintConfidence: config.HintConfidenceLanguageSupport,
+				},
+			},
+		},
+	)
+}
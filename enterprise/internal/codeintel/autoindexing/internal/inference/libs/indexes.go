@@ -17,8 +17,10 @@ type indexesAPI struct{}
 
 var defaultIndexers = map[string]string{
 	"clang":      "sourcegraph/lsif-clang",
+	"dotnet":     "sourcegraph/scip-dotnet",
 	"go":         "sourcegraph/lsif-go",
 	"java":       "sourcegraph/scip-java",
+	"php":        "davidrjenni/lsif-php",
 	"python":     "sourcegraph/scip-python",
 	"rust":       "sourcegraph/scip-rust",
 	"typescript": "sourcegraph/scip-typescript",
@@ -37,6 +39,13 @@ var defaultIndexerSHAs = map[string]string{
 	"sourcegraph/scip-ruby":       "sha256:1e7538eead787a9a220e54c442eaf10372f3f41d2be2871713e6ec367bd40f81",
 }
 
+// Indexers not (yet) pinned to a SHA are referenced by tag. Running update-shas.sh
+// pins them to the SHA of that tag and removes them from this map.
+var defaultIndexerTags = map[string]string{
+	"sourcegraph/scip-dotnet": "latest",
+	"davidrjenni/lsif-php":    "latest",
+}
+
 func DefaultIndexerForLang(language string) (string, bool) {
 	indexer, ok := defaultIndexers[language]
 	if !ok {
@@ -45,6 +54,10 @@ func DefaultIndexerForLang(language string) (string, bool) {
 
 	sha, ok := defaultIndexerSHAs[indexer]
 	if !ok {
+		if tag, ok := defaultIndexerTags[indexer]; ok {
+			return fmt.Sprintf("%s:%s", indexer, tag), true
+		}
+
 		panic(fmt.Sprintf("no SHA set for indexer %q", indexer))
 	}
 
@@ -5,19 +5,27 @@ set -u
 DOCKER_USER=${DOCKER_USER:?"No DOCKER_USER is set."}
 DOCKER_PASS=${DOCKER_PASS:?"No DOCKER_PASS is set."}
 
-for indexer in lsif-clang lsif-go lsif-rust scip-rust scip-java scip-python scip-typescript scip-ruby; do
+for image in sourcegraph/lsif-clang sourcegraph/lsif-go sourcegraph/lsif-rust sourcegraph/scip-rust sourcegraph/scip-java sourcegraph/scip-python sourcegraph/scip-typescript sourcegraph/scip-ruby sourcegraph/scip-dotnet davidrjenni/lsif-php; do
   tag="latest"
-  if [[ "${indexer}" = "scip-python" ]] || [[ "${indexer}" = "scip-typescript" || "${indexer}" = "scip-ruby" ]]; then
+  if [[ "${image}" = "sourcegraph/scip-python" ]] || [[ "${image}" = "sourcegraph/scip-typescript" || "${image}" = "sourcegraph/scip-ruby" ]]; then
     tag="autoindex"
   fi
 
-  sha=$(docker buildx imagetools inspect sourcegraph/${indexer}:${tag} --raw | sha256sum | awk '{print "\"" "sha256:" $1 "\""}')
+  sha=$(docker buildx imagetools inspect ${image}:${tag} --raw | sha256sum | awk '{print "\"" "sha256:" $1 "\""}')
 
-  sed -i.bak \
-    "s|\("'"'"sourcegraph/${indexer}"'"'":\).*|\1${sha},|g" \
-    indexes.go
+  if grep -q "\"${image}\":.*sha256:" indexes.go; then
+    sed -i.bak \
+      "s|\("'"'"${image}"'"'":\).*sha256:.*|\1${sha},|g" \
+      indexes.go
+  else
+    # Pin an indexer that is still referenced by tag
+    sed -i.bak \
+      -e "/^var defaultIndexerSHAs/,/^}/ s|^}|\t"'"'"${image}"'"'": ${sha},\n}|" \
+      -e "/^var defaultIndexerTags/,/^}/ {\\|"'"'"${image}"'"'":|d}" \
+      indexes.go
+  fi
 
-  echo "Updated tag for ${indexer}"
+  echo "Updated tag for ${image}"
   rm indexes.go.bak
 done
 
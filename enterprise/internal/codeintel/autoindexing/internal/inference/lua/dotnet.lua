@@ -0,0 +1,129 @@
+local path = require "path"
+local pattern = require "sg.autoindex.patterns"
+local recognizer = require "sg.autoindex.recognizer"
+
+local shared = require "sg.autoindex.shared"
+
+local indexer = require("sg.autoindex.indexes").get "dotnet"
+local outfile = "index.scip"
+
+-- Keep the packages restored by NuGet within the workspace, so that they are saved after
+-- the job succeeded and restored for the next job.
+local nuget_steps = [[export NUGET_PACKAGES="$PWD/.sourcegraph-cache/nuget"]]
+
+local is_solution_file = function(base)
+  return string.match(base, "%.sln$") ~= nil
+end
+
+local is_project_file = function(base)
+  return string.match(base, "%.csproj$") ~= nil
+end
+
+local make_job = function(root, target)
+  return {
+    steps = {
+      {
+        root = root,
+        image = indexer,
+        commands = { nuget_steps, "dotnet restore " .. target },
+      },
+    },
+    local_steps = { nuget_steps },
+    root = root,
+    indexer = indexer,
+    indexer_args = { "scip-dotnet", "index", target },
+    outfile = outfile,
+    caches = {
+      {
+        key = "nuget",
+        key_files = { target },
+        paths = { ".sourcegraph-cache/nuget" },
+      },
+    },
+  }
+end
+
+return recognizer.new_path_recognizer {
+  patterns = {
+    pattern.new_path_extension "sln",
+    pattern.new_path_extension "csproj",
+    pattern.new_path_extension "cs",
+    pattern.new_path_exclude(shared.exclude_paths),
+  },
+
+  -- Invoked when solution, project, or C# files exist
+  generate = function(_, paths)
+    local jobs = {}
+    local solution_dirs = {}
+
+    -- Index each solution, which covers all of the projects it references
+    for i = 1, #paths do
+      local dir = path.dirname(paths[i])
+      local base = path.basename(paths[i])
+
+      if is_solution_file(base) then
+        table.insert(jobs, make_job(dir, base))
+        solution_dirs[dir] = true
+      end
+    end
+
+    -- Index each project that is not nested under the directory of a solution
+    for i = 1, #paths do
+      local base = path.basename(paths[i])
+
+      if is_project_file(base) then
+        local covered = false
+        local ancestors = path.ancestors(paths[i])
+        for j = 1, #ancestors do
+          if solution_dirs[ancestors[j]] then
+            covered = true
+            break
+          end
+        end
+
+        if not covered then
+          table.insert(jobs, make_job(path.dirname(paths[i]), base))
+        end
+      end
+    end
+
+    return jobs
+  end,
+
+  -- Invoked when solution, project, or C# files exist
+  hints = function(_, paths)
+    local hints = {}
+    local visited = {}
+
+    for i = 1, #paths do
+      local dir = path.dirname(paths[i])
+      local base = path.basename(paths[i])
+
+      if visited[dir] == nil and (is_solution_file(base) or is_project_file(base)) then
+        table.insert(hints, {
+          root = dir,
+          indexer = indexer,
+          confidence = "PROJECT_STRUCTURE_SUPPORTED",
+        })
+
+        visited[dir] = true
+      end
+    end
+
+    for i = 1, #paths do
+      local dir = path.dirname(paths[i])
+
+      if visited[dir] == nil then
+        table.insert(hints, {
+          root = dir,
+          indexer = indexer,
+          confidence = "LANGUAGE_SUPPORTED",
+        })
+
+        visited[dir] = true
+      end
+    end
+
+    return hints
+  end,
+}
@@ -15,6 +15,18 @@ else
 fi
 ]]
 
+-- Keep the module cache within the workspace, so that it is saved after the job
+-- succeeded and restored for the next job as long as go.sum does not change.
+local gomodcache_steps = [[export GOMODCACHE="$PWD/.sourcegraph-cache/go-mod"]]
+
+local gomod_caches = {
+  {
+    key = "go-mod",
+    key_files = { "go.sum" },
+    paths = { ".sourcegraph-cache/go-mod" },
+  },
+}
+
 local exclude_paths = pattern.new_path_combine(shared.exclude_paths, {
   pattern.new_path_segment "vendor",
 })
@@ -36,15 +48,16 @@ local gomod_recognizer = recognizer.new_path_recognizer {
           {
             root = root,
             image = indexer,
-            commands = { netrc_steps, "go mod download" },
+            commands = { netrc_steps, gomodcache_steps, "go mod download" },
           },
         },
-        local_steps = { netrc_steps },
+        local_steps = { netrc_steps, gomodcache_steps },
         root = root,
         indexer = indexer,
         indexer_args = { "lsif-go", "--no-animation" },
         outfile = "",
         requested_envvars = { "GOPRIVATE", "GOPROXY", "GONOPROXY", "GOSUMDB", "GONOSUMDB", "NETRC_DATA" },
+        caches = gomod_caches,
       })
     end
 
@@ -2,11 +2,35 @@ local path = require "path"
 local recognizer = require "sg.autoindex.recognizer"
 local pattern = require "sg.autoindex.patterns"
 
+local shared = require "sg.autoindex.shared"
+local util = require "sg.autoindex.util"
+
 local indexer = require("sg.autoindex.indexes").get "java"
 local outfile = "index.scip"
 
+-- Keep the dependencies fetched by coursier within the workspace, so that they are
+-- saved after the job succeeded and restored for the next job as long as the build
+-- configuration does not change.
+local coursier_steps = [[export COURSIER_CACHE="$PWD/.sourcegraph-cache/coursier"]]
+
+local coursier_caches = function(key_file)
+  return {
+    {
+      key = "scip-java",
+      key_files = { key_file },
+      paths = { ".sourcegraph-cache/coursier" },
+    },
+  }
+end
+
 local is_project_structure_supported = function(base)
-  return base == "pom.xml" or base == "build.gradle" or base == "build.gradle.kts"
+  return base == "pom.xml" or base == "build.gradle" or base == "build.gradle.kts" or base == "build.sbt"
+end
+
+-- Build definitions from which scip-java can infer the build tool of a project
+-- without any additional configuration.
+local is_build_tool_supported = function(base)
+  return base == "build.gradle.kts" or base == "build.sbt"
 end
 
 return recognizer.new_path_recognizer {
@@ -17,31 +41,79 @@ return recognizer.new_path_recognizer {
     pattern.new_path_basename "pom.xml",
     pattern.new_path_basename "build.gradle",
     pattern.new_path_basename "build.gradle.kts",
+    pattern.new_path_basename "build.sbt",
   },
 
-  -- Invoked when Java, Scala, Kotlin, or Gradle build files exist
+  -- Invoked when Java, Scala, Kotlin, Gradle, or sbt build files exist
   generate = function(api)
     api:register(recognizer.new_path_recognizer {
       patterns = {
         pattern.new_path_literal "lsif-java.json",
+        pattern.new_path_basename "build.gradle.kts",
+        pattern.new_path_basename "build.sbt",
+        pattern.new_path_exclude(shared.exclude_paths),
       },
 
-      -- Invoked when lsif-java.json exists in root of repository
-      generate = function(api, paths)
-        return {
-          steps = {},
-          root = "",
-          indexer = indexer,
-          indexer_args = { "scip-java", "index", "--build-tool=scip" },
-          outfile = outfile,
-        }
+      -- Invoked when lsif-java.json exists in root of repository, or when Gradle Kotlin DSL
+      -- or sbt build files exist
+      generate = function(_, paths)
+        -- An explicit build configuration takes precedence over inferred build tools
+        if util.contains(paths, "lsif-java.json") then
+          return {
+            steps = {},
+            local_steps = { coursier_steps },
+            root = "",
+            indexer = indexer,
+            indexer_args = { "scip-java", "index", "--build-tool=scip" },
+            outfile = outfile,
+            caches = coursier_caches "lsif-java.json",
+          }
+        end
+
+        local roots = {}
+        for i = 1, #paths do
+          local base = path.basename(paths[i])
+          if is_build_tool_supported(base) then
+            roots[path.dirname(paths[i])] = base
+          end
+        end
+
+        local jobs = {}
+        for i = 1, #paths do
+          local root = path.dirname(paths[i])
+          local base = path.basename(paths[i])
+          -- Builds of nested (sub)projects are driven by the build of the enclosing project
+          local nested = false
+          local ancestors = path.ancestors(root)
+          for j = 1, #ancestors do
+            if ancestors[j] ~= root and roots[ancestors[j]] ~= nil then
+              nested = true
+              break
+            end
+          end
+
+          -- Each root is visited once, via the build file that registered it
+          if roots[root] == base and not nested then
+            table.insert(jobs, {
+              steps = {},
+              local_steps = { coursier_steps },
+              root = root,
+              indexer = indexer,
+              indexer_args = { "scip-java", "index" },
+              outfile = outfile,
+              caches = coursier_caches(base),
+            })
+          end
+        end
+
+        return jobs
       end,
     })
 
     return {}
   end,
 
-  -- Invoked when Java, Scala, Kotlin, or Gradle build files exist
+  -- Invoked when Java, Scala, Kotlin, Gradle, or sbt build files exist
   hints = function(_, paths)
     local hints = {}
     local visited = {}
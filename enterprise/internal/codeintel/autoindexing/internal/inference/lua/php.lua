@@ -0,0 +1,99 @@
+local path = require "path"
+local pattern = require "sg.autoindex.patterns"
+local recognizer = require "sg.autoindex.recognizer"
+
+local shared = require "sg.autoindex.shared"
+
+local indexer = require("sg.autoindex.indexes").get "php"
+
+-- Keep the packages downloaded by composer within the workspace, so that they are saved
+-- after the job succeeded and restored for the next job as long as composer.json does not
+-- change.
+local composer_steps = [[export COMPOSER_CACHE_DIR="$PWD/.sourcegraph-cache/composer"]]
+
+local exclude_paths = pattern.new_path_combine(shared.exclude_paths, {
+  pattern.new_path_segment "vendor",
+})
+
+local is_composer_file = function(base)
+  return base == "composer.json"
+end
+
+return recognizer.new_path_recognizer {
+  patterns = {
+    pattern.new_path_basename "composer.json",
+    pattern.new_path_extension "php",
+    pattern.new_path_exclude(exclude_paths),
+  },
+
+  -- Invoked when composer.json or PHP files exist
+  generate = function(_, paths)
+    local jobs = {}
+    for i = 1, #paths do
+      local root = path.dirname(paths[i])
+
+      if is_composer_file(path.basename(paths[i])) then
+        table.insert(jobs, {
+          steps = {
+            {
+              root = root,
+              image = indexer,
+              -- Scripts may require tooling that is not available in the indexer image
+              commands = { composer_steps, "composer install --no-interaction --no-scripts --ignore-platform-reqs" },
+            },
+          },
+          local_steps = { composer_steps },
+          root = root,
+          indexer = indexer,
+          indexer_args = { "lsif-php" },
+          outfile = "",
+          caches = {
+            {
+              key = "composer",
+              key_files = { "composer.json" },
+              paths = { ".sourcegraph-cache/composer" },
+            },
+          },
+        })
+      end
+    end
+
+    return jobs
+  end,
+
+  -- Invoked when composer.json or PHP files exist
+  hints = function(_, paths)
+    local hints = {}
+    local visited = {}
+
+    for i = 1, #paths do
+      local dir = path.dirname(paths[i])
+
+      if visited[dir] == nil and is_composer_file(path.basename(paths[i])) then
+        table.insert(hints, {
+          root = dir,
+          indexer = indexer,
+          confidence = "PROJECT_STRUCTURE_SUPPORTED",
+        })
+
+        visited[dir] = true
+      end
+    end
+
+    for i = 1, #paths do
+      local dir = path.dirname(paths[i])
+
+      if visited[dir] == nil then
+        table.insert(hints, {
+          root = dir,
+          indexer = indexer,
+          confidence = "LANGUAGE_SUPPORTED",
+        })
+
+        visited[dir] = true
+      end
+    end
+
+    return hints
+  end,
+}
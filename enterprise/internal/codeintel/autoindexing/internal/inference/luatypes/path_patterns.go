@@ -53,15 +53,21 @@ func FlattenPatterns(pathPatterns []*PathPattern, inverted bool) (patterns []str
 }
 
 // FlattenPattern returns the set of patterns matching the given inverted flag on this
-// path pattern or any of its descendants.
+// path pattern or any of its descendants. The children of an exclude pattern are the
+// (non-inverted) patterns it excludes, so they are flattened as part of the exclusion.
 func FlattenPattern(pathPattern *PathPattern, inverted bool) (patterns []string) {
 	if pathPattern.invert == inverted {
 		if pathPattern.pattern != "" {
 			patterns = append(patterns, pathPattern.pattern)
 		}
 
+		childrenInverted := inverted
+		if pathPattern.invert {
+			childrenInverted = false
+		}
+
 		for _, child := range pathPattern.children {
-			patterns = append(patterns, FlattenPattern(child, inverted)...)
+			patterns = append(patterns, FlattenPattern(child, childrenInverted)...)
 		}
 	}
 
@@ -0,0 +1,28 @@
+package luatypes
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFlattenPattern(t *testing.T) {
+	exclude := NewCombinedPattern([]*PathPattern{
+		NewPattern("(^|/)vendor/"),
+		NewCombinedPattern([]*PathPattern{NewPattern("(^|/)testdata/")}),
+	})
+	patterns := []*PathPattern{
+		NewPattern("(^|/)go\\.mod$"),
+		NewExcludePattern([]*PathPattern{exclude}),
+	}
+
+	if diff := cmp.Diff([]string{"(^|/)go\\.mod$"}, FlattenPatterns(patterns, false)); diff != "" {
+		t.Errorf("unexpected patterns (-want +got):\n%s", diff)
+	}
+
+	// The patterns nested under an exclude pattern are not inverted themselves, but are
+	// flattened as part of the exclusion
+	if diff := cmp.Diff([]string{"(^|/)vendor/", "(^|/)testdata/"}, FlattenPatterns(patterns, true)); diff != "" {
+		t.Errorf("unexpected inverted patterns (-want +got):\n%s", diff)
+	}
+}
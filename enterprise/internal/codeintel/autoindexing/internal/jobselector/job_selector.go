@@ -232,6 +232,7 @@ func convertIndexConfiguration(repositoryID int, commit string, indexConfigurati
 			IndexerArgs:      indexJob.IndexerArgs,
 			Outfile:          indexJob.Outfile,
 			RequestedEnvVars: indexJob.RequestedEnvVars,
+			Caches:           convertCaches(indexJob.Caches),
 		})
 	}
 
@@ -262,8 +263,27 @@ func convertInferredConfiguration(repositoryID int, commit string, indexJobs []c
 			IndexerArgs:      indexJob.IndexerArgs,
 			Outfile:          indexJob.Outfile,
 			RequestedEnvVars: indexJob.RequestedEnvVars,
+			Caches:           convertCaches(indexJob.Caches),
 		})
 	}
 
 	return indexes
 }
+
+// convertCaches converts the caches declared by an index job into the caches of an index record.
+func convertCaches(caches []config.Cache) []types.IndexCache {
+	if len(caches) == 0 {
+		return nil
+	}
+
+	indexCaches := make([]types.IndexCache, 0, len(caches))
+	for _, cache := range caches {
+		indexCaches = append(indexCaches, types.IndexCache{
+			Key:      cache.Key,
+			KeyFiles: cache.KeyFiles,
+			Paths:    cache.Paths,
+		})
+	}
+
+	return indexCaches
+}
@@ -47,6 +47,7 @@ func scanIndex(s dbutil.Scanner) (index types.Index, err error) {
 		&index.AssociatedUploadID,
 		&index.ShouldReindex,
 		pq.Array(&index.RequestedEnvVars),
+		pq.Array(&index.Caches),
 	); err != nil {
 		return index, err
 	}
@@ -107,6 +108,7 @@ func scanIndexWithCount(s dbutil.Scanner) (index types.Index, count int, err err
 		&index.AssociatedUploadID,
 		&index.ShouldReindex,
 		pq.Array(&index.RequestedEnvVars),
+		pq.Array(&index.Caches),
 		&count,
 	); err != nil {
 		return index, 0, err
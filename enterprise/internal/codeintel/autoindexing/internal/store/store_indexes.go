@@ -44,7 +44,7 @@ func (s *store) InsertIndexes(ctx context.Context, indexes []types.Index) (_ []t
 		}
 
 		values = append(values, sqlf.Sprintf(
-			"(%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)",
+			"(%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)",
 			index.State,
 			index.Commit,
 			index.RepositoryID,
@@ -56,6 +56,7 @@ func (s *store) InsertIndexes(ctx context.Context, indexes []types.Index) (_ []t
 			index.Outfile,
 			pq.Array(index.ExecutionLogs),
 			pq.Array(index.RequestedEnvVars),
+			pq.Array(index.Caches),
 		))
 	}
 
@@ -87,7 +88,8 @@ INSERT INTO lsif_indexes (
 	indexer_args,
 	outfile,
 	execution_logs,
-	requested_envvars
+	requested_envvars,
+	caches
 ) VALUES %s
 RETURNING id
 `
@@ -169,6 +171,7 @@ SELECT
 	` + indexAssociatedUploadIDQueryFragment + `,
 	u.should_reindex,
 	u.requested_envvars,
+	u.caches,
 	COUNT(*) OVER() AS count
 FROM lsif_indexes u
 LEFT JOIN (` + indexRankQueryFragment + `) s
@@ -389,7 +392,8 @@ SELECT
 	u.local_steps,
 	` + indexAssociatedUploadIDQueryFragment + `,
 	u.should_reindex,
-	u.requested_envvars
+	u.requested_envvars,
+	u.caches
 FROM lsif_indexes u
 LEFT JOIN (` + indexRankQueryFragment + `) s
 ON u.id = s.id
@@ -446,7 +450,8 @@ SELECT
 	u.local_steps,
 	` + indexAssociatedUploadIDQueryFragment + `,
 	u.should_reindex,
-	u.requested_envvars
+	u.requested_envvars,
+	u.caches
 FROM lsif_indexes u
 LEFT JOIN (` + indexRankQueryFragment + `) s
 ON u.id = s.id
@@ -828,7 +833,8 @@ SELECT
 	u.local_steps,
 	` + indexAssociatedUploadIDQueryFragment + `,
 	u.should_reindex,
-	u.requested_envvars
+	u.requested_envvars,
+	u.caches
 FROM lsif_indexes_with_repository_name u
 LEFT JOIN (` + indexRankQueryFragment + `) s
 ON u.id = s.id
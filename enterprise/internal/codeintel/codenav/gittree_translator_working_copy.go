@@ -0,0 +1,186 @@
+package codenav
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/sourcegraph/go-diff/diff"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+)
+
+type workingCopyGitTreeTranslator struct {
+	path          string
+	hunks         []*diff.Hunk
+	reversedHunks []*diff.Hunk
+}
+
+// NewWorkingCopyGitTreeTranslator creates a GitTreeTranslator that translates positions within the
+// given path between a commit and a working copy of that commit with uncommitted changes, such as
+// the buffer of an editor. The given hunks describe the changes from the commit to the working copy
+// (see WorkingCopyHunks).
+//
+// The working copy takes the place of the target commit, so the commit arguments of the returned
+// translator are ignored. Translating with reverse set to true maps positions of the working copy
+// onto the commit. Positions in other paths are returned unchanged.
+func NewWorkingCopyGitTreeTranslator(path string, hunks []*diff.Hunk) GitTreeTranslator {
+	return &workingCopyGitTreeTranslator{
+		path:          path,
+		hunks:         hunks,
+		reversedHunks: reverseHunks(hunks),
+	}
+}
+
+// GetTargetCommitPathFromSourcePath returns the given path unchanged, as uncommitted changes
+// do not rename files.
+func (g *workingCopyGitTreeTranslator) GetTargetCommitPathFromSourcePath(ctx context.Context, commit, path string, reverse bool) (string, bool, error) {
+	return path, true, nil
+}
+
+// GetTargetCommitPositionFromSourcePosition translates the given position of the translator's path
+// from the commit into the working copy, or the other way around if reverse is true.
+func (g *workingCopyGitTreeTranslator) GetTargetCommitPositionFromSourcePosition(ctx context.Context, commit string, px types.Position, reverse bool) (string, types.Position, bool, error) {
+	position, ok := translatePosition(g.hunksFor(reverse), px)
+	return g.path, position, ok, nil
+}
+
+// GetTargetCommitRangeFromSourceRange translates the given range from the commit into the working
+// copy, or the other way around if reverse is true.
+func (g *workingCopyGitTreeTranslator) GetTargetCommitRangeFromSourceRange(ctx context.Context, commit, path string, rx types.Range, reverse bool) (string, types.Range, bool, error) {
+	if path != g.path {
+		return path, rx, true, nil
+	}
+
+	rng, ok := translateRange(g.hunksFor(reverse), rx)
+	return path, rng, ok, nil
+}
+
+func (g *workingCopyGitTreeTranslator) hunksFor(reverse bool) []*diff.Hunk {
+	if reverse {
+		return g.reversedHunks
+	}
+	return g.hunks
+}
+
+// WorkingCopyHunks returns the line-based changes between the original and the modified contents
+// of a file as a position-ordered slice of hunks without context lines.
+func WorkingCopyHunks(original, modified string) []*diff.Hunk {
+	if original == modified {
+		return nil
+	}
+
+	originalLines := splitLines(original)
+	modifiedLines := splitLines(modified)
+
+	// Diff the files line by line by encoding each distinct line as a single rune.
+	lineRunes := map[string]rune{}
+	encode := func(lines []string) []rune {
+		runes := make([]rune, 0, len(lines))
+		for _, line := range lines {
+			r, ok := lineRunes[line]
+			if !ok {
+				r = lineIndexToRune(len(lineRunes))
+				lineRunes[line] = r
+			}
+			runes = append(runes, r)
+		}
+		return runes
+	}
+	diffs := diffmatchpatch.New().DiffMainRunes(encode(originalLines), encode(modifiedLines), false)
+
+	var (
+		hunks             []*diff.Hunk
+		current           *diff.Hunk
+		removed, added    []string
+		origLine, newLine = int32(1), int32(1)
+	)
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		var body strings.Builder
+		for _, line := range removed {
+			body.WriteString("-" + line + "\n")
+		}
+		for _, line := range added {
+			body.WriteString("+" + line + "\n")
+		}
+		current.Body = []byte(body.String())
+		hunks = append(hunks, current)
+		current, removed, added = nil, nil, nil
+	}
+
+	for _, d := range diffs {
+		n := int32(len([]rune(d.Text)))
+
+		if d.Type == diffmatchpatch.DiffEqual {
+			flush()
+			origLine += n
+			newLine += n
+			continue
+		}
+
+		if current == nil {
+			current = &diff.Hunk{OrigStartLine: origLine, NewStartLine: newLine}
+		}
+
+		if d.Type == diffmatchpatch.DiffDelete {
+			removed = append(removed, originalLines[origLine-1:origLine-1+n]...)
+			current.OrigLines += n
+			origLine += n
+		} else {
+			added = append(added, modifiedLines[newLine-1:newLine-1+n]...)
+			current.NewLines += n
+			newLine += n
+		}
+	}
+	flush()
+
+	return hunks
+}
+
+// splitLines splits the given file contents into lines without line terminators. A missing
+// newline at the end of the file is ignored so that it doesn't make the last line look modified.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// lineIndexToRune maps the given index onto a valid rune, skipping the range of surrogate
+// halves which cannot be represented in strings.
+func lineIndexToRune(i int) rune {
+	if i >= 0xD800 {
+		i += 0x800
+	}
+	return rune(i)
+}
+
+// reverseHunks returns hunks that undo the changes of the given hunks.
+func reverseHunks(hunks []*diff.Hunk) []*diff.Hunk {
+	reversed := make([]*diff.Hunk, 0, len(hunks))
+	for _, hunk := range hunks {
+		var removed, added strings.Builder
+		for _, line := range strings.Split(strings.TrimSuffix(string(hunk.Body), "\n"), "\n") {
+			switch {
+			case strings.HasPrefix(line, "+"):
+				removed.WriteString("-" + line[1:] + "\n")
+			case strings.HasPrefix(line, "-"):
+				added.WriteString("+" + line[1:] + "\n")
+			}
+		}
+
+		reversed = append(reversed, &diff.Hunk{
+			OrigStartLine: hunk.NewStartLine,
+			OrigLines:     hunk.NewLines,
+			NewStartLine:  hunk.OrigStartLine,
+			NewLines:      hunk.OrigLines,
+			Body:          []byte(removed.String() + added.String()),
+		})
+	}
+
+	return reversed
+}
@@ -0,0 +1,58 @@
+package codenav
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+)
+
+func TestWorkingCopyGitTreeTranslator(t *testing.T) {
+	original := "package main\n\nfunc a() {}\n\nfunc b() {}\n\nfunc c() {}\n"
+	modified := "package main\n\nimport \"fmt\"\n\nfunc a() {}\n\nfunc b2() {}\n\nfunc c() {}\n"
+
+	translator := NewWorkingCopyGitTreeTranslator("main.go", WorkingCopyHunks(original, modified))
+
+	testCases := []struct {
+		line     int
+		reverse  bool
+		wantLine int
+		wantOK   bool
+	}{
+		{line: 0, wantLine: 0, wantOK: true},    // package main
+		{line: 2, wantLine: 4, wantOK: true},    // func a
+		{line: 4, wantOK: false},                // func b (edited)
+		{line: 6, wantLine: 8, wantOK: true},    // func c
+		{line: 2, reverse: true, wantOK: false}, // import "fmt" (added)
+		{line: 4, reverse: true, wantLine: 2, wantOK: true},
+		{line: 8, reverse: true, wantLine: 6, wantOK: true},
+	}
+
+	for _, testCase := range testCases {
+		path, position, ok, err := translator.GetTargetCommitPositionFromSourcePosition(context.Background(), "deadbeef", types.Position{Line: testCase.line, Character: 5}, testCase.reverse)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if path != "main.go" {
+			t.Errorf("unexpected path. want=%s have=%s", "main.go", path)
+		}
+		if ok != testCase.wantOK {
+			t.Errorf("unexpected ok for line %d (reverse=%v). want=%v have=%v", testCase.line, testCase.reverse, testCase.wantOK, ok)
+			continue
+		}
+		if ok && (position != types.Position{Line: testCase.wantLine, Character: 5}) {
+			t.Errorf("unexpected position for line %d (reverse=%v). want=%d have=%d", testCase.line, testCase.reverse, testCase.wantLine, position.Line)
+		}
+	}
+
+	rx := types.Range{Start: types.Position{Line: 4}, End: types.Position{Line: 4, Character: 8}}
+	if _, rng, ok, _ := translator.GetTargetCommitRangeFromSourceRange(context.Background(), "deadbeef", "other.go", rx, false); !ok || rng != rx {
+		t.Errorf("expected ranges in other paths to be unchanged. have=%v", rng)
+	}
+}
+
+func TestWorkingCopyHunksNoChanges(t *testing.T) {
+	if hunks := WorkingCopyHunks("a\nb", "a\nb\n"); len(hunks) != 0 {
+		t.Errorf("expected no hunks. have=%d", len(hunks))
+	}
+}
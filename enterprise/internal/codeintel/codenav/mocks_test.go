@@ -16,6 +16,7 @@ import (
 	shared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
 	gitserver "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/gitserver"
 	types "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+	shared1 "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
 	api "github.com/sourcegraph/sourcegraph/internal/api"
 	authz "github.com/sourcegraph/sourcegraph/internal/authz"
 	database "github.com/sourcegraph/sourcegraph/internal/database"
@@ -2463,6 +2464,10 @@ type MockUploadService struct {
 	// object controlling the behavior of the method
 	// GetUploadIDsWithReferences.
 	GetUploadIDsWithReferencesFunc *UploadServiceGetUploadIDsWithReferencesFunc
+	// GetVisibleUploadsReferencingAnyVersionFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// GetVisibleUploadsReferencingAnyVersion.
+	GetVisibleUploadsReferencingAnyVersionFunc *UploadServiceGetVisibleUploadsReferencingAnyVersionFunc
 	// InferClosestUploadsFunc is an instance of a mock function object
 	// controlling the behavior of the method InferClosestUploads.
 	InferClosestUploadsFunc *UploadServiceInferClosestUploadsFunc
@@ -2487,6 +2492,11 @@ func NewMockUploadService() *MockUploadService {
 				return
 			},
 		},
+		GetVisibleUploadsReferencingAnyVersionFunc: &UploadServiceGetVisibleUploadsReferencingAnyVersionFunc{
+			defaultHook: func(context.Context, int, string, []precise.QualifiedMonikerData, int) (r0 []shared1.PackageReference, r1 error) {
+				return
+			},
+		},
 		InferClosestUploadsFunc: &UploadServiceInferClosestUploadsFunc{
 			defaultHook: func(context.Context, int, string, string, bool, string) (r0 []types.Dump, r1 error) {
 				return
@@ -2514,6 +2524,11 @@ func NewStrictMockUploadService() *MockUploadService {
 				panic("unexpected invocation of MockUploadService.GetUploadIDsWithReferences")
 			},
 		},
+		GetVisibleUploadsReferencingAnyVersionFunc: &UploadServiceGetVisibleUploadsReferencingAnyVersionFunc{
+			defaultHook: func(context.Context, int, string, []precise.QualifiedMonikerData, int) ([]shared1.PackageReference, error) {
+				panic("unexpected invocation of MockUploadService.GetVisibleUploadsReferencingAnyVersion")
+			},
+		},
 		InferClosestUploadsFunc: &UploadServiceInferClosestUploadsFunc{
 			defaultHook: func(context.Context, int, string, string, bool, string) ([]types.Dump, error) {
 				panic("unexpected invocation of MockUploadService.InferClosestUploads")
@@ -2536,6 +2551,9 @@ func NewMockUploadServiceFrom(i UploadService) *MockUploadService {
 		GetUploadIDsWithReferencesFunc: &UploadServiceGetUploadIDsWithReferencesFunc{
 			defaultHook: i.GetUploadIDsWithReferences,
 		},
+		GetVisibleUploadsReferencingAnyVersionFunc: &UploadServiceGetVisibleUploadsReferencingAnyVersionFunc{
+			defaultHook: i.GetVisibleUploadsReferencingAnyVersion,
+		},
 		InferClosestUploadsFunc: &UploadServiceInferClosestUploadsFunc{
 			defaultHook: i.InferClosestUploads,
 		},
@@ -2895,6 +2913,129 @@ func (c UploadServiceGetUploadIDsWithReferencesFuncCall) Results() []interface{}
 	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
 }
 
+// UploadServiceGetVisibleUploadsReferencingAnyVersionFunc describes the
+// behavior when the GetVisibleUploadsReferencingAnyVersion method of the
+// parent MockUploadService instance is invoked.
+type UploadServiceGetVisibleUploadsReferencingAnyVersionFunc struct {
+	defaultHook func(context.Context, int, string, []precise.QualifiedMonikerData, int) ([]shared1.PackageReference, error)
+	hooks       []func(context.Context, int, string, []precise.QualifiedMonikerData, int) ([]shared1.PackageReference, error)
+	history     []UploadServiceGetVisibleUploadsReferencingAnyVersionFuncCall
+	mutex       sync.Mutex
+}
+
+// GetVisibleUploadsReferencingAnyVersion delegates to the next hook
+// function in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockUploadService) GetVisibleUploadsReferencingAnyVersion(v0 context.Context, v1 int, v2 string, v3 []precise.QualifiedMonikerData, v4 int) ([]shared1.PackageReference, error) {
+	r0, r1 := m.GetVisibleUploadsReferencingAnyVersionFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.GetVisibleUploadsReferencingAnyVersionFunc.appendCall(UploadServiceGetVisibleUploadsReferencingAnyVersionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetVisibleUploadsReferencingAnyVersion method of the parent
+// MockUploadService instance is invoked and the hook queue is empty.
+func (f *UploadServiceGetVisibleUploadsReferencingAnyVersionFunc) SetDefaultHook(hook func(context.Context, int, string, []precise.QualifiedMonikerData, int) ([]shared1.PackageReference, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetVisibleUploadsReferencingAnyVersion method of the parent
+// MockUploadService instance invokes the hook at the front of the queue and
+// discards it. After the queue is empty, the default hook function is
+// invoked for any future action.
+func (f *UploadServiceGetVisibleUploadsReferencingAnyVersionFunc) PushHook(hook func(context.Context, int, string, []precise.QualifiedMonikerData, int) ([]shared1.PackageReference, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UploadServiceGetVisibleUploadsReferencingAnyVersionFunc) SetDefaultReturn(r0 []shared1.PackageReference, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, []precise.QualifiedMonikerData, int) ([]shared1.PackageReference, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UploadServiceGetVisibleUploadsReferencingAnyVersionFunc) PushReturn(r0 []shared1.PackageReference, r1 error) {
+	f.PushHook(func(context.Context, int, string, []precise.QualifiedMonikerData, int) ([]shared1.PackageReference, error) {
+		return r0, r1
+	})
+}
+
+func (f *UploadServiceGetVisibleUploadsReferencingAnyVersionFunc) nextHook() func(context.Context, int, string, []precise.QualifiedMonikerData, int) ([]shared1.PackageReference, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UploadServiceGetVisibleUploadsReferencingAnyVersionFunc) appendCall(r0 UploadServiceGetVisibleUploadsReferencingAnyVersionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// UploadServiceGetVisibleUploadsReferencingAnyVersionFuncCall objects
+// describing the invocations of this function.
+func (f *UploadServiceGetVisibleUploadsReferencingAnyVersionFunc) History() []UploadServiceGetVisibleUploadsReferencingAnyVersionFuncCall {
+	f.mutex.Lock()
+	history := make([]UploadServiceGetVisibleUploadsReferencingAnyVersionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UploadServiceGetVisibleUploadsReferencingAnyVersionFuncCall is an object
+// that describes an invocation of method
+// GetVisibleUploadsReferencingAnyVersion on an instance of
+// MockUploadService.
+type UploadServiceGetVisibleUploadsReferencingAnyVersionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []precise.QualifiedMonikerData
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared1.PackageReference
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UploadServiceGetVisibleUploadsReferencingAnyVersionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UploadServiceGetVisibleUploadsReferencingAnyVersionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // UploadServiceInferClosestUploadsFunc describes the behavior when the
 // InferClosestUploads method of the parent MockUploadService instance is
 // invoked.
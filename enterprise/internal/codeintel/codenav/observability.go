@@ -12,15 +12,16 @@ import (
 )
 
 type operations struct {
-	getReferences          *observation.Operation
-	getImplementations     *observation.Operation
-	getDiagnostics         *observation.Operation
-	getHover               *observation.Operation
-	getDefinitions         *observation.Operation
-	getRanges              *observation.Operation
-	getStencil             *observation.Operation
-	getDumpsByIDs          *observation.Operation
-	getClosestDumpsForBlob *observation.Operation
+	getReferences               *observation.Operation
+	getReferencesAcrossVersions *observation.Operation
+	getImplementations          *observation.Operation
+	getDiagnostics              *observation.Operation
+	getHover                    *observation.Operation
+	getDefinitions              *observation.Operation
+	getRanges                   *observation.Operation
+	getStencil                  *observation.Operation
+	getDumpsByIDs               *observation.Operation
+	getClosestDumpsForBlob      *observation.Operation
 }
 
 var m = new(metrics.SingletonREDMetrics)
@@ -44,15 +45,16 @@ func newOperations(observationCtx *observation.Context) *operations {
 	}
 
 	return &operations{
-		getReferences:          op("getReferences"),
-		getImplementations:     op("getImplementations"),
-		getDiagnostics:         op("getDiagnostics"),
-		getHover:               op("getHover"),
-		getDefinitions:         op("getDefinitions"),
-		getRanges:              op("getRanges"),
-		getStencil:             op("getStencil"),
-		getDumpsByIDs:          op("GetDumpsByIDs"),
-		getClosestDumpsForBlob: op("GetClosestDumpsForBlob"),
+		getReferences:               op("getReferences"),
+		getReferencesAcrossVersions: op("getReferencesAcrossVersions"),
+		getImplementations:          op("getImplementations"),
+		getDiagnostics:              op("getDiagnostics"),
+		getHover:                    op("getHover"),
+		getDefinitions:              op("getDefinitions"),
+		getRanges:                   op("getRanges"),
+		getStencil:                  op("getStencil"),
+		getDumpsByIDs:               op("GetDumpsByIDs"),
+		getClosestDumpsForBlob:      op("GetClosestDumpsForBlob"),
 	}
 }
 
@@ -2,6 +2,7 @@ package codenav
 
 import (
 	"context"
+	"sort"
 	"strings"
 
 	traceLog "github.com/opentracing/opentracing-go/log"
@@ -16,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
@@ -291,6 +293,115 @@ func (s *Service) GetReferences(ctx context.Context, args shared.RequestArgs, re
 	return referenceLocations, cursor, nil
 }
 
+// GetReferencesAcrossVersions returns the list of source locations that reference the symbol at the given
+// position from uploads that depend on any version of the package providing that symbol. The results are
+// grouped by the package version on which each referencing upload depends, most recent version first, and at
+// most args.Limit locations are returned per version. Versions with no matching locations are omitted.
+func (s *Service) GetReferencesAcrossVersions(ctx context.Context, args shared.RequestArgs, requestState RequestState) (_ []shared.VersionedReferences, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getReferencesAcrossVersions, serviceObserverThreshold, observation.Args{
+		LogFields: []traceLog.Field{
+			traceLog.Int("repositoryID", args.RepositoryID),
+			traceLog.String("commit", args.Commit),
+			traceLog.String("path", args.Path),
+			traceLog.Int("numUploads", len(requestState.GetCacheUploads())),
+			traceLog.String("uploads", uploadIDsToString(requestState.GetCacheUploads())),
+			traceLog.Int("line", args.Line),
+			traceLog.Int("character", args.Character),
+			traceLog.Int("limit", args.Limit),
+		},
+	})
+	defer endObservation()
+
+	visibleUploads, err := s.getVisibleUploads(ctx, args.Line, args.Character, requestState)
+	if err != nil {
+		return nil, err
+	}
+
+	orderedMonikers, err := s.getOrderedMonikers(ctx, visibleUploads, "import", "export")
+	if err != nil {
+		return nil, err
+	}
+	trace.AddEvent("TODO Domain Owner",
+		attribute.Int("numMonikers", len(orderedMonikers)),
+		attribute.String("monikers", monikersToString(orderedMonikers)))
+
+	packageReferences, err := s.uploadSvc.GetVisibleUploadsReferencingAnyVersion(
+		ctx,
+		args.RepositoryID,
+		args.Commit,
+		orderedMonikers,
+		requestState.maximumIndexesPerMonikerSearch,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "uploadSvc.GetVisibleUploadsReferencingAnyVersion")
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numPackageReferences", len(packageReferences)))
+
+	ignoreIDs := map[int]struct{}{}
+	for _, visibleUpload := range visibleUploads {
+		ignoreIDs[visibleUpload.Upload.ID] = struct{}{}
+	}
+
+	// Group the referencing uploads by package version. Package references are returned from
+	// the database ordered by version and upload identifier.
+	versions := []string{}
+	uploadIDsByVersion := map[string][]int{}
+	for _, packageReference := range packageReferences {
+		if _, ok := ignoreIDs[packageReference.DumpID]; ok {
+			continue
+		}
+
+		uploadIDs, ok := uploadIDsByVersion[packageReference.Version]
+		if !ok {
+			versions = append(versions, packageReference.Version)
+		}
+		if len(uploadIDs) == 0 || uploadIDs[len(uploadIDs)-1] != packageReference.DumpID {
+			uploadIDsByVersion[packageReference.Version] = append(uploadIDs, packageReference.DumpID)
+		}
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return reposource.VersionGreaterThan(versions[i], versions[j])
+	})
+
+	versionedReferences := make([]shared.VersionedReferences, 0, len(versions))
+	for _, version := range versions {
+		uploads, err := s.getUploadsByIDs(ctx, uploadIDsByVersion[version], requestState)
+		if err != nil {
+			return nil, err
+		}
+		if len(uploads) == 0 {
+			continue
+		}
+
+		versionedMonikers := make([]precise.QualifiedMonikerData, 0, len(orderedMonikers))
+		for _, moniker := range orderedMonikers {
+			versionedMonikers = append(versionedMonikers, monikerAtVersion(moniker, version))
+		}
+
+		locations, _, err := s.getBulkMonikerLocations(ctx, uploads, versionedMonikers, "references", args.Limit, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		uploadLocations, err := s.getUploadLocations(ctx, args, requestState, locations, true)
+		if err != nil {
+			return nil, err
+		}
+		if len(uploadLocations) == 0 {
+			continue
+		}
+
+		versionedReferences = append(versionedReferences, shared.VersionedReferences{
+			Version:   version,
+			Locations: uploadLocations,
+		})
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numVersions", len(versionedReferences)))
+
+	return versionedReferences, nil
+}
+
 // getUploadsWithDefinitionsForMonikers returns the set of uploads that provide any of the given monikers.
 // This method will not return uploads for commits which are unknown to gitserver.
 func (s *Service) getUploadsWithDefinitionsForMonikers(ctx context.Context, orderedMonikers []precise.QualifiedMonikerData, requestState RequestState) ([]types.Dump, error) {
@@ -9,6 +9,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
 	codeintelgitserver "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/gitserver"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+	uploadsshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -519,3 +520,143 @@ func TestReferencesRemoteWithSubRepoPermissions(t *testing.T) {
 		}
 	}
 }
+
+func TestReferencesAcrossVersions(t *testing.T) {
+	// Set up mocks
+	mockStore := NewMockStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitServer := codeintelgitserver.New(&observation.TestContext, database.NewMockDB())
+	hunkCache, _ := NewHunkCache(50)
+
+	// Init service
+	svc := newService(&observation.TestContext, mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitServer, &sgtypes.Repo{}, mockCommit, mockPath, hunkCache)
+	uploads := []types.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+	}
+	mockRequestState.SetUploadsDataLoader(uploads)
+
+	mockGitserverClient.CommitsExistFunc.SetDefaultHook(func(ctx context.Context, rcs []codeintelgitserver.RepositoryCommit) (exists []bool, _ error) {
+		for range rcs {
+			exists = append(exists, true)
+		}
+		return
+	})
+
+	moniker := precise.MonikerData{
+		Kind:                 "import",
+		Scheme:               "scip-typescript",
+		Identifier:           "scip-typescript npm leftpad 0.1.0 `index.ts`/padLeft().",
+		PackageInformationID: "scip:bnBt:bGVmdHBhZA:MC4xLjA",
+	}
+	mockLsifStore.GetMonikersByPositionFunc.PushReturn([][]precise.MonikerData{{moniker}}, nil)
+	mockLsifStore.GetPackageInformationFunc.PushReturn(precise.PackageInformationData{Manager: "npm", Name: "leftpad", Version: "0.1.0"}, true, nil)
+
+	mockUploadSvc.GetVisibleUploadsReferencingAnyVersionFunc.PushReturn([]uploadsshared.PackageReference{
+		{Package: uploadsshared.Package{DumpID: 250, Scheme: "scip-typescript", Manager: "npm", Name: "leftpad", Version: "0.1.0"}},
+		{Package: uploadsshared.Package{DumpID: 253, Scheme: "scip-typescript", Manager: "npm", Name: "leftpad", Version: "0.10.0"}},
+		{Package: uploadsshared.Package{DumpID: 50, Scheme: "scip-typescript", Manager: "npm", Name: "leftpad", Version: "0.2.0"}},
+		{Package: uploadsshared.Package{DumpID: 251, Scheme: "scip-typescript", Manager: "npm", Name: "leftpad", Version: "0.2.0"}},
+		{Package: uploadsshared.Package{DumpID: 252, Scheme: "scip-typescript", Manager: "npm", Name: "leftpad", Version: "0.2.0"}},
+	}, nil)
+
+	referenceUploads := []types.Dump{
+		{ID: 250, Commit: "deadbeef1", Root: "sub1/"},
+		{ID: 251, Commit: "deadbeef2", Root: "sub2/"},
+		{ID: 252, Commit: "deadbeef3", Root: "sub3/"},
+		{ID: 253, Commit: "deadbeef4", Root: "sub4/"},
+	}
+	// Versions are searched most recent first
+	mockUploadSvc.GetDumpsByIDsFunc.PushReturn(referenceUploads[3:], nil)
+	mockUploadSvc.GetDumpsByIDsFunc.PushReturn(referenceUploads[1:3], nil)
+	mockUploadSvc.GetDumpsByIDsFunc.PushReturn(referenceUploads[:1], nil)
+
+	mockLsifStore.GetBulkMonikerLocationsFunc.PushReturn(nil, 0, nil)
+	mockLsifStore.GetBulkMonikerLocationsFunc.PushReturn([]shared.Location{{DumpID: 251, Path: "b.go", Range: testRange2}, {DumpID: 252, Path: "c.go", Range: testRange3}}, 2, nil)
+	mockLsifStore.GetBulkMonikerLocationsFunc.PushReturn([]shared.Location{{DumpID: 250, Path: "a.go", Range: testRange1}}, 1, nil)
+
+	mockRequest := shared.RequestArgs{
+		RepositoryID: 42,
+		Commit:       mockCommit,
+		Path:         mockPath,
+		Line:         10,
+		Character:    20,
+		Limit:        50,
+	}
+	versionedReferences, err := svc.GetReferencesAcrossVersions(context.Background(), mockRequest, mockRequestState)
+	if err != nil {
+		t.Fatalf("unexpected error querying references: %s", err)
+	}
+
+	expectedReferences := []shared.VersionedReferences{
+		{
+			Version: "0.2.0",
+			Locations: []types.UploadLocation{
+				{Dump: referenceUploads[1], Path: "sub2/b.go", TargetCommit: "deadbeef2", TargetRange: testRange2},
+				{Dump: referenceUploads[2], Path: "sub3/c.go", TargetCommit: "deadbeef3", TargetRange: testRange3},
+			},
+		},
+		{
+			Version: "0.1.0",
+			Locations: []types.UploadLocation{
+				{Dump: referenceUploads[0], Path: "sub1/a.go", TargetCommit: "deadbeef1", TargetRange: testRange1},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedReferences, versionedReferences); diff != "" {
+		t.Errorf("unexpected references (-want +got):\n%s", diff)
+	}
+
+	if history := mockLsifStore.GetBulkMonikerLocationsFunc.History(); len(history) != 3 {
+		t.Fatalf("unexpected number of moniker searches. want=%d have=%d", 3, len(history))
+	} else {
+		for i, version := range []string{"0.10.0", "0.2.0", "0.1.0"} {
+			expectedMonikers := []precise.MonikerData{{
+				Kind:                 "import",
+				Scheme:               "scip-typescript",
+				Identifier:           "scip-typescript npm leftpad " + version + " `index.ts`/padLeft().",
+				PackageInformationID: "scip:bnBt:bGVmdHBhZA:MC4xLjA",
+			}}
+			if diff := cmp.Diff(expectedMonikers, history[i].Arg3); diff != "" {
+				t.Errorf("unexpected monikers for version %s (-want +got):\n%s", version, diff)
+			}
+		}
+	}
+}
+
+func TestMonikerAtVersion(t *testing.T) {
+	testCases := []struct {
+		identifier string
+		version    string
+		expected   string
+	}{
+		// SCIP symbols
+		{"scip-go gomod github.com/foo/bar v1.0.0 `github.com/foo/bar`/Baz#", "v2.0.0", "scip-go gomod github.com/foo/bar v2.0.0 `github.com/foo/bar`/Baz#"},
+		{"scip-java maven com.foo  bar v1.0.0 foo/Bar#", "v2 beta", "scip-java maven com.foo  bar v2  beta foo/Bar#"},
+		{"scip-python python foo v1.0.0 foo/bar().", "", "scip-python python foo . foo/bar()."},
+
+		// LSIF identifiers do not embed a version
+		{"github.com/foo/bar:Baz", "v2.0.0", "github.com/foo/bar:Baz"},
+	}
+
+	for _, testCase := range testCases {
+		moniker := precise.QualifiedMonikerData{
+			MonikerData:            precise.MonikerData{Identifier: testCase.identifier},
+			PackageInformationData: precise.PackageInformationData{Version: "v1.0.0"},
+		}
+
+		versioned := monikerAtVersion(moniker, testCase.version)
+		if versioned.Identifier != testCase.expected {
+			t.Errorf("unexpected identifier for %q. want=%q have=%q", testCase.identifier, testCase.expected, versioned.Identifier)
+		}
+		if versioned.Version != testCase.version {
+			t.Errorf("unexpected version for %q. want=%q have=%q", testCase.identifier, testCase.version, versioned.Version)
+		}
+	}
+}
@@ -22,6 +22,13 @@ type RequestArgs struct {
 	RawCursor    string
 }
 
+// VersionedReferences groups the reference locations found in uploads that depend on the same
+// version of the package providing the target symbol.
+type VersionedReferences struct {
+	Version   string
+	Locations []types.UploadLocation
+}
+
 // DiagnosticAtUpload is a diagnostic from within a particular upload. The adjusted commit denotes
 // the target commit for which the location was adjusted (the originally requested commit).
 type DiagnosticAtUpload struct {
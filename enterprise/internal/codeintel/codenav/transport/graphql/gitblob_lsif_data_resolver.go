@@ -201,6 +201,31 @@ func (r *gitBlobLSIFDataResolver) References(ctx context.Context, args *resolver
 	return NewLocationConnectionResolver(refs, strPtr(nextCursor), r.locationResolver), nil
 }
 
+// ReferencesAcrossVersions returns the list of source locations that reference the symbol at the given position
+// from uploads depending on any version of the symbol's package, grouped by package version.
+func (r *gitBlobLSIFDataResolver) ReferencesAcrossVersions(ctx context.Context, args *resolverstubs.LSIFReferencesAcrossVersionsArgs) (_ []resolverstubs.PackageVersionReferencesResolver, err error) {
+	limit := derefInt32(args.First, DefaultReferencesPageSize)
+	if limit <= 0 {
+		return nil, ErrIllegalLimit
+	}
+
+	requestArgs := shared.RequestArgs{RepositoryID: r.requestState.RepositoryID, Commit: r.requestState.Commit, Path: r.requestState.Path, Line: int(args.Line), Character: int(args.Character), Limit: limit}
+	ctx, _, endObservation := observeResolver(ctx, &err, r.operations.referencesAcrossVersions, time.Second, getObservationArgs(requestArgs))
+	defer endObservation()
+
+	versionedReferences, err := r.codeNavSvc.GetReferencesAcrossVersions(ctx, requestArgs, r.requestState)
+	if err != nil {
+		return nil, errors.Wrap(err, "codeNavSvc.GetReferencesAcrossVersions")
+	}
+
+	resolvers := make([]resolverstubs.PackageVersionReferencesResolver, 0, len(versionedReferences))
+	for _, references := range versionedReferences {
+		resolvers = append(resolvers, NewPackageVersionReferencesResolver(references, r.locationResolver))
+	}
+
+	return resolvers, nil
+}
+
 // DefaultReferencesPageSize is the implementation result page size when no limit is supplied.
 const DefaultImplementationsPageSize = 100
 
@@ -225,6 +225,55 @@ func TestReferencesDefaultIllegalLimit(t *testing.T) {
 	}
 }
 
+func TestReferencesAcrossVersions(t *testing.T) {
+	mockAutoIndexingSvc := NewMockAutoIndexingService()
+	mockUploadsService := NewMockUploadsService()
+	mockPolicyService := NewMockPolicyService()
+	mockCodeNavService := NewMockCodeNavService()
+	mockRequestState := codenav.RequestState{
+		RepositoryID: 1,
+		Commit:       "deadbeef1",
+		Path:         "/src/main",
+	}
+	mockOperations := newOperations(&observation.TestContext)
+
+	resolver := NewGitBlobLSIFDataResolver(
+		mockCodeNavService,
+		mockAutoIndexingSvc,
+		mockUploadsService,
+		mockPolicyService,
+		mockRequestState,
+		observation.NewErrorCollector(),
+		mockOperations,
+	)
+
+	mockCodeNavService.GetReferencesAcrossVersionsFunc.PushReturn([]shared.VersionedReferences{
+		{Version: "0.1.0"},
+		{Version: "0.2.0"},
+	}, nil)
+
+	first := int32(25)
+	args := &resolverstubs.LSIFReferencesAcrossVersionsArgs{Line: 10, Character: 15, First: &first}
+
+	versionedReferences, err := resolver.ReferencesAcrossVersions(context.Background(), args)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(versionedReferences) != 2 || versionedReferences[0].Version() != "0.1.0" || versionedReferences[1].Version() != "0.2.0" {
+		t.Fatalf("unexpected versioned references: %v", versionedReferences)
+	}
+
+	if len(mockCodeNavService.GetReferencesAcrossVersionsFunc.History()) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockCodeNavService.GetReferencesAcrossVersionsFunc.History()))
+	}
+	if val := mockCodeNavService.GetReferencesAcrossVersionsFunc.History()[0].Arg1; val.Line != 10 || val.Character != 15 {
+		t.Fatalf("unexpected position. want=%v have=%v", "10:15", val)
+	}
+	if val := mockCodeNavService.GetReferencesAcrossVersionsFunc.History()[0].Arg1; val.Limit != 25 {
+		t.Fatalf("unexpected limit. want=%v have=%v", 25, val)
+	}
+}
+
 func TestHover(t *testing.T) {
 	mockAutoIndexingSvc := NewMockAutoIndexingService()
 	mockUploadsService := NewMockUploadsService()
@@ -857,6 +857,10 @@ type MockCodeNavService struct {
 	// GetReferencesFunc is an instance of a mock function object
 	// controlling the behavior of the method GetReferences.
 	GetReferencesFunc *CodeNavServiceGetReferencesFunc
+	// GetReferencesAcrossVersionsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetReferencesAcrossVersions.
+	GetReferencesAcrossVersionsFunc *CodeNavServiceGetReferencesAcrossVersionsFunc
 	// GetStencilFunc is an instance of a mock function object controlling
 	// the behavior of the method GetStencil.
 	GetStencilFunc *CodeNavServiceGetStencilFunc
@@ -909,6 +913,11 @@ func NewMockCodeNavService() *MockCodeNavService {
 				return
 			},
 		},
+		GetReferencesAcrossVersionsFunc: &CodeNavServiceGetReferencesAcrossVersionsFunc{
+			defaultHook: func(context.Context, shared1.RequestArgs, codenav.RequestState) (r0 []shared1.VersionedReferences, r1 error) {
+				return
+			},
+		},
 		GetStencilFunc: &CodeNavServiceGetStencilFunc{
 			defaultHook: func(context.Context, shared1.RequestArgs, codenav.RequestState) (r0 []types.Range, r1 error) {
 				return
@@ -966,6 +975,11 @@ func NewStrictMockCodeNavService() *MockCodeNavService {
 				panic("unexpected invocation of MockCodeNavService.GetReferences")
 			},
 		},
+		GetReferencesAcrossVersionsFunc: &CodeNavServiceGetReferencesAcrossVersionsFunc{
+			defaultHook: func(context.Context, shared1.RequestArgs, codenav.RequestState) ([]shared1.VersionedReferences, error) {
+				panic("unexpected invocation of MockCodeNavService.GetReferencesAcrossVersions")
+			},
+		},
 		GetStencilFunc: &CodeNavServiceGetStencilFunc{
 			defaultHook: func(context.Context, shared1.RequestArgs, codenav.RequestState) ([]types.Range, error) {
 				panic("unexpected invocation of MockCodeNavService.GetStencil")
@@ -1008,6 +1022,9 @@ func NewMockCodeNavServiceFrom(i CodeNavService) *MockCodeNavService {
 		GetReferencesFunc: &CodeNavServiceGetReferencesFunc{
 			defaultHook: i.GetReferences,
 		},
+		GetReferencesAcrossVersionsFunc: &CodeNavServiceGetReferencesAcrossVersionsFunc{
+			defaultHook: i.GetReferencesAcrossVersions,
+		},
 		GetStencilFunc: &CodeNavServiceGetStencilFunc{
 			defaultHook: i.GetStencil,
 		},
@@ -1949,6 +1966,121 @@ func (c CodeNavServiceGetReferencesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// CodeNavServiceGetReferencesAcrossVersionsFunc describes the behavior when
+// the GetReferencesAcrossVersions method of the parent MockCodeNavService
+// instance is invoked.
+type CodeNavServiceGetReferencesAcrossVersionsFunc struct {
+	defaultHook func(context.Context, shared1.RequestArgs, codenav.RequestState) ([]shared1.VersionedReferences, error)
+	hooks       []func(context.Context, shared1.RequestArgs, codenav.RequestState) ([]shared1.VersionedReferences, error)
+	history     []CodeNavServiceGetReferencesAcrossVersionsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetReferencesAcrossVersions delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeNavService) GetReferencesAcrossVersions(v0 context.Context, v1 shared1.RequestArgs, v2 codenav.RequestState) ([]shared1.VersionedReferences, error) {
+	r0, r1 := m.GetReferencesAcrossVersionsFunc.nextHook()(v0, v1, v2)
+	m.GetReferencesAcrossVersionsFunc.appendCall(CodeNavServiceGetReferencesAcrossVersionsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetReferencesAcrossVersions method of the parent MockCodeNavService
+// instance is invoked and the hook queue is empty.
+func (f *CodeNavServiceGetReferencesAcrossVersionsFunc) SetDefaultHook(hook func(context.Context, shared1.RequestArgs, codenav.RequestState) ([]shared1.VersionedReferences, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetReferencesAcrossVersions method of the parent MockCodeNavService
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeNavServiceGetReferencesAcrossVersionsFunc) PushHook(hook func(context.Context, shared1.RequestArgs, codenav.RequestState) ([]shared1.VersionedReferences, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeNavServiceGetReferencesAcrossVersionsFunc) SetDefaultReturn(r0 []shared1.VersionedReferences, r1 error) {
+	f.SetDefaultHook(func(context.Context, shared1.RequestArgs, codenav.RequestState) ([]shared1.VersionedReferences, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeNavServiceGetReferencesAcrossVersionsFunc) PushReturn(r0 []shared1.VersionedReferences, r1 error) {
+	f.PushHook(func(context.Context, shared1.RequestArgs, codenav.RequestState) ([]shared1.VersionedReferences, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeNavServiceGetReferencesAcrossVersionsFunc) nextHook() func(context.Context, shared1.RequestArgs, codenav.RequestState) ([]shared1.VersionedReferences, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeNavServiceGetReferencesAcrossVersionsFunc) appendCall(r0 CodeNavServiceGetReferencesAcrossVersionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeNavServiceGetReferencesAcrossVersionsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeNavServiceGetReferencesAcrossVersionsFunc) History() []CodeNavServiceGetReferencesAcrossVersionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeNavServiceGetReferencesAcrossVersionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeNavServiceGetReferencesAcrossVersionsFuncCall is an object that
+// describes an invocation of method GetReferencesAcrossVersions on an
+// instance of MockCodeNavService.
+type CodeNavServiceGetReferencesAcrossVersionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 shared1.RequestArgs
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 codenav.RequestState
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared1.VersionedReferences
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeNavServiceGetReferencesAcrossVersionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeNavServiceGetReferencesAcrossVersionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeNavServiceGetStencilFunc describes the behavior when the GetStencil
 // method of the parent MockCodeNavService instance is invoked.
 type CodeNavServiceGetStencilFunc struct {
@@ -14,13 +14,14 @@ import (
 )
 
 type operations struct {
-	hover           *observation.Operation
-	definitions     *observation.Operation
-	references      *observation.Operation
-	implementations *observation.Operation
-	diagnostics     *observation.Operation
-	stencil         *observation.Operation
-	ranges          *observation.Operation
+	hover                    *observation.Operation
+	definitions              *observation.Operation
+	references               *observation.Operation
+	referencesAcrossVersions *observation.Operation
+	implementations          *observation.Operation
+	diagnostics              *observation.Operation
+	stencil                  *observation.Operation
+	ranges                   *observation.Operation
 
 	gitBlobLsifData *observation.Operation
 }
@@ -42,13 +43,14 @@ func newOperations(observationCtx *observation.Context) *operations {
 	}
 
 	return &operations{
-		hover:           op("Hover"),
-		definitions:     op("Definitions"),
-		references:      op("References"),
-		implementations: op("Implementations"),
-		diagnostics:     op("Diagnostics"),
-		stencil:         op("Stencil"),
-		ranges:          op("Ranges"),
+		hover:                    op("Hover"),
+		definitions:              op("Definitions"),
+		references:               op("References"),
+		referencesAcrossVersions: op("ReferencesAcrossVersions"),
+		implementations:          op("Implementations"),
+		diagnostics:              op("Diagnostics"),
+		stencil:                  op("Stencil"),
+		ranges:                   op("Ranges"),
 
 		gitBlobLsifData: op("GitBlobLsifData"),
 	}
@@ -0,0 +1,27 @@
+package graphql
+
+import (
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	sharedresolvers "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/resolvers"
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
+)
+
+type packageVersionReferencesResolver struct {
+	references       shared.VersionedReferences
+	locationResolver *sharedresolvers.CachedLocationResolver
+}
+
+func NewPackageVersionReferencesResolver(references shared.VersionedReferences, locationResolver *sharedresolvers.CachedLocationResolver) resolverstubs.PackageVersionReferencesResolver {
+	return &packageVersionReferencesResolver{
+		references:       references,
+		locationResolver: locationResolver,
+	}
+}
+
+func (r *packageVersionReferencesResolver) Version() string {
+	return r.references.Version
+}
+
+func (r *packageVersionReferencesResolver) References() resolverstubs.LocationConnectionResolver {
+	return NewLocationConnectionResolver(r.references.Locations, nil, r.locationResolver)
+}
@@ -0,0 +1,71 @@
+package lsp
+
+import (
+	"net/http"
+
+	"github.com/gorilla/websocket"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type handler struct {
+	svc                            CodeNavService
+	repoStore                      RepoStore
+	gitserver                      GitserverClient
+	maximumIndexesPerMonikerSearch int
+	hunkCache                      codenav.HunkCache
+	externalURL                    func() string
+	upgrader                       websocket.Upgrader
+	logger                         log.Logger
+
+	// Metrics
+	operations *operations
+}
+
+// NewHandler returns an HTTP handler that serves precise code navigation over the
+// Language Server Protocol. Each request is upgraded to a websocket connection that
+// carries one JSON-RPC message per websocket message. If the framing query parameter
+// is set to "stdio", the connection instead carries the byte stream a language client
+// would write to the stdin of a language server, so that any websocket client that
+// bridges stdio can connect an editor to the gateway.
+func NewHandler(observationCtx *observation.Context, svc CodeNavService, repoStore RepoStore, gitserver GitserverClient, externalURL func() string, maxIndexSearch, hunkCacheSize int) (http.Handler, error) {
+	hunkCache, err := codenav.NewHunkCache(hunkCacheSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &handler{
+		svc:                            svc,
+		repoStore:                      repoStore,
+		gitserver:                      gitserver,
+		maximumIndexesPerMonikerSearch: maxIndexSearch,
+		hunkCache:                      hunkCache,
+		externalURL:                    externalURL,
+		logger:                         observationCtx.Logger.Scoped("lsp", "LSP gateway for precise code navigation"),
+		operations:                     newOperations(observationCtx),
+	}, nil
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader has already responded with an error.
+		return
+	}
+	defer conn.Close()
+	conn.SetReadLimit(maxMessageSize)
+
+	var s stream = &websocketStream{conn: conn}
+	if r.URL.Query().Get("framing") == "stdio" {
+		byteStream := &websocketByteStream{conn: conn}
+		s = newHeaderStream(byteStream, byteStream)
+	}
+
+	if err := newSession(h).serve(r.Context(), s); err != nil {
+		if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+			h.logger.Warn("LSP session failed", log.Error(err))
+		}
+	}
+}
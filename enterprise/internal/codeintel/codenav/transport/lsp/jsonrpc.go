@@ -0,0 +1,150 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+
+	"github.com/gorilla/websocket"
+	"github.com/sourcegraph/go-lsp"
+)
+
+// request is a JSON-RPC 2.0 request. Requests without an ID are notifications,
+// which must not be responded to.
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      *lsp.ID         `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// response is a JSON-RPC 2.0 response. Exactly one of Result and Error is set.
+type response struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *lsp.ID          `json:"id"`
+	Result  *json.RawMessage `json:"result,omitempty"`
+	Error   *responseError   `json:"error,omitempty"`
+}
+
+// The error codes defined by JSON-RPC 2.0 and the Language Server Protocol.
+const (
+	codeParseError           = -32700
+	codeInvalidRequest       = -32600
+	codeMethodNotFound       = -32601
+	codeInvalidParams        = -32602
+	codeInternalError        = -32603
+	codeServerNotInitialized = -32002
+)
+
+type responseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *responseError) Error() string {
+	return e.Message
+}
+
+// maxMessageSize is the maximum size of a single JSON-RPC message read from a client.
+// Larger messages are rejected before they are read into memory.
+const maxMessageSize = 4 * 1024 * 1024
+
+// stream reads and writes JSON-RPC messages.
+type stream interface {
+	Read() ([]byte, error)
+	Write(data []byte) error
+}
+
+// websocketStream is a stream that exchanges one JSON-RPC message per
+// websocket message.
+type websocketStream struct {
+	conn *websocket.Conn
+}
+
+func (s *websocketStream) Read() ([]byte, error) {
+	_, data, err := s.conn.ReadMessage()
+	return data, err
+}
+
+func (s *websocketStream) Write(data []byte) error {
+	return s.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// headerStream is a stream that exchanges messages framed by the headers of the
+// LSP base protocol, as language clients do over stdio.
+type headerStream struct {
+	r *textproto.Reader
+	w io.Writer
+}
+
+func newHeaderStream(r io.Reader, w io.Writer) *headerStream {
+	return &headerStream{r: textproto.NewReader(bufio.NewReader(r)), w: w}
+}
+
+func (s *headerStream) Read() ([]byte, error) {
+	header, err := s.r.ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	// The content length must be validated before allocating the message, as it is
+	// entirely under the control of the client.
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil || length <= 0 {
+		return nil, &responseError{Code: codeInvalidRequest, Message: "invalid Content-Length header"}
+	}
+	if length > maxMessageSize {
+		return nil, &responseError{Code: codeInvalidRequest, Message: fmt.Sprintf("message exceeds the maximum size of %d bytes", maxMessageSize)}
+	}
+
+	data := make([]byte, length)
+	if _, err := io.ReadFull(s.r.R, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (s *headerStream) Write(data []byte) error {
+	_, err := fmt.Fprintf(s.w, "Content-Length: %d\r\n\r\n%s", len(data), data)
+	return err
+}
+
+// websocketByteStream adapts the binary messages of a websocket connection into a
+// contiguous byte stream, so that stdio can be bridged to it without regard for
+// message boundaries.
+type websocketByteStream struct {
+	conn   *websocket.Conn
+	reader io.Reader
+}
+
+func (s *websocketByteStream) Read(p []byte) (int, error) {
+	for {
+		if s.reader == nil {
+			_, reader, err := s.conn.NextReader()
+			if err != nil {
+				return 0, err
+			}
+			s.reader = reader
+		}
+
+		n, err := s.reader.Read(p)
+		if err == io.EOF {
+			s.reader = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
+
+func (s *websocketByteStream) Write(p []byte) (int, error) {
+	if err := s.conn.WriteMessage(websocket.BinaryMessage, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
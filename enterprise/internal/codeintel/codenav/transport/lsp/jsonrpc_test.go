@@ -0,0 +1,55 @@
+package lsp
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestHeaderStream(t *testing.T) {
+	input := "Content-Length: 17\r\nContent-Type: application/vscode-jsonrpc; charset=utf-8\r\n\r\n{\"method\":\"foo\"}\n" +
+		"Content-Length: 16\r\n\r\n{\"method\":\"bar\"}"
+
+	var output bytes.Buffer
+	stream := newHeaderStream(strings.NewReader(input), &output)
+
+	for _, expected := range []string{"{\"method\":\"foo\"}\n", "{\"method\":\"bar\"}"} {
+		data, err := stream.Read()
+		if err != nil {
+			t.Fatalf("unexpected error reading message: %s", err)
+		}
+		if string(data) != expected {
+			t.Errorf("unexpected message. want=%q have=%q", expected, data)
+		}
+	}
+	if _, err := stream.Read(); err != io.EOF {
+		t.Errorf("unexpected error at end of stream. want=%v have=%v", io.EOF, err)
+	}
+
+	if err := stream.Write([]byte(`{"id":1}`)); err != nil {
+		t.Fatalf("unexpected error writing message: %s", err)
+	}
+	if expected := "Content-Length: 8\r\n\r\n{\"id\":1}"; output.String() != expected {
+		t.Errorf("unexpected output. want=%q have=%q", expected, output.String())
+	}
+}
+
+func TestHeaderStreamInvalidContentLength(t *testing.T) {
+	for _, header := range []string{
+		"Content-Length: foo",
+		"Content-Length: 0",
+		"Content-Length: -1",
+		"Content-Length: 1073741824",
+	} {
+		stream := newHeaderStream(strings.NewReader(header+"\r\n\r\n{}"), io.Discard)
+
+		_, err := stream.Read()
+		var respErr *responseError
+		if !errors.As(err, &respErr) || respErr.Code != codeInvalidRequest {
+			t.Errorf("unexpected error for %q. want=invalid request have=%v", header, err)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package lsp
+
+import (
+	"fmt"
+
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type operations struct {
+	initialize     *observation.Operation
+	definition     *observation.Operation
+	references     *observation.Operation
+	hover          *observation.Operation
+	implementation *observation.Operation
+	documentSymbol *observation.Operation
+}
+
+func newOperations(observationCtx *observation.Context) *operations {
+	m := metrics.NewREDMetrics(
+		observationCtx.Registerer,
+		"codeintel_codenav_transport_lsp",
+		metrics.WithLabels("op"),
+		metrics.WithCountHelp("Total number of method invocations."),
+	)
+
+	op := func(name string) *observation.Operation {
+		return observationCtx.Operation(observation.Op{
+			Name:              fmt.Sprintf("codeintel.codenav.transport.lsp.%s", name),
+			MetricLabelValues: []string{name},
+			Metrics:           m,
+		})
+	}
+
+	return &operations{
+		initialize:     op("Initialize"),
+		definition:     op("Definition"),
+		references:     op("References"),
+		hover:          op("Hover"),
+		implementation: op("Implementation"),
+		documentSymbol: op("DocumentSymbol"),
+	}
+}
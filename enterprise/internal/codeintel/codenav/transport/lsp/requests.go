@@ -0,0 +1,315 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	traceLog "github.com/opentracing/opentracing-go/log"
+	"github.com/sourcegraph/go-lsp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// locationsPageSize is the number of locations requested from the code navigation
+	// service at once when paging through references and implementations.
+	locationsPageSize = 100
+
+	// maximumLocations is the maximum number of references and implementations returned
+	// for a single request, as LSP does not support paginating them.
+	maximumLocations = 1000
+)
+
+func (s *session) definition(ctx context.Context, rawParams json.RawMessage) (_ []lsp.Location, err error) {
+	var params lsp.TextDocumentPositionParams
+	if err := unmarshalParams(rawParams, &params); err != nil {
+		return nil, err
+	}
+
+	ctx, _, endObservation := s.operations.definition.With(ctx, &err, s.observationArgs(params))
+	defer endObservation(1, observation.Args{})
+
+	args, requestState, ok, err := s.requestArgs(ctx, params)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	locations, err := s.svc.GetDefinitions(ctx, args, requestState)
+	if err != nil {
+		return nil, errors.Wrap(err, "svc.GetDefinitions")
+	}
+
+	return s.toClientLocations(ctx, locations)
+}
+
+func (s *session) references(ctx context.Context, rawParams json.RawMessage) (_ []lsp.Location, err error) {
+	var params lsp.ReferenceParams
+	if err := unmarshalParams(rawParams, &params); err != nil {
+		return nil, err
+	}
+
+	ctx, _, endObservation := s.operations.references.With(ctx, &err, s.observationArgs(params.TextDocumentPositionParams))
+	defer endObservation(1, observation.Args{})
+
+	args, requestState, ok, err := s.requestArgs(ctx, params.TextDocumentPositionParams)
+	if err != nil || !ok {
+		return nil, err
+	}
+	args.Limit = locationsPageSize
+
+	var locations []types.UploadLocation
+	cursor := shared.ReferencesCursor{Phase: "local"}
+	for len(locations) < maximumLocations {
+		var page []types.UploadLocation
+		page, cursor, err = s.svc.GetReferences(ctx, args, requestState, cursor)
+		if err != nil {
+			return nil, errors.Wrap(err, "svc.GetReferences")
+		}
+		locations = append(locations, page...)
+
+		if cursor.Phase == "done" {
+			break
+		}
+	}
+
+	return s.toClientLocations(ctx, locations)
+}
+
+func (s *session) implementation(ctx context.Context, rawParams json.RawMessage) (_ []lsp.Location, err error) {
+	var params lsp.TextDocumentPositionParams
+	if err := unmarshalParams(rawParams, &params); err != nil {
+		return nil, err
+	}
+
+	ctx, _, endObservation := s.operations.implementation.With(ctx, &err, s.observationArgs(params))
+	defer endObservation(1, observation.Args{})
+
+	args, requestState, ok, err := s.requestArgs(ctx, params)
+	if err != nil || !ok {
+		return nil, err
+	}
+	args.Limit = locationsPageSize
+
+	var locations []types.UploadLocation
+	cursor := shared.ImplementationsCursor{Phase: "local"}
+	for len(locations) < maximumLocations {
+		var page []types.UploadLocation
+		page, cursor, err = s.svc.GetImplementations(ctx, args, requestState, cursor)
+		if err != nil {
+			return nil, errors.Wrap(err, "svc.GetImplementations")
+		}
+		locations = append(locations, page...)
+
+		if cursor.Phase == "done" {
+			break
+		}
+	}
+
+	return s.toClientLocations(ctx, locations)
+}
+
+func (s *session) hover(ctx context.Context, rawParams json.RawMessage) (_ *lsp.Hover, err error) {
+	var params lsp.TextDocumentPositionParams
+	if err := unmarshalParams(rawParams, &params); err != nil {
+		return nil, err
+	}
+
+	ctx, _, endObservation := s.operations.hover.With(ctx, &err, s.observationArgs(params))
+	defer endObservation(1, observation.Args{})
+
+	args, requestState, ok, err := s.requestArgs(ctx, params)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	text, rng, exists, err := s.svc.GetHover(ctx, args, requestState)
+	if err != nil {
+		return nil, errors.Wrap(err, "svc.GetHover")
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	hover := &lsp.Hover{Contents: []lsp.MarkedString{lsp.RawMarkedString(text)}}
+
+	// The hover range is within the requested document, so it needs to be translated
+	// into the working copy of the client.
+	location, ok, err := s.toClientLocation(ctx, types.UploadLocation{
+		Dump:         types.Dump{RepositoryID: int(s.repo.ID)},
+		Path:         args.Path,
+		TargetCommit: s.commit,
+		TargetRange:  rng,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		hover.Range = &location.Range
+	}
+
+	return hover, nil
+}
+
+func (s *session) documentSymbol(ctx context.Context, rawParams json.RawMessage) (_ []lsp.SymbolInformation, err error) {
+	var params lsp.DocumentSymbolParams
+	if err := unmarshalParams(rawParams, &params); err != nil {
+		return nil, err
+	}
+
+	ctx, _, endObservation := s.operations.documentSymbol.With(ctx, &err, observation.Args{LogFields: []traceLog.Field{
+		traceLog.String("uri", string(params.TextDocument.URI)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	path, err := s.pathFromURI(params.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	// There is no index of the symbols of a document, so we use the ranges that are
+	// their own definition instead. Their names are taken from the document content at
+	// the commit of the session.
+	var contents string
+	if doc, ok := s.documents[path]; ok {
+		if doc.original == nil {
+			return nil, nil
+		}
+		contents = *doc.original
+	} else {
+		raw, err := s.gitserver.RawContents(ctx, int(s.repo.ID), s.commit, path)
+		if err != nil {
+			return nil, errors.Wrap(err, "gitserver.RawContents")
+		}
+		contents = string(raw)
+	}
+	lines := strings.Split(contents, "\n")
+
+	requestState, ok, err := s.requestState(ctx, path)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	args := shared.RequestArgs{RepositoryID: int(s.repo.ID), Commit: s.commit, Path: path}
+	ranges, err := s.svc.GetRanges(ctx, args, requestState, 0, len(lines))
+	if err != nil {
+		return nil, errors.Wrap(err, "svc.GetRanges")
+	}
+
+	var symbols []lsp.SymbolInformation
+	for _, r := range ranges {
+		if !isDefinitionSite(r, int(s.repo.ID), s.commit, path) {
+			continue
+		}
+
+		name, ok := textInRange(lines, r.Range)
+		if !ok {
+			continue
+		}
+
+		location, ok, err := s.toClientLocation(ctx, types.UploadLocation{
+			Dump:         types.Dump{RepositoryID: int(s.repo.ID)},
+			Path:         path,
+			TargetCommit: s.commit,
+			TargetRange:  r.Range,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		symbols = append(symbols, lsp.SymbolInformation{
+			Name:     name,
+			Kind:     symbolKind(r.HoverText),
+			Location: location,
+		})
+	}
+
+	return symbols, nil
+}
+
+func (s *session) observationArgs(params lsp.TextDocumentPositionParams) observation.Args {
+	return observation.Args{LogFields: []traceLog.Field{
+		traceLog.String("uri", string(params.TextDocument.URI)),
+		traceLog.Int("line", params.Position.Line),
+		traceLog.Int("character", params.Position.Character),
+	}}
+}
+
+// isDefinitionSite returns true if one of the definitions of the given range is the range itself.
+func isDefinitionSite(r shared.AdjustedCodeIntelligenceRange, repositoryID int, commit, path string) bool {
+	for _, definition := range r.Definitions {
+		if definition.Dump.RepositoryID == repositoryID && definition.TargetCommit == commit && definition.Path == path && definition.TargetRange == r.Range {
+			return true
+		}
+	}
+	return false
+}
+
+// textInRange returns the text of the given single-line range.
+func textInRange(lines []string, r types.Range) (string, bool) {
+	if r.Start.Line != r.End.Line || r.Start.Line < 0 || r.Start.Line >= len(lines) {
+		return "", false
+	}
+
+	line := lines[r.Start.Line]
+	if r.Start.Character < 0 || r.Start.Character >= r.End.Character || r.End.Character > len(line) {
+		return "", false
+	}
+
+	return line[r.Start.Character:r.End.Character], true
+}
+
+// symbolKind guesses the kind of a symbol from the first line of its hover text, which
+// usually contains its declaration.
+func symbolKind(hoverText string) lsp.SymbolKind {
+	declaration := hoverText
+	for _, line := range strings.Split(hoverText, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "```") {
+			declaration = line
+			break
+		}
+	}
+
+	fields := strings.Fields(declaration)
+	for i, field := range fields {
+		switch field {
+		case "func", "function", "def", "fn", "fun":
+			if i+1 < len(fields) && strings.HasPrefix(fields[i+1], "(") {
+				return lsp.SKMethod
+			}
+			return lsp.SKFunction
+		case "method":
+			return lsp.SKMethod
+		case "class":
+			return lsp.SKClass
+		case "interface", "trait", "protocol":
+			return lsp.SKInterface
+		case "struct":
+			return lsp.SKStruct
+		case "enum":
+			return lsp.SKEnum
+		case "const":
+			return lsp.SKConstant
+		case "package", "module", "namespace", "mod":
+			return lsp.SKModule
+		case "field":
+			return lsp.SKField
+		case "type":
+			if strings.Contains(declaration, "interface") {
+				return lsp.SKInterface
+			}
+			if strings.Contains(declaration, "struct") {
+				return lsp.SKStruct
+			}
+			return lsp.SKClass
+		}
+	}
+
+	return lsp.SKVariable
+}
@@ -0,0 +1,471 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/sourcegraph/go-lsp"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	sgtypes "github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// session is the state of a single LSP connection. A session answers requests for a
+// single repository at a single commit, which the client passes in the initialization
+// options of the initialize request.
+type session struct {
+	*handler
+
+	repo    *sgtypes.Repo
+	commit  string
+	rootURI string
+	// rootPath is the unescaped path of rootURI, which is used to map the URIs sent by the
+	// client onto paths within the repository.
+	rootPath  string
+	documents map[string]*document
+	exited    bool
+}
+
+// document is a file that is open in the editor of the client.
+type document struct {
+	path string
+	// original is the content of the file at the commit of the session, or nil if the
+	// file does not exist at that commit.
+	original *string
+	// translator translates positions between the commit of the session and the content
+	// of the file in the editor.
+	translator codenav.GitTreeTranslator
+}
+
+type initializationOptions struct {
+	// Repository is the name of the repository on Sourcegraph, e.g. github.com/foo/bar.
+	Repository string `json:"repository"`
+	// Revision is the revision of the repository the working copy of the client is based
+	// on. Defaults to HEAD.
+	Revision string `json:"revision"`
+}
+
+func newSession(h *handler) *session {
+	return &session{
+		handler:   h,
+		documents: map[string]*document{},
+	}
+}
+
+// serve reads requests from the given stream and writes the responses back to it until
+// the client exits or the stream fails. Requests are handled in order, so changes to the
+// open documents are always applied before the requests that follow them.
+func (s *session) serve(ctx context.Context, stream stream) error {
+	for !s.exited {
+		data, err := stream.Read()
+		if err != nil {
+			// Messages that can't be framed are reported to the client before the session
+			// ends, as the position of the next message in the stream is unknown.
+			var respErr *responseError
+			if errors.As(err, &respErr) {
+				if err := s.reply(stream, nil, nil, respErr); err != nil {
+					return err
+				}
+			}
+			return err
+		}
+
+		var req request
+		if err := json.Unmarshal(data, &req); err != nil {
+			if err := s.reply(stream, nil, nil, &responseError{Code: codeParseError, Message: err.Error()}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		result, err := s.handle(ctx, &req)
+		if req.ID == nil {
+			// Notifications can't be responded to, so the best we can do is to log errors.
+			if err != nil {
+				s.logger.Warn("failed to handle LSP notification", log.String("method", req.Method), log.Error(err))
+			}
+			continue
+		}
+
+		if err := s.reply(stream, req.ID, result, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *session) reply(stream stream, id *lsp.ID, result any, err error) error {
+	resp := response{JSONRPC: "2.0", ID: id}
+
+	if err != nil {
+		var respErr *responseError
+		if !errors.As(err, &respErr) {
+			respErr = &responseError{Code: codeInternalError, Message: err.Error()}
+		}
+		resp.Error = respErr
+	} else {
+		raw, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		rawMessage := json.RawMessage(raw)
+		resp.Result = &rawMessage
+	}
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	return stream.Write(data)
+}
+
+func (s *session) handle(ctx context.Context, req *request) (any, error) {
+	switch req.Method {
+	case "initialize":
+		return s.initialize(ctx, req.Params)
+	case "exit":
+		s.exited = true
+		return nil, nil
+	}
+
+	if s.repo == nil {
+		return nil, &responseError{Code: codeServerNotInitialized, Message: "the server has not been initialized"}
+	}
+
+	switch req.Method {
+	case "initialized", "shutdown", "$/cancelRequest":
+		return nil, nil
+	case "textDocument/didOpen":
+		return nil, s.didOpen(ctx, req.Params)
+	case "textDocument/didChange":
+		return nil, s.didChange(req.Params)
+	case "textDocument/didClose":
+		return nil, s.didClose(req.Params)
+	case "textDocument/definition":
+		return s.definition(ctx, req.Params)
+	case "textDocument/references":
+		return s.references(ctx, req.Params)
+	case "textDocument/hover":
+		return s.hover(ctx, req.Params)
+	case "textDocument/implementation":
+		return s.implementation(ctx, req.Params)
+	case "textDocument/documentSymbol":
+		return s.documentSymbol(ctx, req.Params)
+	}
+
+	return nil, &responseError{Code: codeMethodNotFound, Message: fmt.Sprintf("method not supported: %s", req.Method)}
+}
+
+func unmarshalParams(params json.RawMessage, v any) error {
+	if err := json.Unmarshal(params, v); err != nil {
+		return &responseError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+func (s *session) initialize(ctx context.Context, rawParams json.RawMessage) (_ *lsp.InitializeResult, err error) {
+	ctx, _, endObservation := s.operations.initialize.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	if s.repo != nil {
+		return nil, &responseError{Code: codeInvalidRequest, Message: "the server has already been initialized"}
+	}
+
+	var params lsp.InitializeParams
+	if err := unmarshalParams(rawParams, &params); err != nil {
+		return nil, err
+	}
+	var options struct {
+		InitializationOptions initializationOptions `json:"initializationOptions"`
+	}
+	if err := unmarshalParams(rawParams, &options); err != nil {
+		return nil, err
+	}
+
+	rootURI := string(params.RootURI)
+	if rootURI == "" && params.RootPath != "" {
+		rootURI = "file://" + (&url.URL{Path: params.RootPath}).EscapedPath()
+	}
+	if rootURI == "" {
+		return nil, &responseError{Code: codeInvalidParams, Message: "rootUri is required"}
+	}
+	if !strings.HasSuffix(rootURI, "/") {
+		rootURI += "/"
+	}
+	rootPath, err := url.PathUnescape(rootURI)
+	if err != nil {
+		return nil, &responseError{Code: codeInvalidParams, Message: fmt.Sprintf("invalid rootUri: %s", err)}
+	}
+
+	if options.InitializationOptions.Repository == "" {
+		return nil, &responseError{Code: codeInvalidParams, Message: "initializationOptions.repository is required"}
+	}
+
+	// 🚨 SECURITY: The repository store only returns repositories the user has access to.
+	repo, err := s.repoStore.GetByName(ctx, api.RepoName(options.InitializationOptions.Repository))
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, &responseError{Code: codeInvalidParams, Message: fmt.Sprintf("repository not found: %s", options.InitializationOptions.Repository)}
+		}
+		return nil, err
+	}
+
+	revision := options.InitializationOptions.Revision
+	if revision == "" {
+		revision = "HEAD"
+	}
+	commit, err := s.gitserver.ResolveRevision(ctx, int(repo.ID), revision)
+	if err != nil {
+		return nil, &responseError{Code: codeInvalidParams, Message: fmt.Sprintf("failed to resolve revision %q: %s", revision, err)}
+	}
+
+	s.repo = repo
+	s.commit = string(commit)
+	s.rootURI = rootURI
+	s.rootPath = rootPath
+
+	syncKind := lsp.TDSKFull
+	return &lsp.InitializeResult{
+		Capabilities: lsp.ServerCapabilities{
+			TextDocumentSync:       &lsp.TextDocumentSyncOptionsOrKind{Kind: &syncKind},
+			HoverProvider:          true,
+			DefinitionProvider:     true,
+			ReferencesProvider:     true,
+			ImplementationProvider: true,
+			DocumentSymbolProvider: true,
+		},
+	}, nil
+}
+
+func (s *session) didOpen(ctx context.Context, rawParams json.RawMessage) error {
+	var params lsp.DidOpenTextDocumentParams
+	if err := unmarshalParams(rawParams, &params); err != nil {
+		return err
+	}
+
+	path, err := s.pathFromURI(params.TextDocument.URI)
+	if err != nil {
+		return err
+	}
+
+	doc := &document{path: path}
+	s.documents[path] = doc
+
+	contents, err := s.gitserver.RawContents(ctx, int(s.repo.ID), s.commit, path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			// The file has been added to the working copy, so there is no code
+			// intelligence for it.
+			return nil
+		}
+		return errors.Wrap(err, "gitserver.RawContents")
+	}
+	original := string(contents)
+	doc.original = &original
+	doc.update(params.TextDocument.Text)
+
+	return nil
+}
+
+func (s *session) didChange(rawParams json.RawMessage) error {
+	var params lsp.DidChangeTextDocumentParams
+	if err := unmarshalParams(rawParams, &params); err != nil {
+		return err
+	}
+
+	path, err := s.pathFromURI(params.TextDocument.URI)
+	if err != nil {
+		return err
+	}
+
+	doc, ok := s.documents[path]
+	if !ok || doc.original == nil || len(params.ContentChanges) == 0 {
+		return nil
+	}
+
+	// We only announce full document synchronization, so the last change contains the
+	// complete content of the document.
+	change := params.ContentChanges[len(params.ContentChanges)-1]
+	if change.Range != nil {
+		// We can't apply incremental changes, so positions in this document can no longer
+		// be translated.
+		doc.original = nil
+		return errors.Newf("received incremental change for %s", path)
+	}
+	doc.update(change.Text)
+
+	return nil
+}
+
+func (s *session) didClose(rawParams json.RawMessage) error {
+	var params lsp.DidCloseTextDocumentParams
+	if err := unmarshalParams(rawParams, &params); err != nil {
+		return err
+	}
+
+	path, err := s.pathFromURI(params.TextDocument.URI)
+	if err != nil {
+		return err
+	}
+
+	delete(s.documents, path)
+	return nil
+}
+
+func (d *document) update(text string) {
+	d.translator = codenav.NewWorkingCopyGitTreeTranslator(d.path, codenav.WorkingCopyHunks(*d.original, text))
+}
+
+// pathFromURI returns the path within the repository of the given document URI.
+func (s *session) pathFromURI(uri lsp.DocumentURI) (string, error) {
+	uriPath, err := url.PathUnescape(string(uri))
+	if err != nil {
+		return "", &responseError{Code: codeInvalidParams, Message: fmt.Sprintf("invalid document URI %s: %s", uri, err)}
+	}
+	if !strings.HasPrefix(uriPath, s.rootPath) {
+		return "", &responseError{Code: codeInvalidParams, Message: fmt.Sprintf("document %s is not within the workspace root %s", uri, s.rootURI)}
+	}
+
+	return strings.TrimPrefix(uriPath, s.rootPath), nil
+}
+
+// uriFromPath returns the document URI of the given path within the repository.
+func (s *session) uriFromPath(path string) lsp.DocumentURI {
+	return lsp.DocumentURI(s.rootURI + strings.TrimPrefix((&url.URL{Path: path}).EscapedPath(), "/"))
+}
+
+// toCommitPosition translates the given position in a document of the client into the
+// commit of the session. If the position has been edited in the working copy of the
+// client, false is returned.
+func (s *session) toCommitPosition(ctx context.Context, uri lsp.DocumentURI, pos lsp.Position) (string, types.Position, bool, error) {
+	path, err := s.pathFromURI(uri)
+	if err != nil {
+		return "", types.Position{}, false, err
+	}
+
+	position := types.Position{Line: pos.Line, Character: pos.Character}
+
+	doc, ok := s.documents[path]
+	if !ok {
+		// We don't know about changes to files that aren't open in the editor.
+		return path, position, true, nil
+	}
+	if doc.original == nil {
+		return path, types.Position{}, false, nil
+	}
+
+	_, position, ok, err = doc.translator.GetTargetCommitPositionFromSourcePosition(ctx, s.commit, position, true)
+	return path, position, ok, err
+}
+
+// toClientLocation converts the given location into an LSP location. Locations within the
+// repository and commit of the session point to the working copy of the client and are
+// adjusted to the changes in open documents. Other locations point to Sourcegraph.
+func (s *session) toClientLocation(ctx context.Context, location types.UploadLocation) (lsp.Location, bool, error) {
+	if location.Dump.RepositoryID != int(s.repo.ID) || location.TargetCommit != s.commit {
+		return lsp.Location{
+			URI:   lsp.DocumentURI(s.sourcegraphURL(location)),
+			Range: toLSPRange(location.TargetRange),
+		}, true, nil
+	}
+
+	rng := location.TargetRange
+	if doc, ok := s.documents[location.Path]; ok {
+		if doc.original == nil {
+			return lsp.Location{}, false, nil
+		}
+
+		var err error
+		_, rng, ok, err = doc.translator.GetTargetCommitRangeFromSourceRange(ctx, s.commit, location.Path, rng, false)
+		if err != nil || !ok {
+			return lsp.Location{}, false, err
+		}
+	}
+
+	return lsp.Location{
+		URI:   s.uriFromPath(location.Path),
+		Range: toLSPRange(rng),
+	}, true, nil
+}
+
+func (s *session) toClientLocations(ctx context.Context, locations []types.UploadLocation) ([]lsp.Location, error) {
+	clientLocations := make([]lsp.Location, 0, len(locations))
+	for _, location := range locations {
+		clientLocation, ok, err := s.toClientLocation(ctx, location)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			clientLocations = append(clientLocations, clientLocation)
+		}
+	}
+
+	return clientLocations, nil
+}
+
+// sourcegraphURL returns the URL of the given location on Sourcegraph.
+func (s *session) sourcegraphURL(location types.UploadLocation) string {
+	return fmt.Sprintf(
+		"%s/%s@%s/-/blob/%s?L%d:%d",
+		strings.TrimSuffix(s.externalURL(), "/"),
+		location.Dump.RepositoryName,
+		location.TargetCommit,
+		strings.TrimPrefix(location.Path, "/"),
+		location.TargetRange.Start.Line+1,
+		location.TargetRange.Start.Character+1,
+	)
+}
+
+// requestArgs returns the arguments and the request state to resolve the given position
+// with the code navigation service. If the position can't be resolved, false is returned.
+func (s *session) requestArgs(ctx context.Context, params lsp.TextDocumentPositionParams) (shared.RequestArgs, codenav.RequestState, bool, error) {
+	path, position, ok, err := s.toCommitPosition(ctx, params.TextDocument.URI, params.Position)
+	if err != nil || !ok {
+		return shared.RequestArgs{}, codenav.RequestState{}, false, err
+	}
+
+	requestState, ok, err := s.requestState(ctx, path)
+	if err != nil || !ok {
+		return shared.RequestArgs{}, codenav.RequestState{}, false, err
+	}
+
+	return shared.RequestArgs{
+		RepositoryID: int(s.repo.ID),
+		Commit:       s.commit,
+		Path:         path,
+		Line:         position.Line,
+		Character:    position.Character,
+	}, requestState, true, nil
+}
+
+// requestState returns the request state for the given path. If there are no uploads that
+// can answer queries for the path, false is returned.
+func (s *session) requestState(ctx context.Context, path string) (codenav.RequestState, bool, error) {
+	uploads, err := s.svc.GetClosestDumpsForBlob(ctx, int(s.repo.ID), s.commit, path, false, "")
+	if err != nil || len(uploads) == 0 {
+		return codenav.RequestState{}, false, err
+	}
+
+	// 🚨 SECURITY: The request state checks sub-repository permissions of the returned locations.
+	return codenav.NewRequestState(uploads, authz.DefaultSubRepoPermsChecker, s.gitserver, s.repo, s.commit, path, s.maximumIndexesPerMonikerSearch, s.hunkCache), true, nil
+}
+
+func toLSPRange(r types.Range) lsp.Range {
+	return lsp.Range{
+		Start: lsp.Position{Line: r.Start.Line, Character: r.Start.Character},
+		End:   lsp.Position{Line: r.End.Line, Character: r.End.Character},
+	}
+}
@@ -0,0 +1,188 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/go-lsp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	sgtypes "github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestSession(t *testing.T) {
+	mockSvc := NewMockCodeNavService()
+	mockRepoStore := NewMockRepoStore()
+	mockGitserver := NewMockGitserverClient()
+
+	mockRepoStore.GetByNameFunc.SetDefaultReturn(&sgtypes.Repo{ID: 42, Name: "github.com/sourcegraph/sourcegraph"}, nil)
+	mockGitserver.ResolveRevisionFunc.SetDefaultReturn("deadbeef", nil)
+	mockGitserver.RawContentsFunc.SetDefaultHook(func(ctx context.Context, repositoryID int, commit, file string) ([]byte, error) {
+		if file == "main.go" {
+			return []byte("package main\n\nfunc main() {\n\tfoo()\n}\n\nfunc foo() {}\n"), nil
+		}
+		return nil, os.ErrNotExist
+	})
+	mockSvc.GetClosestDumpsForBlobFunc.SetDefaultReturn([]types.Dump{{ID: 1, RepositoryID: 42, Commit: "deadbeef"}}, nil)
+
+	var definitionArgs shared.RequestArgs
+	mockSvc.GetDefinitionsFunc.SetDefaultHook(func(ctx context.Context, args shared.RequestArgs, _ codenav.RequestState) ([]types.UploadLocation, error) {
+		definitionArgs = args
+		return []types.UploadLocation{
+			{
+				Dump:         types.Dump{RepositoryID: 42, RepositoryName: "github.com/sourcegraph/sourcegraph"},
+				Path:         "main.go",
+				TargetCommit: "deadbeef",
+				TargetRange:  types.Range{Start: types.Position{Line: 6, Character: 5}, End: types.Position{Line: 6, Character: 8}},
+			},
+			{
+				Dump:         types.Dump{RepositoryID: 50, RepositoryName: "github.com/sourcegraph/other"},
+				Path:         "other.go",
+				TargetCommit: "cafebabe",
+				TargetRange:  types.Range{Start: types.Position{Line: 9, Character: 2}, End: types.Position{Line: 9, Character: 5}},
+			},
+		}, nil
+	})
+
+	h, err := NewHandler(&observation.TestContext, mockSvc, mockRepoStore, mockGitserver, func() string { return "https://sourcegraph.test/" }, 50, 100)
+	if err != nil {
+		t.Fatalf("unexpected error creating handler: %s", err)
+	}
+
+	// The editor has inserted two lines above the definition of foo.
+	stream := newTestStream(t,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"rootUri":"file:///work/","initializationOptions":{"repository":"github.com/sourcegraph/sourcegraph","revision":"main"}}}`,
+		`{"jsonrpc":"2.0","method":"initialized","params":{}}`,
+		`{"jsonrpc":"2.0","method":"textDocument/didOpen","params":{"textDocument":{"uri":"file:///work/main.go","languageId":"go","version":1,"text":"package main\n\n// bar is new.\nfunc bar() {}\n\nfunc main() {\n\tfoo()\n}\n\nfunc foo() {}\n"}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"textDocument/definition","params":{"textDocument":{"uri":"file:///work/main.go"},"position":{"line":6,"character":2}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"textDocument/definition","params":{"textDocument":{"uri":"file:///work/main.go"},"position":{"line":3,"character":6}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"workspace/symbol","params":{"query":"foo"}}`,
+		`{"jsonrpc":"2.0","method":"exit"}`,
+	)
+	if err := newSession(h.(*handler)).serve(context.Background(), stream); err != nil {
+		t.Fatalf("unexpected error serving session: %s", err)
+	}
+
+	if len(stream.responses) != 4 {
+		t.Fatalf("unexpected number of responses. want=%d have=%d", 4, len(stream.responses))
+	}
+
+	if value := mockGitserver.ResolveRevisionFunc.History()[0].Arg2; value != "main" {
+		t.Errorf("unexpected revision. want=%q have=%q", "main", value)
+	}
+
+	// Positions are translated from the working copy into the commit.
+	expectedArgs := shared.RequestArgs{RepositoryID: 42, Commit: "deadbeef", Path: "main.go", Line: 3, Character: 2}
+	if diff := cmp.Diff(expectedArgs, definitionArgs); diff != "" {
+		t.Errorf("unexpected request args (-want +got):\n%s", diff)
+	}
+
+	var locations []lsp.Location
+	if err := json.Unmarshal(*stream.responses[1].Result, &locations); err != nil {
+		t.Fatalf("unexpected error unmarshalling locations: %s", err)
+	}
+	expectedLocations := []lsp.Location{
+		{
+			URI:   "file:///work/main.go",
+			Range: lsp.Range{Start: lsp.Position{Line: 9, Character: 5}, End: lsp.Position{Line: 9, Character: 8}},
+		},
+		{
+			URI:   "https://sourcegraph.test/github.com/sourcegraph/other@cafebabe/-/blob/other.go?L10:3",
+			Range: lsp.Range{Start: lsp.Position{Line: 9, Character: 2}, End: lsp.Position{Line: 9, Character: 5}},
+		},
+	}
+	if diff := cmp.Diff(expectedLocations, locations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+
+	// Positions within lines that only exist in the working copy have no results.
+	if resp := stream.responses[2]; resp.Error != nil || (resp.Result != nil && string(*resp.Result) != "null") {
+		t.Errorf("unexpected response for edited line. want=null have=%v", resp)
+	}
+	if len(mockSvc.GetDefinitionsFunc.History()) != 1 {
+		t.Errorf("unexpected number of definition requests. want=%d have=%d", 1, len(mockSvc.GetDefinitionsFunc.History()))
+	}
+
+	if err := stream.responses[3].Error; err == nil || err.Code != codeMethodNotFound {
+		t.Errorf("unexpected error for unsupported method. want code=%d have=%v", codeMethodNotFound, err)
+	}
+}
+
+func TestSessionNotInitialized(t *testing.T) {
+	h, err := NewHandler(&observation.TestContext, NewMockCodeNavService(), NewMockRepoStore(), NewMockGitserverClient(), func() string { return "" }, 50, 100)
+	if err != nil {
+		t.Fatalf("unexpected error creating handler: %s", err)
+	}
+
+	stream := newTestStream(t,
+		`{"jsonrpc":"2.0","id":1,"method":"textDocument/hover","params":{"textDocument":{"uri":"file:///work/main.go"},"position":{"line":0,"character":0}}}`,
+	)
+	if err := newSession(h.(*handler)).serve(context.Background(), stream); err != io.EOF {
+		t.Fatalf("unexpected error serving session. want=%v have=%v", io.EOF, err)
+	}
+
+	if len(stream.responses) != 1 {
+		t.Fatalf("unexpected number of responses. want=%d have=%d", 1, len(stream.responses))
+	}
+	if err := stream.responses[0].Error; err == nil || err.Code != codeServerNotInitialized {
+		t.Errorf("unexpected error. want code=%d have=%v", codeServerNotInitialized, err)
+	}
+}
+
+func TestSymbolKind(t *testing.T) {
+	testCases := []struct {
+		hoverText string
+		expected  lsp.SymbolKind
+	}{
+		{"```go\nfunc foo()\n```", lsp.SKFunction},
+		{"```go\nfunc (s *S) foo()\n```", lsp.SKMethod},
+		{"```go\ntype S struct\n```", lsp.SKStruct},
+		{"```go\ntype I interface\n```", lsp.SKInterface},
+		{"```python\nclass Foo\n```", lsp.SKClass},
+		{"```go\nconst x = 1\n```", lsp.SKConstant},
+		{"```go\nvar x int\n```", lsp.SKVariable},
+	}
+
+	for _, testCase := range testCases {
+		if kind := symbolKind(testCase.hoverText); kind != testCase.expected {
+			t.Errorf("unexpected symbol kind for %q. want=%d have=%d", testCase.hoverText, testCase.expected, kind)
+		}
+	}
+}
+
+// testStream is a stream that reads the given requests and records all responses.
+type testStream struct {
+	t         *testing.T
+	requests  []string
+	responses []response
+}
+
+func newTestStream(t *testing.T, requests ...string) *testStream {
+	return &testStream{t: t, requests: requests}
+}
+
+func (s *testStream) Read() ([]byte, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+
+	request := s.requests[0]
+	s.requests = s.requests[1:]
+	return []byte(request), nil
+}
+
+func (s *testStream) Write(data []byte) error {
+	var resp response
+	if err := json.Unmarshal(data, &resp); err != nil {
+		s.t.Fatalf("unexpected error unmarshalling response: %s", err)
+	}
+	s.responses = append(s.responses, resp)
+	return nil
+}
@@ -6,6 +6,8 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
@@ -38,6 +40,64 @@ func uploadIDsToString(vs []types.Dump) string {
 	return strings.Join(ids, ", ")
 }
 
+// monikerAtVersion returns a copy of the given moniker that refers to the given version of its package.
+// SCIP symbol identifiers embed the package version, so the version component of the identifier is also
+// rewritten. LSIF moniker identifiers do not include a version and are returned unchanged.
+func monikerAtVersion(moniker precise.QualifiedMonikerData, version string) precise.QualifiedMonikerData {
+	if symbol, err := scip.ParseSymbol(moniker.Identifier); err == nil && symbol.Package != nil && symbol.Package.Version == moniker.Version {
+		if identifier, ok := replaceSymbolVersion(moniker.Identifier, version); ok {
+			moniker.Identifier = identifier
+		}
+	}
+
+	moniker.Version = version
+	return moniker
+}
+
+// replaceSymbolVersion replaces the package version of the given SCIP symbol. A symbol with a package
+// is formatted as `<scheme> <manager> <name> <version> <descriptors>`, where spaces within each of the
+// leading components are escaped as double spaces and empty components are written as a single dot.
+func replaceSymbolVersion(symbol, version string) (string, bool) {
+	start := 0
+	for i := 0; i < 3; i++ {
+		end, ok := symbolComponentEnd(symbol, start)
+		if !ok {
+			return "", false
+		}
+		start = end + 1
+	}
+
+	end, ok := symbolComponentEnd(symbol, start)
+	if !ok {
+		return "", false
+	}
+
+	escaped := strings.ReplaceAll(version, " ", "  ")
+	if escaped == "" {
+		escaped = "."
+	}
+
+	return symbol[:start] + escaped + symbol[end:], true
+}
+
+// symbolComponentEnd returns the index of the space terminating the space-escaped symbol component
+// that begins at the given offset.
+func symbolComponentEnd(symbol string, start int) (int, bool) {
+	for i := start; i < len(symbol); i++ {
+		if symbol[i] != ' ' {
+			continue
+		}
+		if i+1 < len(symbol) && symbol[i+1] == ' ' {
+			i++
+			continue
+		}
+
+		return i, true
+	}
+
+	return 0, false
+}
+
 // isSourceLocation returns true if the given location encloses the source position within one of the visible uploads.
 func isSourceLocation(visibleUploads []visibleUpload, location shared.Location) bool {
 	for i := range visibleUploads {
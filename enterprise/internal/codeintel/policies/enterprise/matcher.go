@@ -29,6 +29,17 @@ type PolicyMatch struct {
 	CommittedAt    *time.Time
 }
 
+// EphemeralUploadPolicyMatch returns the policy match that applies to ephemeral uploads. Ephemeral uploads
+// are made for commits that are not reachable from any branch or tag (e.g. the head of a pull request), so
+// they are never described by a configuration policy. Instead, they are retained for the given duration
+// after upload.
+func EphemeralUploadPolicyMatch(maxAge time.Duration) PolicyMatch {
+	return PolicyMatch{
+		Name:           "ephemeral",
+		PolicyDuration: &maxAge,
+	}
+}
+
 func NewMatcher(
 	gitserverClient GitserverClient,
 	extractor Extractor,
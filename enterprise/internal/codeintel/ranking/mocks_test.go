@@ -18,6 +18,7 @@ import (
 	authz "github.com/sourcegraph/sourcegraph/internal/authz"
 	conftypes "github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	gitserver "github.com/sourcegraph/sourcegraph/internal/gitserver"
+	gitdomain "github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	search "github.com/sourcegraph/sourcegraph/internal/search"
 	result "github.com/sourcegraph/sourcegraph/internal/search/result"
 	schema "github.com/sourcegraph/sourcegraph/schema"
@@ -1553,12 +1554,18 @@ type MockGitserverClient struct {
 	// ArchiveReaderFunc is an instance of a mock function object
 	// controlling the behavior of the method ArchiveReader.
 	ArchiveReaderFunc *GitserverClientArchiveReaderFunc
+	// CommitLogForRepoFunc is an instance of a mock function object
+	// controlling the behavior of the method CommitLogForRepo.
+	CommitLogForRepoFunc *GitserverClientCommitLogForRepoFunc
 	// HeadFromNameFunc is an instance of a mock function object controlling
 	// the behavior of the method HeadFromName.
 	HeadFromNameFunc *GitserverClientHeadFromNameFunc
 	// ListFilesForRepoFunc is an instance of a mock function object
 	// controlling the behavior of the method ListFilesForRepo.
 	ListFilesForRepoFunc *GitserverClientListFilesForRepoFunc
+	// RawContentsForRepoFunc is an instance of a mock function object
+	// controlling the behavior of the method RawContentsForRepo.
+	RawContentsForRepoFunc *GitserverClientRawContentsForRepoFunc
 }
 
 // NewMockGitserverClient creates a new mock of the GitserverClient
@@ -1571,6 +1578,11 @@ func NewMockGitserverClient() *MockGitserverClient {
 				return
 			},
 		},
+		CommitLogForRepoFunc: &GitserverClientCommitLogForRepoFunc{
+			defaultHook: func(context.Context, api.RepoName, time.Time) (r0 []*gitdomain.CommitLog, r1 error) {
+				return
+			},
+		},
 		HeadFromNameFunc: &GitserverClientHeadFromNameFunc{
 			defaultHook: func(context.Context, api.RepoName) (r0 string, r1 bool, r2 error) {
 				return
@@ -1581,6 +1593,11 @@ func NewMockGitserverClient() *MockGitserverClient {
 				return
 			},
 		},
+		RawContentsForRepoFunc: &GitserverClientRawContentsForRepoFunc{
+			defaultHook: func(context.Context, api.RepoName, string, string) (r0 []byte, r1 error) {
+				return
+			},
+		},
 	}
 }
 
@@ -1593,6 +1610,11 @@ func NewStrictMockGitserverClient() *MockGitserverClient {
 				panic("unexpected invocation of MockGitserverClient.ArchiveReader")
 			},
 		},
+		CommitLogForRepoFunc: &GitserverClientCommitLogForRepoFunc{
+			defaultHook: func(context.Context, api.RepoName, time.Time) ([]*gitdomain.CommitLog, error) {
+				panic("unexpected invocation of MockGitserverClient.CommitLogForRepo")
+			},
+		},
 		HeadFromNameFunc: &GitserverClientHeadFromNameFunc{
 			defaultHook: func(context.Context, api.RepoName) (string, bool, error) {
 				panic("unexpected invocation of MockGitserverClient.HeadFromName")
@@ -1603,6 +1625,11 @@ func NewStrictMockGitserverClient() *MockGitserverClient {
 				panic("unexpected invocation of MockGitserverClient.ListFilesForRepo")
 			},
 		},
+		RawContentsForRepoFunc: &GitserverClientRawContentsForRepoFunc{
+			defaultHook: func(context.Context, api.RepoName, string, string) ([]byte, error) {
+				panic("unexpected invocation of MockGitserverClient.RawContentsForRepo")
+			},
+		},
 	}
 }
 
@@ -1614,12 +1641,18 @@ func NewMockGitserverClientFrom(i GitserverClient) *MockGitserverClient {
 		ArchiveReaderFunc: &GitserverClientArchiveReaderFunc{
 			defaultHook: i.ArchiveReader,
 		},
+		CommitLogForRepoFunc: &GitserverClientCommitLogForRepoFunc{
+			defaultHook: i.CommitLogForRepo,
+		},
 		HeadFromNameFunc: &GitserverClientHeadFromNameFunc{
 			defaultHook: i.HeadFromName,
 		},
 		ListFilesForRepoFunc: &GitserverClientListFilesForRepoFunc{
 			defaultHook: i.ListFilesForRepo,
 		},
+		RawContentsForRepoFunc: &GitserverClientRawContentsForRepoFunc{
+			defaultHook: i.RawContentsForRepo,
+		},
 	}
 }
 
@@ -1738,6 +1771,120 @@ func (c GitserverClientArchiveReaderFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// GitserverClientCommitLogForRepoFunc describes the behavior when the
+// CommitLogForRepo method of the parent MockGitserverClient instance is
+// invoked.
+type GitserverClientCommitLogForRepoFunc struct {
+	defaultHook func(context.Context, api.RepoName, time.Time) ([]*gitdomain.CommitLog, error)
+	hooks       []func(context.Context, api.RepoName, time.Time) ([]*gitdomain.CommitLog, error)
+	history     []GitserverClientCommitLogForRepoFuncCall
+	mutex       sync.Mutex
+}
+
+// CommitLogForRepo delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGitserverClient) CommitLogForRepo(v0 context.Context, v1 api.RepoName, v2 time.Time) ([]*gitdomain.CommitLog, error) {
+	r0, r1 := m.CommitLogForRepoFunc.nextHook()(v0, v1, v2)
+	m.CommitLogForRepoFunc.appendCall(GitserverClientCommitLogForRepoFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CommitLogForRepo
+// method of the parent MockGitserverClient instance is invoked and the hook
+// queue is empty.
+func (f *GitserverClientCommitLogForRepoFunc) SetDefaultHook(hook func(context.Context, api.RepoName, time.Time) ([]*gitdomain.CommitLog, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CommitLogForRepo method of the parent MockGitserverClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *GitserverClientCommitLogForRepoFunc) PushHook(hook func(context.Context, api.RepoName, time.Time) ([]*gitdomain.CommitLog, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverClientCommitLogForRepoFunc) SetDefaultReturn(r0 []*gitdomain.CommitLog, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, time.Time) ([]*gitdomain.CommitLog, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverClientCommitLogForRepoFunc) PushReturn(r0 []*gitdomain.CommitLog, r1 error) {
+	f.PushHook(func(context.Context, api.RepoName, time.Time) ([]*gitdomain.CommitLog, error) {
+		return r0, r1
+	})
+}
+
+func (f *GitserverClientCommitLogForRepoFunc) nextHook() func(context.Context, api.RepoName, time.Time) ([]*gitdomain.CommitLog, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverClientCommitLogForRepoFunc) appendCall(r0 GitserverClientCommitLogForRepoFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverClientCommitLogForRepoFuncCall
+// objects describing the invocations of this function.
+func (f *GitserverClientCommitLogForRepoFunc) History() []GitserverClientCommitLogForRepoFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverClientCommitLogForRepoFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverClientCommitLogForRepoFuncCall is an object that describes an
+// invocation of method CommitLogForRepo on an instance of
+// MockGitserverClient.
+type GitserverClientCommitLogForRepoFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*gitdomain.CommitLog
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverClientCommitLogForRepoFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverClientCommitLogForRepoFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // GitserverClientHeadFromNameFunc describes the behavior when the
 // HeadFromName method of the parent MockGitserverClient instance is
 // invoked.
@@ -1967,6 +2114,123 @@ func (c GitserverClientListFilesForRepoFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// GitserverClientRawContentsForRepoFunc describes the behavior when the
+// RawContentsForRepo method of the parent MockGitserverClient instance is
+// invoked.
+type GitserverClientRawContentsForRepoFunc struct {
+	defaultHook func(context.Context, api.RepoName, string, string) ([]byte, error)
+	hooks       []func(context.Context, api.RepoName, string, string) ([]byte, error)
+	history     []GitserverClientRawContentsForRepoFuncCall
+	mutex       sync.Mutex
+}
+
+// RawContentsForRepo delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGitserverClient) RawContentsForRepo(v0 context.Context, v1 api.RepoName, v2 string, v3 string) ([]byte, error) {
+	r0, r1 := m.RawContentsForRepoFunc.nextHook()(v0, v1, v2, v3)
+	m.RawContentsForRepoFunc.appendCall(GitserverClientRawContentsForRepoFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the RawContentsForRepo
+// method of the parent MockGitserverClient instance is invoked and the hook
+// queue is empty.
+func (f *GitserverClientRawContentsForRepoFunc) SetDefaultHook(hook func(context.Context, api.RepoName, string, string) ([]byte, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RawContentsForRepo method of the parent MockGitserverClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *GitserverClientRawContentsForRepoFunc) PushHook(hook func(context.Context, api.RepoName, string, string) ([]byte, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverClientRawContentsForRepoFunc) SetDefaultReturn(r0 []byte, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, string, string) ([]byte, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverClientRawContentsForRepoFunc) PushReturn(r0 []byte, r1 error) {
+	f.PushHook(func(context.Context, api.RepoName, string, string) ([]byte, error) {
+		return r0, r1
+	})
+}
+
+func (f *GitserverClientRawContentsForRepoFunc) nextHook() func(context.Context, api.RepoName, string, string) ([]byte, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverClientRawContentsForRepoFunc) appendCall(r0 GitserverClientRawContentsForRepoFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverClientRawContentsForRepoFuncCall
+// objects describing the invocations of this function.
+func (f *GitserverClientRawContentsForRepoFunc) History() []GitserverClientRawContentsForRepoFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverClientRawContentsForRepoFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverClientRawContentsForRepoFuncCall is an object that describes an
+// invocation of method RawContentsForRepo on an instance of
+// MockGitserverClient.
+type GitserverClientRawContentsForRepoFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []byte
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverClientRawContentsForRepoFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverClientRawContentsForRepoFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // MockSymbolsClient is a mock implementation of the SymbolsClient interface
 // (from the package
 // github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/ranking)
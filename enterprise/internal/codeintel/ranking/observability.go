@@ -8,10 +8,11 @@ import (
 )
 
 type operations struct {
-	getRepoRank       *observation.Operation
-	getDocumentRanks  *observation.Operation
-	indexRepositories *observation.Operation
-	indexRepository   *observation.Operation
+	getRepoRank         *observation.Operation
+	getDocumentRanks    *observation.Operation
+	explainDocumentRank *observation.Operation
+	indexRepositories   *observation.Operation
+	indexRepository     *observation.Operation
 }
 
 var m = new(metrics.SingletonREDMetrics)
@@ -35,9 +36,10 @@ func newOperations(observationCtx *observation.Context) *operations {
 	}
 
 	return &operations{
-		getRepoRank:       op("GetRepoRank"),
-		getDocumentRanks:  op("GetDocumentRanks"),
-		indexRepositories: op("IndexRepositories"),
-		indexRepository:   op("indexRepository"),
+		getRepoRank:         op("GetRepoRank"),
+		getDocumentRanks:    op("GetDocumentRanks"),
+		explainDocumentRank: op("ExplainDocumentRank"),
+		indexRepositories:   op("IndexRepositories"),
+		indexRepository:     op("indexRepository"),
 	}
 }
@@ -2,14 +2,16 @@ package ranking
 
 import (
 	"context"
+	"fmt"
 	"sort"
-	"strings"
 	"time"
 
 	"cloud.google.com/go/storage"
+	"github.com/derision-test/glock"
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/ranking/internal/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/paths"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
@@ -27,6 +29,7 @@ type Service struct {
 	resultsBucket   *storage.BucketHandle
 	operations      *operations
 	logger          log.Logger
+	clock           glock.Clock
 }
 
 func newService(
@@ -47,6 +50,7 @@ func newService(
 		resultsBucket:   resultsBucket,
 		operations:      newOperations(observationCtx),
 		logger:          observationCtx.Logger,
+		clock:           glock.NewRealClock(),
 	}
 }
 
@@ -95,13 +99,15 @@ var allPathsPattern = lazyregexp.New(".*")
 // GetDocumentRank returns a map from paths within the given repo to their rank vector. Paths are
 // assumed to be ordered by each pairwise component of the resulting vector, higher ranks coming
 // earlier. We currently rank documents by path name length and lexicographic order, while performing
-// a few heuristics to sink generated, test, and vendor files lower in the ranking.
+// a few heuristics to sink generated, test, and vendor files lower in the ranking. Site-defined
+// signals (see schema.DocumentRankingSignals) are ranked after these heuristics.
 //
 // Rank vector index labels:
 //   - precision                   [0 to 1]
 //   - generated                   [0 or 1]
 //   - vendor                      [0 or 1]
 //   - test                        [0 or 1]
+//   - site-defined signals        [0 to 1] (=0.5 w/o configured signals)
 //   - global document rank        [0 to 1] (=0 w/o pagerank)
 //   - name length                 [0 to 1] (=1 w/  pagerank)
 //   - lexicographic order in repo [0 to 1] (=1 w/  pagerank)
@@ -109,70 +115,181 @@ func (s *Service) GetDocumentRanks(ctx context.Context, repoName api.RepoName) (
 	_, _, endObservation := s.operations.getDocumentRanks.With(ctx, &err, observation.Args{})
 	defer endObservation(1, observation.Args{})
 
-	ranks := map[string][]float64{}
-	documentRanks, ok, err := s.store.GetDocumentRanks(ctx, repoName)
+	inputs, err := s.getDocumentRankInputs(ctx, repoName)
 	if err != nil {
 		return nil, err
 	}
-	if ok {
-		for path, rank := range documentRanks {
-			ranks[path] = []float64{
-				rank[0],                             // precision level (0, 1]
-				1 - boolRank(isPathGenerated(path)), // rank generated paths lower
-				1 - boolRank(isPathVendored(path)),  // rank vendored paths lower
-				1 - boolRank(isPathTest(path)),      // rank test paths lower
-				squashRange(rank[1]),                // global document rank
-				1,                                   // name length
-				1,                                   // lexicographic order in repo
-			}
+
+	ranks := make(map[string][]float64, len(inputs.paths)+len(inputs.preciseRanks))
+	for path := range inputs.preciseRanks {
+		ranks[path], _ = inputs.rank(path)
+	}
+	for _, path := range inputs.paths {
+		if _, ok := ranks[path]; !ok {
+			ranks[path], _ = inputs.rank(path)
 		}
 	}
 
-	paths, err := s.gitserverClient.ListFilesForRepo(ctx, repoName, "HEAD", allPathsPattern.Re())
+	return ranks, nil
+}
+
+// ExplainDocumentRank returns the rank vector of the given path within the given repo along with
+// a description of how each of its components was computed. See GetDocumentRanks for the meaning
+// of each component. A nil explanation is returned if the path is not known to the repo.
+func (s *Service) ExplainDocumentRank(ctx context.Context, repoName api.RepoName, path string) (_ *api.DocumentRankExplanation, err error) {
+	_, _, endObservation := s.operations.explainDocumentRank.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	inputs, err := s.getDocumentRankInputs(ctx, repoName)
 	if err != nil {
 		return nil, err
 	}
-	sort.Strings(paths)
 
-	for i, path := range paths {
-		if _, ok := ranks[path]; ok {
-			continue
-		}
+	rank, ok := inputs.rank(path)
+	if !ok {
+		return nil, nil
+	}
 
-		ranks[path] = []float64{
-			0,                                     // imprecise
-			1 - boolRank(isPathGenerated(path)),   // rank generated paths lower
-			1 - boolRank(isPathVendored(path)),    // rank vendored paths lower
-			1 - boolRank(isPathTest(path)),        // rank test paths lower
-			0,                                     // no global document rank
-			1.0 - squashRange(float64(len(path))), // name length (prefer short names)
-			1.0 - float64(i)/float64(len(paths)),  // lexicographic order in repo
-		}
+	return &api.DocumentRankExplanation{
+		Path:       path,
+		Rank:       rank,
+		Components: inputs.explain(path, rank),
+		Signals:    inputs.signals.explain(path),
+	}, nil
+}
+
+// documentRankInputs are the repository-wide values from which document rank vectors are computed.
+type documentRankInputs struct {
+	preciseRanks map[string][2]float64
+	paths        []string
+	signals      *documentSignals
+}
+
+func (s *Service) getDocumentRankInputs(ctx context.Context, repoName api.RepoName) (documentRankInputs, error) {
+	preciseRanks, _, err := s.store.GetDocumentRanks(ctx, repoName)
+	if err != nil {
+		return documentRankInputs{}, err
 	}
 
-	return ranks, nil
+	paths, err := s.gitserverClient.ListFilesForRepo(ctx, repoName, "HEAD", allPathsPattern.Re())
+	if err != nil {
+		return documentRankInputs{}, err
+	}
+	sort.Strings(paths)
+
+	signals, err := s.getDocumentSignals(ctx, repoName, paths)
+	if err != nil {
+		return documentRankInputs{}, err
+	}
+
+	return documentRankInputs{
+		preciseRanks: preciseRanks,
+		paths:        paths,
+		signals:      signals,
+	}, nil
 }
 
-func (s *Service) LastUpdatedAt(ctx context.Context, repoIDs []api.RepoID) (map[api.RepoID]time.Time, error) {
-	return s.store.LastUpdatedAt(ctx, repoIDs)
+// rank returns the rank vector of the given path. A false-valued flag is returned if the path
+// neither has a precise rank nor exists in the repo.
+func (inputs documentRankInputs) rank(path string) ([]float64, bool) {
+	if rank, ok := inputs.preciseRanks[path]; ok {
+		return []float64{
+			rank[0],                                 // precision level (0, 1]
+			1 - boolRank(paths.IsGenerated(path)),   // rank generated paths lower
+			1 - boolRank(paths.IsVendored(path)),    // rank vendored paths lower
+			1 - boolRank(paths.IsTest(path)),        // rank test paths lower
+			signalsRank(inputs.signals.score(path)), // site-defined signals
+			squashRange(rank[1]),                    // global document rank
+			1,                                       // name length
+			1,                                       // lexicographic order in repo
+		}, true
+	}
+
+	i := sort.SearchStrings(inputs.paths, path)
+	if i >= len(inputs.paths) || inputs.paths[i] != path {
+		return nil, false
+	}
+
+	return []float64{
+		0,                                       // imprecise
+		1 - boolRank(paths.IsGenerated(path)),   // rank generated paths lower
+		1 - boolRank(paths.IsVendored(path)),    // rank vendored paths lower
+		1 - boolRank(paths.IsTest(path)),        // rank test paths lower
+		signalsRank(inputs.signals.score(path)), // site-defined signals
+		0,                                       // no global document rank
+		1.0 - squashRange(float64(len(path))),   // name length (prefer short names)
+		1.0 - float64(i)/float64(len(inputs.paths)), // lexicographic order in repo
+	}, true
 }
 
-func (s *Service) UpdatedAfter(ctx context.Context, t time.Time) ([]api.RepoName, error) {
-	return s.store.UpdatedAfter(ctx, t)
+// explain labels each component of the given rank vector of the given path.
+func (inputs documentRankInputs) explain(path string, rank []float64) []api.DocumentRankComponent {
+	preciseRank, precise := inputs.preciseRanks[path]
+
+	precisionReason := "no precise index provides a rank for this path"
+	globalRankReason := "no precise index provides a rank for this path"
+	nameLengthReason := fmt.Sprintf("path has %d characters", len(path))
+	lexicographicReason := fmt.Sprintf("path is at position %d of %d in lexicographic order", sort.SearchStrings(inputs.paths, path), len(inputs.paths))
+	if precise {
+		precisionReason = "path is ranked by a precise index"
+		globalRankReason = fmt.Sprintf("document rank %.6f squashed into [0, 1)", preciseRank[1])
+		nameLengthReason = "not used for paths ranked by a precise index"
+		lexicographicReason = "not used for paths ranked by a precise index"
+	}
+
+	signalsReason := "no site-defined signals apply to this path"
+	if score := inputs.signals.score(path); score != 0 {
+		signalsReason = fmt.Sprintf("weighted sum of site-defined signals is %.6f", score)
+	}
+
+	reasons := []string{
+		precisionReason,
+		heuristicReason(paths.IsGenerated(path), "a generated"),
+		heuristicReason(paths.IsVendored(path), "a vendored"),
+		heuristicReason(paths.IsTest(path), "a test"),
+		signalsReason,
+		globalRankReason,
+		nameLengthReason,
+		lexicographicReason,
+	}
+
+	components := make([]api.DocumentRankComponent, 0, len(rank))
+	for i, value := range rank {
+		components = append(components, api.DocumentRankComponent{
+			Name:   documentRankComponentNames[i],
+			Value:  value,
+			Reason: reasons[i],
+		})
+	}
+
+	return components
 }
 
-func isPathGenerated(path string) bool {
-	return strings.HasSuffix(path, "min.js") || strings.HasSuffix(path, "js.map")
+var documentRankComponentNames = []string{
+	"precision",
+	"generated",
+	"vendor",
+	"test",
+	"signals",
+	"global document rank",
+	"name length",
+	"lexicographic order",
 }
 
-func isPathVendored(path string) bool {
-	return strings.Contains(path, "vendor/") || strings.Contains(path, "node_modules/")
+func heuristicReason(matches bool, kind string) string {
+	if matches {
+		return fmt.Sprintf("path looks like %s file", kind)
+	}
+
+	return fmt.Sprintf("path does not look like %s file", kind)
 }
 
-var testPattern = lazyregexp.New("test")
+func (s *Service) LastUpdatedAt(ctx context.Context, repoIDs []api.RepoID) (map[api.RepoID]time.Time, error) {
+	return s.store.LastUpdatedAt(ctx, repoIDs)
+}
 
-func isPathTest(path string) bool {
-	return testPattern.MatchString(path)
+func (s *Service) UpdatedAfter(ctx context.Context, t time.Time) ([]api.RepoName, error) {
+	return s.store.UpdatedAfter(ctx, t)
 }
 
 // Converts a boolean to a [0, 1] rank (where true is ordered before false).
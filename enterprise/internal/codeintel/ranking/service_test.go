@@ -4,10 +4,14 @@ import (
 	"context"
 	"math"
 	"testing"
+	"time"
 
+	"github.com/derision-test/glock"
 	"github.com/google/go-cmp/cmp"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
@@ -101,24 +105,24 @@ func TestGetDocumentRanks(t *testing.T) {
 
 	expected := map[string][]float64{
 		// Precise
-		"rust/main.rs": {1.00, 1, 1, 1, 0.45652173, 1, 1}, // squashRange(0.84) -> 0.45652173
-		"rust/lib.rs":  {0.75, 1, 1, 1, 0.29577464, 1, 1}, // squashRange(0.42) -> 0.29577464
-		"rust/min.js":  {0.25, 0, 1, 1, 0.19354838, 1, 1}, // squashRange(0.24) -> 0.19354838
+		"rust/main.rs": {1.00, 1, 1, 1, 0.5, 0.45652173, 1, 1}, // squashRange(0.84) -> 0.45652173
+		"rust/lib.rs":  {0.75, 1, 1, 1, 0.5, 0.29577464, 1, 1}, // squashRange(0.42) -> 0.29577464
+		"rust/min.js":  {0.25, 0, 1, 1, 0.5, 0.19354838, 1, 1}, // squashRange(0.24) -> 0.19354838
 
 		// Fallback
-		"code/a.go":           {0, 1, 1, 1, 0, 0.100, 1 - (0.00 / 13.0)},
-		"code/b.go":           {0, 1, 1, 1, 0, 0.100, 1 - (1.00 / 13.0)},
-		"code/c.go":           {0, 1, 1, 1, 0, 0.100, 1 - (2.00 / 13.0)},
-		"code/d.go":           {0, 1, 1, 1, 0, 0.100, 1 - (3.00 / 13.0)},
-		"main.go":             {0, 1, 1, 1, 0, 0.125, 1 - (4.00 / 13.0)},
-		"node_modules/bar.js": {0, 1, 0, 1, 0, 0.050, 1 - (5.00 / 13.0)},
-		"node_modules/baz.js": {0, 1, 0, 1, 0, 0.050, 1 - (6.00 / 13.0)},
-		"node_modules/foo.js": {0, 1, 0, 1, 0, 0.050, 1 - (7.00 / 13.0)},
-		"rendered/web/min.js": {0, 0, 1, 1, 0, 0.050, 1 - (8.00 / 13.0)},
-		"test/a.go":           {0, 1, 1, 0, 0, 0.100, 1 - (9.00 / 13.0)},
-		"test/b.go":           {0, 1, 1, 0, 0, 0.100, 1 - (10.0 / 13.0)},
-		"test/c.go":           {0, 1, 1, 0, 0, 0.100, 1 - (11.0 / 13.0)},
-		"test/d.go":           {0, 1, 1, 0, 0, 0.100, 1 - (12.0 / 13.0)},
+		"code/a.go":           {0, 1, 1, 1, 0.5, 0, 0.100, 1 - (0.00 / 13.0)},
+		"code/b.go":           {0, 1, 1, 1, 0.5, 0, 0.100, 1 - (1.00 / 13.0)},
+		"code/c.go":           {0, 1, 1, 1, 0.5, 0, 0.100, 1 - (2.00 / 13.0)},
+		"code/d.go":           {0, 1, 1, 1, 0.5, 0, 0.100, 1 - (3.00 / 13.0)},
+		"main.go":             {0, 1, 1, 1, 0.5, 0, 0.125, 1 - (4.00 / 13.0)},
+		"node_modules/bar.js": {0, 1, 0, 1, 0.5, 0, 0.050, 1 - (5.00 / 13.0)},
+		"node_modules/baz.js": {0, 1, 0, 1, 0.5, 0, 0.050, 1 - (6.00 / 13.0)},
+		"node_modules/foo.js": {0, 1, 0, 1, 0.5, 0, 0.050, 1 - (7.00 / 13.0)},
+		"rendered/web/min.js": {0, 0, 1, 1, 0.5, 0, 0.050, 1 - (8.00 / 13.0)},
+		"test/a.go":           {0, 1, 1, 0, 0.5, 0, 0.100, 1 - (9.00 / 13.0)},
+		"test/b.go":           {0, 1, 1, 0, 0.5, 0, 0.100, 1 - (10.0 / 13.0)},
+		"test/c.go":           {0, 1, 1, 0, 0.5, 0, 0.100, 1 - (11.0 / 13.0)},
+		"test/d.go":           {0, 1, 1, 0, 0.5, 0, 0.100, 1 - (12.0 / 13.0)},
 	}
 
 	opt := cmp.Comparer(cmpFloat)
@@ -128,6 +132,134 @@ func TestGetDocumentRanks(t *testing.T) {
 	}
 }
 
+func TestGetDocumentRanksWithSignals(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newServiceWithDocumentSignals()
+
+	ranks, err := svc.GetDocumentRanks(ctx, "foo")
+	if err != nil {
+		t.Fatalf("unexpected error getting document ranks: %s", err)
+	}
+
+	expected := map[string]float64{
+		".github/CODEOWNERS": 0.5,                   // no signals
+		"code/a.go":          0.5 + 0.5*(3.5/4.5),   // path + codeowners + recency
+		"code/b.go":          0.5 + 0.5*(1.0/2.0),   // path
+		"main.go":            0.5 - 0.5*(0.75/1.75), // negative path + recency
+	}
+
+	signalRanks := map[string]float64{}
+	for path, rank := range ranks {
+		signalRanks[path] = rank[4]
+	}
+
+	if diff := cmp.Diff(expected, signalRanks, cmp.Comparer(cmpFloat)); diff != "" {
+		t.Errorf("unexpected signal ranks (-want +got):\n%s", diff)
+	}
+}
+
+func TestExplainDocumentRank(t *testing.T) {
+	ctx := context.Background()
+	svc, gitserverClient := newServiceWithDocumentSignals()
+
+	explanation, err := svc.ExplainDocumentRank(ctx, "foo", "code/a.go")
+	if err != nil {
+		t.Fatalf("unexpected error explaining document rank: %s", err)
+	}
+	if explanation == nil {
+		t.Fatalf("expected an explanation")
+	}
+
+	ranks, err := svc.GetDocumentRanks(ctx, "foo")
+	if err != nil {
+		t.Fatalf("unexpected error getting document ranks: %s", err)
+	}
+	if diff := cmp.Diff(ranks["code/a.go"], explanation.Rank, cmp.Comparer(cmpFloat)); diff != "" {
+		t.Errorf("unexpected rank (-want +got):\n%s", diff)
+	}
+
+	var names []string
+	for i, component := range explanation.Components {
+		names = append(names, component.Name)
+
+		if !cmpFloat(component.Value, explanation.Rank[i]) {
+			t.Errorf("unexpected value for component %q. want=%.5f have=%.5f", component.Name, explanation.Rank[i], component.Value)
+		}
+	}
+	if diff := cmp.Diff(documentRankComponentNames, names); diff != "" {
+		t.Errorf("unexpected component names (-want +got):\n%s", diff)
+	}
+
+	expectedSignals := []api.DocumentRankSignal{
+		{Name: "path", Value: 1, Weight: 1, Reason: `path matches pattern "code/**"`},
+		{Name: "recency", Value: 0.5, Weight: 1, Reason: "path was last changed at 2023-02-11T00:00:00Z"},
+		{Name: "codeowners", Value: 1, Weight: 2, Reason: "path is owned according to .github/CODEOWNERS"},
+	}
+	if diff := cmp.Diff(expectedSignals, explanation.Signals); diff != "" {
+		t.Errorf("unexpected signals (-want +got):\n%s", diff)
+	}
+
+	if history := gitserverClient.RawContentsForRepoFunc.History(); len(history) != 2 {
+		t.Errorf("unexpected number of CODEOWNERS reads. want=%d have=%d", 2, len(history))
+	} else if history[0].Arg3 != ".github/CODEOWNERS" {
+		t.Errorf("unexpected CODEOWNERS path. want=%q have=%q", ".github/CODEOWNERS", history[0].Arg3)
+	}
+
+	if history := gitserverClient.CommitLogForRepoFunc.History(); len(history) != 2 {
+		t.Errorf("unexpected number of commit log reads. want=%d have=%d", 2, len(history))
+	} else if after := now.Add(-10 * 24 * time.Hour); !history[0].Arg2.Equal(after) {
+		t.Errorf("unexpected commit log horizon. want=%s have=%s", after, history[0].Arg2)
+	}
+
+	explanation, err = svc.ExplainDocumentRank(ctx, "foo", "missing.go")
+	if err != nil {
+		t.Fatalf("unexpected error explaining document rank: %s", err)
+	}
+	if explanation != nil {
+		t.Errorf("unexpected explanation for unknown path: %v", explanation)
+	}
+}
+
+var now = time.Date(2023, 2, 12, 0, 0, 0, 0, time.UTC)
+
+func newServiceWithDocumentSignals() (*Service, *MockGitserverClient) {
+	mockStore := NewMockStore()
+	gitserverClient := NewMockGitserverClient()
+	mockConfigQuerier := NewMockSiteConfigQuerier()
+	svc := newService(&observation.TestContext, mockStore, nil, gitserverClient, nil, mockConfigQuerier, nil)
+	svc.clock = glock.NewMockClockAt(now)
+
+	mockConfigQuerier.SiteConfigFunc.SetDefaultReturn(schema.SiteConfiguration{
+		ExperimentalFeatures: &schema.ExperimentalFeatures{
+			Ranking: &schema.Ranking{
+				DocumentSignals: &schema.DocumentRankingSignals{
+					PathWeights: []*schema.DocumentRankingPathWeight{
+						{Pattern: "code/**", Weight: 1},
+						{Pattern: "*.go", Weight: -1},
+					},
+					RecencyWeight:       1,
+					RecencyHalfLifeDays: 1,
+					CodeownersWeight:    2,
+				},
+			},
+		},
+	})
+
+	gitserverClient.ListFilesForRepoFunc.SetDefaultReturn([]string{
+		"main.go",
+		"code/b.go",
+		"code/a.go",
+		".github/CODEOWNERS",
+	}, nil)
+	gitserverClient.RawContentsForRepoFunc.SetDefaultReturn([]byte("/code/a.go @alice\n"), nil)
+	gitserverClient.CommitLogForRepoFunc.SetDefaultReturn([]*gitdomain.CommitLog{
+		{ID: "deadbeef", CommitDate: now.Add(-24 * time.Hour), ChangedFiles: []string{"code/a.go"}},
+		{ID: "cafebabe", CommitDate: now.Add(-48 * time.Hour), ChangedFiles: []string{"code/a.go", "main.go"}},
+	}, nil)
+
+	return svc, gitserverClient
+}
+
 const epsilon = 0.00000001
 
 func cmpFloat(x, y float64) bool {
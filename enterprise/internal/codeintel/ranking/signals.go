@@ -0,0 +1,226 @@
+package ranking
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/gobwas/glob"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/own/codeowners"
+	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/proto"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// codeownersPaths are the locations searched for a CODEOWNERS file, in order of precedence.
+var codeownersPaths = []string{
+	".github/CODEOWNERS",
+	".gitlab/CODEOWNERS",
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+}
+
+const defaultRecencyHalfLifeDays = 90
+
+// recencyHorizonHalfLives bounds the history read to compute the recency signal. Paths that were
+// last changed before this many half lives ago have a negligible recency and are treated as unchanged.
+const recencyHorizonHalfLives = 10
+
+// documentSignals holds the site-defined document ranking signals resolved for a single repository.
+type documentSignals struct {
+	config          schema.DocumentRankingSignals
+	pathWeights     []pathWeight
+	codeowners      *codeownerspb.File
+	codeownersPath  string
+	now             time.Time
+	recencyHalfLife time.Duration
+	lastChangedAt   map[string]time.Time
+}
+
+type pathWeight struct {
+	pattern string
+	glob    glob.Glob
+	weight  float64
+}
+
+// getDocumentSignals resolves the site-defined document ranking signals for the given repository. The
+// given paths are the (sorted) files of the repository at HEAD. Repository data is only fetched for
+// signals with a non-zero weight.
+func (s *Service) getDocumentSignals(ctx context.Context, repoName api.RepoName, paths []string) (*documentSignals, error) {
+	signals := &documentSignals{now: s.clock.Now()}
+
+	siteConfig := s.getConf.SiteConfig()
+	if siteConfig.ExperimentalFeatures == nil || siteConfig.ExperimentalFeatures.Ranking == nil || siteConfig.ExperimentalFeatures.Ranking.DocumentSignals == nil {
+		return signals, nil
+	}
+	signals.config = *siteConfig.ExperimentalFeatures.Ranking.DocumentSignals
+
+	for _, pw := range signals.config.PathWeights {
+		if pw == nil || pw.Weight == 0 {
+			continue
+		}
+
+		g, err := glob.Compile(pw.Pattern, '/')
+		if err != nil {
+			s.logger.Warn("Ignoring invalid document ranking path pattern", log.String("pattern", pw.Pattern), log.Error(err))
+			continue
+		}
+
+		signals.pathWeights = append(signals.pathWeights, pathWeight{pattern: pw.Pattern, glob: g, weight: pw.Weight})
+	}
+
+	if signals.config.RecencyWeight != 0 {
+		halfLifeDays := signals.config.RecencyHalfLifeDays
+		if halfLifeDays <= 0 {
+			halfLifeDays = defaultRecencyHalfLifeDays
+		}
+		signals.recencyHalfLife = time.Duration(halfLifeDays) * 24 * time.Hour
+
+		commits, err := s.gitserverClient.CommitLogForRepo(ctx, repoName, signals.now.Add(-recencyHorizonHalfLives*signals.recencyHalfLife))
+		if err != nil {
+			return nil, err
+		}
+
+		// Commits are ordered most recent first, so the first commit changing a path is its last change
+		signals.lastChangedAt = map[string]time.Time{}
+		for _, commit := range commits {
+			for _, path := range commit.ChangedFiles {
+				if _, ok := signals.lastChangedAt[path]; !ok {
+					signals.lastChangedAt[path] = commit.CommitDate
+				}
+			}
+		}
+	}
+
+	if signals.config.CodeownersWeight != 0 {
+		for _, path := range codeownersPaths {
+			if i := sort.SearchStrings(paths, path); i >= len(paths) || paths[i] != path {
+				continue
+			}
+
+			contents, err := s.gitserverClient.RawContentsForRepo(ctx, repoName, "HEAD", path)
+			if err != nil {
+				return nil, err
+			}
+
+			file, err := codeowners.Parse(bytes.NewReader(contents))
+			if err != nil {
+				s.logger.Warn("Ignoring unparseable CODEOWNERS file", log.String("repo", string(repoName)), log.String("path", path), log.Error(err))
+				break
+			}
+
+			signals.codeowners = file
+			signals.codeownersPath = path
+			break
+		}
+	}
+
+	return signals, nil
+}
+
+// score returns the weighted sum of the signals of the given path.
+func (s *documentSignals) score(path string) float64 {
+	score := 0.0
+	for _, pw := range s.pathWeights {
+		if pw.glob.Match(path) {
+			score += pw.weight
+		}
+	}
+
+	if s.config.RecencyWeight != 0 {
+		score += s.recency(path) * s.config.RecencyWeight
+	}
+
+	if s.config.CodeownersWeight != 0 && s.isOwned(path) {
+		score += s.config.CodeownersWeight
+	}
+
+	return score
+}
+
+// explain returns the contribution of each signal with a non-zero weight to the score of the given path.
+func (s *documentSignals) explain(path string) []api.DocumentRankSignal {
+	var signals []api.DocumentRankSignal
+
+	for _, pw := range s.pathWeights {
+		if pw.glob.Match(path) {
+			signals = append(signals, api.DocumentRankSignal{
+				Name:   "path",
+				Value:  1,
+				Weight: pw.weight,
+				Reason: fmt.Sprintf("path matches pattern %q", pw.pattern),
+			})
+		}
+	}
+
+	if s.config.RecencyWeight != 0 {
+		reason := fmt.Sprintf("path was not changed in the last %d days", int(recencyHorizonHalfLives*s.recencyHalfLife/(24*time.Hour)))
+		if lastChangedAt, ok := s.lastChangedAt[path]; ok {
+			reason = fmt.Sprintf("path was last changed at %s", lastChangedAt.UTC().Format(time.RFC3339))
+		}
+
+		signals = append(signals, api.DocumentRankSignal{
+			Name:   "recency",
+			Value:  s.recency(path),
+			Weight: s.config.RecencyWeight,
+			Reason: reason,
+		})
+	}
+
+	if s.config.CodeownersWeight != 0 {
+		value, reason := 0.0, "repository has no CODEOWNERS file"
+		if s.codeowners != nil {
+			if s.isOwned(path) {
+				value, reason = 1, fmt.Sprintf("path is owned according to %s", s.codeownersPath)
+			} else {
+				reason = fmt.Sprintf("path has no owners according to %s", s.codeownersPath)
+			}
+		}
+
+		signals = append(signals, api.DocumentRankSignal{
+			Name:   "codeowners",
+			Value:  value,
+			Weight: s.config.CodeownersWeight,
+			Reason: reason,
+		})
+	}
+
+	return signals
+}
+
+// recency returns the recency of the last change to the given path, or zero if the path was not
+// changed within the recency horizon.
+func (s *documentSignals) recency(path string) float64 {
+	lastChangedAt, ok := s.lastChangedAt[path]
+	if !ok {
+		return 0
+	}
+
+	return recencyRank(s.now.Sub(lastChangedAt), s.recencyHalfLife)
+}
+
+// isOwned returns true if the given path has an owner according to the CODEOWNERS file of the repository.
+func (s *documentSignals) isOwned(path string) bool {
+	return s.codeowners != nil && len(s.codeowners.FindOwners(path)) > 0
+}
+
+// recencyRank maps the age of a commit to a value in the range (0, 1] that halves every
+// half life.
+func recencyRank(age, halfLife time.Duration) float64 {
+	if age < 0 {
+		age = 0
+	}
+
+	return math.Pow(0.5, float64(age)/float64(halfLife))
+}
+
+// signalsRank maps a (possibly negative) signal score to a value in the range (0, 1)
+// monotonically, where a zero score maps to 0.5.
+func signalsRank(score float64) float64 {
+	return 0.5 + 0.5*score/(1+math.Abs(score))
+}
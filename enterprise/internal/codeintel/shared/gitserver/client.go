@@ -205,6 +205,12 @@ func (c *Client) CommitDate(ctx context.Context, repositoryID int, commit string
 	return "", time.Time{}, false, errors.Wrap(err, "git.CommitDate")
 }
 
+// CommitLogForRepo returns the non-merge commits of the given repository reachable from HEAD that were
+// committed after the given time, most recent first, along with the paths changed by each commit.
+func (c *Client) CommitLogForRepo(ctx context.Context, repo api.RepoName, after time.Time) ([]*gitdomain.CommitLog, error) {
+	return c.gitserverClient.CommitLog(ctx, repo, after)
+}
+
 // CommitGraph returns the commit graph for the given repository as a mapping from a commit
 // to its parents. If a commit is supplied, the returned graph will be rooted at the given
 // commit. If a non-zero limit is supplied, at most that many commits will be returned.
@@ -332,6 +338,11 @@ func (c *Client) RawContents(ctx context.Context, repositoryID int, commit, file
 		return nil, err
 	}
 
+	return c.RawContentsForRepo(ctx, repo, commit, file)
+}
+
+// RawContentsForRepo returns the contents of a file in a particular commit of the given repository.
+func (c *Client) RawContentsForRepo(ctx context.Context, repo api.RepoName, commit, file string) (_ []byte, err error) {
 	out, err := c.gitserverClient.ReadFile(ctx, authz.DefaultSubRepoPermsChecker, repo, api.CommitID(commit), file)
 	if err == nil {
 		return out, nil
@@ -0,0 +1,25 @@
+// Package paths provides heuristics that classify the files of a repository by their path.
+package paths
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+)
+
+// IsGenerated returns true if the given path looks like a generated file.
+func IsGenerated(path string) bool {
+	return strings.HasSuffix(path, "min.js") || strings.HasSuffix(path, "js.map")
+}
+
+// IsVendored returns true if the given path looks like a vendored file.
+func IsVendored(path string) bool {
+	return strings.Contains(path, "vendor/") || strings.Contains(path, "node_modules/")
+}
+
+var testPattern = lazyregexp.New("test")
+
+// IsTest returns true if the given path looks like a test file.
+func IsTest(path string) bool {
+	return testPattern.MatchString(path)
+}
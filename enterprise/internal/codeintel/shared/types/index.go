@@ -33,6 +33,7 @@ type Index struct {
 	AssociatedUploadID *int                         `json:"associatedUpload"`
 	ShouldReindex      bool                         `json:"shouldReindex"`
 	RequestedEnvVars   []string                     `json:"requestedEnvVars"`
+	Caches             []IndexCache                 `json:"caches"`
 }
 
 func (i Index) RecordID() int {
@@ -57,3 +58,25 @@ func (s *DockerStep) Scan(value any) error {
 func (s DockerStep) Value() (driver.Value, error) {
 	return json.Marshal(s)
 }
+
+// IndexCache declares directories relative to the index root that are restored before
+// the index job runs, and saved after it succeeded. The content of the key files is
+// hashed into the cache key.
+type IndexCache struct {
+	Key      string   `json:"key"`
+	KeyFiles []string `json:"key_files"`
+	Paths    []string `json:"paths"`
+}
+
+func (c *IndexCache) Scan(value any) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.Errorf("value is not []byte: %T", value)
+	}
+
+	return json.Unmarshal(b, &c)
+}
+
+func (c IndexCache) Value() (driver.Value, error) {
+	return json.Marshal(c)
+}
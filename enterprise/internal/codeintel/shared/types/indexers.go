@@ -1,5 +1,7 @@
 package types
 
+import "strings"
+
 type CodeIntelIndexer struct {
 	Name string
 	URN  string
@@ -82,6 +84,10 @@ var (
 		Name: "scip-ruby",
 		URN:  "github.com/sourcegraph/scip-ruby",
 	}
+	scipDotnet = CodeIntelIndexer{
+		Name: "scip-dotnet",
+		URN:  "github.com/sourcegraph/scip-dotnet",
+	}
 )
 
 var AllIndexers = []CodeIntelIndexer{
@@ -104,6 +110,7 @@ var AllIndexers = []CodeIntelIndexer{
 	lsifTerraform,
 	lsifDotnet,
 	scipRuby,
+	scipDotnet,
 }
 
 // A map of file extension to a list of indexers in order of recommendation
@@ -131,7 +138,7 @@ var LanguageToIndexer = map[string][]CodeIntelIndexer{
 	".rs":      {rustAnalyzer},
 	".php":     {lsifPHP},
 	".tf":      {lsifTerraform},
-	".cs":      {lsifDotnet},
+	".cs":      {scipDotnet, lsifDotnet},
 	".rb":      {scipRuby},
 }
 
@@ -146,6 +153,7 @@ var ImageToIndexer = map[string]CodeIntelIndexer{
 	"sourcegraph/scip-rust":       rustAnalyzer,
 	"sourcegraph/scip-python":     scipPython,
 	"sourcegraph/scip-ruby":       scipRuby,
+	"sourcegraph/scip-dotnet":     scipDotnet,
 }
 
 var PreferredIndexers = map[string]CodeIntelIndexer{
@@ -169,4 +177,52 @@ var PreferredIndexers = map[string]CodeIntelIndexer{
 	"lsif-terraform":  lsifTerraform,
 	"lsif-dotnet":     lsifDotnet,
 	"scip-ruby":       scipRuby,
+	"scip-dotnet":     scipDotnet,
+}
+
+// indexerLanguages maps the URN of each known indexer to the name of the language it indexes.
+var indexerLanguages = map[string]string{
+	lsifNode.URN:       "TypeScript",
+	msftNode.URN:       "TypeScript",
+	scipTypescript.URN: "TypeScript",
+	scipJava.URN:       "Java",
+	msftJava.URN:       "Java",
+	lsifGo.URN:         "Go",
+	lsifClang.URN:      "C++",
+	lsifCPP.URN:        "C++",
+	lsifDart.URN:       "Dart",
+	workivaDart.URN:    "Dart",
+	hieLSIF.URN:        "Haskell",
+	lsifJsonnet.URN:    "Jsonnet",
+	lsifOcaml.URN:      "OCaml",
+	scipPython.URN:     "Python",
+	rustAnalyzer.URN:   "Rust",
+	lsifPHP.URN:        "PHP",
+	lsifTerraform.URN:  "Terraform",
+	lsifDotnet.URN:     "C#",
+	scipRuby.URN:       "Ruby",
+	scipDotnet.URN:     "C#",
+}
+
+// IndexerLanguage returns the name of the language indexed by the given indexer, which is either
+// the name of an indexer tool (e.g., lsif-go) as reported by uploads, or a (possibly tagged) indexer
+// image (e.g., sourcegraph/lsif-go:latest) as used by index jobs. An empty string is returned if the
+// indexer is not known.
+func IndexerLanguage(indexer string) string {
+	// Drop the digest and tag of indexer images
+	name := strings.Split(strings.Split(indexer, "@")[0], ":")[0]
+
+	if idx, ok := ImageToIndexer[name]; ok {
+		return indexerLanguages[idx.URN]
+	}
+	if idx, ok := PreferredIndexers[name]; ok {
+		return indexerLanguages[idx.URN]
+	}
+	for _, idx := range AllIndexers {
+		if idx.Name == name {
+			return indexerLanguages[idx.URN]
+		}
+	}
+
+	return ""
 }
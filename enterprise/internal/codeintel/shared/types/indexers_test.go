@@ -0,0 +1,22 @@
+package types
+
+import "testing"
+
+func TestIndexerLanguage(t *testing.T) {
+	testCases := map[string]string{
+		"lsif-go":                           "Go",
+		"scip-typescript":                   "TypeScript",
+		"sourcegraph/scip-java":             "Java",
+		"sourcegraph/scip-python:autoindex": "Python",
+		"sourcegraph/lsif-go@sha256:253c991fdd8b118afadcfbef6d7b6b0fe8d8e4b4a4a1a0b1e8f4e0f1a3c8b5d7": "Go",
+		"msft/lsif-node":   "TypeScript",
+		"unknown-indexer":  "",
+		"sourcegraph/nope": "",
+	}
+
+	for indexer, expected := range testCases {
+		if language := IndexerLanguage(indexer); language != expected {
+			t.Errorf("unexpected language for %q: want=%q got=%q", indexer, expected, language)
+		}
+	}
+}
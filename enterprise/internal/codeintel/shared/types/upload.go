@@ -27,6 +27,8 @@ type Upload struct {
 	AssociatedIndexID *int
 	ContentType       string
 	ShouldReindex     bool
+	Ephemeral         bool
+	BaseUploadID      *int
 }
 
 func (u Upload) RecordID() int {
@@ -80,6 +80,7 @@ type expirationConfig struct {
 	env.BaseConfig
 
 	CommitBatchSize        int
+	EphemeralUploadMaxAge  time.Duration
 	ExpirerInterval        time.Duration
 	PolicyBatchSize        int
 	RepositoryBatchSize    int
@@ -99,6 +100,7 @@ func (c *expirationConfig) Load() {
 	uploadProcessDelay := env.ChooseFallbackVariableName("CODEINTEL_UPLOAD_EXPIRER_UPLOAD_PROCESS_DELAY", "PRECISE_CODE_INTEL_RETENTION_UPLOAD_PROCESS_DELAY")
 
 	c.CommitBatchSize = c.GetInt(commitBatchSize, "100", "The number of commits to process per upload at a time.")
+	c.EphemeralUploadMaxAge = c.GetInterval("CODEINTEL_UPLOAD_EXPIRER_EPHEMERAL_UPLOAD_MAX_AGE", "72h", "The time after which ephemeral uploads (e.g. uploads for the head commit of a pull request) are expired.")
 	c.ExpirerInterval = c.GetInterval("CODEINTEL_UPLOAD_EXPIRER_INTERVAL", "1s", "How frequently to run the upload expirer routine.")
 	c.PolicyBatchSize = c.GetInt(policyBatchSize, "100", "The number of policies to consider for expiration at a time.")
 	c.RepositoryBatchSize = c.GetInt(repositoryBatchSize, "100", "The number of repositories to consider for expiration at a time.")
@@ -120,3 +122,35 @@ func (c *exportConfig) Load() {
 	c.RankingInterval = c.GetInterval("CODEINTEL_UPLOADS_RANKING_INTERVAL", "1s", "How frequently to serialize a batch of the code intel graph for ranking.")
 	c.NumRankingRoutines = c.GetInt("CODEINTEL_UPLOADS_RANKING_NUM_ROUTINES", "4", "The number of concurrent ranking graph serializer routines to run per worker instance.")
 }
+
+type unreferencedSymbolsConfig struct {
+	env.BaseConfig
+
+	Interval               time.Duration
+	RepositoryProcessDelay time.Duration
+	RepositoryBatchSize    int
+}
+
+var ConfigUnreferencedSymbolsInst = &unreferencedSymbolsConfig{}
+
+func (c *unreferencedSymbolsConfig) Load() {
+	c.Interval = c.GetInterval("CODEINTEL_UPLOADS_UNREFERENCED_SYMBOLS_INTERVAL", "1m", "How frequently to compute unreferenced symbols reports.")
+	c.RepositoryProcessDelay = c.GetInterval("CODEINTEL_UPLOADS_UNREFERENCED_SYMBOLS_REPOSITORY_PROCESS_DELAY", "24h", "The minimum frequency that the same repository's unreferenced symbols report can be recomputed.")
+	c.RepositoryBatchSize = c.GetInt("CODEINTEL_UPLOADS_UNREFERENCED_SYMBOLS_REPOSITORY_BATCH_SIZE", "10", "The number of repositories whose unreferenced symbols report is computed at a time.")
+}
+
+type codeIntelCoverageConfig struct {
+	env.BaseConfig
+
+	Interval               time.Duration
+	RepositoryProcessDelay time.Duration
+	RepositoryBatchSize    int
+}
+
+var ConfigCodeIntelCoverageInst = &codeIntelCoverageConfig{}
+
+func (c *codeIntelCoverageConfig) Load() {
+	c.Interval = c.GetInterval("CODEINTEL_UPLOADS_COVERAGE_INTERVAL", "1m", "How frequently to compute code intel coverage reports.")
+	c.RepositoryProcessDelay = c.GetInterval("CODEINTEL_UPLOADS_COVERAGE_REPOSITORY_PROCESS_DELAY", "1h", "The minimum frequency that the same repository's code intel coverage report can be recomputed.")
+	c.RepositoryBatchSize = c.GetInt("CODEINTEL_UPLOADS_COVERAGE_REPOSITORY_BATCH_SIZE", "50", "The number of repositories whose code intel coverage report is computed at a time.")
+}
@@ -0,0 +1,183 @@
+package uploads
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/internal/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// ComputeCodeIntelCoverage recomputes the coverage report of a batch of repositories whose report is
+// missing or older than the given process delay. The report describes, for each language indexed in
+// the repository, the uploads visible at the tip of the default branch, how far the closest of those
+// uploads is behind the tip, and the most recent failure to upload or auto-index the language. Errors
+// computing the report of a single repository are logged and do not fail the batch.
+func (s *Service) ComputeCodeIntelCoverage(ctx context.Context, processDelay time.Duration, repositoryBatchSize int) (err error) {
+	ctx, _, endObservation := s.operations.computeCodeIntelCoverage.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	now := s.clock.Now()
+
+	repositoryIDs, err := s.store.GetRepositoriesForCoverageReport(ctx, processDelay, repositoryBatchSize, now)
+	if err != nil {
+		return err
+	}
+
+	for _, repositoryID := range repositoryIDs {
+		// A failure to compute the report of one repository should not block the reports of
+		// the remaining repositories. The report will be recomputed on a later pass.
+		if err := s.computeRepositoryCodeIntelCoverage(ctx, repositoryID, now); err != nil {
+			s.logger.Error(
+				"Failed to compute code intel coverage report",
+				log.Int("repositoryID", repositoryID),
+				log.Error(err),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) computeRepositoryCodeIntelCoverage(ctx context.Context, repositoryID int, now time.Time) error {
+	// The distance of uploads from the tip is only known if we can resolve the tip. We still
+	// record the visible uploads and failures of repositories that can't be resolved (e.g.,
+	// ones that are not yet cloned), but leave the distance unknown.
+	headCommit, ok, err := s.gitserverClient.Head(ctx, repositoryID)
+	if err != nil {
+		s.logger.Warn(
+			"Failed to resolve head commit for coverage report",
+			log.Int("repositoryID", repositoryID),
+			log.Error(err),
+		)
+	}
+	if err != nil || !ok {
+		headCommit = ""
+	}
+
+	uploads, err := s.store.GetCoverageUploadsAtTip(ctx, repositoryID, headCommit)
+	if err != nil {
+		return err
+	}
+
+	failures, err := s.store.GetCoverageFailures(ctx, repositoryID)
+	if err != nil {
+		return err
+	}
+
+	coverage := buildCodeIntelCoverage(uploads, failures)
+
+	if err := s.store.UpdateCodeIntelCoverage(ctx, repositoryID, headCommit, coverage, now); err != nil {
+		return err
+	}
+
+	s.logger.Info(
+		"Computed code intel coverage report",
+		log.Int("repositoryID", repositoryID),
+		log.Int("numLanguages", len(coverage)),
+	)
+
+	return nil
+}
+
+// buildCodeIntelCoverage groups the given uploads and failures by the language of their indexer.
+// Indexers of an unknown language are reported under the name of the indexer.
+func buildCodeIntelCoverage(uploads []store.CoverageUpload, failures []store.CoverageFailure) []shared.CodeIntelCoverage {
+	coverageByLanguage := map[string]*shared.CodeIntelCoverage{}
+	indexersByLanguage := map[string]map[string]struct{}{}
+
+	get := func(indexer string) *shared.CodeIntelCoverage {
+		name := coverageIndexerName(indexer)
+		language := types.IndexerLanguage(name)
+		if language == "" {
+			language = name
+		}
+
+		if _, ok := coverageByLanguage[language]; !ok {
+			coverageByLanguage[language] = &shared.CodeIntelCoverage{Language: language}
+			indexersByLanguage[language] = map[string]struct{}{}
+		}
+		indexersByLanguage[language][name] = struct{}{}
+
+		return coverageByLanguage[language]
+	}
+
+	for _, upload := range uploads {
+		upload := upload
+		coverage := get(upload.Indexer)
+		coverage.NumUploads++
+
+		if coverage.LastUploadedAt == nil || upload.UploadedAt.After(*coverage.LastUploadedAt) {
+			coverage.LastUploadedAt = &upload.UploadedAt
+		}
+
+		if coverage.UploadID == nil || isCloserToTip(upload.CommitsBehind, coverage.CommitsBehind) {
+			coverage.UploadID = &upload.ID
+			coverage.CommitsBehind = upload.CommitsBehind
+		}
+	}
+
+	for _, failure := range failures {
+		failure := failure
+		coverage := get(failure.Indexer)
+
+		if coverage.LastFailureAt == nil || failure.FailedAt.After(*coverage.LastFailureAt) {
+			coverage.LastFailureAt = &failure.FailedAt
+			coverage.LastFailureReason = &failure.Reason
+		}
+	}
+
+	languages := make([]string, 0, len(coverageByLanguage))
+	for language := range coverageByLanguage {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+
+	coverage := make([]shared.CodeIntelCoverage, 0, len(languages))
+	for _, language := range languages {
+		indexers := make([]string, 0, len(indexersByLanguage[language]))
+		for indexer := range indexersByLanguage[language] {
+			indexers = append(indexers, indexer)
+		}
+		sort.Strings(indexers)
+
+		c := *coverageByLanguage[language]
+		c.Indexers = indexers
+		coverage = append(coverage, c)
+	}
+
+	return coverage
+}
+
+// isCloserToTip returns true if an upload the given number of commits behind the tip is
+// closer to the tip than an upload the other number of commits behind. Unknown distances
+// are considered further than any known distance.
+func isCloserToTip(commitsBehind, otherCommitsBehind *int) bool {
+	if commitsBehind == nil {
+		return false
+	}
+
+	return otherCommitsBehind == nil || *commitsBehind < *otherCommitsBehind
+}
+
+// coverageIndexerName drops the digest of indexer images used by auto-indexing jobs.
+func coverageIndexerName(indexer string) string {
+	return strings.Split(indexer, "@")[0]
+}
+
+func (s *Service) GetCodeIntelCoverage(ctx context.Context, opts shared.GetCodeIntelCoverageOptions) (_ []shared.CodeIntelCoverage, _ int, err error) {
+	ctx, _, endObservation := s.operations.getCodeIntelCoverage.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.String("term", opts.Term),
+		otlog.String("language", opts.Language),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.store.GetCodeIntelCoverage(ctx, opts)
+}
@@ -0,0 +1,130 @@
+package uploads
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/internal/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestComputeCodeIntelCoverage(t *testing.T) {
+	mockStore := NewMockStore()
+	mockGitserverClient := NewMockGitserverClient()
+	svc := newService(&observation.TestContext, mockStore, nil, nil, mockGitserverClient, nil, nil, nil, nil)
+
+	t1 := time.Unix(1587396557, 0).UTC()
+	t2 := t1.Add(time.Hour)
+	t3 := t2.Add(time.Hour)
+
+	mockStore.GetRepositoriesForCoverageReportFunc.SetDefaultReturn([]int{50, 52, 51}, nil)
+	mockGitserverClient.HeadFunc.SetDefaultHook(func(ctx context.Context, repositoryID int) (string, bool, error) {
+		if repositoryID == 51 {
+			return "", false, errors.New("repository not cloned")
+		}
+		return "deadbeef", true, nil
+	})
+	mockStore.GetCoverageUploadsAtTipFunc.SetDefaultHook(func(ctx context.Context, repositoryID int, commit string) ([]store.CoverageUpload, error) {
+		if repositoryID == 51 {
+			return []store.CoverageUpload{{ID: 200, Indexer: "scip-python", UploadedAt: t1}}, nil
+		}
+
+		return []store.CoverageUpload{
+			{ID: 100, Indexer: "lsif-go", UploadedAt: t1, CommitsBehind: intPtr(10)},
+			{ID: 101, Indexer: "lsif-go", UploadedAt: t2, CommitsBehind: intPtr(3)},
+			{ID: 102, Indexer: "lsif-go", UploadedAt: t3},
+			{ID: 103, Indexer: "custom-indexer", UploadedAt: t1, CommitsBehind: intPtr(0)},
+		}, nil
+	})
+	mockStore.GetCoverageFailuresFunc.SetDefaultHook(func(ctx context.Context, repositoryID int) ([]store.CoverageFailure, error) {
+		if repositoryID == 51 {
+			return nil, nil
+		}
+		if repositoryID == 52 {
+			return nil, errors.New("uh-oh")
+		}
+
+		return []store.CoverageFailure{
+			{Indexer: "sourcegraph/lsif-go@sha256:123456", FailedAt: t2, Reason: "go mod download failed"},
+			{Indexer: "lsif-go", FailedAt: t1, Reason: "corrupt upload"},
+			{Indexer: "sourcegraph/scip-java", FailedAt: t3, Reason: "no build tool"},
+		}, nil
+	})
+
+	if err := svc.ComputeCodeIntelCoverage(context.Background(), time.Hour, 10); err != nil {
+		t.Fatalf("unexpected error computing coverage: %s", err)
+	}
+
+	if calls := mockStore.GetCoverageUploadsAtTipFunc.History(); len(calls) != 3 {
+		t.Fatalf("unexpected number of GetCoverageUploadsAtTip calls: want=%d got=%d", 3, len(calls))
+	} else if calls[0].Arg2 != "deadbeef" || calls[2].Arg2 != "" {
+		t.Errorf("unexpected commits: want=%q got=%q", []string{"deadbeef", ""}, []string{calls[0].Arg2, calls[2].Arg2})
+	}
+
+	// The report of repository 52 fails without affecting the others
+	calls := mockStore.UpdateCodeIntelCoverageFunc.History()
+	if len(calls) != 2 {
+		t.Fatalf("unexpected number of UpdateCodeIntelCoverage calls: want=%d got=%d", 2, len(calls))
+	}
+
+	goFailure := "go mod download failed"
+	javaFailure := "no build tool"
+	expectedCoverage := []shared.CodeIntelCoverage{
+		{
+			Language:          "Go",
+			Indexers:          []string{"lsif-go", "sourcegraph/lsif-go"},
+			NumUploads:        3,
+			UploadID:          intPtr(101),
+			CommitsBehind:     intPtr(3),
+			LastUploadedAt:    &t3,
+			LastFailureAt:     &t2,
+			LastFailureReason: &goFailure,
+		},
+		{
+			Language:          "Java",
+			Indexers:          []string{"sourcegraph/scip-java"},
+			LastFailureAt:     &t3,
+			LastFailureReason: &javaFailure,
+		},
+		{
+			Language:       "custom-indexer",
+			Indexers:       []string{"custom-indexer"},
+			NumUploads:     1,
+			UploadID:       intPtr(103),
+			CommitsBehind:  intPtr(0),
+			LastUploadedAt: &t1,
+		},
+	}
+	if calls[0].Arg1 != 50 || calls[0].Arg2 != "deadbeef" {
+		t.Errorf("unexpected repository and commit: want=%d/%q got=%d/%q", 50, "deadbeef", calls[0].Arg1, calls[0].Arg2)
+	}
+	if diff := cmp.Diff(expectedCoverage, calls[0].Arg3); diff != "" {
+		t.Errorf("unexpected coverage (-want +got):\n%s", diff)
+	}
+
+	// The distance of uploads in repositories without a resolvable head is unknown
+	expectedCoverage = []shared.CodeIntelCoverage{
+		{
+			Language:       "Python",
+			Indexers:       []string{"scip-python"},
+			NumUploads:     1,
+			UploadID:       intPtr(200),
+			LastUploadedAt: &t1,
+		},
+	}
+	if calls[1].Arg1 != 51 || calls[1].Arg2 != "" {
+		t.Errorf("unexpected repository and commit: want=%d/%q got=%d/%q", 51, "", calls[1].Arg1, calls[1].Arg2)
+	}
+	if diff := cmp.Diff(expectedCoverage, calls[1].Arg3); diff != "" {
+		t.Errorf("unexpected coverage (-want +got):\n%s", diff)
+	}
+}
+
+func intPtr(v int) *int {
+	return &v
+}
@@ -0,0 +1,90 @@
+package uploads
+
+import (
+	"context"
+	"io"
+
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// The field numbers of the scip.Index message.
+const (
+	scipIndexMetadataFieldNumber  = 1
+	scipIndexDocumentsFieldNumber = 2
+)
+
+// ExportSCIPIndex writes the processed SCIP data of the given upload to the given writer as a
+// serialized scip.Index message. Documents are written one at a time as they are read from the
+// codeintel database, so the index is never held in memory as a whole.
+//
+// Symbol information of external symbols was denormalized into the documents referencing them
+// during processing, so the exported index does not contain any external symbols.
+//
+// If the upload does not exist, is not visible to the current user, or has not been processed
+// into SCIP data, false is returned and nothing is written. Documents the current user can't
+// read due to sub-repo permissions are omitted from the index.
+func (s *Service) ExportSCIPIndex(ctx context.Context, checker authz.SubRepoPermissionChecker, uploadID int, w io.Writer) (_ bool, err error) {
+	ctx, _, endObservation := s.operations.exportSCIPIndex.With(ctx, &err, observation.Args{LogFields: []log.Field{log.Int("uploadID", uploadID)}})
+	defer endObservation(1, observation.Args{})
+
+	// 🚨 SECURITY: The store only returns uploads of repositories the current user can access.
+	upload, ok, err := s.store.GetUploadByID(ctx, uploadID)
+	if err != nil || !ok || upload.State != "completed" {
+		return false, err
+	}
+
+	metadata, ok, err := s.lsifstore.GetSCIPMetadata(ctx, uploadID)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	if err := writeIndexField(w, scipIndexMetadataFieldNumber, &scip.Metadata{
+		Version: scip.ProtocolVersion(metadata.ProtocolVersion),
+		ToolInfo: &scip.ToolInfo{
+			Name:      metadata.ToolName,
+			Version:   metadata.ToolVersion,
+			Arguments: metadata.ToolArguments,
+		},
+		TextDocumentEncoding: scip.TextEncoding(scip.TextEncoding_value[metadata.TextDocumentEncoding]),
+	}); err != nil {
+		return true, err
+	}
+
+	// A serialized message is a sequence of fields, so appending each document as a separate
+	// field yields the same index as serializing all documents at once.
+	a := actor.FromContext(ctx)
+	return true, s.lsifstore.ScanDocuments(ctx, uploadID, func(path string, document *scip.Document) error {
+		// 🚨 SECURITY: Omit documents the current user can't read due to sub-repo permissions.
+		if include, err := authz.FilterActorPath(ctx, checker, a, api.RepoName(upload.RepositoryName), path); err != nil {
+			return err
+		} else if !include {
+			return nil
+		}
+
+		// The relative path is stored outside of the document payload during processing.
+		document.RelativePath = path
+
+		return writeIndexField(w, scipIndexDocumentsFieldNumber, document)
+	})
+}
+
+// writeIndexField writes the given message as a length-delimited field of a scip.Index message.
+func writeIndexField(w io.Writer, fieldNumber protowire.Number, message proto.Message) error {
+	payload, err := proto.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	buf := protowire.AppendTag(nil, fieldNumber, protowire.BytesType)
+	buf = protowire.AppendBytes(buf, payload)
+	_, err = w.Write(buf)
+	return err
+}
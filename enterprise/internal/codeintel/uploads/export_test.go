@@ -0,0 +1,155 @@
+package uploads
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/internal/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestExportSCIPIndex(t *testing.T) {
+	mockStore := NewMockStore()
+	mockLsifStore := NewMockLsifStore()
+	svc := newService(&observation.TestContext, mockStore, nil, mockLsifStore, nil, nil, nil, nil, nil)
+
+	mockStore.GetUploadByIDFunc.SetDefaultReturn(types.Upload{ID: 42, State: "completed"}, true, nil)
+	mockLsifStore.GetSCIPMetadataFunc.SetDefaultReturn(lsifstore.ProcessedMetadata{
+		TextDocumentEncoding: "UTF8",
+		ToolName:             "scip-go",
+		ToolVersion:          "0.1.0",
+		ToolArguments:        []string{"--module-version", "v1"},
+		ProtocolVersion:      0,
+	}, true, nil)
+	mockLsifStore.ScanDocumentsFunc.SetDefaultHook(func(ctx context.Context, id int, f func(path string, document *scip.Document) error) error {
+		for _, path := range []string{"cmd/main.go", "lib/lib.go"} {
+			if err := f(path, &scip.Document{
+				Occurrences: []*scip.Occurrence{{Range: []int32{1, 2, 3}, Symbol: "scip-go gomod example v1 `" + path + "`/"}},
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	var buf bytes.Buffer
+	exists, err := svc.ExportSCIPIndex(context.Background(), nil, 42, &buf)
+	if err != nil {
+		t.Fatalf("unexpected error exporting index: %s", err)
+	}
+	if !exists {
+		t.Fatalf("expected index to exist")
+	}
+
+	var index scip.Index
+	if err := proto.Unmarshal(buf.Bytes(), &index); err != nil {
+		t.Fatalf("unexpected error unmarshalling index: %s", err)
+	}
+
+	expectedIndex := &scip.Index{
+		Metadata: &scip.Metadata{
+			ToolInfo: &scip.ToolInfo{
+				Name:      "scip-go",
+				Version:   "0.1.0",
+				Arguments: []string{"--module-version", "v1"},
+			},
+			TextDocumentEncoding: scip.TextEncoding_UTF8,
+		},
+		Documents: []*scip.Document{
+			{
+				RelativePath: "cmd/main.go",
+				Occurrences:  []*scip.Occurrence{{Range: []int32{1, 2, 3}, Symbol: "scip-go gomod example v1 `cmd/main.go`/"}},
+			},
+			{
+				RelativePath: "lib/lib.go",
+				Occurrences:  []*scip.Occurrence{{Range: []int32{1, 2, 3}, Symbol: "scip-go gomod example v1 `lib/lib.go`/"}},
+			},
+		},
+	}
+	if !proto.Equal(expectedIndex, &index) {
+		t.Errorf("unexpected index. want=%v have=%v", expectedIndex, &index)
+	}
+}
+
+func TestExportSCIPIndexSubRepoPermissions(t *testing.T) {
+	mockStore := NewMockStore()
+	mockLsifStore := NewMockLsifStore()
+	svc := newService(&observation.TestContext, mockStore, nil, mockLsifStore, nil, nil, nil, nil, nil)
+
+	mockStore.GetUploadByIDFunc.SetDefaultReturn(types.Upload{ID: 42, State: "completed", RepositoryName: "github.com/foo/bar"}, true, nil)
+	mockLsifStore.GetSCIPMetadataFunc.SetDefaultReturn(lsifstore.ProcessedMetadata{TextDocumentEncoding: "UTF8", ToolName: "scip-go"}, true, nil)
+	mockLsifStore.ScanDocumentsFunc.SetDefaultHook(func(ctx context.Context, id int, f func(path string, document *scip.Document) error) error {
+		for _, path := range []string{"cmd/main.go", "secret/secret.go"} {
+			if err := f(path, &scip.Document{}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	checker := authz.NewMockSubRepoPermissionChecker()
+	checker.EnabledFunc.SetDefaultReturn(true)
+	checker.PermissionsFunc.SetDefaultHook(func(ctx context.Context, userID int32, content authz.RepoContent) (authz.Perms, error) {
+		if content.Repo != "github.com/foo/bar" {
+			t.Errorf("unexpected repository %q", content.Repo)
+		}
+		if content.Path == "secret/secret.go" {
+			return authz.None, nil
+		}
+		return authz.Read, nil
+	})
+
+	var buf bytes.Buffer
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	if exists, err := svc.ExportSCIPIndex(ctx, checker, 42, &buf); err != nil {
+		t.Fatalf("unexpected error exporting index: %s", err)
+	} else if !exists {
+		t.Fatalf("expected index to exist")
+	}
+
+	var index scip.Index
+	if err := proto.Unmarshal(buf.Bytes(), &index); err != nil {
+		t.Fatalf("unexpected error unmarshalling index: %s", err)
+	}
+	if len(index.Documents) != 1 || index.Documents[0].RelativePath != "cmd/main.go" {
+		t.Errorf("unexpected documents. want=%q have=%v", []string{"cmd/main.go"}, index.Documents)
+	}
+}
+
+func TestExportSCIPIndexUnprocessed(t *testing.T) {
+	mockStore := NewMockStore()
+	mockLsifStore := NewMockLsifStore()
+	svc := newService(&observation.TestContext, mockStore, nil, mockLsifStore, nil, nil, nil, nil, nil)
+
+	for _, state := range []string{"queued", "processing", "errored"} {
+		mockStore.GetUploadByIDFunc.SetDefaultReturn(types.Upload{ID: 42, State: state}, true, nil)
+
+		var buf bytes.Buffer
+		if exists, err := svc.ExportSCIPIndex(context.Background(), nil, 42, &buf); err != nil {
+			t.Fatalf("unexpected error exporting index: %s", err)
+		} else if exists {
+			t.Errorf("expected no index for upload in state %s", state)
+		}
+		if buf.Len() != 0 {
+			t.Errorf("expected nothing to be written for upload in state %s", state)
+		}
+	}
+
+	// Uploads processed into LSIF data have no SCIP metadata
+	mockStore.GetUploadByIDFunc.SetDefaultReturn(types.Upload{ID: 42, State: "completed"}, true, nil)
+	if exists, err := svc.ExportSCIPIndex(context.Background(), nil, 42, &bytes.Buffer{}); err != nil {
+		t.Fatalf("unexpected error exporting index: %s", err)
+	} else if exists {
+		t.Errorf("expected no index for upload without SCIP data")
+	}
+	if len(mockLsifStore.ScanDocumentsFunc.History()) != 0 {
+		t.Errorf("expected no documents to be scanned")
+	}
+}
@@ -93,11 +93,16 @@ func NewUploadProcessorJob(
 	db database.DB,
 	uploadStore uploadstore.Store,
 	workerConcurrency int,
+	workerMaxConcurrencyPerRepository int,
 	workerBudget int64,
 	workerPollInterval time.Duration,
 	maximumRuntimePerJob time.Duration,
 ) goroutine.BackgroundRoutine {
-	uploadsProcessorStore := dbworkerstore.New(observationCtx, db.Handle(), uploadsstore.UploadWorkerStoreOptions)
+	// Limiting the uploads processed per repository keeps a repository with many
+	// queued uploads from holding up the uploads of other repositories.
+	storeOptions := uploadsstore.UploadWorkerStoreOptions
+	storeOptions.MaxConcurrencyPerKey = workerMaxConcurrencyPerRepository
+	uploadsProcessorStore := dbworkerstore.New(observationCtx, db.Handle(), storeOptions)
 
 	dbworker.InitPrometheusMetric(observationCtx, uploadsProcessorStore, "codeintel", "upload", nil)
 
@@ -193,6 +198,7 @@ func NewExpirationTasks(observationCtx *observation.Context, uploadSvc *Service)
 				UploadBatchSize:        ConfigExpirationInst.UploadBatchSize,
 				CommitBatchSize:        ConfigExpirationInst.CommitBatchSize,
 				PolicyBatchSize:        ConfigExpirationInst.PolicyBatchSize,
+				EphemeralUploadMaxAge:  ConfigExpirationInst.EphemeralUploadMaxAge,
 			},
 		),
 	}
@@ -208,3 +214,31 @@ func NewGraphExporters(observationCtx *observation.Context, uploadSvc *Service)
 		),
 	}
 }
+
+func NewUnreferencedSymbolsReporters(observationCtx *observation.Context, uploadSvc *Service) []goroutine.BackgroundRoutine {
+	return []goroutine.BackgroundRoutine{
+		background.NewUnreferencedSymbolsReporter(
+			observationCtx,
+			uploadSvc,
+			ConfigUnreferencedSymbolsInst.Interval,
+			background.UnreferencedSymbolsReporterConfig{
+				RepositoryProcessDelay: ConfigUnreferencedSymbolsInst.RepositoryProcessDelay,
+				RepositoryBatchSize:    ConfigUnreferencedSymbolsInst.RepositoryBatchSize,
+			},
+		),
+	}
+}
+
+func NewCodeIntelCoverageReporters(observationCtx *observation.Context, uploadSvc *Service) []goroutine.BackgroundRoutine {
+	return []goroutine.BackgroundRoutine{
+		background.NewCodeIntelCoverageReporter(
+			observationCtx,
+			uploadSvc,
+			ConfigCodeIntelCoverageInst.Interval,
+			background.CodeIntelCoverageReporterConfig{
+				RepositoryProcessDelay: ConfigCodeIntelCoverageInst.RepositoryProcessDelay,
+				RepositoryBatchSize:    ConfigCodeIntelCoverageInst.RepositoryBatchSize,
+			},
+		),
+	}
+}
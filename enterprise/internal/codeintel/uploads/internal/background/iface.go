@@ -22,6 +22,8 @@ import (
 type UploadService interface {
 	SerializeRankingGraph(ctx context.Context, numRankingRoutines int) error
 	VacuumRankingGraph(ctx context.Context) error
+	ComputeUnreferencedSymbolsReports(ctx context.Context, processDelay time.Duration, repositoryBatchSize int) error
+	ComputeCodeIntelCoverage(ctx context.Context, processDelay time.Duration, repositoryBatchSize int) error
 }
 
 type GitserverClient interface {
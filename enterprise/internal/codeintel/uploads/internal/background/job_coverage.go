@@ -0,0 +1,29 @@
+package background
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type CodeIntelCoverageReporterConfig struct {
+	RepositoryProcessDelay time.Duration
+	RepositoryBatchSize    int
+}
+
+func NewCodeIntelCoverageReporter(
+	observationCtx *observation.Context,
+	uploadsService UploadService,
+	interval time.Duration,
+	config CodeIntelCoverageReporterConfig,
+) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(
+		context.Background(),
+		"codeintel.coverage-reporter", "computes reports of precise code intel coverage per repository and language",
+		interval,
+		goroutine.HandlerFunc(func(ctx context.Context) error {
+			return uploadsService.ComputeCodeIntelCoverage(ctx, config.RepositoryProcessDelay, config.RepositoryBatchSize)
+		}))
+}
@@ -22,6 +22,7 @@ type ExpirerConfig struct {
 	UploadBatchSize        int
 	CommitBatchSize        int
 	PolicyBatchSize        int
+	EphemeralUploadMaxAge  time.Duration
 }
 
 func NewUploadExpirer(
@@ -242,6 +243,13 @@ func (s *expirer) isUploadProtectedByPolicy(
 ) (bool, error) {
 	metrics.NumUploadsScanned.Inc()
 
+	if upload.Ephemeral {
+		// Ephemeral uploads are not part of the commit graph and are not visible from any commit
+		// described by a configuration policy. They are protected only for a short time after upload.
+		policyMatch := policiesEnterprise.EphemeralUploadPolicyMatch(cfg.EphemeralUploadMaxAge)
+		return now.Sub(upload.UploadedAt) < *policyMatch.PolicyDuration, nil
+	}
+
 	var token *string
 
 	for first := true; first || token != nil; first = false {
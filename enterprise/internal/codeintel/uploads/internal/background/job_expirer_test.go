@@ -35,6 +35,7 @@ func TestUploadExpirer(t *testing.T) {
 		UploadProcessDelay:     24 * time.Hour,
 		UploadBatchSize:        100,
 		CommitBatchSize:        100,
+		EphemeralUploadMaxAge:  72 * time.Hour,
 	}); err != nil {
 		t.Fatalf("unexpected error from handle: %s", err)
 	}
@@ -51,12 +52,12 @@ func TestUploadExpirer(t *testing.T) {
 	}
 	sort.Ints(expiredIDs)
 
-	expectedProtectedIDs := []int{12, 16, 18, 20, 25, 26, 27, 28}
+	expectedProtectedIDs := []int{12, 16, 18, 20, 25, 26, 27, 28, 31}
 	if diff := cmp.Diff(expectedProtectedIDs, protectedIDs); diff != "" {
 		t.Errorf("unexpected protected upload identifiers (-want +got):\n%s", diff)
 	}
 
-	expectedExpiredIDs := []int{11, 13, 14, 15, 17, 19, 21, 22, 23, 24, 29, 30}
+	expectedExpiredIDs := []int{11, 13, 14, 15, 17, 19, 21, 22, 23, 24, 29, 30, 32}
 	if diff := cmp.Diff(expectedExpiredIDs, expiredIDs); diff != "" {
 		t.Errorf("unexpected expired upload identifiers (-want +got):\n%s", diff)
 	}
@@ -131,6 +132,8 @@ func setupMockUploadService(now time.Time) *MockStore {
 		{ID: 28, State: "completed", RepositoryID: 53, Commit: "deadbeef18", UploadedAt: daysAgo(now, 2)},
 		{ID: 29, State: "completed", RepositoryID: 53, Commit: "deadbeef19", UploadedAt: daysAgo(now, 1)},
 		{ID: 30, State: "completed", RepositoryID: 53, Commit: "deadbeef20", UploadedAt: daysAgo(now, 9)},
+		{ID: 31, State: "completed", RepositoryID: 50, Commit: "deadbeef21", UploadedAt: daysAgo(now, 2), Ephemeral: true}, // ephemeral
+		{ID: 32, State: "completed", RepositoryID: 50, Commit: "deadbeef22", UploadedAt: daysAgo(now, 4), Ephemeral: true},
 	}
 
 	repositoryIDMap := map[int]struct{}{}
@@ -221,6 +224,8 @@ func testUploadExpirerMockPolicyMatcher() *MockPolicyMatcher {
 			"deadbeef03": {{PolicyDuration: days(2)}}, // 2 < 3
 			"deadbeef04": {},
 			"deadbeef05": {},
+			"deadbeef21": {},                      // ephemeral, 3 > 2 (protected)
+			"deadbeef22": {{PolicyDuration: nil}}, // ephemeral, 3 < 4 (not visible from policy commits)
 		},
 		51: {
 			// N.B. deadcafe (alt visible commit) used here
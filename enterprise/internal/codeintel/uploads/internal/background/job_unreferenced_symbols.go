@@ -0,0 +1,29 @@
+package background
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type UnreferencedSymbolsReporterConfig struct {
+	RepositoryProcessDelay time.Duration
+	RepositoryBatchSize    int
+}
+
+func NewUnreferencedSymbolsReporter(
+	observationCtx *observation.Context,
+	uploadsService UploadService,
+	interval time.Duration,
+	config UnreferencedSymbolsReporterConfig,
+) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(
+		context.Background(),
+		"codeintel.unreferenced-symbols-reporter", "computes reports of symbols defined in precise indexes that are never referenced",
+		interval,
+		goroutine.HandlerFunc(func(ctx context.Context) error {
+			return uploadsService.ComputeUnreferencedSymbolsReports(ctx, config.RepositoryProcessDelay, config.RepositoryBatchSize)
+		}))
+}
@@ -200,6 +200,12 @@ func (h *handler) HandleRawUpload(ctx context.Context, logger log.Logger, upload
 		return requeued, err
 	}
 
+	if upload.BaseUploadID != nil {
+		if requeued, err := requeueIfBaseUploadUnprocessed(ctx, logger, h.store, h.workerStore, upload); err != nil || requeued {
+			return requeued, err
+		}
+	}
+
 	// Determine if the upload is for the default Git branch.
 	isDefaultBranch, err := h.gitserverClient.DefaultBranchContains(ctx, upload.RepositoryID, upload.Commit)
 	if err != nil {
@@ -224,8 +230,13 @@ func (h *handler) HandleRawUpload(ctx context.Context, logger log.Logger, upload
 		var (
 			groupedBundleData  *precise.GroupedBundleDataChans
 			correlatedSCIPData lsifstore.ProcessedSCIPData
+			baseDocumentPaths  []string
+			copiedSymbols      []lsifstore.CopiedSymbol
 		)
 		if upload.ContentType == lsifContentType {
+			if upload.BaseUploadID != nil {
+				return errors.New("incremental uploads must be SCIP indexes")
+			}
 			if groupedBundleData, err = conversion.Correlate(ctx, r, upload.Root, getChildren); err != nil {
 				return errors.Wrap(err, "conversion.Correlate")
 			}
@@ -233,6 +244,14 @@ func (h *handler) HandleRawUpload(ctx context.Context, logger log.Logger, upload
 			if correlatedSCIPData, err = correlateSCIP(ctx, r, upload.Root, getChildren); err != nil {
 				return errors.Wrap(err, "conversion.Correlate")
 			}
+			if upload.BaseUploadID != nil {
+				// Determine which documents of the base upload still exist at the commit of this
+				// upload. These documents are copied into this upload unless they're replaced by
+				// a document of the same path in the uploaded index.
+				if baseDocumentPaths, err = existingBaseDocumentPaths(ctx, h.lsifStore, *upload.BaseUploadID, upload.Root, getChildren); err != nil {
+					return err
+				}
+			}
 		} else {
 			return errors.Newf("unsupported content type %q", upload.ContentType)
 		}
@@ -277,7 +296,7 @@ func (h *handler) HandleRawUpload(ctx context.Context, logger log.Logger, upload
 		} else if upload.ContentType == scipContentType {
 			// Note: this is writing to a different database than the block below, so we need to use a
 			// different transaction context (managed by the writeData function).
-			if err := writeSCIPData(ctx, h.lsifStore, upload, correlatedSCIPData, trace); err != nil {
+			if copiedSymbols, err = writeSCIPData(ctx, h.lsifStore, upload, correlatedSCIPData, baseDocumentPaths, trace); err != nil {
 				if isUniqueConstraintViolation(err) {
 					// If this is a unique constraint violation, then we've previously processed this same
 					// upload record up to this point, but failed to perform the transaction below. We can
@@ -318,6 +337,8 @@ func (h *handler) HandleRawUpload(ctx context.Context, logger log.Logger, upload
 				if err != nil {
 					return err
 				}
+				// Add the packages and package references of the documents copied from the base upload
+				packages, packageReferences = mergeCopiedPackages(packages, packageReferences, copiedSymbols)
 
 				trace.AddEvent("TODO Domain Owner", attribute.Int("packages", len(packages)))
 				// Update package and package reference data to support cross-repo queries.
@@ -398,6 +419,36 @@ func requeueIfCloningOrCommitUnknown(ctx context.Context, logger log.Logger, rep
 	return true, nil
 }
 
+// requeueIfBaseUploadUnprocessed ensures that the base upload of an incremental upload has been processed.
+// If the base upload is still being uploaded or processed, then the upload will be requeued and this function
+// returns a true valued flag. Otherwise, the base upload does not exist or has failed to process, and the
+// incremental upload cannot be processed, which we'll fail on.
+func requeueIfBaseUploadUnprocessed(ctx context.Context, logger log.Logger, dbStore store.Store, workerStore dbworkerstore.Store[codeinteltypes.Upload], upload codeinteltypes.Upload) (requeued bool, _ error) {
+	baseUpload, ok, err := dbStore.GetUploadByID(ctx, *upload.BaseUploadID)
+	if err != nil {
+		return false, errors.Wrap(err, "store.GetUploadByID")
+	}
+	if ok {
+		switch baseUpload.State {
+		case "completed":
+			return false, nil
+
+		case "uploading", "queued", "processing":
+			after := time.Now().UTC().Add(requeueDelay)
+
+			if err := workerStore.Requeue(ctx, upload.ID, after); err != nil {
+				return false, errors.Wrap(err, "store.Requeue")
+			}
+			logger.Warn("Requeued LSIF upload record",
+				log.Int("id", upload.ID),
+				log.String("reason", "base upload not yet processed"))
+			return true, nil
+		}
+	}
+
+	return false, errors.Newf("base upload %d is not available", *upload.BaseUploadID)
+}
+
 // withUploadData will invoke the given function with a reader of the upload's raw data. The
 // consumer should expect raw newline-delimited JSON content. If the function returns without
 // an error, the upload file will be deleted.
@@ -389,6 +389,168 @@ func TestHandleSCIP(t *testing.T) {
 	}
 }
 
+func TestHandleSCIPIncremental(t *testing.T) {
+	setupRepoMocks(t)
+
+	baseUploadID := 41
+	upload := codeinteltypes.Upload{
+		ID:           42,
+		Root:         "",
+		Commit:       "deadbeef",
+		RepositoryID: 50,
+		Indexer:      "lsif-go",
+		ContentType:  "application/x-protobuf+scip",
+		BaseUploadID: &baseUploadID,
+	}
+
+	mockWorkerStore := NewMockWorkerStore[codeinteltypes.Upload]()
+	mockDBStore := NewMockStore()
+	mockRepoStore := NewMockRepoStore()
+	mockLSIFStore := NewMockLsifStore()
+	mockUploadStore := uploadstoremocks.NewMockStore()
+	gitserverClient := NewMockGitserverClient()
+
+	// Set default transaction behavior
+	mockDBStore.TransactFunc.SetDefaultReturn(mockDBStore, nil)
+	mockDBStore.DoneFunc.SetDefaultHook(func(err error) error { return err })
+	mockLSIFStore.TransactFunc.SetDefaultReturn(mockLSIFStore, nil)
+
+	scipWriter := NewMockSCIPWriter()
+	scipWriter.CopyDocumentsFunc.SetDefaultReturn([]lsifstore.CopiedSymbol{
+		{Name: "scip-typescript npm unchanged 1.0.0 src/`unchanged.ts`/unchanged().", IsDefinition: true},
+		{Name: "scip-typescript npm referenced 2.0.0 src/`index.d.ts`/referenced().", IsDefinition: false},
+		{Name: "local 0", IsDefinition: true},
+	}, nil)
+	mockLSIFStore.NewSCIPWriterFunc.SetDefaultReturn(scipWriter, nil)
+
+	mockDBStore.GetUploadByIDFunc.SetDefaultReturn(codeinteltypes.Upload{ID: baseUploadID, State: "completed"}, true, nil)
+	mockLSIFStore.GetSCIPDocumentPathsFunc.SetDefaultReturn([]string{
+		"template/src/deleted.ts",      // deleted since the base commit
+		"template/src/unchanged.ts",    // not part of the incremental index
+		"template/src/util/graphql.ts", // replaced by the incremental index
+	}, nil)
+
+	mockUploadStore.GetFunc.SetDefaultHook(copyTestDumpScip)
+
+	directoryChildren := map[string][]string{}
+	for dirname, children := range scipDirectoryChildren {
+		directoryChildren[dirname] = children
+	}
+	directoryChildren["template/src"] = append([]string{"template/src/unchanged.ts"}, directoryChildren["template/src"]...)
+	gitserverClient.DirectoryChildrenFunc.SetDefaultReturn(directoryChildren, nil)
+	gitserverClient.CommitDateFunc.SetDefaultReturn("deadbeef", time.Unix(1587396557, 0).UTC(), true, nil)
+
+	svc := &handler{
+		store:           mockDBStore,
+		lsifStore:       mockLSIFStore,
+		gitserverClient: gitserverClient,
+		repoStore:       mockRepoStore,
+		workerStore:     mockWorkerStore,
+	}
+
+	requeued, err := svc.HandleRawUpload(context.Background(), logtest.Scoped(t), upload, mockUploadStore, observation.TestTraceLogger(logtest.Scoped(t)))
+	if err != nil {
+		t.Fatalf("unexpected error handling upload: %s", err)
+	} else if requeued {
+		t.Errorf("unexpected requeue")
+	}
+
+	if len(scipWriter.InsertDocumentFunc.History()) != 11 {
+		t.Errorf("unexpected number of of InsertDocumentFunc.History() calls. want=%d have=%d", 11, len(scipWriter.InsertDocumentFunc.History()))
+	}
+
+	if calls := scipWriter.CopyDocumentsFunc.History(); len(calls) != 1 {
+		t.Errorf("unexpected number of CopyDocuments calls. want=%d have=%d", 1, len(calls))
+	} else if calls[0].Arg1 != baseUploadID {
+		t.Errorf("unexpected base upload id. want=%d have=%d", baseUploadID, calls[0].Arg1)
+	} else if diff := cmp.Diff([]string{"template/src/unchanged.ts"}, calls[0].Arg2); diff != "" {
+		t.Errorf("unexpected copied paths (-want +got):\n%s", diff)
+	}
+
+	// The packages of the symbols of the copied documents are added to those of the index
+	unchangedPackage := precise.Package{Scheme: "scip-typescript", Manager: "npm", Name: "unchanged", Version: "1.0.0"}
+	referencedPackage := precise.Package{Scheme: "scip-typescript", Manager: "npm", Name: "referenced", Version: "2.0.0"}
+
+	if calls := mockDBStore.UpdatePackagesFunc.History(); len(calls) != 1 {
+		t.Errorf("unexpected number of UpdatePackages calls. want=%d have=%d", 1, len(calls))
+	} else if !containsPackage(calls[0].Arg2, unchangedPackage) {
+		t.Errorf("expected package %v of the copied documents", unchangedPackage)
+	}
+
+	if calls := mockDBStore.UpdatePackageReferencesFunc.History(); len(calls) != 1 {
+		t.Errorf("unexpected number of UpdatePackageReferences calls. want=%d have=%d", 1, len(calls))
+	} else {
+		var packages []precise.Package
+		for _, packageReference := range calls[0].Arg2 {
+			packages = append(packages, packageReference.Package)
+		}
+		if !containsPackage(packages, referencedPackage) {
+			t.Errorf("expected package reference %v of the copied documents", referencedPackage)
+		}
+		if containsPackage(packages, unchangedPackage) {
+			t.Errorf("unexpected package reference %v defined by the copied documents", unchangedPackage)
+		}
+	}
+}
+
+func containsPackage(packages []precise.Package, pkg precise.Package) bool {
+	for _, p := range packages {
+		if p == pkg {
+			return true
+		}
+	}
+	return false
+}
+
+func TestHandleSCIPIncrementalBaseUnprocessed(t *testing.T) {
+	setupRepoMocks(t)
+
+	baseUploadID := 41
+	upload := codeinteltypes.Upload{
+		ID:           42,
+		Commit:       "deadbeef",
+		RepositoryID: 50,
+		Indexer:      "lsif-go",
+		ContentType:  "application/x-protobuf+scip",
+		BaseUploadID: &baseUploadID,
+	}
+
+	mockWorkerStore := NewMockWorkerStore[codeinteltypes.Upload]()
+	mockDBStore := NewMockStore()
+	mockRepoStore := NewMockRepoStore()
+	mockUploadStore := uploadstoremocks.NewMockStore()
+
+	svc := &handler{
+		store:           mockDBStore,
+		gitserverClient: NewMockGitserverClient(),
+		repoStore:       mockRepoStore,
+		workerStore:     mockWorkerStore,
+	}
+
+	// Base upload is still queued
+	mockDBStore.GetUploadByIDFunc.PushReturn(codeinteltypes.Upload{ID: baseUploadID, State: "queued"}, true, nil)
+
+	requeued, err := svc.HandleRawUpload(context.Background(), logtest.Scoped(t), upload, mockUploadStore, observation.TestTraceLogger(logtest.Scoped(t)))
+	if err != nil {
+		t.Fatalf("unexpected error handling upload: %s", err)
+	} else if !requeued {
+		t.Errorf("expected upload to be requeued")
+	}
+	if len(mockWorkerStore.RequeueFunc.History()) != 1 {
+		t.Errorf("unexpected number of Requeue calls. want=%d have=%d", 1, len(mockWorkerStore.RequeueFunc.History()))
+	}
+
+	// Base upload failed to process
+	mockDBStore.GetUploadByIDFunc.PushReturn(codeinteltypes.Upload{ID: baseUploadID, State: "errored"}, true, nil)
+
+	if _, err := svc.HandleRawUpload(context.Background(), logtest.Scoped(t), upload, mockUploadStore, observation.TestTraceLogger(logtest.Scoped(t))); err == nil {
+		t.Fatalf("expected an error handling upload")
+	}
+	if len(mockUploadStore.GetFunc.History()) != 0 {
+		t.Errorf("expected upload data not to be read")
+	}
+}
+
 func TestHandleError(t *testing.T) {
 	setupRepoMocks(t)
 
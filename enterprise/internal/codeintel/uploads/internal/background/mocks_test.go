@@ -2408,6 +2408,9 @@ type MockStore struct {
 	// GetAuditLogsForUploadFunc is an instance of a mock function object
 	// controlling the behavior of the method GetAuditLogsForUpload.
 	GetAuditLogsForUploadFunc *StoreGetAuditLogsForUploadFunc
+	// GetCodeIntelCoverageFunc is an instance of a mock function object
+	// controlling the behavior of the method GetCodeIntelCoverage.
+	GetCodeIntelCoverageFunc *StoreGetCodeIntelCoverageFunc
 	// GetCommitGraphMetadataFunc is an instance of a mock function object
 	// controlling the behavior of the method GetCommitGraphMetadata.
 	GetCommitGraphMetadataFunc *StoreGetCommitGraphMetadataFunc
@@ -2415,6 +2418,12 @@ type MockStore struct {
 	// object controlling the behavior of the method
 	// GetCommitsVisibleToUpload.
 	GetCommitsVisibleToUploadFunc *StoreGetCommitsVisibleToUploadFunc
+	// GetCoverageFailuresFunc is an instance of a mock function object
+	// controlling the behavior of the method GetCoverageFailures.
+	GetCoverageFailuresFunc *StoreGetCoverageFailuresFunc
+	// GetCoverageUploadsAtTipFunc is an instance of a mock function object
+	// controlling the behavior of the method GetCoverageUploadsAtTip.
+	GetCoverageUploadsAtTipFunc *StoreGetCoverageUploadsAtTipFunc
 	// GetDirtyRepositoriesFunc is an instance of a mock function object
 	// controlling the behavior of the method GetDirtyRepositories.
 	GetDirtyRepositoriesFunc *StoreGetDirtyRepositoriesFunc
@@ -2435,6 +2444,13 @@ type MockStore struct {
 	// GetRecentUploadsSummaryFunc is an instance of a mock function object
 	// controlling the behavior of the method GetRecentUploadsSummary.
 	GetRecentUploadsSummaryFunc *StoreGetRecentUploadsSummaryFunc
+	// GetReferencingUploadIDsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetReferencingUploadIDs.
+	GetReferencingUploadIDsFunc *StoreGetReferencingUploadIDsFunc
+	// GetRepositoriesForCoverageReportFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// GetRepositoriesForCoverageReport.
+	GetRepositoriesForCoverageReportFunc *StoreGetRepositoriesForCoverageReportFunc
 	// GetRepositoriesForIndexScanFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// GetRepositoriesForIndexScan.
@@ -2446,6 +2462,13 @@ type MockStore struct {
 	// GetStaleSourcedCommitsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetStaleSourcedCommits.
 	GetStaleSourcedCommitsFunc *StoreGetStaleSourcedCommitsFunc
+	// GetUnreferencedSymbolsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetUnreferencedSymbols.
+	GetUnreferencedSymbolsFunc *StoreGetUnreferencedSymbolsFunc
+	// GetUnreferencedSymbolsSummaryFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetUnreferencedSymbolsSummary.
+	GetUnreferencedSymbolsSummaryFunc *StoreGetUnreferencedSymbolsSummaryFunc
 	// GetUploadByIDFunc is an instance of a mock function object
 	// controlling the behavior of the method GetUploadByID.
 	GetUploadByIDFunc *StoreGetUploadByIDFunc
@@ -2466,10 +2489,18 @@ type MockStore struct {
 	// GetUploadsForRankingFunc is an instance of a mock function object
 	// controlling the behavior of the method GetUploadsForRanking.
 	GetUploadsForRankingFunc *StoreGetUploadsForRankingFunc
+	// GetUploadsForUnreferencedSymbolsReportFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// GetUploadsForUnreferencedSymbolsReport.
+	GetUploadsForUnreferencedSymbolsReportFunc *StoreGetUploadsForUnreferencedSymbolsReportFunc
 	// GetVisibleUploadsMatchingMonikersFunc is an instance of a mock
 	// function object controlling the behavior of the method
 	// GetVisibleUploadsMatchingMonikers.
 	GetVisibleUploadsMatchingMonikersFunc *StoreGetVisibleUploadsMatchingMonikersFunc
+	// GetVisibleUploadsReferencingAnyVersionFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// GetVisibleUploadsReferencingAnyVersion.
+	GetVisibleUploadsReferencingAnyVersionFunc *StoreGetVisibleUploadsReferencingAnyVersionFunc
 	// HardDeleteUploadsByIDsFunc is an instance of a mock function object
 	// controlling the behavior of the method HardDeleteUploadsByIDs.
 	HardDeleteUploadsByIDsFunc *StoreHardDeleteUploadsByIDsFunc
@@ -2539,6 +2570,9 @@ type MockStore struct {
 	// TransactFunc is an instance of a mock function object controlling the
 	// behavior of the method Transact.
 	TransactFunc *StoreTransactFunc
+	// UpdateCodeIntelCoverageFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateCodeIntelCoverage.
+	UpdateCodeIntelCoverageFunc *StoreUpdateCodeIntelCoverageFunc
 	// UpdateCommittedAtFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateCommittedAt.
 	UpdateCommittedAtFunc *StoreUpdateCommittedAtFunc
@@ -2551,6 +2585,10 @@ type MockStore struct {
 	// UpdateSourcedCommitsFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateSourcedCommits.
 	UpdateSourcedCommitsFunc *StoreUpdateSourcedCommitsFunc
+	// UpdateUnreferencedSymbolsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// UpdateUnreferencedSymbols.
+	UpdateUnreferencedSymbolsFunc *StoreUpdateUnreferencedSymbolsFunc
 	// UpdateUploadRetentionFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateUploadRetention.
 	UpdateUploadRetentionFunc *StoreUpdateUploadRetentionFunc
@@ -2627,6 +2665,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetCodeIntelCoverageFunc: &StoreGetCodeIntelCoverageFunc{
+			defaultHook: func(context.Context, shared1.GetCodeIntelCoverageOptions) (r0 []shared1.CodeIntelCoverage, r1 int, r2 error) {
+				return
+			},
+		},
 		GetCommitGraphMetadataFunc: &StoreGetCommitGraphMetadataFunc{
 			defaultHook: func(context.Context, int) (r0 bool, r1 *time.Time, r2 error) {
 				return
@@ -2637,6 +2680,16 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetCoverageFailuresFunc: &StoreGetCoverageFailuresFunc{
+			defaultHook: func(context.Context, int) (r0 []store.CoverageFailure, r1 error) {
+				return
+			},
+		},
+		GetCoverageUploadsAtTipFunc: &StoreGetCoverageUploadsAtTipFunc{
+			defaultHook: func(context.Context, int, string) (r0 []store.CoverageUpload, r1 error) {
+				return
+			},
+		},
 		GetDirtyRepositoriesFunc: &StoreGetDirtyRepositoriesFunc{
 			defaultHook: func(context.Context) (r0 map[int]int, r1 error) {
 				return
@@ -2667,6 +2720,16 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetReferencingUploadIDsFunc: &StoreGetReferencingUploadIDsFunc{
+			defaultHook: func(context.Context, int) (r0 []int, r1 error) {
+				return
+			},
+		},
+		GetRepositoriesForCoverageReportFunc: &StoreGetRepositoriesForCoverageReportFunc{
+			defaultHook: func(context.Context, time.Duration, int, time.Time) (r0 []int, r1 error) {
+				return
+			},
+		},
 		GetRepositoriesForIndexScanFunc: &StoreGetRepositoriesForIndexScanFunc{
 			defaultHook: func(context.Context, string, string, time.Duration, bool, *int, int, time.Time) (r0 []int, r1 error) {
 				return
@@ -2682,6 +2745,16 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetUnreferencedSymbolsFunc: &StoreGetUnreferencedSymbolsFunc{
+			defaultHook: func(context.Context, shared1.GetUnreferencedSymbolsOptions) (r0 []shared1.UnreferencedSymbol, r1 int, r2 error) {
+				return
+			},
+		},
+		GetUnreferencedSymbolsSummaryFunc: &StoreGetUnreferencedSymbolsSummaryFunc{
+			defaultHook: func(context.Context, int) (r0 shared1.UnreferencedSymbolsSummary, r1 bool, r2 error) {
+				return
+			},
+		},
 		GetUploadByIDFunc: &StoreGetUploadByIDFunc{
 			defaultHook: func(context.Context, int) (r0 types.Upload, r1 bool, r2 error) {
 				return
@@ -2712,11 +2785,21 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetUploadsForUnreferencedSymbolsReportFunc: &StoreGetUploadsForUnreferencedSymbolsReportFunc{
+			defaultHook: func(context.Context, time.Duration, int, time.Time) (r0 []store.UnreferencedSymbolsUpload, r1 error) {
+				return
+			},
+		},
 		GetVisibleUploadsMatchingMonikersFunc: &StoreGetVisibleUploadsMatchingMonikersFunc{
 			defaultHook: func(context.Context, int, string, []precise.QualifiedMonikerData, int, int) (r0 shared1.PackageReferenceScanner, r1 int, r2 error) {
 				return
 			},
 		},
+		GetVisibleUploadsReferencingAnyVersionFunc: &StoreGetVisibleUploadsReferencingAnyVersionFunc{
+			defaultHook: func(context.Context, int, string, []precise.QualifiedMonikerData, int) (r0 []shared1.PackageReference, r1 error) {
+				return
+			},
+		},
 		HardDeleteUploadsByIDsFunc: &StoreHardDeleteUploadsByIDsFunc{
 			defaultHook: func(context.Context, ...int) (r0 error) {
 				return
@@ -2822,6 +2905,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		UpdateCodeIntelCoverageFunc: &StoreUpdateCodeIntelCoverageFunc{
+			defaultHook: func(context.Context, int, string, []shared1.CodeIntelCoverage, time.Time) (r0 error) {
+				return
+			},
+		},
 		UpdateCommittedAtFunc: &StoreUpdateCommittedAtFunc{
 			defaultHook: func(context.Context, int, string, string) (r0 error) {
 				return
@@ -2842,6 +2930,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		UpdateUnreferencedSymbolsFunc: &StoreUpdateUnreferencedSymbolsFunc{
+			defaultHook: func(context.Context, int, []shared1.UnreferencedSymbol, time.Time) (r0 error) {
+				return
+			},
+		},
 		UpdateUploadRetentionFunc: &StoreUpdateUploadRetentionFunc{
 			defaultHook: func(context.Context, []int, []int) (r0 error) {
 				return
@@ -2924,6 +3017,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetAuditLogsForUpload")
 			},
 		},
+		GetCodeIntelCoverageFunc: &StoreGetCodeIntelCoverageFunc{
+			defaultHook: func(context.Context, shared1.GetCodeIntelCoverageOptions) ([]shared1.CodeIntelCoverage, int, error) {
+				panic("unexpected invocation of MockStore.GetCodeIntelCoverage")
+			},
+		},
 		GetCommitGraphMetadataFunc: &StoreGetCommitGraphMetadataFunc{
 			defaultHook: func(context.Context, int) (bool, *time.Time, error) {
 				panic("unexpected invocation of MockStore.GetCommitGraphMetadata")
@@ -2934,6 +3032,16 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetCommitsVisibleToUpload")
 			},
 		},
+		GetCoverageFailuresFunc: &StoreGetCoverageFailuresFunc{
+			defaultHook: func(context.Context, int) ([]store.CoverageFailure, error) {
+				panic("unexpected invocation of MockStore.GetCoverageFailures")
+			},
+		},
+		GetCoverageUploadsAtTipFunc: &StoreGetCoverageUploadsAtTipFunc{
+			defaultHook: func(context.Context, int, string) ([]store.CoverageUpload, error) {
+				panic("unexpected invocation of MockStore.GetCoverageUploadsAtTip")
+			},
+		},
 		GetDirtyRepositoriesFunc: &StoreGetDirtyRepositoriesFunc{
 			defaultHook: func(context.Context) (map[int]int, error) {
 				panic("unexpected invocation of MockStore.GetDirtyRepositories")
@@ -2964,6 +3072,16 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetRecentUploadsSummary")
 			},
 		},
+		GetReferencingUploadIDsFunc: &StoreGetReferencingUploadIDsFunc{
+			defaultHook: func(context.Context, int) ([]int, error) {
+				panic("unexpected invocation of MockStore.GetReferencingUploadIDs")
+			},
+		},
+		GetRepositoriesForCoverageReportFunc: &StoreGetRepositoriesForCoverageReportFunc{
+			defaultHook: func(context.Context, time.Duration, int, time.Time) ([]int, error) {
+				panic("unexpected invocation of MockStore.GetRepositoriesForCoverageReport")
+			},
+		},
 		GetRepositoriesForIndexScanFunc: &StoreGetRepositoriesForIndexScanFunc{
 			defaultHook: func(context.Context, string, string, time.Duration, bool, *int, int, time.Time) ([]int, error) {
 				panic("unexpected invocation of MockStore.GetRepositoriesForIndexScan")
@@ -2979,6 +3097,16 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetStaleSourcedCommits")
 			},
 		},
+		GetUnreferencedSymbolsFunc: &StoreGetUnreferencedSymbolsFunc{
+			defaultHook: func(context.Context, shared1.GetUnreferencedSymbolsOptions) ([]shared1.UnreferencedSymbol, int, error) {
+				panic("unexpected invocation of MockStore.GetUnreferencedSymbols")
+			},
+		},
+		GetUnreferencedSymbolsSummaryFunc: &StoreGetUnreferencedSymbolsSummaryFunc{
+			defaultHook: func(context.Context, int) (shared1.UnreferencedSymbolsSummary, bool, error) {
+				panic("unexpected invocation of MockStore.GetUnreferencedSymbolsSummary")
+			},
+		},
 		GetUploadByIDFunc: &StoreGetUploadByIDFunc{
 			defaultHook: func(context.Context, int) (types.Upload, bool, error) {
 				panic("unexpected invocation of MockStore.GetUploadByID")
@@ -3009,11 +3137,21 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetUploadsForRanking")
 			},
 		},
+		GetUploadsForUnreferencedSymbolsReportFunc: &StoreGetUploadsForUnreferencedSymbolsReportFunc{
+			defaultHook: func(context.Context, time.Duration, int, time.Time) ([]store.UnreferencedSymbolsUpload, error) {
+				panic("unexpected invocation of MockStore.GetUploadsForUnreferencedSymbolsReport")
+			},
+		},
 		GetVisibleUploadsMatchingMonikersFunc: &StoreGetVisibleUploadsMatchingMonikersFunc{
 			defaultHook: func(context.Context, int, string, []precise.QualifiedMonikerData, int, int) (shared1.PackageReferenceScanner, int, error) {
 				panic("unexpected invocation of MockStore.GetVisibleUploadsMatchingMonikers")
 			},
 		},
+		GetVisibleUploadsReferencingAnyVersionFunc: &StoreGetVisibleUploadsReferencingAnyVersionFunc{
+			defaultHook: func(context.Context, int, string, []precise.QualifiedMonikerData, int) ([]shared1.PackageReference, error) {
+				panic("unexpected invocation of MockStore.GetVisibleUploadsReferencingAnyVersion")
+			},
+		},
 		HardDeleteUploadsByIDsFunc: &StoreHardDeleteUploadsByIDsFunc{
 			defaultHook: func(context.Context, ...int) error {
 				panic("unexpected invocation of MockStore.HardDeleteUploadsByIDs")
@@ -3119,6 +3257,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.Transact")
 			},
 		},
+		UpdateCodeIntelCoverageFunc: &StoreUpdateCodeIntelCoverageFunc{
+			defaultHook: func(context.Context, int, string, []shared1.CodeIntelCoverage, time.Time) error {
+				panic("unexpected invocation of MockStore.UpdateCodeIntelCoverage")
+			},
+		},
 		UpdateCommittedAtFunc: &StoreUpdateCommittedAtFunc{
 			defaultHook: func(context.Context, int, string, string) error {
 				panic("unexpected invocation of MockStore.UpdateCommittedAt")
@@ -3139,6 +3282,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.UpdateSourcedCommits")
 			},
 		},
+		UpdateUnreferencedSymbolsFunc: &StoreUpdateUnreferencedSymbolsFunc{
+			defaultHook: func(context.Context, int, []shared1.UnreferencedSymbol, time.Time) error {
+				panic("unexpected invocation of MockStore.UpdateUnreferencedSymbols")
+			},
+		},
 		UpdateUploadRetentionFunc: &StoreUpdateUploadRetentionFunc{
 			defaultHook: func(context.Context, []int, []int) error {
 				panic("unexpected invocation of MockStore.UpdateUploadRetention")
@@ -3197,12 +3345,21 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetAuditLogsForUploadFunc: &StoreGetAuditLogsForUploadFunc{
 			defaultHook: i.GetAuditLogsForUpload,
 		},
+		GetCodeIntelCoverageFunc: &StoreGetCodeIntelCoverageFunc{
+			defaultHook: i.GetCodeIntelCoverage,
+		},
 		GetCommitGraphMetadataFunc: &StoreGetCommitGraphMetadataFunc{
 			defaultHook: i.GetCommitGraphMetadata,
 		},
 		GetCommitsVisibleToUploadFunc: &StoreGetCommitsVisibleToUploadFunc{
 			defaultHook: i.GetCommitsVisibleToUpload,
 		},
+		GetCoverageFailuresFunc: &StoreGetCoverageFailuresFunc{
+			defaultHook: i.GetCoverageFailures,
+		},
+		GetCoverageUploadsAtTipFunc: &StoreGetCoverageUploadsAtTipFunc{
+			defaultHook: i.GetCoverageUploadsAtTip,
+		},
 		GetDirtyRepositoriesFunc: &StoreGetDirtyRepositoriesFunc{
 			defaultHook: i.GetDirtyRepositories,
 		},
@@ -3221,6 +3378,12 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetRecentUploadsSummaryFunc: &StoreGetRecentUploadsSummaryFunc{
 			defaultHook: i.GetRecentUploadsSummary,
 		},
+		GetReferencingUploadIDsFunc: &StoreGetReferencingUploadIDsFunc{
+			defaultHook: i.GetReferencingUploadIDs,
+		},
+		GetRepositoriesForCoverageReportFunc: &StoreGetRepositoriesForCoverageReportFunc{
+			defaultHook: i.GetRepositoriesForCoverageReport,
+		},
 		GetRepositoriesForIndexScanFunc: &StoreGetRepositoriesForIndexScanFunc{
 			defaultHook: i.GetRepositoriesForIndexScan,
 		},
@@ -3230,6 +3393,12 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetStaleSourcedCommitsFunc: &StoreGetStaleSourcedCommitsFunc{
 			defaultHook: i.GetStaleSourcedCommits,
 		},
+		GetUnreferencedSymbolsFunc: &StoreGetUnreferencedSymbolsFunc{
+			defaultHook: i.GetUnreferencedSymbols,
+		},
+		GetUnreferencedSymbolsSummaryFunc: &StoreGetUnreferencedSymbolsSummaryFunc{
+			defaultHook: i.GetUnreferencedSymbolsSummary,
+		},
 		GetUploadByIDFunc: &StoreGetUploadByIDFunc{
 			defaultHook: i.GetUploadByID,
 		},
@@ -3248,9 +3417,15 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetUploadsForRankingFunc: &StoreGetUploadsForRankingFunc{
 			defaultHook: i.GetUploadsForRanking,
 		},
+		GetUploadsForUnreferencedSymbolsReportFunc: &StoreGetUploadsForUnreferencedSymbolsReportFunc{
+			defaultHook: i.GetUploadsForUnreferencedSymbolsReport,
+		},
 		GetVisibleUploadsMatchingMonikersFunc: &StoreGetVisibleUploadsMatchingMonikersFunc{
 			defaultHook: i.GetVisibleUploadsMatchingMonikers,
 		},
+		GetVisibleUploadsReferencingAnyVersionFunc: &StoreGetVisibleUploadsReferencingAnyVersionFunc{
+			defaultHook: i.GetVisibleUploadsReferencingAnyVersion,
+		},
 		HardDeleteUploadsByIDsFunc: &StoreHardDeleteUploadsByIDsFunc{
 			defaultHook: i.HardDeleteUploadsByIDs,
 		},
@@ -3314,6 +3489,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		TransactFunc: &StoreTransactFunc{
 			defaultHook: i.Transact,
 		},
+		UpdateCodeIntelCoverageFunc: &StoreUpdateCodeIntelCoverageFunc{
+			defaultHook: i.UpdateCodeIntelCoverage,
+		},
 		UpdateCommittedAtFunc: &StoreUpdateCommittedAtFunc{
 			defaultHook: i.UpdateCommittedAt,
 		},
@@ -3326,6 +3504,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		UpdateSourcedCommitsFunc: &StoreUpdateSourcedCommitsFunc{
 			defaultHook: i.UpdateSourcedCommits,
 		},
+		UpdateUnreferencedSymbolsFunc: &StoreUpdateUnreferencedSymbolsFunc{
+			defaultHook: i.UpdateUnreferencedSymbols,
+		},
 		UpdateUploadRetentionFunc: &StoreUpdateUploadRetentionFunc{
 			defaultHook: i.UpdateUploadRetention,
 		},
@@ -4681,6 +4862,117 @@ func (c StoreGetAuditLogsForUploadFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreGetCodeIntelCoverageFunc describes the behavior when the
+// GetCodeIntelCoverage method of the parent MockStore instance is invoked.
+type StoreGetCodeIntelCoverageFunc struct {
+	defaultHook func(context.Context, shared1.GetCodeIntelCoverageOptions) ([]shared1.CodeIntelCoverage, int, error)
+	hooks       []func(context.Context, shared1.GetCodeIntelCoverageOptions) ([]shared1.CodeIntelCoverage, int, error)
+	history     []StoreGetCodeIntelCoverageFuncCall
+	mutex       sync.Mutex
+}
+
+// GetCodeIntelCoverage delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) GetCodeIntelCoverage(v0 context.Context, v1 shared1.GetCodeIntelCoverageOptions) ([]shared1.CodeIntelCoverage, int, error) {
+	r0, r1, r2 := m.GetCodeIntelCoverageFunc.nextHook()(v0, v1)
+	m.GetCodeIntelCoverageFunc.appendCall(StoreGetCodeIntelCoverageFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the GetCodeIntelCoverage
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreGetCodeIntelCoverageFunc) SetDefaultHook(hook func(context.Context, shared1.GetCodeIntelCoverageOptions) ([]shared1.CodeIntelCoverage, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetCodeIntelCoverage method of the parent MockStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreGetCodeIntelCoverageFunc) PushHook(hook func(context.Context, shared1.GetCodeIntelCoverageOptions) ([]shared1.CodeIntelCoverage, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetCodeIntelCoverageFunc) SetDefaultReturn(r0 []shared1.CodeIntelCoverage, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, shared1.GetCodeIntelCoverageOptions) ([]shared1.CodeIntelCoverage, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetCodeIntelCoverageFunc) PushReturn(r0 []shared1.CodeIntelCoverage, r1 int, r2 error) {
+	f.PushHook(func(context.Context, shared1.GetCodeIntelCoverageOptions) ([]shared1.CodeIntelCoverage, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreGetCodeIntelCoverageFunc) nextHook() func(context.Context, shared1.GetCodeIntelCoverageOptions) ([]shared1.CodeIntelCoverage, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetCodeIntelCoverageFunc) appendCall(r0 StoreGetCodeIntelCoverageFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetCodeIntelCoverageFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetCodeIntelCoverageFunc) History() []StoreGetCodeIntelCoverageFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetCodeIntelCoverageFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetCodeIntelCoverageFuncCall is an object that describes an
+// invocation of method GetCodeIntelCoverage on an instance of MockStore.
+type StoreGetCodeIntelCoverageFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 shared1.GetCodeIntelCoverageOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared1.CodeIntelCoverage
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetCodeIntelCoverageFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetCodeIntelCoverageFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // StoreGetCommitGraphMetadataFunc describes the behavior when the
 // GetCommitGraphMetadata method of the parent MockStore instance is
 // invoked.
@@ -4912,35 +5204,35 @@ func (c StoreGetCommitsVisibleToUploadFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
-// StoreGetDirtyRepositoriesFunc describes the behavior when the
-// GetDirtyRepositories method of the parent MockStore instance is invoked.
-type StoreGetDirtyRepositoriesFunc struct {
-	defaultHook func(context.Context) (map[int]int, error)
-	hooks       []func(context.Context) (map[int]int, error)
-	history     []StoreGetDirtyRepositoriesFuncCall
+// StoreGetCoverageFailuresFunc describes the behavior when the
+// GetCoverageFailures method of the parent MockStore instance is invoked.
+type StoreGetCoverageFailuresFunc struct {
+	defaultHook func(context.Context, int) ([]store.CoverageFailure, error)
+	hooks       []func(context.Context, int) ([]store.CoverageFailure, error)
+	history     []StoreGetCoverageFailuresFuncCall
 	mutex       sync.Mutex
 }
 
-// GetDirtyRepositories delegates to the next hook function in the queue and
+// GetCoverageFailures delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockStore) GetDirtyRepositories(v0 context.Context) (map[int]int, error) {
-	r0, r1 := m.GetDirtyRepositoriesFunc.nextHook()(v0)
-	m.GetDirtyRepositoriesFunc.appendCall(StoreGetDirtyRepositoriesFuncCall{v0, r0, r1})
+func (m *MockStore) GetCoverageFailures(v0 context.Context, v1 int) ([]store.CoverageFailure, error) {
+	r0, r1 := m.GetCoverageFailuresFunc.nextHook()(v0, v1)
+	m.GetCoverageFailuresFunc.appendCall(StoreGetCoverageFailuresFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetDirtyRepositories
+// SetDefaultHook sets function that is called when the GetCoverageFailures
 // method of the parent MockStore instance is invoked and the hook queue is
 // empty.
-func (f *StoreGetDirtyRepositoriesFunc) SetDefaultHook(hook func(context.Context) (map[int]int, error)) {
+func (f *StoreGetCoverageFailuresFunc) SetDefaultHook(hook func(context.Context, int) ([]store.CoverageFailure, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetDirtyRepositories method of the parent MockStore instance invokes the
+// GetCoverageFailures method of the parent MockStore instance invokes the
 // hook at the front of the queue and discards it. After the queue is empty,
 // the default hook function is invoked for any future action.
-func (f *StoreGetDirtyRepositoriesFunc) PushHook(hook func(context.Context) (map[int]int, error)) {
+func (f *StoreGetCoverageFailuresFunc) PushHook(hook func(context.Context, int) ([]store.CoverageFailure, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -4948,20 +5240,20 @@ func (f *StoreGetDirtyRepositoriesFunc) PushHook(hook func(context.Context) (map
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *StoreGetDirtyRepositoriesFunc) SetDefaultReturn(r0 map[int]int, r1 error) {
-	f.SetDefaultHook(func(context.Context) (map[int]int, error) {
+func (f *StoreGetCoverageFailuresFunc) SetDefaultReturn(r0 []store.CoverageFailure, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]store.CoverageFailure, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *StoreGetDirtyRepositoriesFunc) PushReturn(r0 map[int]int, r1 error) {
-	f.PushHook(func(context.Context) (map[int]int, error) {
+func (f *StoreGetCoverageFailuresFunc) PushReturn(r0 []store.CoverageFailure, r1 error) {
+	f.PushHook(func(context.Context, int) ([]store.CoverageFailure, error) {
 		return r0, r1
 	})
 }
 
-func (f *StoreGetDirtyRepositoriesFunc) nextHook() func(context.Context) (map[int]int, error) {
+func (f *StoreGetCoverageFailuresFunc) nextHook() func(context.Context, int) ([]store.CoverageFailure, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -4974,32 +5266,35 @@ func (f *StoreGetDirtyRepositoriesFunc) nextHook() func(context.Context) (map[in
 	return hook
 }
 
-func (f *StoreGetDirtyRepositoriesFunc) appendCall(r0 StoreGetDirtyRepositoriesFuncCall) {
+func (f *StoreGetCoverageFailuresFunc) appendCall(r0 StoreGetCoverageFailuresFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of StoreGetDirtyRepositoriesFuncCall objects
+// History returns a sequence of StoreGetCoverageFailuresFuncCall objects
 // describing the invocations of this function.
-func (f *StoreGetDirtyRepositoriesFunc) History() []StoreGetDirtyRepositoriesFuncCall {
+func (f *StoreGetCoverageFailuresFunc) History() []StoreGetCoverageFailuresFuncCall {
 	f.mutex.Lock()
-	history := make([]StoreGetDirtyRepositoriesFuncCall, len(f.history))
+	history := make([]StoreGetCoverageFailuresFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// StoreGetDirtyRepositoriesFuncCall is an object that describes an
-// invocation of method GetDirtyRepositories on an instance of MockStore.
-type StoreGetDirtyRepositoriesFuncCall struct {
+// StoreGetCoverageFailuresFuncCall is an object that describes an
+// invocation of method GetCoverageFailures on an instance of MockStore.
+type StoreGetCoverageFailuresFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 map[int]int
+	Result0 []store.CoverageFailure
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -5007,44 +5302,46 @@ type StoreGetDirtyRepositoriesFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c StoreGetDirtyRepositoriesFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c StoreGetCoverageFailuresFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c StoreGetDirtyRepositoriesFuncCall) Results() []interface{} {
+func (c StoreGetCoverageFailuresFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// StoreGetDumpsByIDsFunc describes the behavior when the GetDumpsByIDs
-// method of the parent MockStore instance is invoked.
-type StoreGetDumpsByIDsFunc struct {
-	defaultHook func(context.Context, []int) ([]types.Dump, error)
-	hooks       []func(context.Context, []int) ([]types.Dump, error)
-	history     []StoreGetDumpsByIDsFuncCall
+// StoreGetCoverageUploadsAtTipFunc describes the behavior when the
+// GetCoverageUploadsAtTip method of the parent MockStore instance is
+// invoked.
+type StoreGetCoverageUploadsAtTipFunc struct {
+	defaultHook func(context.Context, int, string) ([]store.CoverageUpload, error)
+	hooks       []func(context.Context, int, string) ([]store.CoverageUpload, error)
+	history     []StoreGetCoverageUploadsAtTipFuncCall
 	mutex       sync.Mutex
 }
 
-// GetDumpsByIDs delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockStore) GetDumpsByIDs(v0 context.Context, v1 []int) ([]types.Dump, error) {
-	r0, r1 := m.GetDumpsByIDsFunc.nextHook()(v0, v1)
-	m.GetDumpsByIDsFunc.appendCall(StoreGetDumpsByIDsFuncCall{v0, v1, r0, r1})
+// GetCoverageUploadsAtTip delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) GetCoverageUploadsAtTip(v0 context.Context, v1 int, v2 string) ([]store.CoverageUpload, error) {
+	r0, r1 := m.GetCoverageUploadsAtTipFunc.nextHook()(v0, v1, v2)
+	m.GetCoverageUploadsAtTipFunc.appendCall(StoreGetCoverageUploadsAtTipFuncCall{v0, v1, v2, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetDumpsByIDs method
-// of the parent MockStore instance is invoked and the hook queue is empty.
-func (f *StoreGetDumpsByIDsFunc) SetDefaultHook(hook func(context.Context, []int) ([]types.Dump, error)) {
+// SetDefaultHook sets function that is called when the
+// GetCoverageUploadsAtTip method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreGetCoverageUploadsAtTipFunc) SetDefaultHook(hook func(context.Context, int, string) ([]store.CoverageUpload, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetDumpsByIDs method of the parent MockStore instance invokes the hook at
-// the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *StoreGetDumpsByIDsFunc) PushHook(hook func(context.Context, []int) ([]types.Dump, error)) {
+// GetCoverageUploadsAtTip method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreGetCoverageUploadsAtTipFunc) PushHook(hook func(context.Context, int, string) ([]store.CoverageUpload, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -5052,20 +5349,20 @@ func (f *StoreGetDumpsByIDsFunc) PushHook(hook func(context.Context, []int) ([]t
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *StoreGetDumpsByIDsFunc) SetDefaultReturn(r0 []types.Dump, r1 error) {
-	f.SetDefaultHook(func(context.Context, []int) ([]types.Dump, error) {
+func (f *StoreGetCoverageUploadsAtTipFunc) SetDefaultReturn(r0 []store.CoverageUpload, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string) ([]store.CoverageUpload, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *StoreGetDumpsByIDsFunc) PushReturn(r0 []types.Dump, r1 error) {
-	f.PushHook(func(context.Context, []int) ([]types.Dump, error) {
+func (f *StoreGetCoverageUploadsAtTipFunc) PushReturn(r0 []store.CoverageUpload, r1 error) {
+	f.PushHook(func(context.Context, int, string) ([]store.CoverageUpload, error) {
 		return r0, r1
 	})
 }
 
-func (f *StoreGetDumpsByIDsFunc) nextHook() func(context.Context, []int) ([]types.Dump, error) {
+func (f *StoreGetCoverageUploadsAtTipFunc) nextHook() func(context.Context, int, string) ([]store.CoverageUpload, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -5078,35 +5375,38 @@ func (f *StoreGetDumpsByIDsFunc) nextHook() func(context.Context, []int) ([]type
 	return hook
 }
 
-func (f *StoreGetDumpsByIDsFunc) appendCall(r0 StoreGetDumpsByIDsFuncCall) {
+func (f *StoreGetCoverageUploadsAtTipFunc) appendCall(r0 StoreGetCoverageUploadsAtTipFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of StoreGetDumpsByIDsFuncCall objects
-// describing the invocations of this function.
-func (f *StoreGetDumpsByIDsFunc) History() []StoreGetDumpsByIDsFuncCall {
+// History returns a sequence of StoreGetCoverageUploadsAtTipFuncCall
+// objects describing the invocations of this function.
+func (f *StoreGetCoverageUploadsAtTipFunc) History() []StoreGetCoverageUploadsAtTipFuncCall {
 	f.mutex.Lock()
-	history := make([]StoreGetDumpsByIDsFuncCall, len(f.history))
+	history := make([]StoreGetCoverageUploadsAtTipFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// StoreGetDumpsByIDsFuncCall is an object that describes an invocation of
-// method GetDumpsByIDs on an instance of MockStore.
-type StoreGetDumpsByIDsFuncCall struct {
+// StoreGetCoverageUploadsAtTipFuncCall is an object that describes an
+// invocation of method GetCoverageUploadsAtTip on an instance of MockStore.
+type StoreGetCoverageUploadsAtTipFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 []int
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []types.Dump
+	Result0 []store.CoverageUpload
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -5114,31 +5414,243 @@ type StoreGetDumpsByIDsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c StoreGetDumpsByIDsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c StoreGetCoverageUploadsAtTipFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c StoreGetDumpsByIDsFuncCall) Results() []interface{} {
+func (c StoreGetCoverageUploadsAtTipFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// StoreGetDumpsWithDefinitionsForMonikersFunc describes the behavior when
-// the GetDumpsWithDefinitionsForMonikers method of the parent MockStore
-// instance is invoked.
-type StoreGetDumpsWithDefinitionsForMonikersFunc struct {
-	defaultHook func(context.Context, []precise.QualifiedMonikerData) ([]types.Dump, error)
-	hooks       []func(context.Context, []precise.QualifiedMonikerData) ([]types.Dump, error)
-	history     []StoreGetDumpsWithDefinitionsForMonikersFuncCall
+// StoreGetDirtyRepositoriesFunc describes the behavior when the
+// GetDirtyRepositories method of the parent MockStore instance is invoked.
+type StoreGetDirtyRepositoriesFunc struct {
+	defaultHook func(context.Context) (map[int]int, error)
+	hooks       []func(context.Context) (map[int]int, error)
+	history     []StoreGetDirtyRepositoriesFuncCall
 	mutex       sync.Mutex
 }
 
-// GetDumpsWithDefinitionsForMonikers delegates to the next hook function in
-// the queue and stores the parameter and result values of this invocation.
-func (m *MockStore) GetDumpsWithDefinitionsForMonikers(v0 context.Context, v1 []precise.QualifiedMonikerData) ([]types.Dump, error) {
-	r0, r1 := m.GetDumpsWithDefinitionsForMonikersFunc.nextHook()(v0, v1)
-	m.GetDumpsWithDefinitionsForMonikersFunc.appendCall(StoreGetDumpsWithDefinitionsForMonikersFuncCall{v0, v1, r0, r1})
+// GetDirtyRepositories delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) GetDirtyRepositories(v0 context.Context) (map[int]int, error) {
+	r0, r1 := m.GetDirtyRepositoriesFunc.nextHook()(v0)
+	m.GetDirtyRepositoriesFunc.appendCall(StoreGetDirtyRepositoriesFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetDirtyRepositories
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreGetDirtyRepositoriesFunc) SetDefaultHook(hook func(context.Context) (map[int]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetDirtyRepositories method of the parent MockStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreGetDirtyRepositoriesFunc) PushHook(hook func(context.Context) (map[int]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetDirtyRepositoriesFunc) SetDefaultReturn(r0 map[int]int, r1 error) {
+	f.SetDefaultHook(func(context.Context) (map[int]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetDirtyRepositoriesFunc) PushReturn(r0 map[int]int, r1 error) {
+	f.PushHook(func(context.Context) (map[int]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetDirtyRepositoriesFunc) nextHook() func(context.Context) (map[int]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetDirtyRepositoriesFunc) appendCall(r0 StoreGetDirtyRepositoriesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetDirtyRepositoriesFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetDirtyRepositoriesFunc) History() []StoreGetDirtyRepositoriesFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetDirtyRepositoriesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetDirtyRepositoriesFuncCall is an object that describes an
+// invocation of method GetDirtyRepositories on an instance of MockStore.
+type StoreGetDirtyRepositoriesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[int]int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetDirtyRepositoriesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetDirtyRepositoriesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreGetDumpsByIDsFunc describes the behavior when the GetDumpsByIDs
+// method of the parent MockStore instance is invoked.
+type StoreGetDumpsByIDsFunc struct {
+	defaultHook func(context.Context, []int) ([]types.Dump, error)
+	hooks       []func(context.Context, []int) ([]types.Dump, error)
+	history     []StoreGetDumpsByIDsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetDumpsByIDs delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockStore) GetDumpsByIDs(v0 context.Context, v1 []int) ([]types.Dump, error) {
+	r0, r1 := m.GetDumpsByIDsFunc.nextHook()(v0, v1)
+	m.GetDumpsByIDsFunc.appendCall(StoreGetDumpsByIDsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetDumpsByIDs method
+// of the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreGetDumpsByIDsFunc) SetDefaultHook(hook func(context.Context, []int) ([]types.Dump, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetDumpsByIDs method of the parent MockStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreGetDumpsByIDsFunc) PushHook(hook func(context.Context, []int) ([]types.Dump, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetDumpsByIDsFunc) SetDefaultReturn(r0 []types.Dump, r1 error) {
+	f.SetDefaultHook(func(context.Context, []int) ([]types.Dump, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetDumpsByIDsFunc) PushReturn(r0 []types.Dump, r1 error) {
+	f.PushHook(func(context.Context, []int) ([]types.Dump, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetDumpsByIDsFunc) nextHook() func(context.Context, []int) ([]types.Dump, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetDumpsByIDsFunc) appendCall(r0 StoreGetDumpsByIDsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetDumpsByIDsFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetDumpsByIDsFunc) History() []StoreGetDumpsByIDsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetDumpsByIDsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetDumpsByIDsFuncCall is an object that describes an invocation of
+// method GetDumpsByIDs on an instance of MockStore.
+type StoreGetDumpsByIDsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []types.Dump
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetDumpsByIDsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetDumpsByIDsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreGetDumpsWithDefinitionsForMonikersFunc describes the behavior when
+// the GetDumpsWithDefinitionsForMonikers method of the parent MockStore
+// instance is invoked.
+type StoreGetDumpsWithDefinitionsForMonikersFunc struct {
+	defaultHook func(context.Context, []precise.QualifiedMonikerData) ([]types.Dump, error)
+	hooks       []func(context.Context, []precise.QualifiedMonikerData) ([]types.Dump, error)
+	history     []StoreGetDumpsWithDefinitionsForMonikersFuncCall
+	mutex       sync.Mutex
+}
+
+// GetDumpsWithDefinitionsForMonikers delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockStore) GetDumpsWithDefinitionsForMonikers(v0 context.Context, v1 []precise.QualifiedMonikerData) ([]types.Dump, error) {
+	r0, r1 := m.GetDumpsWithDefinitionsForMonikersFunc.nextHook()(v0, v1)
+	m.GetDumpsWithDefinitionsForMonikersFunc.appendCall(StoreGetDumpsWithDefinitionsForMonikersFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
@@ -5569,37 +6081,36 @@ func (c StoreGetRecentUploadsSummaryFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// StoreGetRepositoriesForIndexScanFunc describes the behavior when the
-// GetRepositoriesForIndexScan method of the parent MockStore instance is
+// StoreGetReferencingUploadIDsFunc describes the behavior when the
+// GetReferencingUploadIDs method of the parent MockStore instance is
 // invoked.
-type StoreGetRepositoriesForIndexScanFunc struct {
-	defaultHook func(context.Context, string, string, time.Duration, bool, *int, int, time.Time) ([]int, error)
-	hooks       []func(context.Context, string, string, time.Duration, bool, *int, int, time.Time) ([]int, error)
-	history     []StoreGetRepositoriesForIndexScanFuncCall
+type StoreGetReferencingUploadIDsFunc struct {
+	defaultHook func(context.Context, int) ([]int, error)
+	hooks       []func(context.Context, int) ([]int, error)
+	history     []StoreGetReferencingUploadIDsFuncCall
 	mutex       sync.Mutex
 }
 
-// GetRepositoriesForIndexScan delegates to the next hook function in the
-// queue and stores the parameter and result values of this invocation.
-func (m *MockStore) GetRepositoriesForIndexScan(v0 context.Context, v1 string, v2 string, v3 time.Duration, v4 bool, v5 *int, v6 int, v7 time.Time) ([]int, error) {
-	r0, r1 := m.GetRepositoriesForIndexScanFunc.nextHook()(v0, v1, v2, v3, v4, v5, v6, v7)
-	m.GetRepositoriesForIndexScanFunc.appendCall(StoreGetRepositoriesForIndexScanFuncCall{v0, v1, v2, v3, v4, v5, v6, v7, r0, r1})
+// GetReferencingUploadIDs delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) GetReferencingUploadIDs(v0 context.Context, v1 int) ([]int, error) {
+	r0, r1 := m.GetReferencingUploadIDsFunc.nextHook()(v0, v1)
+	m.GetReferencingUploadIDsFunc.appendCall(StoreGetReferencingUploadIDsFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the
-// GetRepositoriesForIndexScan method of the parent MockStore instance is
+// GetReferencingUploadIDs method of the parent MockStore instance is
 // invoked and the hook queue is empty.
-func (f *StoreGetRepositoriesForIndexScanFunc) SetDefaultHook(hook func(context.Context, string, string, time.Duration, bool, *int, int, time.Time) ([]int, error)) {
+func (f *StoreGetReferencingUploadIDsFunc) SetDefaultHook(hook func(context.Context, int) ([]int, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetRepositoriesForIndexScan method of the parent MockStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *StoreGetRepositoriesForIndexScanFunc) PushHook(hook func(context.Context, string, string, time.Duration, bool, *int, int, time.Time) ([]int, error)) {
+// GetReferencingUploadIDs method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreGetReferencingUploadIDsFunc) PushHook(hook func(context.Context, int) ([]int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -5607,20 +6118,20 @@ func (f *StoreGetRepositoriesForIndexScanFunc) PushHook(hook func(context.Contex
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *StoreGetRepositoriesForIndexScanFunc) SetDefaultReturn(r0 []int, r1 error) {
-	f.SetDefaultHook(func(context.Context, string, string, time.Duration, bool, *int, int, time.Time) ([]int, error) {
+func (f *StoreGetReferencingUploadIDsFunc) SetDefaultReturn(r0 []int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]int, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *StoreGetRepositoriesForIndexScanFunc) PushReturn(r0 []int, r1 error) {
-	f.PushHook(func(context.Context, string, string, time.Duration, bool, *int, int, time.Time) ([]int, error) {
+func (f *StoreGetReferencingUploadIDsFunc) PushReturn(r0 []int, r1 error) {
+	f.PushHook(func(context.Context, int) ([]int, error) {
 		return r0, r1
 	})
 }
 
-func (f *StoreGetRepositoriesForIndexScanFunc) nextHook() func(context.Context, string, string, time.Duration, bool, *int, int, time.Time) ([]int, error) {
+func (f *StoreGetReferencingUploadIDsFunc) nextHook() func(context.Context, int) ([]int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -5633,27 +6144,255 @@ func (f *StoreGetRepositoriesForIndexScanFunc) nextHook() func(context.Context,
 	return hook
 }
 
-func (f *StoreGetRepositoriesForIndexScanFunc) appendCall(r0 StoreGetRepositoriesForIndexScanFuncCall) {
+func (f *StoreGetReferencingUploadIDsFunc) appendCall(r0 StoreGetReferencingUploadIDsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of StoreGetRepositoriesForIndexScanFuncCall
+// History returns a sequence of StoreGetReferencingUploadIDsFuncCall
 // objects describing the invocations of this function.
-func (f *StoreGetRepositoriesForIndexScanFunc) History() []StoreGetRepositoriesForIndexScanFuncCall {
+func (f *StoreGetReferencingUploadIDsFunc) History() []StoreGetReferencingUploadIDsFuncCall {
 	f.mutex.Lock()
-	history := make([]StoreGetRepositoriesForIndexScanFuncCall, len(f.history))
+	history := make([]StoreGetReferencingUploadIDsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// StoreGetRepositoriesForIndexScanFuncCall is an object that describes an
-// invocation of method GetRepositoriesForIndexScan on an instance of
-// MockStore.
-type StoreGetRepositoriesForIndexScanFuncCall struct {
+// StoreGetReferencingUploadIDsFuncCall is an object that describes an
+// invocation of method GetReferencingUploadIDs on an instance of MockStore.
+type StoreGetReferencingUploadIDsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetReferencingUploadIDsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetReferencingUploadIDsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreGetRepositoriesForCoverageReportFunc describes the behavior when the
+// GetRepositoriesForCoverageReport method of the parent MockStore instance
+// is invoked.
+type StoreGetRepositoriesForCoverageReportFunc struct {
+	defaultHook func(context.Context, time.Duration, int, time.Time) ([]int, error)
+	hooks       []func(context.Context, time.Duration, int, time.Time) ([]int, error)
+	history     []StoreGetRepositoriesForCoverageReportFuncCall
+	mutex       sync.Mutex
+}
+
+// GetRepositoriesForCoverageReport delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockStore) GetRepositoriesForCoverageReport(v0 context.Context, v1 time.Duration, v2 int, v3 time.Time) ([]int, error) {
+	r0, r1 := m.GetRepositoriesForCoverageReportFunc.nextHook()(v0, v1, v2, v3)
+	m.GetRepositoriesForCoverageReportFunc.appendCall(StoreGetRepositoriesForCoverageReportFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetRepositoriesForCoverageReport method of the parent MockStore instance
+// is invoked and the hook queue is empty.
+func (f *StoreGetRepositoriesForCoverageReportFunc) SetDefaultHook(hook func(context.Context, time.Duration, int, time.Time) ([]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetRepositoriesForCoverageReport method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreGetRepositoriesForCoverageReportFunc) PushHook(hook func(context.Context, time.Duration, int, time.Time) ([]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetRepositoriesForCoverageReportFunc) SetDefaultReturn(r0 []int, r1 error) {
+	f.SetDefaultHook(func(context.Context, time.Duration, int, time.Time) ([]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetRepositoriesForCoverageReportFunc) PushReturn(r0 []int, r1 error) {
+	f.PushHook(func(context.Context, time.Duration, int, time.Time) ([]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetRepositoriesForCoverageReportFunc) nextHook() func(context.Context, time.Duration, int, time.Time) ([]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetRepositoriesForCoverageReportFunc) appendCall(r0 StoreGetRepositoriesForCoverageReportFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// StoreGetRepositoriesForCoverageReportFuncCall objects describing the
+// invocations of this function.
+func (f *StoreGetRepositoriesForCoverageReportFunc) History() []StoreGetRepositoriesForCoverageReportFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetRepositoriesForCoverageReportFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetRepositoriesForCoverageReportFuncCall is an object that describes
+// an invocation of method GetRepositoriesForCoverageReport on an instance
+// of MockStore.
+type StoreGetRepositoriesForCoverageReportFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 time.Duration
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetRepositoriesForCoverageReportFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetRepositoriesForCoverageReportFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreGetRepositoriesForIndexScanFunc describes the behavior when the
+// GetRepositoriesForIndexScan method of the parent MockStore instance is
+// invoked.
+type StoreGetRepositoriesForIndexScanFunc struct {
+	defaultHook func(context.Context, string, string, time.Duration, bool, *int, int, time.Time) ([]int, error)
+	hooks       []func(context.Context, string, string, time.Duration, bool, *int, int, time.Time) ([]int, error)
+	history     []StoreGetRepositoriesForIndexScanFuncCall
+	mutex       sync.Mutex
+}
+
+// GetRepositoriesForIndexScan delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) GetRepositoriesForIndexScan(v0 context.Context, v1 string, v2 string, v3 time.Duration, v4 bool, v5 *int, v6 int, v7 time.Time) ([]int, error) {
+	r0, r1 := m.GetRepositoriesForIndexScanFunc.nextHook()(v0, v1, v2, v3, v4, v5, v6, v7)
+	m.GetRepositoriesForIndexScanFunc.appendCall(StoreGetRepositoriesForIndexScanFuncCall{v0, v1, v2, v3, v4, v5, v6, v7, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetRepositoriesForIndexScan method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreGetRepositoriesForIndexScanFunc) SetDefaultHook(hook func(context.Context, string, string, time.Duration, bool, *int, int, time.Time) ([]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetRepositoriesForIndexScan method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreGetRepositoriesForIndexScanFunc) PushHook(hook func(context.Context, string, string, time.Duration, bool, *int, int, time.Time) ([]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetRepositoriesForIndexScanFunc) SetDefaultReturn(r0 []int, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string, time.Duration, bool, *int, int, time.Time) ([]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetRepositoriesForIndexScanFunc) PushReturn(r0 []int, r1 error) {
+	f.PushHook(func(context.Context, string, string, time.Duration, bool, *int, int, time.Time) ([]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetRepositoriesForIndexScanFunc) nextHook() func(context.Context, string, string, time.Duration, bool, *int, int, time.Time) ([]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetRepositoriesForIndexScanFunc) appendCall(r0 StoreGetRepositoriesForIndexScanFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetRepositoriesForIndexScanFuncCall
+// objects describing the invocations of this function.
+func (f *StoreGetRepositoriesForIndexScanFunc) History() []StoreGetRepositoriesForIndexScanFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetRepositoriesForIndexScanFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetRepositoriesForIndexScanFuncCall is an object that describes an
+// invocation of method GetRepositoriesForIndexScan on an instance of
+// MockStore.
+type StoreGetRepositoriesForIndexScanFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -5921,34 +6660,36 @@ func (c StoreGetStaleSourcedCommitsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// StoreGetUploadByIDFunc describes the behavior when the GetUploadByID
-// method of the parent MockStore instance is invoked.
-type StoreGetUploadByIDFunc struct {
-	defaultHook func(context.Context, int) (types.Upload, bool, error)
-	hooks       []func(context.Context, int) (types.Upload, bool, error)
-	history     []StoreGetUploadByIDFuncCall
+// StoreGetUnreferencedSymbolsFunc describes the behavior when the
+// GetUnreferencedSymbols method of the parent MockStore instance is
+// invoked.
+type StoreGetUnreferencedSymbolsFunc struct {
+	defaultHook func(context.Context, shared1.GetUnreferencedSymbolsOptions) ([]shared1.UnreferencedSymbol, int, error)
+	hooks       []func(context.Context, shared1.GetUnreferencedSymbolsOptions) ([]shared1.UnreferencedSymbol, int, error)
+	history     []StoreGetUnreferencedSymbolsFuncCall
 	mutex       sync.Mutex
 }
 
-// GetUploadByID delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockStore) GetUploadByID(v0 context.Context, v1 int) (types.Upload, bool, error) {
-	r0, r1, r2 := m.GetUploadByIDFunc.nextHook()(v0, v1)
-	m.GetUploadByIDFunc.appendCall(StoreGetUploadByIDFuncCall{v0, v1, r0, r1, r2})
+// GetUnreferencedSymbols delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) GetUnreferencedSymbols(v0 context.Context, v1 shared1.GetUnreferencedSymbolsOptions) ([]shared1.UnreferencedSymbol, int, error) {
+	r0, r1, r2 := m.GetUnreferencedSymbolsFunc.nextHook()(v0, v1)
+	m.GetUnreferencedSymbolsFunc.appendCall(StoreGetUnreferencedSymbolsFuncCall{v0, v1, r0, r1, r2})
 	return r0, r1, r2
 }
 
-// SetDefaultHook sets function that is called when the GetUploadByID method
-// of the parent MockStore instance is invoked and the hook queue is empty.
-func (f *StoreGetUploadByIDFunc) SetDefaultHook(hook func(context.Context, int) (types.Upload, bool, error)) {
+// SetDefaultHook sets function that is called when the
+// GetUnreferencedSymbols method of the parent MockStore instance is invoked
+// and the hook queue is empty.
+func (f *StoreGetUnreferencedSymbolsFunc) SetDefaultHook(hook func(context.Context, shared1.GetUnreferencedSymbolsOptions) ([]shared1.UnreferencedSymbol, int, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetUploadByID method of the parent MockStore instance invokes the hook at
-// the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *StoreGetUploadByIDFunc) PushHook(hook func(context.Context, int) (types.Upload, bool, error)) {
+// GetUnreferencedSymbols method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreGetUnreferencedSymbolsFunc) PushHook(hook func(context.Context, shared1.GetUnreferencedSymbolsOptions) ([]shared1.UnreferencedSymbol, int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -5956,20 +6697,20 @@ func (f *StoreGetUploadByIDFunc) PushHook(hook func(context.Context, int) (types
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *StoreGetUploadByIDFunc) SetDefaultReturn(r0 types.Upload, r1 bool, r2 error) {
-	f.SetDefaultHook(func(context.Context, int) (types.Upload, bool, error) {
+func (f *StoreGetUnreferencedSymbolsFunc) SetDefaultReturn(r0 []shared1.UnreferencedSymbol, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, shared1.GetUnreferencedSymbolsOptions) ([]shared1.UnreferencedSymbol, int, error) {
 		return r0, r1, r2
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *StoreGetUploadByIDFunc) PushReturn(r0 types.Upload, r1 bool, r2 error) {
-	f.PushHook(func(context.Context, int) (types.Upload, bool, error) {
+func (f *StoreGetUnreferencedSymbolsFunc) PushReturn(r0 []shared1.UnreferencedSymbol, r1 int, r2 error) {
+	f.PushHook(func(context.Context, shared1.GetUnreferencedSymbolsOptions) ([]shared1.UnreferencedSymbol, int, error) {
 		return r0, r1, r2
 	})
 }
 
-func (f *StoreGetUploadByIDFunc) nextHook() func(context.Context, int) (types.Upload, bool, error) {
+func (f *StoreGetUnreferencedSymbolsFunc) nextHook() func(context.Context, shared1.GetUnreferencedSymbolsOptions) ([]shared1.UnreferencedSymbol, int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -5982,38 +6723,38 @@ func (f *StoreGetUploadByIDFunc) nextHook() func(context.Context, int) (types.Up
 	return hook
 }
 
-func (f *StoreGetUploadByIDFunc) appendCall(r0 StoreGetUploadByIDFuncCall) {
+func (f *StoreGetUnreferencedSymbolsFunc) appendCall(r0 StoreGetUnreferencedSymbolsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of StoreGetUploadByIDFuncCall objects
+// History returns a sequence of StoreGetUnreferencedSymbolsFuncCall objects
 // describing the invocations of this function.
-func (f *StoreGetUploadByIDFunc) History() []StoreGetUploadByIDFuncCall {
+func (f *StoreGetUnreferencedSymbolsFunc) History() []StoreGetUnreferencedSymbolsFuncCall {
 	f.mutex.Lock()
-	history := make([]StoreGetUploadByIDFuncCall, len(f.history))
+	history := make([]StoreGetUnreferencedSymbolsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// StoreGetUploadByIDFuncCall is an object that describes an invocation of
-// method GetUploadByID on an instance of MockStore.
-type StoreGetUploadByIDFuncCall struct {
+// StoreGetUnreferencedSymbolsFuncCall is an object that describes an
+// invocation of method GetUnreferencedSymbols on an instance of MockStore.
+type StoreGetUnreferencedSymbolsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int
+	Arg1 shared1.GetUnreferencedSymbolsOptions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 types.Upload
+	Result0 []shared1.UnreferencedSymbol
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 bool
+	Result1 int
 	// Result2 is the value of the 3rd result returned from this method
 	// invocation.
 	Result2 error
@@ -6021,32 +6762,256 @@ type StoreGetUploadByIDFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c StoreGetUploadByIDFuncCall) Args() []interface{} {
+func (c StoreGetUnreferencedSymbolsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c StoreGetUploadByIDFuncCall) Results() []interface{} {
+func (c StoreGetUnreferencedSymbolsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
-// StoreGetUploadIDsWithReferencesFunc describes the behavior when the
-// GetUploadIDsWithReferences method of the parent MockStore instance is
+// StoreGetUnreferencedSymbolsSummaryFunc describes the behavior when the
+// GetUnreferencedSymbolsSummary method of the parent MockStore instance is
 // invoked.
-type StoreGetUploadIDsWithReferencesFunc struct {
-	defaultHook func(context.Context, []precise.QualifiedMonikerData, []int, int, string, int, int, observation.TraceLogger) ([]int, int, int, error)
-	hooks       []func(context.Context, []precise.QualifiedMonikerData, []int, int, string, int, int, observation.TraceLogger) ([]int, int, int, error)
-	history     []StoreGetUploadIDsWithReferencesFuncCall
+type StoreGetUnreferencedSymbolsSummaryFunc struct {
+	defaultHook func(context.Context, int) (shared1.UnreferencedSymbolsSummary, bool, error)
+	hooks       []func(context.Context, int) (shared1.UnreferencedSymbolsSummary, bool, error)
+	history     []StoreGetUnreferencedSymbolsSummaryFuncCall
 	mutex       sync.Mutex
 }
 
-// GetUploadIDsWithReferences delegates to the next hook function in the
+// GetUnreferencedSymbolsSummary delegates to the next hook function in the
 // queue and stores the parameter and result values of this invocation.
-func (m *MockStore) GetUploadIDsWithReferences(v0 context.Context, v1 []precise.QualifiedMonikerData, v2 []int, v3 int, v4 string, v5 int, v6 int, v7 observation.TraceLogger) ([]int, int, int, error) {
-	r0, r1, r2, r3 := m.GetUploadIDsWithReferencesFunc.nextHook()(v0, v1, v2, v3, v4, v5, v6, v7)
-	m.GetUploadIDsWithReferencesFunc.appendCall(StoreGetUploadIDsWithReferencesFuncCall{v0, v1, v2, v3, v4, v5, v6, v7, r0, r1, r2, r3})
-	return r0, r1, r2, r3
+func (m *MockStore) GetUnreferencedSymbolsSummary(v0 context.Context, v1 int) (shared1.UnreferencedSymbolsSummary, bool, error) {
+	r0, r1, r2 := m.GetUnreferencedSymbolsSummaryFunc.nextHook()(v0, v1)
+	m.GetUnreferencedSymbolsSummaryFunc.appendCall(StoreGetUnreferencedSymbolsSummaryFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// GetUnreferencedSymbolsSummary method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreGetUnreferencedSymbolsSummaryFunc) SetDefaultHook(hook func(context.Context, int) (shared1.UnreferencedSymbolsSummary, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUnreferencedSymbolsSummary method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreGetUnreferencedSymbolsSummaryFunc) PushHook(hook func(context.Context, int) (shared1.UnreferencedSymbolsSummary, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetUnreferencedSymbolsSummaryFunc) SetDefaultReturn(r0 shared1.UnreferencedSymbolsSummary, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) (shared1.UnreferencedSymbolsSummary, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetUnreferencedSymbolsSummaryFunc) PushReturn(r0 shared1.UnreferencedSymbolsSummary, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) (shared1.UnreferencedSymbolsSummary, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreGetUnreferencedSymbolsSummaryFunc) nextHook() func(context.Context, int) (shared1.UnreferencedSymbolsSummary, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetUnreferencedSymbolsSummaryFunc) appendCall(r0 StoreGetUnreferencedSymbolsSummaryFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetUnreferencedSymbolsSummaryFuncCall
+// objects describing the invocations of this function.
+func (f *StoreGetUnreferencedSymbolsSummaryFunc) History() []StoreGetUnreferencedSymbolsSummaryFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetUnreferencedSymbolsSummaryFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetUnreferencedSymbolsSummaryFuncCall is an object that describes an
+// invocation of method GetUnreferencedSymbolsSummary on an instance of
+// MockStore.
+type StoreGetUnreferencedSymbolsSummaryFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 shared1.UnreferencedSymbolsSummary
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetUnreferencedSymbolsSummaryFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetUnreferencedSymbolsSummaryFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// StoreGetUploadByIDFunc describes the behavior when the GetUploadByID
+// method of the parent MockStore instance is invoked.
+type StoreGetUploadByIDFunc struct {
+	defaultHook func(context.Context, int) (types.Upload, bool, error)
+	hooks       []func(context.Context, int) (types.Upload, bool, error)
+	history     []StoreGetUploadByIDFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUploadByID delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockStore) GetUploadByID(v0 context.Context, v1 int) (types.Upload, bool, error) {
+	r0, r1, r2 := m.GetUploadByIDFunc.nextHook()(v0, v1)
+	m.GetUploadByIDFunc.appendCall(StoreGetUploadByIDFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the GetUploadByID method
+// of the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreGetUploadByIDFunc) SetDefaultHook(hook func(context.Context, int) (types.Upload, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUploadByID method of the parent MockStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreGetUploadByIDFunc) PushHook(hook func(context.Context, int) (types.Upload, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetUploadByIDFunc) SetDefaultReturn(r0 types.Upload, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) (types.Upload, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetUploadByIDFunc) PushReturn(r0 types.Upload, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) (types.Upload, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreGetUploadByIDFunc) nextHook() func(context.Context, int) (types.Upload, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetUploadByIDFunc) appendCall(r0 StoreGetUploadByIDFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetUploadByIDFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetUploadByIDFunc) History() []StoreGetUploadByIDFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetUploadByIDFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetUploadByIDFuncCall is an object that describes an invocation of
+// method GetUploadByID on an instance of MockStore.
+type StoreGetUploadByIDFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 types.Upload
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetUploadByIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetUploadByIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// StoreGetUploadIDsWithReferencesFunc describes the behavior when the
+// GetUploadIDsWithReferences method of the parent MockStore instance is
+// invoked.
+type StoreGetUploadIDsWithReferencesFunc struct {
+	defaultHook func(context.Context, []precise.QualifiedMonikerData, []int, int, string, int, int, observation.TraceLogger) ([]int, int, int, error)
+	hooks       []func(context.Context, []precise.QualifiedMonikerData, []int, int, string, int, int, observation.TraceLogger) ([]int, int, int, error)
+	history     []StoreGetUploadIDsWithReferencesFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUploadIDsWithReferences delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) GetUploadIDsWithReferences(v0 context.Context, v1 []precise.QualifiedMonikerData, v2 []int, v3 int, v4 string, v5 int, v6 int, v7 observation.TraceLogger) ([]int, int, int, error) {
+	r0, r1, r2, r3 := m.GetUploadIDsWithReferencesFunc.nextHook()(v0, v1, v2, v3, v4, v5, v6, v7)
+	m.GetUploadIDsWithReferencesFunc.appendCall(StoreGetUploadIDsWithReferencesFuncCall{v0, v1, v2, v3, v4, v5, v6, v7, r0, r1, r2, r3})
+	return r0, r1, r2, r3
 }
 
 // SetDefaultHook sets function that is called when the
@@ -6623,37 +7588,38 @@ func (c StoreGetUploadsForRankingFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// StoreGetVisibleUploadsMatchingMonikersFunc describes the behavior when
-// the GetVisibleUploadsMatchingMonikers method of the parent MockStore
-// instance is invoked.
-type StoreGetVisibleUploadsMatchingMonikersFunc struct {
-	defaultHook func(context.Context, int, string, []precise.QualifiedMonikerData, int, int) (shared1.PackageReferenceScanner, int, error)
-	hooks       []func(context.Context, int, string, []precise.QualifiedMonikerData, int, int) (shared1.PackageReferenceScanner, int, error)
-	history     []StoreGetVisibleUploadsMatchingMonikersFuncCall
+// StoreGetUploadsForUnreferencedSymbolsReportFunc describes the behavior
+// when the GetUploadsForUnreferencedSymbolsReport method of the parent
+// MockStore instance is invoked.
+type StoreGetUploadsForUnreferencedSymbolsReportFunc struct {
+	defaultHook func(context.Context, time.Duration, int, time.Time) ([]store.UnreferencedSymbolsUpload, error)
+	hooks       []func(context.Context, time.Duration, int, time.Time) ([]store.UnreferencedSymbolsUpload, error)
+	history     []StoreGetUploadsForUnreferencedSymbolsReportFuncCall
 	mutex       sync.Mutex
 }
 
-// GetVisibleUploadsMatchingMonikers delegates to the next hook function in
-// the queue and stores the parameter and result values of this invocation.
-func (m *MockStore) GetVisibleUploadsMatchingMonikers(v0 context.Context, v1 int, v2 string, v3 []precise.QualifiedMonikerData, v4 int, v5 int) (shared1.PackageReferenceScanner, int, error) {
-	r0, r1, r2 := m.GetVisibleUploadsMatchingMonikersFunc.nextHook()(v0, v1, v2, v3, v4, v5)
-	m.GetVisibleUploadsMatchingMonikersFunc.appendCall(StoreGetVisibleUploadsMatchingMonikersFuncCall{v0, v1, v2, v3, v4, v5, r0, r1, r2})
-	return r0, r1, r2
+// GetUploadsForUnreferencedSymbolsReport delegates to the next hook
+// function in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockStore) GetUploadsForUnreferencedSymbolsReport(v0 context.Context, v1 time.Duration, v2 int, v3 time.Time) ([]store.UnreferencedSymbolsUpload, error) {
+	r0, r1 := m.GetUploadsForUnreferencedSymbolsReportFunc.nextHook()(v0, v1, v2, v3)
+	m.GetUploadsForUnreferencedSymbolsReportFunc.appendCall(StoreGetUploadsForUnreferencedSymbolsReportFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the
-// GetVisibleUploadsMatchingMonikers method of the parent MockStore instance
-// is invoked and the hook queue is empty.
-func (f *StoreGetVisibleUploadsMatchingMonikersFunc) SetDefaultHook(hook func(context.Context, int, string, []precise.QualifiedMonikerData, int, int) (shared1.PackageReferenceScanner, int, error)) {
+// GetUploadsForUnreferencedSymbolsReport method of the parent MockStore
+// instance is invoked and the hook queue is empty.
+func (f *StoreGetUploadsForUnreferencedSymbolsReportFunc) SetDefaultHook(hook func(context.Context, time.Duration, int, time.Time) ([]store.UnreferencedSymbolsUpload, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetVisibleUploadsMatchingMonikers method of the parent MockStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *StoreGetVisibleUploadsMatchingMonikersFunc) PushHook(hook func(context.Context, int, string, []precise.QualifiedMonikerData, int, int) (shared1.PackageReferenceScanner, int, error)) {
+// GetUploadsForUnreferencedSymbolsReport method of the parent MockStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *StoreGetUploadsForUnreferencedSymbolsReportFunc) PushHook(hook func(context.Context, time.Duration, int, time.Time) ([]store.UnreferencedSymbolsUpload, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -6661,20 +7627,20 @@ func (f *StoreGetVisibleUploadsMatchingMonikersFunc) PushHook(hook func(context.
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *StoreGetVisibleUploadsMatchingMonikersFunc) SetDefaultReturn(r0 shared1.PackageReferenceScanner, r1 int, r2 error) {
-	f.SetDefaultHook(func(context.Context, int, string, []precise.QualifiedMonikerData, int, int) (shared1.PackageReferenceScanner, int, error) {
-		return r0, r1, r2
+func (f *StoreGetUploadsForUnreferencedSymbolsReportFunc) SetDefaultReturn(r0 []store.UnreferencedSymbolsUpload, r1 error) {
+	f.SetDefaultHook(func(context.Context, time.Duration, int, time.Time) ([]store.UnreferencedSymbolsUpload, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *StoreGetVisibleUploadsMatchingMonikersFunc) PushReturn(r0 shared1.PackageReferenceScanner, r1 int, r2 error) {
-	f.PushHook(func(context.Context, int, string, []precise.QualifiedMonikerData, int, int) (shared1.PackageReferenceScanner, int, error) {
-		return r0, r1, r2
+func (f *StoreGetUploadsForUnreferencedSymbolsReportFunc) PushReturn(r0 []store.UnreferencedSymbolsUpload, r1 error) {
+	f.PushHook(func(context.Context, time.Duration, int, time.Time) ([]store.UnreferencedSymbolsUpload, error) {
+		return r0, r1
 	})
 }
 
-func (f *StoreGetVisibleUploadsMatchingMonikersFunc) nextHook() func(context.Context, int, string, []precise.QualifiedMonikerData, int, int) (shared1.PackageReferenceScanner, int, error) {
+func (f *StoreGetUploadsForUnreferencedSymbolsReportFunc) nextHook() func(context.Context, time.Duration, int, time.Time) ([]store.UnreferencedSymbolsUpload, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -6687,34 +7653,152 @@ func (f *StoreGetVisibleUploadsMatchingMonikersFunc) nextHook() func(context.Con
 	return hook
 }
 
-func (f *StoreGetVisibleUploadsMatchingMonikersFunc) appendCall(r0 StoreGetVisibleUploadsMatchingMonikersFuncCall) {
+func (f *StoreGetUploadsForUnreferencedSymbolsReportFunc) appendCall(r0 StoreGetUploadsForUnreferencedSymbolsReportFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
 // History returns a sequence of
-// StoreGetVisibleUploadsMatchingMonikersFuncCall objects describing the
-// invocations of this function.
-func (f *StoreGetVisibleUploadsMatchingMonikersFunc) History() []StoreGetVisibleUploadsMatchingMonikersFuncCall {
+// StoreGetUploadsForUnreferencedSymbolsReportFuncCall objects describing
+// the invocations of this function.
+func (f *StoreGetUploadsForUnreferencedSymbolsReportFunc) History() []StoreGetUploadsForUnreferencedSymbolsReportFuncCall {
 	f.mutex.Lock()
-	history := make([]StoreGetVisibleUploadsMatchingMonikersFuncCall, len(f.history))
+	history := make([]StoreGetUploadsForUnreferencedSymbolsReportFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// StoreGetVisibleUploadsMatchingMonikersFuncCall is an object that
-// describes an invocation of method GetVisibleUploadsMatchingMonikers on an
-// instance of MockStore.
-type StoreGetVisibleUploadsMatchingMonikersFuncCall struct {
+// StoreGetUploadsForUnreferencedSymbolsReportFuncCall is an object that
+// describes an invocation of method GetUploadsForUnreferencedSymbolsReport
+// on an instance of MockStore.
+type StoreGetUploadsForUnreferencedSymbolsReportFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int
+	Arg1 time.Duration
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []store.UnreferencedSymbolsUpload
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetUploadsForUnreferencedSymbolsReportFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetUploadsForUnreferencedSymbolsReportFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreGetVisibleUploadsMatchingMonikersFunc describes the behavior when
+// the GetVisibleUploadsMatchingMonikers method of the parent MockStore
+// instance is invoked.
+type StoreGetVisibleUploadsMatchingMonikersFunc struct {
+	defaultHook func(context.Context, int, string, []precise.QualifiedMonikerData, int, int) (shared1.PackageReferenceScanner, int, error)
+	hooks       []func(context.Context, int, string, []precise.QualifiedMonikerData, int, int) (shared1.PackageReferenceScanner, int, error)
+	history     []StoreGetVisibleUploadsMatchingMonikersFuncCall
+	mutex       sync.Mutex
+}
+
+// GetVisibleUploadsMatchingMonikers delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockStore) GetVisibleUploadsMatchingMonikers(v0 context.Context, v1 int, v2 string, v3 []precise.QualifiedMonikerData, v4 int, v5 int) (shared1.PackageReferenceScanner, int, error) {
+	r0, r1, r2 := m.GetVisibleUploadsMatchingMonikersFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.GetVisibleUploadsMatchingMonikersFunc.appendCall(StoreGetVisibleUploadsMatchingMonikersFuncCall{v0, v1, v2, v3, v4, v5, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// GetVisibleUploadsMatchingMonikers method of the parent MockStore instance
+// is invoked and the hook queue is empty.
+func (f *StoreGetVisibleUploadsMatchingMonikersFunc) SetDefaultHook(hook func(context.Context, int, string, []precise.QualifiedMonikerData, int, int) (shared1.PackageReferenceScanner, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetVisibleUploadsMatchingMonikers method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreGetVisibleUploadsMatchingMonikersFunc) PushHook(hook func(context.Context, int, string, []precise.QualifiedMonikerData, int, int) (shared1.PackageReferenceScanner, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetVisibleUploadsMatchingMonikersFunc) SetDefaultReturn(r0 shared1.PackageReferenceScanner, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, string, []precise.QualifiedMonikerData, int, int) (shared1.PackageReferenceScanner, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetVisibleUploadsMatchingMonikersFunc) PushReturn(r0 shared1.PackageReferenceScanner, r1 int, r2 error) {
+	f.PushHook(func(context.Context, int, string, []precise.QualifiedMonikerData, int, int) (shared1.PackageReferenceScanner, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreGetVisibleUploadsMatchingMonikersFunc) nextHook() func(context.Context, int, string, []precise.QualifiedMonikerData, int, int) (shared1.PackageReferenceScanner, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetVisibleUploadsMatchingMonikersFunc) appendCall(r0 StoreGetVisibleUploadsMatchingMonikersFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// StoreGetVisibleUploadsMatchingMonikersFuncCall objects describing the
+// invocations of this function.
+func (f *StoreGetVisibleUploadsMatchingMonikersFunc) History() []StoreGetVisibleUploadsMatchingMonikersFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetVisibleUploadsMatchingMonikersFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetVisibleUploadsMatchingMonikersFuncCall is an object that
+// describes an invocation of method GetVisibleUploadsMatchingMonikers on an
+// instance of MockStore.
+type StoreGetVisibleUploadsMatchingMonikersFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
 	Arg2 string
@@ -6750,6 +7834,128 @@ func (c StoreGetVisibleUploadsMatchingMonikersFuncCall) Results() []interface{}
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// StoreGetVisibleUploadsReferencingAnyVersionFunc describes the behavior
+// when the GetVisibleUploadsReferencingAnyVersion method of the parent
+// MockStore instance is invoked.
+type StoreGetVisibleUploadsReferencingAnyVersionFunc struct {
+	defaultHook func(context.Context, int, string, []precise.QualifiedMonikerData, int) ([]shared1.PackageReference, error)
+	hooks       []func(context.Context, int, string, []precise.QualifiedMonikerData, int) ([]shared1.PackageReference, error)
+	history     []StoreGetVisibleUploadsReferencingAnyVersionFuncCall
+	mutex       sync.Mutex
+}
+
+// GetVisibleUploadsReferencingAnyVersion delegates to the next hook
+// function in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockStore) GetVisibleUploadsReferencingAnyVersion(v0 context.Context, v1 int, v2 string, v3 []precise.QualifiedMonikerData, v4 int) ([]shared1.PackageReference, error) {
+	r0, r1 := m.GetVisibleUploadsReferencingAnyVersionFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.GetVisibleUploadsReferencingAnyVersionFunc.appendCall(StoreGetVisibleUploadsReferencingAnyVersionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetVisibleUploadsReferencingAnyVersion method of the parent MockStore
+// instance is invoked and the hook queue is empty.
+func (f *StoreGetVisibleUploadsReferencingAnyVersionFunc) SetDefaultHook(hook func(context.Context, int, string, []precise.QualifiedMonikerData, int) ([]shared1.PackageReference, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetVisibleUploadsReferencingAnyVersion method of the parent MockStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *StoreGetVisibleUploadsReferencingAnyVersionFunc) PushHook(hook func(context.Context, int, string, []precise.QualifiedMonikerData, int) ([]shared1.PackageReference, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetVisibleUploadsReferencingAnyVersionFunc) SetDefaultReturn(r0 []shared1.PackageReference, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, []precise.QualifiedMonikerData, int) ([]shared1.PackageReference, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetVisibleUploadsReferencingAnyVersionFunc) PushReturn(r0 []shared1.PackageReference, r1 error) {
+	f.PushHook(func(context.Context, int, string, []precise.QualifiedMonikerData, int) ([]shared1.PackageReference, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetVisibleUploadsReferencingAnyVersionFunc) nextHook() func(context.Context, int, string, []precise.QualifiedMonikerData, int) ([]shared1.PackageReference, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetVisibleUploadsReferencingAnyVersionFunc) appendCall(r0 StoreGetVisibleUploadsReferencingAnyVersionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// StoreGetVisibleUploadsReferencingAnyVersionFuncCall objects describing
+// the invocations of this function.
+func (f *StoreGetVisibleUploadsReferencingAnyVersionFunc) History() []StoreGetVisibleUploadsReferencingAnyVersionFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetVisibleUploadsReferencingAnyVersionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetVisibleUploadsReferencingAnyVersionFuncCall is an object that
+// describes an invocation of method GetVisibleUploadsReferencingAnyVersion
+// on an instance of MockStore.
+type StoreGetVisibleUploadsReferencingAnyVersionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []precise.QualifiedMonikerData
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared1.PackageReference
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetVisibleUploadsReferencingAnyVersionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetVisibleUploadsReferencingAnyVersionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreHardDeleteUploadsByIDsFunc describes the behavior when the
 // HardDeleteUploadsByIDs method of the parent MockStore instance is
 // invoked.
@@ -9054,35 +10260,36 @@ func (c StoreTransactFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// StoreUpdateCommittedAtFunc describes the behavior when the
-// UpdateCommittedAt method of the parent MockStore instance is invoked.
-type StoreUpdateCommittedAtFunc struct {
-	defaultHook func(context.Context, int, string, string) error
-	hooks       []func(context.Context, int, string, string) error
-	history     []StoreUpdateCommittedAtFuncCall
+// StoreUpdateCodeIntelCoverageFunc describes the behavior when the
+// UpdateCodeIntelCoverage method of the parent MockStore instance is
+// invoked.
+type StoreUpdateCodeIntelCoverageFunc struct {
+	defaultHook func(context.Context, int, string, []shared1.CodeIntelCoverage, time.Time) error
+	hooks       []func(context.Context, int, string, []shared1.CodeIntelCoverage, time.Time) error
+	history     []StoreUpdateCodeIntelCoverageFuncCall
 	mutex       sync.Mutex
 }
 
-// UpdateCommittedAt delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockStore) UpdateCommittedAt(v0 context.Context, v1 int, v2 string, v3 string) error {
-	r0 := m.UpdateCommittedAtFunc.nextHook()(v0, v1, v2, v3)
-	m.UpdateCommittedAtFunc.appendCall(StoreUpdateCommittedAtFuncCall{v0, v1, v2, v3, r0})
+// UpdateCodeIntelCoverage delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) UpdateCodeIntelCoverage(v0 context.Context, v1 int, v2 string, v3 []shared1.CodeIntelCoverage, v4 time.Time) error {
+	r0 := m.UpdateCodeIntelCoverageFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.UpdateCodeIntelCoverageFunc.appendCall(StoreUpdateCodeIntelCoverageFuncCall{v0, v1, v2, v3, v4, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the UpdateCommittedAt
-// method of the parent MockStore instance is invoked and the hook queue is
-// empty.
-func (f *StoreUpdateCommittedAtFunc) SetDefaultHook(hook func(context.Context, int, string, string) error) {
+// SetDefaultHook sets function that is called when the
+// UpdateCodeIntelCoverage method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreUpdateCodeIntelCoverageFunc) SetDefaultHook(hook func(context.Context, int, string, []shared1.CodeIntelCoverage, time.Time) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// UpdateCommittedAt method of the parent MockStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *StoreUpdateCommittedAtFunc) PushHook(hook func(context.Context, int, string, string) error) {
+// UpdateCodeIntelCoverage method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreUpdateCodeIntelCoverageFunc) PushHook(hook func(context.Context, int, string, []shared1.CodeIntelCoverage, time.Time) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -9090,20 +10297,20 @@ func (f *StoreUpdateCommittedAtFunc) PushHook(hook func(context.Context, int, st
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *StoreUpdateCommittedAtFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int, string, string) error {
+func (f *StoreUpdateCodeIntelCoverageFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, string, []shared1.CodeIntelCoverage, time.Time) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *StoreUpdateCommittedAtFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int, string, string) error {
+func (f *StoreUpdateCodeIntelCoverageFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, string, []shared1.CodeIntelCoverage, time.Time) error {
 		return r0
 	})
 }
 
-func (f *StoreUpdateCommittedAtFunc) nextHook() func(context.Context, int, string, string) error {
+func (f *StoreUpdateCodeIntelCoverageFunc) nextHook() func(context.Context, int, string, []shared1.CodeIntelCoverage, time.Time) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -9116,26 +10323,26 @@ func (f *StoreUpdateCommittedAtFunc) nextHook() func(context.Context, int, strin
 	return hook
 }
 
-func (f *StoreUpdateCommittedAtFunc) appendCall(r0 StoreUpdateCommittedAtFuncCall) {
+func (f *StoreUpdateCodeIntelCoverageFunc) appendCall(r0 StoreUpdateCodeIntelCoverageFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of StoreUpdateCommittedAtFuncCall objects
-// describing the invocations of this function.
-func (f *StoreUpdateCommittedAtFunc) History() []StoreUpdateCommittedAtFuncCall {
+// History returns a sequence of StoreUpdateCodeIntelCoverageFuncCall
+// objects describing the invocations of this function.
+func (f *StoreUpdateCodeIntelCoverageFunc) History() []StoreUpdateCodeIntelCoverageFuncCall {
 	f.mutex.Lock()
-	history := make([]StoreUpdateCommittedAtFuncCall, len(f.history))
+	history := make([]StoreUpdateCodeIntelCoverageFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// StoreUpdateCommittedAtFuncCall is an object that describes an invocation
-// of method UpdateCommittedAt on an instance of MockStore.
-type StoreUpdateCommittedAtFuncCall struct {
+// StoreUpdateCodeIntelCoverageFuncCall is an object that describes an
+// invocation of method UpdateCodeIntelCoverage on an instance of MockStore.
+type StoreUpdateCodeIntelCoverageFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -9147,7 +10354,10 @@ type StoreUpdateCommittedAtFuncCall struct {
 	Arg2 string
 	// Arg3 is the value of the 4th argument passed to this method
 	// invocation.
-	Arg3 string
+	Arg3 []shared1.CodeIntelCoverage
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 time.Time
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -9155,13 +10365,124 @@ type StoreUpdateCommittedAtFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c StoreUpdateCommittedAtFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+func (c StoreUpdateCodeIntelCoverageFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c StoreUpdateCommittedAtFuncCall) Results() []interface{} {
+func (c StoreUpdateCodeIntelCoverageFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// StoreUpdateCommittedAtFunc describes the behavior when the
+// UpdateCommittedAt method of the parent MockStore instance is invoked.
+type StoreUpdateCommittedAtFunc struct {
+	defaultHook func(context.Context, int, string, string) error
+	hooks       []func(context.Context, int, string, string) error
+	history     []StoreUpdateCommittedAtFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateCommittedAt delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) UpdateCommittedAt(v0 context.Context, v1 int, v2 string, v3 string) error {
+	r0 := m.UpdateCommittedAtFunc.nextHook()(v0, v1, v2, v3)
+	m.UpdateCommittedAtFunc.appendCall(StoreUpdateCommittedAtFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the UpdateCommittedAt
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreUpdateCommittedAtFunc) SetDefaultHook(hook func(context.Context, int, string, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateCommittedAt method of the parent MockStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreUpdateCommittedAtFunc) PushHook(hook func(context.Context, int, string, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreUpdateCommittedAtFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, string, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreUpdateCommittedAtFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, string, string) error {
+		return r0
+	})
+}
+
+func (f *StoreUpdateCommittedAtFunc) nextHook() func(context.Context, int, string, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreUpdateCommittedAtFunc) appendCall(r0 StoreUpdateCommittedAtFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreUpdateCommittedAtFuncCall objects
+// describing the invocations of this function.
+func (f *StoreUpdateCommittedAtFunc) History() []StoreUpdateCommittedAtFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreUpdateCommittedAtFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreUpdateCommittedAtFuncCall is an object that describes an invocation
+// of method UpdateCommittedAt on an instance of MockStore.
+type StoreUpdateCommittedAtFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreUpdateCommittedAtFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreUpdateCommittedAtFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
@@ -9496,6 +10817,119 @@ func (c StoreUpdateSourcedCommitsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreUpdateUnreferencedSymbolsFunc describes the behavior when the
+// UpdateUnreferencedSymbols method of the parent MockStore instance is
+// invoked.
+type StoreUpdateUnreferencedSymbolsFunc struct {
+	defaultHook func(context.Context, int, []shared1.UnreferencedSymbol, time.Time) error
+	hooks       []func(context.Context, int, []shared1.UnreferencedSymbol, time.Time) error
+	history     []StoreUpdateUnreferencedSymbolsFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateUnreferencedSymbols delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) UpdateUnreferencedSymbols(v0 context.Context, v1 int, v2 []shared1.UnreferencedSymbol, v3 time.Time) error {
+	r0 := m.UpdateUnreferencedSymbolsFunc.nextHook()(v0, v1, v2, v3)
+	m.UpdateUnreferencedSymbolsFunc.appendCall(StoreUpdateUnreferencedSymbolsFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateUnreferencedSymbols method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreUpdateUnreferencedSymbolsFunc) SetDefaultHook(hook func(context.Context, int, []shared1.UnreferencedSymbol, time.Time) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateUnreferencedSymbols method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreUpdateUnreferencedSymbolsFunc) PushHook(hook func(context.Context, int, []shared1.UnreferencedSymbol, time.Time) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreUpdateUnreferencedSymbolsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, []shared1.UnreferencedSymbol, time.Time) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreUpdateUnreferencedSymbolsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, []shared1.UnreferencedSymbol, time.Time) error {
+		return r0
+	})
+}
+
+func (f *StoreUpdateUnreferencedSymbolsFunc) nextHook() func(context.Context, int, []shared1.UnreferencedSymbol, time.Time) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreUpdateUnreferencedSymbolsFunc) appendCall(r0 StoreUpdateUnreferencedSymbolsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreUpdateUnreferencedSymbolsFuncCall
+// objects describing the invocations of this function.
+func (f *StoreUpdateUnreferencedSymbolsFunc) History() []StoreUpdateUnreferencedSymbolsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreUpdateUnreferencedSymbolsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreUpdateUnreferencedSymbolsFuncCall is an object that describes an
+// invocation of method UpdateUnreferencedSymbols on an instance of
+// MockStore.
+type StoreUpdateUnreferencedSymbolsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []shared1.UnreferencedSymbol
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreUpdateUnreferencedSymbolsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreUpdateUnreferencedSymbolsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // StoreUpdateUploadRetentionFunc describes the behavior when the
 // UpdateUploadRetention method of the parent MockStore instance is invoked.
 type StoreUpdateUploadRetentionFunc struct {
@@ -10125,6 +11559,15 @@ type MockLsifStore struct {
 	// DoneFunc is an instance of a mock function object controlling the
 	// behavior of the method Done.
 	DoneFunc *LsifStoreDoneFunc
+	// GetReferencedSymbolNamesFunc is an instance of a mock function object
+	// controlling the behavior of the method GetReferencedSymbolNames.
+	GetReferencedSymbolNamesFunc *LsifStoreGetReferencedSymbolNamesFunc
+	// GetSCIPDocumentPathsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetSCIPDocumentPaths.
+	GetSCIPDocumentPathsFunc *LsifStoreGetSCIPDocumentPathsFunc
+	// GetSCIPMetadataFunc is an instance of a mock function object
+	// controlling the behavior of the method GetSCIPMetadata.
+	GetSCIPMetadataFunc *LsifStoreGetSCIPMetadataFunc
 	// GetUploadDocumentsForPathFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// GetUploadDocumentsForPath.
@@ -10186,6 +11629,21 @@ func NewMockLsifStore() *MockLsifStore {
 				return
 			},
 		},
+		GetReferencedSymbolNamesFunc: &LsifStoreGetReferencedSymbolNamesFunc{
+			defaultHook: func(context.Context, []int, []string) (r0 []string, r1 error) {
+				return
+			},
+		},
+		GetSCIPDocumentPathsFunc: &LsifStoreGetSCIPDocumentPathsFunc{
+			defaultHook: func(context.Context, int) (r0 []string, r1 error) {
+				return
+			},
+		},
+		GetSCIPMetadataFunc: &LsifStoreGetSCIPMetadataFunc{
+			defaultHook: func(context.Context, int) (r0 lsifstore.ProcessedMetadata, r1 bool, r2 error) {
+				return
+			},
+		},
 		GetUploadDocumentsForPathFunc: &LsifStoreGetUploadDocumentsForPathFunc{
 			defaultHook: func(context.Context, int, string) (r0 []string, r1 int, r2 error) {
 				return
@@ -10273,6 +11731,21 @@ func NewStrictMockLsifStore() *MockLsifStore {
 				panic("unexpected invocation of MockLsifStore.Done")
 			},
 		},
+		GetReferencedSymbolNamesFunc: &LsifStoreGetReferencedSymbolNamesFunc{
+			defaultHook: func(context.Context, []int, []string) ([]string, error) {
+				panic("unexpected invocation of MockLsifStore.GetReferencedSymbolNames")
+			},
+		},
+		GetSCIPDocumentPathsFunc: &LsifStoreGetSCIPDocumentPathsFunc{
+			defaultHook: func(context.Context, int) ([]string, error) {
+				panic("unexpected invocation of MockLsifStore.GetSCIPDocumentPaths")
+			},
+		},
+		GetSCIPMetadataFunc: &LsifStoreGetSCIPMetadataFunc{
+			defaultHook: func(context.Context, int) (lsifstore.ProcessedMetadata, bool, error) {
+				panic("unexpected invocation of MockLsifStore.GetSCIPMetadata")
+			},
+		},
 		GetUploadDocumentsForPathFunc: &LsifStoreGetUploadDocumentsForPathFunc{
 			defaultHook: func(context.Context, int, string) ([]string, int, error) {
 				panic("unexpected invocation of MockLsifStore.GetUploadDocumentsForPath")
@@ -10354,6 +11827,15 @@ func NewMockLsifStoreFrom(i lsifstore.LsifStore) *MockLsifStore {
 		DoneFunc: &LsifStoreDoneFunc{
 			defaultHook: i.Done,
 		},
+		GetReferencedSymbolNamesFunc: &LsifStoreGetReferencedSymbolNamesFunc{
+			defaultHook: i.GetReferencedSymbolNames,
+		},
+		GetSCIPDocumentPathsFunc: &LsifStoreGetSCIPDocumentPathsFunc{
+			defaultHook: i.GetSCIPDocumentPaths,
+		},
+		GetSCIPMetadataFunc: &LsifStoreGetSCIPMetadataFunc{
+			defaultHook: i.GetSCIPMetadata,
+		},
 		GetUploadDocumentsForPathFunc: &LsifStoreGetUploadDocumentsForPathFunc{
 			defaultHook: i.GetUploadDocumentsForPath,
 		},
@@ -10586,32 +12068,357 @@ func (f *LsifStoreDeleteUnreferencedDocumentsFunc) appendCall(r0 LsifStoreDelete
 // invocations of this function.
 func (f *LsifStoreDeleteUnreferencedDocumentsFunc) History() []LsifStoreDeleteUnreferencedDocumentsFuncCall {
 	f.mutex.Lock()
-	history := make([]LsifStoreDeleteUnreferencedDocumentsFuncCall, len(f.history))
+	history := make([]LsifStoreDeleteUnreferencedDocumentsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreDeleteUnreferencedDocumentsFuncCall is an object that describes
+// an invocation of method DeleteUnreferencedDocuments on an instance of
+// MockLsifStore.
+type LsifStoreDeleteUnreferencedDocumentsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 time.Duration
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreDeleteUnreferencedDocumentsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreDeleteUnreferencedDocumentsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// LsifStoreDoneFunc describes the behavior when the Done method of the
+// parent MockLsifStore instance is invoked.
+type LsifStoreDoneFunc struct {
+	defaultHook func(error) error
+	hooks       []func(error) error
+	history     []LsifStoreDoneFuncCall
+	mutex       sync.Mutex
+}
+
+// Done delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockLsifStore) Done(v0 error) error {
+	r0 := m.DoneFunc.nextHook()(v0)
+	m.DoneFunc.appendCall(LsifStoreDoneFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Done method of the
+// parent MockLsifStore instance is invoked and the hook queue is empty.
+func (f *LsifStoreDoneFunc) SetDefaultHook(hook func(error) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Done method of the parent MockLsifStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *LsifStoreDoneFunc) PushHook(hook func(error) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreDoneFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(error) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreDoneFunc) PushReturn(r0 error) {
+	f.PushHook(func(error) error {
+		return r0
+	})
+}
+
+func (f *LsifStoreDoneFunc) nextHook() func(error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreDoneFunc) appendCall(r0 LsifStoreDoneFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreDoneFuncCall objects describing
+// the invocations of this function.
+func (f *LsifStoreDoneFunc) History() []LsifStoreDoneFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreDoneFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreDoneFuncCall is an object that describes an invocation of method
+// Done on an instance of MockLsifStore.
+type LsifStoreDoneFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreDoneFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreDoneFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// LsifStoreGetReferencedSymbolNamesFunc describes the behavior when the
+// GetReferencedSymbolNames method of the parent MockLsifStore instance is
+// invoked.
+type LsifStoreGetReferencedSymbolNamesFunc struct {
+	defaultHook func(context.Context, []int, []string) ([]string, error)
+	hooks       []func(context.Context, []int, []string) ([]string, error)
+	history     []LsifStoreGetReferencedSymbolNamesFuncCall
+	mutex       sync.Mutex
+}
+
+// GetReferencedSymbolNames delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockLsifStore) GetReferencedSymbolNames(v0 context.Context, v1 []int, v2 []string) ([]string, error) {
+	r0, r1 := m.GetReferencedSymbolNamesFunc.nextHook()(v0, v1, v2)
+	m.GetReferencedSymbolNamesFunc.appendCall(LsifStoreGetReferencedSymbolNamesFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetReferencedSymbolNames method of the parent MockLsifStore instance is
+// invoked and the hook queue is empty.
+func (f *LsifStoreGetReferencedSymbolNamesFunc) SetDefaultHook(hook func(context.Context, []int, []string) ([]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetReferencedSymbolNames method of the parent MockLsifStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *LsifStoreGetReferencedSymbolNamesFunc) PushHook(hook func(context.Context, []int, []string) ([]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreGetReferencedSymbolNamesFunc) SetDefaultReturn(r0 []string, r1 error) {
+	f.SetDefaultHook(func(context.Context, []int, []string) ([]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreGetReferencedSymbolNamesFunc) PushReturn(r0 []string, r1 error) {
+	f.PushHook(func(context.Context, []int, []string) ([]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *LsifStoreGetReferencedSymbolNamesFunc) nextHook() func(context.Context, []int, []string) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreGetReferencedSymbolNamesFunc) appendCall(r0 LsifStoreGetReferencedSymbolNamesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreGetReferencedSymbolNamesFuncCall
+// objects describing the invocations of this function.
+func (f *LsifStoreGetReferencedSymbolNamesFunc) History() []LsifStoreGetReferencedSymbolNamesFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreGetReferencedSymbolNamesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreGetReferencedSymbolNamesFuncCall is an object that describes an
+// invocation of method GetReferencedSymbolNames on an instance of
+// MockLsifStore.
+type LsifStoreGetReferencedSymbolNamesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreGetReferencedSymbolNamesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreGetReferencedSymbolNamesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// LsifStoreGetSCIPDocumentPathsFunc describes the behavior when the
+// GetSCIPDocumentPaths method of the parent MockLsifStore instance is
+// invoked.
+type LsifStoreGetSCIPDocumentPathsFunc struct {
+	defaultHook func(context.Context, int) ([]string, error)
+	hooks       []func(context.Context, int) ([]string, error)
+	history     []LsifStoreGetSCIPDocumentPathsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetSCIPDocumentPaths delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLsifStore) GetSCIPDocumentPaths(v0 context.Context, v1 int) ([]string, error) {
+	r0, r1 := m.GetSCIPDocumentPathsFunc.nextHook()(v0, v1)
+	m.GetSCIPDocumentPathsFunc.appendCall(LsifStoreGetSCIPDocumentPathsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetSCIPDocumentPaths
+// method of the parent MockLsifStore instance is invoked and the hook queue
+// is empty.
+func (f *LsifStoreGetSCIPDocumentPathsFunc) SetDefaultHook(hook func(context.Context, int) ([]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetSCIPDocumentPaths method of the parent MockLsifStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *LsifStoreGetSCIPDocumentPathsFunc) PushHook(hook func(context.Context, int) ([]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreGetSCIPDocumentPathsFunc) SetDefaultReturn(r0 []string, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreGetSCIPDocumentPathsFunc) PushReturn(r0 []string, r1 error) {
+	f.PushHook(func(context.Context, int) ([]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *LsifStoreGetSCIPDocumentPathsFunc) nextHook() func(context.Context, int) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreGetSCIPDocumentPathsFunc) appendCall(r0 LsifStoreGetSCIPDocumentPathsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreGetSCIPDocumentPathsFuncCall
+// objects describing the invocations of this function.
+func (f *LsifStoreGetSCIPDocumentPathsFunc) History() []LsifStoreGetSCIPDocumentPathsFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreGetSCIPDocumentPathsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// LsifStoreDeleteUnreferencedDocumentsFuncCall is an object that describes
-// an invocation of method DeleteUnreferencedDocuments on an instance of
+// LsifStoreGetSCIPDocumentPathsFuncCall is an object that describes an
+// invocation of method GetSCIPDocumentPaths on an instance of
 // MockLsifStore.
-type LsifStoreDeleteUnreferencedDocumentsFuncCall struct {
+type LsifStoreGetSCIPDocumentPathsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 int
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 time.Duration
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 time.Time
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 int
+	Result0 []string
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -10619,44 +12426,45 @@ type LsifStoreDeleteUnreferencedDocumentsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c LsifStoreDeleteUnreferencedDocumentsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+func (c LsifStoreGetSCIPDocumentPathsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c LsifStoreDeleteUnreferencedDocumentsFuncCall) Results() []interface{} {
+func (c LsifStoreGetSCIPDocumentPathsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// LsifStoreDoneFunc describes the behavior when the Done method of the
-// parent MockLsifStore instance is invoked.
-type LsifStoreDoneFunc struct {
-	defaultHook func(error) error
-	hooks       []func(error) error
-	history     []LsifStoreDoneFuncCall
+// LsifStoreGetSCIPMetadataFunc describes the behavior when the
+// GetSCIPMetadata method of the parent MockLsifStore instance is invoked.
+type LsifStoreGetSCIPMetadataFunc struct {
+	defaultHook func(context.Context, int) (lsifstore.ProcessedMetadata, bool, error)
+	hooks       []func(context.Context, int) (lsifstore.ProcessedMetadata, bool, error)
+	history     []LsifStoreGetSCIPMetadataFuncCall
 	mutex       sync.Mutex
 }
 
-// Done delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockLsifStore) Done(v0 error) error {
-	r0 := m.DoneFunc.nextHook()(v0)
-	m.DoneFunc.appendCall(LsifStoreDoneFuncCall{v0, r0})
-	return r0
+// GetSCIPMetadata delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLsifStore) GetSCIPMetadata(v0 context.Context, v1 int) (lsifstore.ProcessedMetadata, bool, error) {
+	r0, r1, r2 := m.GetSCIPMetadataFunc.nextHook()(v0, v1)
+	m.GetSCIPMetadataFunc.appendCall(LsifStoreGetSCIPMetadataFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
 }
 
-// SetDefaultHook sets function that is called when the Done method of the
-// parent MockLsifStore instance is invoked and the hook queue is empty.
-func (f *LsifStoreDoneFunc) SetDefaultHook(hook func(error) error) {
+// SetDefaultHook sets function that is called when the GetSCIPMetadata
+// method of the parent MockLsifStore instance is invoked and the hook queue
+// is empty.
+func (f *LsifStoreGetSCIPMetadataFunc) SetDefaultHook(hook func(context.Context, int) (lsifstore.ProcessedMetadata, bool, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Done method of the parent MockLsifStore instance invokes the hook at the
-// front of the queue and discards it. After the queue is empty, the default
-// hook function is invoked for any future action.
-func (f *LsifStoreDoneFunc) PushHook(hook func(error) error) {
+// GetSCIPMetadata method of the parent MockLsifStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LsifStoreGetSCIPMetadataFunc) PushHook(hook func(context.Context, int) (lsifstore.ProcessedMetadata, bool, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -10664,20 +12472,20 @@ func (f *LsifStoreDoneFunc) PushHook(hook func(error) error) {
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *LsifStoreDoneFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(error) error {
-		return r0
+func (f *LsifStoreGetSCIPMetadataFunc) SetDefaultReturn(r0 lsifstore.ProcessedMetadata, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) (lsifstore.ProcessedMetadata, bool, error) {
+		return r0, r1, r2
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *LsifStoreDoneFunc) PushReturn(r0 error) {
-	f.PushHook(func(error) error {
-		return r0
+func (f *LsifStoreGetSCIPMetadataFunc) PushReturn(r0 lsifstore.ProcessedMetadata, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) (lsifstore.ProcessedMetadata, bool, error) {
+		return r0, r1, r2
 	})
 }
 
-func (f *LsifStoreDoneFunc) nextHook() func(error) error {
+func (f *LsifStoreGetSCIPMetadataFunc) nextHook() func(context.Context, int) (lsifstore.ProcessedMetadata, bool, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -10690,44 +12498,53 @@ func (f *LsifStoreDoneFunc) nextHook() func(error) error {
 	return hook
 }
 
-func (f *LsifStoreDoneFunc) appendCall(r0 LsifStoreDoneFuncCall) {
+func (f *LsifStoreGetSCIPMetadataFunc) appendCall(r0 LsifStoreGetSCIPMetadataFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of LsifStoreDoneFuncCall objects describing
-// the invocations of this function.
-func (f *LsifStoreDoneFunc) History() []LsifStoreDoneFuncCall {
+// History returns a sequence of LsifStoreGetSCIPMetadataFuncCall objects
+// describing the invocations of this function.
+func (f *LsifStoreGetSCIPMetadataFunc) History() []LsifStoreGetSCIPMetadataFuncCall {
 	f.mutex.Lock()
-	history := make([]LsifStoreDoneFuncCall, len(f.history))
+	history := make([]LsifStoreGetSCIPMetadataFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// LsifStoreDoneFuncCall is an object that describes an invocation of method
-// Done on an instance of MockLsifStore.
-type LsifStoreDoneFuncCall struct {
+// LsifStoreGetSCIPMetadataFuncCall is an object that describes an
+// invocation of method GetSCIPMetadata on an instance of MockLsifStore.
+type LsifStoreGetSCIPMetadataFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 error
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 lsifstore.ProcessedMetadata
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c LsifStoreDoneFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c LsifStoreGetSCIPMetadataFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c LsifStoreDoneFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c LsifStoreGetSCIPMetadataFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
 // LsifStoreGetUploadDocumentsForPathFunc describes the behavior when the
@@ -12161,6 +13978,9 @@ func (c LsifStoreWriteResultChunksFuncCall) Results() []interface{} {
 // github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/internal/lsifstore)
 // used for unit testing.
 type MockSCIPWriter struct {
+	// CopyDocumentsFunc is an instance of a mock function object
+	// controlling the behavior of the method CopyDocuments.
+	CopyDocumentsFunc *SCIPWriterCopyDocumentsFunc
 	// FlushFunc is an instance of a mock function object controlling the
 	// behavior of the method Flush.
 	FlushFunc *SCIPWriterFlushFunc
@@ -12173,6 +13993,11 @@ type MockSCIPWriter struct {
 // methods return zero values for all results, unless overwritten.
 func NewMockSCIPWriter() *MockSCIPWriter {
 	return &MockSCIPWriter{
+		CopyDocumentsFunc: &SCIPWriterCopyDocumentsFunc{
+			defaultHook: func(context.Context, int, []string) (r0 []lsifstore.CopiedSymbol, r1 error) {
+				return
+			},
+		},
 		FlushFunc: &SCIPWriterFlushFunc{
 			defaultHook: func(context.Context) (r0 uint32, r1 error) {
 				return
@@ -12190,6 +14015,11 @@ func NewMockSCIPWriter() *MockSCIPWriter {
 // All methods panic on invocation, unless overwritten.
 func NewStrictMockSCIPWriter() *MockSCIPWriter {
 	return &MockSCIPWriter{
+		CopyDocumentsFunc: &SCIPWriterCopyDocumentsFunc{
+			defaultHook: func(context.Context, int, []string) ([]lsifstore.CopiedSymbol, error) {
+				panic("unexpected invocation of MockSCIPWriter.CopyDocuments")
+			},
+		},
 		FlushFunc: &SCIPWriterFlushFunc{
 			defaultHook: func(context.Context) (uint32, error) {
 				panic("unexpected invocation of MockSCIPWriter.Flush")
@@ -12207,6 +14037,9 @@ func NewStrictMockSCIPWriter() *MockSCIPWriter {
 // All methods delegate to the given implementation, unless overwritten.
 func NewMockSCIPWriterFrom(i lsifstore.SCIPWriter) *MockSCIPWriter {
 	return &MockSCIPWriter{
+		CopyDocumentsFunc: &SCIPWriterCopyDocumentsFunc{
+			defaultHook: i.CopyDocuments,
+		},
 		FlushFunc: &SCIPWriterFlushFunc{
 			defaultHook: i.Flush,
 		},
@@ -12216,6 +14049,117 @@ func NewMockSCIPWriterFrom(i lsifstore.SCIPWriter) *MockSCIPWriter {
 	}
 }
 
+// SCIPWriterCopyDocumentsFunc describes the behavior when the CopyDocuments
+// method of the parent MockSCIPWriter instance is invoked.
+type SCIPWriterCopyDocumentsFunc struct {
+	defaultHook func(context.Context, int, []string) ([]lsifstore.CopiedSymbol, error)
+	hooks       []func(context.Context, int, []string) ([]lsifstore.CopiedSymbol, error)
+	history     []SCIPWriterCopyDocumentsFuncCall
+	mutex       sync.Mutex
+}
+
+// CopyDocuments delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSCIPWriter) CopyDocuments(v0 context.Context, v1 int, v2 []string) ([]lsifstore.CopiedSymbol, error) {
+	r0, r1 := m.CopyDocumentsFunc.nextHook()(v0, v1, v2)
+	m.CopyDocumentsFunc.appendCall(SCIPWriterCopyDocumentsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CopyDocuments method
+// of the parent MockSCIPWriter instance is invoked and the hook queue is
+// empty.
+func (f *SCIPWriterCopyDocumentsFunc) SetDefaultHook(hook func(context.Context, int, []string) ([]lsifstore.CopiedSymbol, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CopyDocuments method of the parent MockSCIPWriter instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SCIPWriterCopyDocumentsFunc) PushHook(hook func(context.Context, int, []string) ([]lsifstore.CopiedSymbol, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SCIPWriterCopyDocumentsFunc) SetDefaultReturn(r0 []lsifstore.CopiedSymbol, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, []string) ([]lsifstore.CopiedSymbol, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SCIPWriterCopyDocumentsFunc) PushReturn(r0 []lsifstore.CopiedSymbol, r1 error) {
+	f.PushHook(func(context.Context, int, []string) ([]lsifstore.CopiedSymbol, error) {
+		return r0, r1
+	})
+}
+
+func (f *SCIPWriterCopyDocumentsFunc) nextHook() func(context.Context, int, []string) ([]lsifstore.CopiedSymbol, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SCIPWriterCopyDocumentsFunc) appendCall(r0 SCIPWriterCopyDocumentsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SCIPWriterCopyDocumentsFuncCall objects
+// describing the invocations of this function.
+func (f *SCIPWriterCopyDocumentsFunc) History() []SCIPWriterCopyDocumentsFuncCall {
+	f.mutex.Lock()
+	history := make([]SCIPWriterCopyDocumentsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SCIPWriterCopyDocumentsFuncCall is an object that describes an invocation
+// of method CopyDocuments on an instance of MockSCIPWriter.
+type SCIPWriterCopyDocumentsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []lsifstore.CopiedSymbol
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SCIPWriterCopyDocumentsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SCIPWriterCopyDocumentsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // SCIPWriterFlushFunc describes the behavior when the Flush method of the
 // parent MockSCIPWriter instance is invoked.
 type SCIPWriterFlushFunc struct {
@@ -12460,6 +14404,9 @@ type MockWorkerStore[T workerutil.Record] struct {
 	// QueuedCountFunc is an instance of a mock function object controlling
 	// the behavior of the method QueuedCount.
 	QueuedCountFunc *WorkerStoreQueuedCountFunc[T]
+	// QueuedCountByFairnessKeyFunc is an instance of a mock function object
+	// controlling the behavior of the method QueuedCountByFairnessKey.
+	QueuedCountByFairnessKeyFunc *WorkerStoreQueuedCountByFairnessKeyFunc[T]
 	// RequeueFunc is an instance of a mock function object controlling the
 	// behavior of the method Requeue.
 	RequeueFunc *WorkerStoreRequeueFunc[T]
@@ -12523,6 +14470,11 @@ func NewMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 				return
 			},
 		},
+		QueuedCountByFairnessKeyFunc: &WorkerStoreQueuedCountByFairnessKeyFunc[T]{
+			defaultHook: func(context.Context, int) (r0 map[string]int, r1 error) {
+				return
+			},
+		},
 		RequeueFunc: &WorkerStoreRequeueFunc[T]{
 			defaultHook: func(context.Context, int, time.Time) (r0 error) {
 				return
@@ -12595,6 +14547,11 @@ func NewStrictMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 				panic("unexpected invocation of MockWorkerStore.QueuedCount")
 			},
 		},
+		QueuedCountByFairnessKeyFunc: &WorkerStoreQueuedCountByFairnessKeyFunc[T]{
+			defaultHook: func(context.Context, int) (map[string]int, error) {
+				panic("unexpected invocation of MockWorkerStore.QueuedCountByFairnessKey")
+			},
+		},
 		RequeueFunc: &WorkerStoreRequeueFunc[T]{
 			defaultHook: func(context.Context, int, time.Time) error {
 				panic("unexpected invocation of MockWorkerStore.Requeue")
@@ -12650,6 +14607,9 @@ func NewMockWorkerStoreFrom[T workerutil.Record](i store1.Store[T]) *MockWorkerS
 		QueuedCountFunc: &WorkerStoreQueuedCountFunc[T]{
 			defaultHook: i.QueuedCount,
 		},
+		QueuedCountByFairnessKeyFunc: &WorkerStoreQueuedCountByFairnessKeyFunc[T]{
+			defaultHook: i.QueuedCountByFairnessKey,
+		},
 		RequeueFunc: &WorkerStoreRequeueFunc[T]{
 			defaultHook: i.Requeue,
 		},
@@ -13662,6 +15622,117 @@ func (c WorkerStoreQueuedCountFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// WorkerStoreQueuedCountByFairnessKeyFunc describes the behavior when the
+// QueuedCountByFairnessKey method of the parent MockWorkerStore instance is
+// invoked.
+type WorkerStoreQueuedCountByFairnessKeyFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, int) (map[string]int, error)
+	hooks       []func(context.Context, int) (map[string]int, error)
+	history     []WorkerStoreQueuedCountByFairnessKeyFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// QueuedCountByFairnessKey delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockWorkerStore[T]) QueuedCountByFairnessKey(v0 context.Context, v1 int) (map[string]int, error) {
+	r0, r1 := m.QueuedCountByFairnessKeyFunc.nextHook()(v0, v1)
+	m.QueuedCountByFairnessKeyFunc.appendCall(WorkerStoreQueuedCountByFairnessKeyFuncCall[T]{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// QueuedCountByFairnessKey method of the parent MockWorkerStore instance is
+// invoked and the hook queue is empty.
+func (f *WorkerStoreQueuedCountByFairnessKeyFunc[T]) SetDefaultHook(hook func(context.Context, int) (map[string]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// QueuedCountByFairnessKey method of the parent MockWorkerStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *WorkerStoreQueuedCountByFairnessKeyFunc[T]) PushHook(hook func(context.Context, int) (map[string]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *WorkerStoreQueuedCountByFairnessKeyFunc[T]) SetDefaultReturn(r0 map[string]int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (map[string]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *WorkerStoreQueuedCountByFairnessKeyFunc[T]) PushReturn(r0 map[string]int, r1 error) {
+	f.PushHook(func(context.Context, int) (map[string]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *WorkerStoreQueuedCountByFairnessKeyFunc[T]) nextHook() func(context.Context, int) (map[string]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *WorkerStoreQueuedCountByFairnessKeyFunc[T]) appendCall(r0 WorkerStoreQueuedCountByFairnessKeyFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of WorkerStoreQueuedCountByFairnessKeyFuncCall
+// objects describing the invocations of this function.
+func (f *WorkerStoreQueuedCountByFairnessKeyFunc[T]) History() []WorkerStoreQueuedCountByFairnessKeyFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]WorkerStoreQueuedCountByFairnessKeyFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// WorkerStoreQueuedCountByFairnessKeyFuncCall is an object that describes
+// an invocation of method QueuedCountByFairnessKey on an instance of
+// MockWorkerStore.
+type WorkerStoreQueuedCountByFairnessKeyFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[string]int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c WorkerStoreQueuedCountByFairnessKeyFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c WorkerStoreQueuedCountByFairnessKeyFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // WorkerStoreRequeueFunc describes the behavior when the Requeue method of
 // the parent MockWorkerStore instance is invoked.
 type WorkerStoreRequeueFunc[T workerutil.Record] struct {
@@ -344,47 +344,147 @@ func packageFromSymbol(symbolName string) (precise.Package, bool) {
 	return pkg, true
 }
 
+// existingBaseDocumentPaths returns the paths of the documents of the given base upload that exist
+// at the commit of the incremental upload being processed.
+func existingBaseDocumentPaths(
+	ctx context.Context,
+	lsifStore lsifstore.LsifStore,
+	baseUploadID int,
+	root string,
+	getChildren pathexistence.GetChildrenFunc,
+) ([]string, error) {
+	paths, err := lsifStore.GetSCIPDocumentPaths(ctx, baseUploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	checker, err := pathexistence.NewExistenceChecker(ctx, root, paths, getChildren)
+	if err != nil {
+		return nil, err
+	}
+
+	existingPaths := paths[:0]
+	for _, path := range paths {
+		if checker.Exists(path) {
+			existingPaths = append(existingPaths, path)
+		}
+	}
+
+	return existingPaths, nil
+}
+
 // writeSCIPData transactionally writes the given correlated SCIP data into the given store targeting
-// the codeintel-db.
+// the codeintel-db. If the upload is incremental, the given documents of the base upload that are not
+// part of the correlated SCIP data are copied into the upload, and the symbols occurring in the copied
+// documents are returned.
 func writeSCIPData(
 	ctx context.Context,
 	lsifStore lsifstore.LsifStore,
 	upload codeinteltypes.Upload,
 	correlatedSCIPData lsifstore.ProcessedSCIPData,
+	baseDocumentPaths []string,
 	trace observation.TraceLogger,
-) (err error) {
+) (copiedSymbols []lsifstore.CopiedSymbol, err error) {
 	tx, err := lsifStore.Transact(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer func() { err = tx.Done(err) }()
 
 	if err := tx.InsertMetadata(ctx, upload.ID, correlatedSCIPData.Metadata); err != nil {
-		return err
+		return nil, err
 	}
 
 	scipWriter, err := tx.NewSCIPWriter(ctx, upload.ID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var numDocuments uint32
+	paths := map[string]struct{}{}
 	for document := range correlatedSCIPData.Documents {
 		if err := scipWriter.InsertDocument(ctx, document.Path, document.Document); err != nil {
-			return err
+			return nil, err
 		}
 
 		numDocuments += 1
+		paths[document.Path] = struct{}{}
 	}
 	trace.AddEvent("TODO Domain Owner", attribute.Int64("numDocuments", int64(numDocuments)))
 
+	if upload.BaseUploadID != nil {
+		// Copy the documents of the base upload that were not replaced by the incremental index
+		copiedPaths := make([]string, 0, len(baseDocumentPaths))
+		for _, path := range baseDocumentPaths {
+			if _, ok := paths[path]; !ok {
+				copiedPaths = append(copiedPaths, path)
+			}
+		}
+
+		if copiedSymbols, err = scipWriter.CopyDocuments(ctx, *upload.BaseUploadID, copiedPaths); err != nil {
+			return nil, err
+		}
+		trace.AddEvent("TODO Domain Owner", attribute.Int64("numCopiedDocuments", int64(len(copiedPaths))))
+	}
+
 	count, err := scipWriter.Flush(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	trace.AddEvent("TODO Domain Owner", attribute.Int64("numSymbols", int64(count)))
 
-	return nil
+	return copiedSymbols, nil
+}
+
+// mergeCopiedPackages adds the packages of the given symbols, copied from the base upload of an
+// incremental upload, to the given packages and package references of the incremental upload. As
+// in correlateSCIP, a package is a package reference only if no symbol of the package is defined.
+func mergeCopiedPackages(
+	packages []precise.Package,
+	packageReferences []precise.PackageReference,
+	copiedSymbols []lsifstore.CopiedSymbol,
+) ([]precise.Package, []precise.PackageReference) {
+	if len(copiedSymbols) == 0 {
+		return packages, packageReferences
+	}
+
+	packageSet := map[precise.Package]bool{}
+	for _, pkg := range packages {
+		packageSet[pkg] = true
+	}
+	for _, packageReference := range packageReferences {
+		if _, ok := packageSet[packageReference.Package]; !ok {
+			packageSet[packageReference.Package] = false
+		}
+	}
+	for _, symbol := range copiedSymbols {
+		if scip.IsLocalSymbol(symbol.Name) {
+			continue
+		}
+
+		if pkg, ok := packageFromSymbol(symbol.Name); ok {
+			packageSet[pkg] = packageSet[pkg] || symbol.IsDefinition
+		}
+	}
+
+	packages, packageReferences = packages[:0], packageReferences[:0]
+	for pkg, hasDefinition := range packageSet {
+		if hasDefinition {
+			packages = append(packages, pkg)
+		} else {
+			packageReferences = append(packageReferences, precise.PackageReference{Package: pkg})
+		}
+	}
+
+	// Sort prior to return to get deterministic output
+	sort.Slice(packages, func(i, j int) bool {
+		return comparePackages(packages[i], packages[j])
+	})
+	sort.Slice(packageReferences, func(i, j int) bool {
+		return comparePackages(packageReferences[i].Package, packageReferences[j].Package)
+	})
+
+	return packages, packageReferences
 }
 
 // comparePackages returns true if pi sorts lower than pj.
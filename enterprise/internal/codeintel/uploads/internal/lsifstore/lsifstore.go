@@ -17,6 +17,7 @@ type LsifStore interface {
 	Done(err error) error
 
 	GetUploadDocumentsForPath(ctx context.Context, bundleID int, pathPattern string) ([]string, int, error)
+	GetSCIPDocumentPaths(ctx context.Context, uploadID int) ([]string, error)
 	DeleteLsifDataByUploadIds(ctx context.Context, bundleIDs ...int) (err error)
 
 	InsertMetadata(ctx context.Context, uploadID int, meta ProcessedMetadata) error
@@ -32,13 +33,16 @@ type LsifStore interface {
 	IDsWithMeta(ctx context.Context, ids []int) ([]int, error)
 	ReconcileCandidates(ctx context.Context, batchSize int) ([]int, error)
 	DeleteUnreferencedDocuments(ctx context.Context, batchSize int, maxAge time.Duration, now time.Time) (count int, err error)
+	GetReferencedSymbolNames(ctx context.Context, uploadIDs []int, symbolNames []string) (_ []string, err error)
 
 	// Stream
 	ScanDocuments(ctx context.Context, id int, f func(path string, document *scip.Document) error) (err error)
+	GetSCIPMetadata(ctx context.Context, id int) (_ ProcessedMetadata, _ bool, err error)
 }
 
 type SCIPWriter interface {
 	InsertDocument(ctx context.Context, path string, scipDocument *scip.Document) error
+	CopyDocuments(ctx context.Context, baseUploadID int, paths []string) ([]CopiedSymbol, error)
 	Flush(ctx context.Context) (uint32, error)
 }
 
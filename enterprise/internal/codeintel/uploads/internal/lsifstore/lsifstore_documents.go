@@ -72,3 +72,21 @@ SELECT path FROM ((
 ORDER BY path
 LIMIT %s
 `
+
+// GetSCIPDocumentPaths returns the paths of all documents of the given upload processed into
+// SCIP data.
+func (s *store) GetSCIPDocumentPaths(ctx context.Context, uploadID int) (_ []string, err error) {
+	ctx, _, endObservation := s.operations.getSCIPDocumentPaths.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.Int("uploadID", uploadID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return basestore.ScanStrings(s.db.Query(ctx, sqlf.Sprintf(scipDocumentPathsQuery, uploadID)))
+}
+
+const scipDocumentPathsQuery = `
+SELECT sid.document_path
+FROM codeintel_scip_document_lookup sid
+WHERE sid.upload_id = %s
+ORDER BY sid.document_path
+`
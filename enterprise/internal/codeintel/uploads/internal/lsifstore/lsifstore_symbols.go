@@ -0,0 +1,82 @@
+package lsifstore
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// GetReferencedSymbolNames returns the subset of the given symbol names that are referenced
+// by at least one document of the given uploads.
+func (s *store) GetReferencedSymbolNames(ctx context.Context, uploadIDs []int, symbolNames []string) (_ []string, err error) {
+	ctx, _, endObservation := s.operations.getReferencedSymbolNames.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.Int("numUploadIDs", len(uploadIDs)),
+		otlog.Int("numSymbolNames", len(symbolNames)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(uploadIDs) == 0 || len(symbolNames) == 0 {
+		return nil, nil
+	}
+
+	return basestore.ScanStrings(s.db.Query(ctx, sqlf.Sprintf(
+		referencedSymbolNamesQuery,
+		pq.Array(symbolNames),
+		pq.Array(uploadIDs),
+	)))
+}
+
+const referencedSymbolNamesQuery = `
+WITH RECURSIVE
+-- Search for the set of trie paths that match one of the given symbol names. We do
+-- a recursive walk starting at the roots of the trie for a given set of uploads, and
+-- only traverse down trie paths that continue to match our search text.
+matching_prefixes(upload_id, id, prefix, search) AS (
+	(
+		SELECT
+			ssn.upload_id,
+			ssn.id,
+			ssn.name_segment,
+			substring(t.name from length(ssn.name_segment) + 1) AS search
+		FROM codeintel_scip_symbol_names ssn
+		JOIN unnest(%s::text[]) AS t(name) ON t.name LIKE ssn.name_segment || '%%'
+		WHERE
+			ssn.upload_id = ANY(%s) AND
+			ssn.prefix_id IS NULL
+	) UNION (
+		SELECT
+			ssn.upload_id,
+			ssn.id,
+			mp.prefix || ssn.name_segment,
+			substring(mp.search from length(ssn.name_segment) + 1) AS search
+		FROM matching_prefixes mp
+		JOIN codeintel_scip_symbol_names ssn ON
+			ssn.upload_id = mp.upload_id AND
+			ssn.prefix_id = mp.id
+		WHERE
+			mp.search != '' AND
+			mp.search LIKE ssn.name_segment || '%%'
+	)
+),
+matching_symbol_names AS (
+	SELECT mp.upload_id, mp.id, mp.prefix AS symbol_name
+	FROM matching_prefixes mp
+	WHERE mp.search = ''
+)
+SELECT DISTINCT msn.symbol_name
+FROM matching_symbol_names msn
+WHERE EXISTS (
+	SELECT 1
+	FROM codeintel_scip_symbols ss
+	WHERE
+		ss.upload_id = msn.upload_id AND
+		ss.symbol_id = msn.id AND
+		ss.reference_ranges IS NOT NULL
+)
+ORDER BY msn.symbol_name
+`
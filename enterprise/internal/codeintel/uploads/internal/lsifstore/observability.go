@@ -12,7 +12,9 @@ type operations struct {
 	idsWithMeta                 *observation.Operation
 	reconcileCandidates         *observation.Operation
 	getUploadDocumentsForPath   *observation.Operation
+	getSCIPDocumentPaths        *observation.Operation
 	scanDocuments               *observation.Operation
+	getSCIPMetadata             *observation.Operation
 	insertMetadata              *observation.Operation
 	writeMeta                   *observation.Operation
 	writeDocuments              *observation.Operation
@@ -21,6 +23,7 @@ type operations struct {
 	writeReferences             *observation.Operation
 	writeImplementations        *observation.Operation
 	deleteUnreferencedDocuments *observation.Operation
+	getReferencedSymbolNames    *observation.Operation
 }
 
 var m = new(metrics.SingletonREDMetrics)
@@ -48,7 +51,9 @@ func newOperations(observationCtx *observation.Context) *operations {
 		idsWithMeta:                 op("IDsWithMeta"),
 		reconcileCandidates:         op("ReconcileCandidates"),
 		getUploadDocumentsForPath:   op("GetUploadDocumentsForPath"),
+		getSCIPDocumentPaths:        op("GetSCIPDocumentPaths"),
 		scanDocuments:               op("ScanDocuments"),
+		getSCIPMetadata:             op("GetSCIPMetadata"),
 		insertMetadata:              op("InsertMetadata"),
 		writeMeta:                   op("WriteMeta"),
 		writeDocuments:              op("WriteDocuments"),
@@ -57,5 +62,6 @@ func newOperations(observationCtx *observation.Context) *operations {
 		writeReferences:             op("WriteReferences"),
 		writeImplementations:        op("WriteImplementations"),
 		deleteUnreferencedDocuments: op("DeleteUnreferencedDocuments"),
+		getReferencedSymbolNames:    op("GetReferencedSymbolNames"),
 	}
 }
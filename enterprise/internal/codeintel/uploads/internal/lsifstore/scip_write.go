@@ -46,6 +46,12 @@ type ProcessedSCIPDocument struct {
 	Err      error
 }
 
+// CopiedSymbol is a symbol occurring in a document copied from a base upload.
+type CopiedSymbol struct {
+	Name         string
+	IsDefinition bool
+}
+
 func (s *store) InsertMetadata(ctx context.Context, uploadID int, meta ProcessedMetadata) (err error) {
 	ctx, _, endObservation := s.operations.insertMetadata.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
 		otlog.Int("uploadID", uploadID),
@@ -364,6 +370,167 @@ func (s *scipWriter) flush(ctx context.Context) error {
 	return nil
 }
 
+// CopyDocuments adds the documents with the given paths of the given base upload to the upload
+// being written. Document payloads are shared between uploads (they are content-addressed by the
+// hash of their payload), so only the document lookup and symbol rows are copied, along with the
+// part of the base upload's symbol name trie that names the symbols of the copied documents. The
+// symbols occurring in the copied documents are returned so that the caller can determine their
+// packages and package references. This must be called before Flush.
+func (s *scipWriter) CopyDocuments(ctx context.Context, baseUploadID int, paths []string) ([]CopiedSymbol, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	// Reserve a range of symbol name identifiers for the copied trie nodes so that the copied
+	// identifiers do not overlap with the ones of this upload.
+	numSymbolNameIDs, _, err := basestore.ScanFirstInt(s.db.Query(ctx, sqlf.Sprintf(scipWriterCopyDocumentsNumSymbolNameIDsQuery, baseUploadID)))
+	if err != nil {
+		return nil, err
+	}
+	offset := s.nextID
+	s.nextID += numSymbolNameIDs
+
+	segments, err := scanSymbolNameSegments(s.db.Query(ctx, sqlf.Sprintf(
+		scipWriterCopyDocumentsSymbolNamesQuery,
+		baseUploadID,
+		pq.Array(paths),
+		baseUploadID,
+		offset,
+		offset,
+		baseUploadID,
+	)))
+	if err != nil {
+		return nil, err
+	}
+
+	symbols, err := scanCopiedSymbolIDs(s.db.Query(ctx, sqlf.Sprintf(
+		scipWriterCopyDocumentsQuery,
+		s.uploadID,
+		baseUploadID,
+		pq.Array(paths),
+		offset,
+		baseUploadID,
+		baseUploadID,
+	)))
+	if err != nil {
+		return nil, err
+	}
+	atomic.AddUint32(&s.count, uint32(len(symbols)))
+
+	// Reconstruct the full names of the copied symbols from the copied trie nodes
+	names := make(map[int]string, len(segments))
+	var nameOf func(id int) string
+	nameOf = func(id int) string {
+		if name, ok := names[id]; ok {
+			return name
+		}
+
+		segment := segments[id]
+		name := segment.nameSegment
+		if segment.prefixID != nil {
+			name = nameOf(*segment.prefixID) + name
+		}
+		names[id] = name
+		return name
+	}
+
+	isDefinitionByName := map[string]bool{}
+	for _, symbol := range symbols {
+		name := nameOf(symbol.symbolID)
+		isDefinitionByName[name] = isDefinitionByName[name] || symbol.isDefinition
+	}
+
+	copiedSymbols := make([]CopiedSymbol, 0, len(isDefinitionByName))
+	for name, isDefinition := range isDefinitionByName {
+		copiedSymbols = append(copiedSymbols, CopiedSymbol{Name: name, IsDefinition: isDefinition})
+	}
+	sort.Slice(copiedSymbols, func(i, j int) bool {
+		return copiedSymbols[i].Name < copiedSymbols[j].Name
+	})
+
+	return copiedSymbols, nil
+}
+
+type symbolNameSegment struct {
+	nameSegment string
+	prefixID    *int
+}
+
+var scanSymbolNameSegments = basestore.NewMapScanner(func(s dbutil.Scanner) (id int, segment symbolNameSegment, _ error) {
+	err := s.Scan(&id, &segment.nameSegment, &segment.prefixID)
+	return id, segment, err
+})
+
+type copiedSymbolID struct {
+	symbolID     int
+	isDefinition bool
+}
+
+var scanCopiedSymbolIDs = basestore.NewSliceScanner(func(s dbutil.Scanner) (symbol copiedSymbolID, _ error) {
+	err := s.Scan(&symbol.symbolID, &symbol.isDefinition)
+	return symbol, err
+})
+
+const scipWriterCopyDocumentsNumSymbolNameIDsQuery = `
+SELECT COALESCE(MAX(id) + 1, 0)
+FROM codeintel_scip_symbol_names
+WHERE upload_id = %s
+`
+
+const scipWriterCopyDocumentsSymbolNamesQuery = `
+WITH RECURSIVE
+-- Walk the symbol name trie from the symbols of the copied documents up to its roots so
+-- that the names of symbols occurring only in replaced documents are not copied.
+copied_symbol_name_ids(id) AS (
+	(
+		SELECT ss.symbol_id
+		FROM codeintel_scip_document_lookup sid
+		JOIN codeintel_scip_symbols ss ON ss.upload_id = sid.upload_id AND ss.document_lookup_id = sid.id
+		WHERE sid.upload_id = %s AND sid.document_path = ANY(%s)
+	) UNION (
+		SELECT sn.prefix_id
+		FROM copied_symbol_name_ids csn
+		JOIN codeintel_scip_symbol_names sn ON sn.upload_id = %s AND sn.id = csn.id
+		WHERE sn.prefix_id IS NOT NULL
+	)
+)
+INSERT INTO t_codeintel_scip_symbol_names (id, name_segment, prefix_id)
+SELECT %s + sn.id, sn.name_segment, %s + sn.prefix_id
+FROM copied_symbol_name_ids csn
+JOIN codeintel_scip_symbol_names sn ON sn.upload_id = %s AND sn.id = csn.id
+RETURNING id, name_segment, prefix_id
+`
+
+const scipWriterCopyDocumentsQuery = `
+WITH
+copied_document_lookups AS (
+	INSERT INTO codeintel_scip_document_lookup (upload_id, document_path, document_id)
+	SELECT %s, sid.document_path, sid.document_id
+	FROM codeintel_scip_document_lookup sid
+	WHERE sid.upload_id = %s AND sid.document_path = ANY(%s)
+	RETURNING id, document_path
+)
+INSERT INTO t_codeintel_scip_symbols (
+	symbol_id,
+	document_lookup_id,
+	definition_ranges,
+	reference_ranges,
+	implementation_ranges,
+	type_definition_ranges
+)
+SELECT
+	%s + ss.symbol_id,
+	cdl.id,
+	ss.definition_ranges,
+	ss.reference_ranges,
+	ss.implementation_ranges,
+	ss.type_definition_ranges
+FROM copied_document_lookups cdl
+JOIN codeintel_scip_document_lookup sid ON sid.upload_id = %s AND sid.document_path = cdl.document_path
+JOIN codeintel_scip_symbols ss ON ss.upload_id = %s AND ss.document_lookup_id = sid.id
+RETURNING symbol_id, definition_ranges IS NOT NULL
+`
+
 const scipWriterWriteFetchDocumentsQuery = `
 SELECT
 	encode(payload_hash, 'hex'),
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/sourcegraph/log/logtest"
 	"github.com/sourcegraph/scip/bindings/go/scip"
 
@@ -30,6 +31,38 @@ func TestInsertMetadata(t *testing.T) {
 	}
 }
 
+func TestGetSCIPMetadata(t *testing.T) {
+	logger := logtest.Scoped(t)
+	codeIntelDB := codeintelshared.NewCodeIntelDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, codeIntelDB)
+	ctx := context.Background()
+
+	metadata := ProcessedMetadata{
+		TextDocumentEncoding: "UTF8",
+		ToolName:             "scip-test",
+		ToolVersion:          "0.1.0",
+		ToolArguments:        []string{"-p", "src"},
+		ProtocolVersion:      1,
+	}
+	if err := store.InsertMetadata(ctx, 42, metadata); err != nil {
+		t.Fatalf("failed to insert metadata: %s", err)
+	}
+
+	if storedMetadata, ok, err := store.GetSCIPMetadata(ctx, 42); err != nil {
+		t.Fatalf("failed to get metadata: %s", err)
+	} else if !ok {
+		t.Fatalf("expected metadata to exist")
+	} else if diff := cmp.Diff(metadata, storedMetadata); diff != "" {
+		t.Errorf("unexpected metadata (-want +got):\n%s", diff)
+	}
+
+	if _, ok, err := store.GetSCIPMetadata(ctx, 43); err != nil {
+		t.Fatalf("failed to get metadata: %s", err)
+	} else if ok {
+		t.Fatalf("expected metadata not to exist")
+	}
+}
+
 func TestInsertSharedDocumentsConcurrently(t *testing.T) {
 	logger := logtest.Scoped(t)
 	codeIntelDB := codeintelshared.NewCodeIntelDB(logger, dbtest.NewDB(logger, t))
@@ -174,3 +207,122 @@ func TestInsertDocumentWithSymbols(t *testing.T) {
 		t.Fatalf("unexpected number of symbols inserted. want=%d have=%d", expected, n)
 	}
 }
+
+func TestCopyDocuments(t *testing.T) {
+	logger := logtest.Scoped(t)
+	codeIntelDB := codeintelshared.NewCodeIntelDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, codeIntelDB)
+	ctx := context.Background()
+
+	writeUpload := func(uploadID int, f func(scipWriter SCIPWriter) error) uint32 {
+		tx, err := store.Transact(ctx)
+		if err != nil {
+			t.Fatalf("failed to start transaction: %s", err)
+		}
+		scipWriter, err := tx.NewSCIPWriter(ctx, uploadID)
+		if err != nil {
+			t.Fatalf("failed to create SCIP writer: %s", err)
+		}
+		if err := f(scipWriter); err != nil {
+			t.Fatalf("failed to write SCIP data: %s", err)
+		}
+		n, err := scipWriter.Flush(ctx)
+		if err != nil {
+			t.Fatalf("failed to flush SCIP data: %s", err)
+		}
+		if err := tx.Done(nil); err != nil {
+			t.Fatalf("failed to commit transaction: %s", err)
+		}
+		return n
+	}
+
+	makeDocument := func(symbol string) *scip.Document {
+		return &scip.Document{
+			Occurrences: []*scip.Occurrence{
+				{Range: []int32{1, 2, 3}, Symbol: symbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+			},
+		}
+	}
+
+	writeUpload(24, func(scipWriter SCIPWriter) error {
+		for _, path := range []string{"a.go", "b.go", "c.go"} {
+			if err := scipWriter.InsertDocument(ctx, path, makeDocument("scip-go gomod example v1 `"+path+"`/")); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	// Upload 25 changes b.go and deletes c.go
+	var copiedSymbols []CopiedSymbol
+	n := writeUpload(25, func(scipWriter SCIPWriter) (err error) {
+		if err := scipWriter.InsertDocument(ctx, "b.go", makeDocument("scip-go gomod example v2 `b.go`/")); err != nil {
+			return err
+		}
+		copiedSymbols, err = scipWriter.CopyDocuments(ctx, 24, []string{"a.go"})
+		return err
+	})
+	if expected := uint32(2); n != expected {
+		t.Fatalf("unexpected number of symbols inserted. want=%d have=%d", expected, n)
+	}
+
+	expectedCopiedSymbols := []CopiedSymbol{
+		{Name: "scip-go gomod example v1 `a.go`/", IsDefinition: true},
+	}
+	if diff := cmp.Diff(expectedCopiedSymbols, copiedSymbols); diff != "" {
+		t.Errorf("unexpected copied symbols (-want +got):\n%s", diff)
+	}
+
+	if paths, err := store.GetSCIPDocumentPaths(ctx, 25); err != nil {
+		t.Fatalf("failed to get document paths: %s", err)
+	} else if diff := cmp.Diff([]string{"a.go", "b.go"}, paths); diff != "" {
+		t.Errorf("unexpected document paths (-want +got):\n%s", diff)
+	}
+
+	// The documents a.go of upload 24 and 25 share a payload
+	count, _, err := basestore.ScanFirstInt(codeIntelDB.Handle().QueryContext(ctx, `SELECT COUNT(*) FROM codeintel_scip_documents`))
+	if err != nil {
+		t.Fatalf("failed to query number of SCIP documents: %s", err)
+	} else if expected := 4; count != expected {
+		t.Fatalf("unexpected number of documents. want=%d have=%d", expected, count)
+	}
+
+	// The symbol names of the copied documents are distinct from those written into upload 25
+	symbolNames, err := basestore.ScanStrings(codeIntelDB.Handle().QueryContext(ctx, `
+		WITH RECURSIVE names(id, name) AS (
+			SELECT sn.id, sn.name_segment FROM codeintel_scip_symbol_names sn WHERE sn.upload_id = 25 AND sn.prefix_id IS NULL
+			UNION
+			SELECT sn.id, n.name || sn.name_segment FROM names n JOIN codeintel_scip_symbol_names sn ON sn.upload_id = 25 AND sn.prefix_id = n.id
+		)
+		SELECT sid.document_path || ':' || n.name
+		FROM codeintel_scip_symbols ss
+		JOIN codeintel_scip_document_lookup sid ON sid.id = ss.document_lookup_id
+		JOIN names n ON n.id = ss.symbol_id
+		WHERE ss.upload_id = 25
+		ORDER BY sid.document_path
+	`))
+	if err != nil {
+		t.Fatalf("failed to query symbol names: %s", err)
+	}
+	expectedSymbolNames := []string{
+		"a.go:scip-go gomod example v1 `a.go`/",
+		"b.go:scip-go gomod example v2 `b.go`/",
+	}
+	if diff := cmp.Diff(expectedSymbolNames, symbolNames); diff != "" {
+		t.Errorf("unexpected symbol names (-want +got):\n%s", diff)
+	}
+
+	// Only the trie nodes naming the symbols of the copied documents are copied
+	numLeaves, _, err := basestore.ScanFirstInt(codeIntelDB.Handle().QueryContext(ctx, `
+		SELECT COUNT(*)
+		FROM codeintel_scip_symbol_names sn
+		WHERE
+			sn.upload_id = 25 AND
+			NOT EXISTS (SELECT 1 FROM codeintel_scip_symbol_names c WHERE c.upload_id = 25 AND c.prefix_id = sn.id)
+	`))
+	if err != nil {
+		t.Fatalf("failed to query number of symbol names: %s", err)
+	} else if expected := 2; numLeaves != expected {
+		t.Errorf("unexpected number of symbol names. want=%d have=%d", expected, numLeaves)
+	}
+}
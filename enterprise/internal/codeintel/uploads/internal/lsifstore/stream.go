@@ -5,6 +5,7 @@ import (
 	"context"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 	otlog "github.com/opentracing/opentracing-go/log"
 	"github.com/sourcegraph/scip/bindings/go/scip"
 	"google.golang.org/protobuf/proto"
@@ -51,6 +52,41 @@ WHERE sid.upload_id = %s
 ORDER BY sid.document_path
 `
 
+func (s *store) GetSCIPMetadata(ctx context.Context, id int) (_ ProcessedMetadata, _ bool, err error) {
+	ctx, _, endObservation := s.operations.getSCIPMetadata.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.Int("id", id),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	var metadata ProcessedMetadata
+	found := false
+	if err := runQuery(ctx, s.db, sqlf.Sprintf(getSCIPMetadataQuery, id), func(dbs dbutil.Scanner) error {
+		found = true
+		return dbs.Scan(
+			&metadata.TextDocumentEncoding,
+			&metadata.ToolName,
+			&metadata.ToolVersion,
+			pq.Array(&metadata.ToolArguments),
+			&metadata.ProtocolVersion,
+		)
+	}); err != nil {
+		return ProcessedMetadata{}, false, err
+	}
+
+	return metadata, found, nil
+}
+
+const getSCIPMetadataQuery = `
+SELECT
+	text_document_encoding,
+	tool_name,
+	tool_version,
+	tool_arguments,
+	protocol_version
+FROM codeintel_scip_metadata
+WHERE upload_id = %s
+`
+
 func runQuery(ctx context.Context, store *basestore.Store, query *sqlf.Query, f func(dbutil.Scanner) error) (err error) {
 	rows, queryErr := store.Query(ctx, query)
 	if queryErr != nil {
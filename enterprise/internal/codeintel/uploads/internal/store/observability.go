@@ -33,29 +33,30 @@ type operations struct {
 	hasRepository                           *observation.Operation
 
 	// Uploads
-	getUploads                           *observation.Operation
-	getUploadByID                        *observation.Operation
-	getUploadsByIDs                      *observation.Operation
-	getVisibleUploadsMatchingMonikers    *observation.Operation
-	updateUploadsVisibleToCommits        *observation.Operation
-	writeVisibleUploads                  *observation.Operation
-	persistNearestUploads                *observation.Operation
-	persistNearestUploadsLinks           *observation.Operation
-	persistUploadsVisibleAtTip           *observation.Operation
-	updateUploadRetention                *observation.Operation
-	updateCommittedAt                    *observation.Operation
-	sourcedCommitsWithoutCommittedAt     *observation.Operation
-	deleteUploadsWithoutRepository       *observation.Operation
-	deleteUploadsStuckUploading          *observation.Operation
-	softDeleteExpiredUploadsViaTraversal *observation.Operation
-	softDeleteExpiredUploads             *observation.Operation
-	hardDeleteUploadsByIDs               *observation.Operation
-	deleteUploadByID                     *observation.Operation
-	insertUpload                         *observation.Operation
-	addUploadPart                        *observation.Operation
-	markQueued                           *observation.Operation
-	markFailed                           *observation.Operation
-	deleteUploads                        *observation.Operation
+	getUploads                             *observation.Operation
+	getUploadByID                          *observation.Operation
+	getUploadsByIDs                        *observation.Operation
+	getVisibleUploadsMatchingMonikers      *observation.Operation
+	getVisibleUploadsReferencingAnyVersion *observation.Operation
+	updateUploadsVisibleToCommits          *observation.Operation
+	writeVisibleUploads                    *observation.Operation
+	persistNearestUploads                  *observation.Operation
+	persistNearestUploadsLinks             *observation.Operation
+	persistUploadsVisibleAtTip             *observation.Operation
+	updateUploadRetention                  *observation.Operation
+	updateCommittedAt                      *observation.Operation
+	sourcedCommitsWithoutCommittedAt       *observation.Operation
+	deleteUploadsWithoutRepository         *observation.Operation
+	deleteUploadsStuckUploading            *observation.Operation
+	softDeleteExpiredUploadsViaTraversal   *observation.Operation
+	softDeleteExpiredUploads               *observation.Operation
+	hardDeleteUploadsByIDs                 *observation.Operation
+	deleteUploadByID                       *observation.Operation
+	insertUpload                           *observation.Operation
+	addUploadPart                          *observation.Operation
+	markQueued                             *observation.Operation
+	markFailed                             *observation.Operation
+	deleteUploads                          *observation.Operation
 
 	// Dumps
 	findClosestDumps                   *observation.Operation
@@ -79,6 +80,20 @@ type operations struct {
 
 	reindexUploads    *observation.Operation
 	reindexUploadByID *observation.Operation
+
+	// Unreferenced symbols
+	getUploadsForUnreferencedSymbolsReport *observation.Operation
+	getReferencingUploadIDs                *observation.Operation
+	updateUnreferencedSymbols              *observation.Operation
+	getUnreferencedSymbols                 *observation.Operation
+	getUnreferencedSymbolsSummary          *observation.Operation
+
+	// Coverage
+	getRepositoriesForCoverageReport *observation.Operation
+	getCoverageUploadsAtTip          *observation.Operation
+	getCoverageFailures              *observation.Operation
+	updateCodeIntelCoverage          *observation.Operation
+	getCodeIntelCoverage             *observation.Operation
 }
 
 var m = new(metrics.SingletonREDMetrics)
@@ -127,25 +142,26 @@ func newOperations(observationCtx *observation.Context) *operations {
 		hasRepository:                           op("HasRepository"),
 
 		// Uploads
-		getUploads:                           op("GetUploads"),
-		getUploadByID:                        op("GetUploadByID"),
-		getUploadsByIDs:                      op("GetUploadsByIDs"),
-		getVisibleUploadsMatchingMonikers:    op("GetVisibleUploadsMatchingMonikers"),
-		updateUploadsVisibleToCommits:        op("UpdateUploadsVisibleToCommits"),
-		updateUploadRetention:                op("UpdateUploadRetention"),
-		updateCommittedAt:                    op("UpdateCommittedAt"),
-		sourcedCommitsWithoutCommittedAt:     op("SourcedCommitsWithoutCommittedAt"),
-		deleteUploadsStuckUploading:          op("DeleteUploadsStuckUploading"),
-		softDeleteExpiredUploadsViaTraversal: op("SoftDeleteExpiredUploadsViaTraversal"),
-		deleteUploadsWithoutRepository:       op("DeleteUploadsWithoutRepository"),
-		softDeleteExpiredUploads:             op("SoftDeleteExpiredUploads"),
-		hardDeleteUploadsByIDs:               op("HardDeleteUploadsByIDs"),
-		deleteUploadByID:                     op("DeleteUploadByID"),
-		insertUpload:                         op("InsertUpload"),
-		addUploadPart:                        op("AddUploadPart"),
-		markQueued:                           op("MarkQueued"),
-		markFailed:                           op("MarkFailed"),
-		deleteUploads:                        op("DeleteUploads"),
+		getUploads:                             op("GetUploads"),
+		getUploadByID:                          op("GetUploadByID"),
+		getUploadsByIDs:                        op("GetUploadsByIDs"),
+		getVisibleUploadsMatchingMonikers:      op("GetVisibleUploadsMatchingMonikers"),
+		getVisibleUploadsReferencingAnyVersion: op("GetVisibleUploadsReferencingAnyVersion"),
+		updateUploadsVisibleToCommits:          op("UpdateUploadsVisibleToCommits"),
+		updateUploadRetention:                  op("UpdateUploadRetention"),
+		updateCommittedAt:                      op("UpdateCommittedAt"),
+		sourcedCommitsWithoutCommittedAt:       op("SourcedCommitsWithoutCommittedAt"),
+		deleteUploadsStuckUploading:            op("DeleteUploadsStuckUploading"),
+		softDeleteExpiredUploadsViaTraversal:   op("SoftDeleteExpiredUploadsViaTraversal"),
+		deleteUploadsWithoutRepository:         op("DeleteUploadsWithoutRepository"),
+		softDeleteExpiredUploads:               op("SoftDeleteExpiredUploads"),
+		hardDeleteUploadsByIDs:                 op("HardDeleteUploadsByIDs"),
+		deleteUploadByID:                       op("DeleteUploadByID"),
+		insertUpload:                           op("InsertUpload"),
+		addUploadPart:                          op("AddUploadPart"),
+		markQueued:                             op("MarkQueued"),
+		markFailed:                             op("MarkFailed"),
+		deleteUploads:                          op("DeleteUploads"),
 
 		writeVisibleUploads:        op("writeVisibleUploads"),
 		persistNearestUploads:      op("persistNearestUploads"),
@@ -174,5 +190,19 @@ func newOperations(observationCtx *observation.Context) *operations {
 
 		reindexUploads:    op("ReindexUploads"),
 		reindexUploadByID: op("ReindexUploadByID"),
+
+		// Unreferenced symbols
+		getUploadsForUnreferencedSymbolsReport: op("GetUploadsForUnreferencedSymbolsReport"),
+		getReferencingUploadIDs:                op("GetReferencingUploadIDs"),
+		updateUnreferencedSymbols:              op("UpdateUnreferencedSymbols"),
+		getUnreferencedSymbols:                 op("GetUnreferencedSymbols"),
+		getUnreferencedSymbolsSummary:          op("GetUnreferencedSymbolsSummary"),
+
+		// Coverage
+		getRepositoriesForCoverageReport: op("GetRepositoriesForCoverageReport"),
+		getCoverageUploadsAtTip:          op("GetCoverageUploadsAtTip"),
+		getCoverageFailures:              op("GetCoverageFailures"),
+		updateCodeIntelCoverage:          op("UpdateCodeIntelCoverage"),
+		getCodeIntelCoverage:             op("GetCodeIntelCoverage"),
 	}
 }
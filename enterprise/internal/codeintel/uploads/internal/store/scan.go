@@ -41,6 +41,8 @@ func scanCompleteUpload(s dbutil.Scanner) (upload types.Upload, _ error) {
 		&upload.ShouldReindex,
 		&upload.Rank,
 		&upload.UncompressedSize,
+		&upload.Ephemeral,
+		&upload.BaseUploadID,
 	); err != nil {
 		return upload, err
 	}
@@ -87,6 +89,8 @@ func scanUploadWithCount(s dbutil.Scanner) (upload types.Upload, count int, err
 		&upload.ShouldReindex,
 		&upload.Rank,
 		&upload.UncompressedSize,
+		&upload.Ephemeral,
+		&upload.BaseUploadID,
 		&count,
 	); err != nil {
 		return upload, 0, err
@@ -146,6 +150,19 @@ func scanDump(s dbutil.Scanner) (dump types.Dump, err error) {
 
 var scanDumps = basestore.NewSliceScanner(scanDump)
 
+func scanPackageReference(s dbutil.Scanner) (reference shared.PackageReference, err error) {
+	err = s.Scan(
+		&reference.DumpID,
+		&reference.Scheme,
+		&reference.Manager,
+		&reference.Name,
+		&reference.Version,
+	)
+	return reference, err
+}
+
+var scanPackageReferences = basestore.NewSliceScanner(scanPackageReference)
+
 // scanSourcedCommits scans triples of repository ids/repository names/commits from the
 // return value of `*Store.query`. The output of this function is ordered by repository
 // identifier, then by commit.
@@ -49,6 +49,7 @@ type Store interface {
 	GetUploadsByIDsAllowDeleted(ctx context.Context, ids ...int) (_ []types.Upload, err error)
 	GetUploadIDsWithReferences(ctx context.Context, orderedMonikers []precise.QualifiedMonikerData, ignoreIDs []int, repositoryID int, commit string, limit int, offset int, trace observation.TraceLogger) (ids []int, recordsScanned int, totalCount int, err error)
 	GetVisibleUploadsMatchingMonikers(ctx context.Context, repositoryID int, commit string, orderedMonikers []precise.QualifiedMonikerData, limit, offset int) (_ shared.PackageReferenceScanner, _ int, err error)
+	GetVisibleUploadsReferencingAnyVersion(ctx context.Context, repositoryID int, commit string, monikers []precise.QualifiedMonikerData, limit int) (_ []shared.PackageReference, err error)
 	GetRecentUploadsSummary(ctx context.Context, repositoryID int) (upload []shared.UploadsWithRepositoryNamespace, err error)
 	GetLastUploadRetentionScanForRepository(ctx context.Context, repositoryID int) (_ *time.Time, err error)
 	UpdateUploadsVisibleToCommits(ctx context.Context, repositoryID int, graph *gitdomain.CommitGraph, refDescriptions map[string][]gitdomain.RefDescription, maxAgeForNonStaleBranches, maxAgeForNonStaleTags time.Duration, dirtyToken int, now time.Time) error
@@ -106,6 +107,20 @@ type Store interface {
 
 	ReindexUploads(ctx context.Context, opts shared.ReindexUploadsOptions) error
 	ReindexUploadByID(ctx context.Context, id int) error
+
+	// Unreferenced symbols
+	GetUploadsForUnreferencedSymbolsReport(ctx context.Context, processDelay time.Duration, limit int, now time.Time) (_ []UnreferencedSymbolsUpload, err error)
+	GetReferencingUploadIDs(ctx context.Context, uploadID int) (_ []int, err error)
+	UpdateUnreferencedSymbols(ctx context.Context, repositoryID int, symbols []shared.UnreferencedSymbol, now time.Time) (err error)
+	GetUnreferencedSymbols(ctx context.Context, opts shared.GetUnreferencedSymbolsOptions) (_ []shared.UnreferencedSymbol, _ int, err error)
+	GetUnreferencedSymbolsSummary(ctx context.Context, repositoryID int) (_ shared.UnreferencedSymbolsSummary, _ bool, err error)
+
+	// Coverage
+	GetRepositoriesForCoverageReport(ctx context.Context, processDelay time.Duration, limit int, now time.Time) (_ []int, err error)
+	GetCoverageUploadsAtTip(ctx context.Context, repositoryID int, commit string) (_ []CoverageUpload, err error)
+	GetCoverageFailures(ctx context.Context, repositoryID int) (_ []CoverageFailure, err error)
+	UpdateCodeIntelCoverage(ctx context.Context, repositoryID int, headCommit string, coverage []shared.CodeIntelCoverage, now time.Time) (err error)
+	GetCodeIntelCoverage(ctx context.Context, opts shared.GetCodeIntelCoverageOptions) (_ []shared.CodeIntelCoverage, _ int, err error)
 }
 
 // store manages the database operations for uploads.
@@ -330,8 +330,10 @@ func insertUploads(t testing.TB, db database.DB, uploads ...types.Upload) {
 				upload_size,
 				associated_index_id,
 				content_type,
-				should_reindex
-			) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+				should_reindex,
+				ephemeral,
+				base_upload_id
+			) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 		`,
 			upload.ID,
 			upload.Commit,
@@ -353,6 +355,8 @@ func insertUploads(t testing.TB, db database.DB, uploads ...types.Upload) {
 			upload.AssociatedIndexID,
 			upload.ContentType,
 			upload.ShouldReindex,
+			upload.Ephemeral,
+			upload.BaseUploadID,
 		)
 
 		if _, err := db.ExecContext(context.Background(), query.Query(sqlf.PostgresBindVar), query.Args()...); err != nil {
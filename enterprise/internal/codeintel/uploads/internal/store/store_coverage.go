@@ -0,0 +1,298 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// GetRepositoriesForCoverageReport returns the identifiers of (at most) the given number of repositories
+// whose coverage report has not been computed within the given process delay. Repositories without any
+// code intelligence data are included so that their lack of coverage is reported. Repositories whose
+// report is the oldest are returned first.
+func (s *store) GetRepositoriesForCoverageReport(ctx context.Context, processDelay time.Duration, limit int, now time.Time) (_ []int, err error) {
+	ctx, _, endObservation := s.operations.getRepositoriesForCoverageReport.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("limit", limit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return basestore.ScanInts(s.db.Query(ctx, sqlf.Sprintf(
+		getRepositoriesForCoverageReportQuery,
+		now,
+		processDelay/time.Second,
+		limit,
+	)))
+}
+
+const getRepositoriesForCoverageReportQuery = `
+SELECT r.id
+FROM repo r
+LEFT JOIN codeintel_coverage_reports ccr ON ccr.repository_id = r.id
+WHERE
+	r.deleted_at IS NULL AND
+	r.blocked IS NULL AND
+	(ccr.computed_at IS NULL OR %s - ccr.computed_at > (%s * '1 second'::interval))
+ORDER BY ccr.computed_at NULLS FIRST, r.id
+LIMIT %s
+`
+
+// CoverageUpload is an upload visible at the tip of the default branch of a repository.
+type CoverageUpload struct {
+	ID         int
+	Indexer    string
+	UploadedAt time.Time
+
+	// CommitsBehind is the distance between the given commit and the commit of the upload
+	// according to the commit graph. This value is nil if the distance is not known.
+	CommitsBehind *int
+}
+
+var scanCoverageUploads = basestore.NewSliceScanner(func(s dbutil.Scanner) (u CoverageUpload, _ error) {
+	err := s.Scan(&u.ID, &u.Indexer, &u.UploadedAt, &u.CommitsBehind)
+	return u, err
+})
+
+// GetCoverageUploadsAtTip returns the uploads visible at the tip of the default branch of the given
+// repository along with their distance from the given commit, which should be the current head of
+// the repository's default branch. An empty commit returns uploads without a known distance.
+func (s *store) GetCoverageUploadsAtTip(ctx context.Context, repositoryID int, commit string) (_ []CoverageUpload, err error) {
+	ctx, _, endObservation := s.operations.getCoverageUploadsAtTip.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+		log.String("commit", commit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return scanCoverageUploads(s.db.Query(ctx, sqlf.Sprintf(
+		getCoverageUploadsAtTipQuery,
+		makeVisibleUploadCandidatesQuery(repositoryID, commit),
+		repositoryID,
+	)))
+}
+
+const getCoverageUploadsAtTipQuery = `
+WITH visible_uploads AS (
+	%s
+)
+SELECT
+	u.id,
+	u.indexer,
+	u.uploaded_at,
+	(SELECT MIN(vu.distance) FROM visible_uploads vu WHERE vu.upload_id = u.id) AS distance
+FROM lsif_uploads u
+JOIN lsif_uploads_visible_at_tip uvt ON uvt.upload_id = u.id
+WHERE
+	uvt.repository_id = %s AND
+	uvt.is_default_branch AND
+	NOT u.ephemeral
+ORDER BY u.id
+`
+
+// CoverageFailure is the most recent failure to process an upload or to run an
+// auto-indexing job with a particular indexer.
+type CoverageFailure struct {
+	Indexer  string
+	FailedAt time.Time
+	Reason   string
+}
+
+var scanCoverageFailures = basestore.NewSliceScanner(func(s dbutil.Scanner) (f CoverageFailure, _ error) {
+	err := s.Scan(&f.Indexer, &f.FailedAt, &f.Reason)
+	return f, err
+})
+
+// GetCoverageFailures returns the most recent processing or auto-indexing failure of each indexer
+// used in the given repository.
+func (s *store) GetCoverageFailures(ctx context.Context, repositoryID int) (_ []CoverageFailure, err error) {
+	ctx, _, endObservation := s.operations.getCoverageFailures.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return scanCoverageFailures(s.db.Query(ctx, sqlf.Sprintf(getCoverageFailuresQuery, repositoryID, repositoryID)))
+}
+
+const getCoverageFailuresQuery = `
+WITH failures AS (
+	SELECT u.indexer, COALESCE(u.finished_at, u.uploaded_at) AS failed_at, COALESCE(u.failure_message, '') AS reason
+	FROM lsif_uploads u
+	WHERE u.repository_id = %s AND u.state IN ('failed', 'errored')
+	UNION ALL
+	SELECT i.indexer, COALESCE(i.finished_at, i.queued_at) AS failed_at, COALESCE(i.failure_message, '') AS reason
+	FROM lsif_indexes i
+	WHERE i.repository_id = %s AND i.state IN ('failed', 'errored')
+)
+SELECT DISTINCT ON (f.indexer) f.indexer, f.failed_at, f.reason
+FROM failures f
+ORDER BY f.indexer, f.failed_at DESC
+`
+
+// UpdateCodeIntelCoverage replaces the coverage report of the given repository.
+func (s *store) UpdateCodeIntelCoverage(ctx context.Context, repositoryID int, headCommit string, coverage []shared.CodeIntelCoverage, now time.Time) (err error) {
+	ctx, _, endObservation := s.operations.updateCodeIntelCoverage.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+		log.Int("numLanguages", len(coverage)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	tx, err := s.db.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(deleteCodeIntelCoverageQuery, repositoryID)); err != nil {
+		return err
+	}
+
+	if err := batch.InsertValues(
+		ctx,
+		tx.Handle(),
+		"codeintel_coverage",
+		batch.MaxNumPostgresParameters,
+		[]string{
+			"repository_id",
+			"language",
+			"indexers",
+			"num_uploads",
+			"upload_id",
+			"commits_behind",
+			"last_uploaded_at",
+			"last_failure_at",
+			"last_failure_reason",
+		},
+		loadCodeIntelCoverageChannel(repositoryID, coverage),
+	); err != nil {
+		return err
+	}
+
+	return tx.Exec(ctx, sqlf.Sprintf(updateCodeIntelCoverageReportQuery, repositoryID, dbutil.NullStringColumn(headCommit), now))
+}
+
+const deleteCodeIntelCoverageQuery = `
+DELETE FROM codeintel_coverage WHERE repository_id = %s
+`
+
+const updateCodeIntelCoverageReportQuery = `
+INSERT INTO codeintel_coverage_reports (repository_id, head_commit, computed_at)
+VALUES (%s, %s, %s)
+ON CONFLICT (repository_id) DO UPDATE SET
+	head_commit = EXCLUDED.head_commit,
+	computed_at = EXCLUDED.computed_at
+`
+
+func loadCodeIntelCoverageChannel(repositoryID int, coverage []shared.CodeIntelCoverage) <-chan []any {
+	ch := make(chan []any, len(coverage))
+
+	go func() {
+		defer close(ch)
+
+		for _, c := range coverage {
+			ch <- []any{
+				repositoryID,
+				c.Language,
+				pq.Array(c.Indexers),
+				c.NumUploads,
+				c.UploadID,
+				c.CommitsBehind,
+				c.LastUploadedAt,
+				c.LastFailureAt,
+				c.LastFailureReason,
+			}
+		}
+	}()
+
+	return ch
+}
+
+// GetCodeIntelCoverage returns a page of the coverage reports of all repositories visible to the
+// current user along with the total number of entries matching the given options. Repositories
+// whose report has no languages are returned as a single uncovered entry with an empty language.
+func (s *store) GetCodeIntelCoverage(ctx context.Context, opts shared.GetCodeIntelCoverageOptions) (_ []shared.CodeIntelCoverage, _ int, err error) {
+	ctx, _, endObservation := s.operations.getCodeIntelCoverage.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("term", opts.Term),
+		log.String("language", opts.Language),
+		log.Int("limit", opts.Limit),
+		log.Int("offset", opts.Offset),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	authzConds, err := database.AuthzQueryConds(ctx, database.NewDBWith(s.logger, s.db))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	conds := []*sqlf.Query{authzConds}
+	if opts.Term != "" {
+		conds = append(conds, sqlf.Sprintf("repo.name ILIKE %s", "%"+opts.Term+"%"))
+	}
+	if opts.Language != "" {
+		conds = append(conds, sqlf.Sprintf("c.language = %s", opts.Language))
+	}
+	if opts.Covered != nil {
+		if *opts.Covered {
+			conds = append(conds, sqlf.Sprintf("c.upload_id IS NOT NULL"))
+		} else {
+			conds = append(conds, sqlf.Sprintf("c.upload_id IS NULL"))
+		}
+	}
+	if opts.MaxCommitsBehind != nil {
+		conds = append(conds, sqlf.Sprintf("c.commits_behind <= %s", *opts.MaxCommitsBehind))
+	}
+
+	return scanCodeIntelCoverageWithCount(s.db.Query(ctx, sqlf.Sprintf(
+		getCodeIntelCoverageQuery,
+		sqlf.Join(conds, " AND "),
+		opts.Limit,
+		opts.Offset,
+	)))
+}
+
+const getCodeIntelCoverageQuery = `
+SELECT
+	ccr.repository_id,
+	repo.name,
+	COALESCE(c.language, ''),
+	COALESCE(c.indexers, '{}'),
+	COALESCE(c.num_uploads, 0),
+	c.upload_id,
+	c.commits_behind,
+	c.last_uploaded_at,
+	c.last_failure_at,
+	c.last_failure_reason,
+	ccr.computed_at,
+	COUNT(*) OVER() AS count
+FROM codeintel_coverage_reports ccr
+JOIN repo ON repo.id = ccr.repository_id
+LEFT JOIN codeintel_coverage c ON c.repository_id = ccr.repository_id
+WHERE repo.deleted_at IS NULL AND %s
+ORDER BY repo.name, c.language
+LIMIT %s OFFSET %s
+`
+
+var scanCodeIntelCoverageWithCount = basestore.NewSliceWithCountScanner(func(s dbutil.Scanner) (c shared.CodeIntelCoverage, count int, _ error) {
+	err := s.Scan(
+		&c.RepositoryID,
+		&c.RepositoryName,
+		&c.Language,
+		pq.Array(&c.Indexers),
+		&c.NumUploads,
+		&c.UploadID,
+		&c.CommitsBehind,
+		&c.LastUploadedAt,
+		&c.LastFailureAt,
+		&c.LastFailureReason,
+		&c.ComputedAt,
+		&count,
+	)
+	return c, count, err
+})
@@ -0,0 +1,239 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/internal/commitgraph"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestGetRepositoriesForCoverageReport(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	now := time.Unix(1587396557, 0).UTC()
+
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO repo (id, name, deleted_at) VALUES (50, 'foo', NULL);
+		INSERT INTO repo (id, name, deleted_at) VALUES (51, 'bar', NULL);
+		INSERT INTO repo (id, name, deleted_at) VALUES (52, 'baz', NULL);
+		INSERT INTO repo (id, name, deleted_at) VALUES (53, 'del', NOW());
+		INSERT INTO repo (id, name, deleted_at) VALUES (54, 'none', NULL);
+		INSERT INTO lsif_uploads (id, repository_id, commit, indexer, num_parts, uploaded_parts, state) VALUES (100, 50, '0000000000000000000000000000000000000001', 'scip-go', 1, '{}', 'completed');
+		INSERT INTO lsif_uploads (id, repository_id, commit, indexer, num_parts, uploaded_parts, state) VALUES (101, 53, '0000000000000000000000000000000000000002', 'scip-go', 1, '{}', 'completed');
+		INSERT INTO lsif_indexes (id, repository_id, commit, indexer, state, docker_steps, root, indexer_args, outfile, local_steps) VALUES (200, 51, '0000000000000000000000000000000000000003', 'scip-java', 'failed', '{}', '', '{}', '', '{}');
+		INSERT INTO lsif_indexes (id, repository_id, commit, indexer, state, docker_steps, root, indexer_args, outfile, local_steps) VALUES (201, 52, '0000000000000000000000000000000000000004', 'scip-java', 'queued', '{}', '', '{}', '', '{}');
+	`); err != nil {
+		t.Fatalf("unexpected error setting up test: %s", err)
+	}
+
+	// Repository 50 has a recently computed report
+	if err := store.UpdateCodeIntelCoverage(ctx, 50, "", nil, now.Add(-time.Minute)); err != nil {
+		t.Fatalf("unexpected error updating coverage: %s", err)
+	}
+
+	repositoryIDs, err := store.GetRepositoriesForCoverageReport(ctx, time.Hour, 10, now)
+	if err != nil {
+		t.Fatalf("unexpected error getting repositories: %s", err)
+	}
+	if diff := cmp.Diff([]int{51, 52, 54}, repositoryIDs); diff != "" {
+		t.Errorf("unexpected repositories (-want +got):\n%s", diff)
+	}
+
+	// Repository 50's report is now stale
+	repositoryIDs, err = store.GetRepositoriesForCoverageReport(ctx, time.Hour, 10, now.Add(time.Hour*2))
+	if err != nil {
+		t.Fatalf("unexpected error getting repositories: %s", err)
+	}
+	if diff := cmp.Diff([]int{51, 52, 54, 50}, repositoryIDs); diff != "" {
+		t.Errorf("unexpected repositories (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetCoverageUploadsAtTip(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	uploadedAt := time.Unix(1587396557, 0).UTC()
+
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO repo (id, name) VALUES (50, 'foo');
+		INSERT INTO lsif_uploads (id, repository_id, commit, indexer, num_parts, uploaded_parts, state, uploaded_at) VALUES (100, 50, '0000000000000000000000000000000000000001', 'scip-go', 1, '{}', 'completed', '2020-04-20 15:29:17+00');
+		INSERT INTO lsif_uploads (id, repository_id, commit, indexer, num_parts, uploaded_parts, state, uploaded_at) VALUES (101, 50, '0000000000000000000000000000000000000002', 'scip-typescript', 1, '{}', 'completed', '2020-04-20 15:29:17+00');
+		INSERT INTO lsif_uploads (id, repository_id, commit, indexer, num_parts, uploaded_parts, state, uploaded_at) VALUES (102, 50, '0000000000000000000000000000000000000002', 'scip-python', 1, '{}', 'completed', '2020-04-20 15:29:17+00');
+		INSERT INTO lsif_uploads_visible_at_tip (upload_id, repository_id, is_default_branch) VALUES (100, 50, true);
+		INSERT INTO lsif_uploads_visible_at_tip (upload_id, repository_id, is_default_branch) VALUES (101, 50, true);
+		INSERT INTO lsif_uploads_visible_at_tip (upload_id, repository_id, is_default_branch) VALUES (102, 50, false);
+	`); err != nil {
+		t.Fatalf("unexpected error setting up test: %s", err)
+	}
+
+	insertNearestUploads(t, db, 50, map[string][]commitgraph.UploadMeta{
+		makeCommit(3): {{UploadID: 100, Distance: 2}},
+	})
+
+	uploads, err := store.GetCoverageUploadsAtTip(ctx, 50, makeCommit(3))
+	if err != nil {
+		t.Fatalf("unexpected error getting uploads: %s", err)
+	}
+	expectedUploads := []CoverageUpload{
+		{ID: 100, Indexer: "scip-go", UploadedAt: uploadedAt, CommitsBehind: intPtr(2)},
+		{ID: 101, Indexer: "scip-typescript", UploadedAt: uploadedAt},
+	}
+	if diff := cmp.Diff(expectedUploads, uploads); diff != "" {
+		t.Errorf("unexpected uploads (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetCoverageFailures(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	t1 := time.Unix(1587396557, 0).UTC()
+	t2 := t1.Add(time.Hour)
+
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO repo (id, name) VALUES (50, 'foo');
+		INSERT INTO lsif_uploads (id, repository_id, commit, indexer, num_parts, uploaded_parts, state, finished_at, failure_message) VALUES (100, 50, '0000000000000000000000000000000000000001', 'scip-go', 1, '{}', 'failed', '2020-04-20 15:29:17+00', 'corrupt upload');
+		INSERT INTO lsif_uploads (id, repository_id, commit, indexer, num_parts, uploaded_parts, state, finished_at) VALUES (101, 50, '0000000000000000000000000000000000000002', 'scip-go', 1, '{}', 'completed', '2020-04-20 16:29:17+00');
+		INSERT INTO lsif_indexes (id, repository_id, commit, indexer, state, docker_steps, root, indexer_args, outfile, local_steps, finished_at, failure_message) VALUES (200, 50, '0000000000000000000000000000000000000003', 'scip-go', 'errored', '{}', '', '{}', '', '{}', '2020-04-20 16:29:17+00', 'go mod download failed');
+		INSERT INTO lsif_indexes (id, repository_id, commit, indexer, state, docker_steps, root, indexer_args, outfile, local_steps, finished_at, failure_message) VALUES (201, 50, '0000000000000000000000000000000000000004', 'scip-java', 'failed', '{}', '', '{}', '', '{}', '2020-04-20 15:29:17+00', 'no build tool');
+	`); err != nil {
+		t.Fatalf("unexpected error setting up test: %s", err)
+	}
+
+	failures, err := store.GetCoverageFailures(ctx, 50)
+	if err != nil {
+		t.Fatalf("unexpected error getting failures: %s", err)
+	}
+	expectedFailures := []CoverageFailure{
+		{Indexer: "scip-go", FailedAt: t2, Reason: "go mod download failed"},
+		{Indexer: "scip-java", FailedAt: t1, Reason: "no build tool"},
+	}
+	if diff := cmp.Diff(expectedFailures, failures); diff != "" {
+		t.Errorf("unexpected failures (-want +got):\n%s", diff)
+	}
+}
+
+func TestCodeIntelCoverage(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	now := time.Unix(1587396557, 0).UTC()
+
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO repo (id, name) VALUES (50, 'github.com/foo/foo');
+		INSERT INTO repo (id, name) VALUES (51, 'github.com/foo/bar');
+		INSERT INTO repo (id, name) VALUES (52, 'github.com/foo/baz');
+		INSERT INTO lsif_uploads (id, repository_id, commit, indexer, num_parts, uploaded_parts, state) VALUES (100, 50, '0000000000000000000000000000000000000001', 'scip-go', 1, '{}', 'completed');
+		INSERT INTO lsif_uploads (id, repository_id, commit, indexer, num_parts, uploaded_parts, state) VALUES (101, 51, '0000000000000000000000000000000000000002', 'scip-go', 1, '{}', 'completed');
+	`); err != nil {
+		t.Fatalf("unexpected error setting up test: %s", err)
+	}
+
+	failureReason := "no build tool"
+	coverage50 := []shared.CodeIntelCoverage{
+		{Language: "Go", Indexers: []string{"scip-go"}, NumUploads: 1, UploadID: intPtr(100), CommitsBehind: intPtr(3), LastUploadedAt: &now},
+		{Language: "Java", Indexers: []string{"scip-java"}, LastFailureAt: &now, LastFailureReason: &failureReason},
+	}
+	coverage51 := []shared.CodeIntelCoverage{
+		{Language: "Go", Indexers: []string{"scip-go"}, NumUploads: 1, UploadID: intPtr(101), CommitsBehind: intPtr(30), LastUploadedAt: &now},
+	}
+
+	// Recomputing a report replaces the previous one
+	if err := store.UpdateCodeIntelCoverage(ctx, 50, makeCommit(9), coverage51, now); err != nil {
+		t.Fatalf("unexpected error updating coverage: %s", err)
+	}
+	if err := store.UpdateCodeIntelCoverage(ctx, 50, makeCommit(9), coverage50, now); err != nil {
+		t.Fatalf("unexpected error updating coverage: %s", err)
+	}
+	if err := store.UpdateCodeIntelCoverage(ctx, 51, "", coverage51, now); err != nil {
+		t.Fatalf("unexpected error updating coverage: %s", err)
+	}
+
+	// Repository 52 has no code intelligence data
+	if err := store.UpdateCodeIntelCoverage(ctx, 52, makeCommit(8), nil, now); err != nil {
+		t.Fatalf("unexpected error updating coverage: %s", err)
+	}
+
+	withRepository := func(c shared.CodeIntelCoverage, repositoryID int, repositoryName string) shared.CodeIntelCoverage {
+		c.RepositoryID = repositoryID
+		c.RepositoryName = repositoryName
+		c.ComputedAt = now
+		return c
+	}
+	barGo := withRepository(coverage51[0], 51, "github.com/foo/bar")
+	fooGo := withRepository(coverage50[0], 50, "github.com/foo/foo")
+	fooJava := withRepository(coverage50[1], 50, "github.com/foo/foo")
+	bazNone := withRepository(shared.CodeIntelCoverage{Indexers: []string{}}, 52, "github.com/foo/baz")
+
+	covered := true
+	uncovered := false
+	maxCommitsBehind := 10
+
+	testCases := []struct {
+		name          string
+		opts          shared.GetCodeIntelCoverageOptions
+		expected      []shared.CodeIntelCoverage
+		expectedCount int
+	}{
+		{"all", shared.GetCodeIntelCoverageOptions{Limit: 10}, []shared.CodeIntelCoverage{barGo, bazNone, fooGo, fooJava}, 4},
+		{"paged", shared.GetCodeIntelCoverageOptions{Limit: 1, Offset: 2}, []shared.CodeIntelCoverage{fooGo}, 4},
+		{"term", shared.GetCodeIntelCoverageOptions{Term: "foo/foo", Limit: 10}, []shared.CodeIntelCoverage{fooGo, fooJava}, 2},
+		{"language", shared.GetCodeIntelCoverageOptions{Language: "Go", Limit: 10}, []shared.CodeIntelCoverage{barGo, fooGo}, 2},
+		{"covered", shared.GetCodeIntelCoverageOptions{Covered: &covered, Limit: 10}, []shared.CodeIntelCoverage{barGo, fooGo}, 2},
+		{"uncovered", shared.GetCodeIntelCoverageOptions{Covered: &uncovered, Limit: 10}, []shared.CodeIntelCoverage{bazNone, fooJava}, 2},
+		{"max commits behind", shared.GetCodeIntelCoverageOptions{MaxCommitsBehind: &maxCommitsBehind, Limit: 10}, []shared.CodeIntelCoverage{fooGo}, 1},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			coverage, totalCount, err := store.GetCodeIntelCoverage(ctx, testCase.opts)
+			if err != nil {
+				t.Fatalf("unexpected error getting coverage: %s", err)
+			}
+			if totalCount != testCase.expectedCount {
+				t.Errorf("unexpected total count: want=%d got=%d", testCase.expectedCount, totalCount)
+			}
+			if diff := cmp.Diff(testCase.expected, coverage); diff != "" {
+				t.Errorf("unexpected coverage (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func intPtr(v int) *int {
+	return &v
+}
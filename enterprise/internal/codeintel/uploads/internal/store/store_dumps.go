@@ -29,6 +29,9 @@ import (
 // will return no dumps (as the input commit is not reachable from anything with an upload). The nearest uploads table must be
 // refreshed before calling this method when the commit is unknown.
 //
+// Ephemeral uploads are not part of the commit graph. They are returned only when the input commit is the exact commit of the
+// ephemeral upload, in which case they shadow any upload with the same root and indexer visible from an ancestor commit.
+//
 // Because refreshing the commit graph can be very expensive, we also provide FindClosestDumpsFromGraphFragment. That method should
 // be used instead in low-latency paths. It should be supplied a small fragment of the commit graph that contains the input commit
 // as well as a commit that is likely to exist in the lsif_nearest_uploads table. This is enough to propagate the correct upload
@@ -117,10 +120,13 @@ func (s *store) FindClosestDumpsFromGraphFragment(ctx context.Context, repositor
 		commits = append(commits, commit)
 	}
 
+	// Ephemeral uploads of the target commit are added to the graph as if they were defined at that
+	// commit. Ephemeral uploads of other commits in the fragment are not visible from the target commit.
 	commitGraphView, err := scanCommitGraphView(s.db.Query(ctx, sqlf.Sprintf(
 		findClosestDumpsFromGraphFragmentCommitGraphQuery,
-		makeVisibleUploadCandidatesQuery(repositoryID, commits...)),
-	))
+		makeVisibleUploadCandidatesQuery(repositoryID, commits...),
+		makeEphemeralUploadCandidatesQuery(repositoryID, commit, false),
+	)))
 	if err != nil {
 		return nil, err
 	}
@@ -150,7 +156,11 @@ func (s *store) FindClosestDumpsFromGraphFragment(ctx context.Context, repositor
 
 const findClosestDumpsFromGraphFragmentCommitGraphQuery = `
 WITH
-visible_uploads AS (%s)
+visible_uploads AS (
+	(%s)
+	UNION ALL
+	(%s)
+)
 SELECT
 	vu.upload_id,
 	encode(vu.commit_bytea, 'hex'),
@@ -388,9 +398,14 @@ func makeFindClosestDumpConditions(path string, rootMustEnclosePath bool, indexe
 
 // makeVisibleUploadsQuery returns a SQL query returning the set of identifiers of uploads
 // visible from the given commit. This is done by removing the "shadowed" values created
-// by looking at a commit and it's ancestors visible commits.
+// by looking at a commit and it's ancestors visible commits. Ephemeral uploads of the given
+// commit are considered only if the commit is known to the commit graph.
 func makeVisibleUploadsQuery(repositoryID int, commit string) *sqlf.Query {
-	return sqlf.Sprintf(visibleUploadsQuery, makeVisibleUploadCandidatesQuery(repositoryID, commit))
+	return sqlf.Sprintf(
+		visibleUploadsQuery,
+		makeVisibleUploadCandidatesQuery(repositoryID, commit),
+		makeEphemeralUploadCandidatesQuery(repositoryID, commit, true),
+	)
 }
 
 const visibleUploadsQuery = `
@@ -400,7 +415,11 @@ FROM (
 	SELECT
 		t.*,
 		row_number() OVER (PARTITION BY root, indexer ORDER BY distance) AS r
-	FROM (%s) t
+	FROM (
+		(%s)
+		UNION ALL
+		(%s)
+	) t
 	JOIN lsif_uploads u ON u.id = upload_id
 ) t
 WHERE t.r <= 1
@@ -446,3 +465,45 @@ UNION (
 	WHERE nu.repository_id = %s AND ul.commit_bytea IN (%s)
 )
 `
+
+// makeEphemeralUploadCandidatesQuery returns a SQL query returning the set of ephemeral uploads
+// of the given commit in the same shape as makeVisibleUploadCandidatesQuery. Ephemeral uploads
+// are visible only from their own commit, so they are always returned with a distance of zero.
+//
+// If requireKnownCommit is true, no uploads are returned unless the commit is known to the commit
+// graph. Callers resolving uploads of unknown commits fall back to a graph fragment when no upload
+// is visible, which would be skipped if only the ephemeral uploads of the commit were returned.
+func makeEphemeralUploadCandidatesQuery(repositoryID int, commit string, requireKnownCommit bool) *sqlf.Query {
+	cond := sqlf.Sprintf("TRUE")
+	if requireKnownCommit {
+		cond = sqlf.Sprintf(
+			ephemeralUploadCandidatesKnownCommitQuery,
+			repositoryID, dbutil.CommitBytea(commit),
+			repositoryID, dbutil.CommitBytea(commit),
+		)
+	}
+
+	return sqlf.Sprintf(ephemeralUploadCandidatesQuery, repositoryID, commit, cond)
+}
+
+const ephemeralUploadCandidatesQuery = `
+SELECT
+	u.repository_id,
+	u.id,
+	decode(u.commit, 'hex'),
+	0
+FROM lsif_uploads u
+WHERE
+	u.repository_id = %s AND
+	u.commit = %s AND
+	u.state = 'completed' AND
+	u.ephemeral AND
+	%s
+`
+
+const ephemeralUploadCandidatesKnownCommitQuery = `
+(
+	EXISTS (SELECT 1 FROM lsif_nearest_uploads WHERE repository_id = %s AND commit_bytea = %s) OR
+	EXISTS (SELECT 1 FROM lsif_nearest_uploads_links WHERE repository_id = %s AND commit_bytea = %s)
+)
+`
@@ -604,6 +604,62 @@ func TestFindClosestDumpsFromGraphFragment(t *testing.T) {
 	})
 }
 
+func TestFindClosestDumpsEphemeral(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	// This database has the following commit graph:
+	//
+	//    <- known commits || new commits ->
+	//                     ||
+	// [1] -- 2 -- (3)     ||
+	//        |            ||
+	//        +----------- || -- (4) -- (5)
+	//
+	// Commits in parentheses have ephemeral uploads.
+
+	uploads := []types.Upload{
+		{ID: 1, Commit: makeCommit(1)},
+		{ID: 2, Commit: makeCommit(1), Root: "lib/"},
+	}
+	ephemeralUploads := []types.Upload{
+		{ID: 3, Commit: makeCommit(3), Ephemeral: true},
+		{ID: 4, Commit: makeCommit(4), Ephemeral: true},
+		{ID: 5, Commit: makeCommit(5), Root: "lib/", Ephemeral: true},
+	}
+	insertUploads(t, db, append(uploads, ephemeralUploads...)...)
+
+	currentGraph := gitdomain.ParseCommitGraph([]string{
+		strings.Join([]string{makeCommit(3), makeCommit(2)}, " "),
+		strings.Join([]string{makeCommit(2), makeCommit(1)}, " "),
+		strings.Join([]string{makeCommit(1)}, " "),
+	})
+
+	// Ephemeral uploads are not part of the commit graph
+	visibleUploads, links := commitgraph.NewGraph(currentGraph, toCommitGraphView(uploads)).Gather()
+
+	// Prep
+	insertNearestUploads(t, db, 50, visibleUploads)
+	insertLinks(t, db, 50, links)
+
+	// Test
+	graphFragment := gitdomain.ParseCommitGraph([]string{
+		strings.Join([]string{makeCommit(5), makeCommit(4)}, " "),
+		strings.Join([]string{makeCommit(4), makeCommit(2)}, " "),
+		strings.Join([]string{makeCommit(3), makeCommit(2)}, " "),
+		strings.Join([]string{makeCommit(2)}, " "),
+	})
+
+	testFindClosestDumps(t, store, []FindClosestDumpsTestCase{
+		{commit: makeCommit(2), file: "lib/file.ts", rootMustEnclosePath: true, graph: graphFragment, allOfIDs: []int{1, 2}},
+		{commit: makeCommit(3), file: "lib/file.ts", rootMustEnclosePath: true, graph: graphFragment, allOfIDs: []int{2, 3}},
+		{commit: makeCommit(4), file: "lib/file.ts", rootMustEnclosePath: true},
+		{commit: makeCommit(4), file: "lib/file.ts", rootMustEnclosePath: true, graph: graphFragment, graphFragmentOnly: true, allOfIDs: []int{2, 4}},
+		{commit: makeCommit(5), file: "lib/file.ts", rootMustEnclosePath: true, graph: graphFragment, graphFragmentOnly: true, allOfIDs: []int{1, 5}},
+	})
+}
+
 func TestDefinitionDumps(t *testing.T) {
 	logger := logtest.Scoped(t)
 	db := database.NewDB(logger, dbtest.NewDB(logger, t))
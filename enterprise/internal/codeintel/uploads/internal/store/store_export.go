@@ -56,6 +56,7 @@ WITH candidates AS (
 						re.upload_id = uvt.upload_id
 				)
 		) AND
+		NOT u.ephemeral AND
 		r.deleted_at IS NULL AND
 		r.blocked IS NULL
 	ORDER BY u.id DESC
@@ -0,0 +1,269 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// UnreferencedSymbolsUpload is a SCIP upload visible at the tip of the default branch of a
+// repository whose unreferenced symbols report is due to be recomputed.
+type UnreferencedSymbolsUpload struct {
+	ID           int
+	RepositoryID int
+	Root         string
+}
+
+var scanUnreferencedSymbolsUploads = basestore.NewSliceScanner(func(s dbutil.Scanner) (u UnreferencedSymbolsUpload, _ error) {
+	err := s.Scan(&u.ID, &u.RepositoryID, &u.Root)
+	return u, err
+})
+
+// GetUploadsForUnreferencedSymbolsReport returns the SCIP uploads visible at the tip of the default
+// branch of (at most) the given number of repositories whose unreferenced symbols report has not been
+// computed within the given process delay. Repositories whose report is the oldest are returned first.
+func (s *store) GetUploadsForUnreferencedSymbolsReport(ctx context.Context, processDelay time.Duration, limit int, now time.Time) (_ []UnreferencedSymbolsUpload, err error) {
+	ctx, _, endObservation := s.operations.getUploadsForUnreferencedSymbolsReport.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("limit", limit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return scanUnreferencedSymbolsUploads(s.db.Query(ctx, sqlf.Sprintf(
+		getUploadsForUnreferencedSymbolsReportQuery,
+		now,
+		processDelay/time.Second,
+		limit,
+	)))
+}
+
+const getUploadsForUnreferencedSymbolsReportQuery = `
+WITH
+candidate_uploads AS (
+	SELECT u.id, u.repository_id, u.root
+	FROM lsif_uploads u
+	JOIN lsif_uploads_visible_at_tip uvt ON uvt.upload_id = u.id
+	JOIN repo r ON r.id = u.repository_id
+	WHERE
+		uvt.is_default_branch AND
+		u.content_type = 'application/x-protobuf+scip' AND
+		NOT u.ephemeral AND
+		r.deleted_at IS NULL AND
+		r.blocked IS NULL
+),
+candidate_repositories AS (
+	SELECT cu.repository_id
+	FROM candidate_uploads cu
+	LEFT JOIN codeintel_unreferenced_symbols_reports usr ON usr.repository_id = cu.repository_id
+	WHERE usr.computed_at IS NULL OR %s - usr.computed_at > (%s * '1 second'::interval)
+	GROUP BY cu.repository_id, usr.computed_at
+	ORDER BY usr.computed_at NULLS FIRST, cu.repository_id
+	LIMIT %s
+)
+SELECT cu.id, cu.repository_id, cu.root
+FROM candidate_uploads cu
+WHERE cu.repository_id IN (SELECT repository_id FROM candidate_repositories)
+ORDER BY cu.repository_id, cu.id
+`
+
+// GetReferencingUploadIDs returns the identifiers of the uploads visible at the tip of the default branch
+// of any repository (other than the given upload) that reference a package provided by the given upload.
+func (s *store) GetReferencingUploadIDs(ctx context.Context, uploadID int) (_ []int, err error) {
+	ctx, _, endObservation := s.operations.getReferencingUploadIDs.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("uploadID", uploadID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return basestore.ScanInts(s.db.Query(ctx, sqlf.Sprintf(getReferencingUploadIDsQuery, uploadID, uploadID)))
+}
+
+const getReferencingUploadIDsQuery = `
+SELECT DISTINCT r.dump_id
+FROM lsif_packages p
+JOIN lsif_references r ON
+	r.scheme = p.scheme AND
+	r.manager = p.manager AND
+	r.name = p.name AND
+	r.version = p.version
+WHERE
+	p.dump_id = %s AND
+	r.dump_id != %s AND
+	r.dump_id IN (SELECT uvt.upload_id FROM lsif_uploads_visible_at_tip uvt WHERE uvt.is_default_branch)
+ORDER BY r.dump_id
+`
+
+// UpdateUnreferencedSymbols replaces the unreferenced symbols report of the given repository.
+func (s *store) UpdateUnreferencedSymbols(ctx context.Context, repositoryID int, symbols []shared.UnreferencedSymbol, now time.Time) (err error) {
+	ctx, _, endObservation := s.operations.updateUnreferencedSymbols.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+		log.Int("numSymbols", len(symbols)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	tx, err := s.db.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(deleteUnreferencedSymbolsQuery, repositoryID)); err != nil {
+		return err
+	}
+
+	if err := batch.InsertValues(
+		ctx,
+		tx.Handle(),
+		"codeintel_unreferenced_symbols",
+		batch.MaxNumPostgresParameters,
+		[]string{"repository_id", "upload_id", "symbol_name", "document_path", "language"},
+		loadUnreferencedSymbolsChannel(repositoryID, symbols),
+	); err != nil {
+		return err
+	}
+
+	return tx.Exec(ctx, sqlf.Sprintf(updateUnreferencedSymbolsReportQuery, repositoryID, now))
+}
+
+const deleteUnreferencedSymbolsQuery = `
+DELETE FROM codeintel_unreferenced_symbols WHERE repository_id = %s
+`
+
+const updateUnreferencedSymbolsReportQuery = `
+INSERT INTO codeintel_unreferenced_symbols_reports (repository_id, computed_at)
+VALUES (%s, %s)
+ON CONFLICT (repository_id) DO UPDATE SET computed_at = EXCLUDED.computed_at
+`
+
+func loadUnreferencedSymbolsChannel(repositoryID int, symbols []shared.UnreferencedSymbol) <-chan []any {
+	ch := make(chan []any, len(symbols))
+
+	go func() {
+		defer close(ch)
+
+		for _, symbol := range symbols {
+			ch <- []any{repositoryID, symbol.UploadID, symbol.SymbolName, symbol.DocumentPath, symbol.Language}
+		}
+	}()
+
+	return ch
+}
+
+// GetUnreferencedSymbols returns a page of the unreferenced symbols report of the given repository
+// along with the total number of symbols matching the given options.
+func (s *store) GetUnreferencedSymbols(ctx context.Context, opts shared.GetUnreferencedSymbolsOptions) (_ []shared.UnreferencedSymbol, _ int, err error) {
+	ctx, _, endObservation := s.operations.getUnreferencedSymbols.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", opts.RepositoryID),
+		log.String("language", opts.Language),
+		log.Int("limit", opts.Limit),
+		log.Int("offset", opts.Offset),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	authzConds, err := database.AuthzQueryConds(ctx, database.NewDBWith(s.logger, s.db))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	conds := []*sqlf.Query{
+		sqlf.Sprintf("us.repository_id = %s", opts.RepositoryID),
+		authzConds,
+	}
+	if opts.Language != "" {
+		conds = append(conds, sqlf.Sprintf("us.language = %s", opts.Language))
+	}
+
+	return scanUnreferencedSymbolsWithCount(s.db.Query(ctx, sqlf.Sprintf(
+		getUnreferencedSymbolsQuery,
+		sqlf.Join(conds, " AND "),
+		opts.Limit,
+		opts.Offset,
+	)))
+}
+
+const getUnreferencedSymbolsQuery = `
+SELECT
+	us.repository_id,
+	us.upload_id,
+	us.symbol_name,
+	us.document_path,
+	us.language,
+	COUNT(*) OVER() AS count
+FROM codeintel_unreferenced_symbols us
+JOIN repo ON repo.id = us.repository_id
+WHERE repo.deleted_at IS NULL AND %s
+ORDER BY us.language, us.document_path, us.symbol_name
+LIMIT %s OFFSET %s
+`
+
+var scanUnreferencedSymbolsWithCount = basestore.NewSliceWithCountScanner(func(s dbutil.Scanner) (symbol shared.UnreferencedSymbol, count int, _ error) {
+	err := s.Scan(
+		&symbol.RepositoryID,
+		&symbol.UploadID,
+		&symbol.SymbolName,
+		&symbol.DocumentPath,
+		&symbol.Language,
+		&count,
+	)
+	return symbol, count, err
+})
+
+// GetUnreferencedSymbolsSummary returns the time the unreferenced symbols report of the given repository
+// was last computed along with the number of unreferenced symbols per language. A false-valued flag is
+// returned if the report has not yet been computed.
+func (s *store) GetUnreferencedSymbolsSummary(ctx context.Context, repositoryID int) (_ shared.UnreferencedSymbolsSummary, _ bool, err error) {
+	ctx, _, endObservation := s.operations.getUnreferencedSymbolsSummary.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	authzConds, err := database.AuthzQueryConds(ctx, database.NewDBWith(s.logger, s.db))
+	if err != nil {
+		return shared.UnreferencedSymbolsSummary{}, false, err
+	}
+
+	computedAt, ok, err := basestore.ScanFirstTime(s.db.Query(ctx, sqlf.Sprintf(getUnreferencedSymbolsReportQuery, repositoryID, authzConds)))
+	if err != nil || !ok {
+		return shared.UnreferencedSymbolsSummary{}, false, err
+	}
+
+	languages, err := scanUnreferencedSymbolsLanguageCounts(s.db.Query(ctx, sqlf.Sprintf(getUnreferencedSymbolsLanguageCountsQuery, repositoryID)))
+	if err != nil {
+		return shared.UnreferencedSymbolsSummary{}, false, err
+	}
+
+	return shared.UnreferencedSymbolsSummary{
+		ComputedAt: computedAt,
+		Languages:  languages,
+	}, true, nil
+}
+
+const getUnreferencedSymbolsReportQuery = `
+SELECT usr.computed_at
+FROM codeintel_unreferenced_symbols_reports usr
+JOIN repo ON repo.id = usr.repository_id
+WHERE
+	usr.repository_id = %s AND
+	repo.deleted_at IS NULL AND
+	%s -- authz conds
+`
+
+const getUnreferencedSymbolsLanguageCountsQuery = `
+SELECT us.language, COUNT(*)
+FROM codeintel_unreferenced_symbols us
+WHERE us.repository_id = %s
+GROUP BY us.language
+ORDER BY COUNT(*) DESC, us.language
+`
+
+var scanUnreferencedSymbolsLanguageCounts = basestore.NewSliceScanner(func(s dbutil.Scanner) (languageCount shared.UnreferencedSymbolsLanguageCount, _ error) {
+	err := s.Scan(&languageCount.Language, &languageCount.Count)
+	return languageCount, err
+})
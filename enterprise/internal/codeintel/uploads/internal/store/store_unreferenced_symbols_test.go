@@ -0,0 +1,189 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestGetUploadsForUnreferencedSymbolsReport(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	now := time.Unix(1587396557, 0).UTC()
+
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO repo (id, name, deleted_at) VALUES (50, 'foo', NULL);
+		INSERT INTO repo (id, name, deleted_at) VALUES (51, 'bar', NULL);
+		INSERT INTO repo (id, name, deleted_at) VALUES (52, 'baz', NULL);
+		INSERT INTO repo (id, name, deleted_at) VALUES (53, 'del', NOW());
+		INSERT INTO lsif_uploads (id, repository_id, commit, indexer, num_parts, uploaded_parts, state, root, content_type) VALUES (100, 50, '0000000000000000000000000000000000000001', 'scip-test', 1, '{}', 'completed', 'a/', 'application/x-protobuf+scip');
+		INSERT INTO lsif_uploads (id, repository_id, commit, indexer, num_parts, uploaded_parts, state, root, content_type) VALUES (101, 50, '0000000000000000000000000000000000000001', 'scip-test', 1, '{}', 'completed', 'b/', 'application/x-protobuf+scip');
+		INSERT INTO lsif_uploads (id, repository_id, commit, indexer, num_parts, uploaded_parts, state, root, content_type) VALUES (102, 51, '0000000000000000000000000000000000000002', 'scip-test', 1, '{}', 'completed', '', 'application/x-protobuf+scip');
+		INSERT INTO lsif_uploads (id, repository_id, commit, indexer, num_parts, uploaded_parts, state, root, content_type) VALUES (103, 52, '0000000000000000000000000000000000000003', 'lsif-test', 1, '{}', 'completed', '', 'application/x-ndjson+lsif');
+		INSERT INTO lsif_uploads (id, repository_id, commit, indexer, num_parts, uploaded_parts, state, root, content_type) VALUES (104, 53, '0000000000000000000000000000000000000004', 'scip-test', 1, '{}', 'completed', '', 'application/x-protobuf+scip');
+		INSERT INTO lsif_uploads_visible_at_tip (upload_id, repository_id, is_default_branch) VALUES (100, 50, true);
+		INSERT INTO lsif_uploads_visible_at_tip (upload_id, repository_id, is_default_branch) VALUES (101, 50, true);
+		INSERT INTO lsif_uploads_visible_at_tip (upload_id, repository_id, is_default_branch) VALUES (102, 51, true);
+		INSERT INTO lsif_uploads_visible_at_tip (upload_id, repository_id, is_default_branch) VALUES (103, 52, true);
+		INSERT INTO lsif_uploads_visible_at_tip (upload_id, repository_id, is_default_branch) VALUES (104, 53, true);
+	`); err != nil {
+		t.Fatalf("unexpected error setting up test: %s", err)
+	}
+
+	// Repository 50 has a recently computed report
+	if err := store.UpdateUnreferencedSymbols(ctx, 50, nil, now.Add(-time.Minute)); err != nil {
+		t.Fatalf("unexpected error updating unreferenced symbols: %s", err)
+	}
+
+	uploads, err := store.GetUploadsForUnreferencedSymbolsReport(ctx, time.Hour, 10, now)
+	if err != nil {
+		t.Fatalf("unexpected error getting uploads: %s", err)
+	}
+	expectedUploads := []UnreferencedSymbolsUpload{
+		{ID: 102, RepositoryID: 51, Root: ""},
+	}
+	if diff := cmp.Diff(expectedUploads, uploads); diff != "" {
+		t.Errorf("unexpected uploads (-want +got):\n%s", diff)
+	}
+
+	// Repository 50's report is now stale
+	uploads, err = store.GetUploadsForUnreferencedSymbolsReport(ctx, time.Hour, 10, now.Add(time.Hour*2))
+	if err != nil {
+		t.Fatalf("unexpected error getting uploads: %s", err)
+	}
+	expectedUploads = []UnreferencedSymbolsUpload{
+		{ID: 100, RepositoryID: 50, Root: "a/"},
+		{ID: 101, RepositoryID: 50, Root: "b/"},
+		{ID: 102, RepositoryID: 51, Root: ""},
+	}
+	if diff := cmp.Diff(expectedUploads, uploads); diff != "" {
+		t.Errorf("unexpected uploads (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetReferencingUploadIDs(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO repo (id, name) VALUES (50, 'foo');
+		INSERT INTO repo (id, name) VALUES (51, 'bar');
+		INSERT INTO lsif_uploads (id, repository_id, commit, indexer, num_parts, uploaded_parts, state) VALUES (100, 50, '0000000000000000000000000000000000000001', 'scip-test', 1, '{}', 'completed');
+		INSERT INTO lsif_uploads (id, repository_id, commit, indexer, num_parts, uploaded_parts, state) VALUES (101, 51, '0000000000000000000000000000000000000002', 'scip-test', 1, '{}', 'completed');
+		INSERT INTO lsif_uploads (id, repository_id, commit, indexer, num_parts, uploaded_parts, state) VALUES (102, 51, '0000000000000000000000000000000000000003', 'scip-test', 1, '{}', 'completed');
+		INSERT INTO lsif_uploads_visible_at_tip (upload_id, repository_id, is_default_branch) VALUES (100, 50, true);
+		INSERT INTO lsif_uploads_visible_at_tip (upload_id, repository_id, is_default_branch) VALUES (101, 51, true);
+		INSERT INTO lsif_packages (dump_id, scheme, manager, name, version) VALUES (100, 'scip-go', 'gomod', 'example', 'v1');
+		INSERT INTO lsif_references (dump_id, scheme, manager, name, version) VALUES (101, 'scip-go', 'gomod', 'example', 'v1');
+		INSERT INTO lsif_references (dump_id, scheme, manager, name, version) VALUES (102, 'scip-go', 'gomod', 'example', 'v1');
+	`); err != nil {
+		t.Fatalf("unexpected error setting up test: %s", err)
+	}
+
+	ids, err := store.GetReferencingUploadIDs(ctx, 100)
+	if err != nil {
+		t.Fatalf("unexpected error getting referencing upload ids: %s", err)
+	}
+	if diff := cmp.Diff([]int{101}, ids); diff != "" {
+		t.Errorf("unexpected upload ids (-want +got):\n%s", diff)
+	}
+}
+
+func TestUnreferencedSymbols(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	now := time.Unix(1587396557, 0).UTC()
+
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO repo (id, name) VALUES (50, 'foo');
+		INSERT INTO lsif_uploads (id, repository_id, commit, indexer, num_parts, uploaded_parts, state) VALUES (100, 50, '0000000000000000000000000000000000000001', 'scip-test', 1, '{}', 'completed');
+	`); err != nil {
+		t.Fatalf("unexpected error setting up test: %s", err)
+	}
+
+	if _, ok, err := store.GetUnreferencedSymbolsSummary(ctx, 50); err != nil {
+		t.Fatalf("unexpected error getting summary: %s", err)
+	} else if ok {
+		t.Fatalf("expected no summary before the report is computed")
+	}
+
+	symbols := []shared.UnreferencedSymbol{
+		{RepositoryID: 50, UploadID: 100, SymbolName: "a", DocumentPath: "a.go", Language: "Go"},
+		{RepositoryID: 50, UploadID: 100, SymbolName: "b", DocumentPath: "b.go", Language: "Go"},
+		{RepositoryID: 50, UploadID: 100, SymbolName: "c", DocumentPath: "c.ts", Language: "TypeScript"},
+	}
+
+	// Write twice to ensure the previous report is replaced
+	for i := 0; i < 2; i++ {
+		if err := store.UpdateUnreferencedSymbols(ctx, 50, symbols, now); err != nil {
+			t.Fatalf("unexpected error updating unreferenced symbols: %s", err)
+		}
+	}
+
+	page, totalCount, err := store.GetUnreferencedSymbols(ctx, shared.GetUnreferencedSymbolsOptions{RepositoryID: 50, Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error getting unreferenced symbols: %s", err)
+	}
+	if totalCount != 3 {
+		t.Errorf("unexpected total count. want=%d have=%d", 3, totalCount)
+	}
+	if diff := cmp.Diff(symbols[:2], page); diff != "" {
+		t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+	}
+
+	page, totalCount, err = store.GetUnreferencedSymbols(ctx, shared.GetUnreferencedSymbolsOptions{RepositoryID: 50, Language: "TypeScript", Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error getting unreferenced symbols: %s", err)
+	}
+	if totalCount != 1 {
+		t.Errorf("unexpected total count. want=%d have=%d", 1, totalCount)
+	}
+	if diff := cmp.Diff(symbols[2:], page); diff != "" {
+		t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+	}
+
+	summary, ok, err := store.GetUnreferencedSymbolsSummary(ctx, 50)
+	if err != nil {
+		t.Fatalf("unexpected error getting summary: %s", err)
+	} else if !ok {
+		t.Fatalf("expected a summary")
+	}
+	expectedSummary := shared.UnreferencedSymbolsSummary{
+		ComputedAt: now,
+		Languages: []shared.UnreferencedSymbolsLanguageCount{
+			{Language: "Go", Count: 2},
+			{Language: "TypeScript", Count: 1},
+		},
+	}
+	if diff := cmp.Diff(expectedSummary, summary); diff != "" {
+		t.Errorf("unexpected summary (-want +got):\n%s", diff)
+	}
+}
@@ -93,6 +93,8 @@ SELECT
 	u.should_reindex,
 	s.rank,
 	u.uncompressed_size,
+	u.ephemeral,
+	u.base_upload_id,
 	COUNT(*) OVER() AS count
 FROM %s
 LEFT JOIN (` + uploadRankQueryFragment + `) s
@@ -140,7 +142,9 @@ SELECT
 	au.upload_size, au.associated_index_id, au.content_type,
 	false AS should_reindex, -- TODO
 	COALESCE((snapshot->'expired')::boolean, false) AS expired,
-	NULL::bigint AS uncompressed_size
+	NULL::bigint AS uncompressed_size,
+	false AS ephemeral,
+	NULL::integer AS base_upload_id
 FROM (
 	SELECT upload_id, snapshot_transition_columns(transition_columns ORDER BY sequence ASC) AS snapshot
 	FROM lsif_uploads_audit_logs
@@ -249,7 +253,9 @@ SELECT
 	u.content_type,
 	u.should_reindex,
 	s.rank,
-	u.uncompressed_size
+	u.uncompressed_size,
+	u.ephemeral,
+	u.base_upload_id
 FROM lsif_uploads u
 LEFT JOIN (` + uploadRankQueryFragment + `) s
 ON u.id = s.id
@@ -320,7 +326,9 @@ SELECT
 	u.content_type,
 	u.should_reindex,
 	s.rank,
-	u.uncompressed_size
+	u.uncompressed_size,
+	u.ephemeral,
+	u.base_upload_id
 FROM lsif_uploads u
 LEFT JOIN (` + uploadRankQueryFragment + `) s
 ON u.id = s.id
@@ -424,7 +432,9 @@ SELECT
 	u.content_type,
 	u.should_reindex,
 	s.rank,
-	u.uncompressed_size
+	u.uncompressed_size,
+	u.ephemeral,
+	u.base_upload_id
 FROM lsif_uploads_with_repository_name u
 LEFT JOIN (` + uploadRankQueryFragment + `) s
 ON u.id = s.id
@@ -686,7 +696,9 @@ referenced_uploads_providing_package_canonically AS (
 
 -- Filter the set of our original candidate uploads to exclude the "safe" uploads found
 -- above. This should include uploads that are expired and either not a canonical provider
--- of their package, or their package is unreferenced by any other upload. We can then lock
+-- of their package, or their package is unreferenced by any other upload. Uploads that are
+-- the base of an incremental upload that has not yet been processed are also retained, as
+-- their data is copied into the incremental upload during processing. We can then lock
 -- the uploads in a deterministic order and update the state of each upload to 'deleting'.
 -- Before hard-deletion, we will clear all associated data for this upload in the codeintel-db.
 candidates AS (
@@ -698,7 +710,8 @@ candidates AS (
 			SELECT 1
 			FROM referenced_uploads_providing_package_canonically pkg_refcount
 			WHERE pkg_refcount.id = u.id
-		)
+		) AND
+		NOT EXISTS (` + unprocessedIncrementalUploadsQueryFragment + `)
 ),
 locked_uploads AS (
 	SELECT u.id
@@ -884,9 +897,17 @@ updated AS (
 
 		-- Delete all of the upload we've traversed if and only if we've identified the entire
 		-- relevant subgraph (we didn't hit our LIMIT above) and every upload of the subgraph is
-		-- expired. If this is not the case, we leave the state the same for all uploads.
+		-- expired and not the base of an unprocessed incremental upload. If this is not the case,
+		-- we leave the state the same for all uploads.
 		state = CASE
-			WHEN (SELECT bool_and(d.expired) AND COUNT(*) <= %s FROM candidates d) THEN 'deleting'
+			WHEN (
+				SELECT bool_and(d.expired) AND COUNT(*) <= %s
+				FROM candidates d
+			) AND NOT EXISTS (
+				SELECT 1
+				FROM candidates u
+				WHERE EXISTS (` + unprocessedIncrementalUploadsQueryFragment + `)
+			) THEN 'deleting'
 			ELSE 'completed'
 		END
 	WHERE u.id IN (SELECT id FROM locked_uploads)
@@ -897,6 +918,16 @@ updated AS (
 SELECT u.repository_id, COUNT(*) FROM updated u WHERE u.state = 'deleting' GROUP BY u.repository_id
 `
 
+// unprocessedIncrementalUploadsQueryFragment selects the incremental uploads that have not yet
+// been processed and copy data from the upload u.
+const unprocessedIncrementalUploadsQueryFragment = `
+	SELECT 1
+	FROM lsif_uploads iu
+	WHERE
+		iu.base_upload_id = u.id AND
+		iu.state IN ('uploading', 'queued', 'processing')
+`
+
 // HardDeleteUploadsByIDs deletes the upload record with the given identifier.
 func (s *store) HardDeleteUploadsByIDs(ctx context.Context, ids ...int) (err error) {
 	ctx, _, endObservation := s.operations.hardDeleteUploadsByIDs.With(ctx, &err, observation.Args{LogFields: []log.Field{
@@ -1174,7 +1205,8 @@ func (s *store) UpdateUploadsVisibleToCommits(
 		attribute.String("maxAgeForNonStaleTags", maxAgeForNonStaleTags.String()))
 
 	// Pull all queryable upload metadata known to this repository so we can correlate
-	// it with the current  commit graph.
+	// it with the current  commit graph. Ephemeral uploads are not part of the commit
+	// graph and are only visible from their own commit (see makeVisibleUploadsQuery).
 	commitGraphView, err := scanCommitGraphView(tx.Query(ctx, sqlf.Sprintf(calculateVisibleUploadsCommitGraphQuery, repositoryID)))
 	if err != nil {
 		return err
@@ -1243,7 +1275,7 @@ func (s *store) UpdateUploadsVisibleToCommits(
 }
 
 const calculateVisibleUploadsCommitGraphQuery = `
-SELECT id, commit, md5(root || ':' || indexer) as token, 0 as distance FROM lsif_uploads WHERE state = 'completed' AND repository_id = %s
+SELECT id, commit, md5(root || ':' || indexer) as token, 0 as distance FROM lsif_uploads WHERE state = 'completed' AND NOT ephemeral AND repository_id = %s
 `
 
 const calculateVisibleUploadsDirtyRepositoryQuery = `
@@ -1417,6 +1449,74 @@ const referenceIDsCountQuery = referenceIDsCTEDefinitions + `
 SELECT COUNT(distinct r.dump_id)
 ` + referenceIDsBaseQuery
 
+// GetVisibleUploadsReferencingAnyVersion returns visible uploads that refer (via package information) to any
+// version of the given monikers' packages. The version of each matching package reference is retained so that
+// callers can group results by the version of the package each consumer depends on. At most limit uploads are
+// returned for each version. Visibility is determined in the same way as GetVisibleUploadsMatchingMonikers.
+func (s *store) GetVisibleUploadsReferencingAnyVersion(ctx context.Context, repositoryID int, commit string, monikers []precise.QualifiedMonikerData, limit int) (_ []shared.PackageReference, err error) {
+	ctx, _, endObservation := s.operations.getVisibleUploadsReferencingAnyVersion.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+		log.String("commit", commit),
+		log.Int("numMonikers", len(monikers)),
+		log.String("monikers", monikersToString(monikers)),
+		log.Int("limit", limit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(monikers) == 0 {
+		return nil, nil
+	}
+
+	qs := make([]*sqlf.Query, 0, len(monikers))
+	for _, moniker := range monikers {
+		qs = append(qs, sqlf.Sprintf("(%s, %s, %s)", moniker.Scheme, moniker.Manager, moniker.Name))
+	}
+
+	authzConds, err := database.AuthzQueryConds(ctx, database.NewDBWith(s.logger, s.db))
+	if err != nil {
+		return nil, err
+	}
+
+	query := sqlf.Sprintf(
+		referencesAcrossVersionsQuery,
+		makeVisibleUploadsQuery(repositoryID, commit),
+		repositoryID,
+		sqlf.Join(qs, ", "),
+		authzConds,
+		limit,
+	)
+
+	return scanPackageReferences(s.db.Query(ctx, query))
+}
+
+const referencesAcrossVersionsQuery = referenceIDsCTEDefinitions + `,
+matching_references AS (
+	SELECT DISTINCT r.dump_id, r.scheme, r.manager, r.name, r.version
+	FROM lsif_references r
+	LEFT JOIN lsif_dumps u ON u.id = r.dump_id
+	JOIN repo ON repo.id = u.repository_id
+	WHERE
+		(r.scheme, r.manager, r.name) IN (%s) AND
+		r.dump_id IN (SELECT * FROM visible_uploads) AND
+		%s -- authz conds
+),
+limited_uploads AS (
+	SELECT s.dump_id, s.version
+	FROM (
+		SELECT
+			mr.dump_id,
+			mr.version,
+			ROW_NUMBER() OVER (PARTITION BY mr.version ORDER BY mr.dump_id) AS row_number
+		FROM (SELECT DISTINCT dump_id, version FROM matching_references) mr
+	) s
+	WHERE s.row_number <= %s
+)
+SELECT mr.dump_id, mr.scheme, mr.manager, mr.name, mr.version
+FROM matching_references mr
+JOIN limited_uploads lu ON lu.dump_id = mr.dump_id AND lu.version = mr.version
+ORDER BY mr.version, mr.dump_id
+`
+
 // refineRetentionConfiguration returns the maximum age for no-stale branches and tags, effectively, as configured
 // for the given repository. If there is no retention configuration for the given repository, the given default
 // values are returned unchanged.
@@ -1876,6 +1976,8 @@ func (s *store) InsertUpload(ctx context.Context, upload types.Upload) (id int,
 			upload.AssociatedIndexID,
 			upload.ContentType,
 			upload.UncompressedSize,
+			upload.Ephemeral,
+			upload.BaseUploadID,
 		),
 	))
 
@@ -1895,8 +1997,10 @@ INSERT INTO lsif_uploads (
 	upload_size,
 	associated_index_id,
 	content_type,
-	uncompressed_size
-) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+	uncompressed_size,
+	ephemeral,
+	base_upload_id
+) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING id
 `
 
@@ -2081,7 +2185,9 @@ func buildGetConditionsAndCte(opts shared.GetUploadsOptions) (*sqlf.Query, []*sq
 				content_type,
 				should_reindex,
 				expired,
-				uncompressed_size
+				uncompressed_size,
+				ephemeral,
+				base_upload_id
 			FROM lsif_uploads
 			UNION ALL
 			SELECT *
@@ -1813,6 +1813,73 @@ func TestGetVisibleUploadsMatchingMonikers(t *testing.T) {
 	})
 }
 
+func TestGetVisibleUploadsReferencingAnyVersion(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	insertUploads(t, db,
+		types.Upload{ID: 1, Commit: makeCommit(1), Root: "sub1/"},
+		types.Upload{ID: 2, Commit: makeCommit(1), Root: "sub2/"},
+		types.Upload{ID: 3, Commit: makeCommit(1), Root: "sub3/"},
+		types.Upload{ID: 4, Commit: makeCommit(1), Root: "sub4/"},
+	)
+
+	insertNearestUploads(t, db, 50, map[string][]commitgraph.UploadMeta{
+		makeCommit(1): {
+			{UploadID: 1, Distance: 0},
+			{UploadID: 2, Distance: 0},
+			{UploadID: 3, Distance: 0},
+			{UploadID: 4, Distance: 0},
+		},
+	})
+
+	insertPackageReferences(t, store, []shared.PackageReference{
+		{Package: shared.Package{DumpID: 1, Scheme: "gomod", Name: "leftpad", Version: "0.2.0"}},
+		{Package: shared.Package{DumpID: 2, Scheme: "gomod", Name: "leftpad", Version: "0.1.0"}},
+		{Package: shared.Package{DumpID: 3, Scheme: "gomod", Name: "leftpad", Version: "0.2.0"}},
+		{Package: shared.Package{DumpID: 4, Scheme: "gomod", Name: "rightpad", Version: "0.1.0"}},
+	})
+
+	moniker := precise.QualifiedMonikerData{
+		MonikerData: precise.MonikerData{
+			Scheme: "gomod",
+		},
+		PackageInformationData: precise.PackageInformationData{
+			Name:    "leftpad",
+			Version: "0.1.0",
+		},
+	}
+
+	references, err := store.GetVisibleUploadsReferencingAnyVersion(context.Background(), 50, makeCommit(1), []precise.QualifiedMonikerData{moniker}, 50)
+	if err != nil {
+		t.Fatalf("unexpected error getting references: %s", err)
+	}
+
+	expected := []shared.PackageReference{
+		{Package: shared.Package{DumpID: 2, Scheme: "gomod", Name: "leftpad", Version: "0.1.0"}},
+		{Package: shared.Package{DumpID: 1, Scheme: "gomod", Name: "leftpad", Version: "0.2.0"}},
+		{Package: shared.Package{DumpID: 3, Scheme: "gomod", Name: "leftpad", Version: "0.2.0"}},
+	}
+	if diff := cmp.Diff(expected, references); diff != "" {
+		t.Errorf("unexpected references (-want +got):\n%s", diff)
+	}
+
+	// The limit applies to each version
+	references, err = store.GetVisibleUploadsReferencingAnyVersion(context.Background(), 50, makeCommit(1), []precise.QualifiedMonikerData{moniker}, 1)
+	if err != nil {
+		t.Fatalf("unexpected error getting references: %s", err)
+	}
+
+	expected = []shared.PackageReference{
+		{Package: shared.Package{DumpID: 2, Scheme: "gomod", Name: "leftpad", Version: "0.1.0"}},
+		{Package: shared.Package{DumpID: 1, Scheme: "gomod", Name: "leftpad", Version: "0.2.0"}},
+	}
+	if diff := cmp.Diff(expected, references); diff != "" {
+		t.Errorf("unexpected references (-want +got):\n%s", diff)
+	}
+}
+
 func TestCommitGraphMetadata(t *testing.T) {
 	logger := logtest.Scoped(t)
 	db := database.NewDB(logger, dbtest.NewDB(logger, t))
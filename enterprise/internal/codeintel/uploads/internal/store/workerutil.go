@@ -46,6 +46,8 @@ var uploadColumnsWithNullRank = []*sqlf.Query{
 	sqlf.Sprintf("u.should_reindex"),
 	sqlf.Sprintf("NULL"),
 	sqlf.Sprintf("u.uncompressed_size"),
+	sqlf.Sprintf("u.ephemeral"),
+	sqlf.Sprintf("u.base_upload_id"),
 }
 
 var UploadWorkerStoreOptions = dbworkerstore.Options[types.Upload]{
@@ -59,8 +61,9 @@ var UploadWorkerStoreOptions = dbworkerstore.Options[types.Upload]{
 		COALESCE(u.process_after, u.uploaded_at),
 		u.id
 	`),
-	StalledMaxAge: StalledUploadMaxAge,
-	MaxNumResets:  UploadMaxNumResets,
+	FairnessKeyExpression: sqlf.Sprintf("u.repository_id"),
+	StalledMaxAge:         StalledUploadMaxAge,
+	MaxNumResets:          UploadMaxNumResets,
 }
 
 func (s *store) WorkerutilStore(observationCtx *observation.Context) dbworkerstore.Store[types.Upload] {
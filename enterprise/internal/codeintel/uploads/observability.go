@@ -32,20 +32,22 @@ type operations struct {
 	getRepositoriesMaxStaleAge              *observation.Operation
 
 	// Uploads
-	getUploads                           *observation.Operation
-	getUploadByID                        *observation.Operation
-	getUploadsByIDs                      *observation.Operation
-	getVisibleUploadsMatchingMonikers    *observation.Operation
-	getUploadDocumentsForPath            *observation.Operation
-	updateUploadsVisibleToCommits        *observation.Operation
-	deleteUploadByID                     *observation.Operation
-	inferClosestUploads                  *observation.Operation
-	deleteUploadsWithoutRepository       *observation.Operation
-	deleteUploadsStuckUploading          *observation.Operation
-	softDeleteExpiredUploads             *observation.Operation
-	softDeleteExpiredUploadsViaTraversal *observation.Operation
-	hardDeleteUploadsByIDs               *observation.Operation
-	deleteLsifDataByUploadIds            *observation.Operation
+	getUploads                             *observation.Operation
+	getUploadByID                          *observation.Operation
+	getUploadsByIDs                        *observation.Operation
+	getVisibleUploadsMatchingMonikers      *observation.Operation
+	getVisibleUploadsReferencingAnyVersion *observation.Operation
+	getUploadDocumentsForPath              *observation.Operation
+	updateUploadsVisibleToCommits          *observation.Operation
+	deleteUploadByID                       *observation.Operation
+	inferClosestUploads                    *observation.Operation
+	deleteUploadsWithoutRepository         *observation.Operation
+	deleteUploadsStuckUploading            *observation.Operation
+	softDeleteExpiredUploads               *observation.Operation
+	softDeleteExpiredUploadsViaTraversal   *observation.Operation
+	hardDeleteUploadsByIDs                 *observation.Operation
+	deleteLsifDataByUploadIds              *observation.Operation
+	exportSCIPIndex                        *observation.Operation
 
 	// Dumps
 	getDumpsWithDefinitionsForMonikers *observation.Operation
@@ -61,6 +63,15 @@ type operations struct {
 	// Tags
 	getListTags *observation.Operation
 
+	// Unreferenced symbols
+	computeUnreferencedSymbolsReports *observation.Operation
+	getUnreferencedSymbols            *observation.Operation
+	getUnreferencedSymbolsSummary     *observation.Operation
+
+	// Coverage
+	computeCodeIntelCoverage *observation.Operation
+	getCodeIntelCoverage     *observation.Operation
+
 	numUploadsRead         prometheus.Counter
 	numBytesUploaded       prometheus.Counter
 	numStaleRecordsDeleted prometheus.Counter
@@ -145,20 +156,22 @@ func newOperations(observationCtx *observation.Context) *operations {
 		getRepositoriesMaxStaleAge:              op("GetRepositoriesMaxStaleAge"),
 
 		// Uploads
-		getUploads:                           op("GetUploads"),
-		getUploadByID:                        op("GetUploadByID"),
-		getUploadsByIDs:                      op("GetUploadsByIDs"),
-		getVisibleUploadsMatchingMonikers:    op("GetVisibleUploadsMatchingMonikers"),
-		getUploadDocumentsForPath:            op("GetUploadDocumentsForPath"),
-		updateUploadsVisibleToCommits:        op("UpdateUploadsVisibleToCommits"),
-		deleteUploadByID:                     op("DeleteUploadByID"),
-		inferClosestUploads:                  op("InferClosestUploads"),
-		deleteUploadsWithoutRepository:       op("DeleteUploadsWithoutRepository"),
-		deleteUploadsStuckUploading:          op("DeleteUploadsStuckUploading"),
-		softDeleteExpiredUploads:             op("SoftDeleteExpiredUploads"),
-		softDeleteExpiredUploadsViaTraversal: op("SoftDeleteExpiredUploadsViaTraversal"),
-		hardDeleteUploadsByIDs:               op("HardDeleteUploadsByIDs"),
-		deleteLsifDataByUploadIds:            op("DeleteLsifDataByUploadIds"),
+		getUploads:                             op("GetUploads"),
+		getUploadByID:                          op("GetUploadByID"),
+		getUploadsByIDs:                        op("GetUploadsByIDs"),
+		getVisibleUploadsMatchingMonikers:      op("GetVisibleUploadsMatchingMonikers"),
+		getVisibleUploadsReferencingAnyVersion: op("GetVisibleUploadsReferencingAnyVersion"),
+		getUploadDocumentsForPath:              op("GetUploadDocumentsForPath"),
+		updateUploadsVisibleToCommits:          op("UpdateUploadsVisibleToCommits"),
+		deleteUploadByID:                       op("DeleteUploadByID"),
+		inferClosestUploads:                    op("InferClosestUploads"),
+		deleteUploadsWithoutRepository:         op("DeleteUploadsWithoutRepository"),
+		deleteUploadsStuckUploading:            op("DeleteUploadsStuckUploading"),
+		softDeleteExpiredUploads:               op("SoftDeleteExpiredUploads"),
+		softDeleteExpiredUploadsViaTraversal:   op("SoftDeleteExpiredUploadsViaTraversal"),
+		hardDeleteUploadsByIDs:                 op("HardDeleteUploadsByIDs"),
+		deleteLsifDataByUploadIds:              op("DeleteLsifDataByUploadIds"),
+		exportSCIPIndex:                        op("ExportSCIPIndex"),
 
 		// Dumps
 		getDumpsWithDefinitionsForMonikers: op("GetDumpsWithDefinitionsForMonikers"),
@@ -174,6 +187,15 @@ func newOperations(observationCtx *observation.Context) *operations {
 		// Tags
 		getListTags: op("GetListTags"),
 
+		// Unreferenced symbols
+		computeUnreferencedSymbolsReports: op("ComputeUnreferencedSymbolsReports"),
+		getUnreferencedSymbols:            op("GetUnreferencedSymbols"),
+		getUnreferencedSymbolsSummary:     op("GetUnreferencedSymbolsSummary"),
+
+		// Coverage
+		computeCodeIntelCoverage: op("ComputeCodeIntelCoverage"),
+		getCodeIntelCoverage:     op("GetCodeIntelCoverage"),
+
 		numUploadsRead:         numUploadsRead,
 		numBytesUploaded:       numBytesUploaded,
 		numStaleRecordsDeleted: numStaleRecordsDeleted,
@@ -155,6 +155,20 @@ func (s *Service) GetUploadIDsWithReferences(ctx context.Context, orderedMoniker
 	return s.store.GetUploadIDsWithReferences(ctx, orderedMonikers, ignoreIDs, repositoryID, commit, limit, offset, trace)
 }
 
+func (s *Service) GetVisibleUploadsReferencingAnyVersion(ctx context.Context, repositoryID int, commit string, monikers []precise.QualifiedMonikerData, limit int) (_ []shared.PackageReference, err error) {
+	ctx, _, endObservation := s.operations.getVisibleUploadsReferencingAnyVersion.With(ctx, &err, observation.Args{
+		LogFields: []log.Field{
+			log.Int("repositoryID", repositoryID),
+			log.String("commit", commit),
+			log.Int("limit", limit),
+			log.String("monikers", fmt.Sprintf("%v", monikers)),
+		},
+	})
+	defer endObservation(1, observation.Args{})
+
+	return s.store.GetVisibleUploadsReferencingAnyVersion(ctx, repositoryID, commit, monikers, limit)
+}
+
 func (s *Service) DeleteUploadByID(ctx context.Context, id int) (_ bool, err error) {
 	ctx, _, endObservation := s.operations.deleteUploadByID.With(ctx, &err, observation.Args{LogFields: []log.Field{log.Int("id", id)}})
 	defer endObservation(1, observation.Args{})
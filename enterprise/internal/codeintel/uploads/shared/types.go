@@ -199,3 +199,72 @@ type UploadLog struct {
 	Reason            *string
 	Operation         string
 }
+
+// UnreferencedSymbol is a symbol defined in a precise index visible at the tip of the default
+// branch of a repository that is not referenced from any other location.
+type UnreferencedSymbol struct {
+	RepositoryID int
+	UploadID     int
+	SymbolName   string
+	DocumentPath string
+	Language     string
+}
+
+type GetUnreferencedSymbolsOptions struct {
+	RepositoryID int
+	Language     string
+	Limit        int
+	Offset       int
+}
+
+// UnreferencedSymbolsSummary describes the most recently computed unreferenced symbols report
+// of a repository.
+type UnreferencedSymbolsSummary struct {
+	ComputedAt time.Time
+	Languages  []UnreferencedSymbolsLanguageCount
+}
+
+type UnreferencedSymbolsLanguageCount struct {
+	Language string
+	Count    int
+}
+
+// CodeIntelCoverage describes the precise code intelligence coverage of the tip of the default
+// branch of a repository for a single language.
+type CodeIntelCoverage struct {
+	RepositoryID   int
+	RepositoryName string
+	Language       string
+	Indexers       []string
+
+	// NumUploads is the number of uploads of the language visible at the tip of the default branch.
+	NumUploads int
+
+	// UploadID is the visible upload closest to the tip of the default branch, and CommitsBehind
+	// is the number of commits between the tip and the commit of that upload (if known).
+	UploadID       *int
+	CommitsBehind  *int
+	LastUploadedAt *time.Time
+
+	LastFailureAt     *time.Time
+	LastFailureReason *string
+
+	ComputedAt time.Time
+}
+
+type GetCodeIntelCoverageOptions struct {
+	// Term is matched against repository names.
+	Term     string
+	Language string
+
+	// Covered, when set, selects languages with (or without) an upload visible at the tip of
+	// the default branch.
+	Covered *bool
+
+	// MaxCommitsBehind, when set, selects languages with a visible upload at most the given
+	// number of commits behind the tip of the default branch.
+	MaxCommitsBehind *int
+
+	Limit  int
+	Offset int
+}
@@ -0,0 +1,71 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+)
+
+type codeIntelCoverageConnectionResolver struct {
+	coverage   []resolverstubs.CodeIntelCoverageResolver
+	totalCount int
+	pageInfo   *PageInfo
+}
+
+func (r *codeIntelCoverageConnectionResolver) Nodes(ctx context.Context) ([]resolverstubs.CodeIntelCoverageResolver, error) {
+	return r.coverage, nil
+}
+
+func (r *codeIntelCoverageConnectionResolver) TotalCount() int32 {
+	return int32(r.totalCount)
+}
+
+func (r *codeIntelCoverageConnectionResolver) PageInfo() resolverstubs.PageInfo {
+	return r.pageInfo
+}
+
+type codeIntelCoverageResolver struct {
+	coverage              shared.CodeIntelCoverage
+	newRepositoryResolver func(ctx context.Context, repositoryID int) (resolverstubs.RepositoryResolver, error)
+	newUploadResolver     func(ctx context.Context, uploadID int) (resolverstubs.LSIFUploadResolver, error)
+}
+
+func (r *codeIntelCoverageResolver) Repository(ctx context.Context) (resolverstubs.RepositoryResolver, error) {
+	return r.newRepositoryResolver(ctx, r.coverage.RepositoryID)
+}
+
+func (r *codeIntelCoverageResolver) Language() *string          { return strPtr(r.coverage.Language) }
+func (r *codeIntelCoverageResolver) Indexers() []string         { return r.coverage.Indexers }
+func (r *codeIntelCoverageResolver) UploadsAtTip() int32        { return int32(r.coverage.NumUploads) }
+func (r *codeIntelCoverageResolver) LastFailureReason() *string { return r.coverage.LastFailureReason }
+
+func (r *codeIntelCoverageResolver) Upload(ctx context.Context) (resolverstubs.LSIFUploadResolver, error) {
+	if r.coverage.UploadID == nil {
+		return nil, nil
+	}
+
+	return r.newUploadResolver(ctx, *r.coverage.UploadID)
+}
+
+func (r *codeIntelCoverageResolver) CommitsBehind() *int32 {
+	if r.coverage.CommitsBehind == nil {
+		return nil
+	}
+
+	commitsBehind := int32(*r.coverage.CommitsBehind)
+	return &commitsBehind
+}
+
+func (r *codeIntelCoverageResolver) LastUploadedAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.coverage.LastUploadedAt)
+}
+
+func (r *codeIntelCoverageResolver) LastFailureAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.coverage.LastFailureAt)
+}
+
+func (r *codeIntelCoverageResolver) ComputedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.coverage.ComputedAt}
+}
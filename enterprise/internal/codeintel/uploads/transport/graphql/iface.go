@@ -21,6 +21,9 @@ type UploadService interface {
 	GetUploadsByIDs(ctx context.Context, ids ...int) (_ []types.Upload, err error)
 	DeleteUploadByID(ctx context.Context, id int) (_ bool, err error)
 	DeleteUploads(ctx context.Context, opts uploadsshared.DeleteUploadsOptions) (err error)
+	GetUnreferencedSymbols(ctx context.Context, opts uploadsshared.GetUnreferencedSymbolsOptions) (_ []uploadsshared.UnreferencedSymbol, _ int, err error)
+	GetUnreferencedSymbolsSummary(ctx context.Context, repositoryID int) (_ uploadsshared.UnreferencedSymbolsSummary, _ bool, err error)
+	GetCodeIntelCoverage(ctx context.Context, opts uploadsshared.GetCodeIntelCoverageOptions) (_ []uploadsshared.CodeIntelCoverage, _ int, err error)
 }
 
 type AutoIndexingService interface {
@@ -886,12 +886,22 @@ type MockUploadService struct {
 	// GetAuditLogsForUploadFunc is an instance of a mock function object
 	// controlling the behavior of the method GetAuditLogsForUpload.
 	GetAuditLogsForUploadFunc *UploadServiceGetAuditLogsForUploadFunc
+	// GetCodeIntelCoverageFunc is an instance of a mock function object
+	// controlling the behavior of the method GetCodeIntelCoverage.
+	GetCodeIntelCoverageFunc *UploadServiceGetCodeIntelCoverageFunc
 	// GetCommitGraphMetadataFunc is an instance of a mock function object
 	// controlling the behavior of the method GetCommitGraphMetadata.
 	GetCommitGraphMetadataFunc *UploadServiceGetCommitGraphMetadataFunc
 	// GetListTagsFunc is an instance of a mock function object controlling
 	// the behavior of the method GetListTags.
 	GetListTagsFunc *UploadServiceGetListTagsFunc
+	// GetUnreferencedSymbolsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetUnreferencedSymbols.
+	GetUnreferencedSymbolsFunc *UploadServiceGetUnreferencedSymbolsFunc
+	// GetUnreferencedSymbolsSummaryFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetUnreferencedSymbolsSummary.
+	GetUnreferencedSymbolsSummaryFunc *UploadServiceGetUnreferencedSymbolsSummaryFunc
 	// GetUploadDocumentsForPathFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// GetUploadDocumentsForPath.
@@ -923,6 +933,11 @@ func NewMockUploadService() *MockUploadService {
 				return
 			},
 		},
+		GetCodeIntelCoverageFunc: &UploadServiceGetCodeIntelCoverageFunc{
+			defaultHook: func(context.Context, shared1.GetCodeIntelCoverageOptions) (r0 []shared1.CodeIntelCoverage, r1 int, r2 error) {
+				return
+			},
+		},
 		GetCommitGraphMetadataFunc: &UploadServiceGetCommitGraphMetadataFunc{
 			defaultHook: func(context.Context, int) (r0 bool, r1 *time.Time, r2 error) {
 				return
@@ -933,6 +948,16 @@ func NewMockUploadService() *MockUploadService {
 				return
 			},
 		},
+		GetUnreferencedSymbolsFunc: &UploadServiceGetUnreferencedSymbolsFunc{
+			defaultHook: func(context.Context, shared1.GetUnreferencedSymbolsOptions) (r0 []shared1.UnreferencedSymbol, r1 int, r2 error) {
+				return
+			},
+		},
+		GetUnreferencedSymbolsSummaryFunc: &UploadServiceGetUnreferencedSymbolsSummaryFunc{
+			defaultHook: func(context.Context, int) (r0 shared1.UnreferencedSymbolsSummary, r1 bool, r2 error) {
+				return
+			},
+		},
 		GetUploadDocumentsForPathFunc: &UploadServiceGetUploadDocumentsForPathFunc{
 			defaultHook: func(context.Context, int, string) (r0 []string, r1 int, r2 error) {
 				return
@@ -970,6 +995,11 @@ func NewStrictMockUploadService() *MockUploadService {
 				panic("unexpected invocation of MockUploadService.GetAuditLogsForUpload")
 			},
 		},
+		GetCodeIntelCoverageFunc: &UploadServiceGetCodeIntelCoverageFunc{
+			defaultHook: func(context.Context, shared1.GetCodeIntelCoverageOptions) ([]shared1.CodeIntelCoverage, int, error) {
+				panic("unexpected invocation of MockUploadService.GetCodeIntelCoverage")
+			},
+		},
 		GetCommitGraphMetadataFunc: &UploadServiceGetCommitGraphMetadataFunc{
 			defaultHook: func(context.Context, int) (bool, *time.Time, error) {
 				panic("unexpected invocation of MockUploadService.GetCommitGraphMetadata")
@@ -980,6 +1010,16 @@ func NewStrictMockUploadService() *MockUploadService {
 				panic("unexpected invocation of MockUploadService.GetListTags")
 			},
 		},
+		GetUnreferencedSymbolsFunc: &UploadServiceGetUnreferencedSymbolsFunc{
+			defaultHook: func(context.Context, shared1.GetUnreferencedSymbolsOptions) ([]shared1.UnreferencedSymbol, int, error) {
+				panic("unexpected invocation of MockUploadService.GetUnreferencedSymbols")
+			},
+		},
+		GetUnreferencedSymbolsSummaryFunc: &UploadServiceGetUnreferencedSymbolsSummaryFunc{
+			defaultHook: func(context.Context, int) (shared1.UnreferencedSymbolsSummary, bool, error) {
+				panic("unexpected invocation of MockUploadService.GetUnreferencedSymbolsSummary")
+			},
+		},
 		GetUploadDocumentsForPathFunc: &UploadServiceGetUploadDocumentsForPathFunc{
 			defaultHook: func(context.Context, int, string) ([]string, int, error) {
 				panic("unexpected invocation of MockUploadService.GetUploadDocumentsForPath")
@@ -1012,12 +1052,21 @@ func NewMockUploadServiceFrom(i UploadService) *MockUploadService {
 		GetAuditLogsForUploadFunc: &UploadServiceGetAuditLogsForUploadFunc{
 			defaultHook: i.GetAuditLogsForUpload,
 		},
+		GetCodeIntelCoverageFunc: &UploadServiceGetCodeIntelCoverageFunc{
+			defaultHook: i.GetCodeIntelCoverage,
+		},
 		GetCommitGraphMetadataFunc: &UploadServiceGetCommitGraphMetadataFunc{
 			defaultHook: i.GetCommitGraphMetadata,
 		},
 		GetListTagsFunc: &UploadServiceGetListTagsFunc{
 			defaultHook: i.GetListTags,
 		},
+		GetUnreferencedSymbolsFunc: &UploadServiceGetUnreferencedSymbolsFunc{
+			defaultHook: i.GetUnreferencedSymbols,
+		},
+		GetUnreferencedSymbolsSummaryFunc: &UploadServiceGetUnreferencedSymbolsSummaryFunc{
+			defaultHook: i.GetUnreferencedSymbolsSummary,
+		},
 		GetUploadDocumentsForPathFunc: &UploadServiceGetUploadDocumentsForPathFunc{
 			defaultHook: i.GetUploadDocumentsForPath,
 		},
@@ -1356,6 +1405,120 @@ func (c UploadServiceGetAuditLogsForUploadFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// UploadServiceGetCodeIntelCoverageFunc describes the behavior when the
+// GetCodeIntelCoverage method of the parent MockUploadService instance is
+// invoked.
+type UploadServiceGetCodeIntelCoverageFunc struct {
+	defaultHook func(context.Context, shared1.GetCodeIntelCoverageOptions) ([]shared1.CodeIntelCoverage, int, error)
+	hooks       []func(context.Context, shared1.GetCodeIntelCoverageOptions) ([]shared1.CodeIntelCoverage, int, error)
+	history     []UploadServiceGetCodeIntelCoverageFuncCall
+	mutex       sync.Mutex
+}
+
+// GetCodeIntelCoverage delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockUploadService) GetCodeIntelCoverage(v0 context.Context, v1 shared1.GetCodeIntelCoverageOptions) ([]shared1.CodeIntelCoverage, int, error) {
+	r0, r1, r2 := m.GetCodeIntelCoverageFunc.nextHook()(v0, v1)
+	m.GetCodeIntelCoverageFunc.appendCall(UploadServiceGetCodeIntelCoverageFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the GetCodeIntelCoverage
+// method of the parent MockUploadService instance is invoked and the hook
+// queue is empty.
+func (f *UploadServiceGetCodeIntelCoverageFunc) SetDefaultHook(hook func(context.Context, shared1.GetCodeIntelCoverageOptions) ([]shared1.CodeIntelCoverage, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetCodeIntelCoverage method of the parent MockUploadService instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *UploadServiceGetCodeIntelCoverageFunc) PushHook(hook func(context.Context, shared1.GetCodeIntelCoverageOptions) ([]shared1.CodeIntelCoverage, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UploadServiceGetCodeIntelCoverageFunc) SetDefaultReturn(r0 []shared1.CodeIntelCoverage, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, shared1.GetCodeIntelCoverageOptions) ([]shared1.CodeIntelCoverage, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UploadServiceGetCodeIntelCoverageFunc) PushReturn(r0 []shared1.CodeIntelCoverage, r1 int, r2 error) {
+	f.PushHook(func(context.Context, shared1.GetCodeIntelCoverageOptions) ([]shared1.CodeIntelCoverage, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *UploadServiceGetCodeIntelCoverageFunc) nextHook() func(context.Context, shared1.GetCodeIntelCoverageOptions) ([]shared1.CodeIntelCoverage, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UploadServiceGetCodeIntelCoverageFunc) appendCall(r0 UploadServiceGetCodeIntelCoverageFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UploadServiceGetCodeIntelCoverageFuncCall
+// objects describing the invocations of this function.
+func (f *UploadServiceGetCodeIntelCoverageFunc) History() []UploadServiceGetCodeIntelCoverageFuncCall {
+	f.mutex.Lock()
+	history := make([]UploadServiceGetCodeIntelCoverageFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UploadServiceGetCodeIntelCoverageFuncCall is an object that describes an
+// invocation of method GetCodeIntelCoverage on an instance of
+// MockUploadService.
+type UploadServiceGetCodeIntelCoverageFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 shared1.GetCodeIntelCoverageOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared1.CodeIntelCoverage
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UploadServiceGetCodeIntelCoverageFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UploadServiceGetCodeIntelCoverageFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // UploadServiceGetCommitGraphMetadataFunc describes the behavior when the
 // GetCommitGraphMetadata method of the parent MockUploadService instance is
 // invoked.
@@ -1588,6 +1751,235 @@ func (c UploadServiceGetListTagsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// UploadServiceGetUnreferencedSymbolsFunc describes the behavior when the
+// GetUnreferencedSymbols method of the parent MockUploadService instance is
+// invoked.
+type UploadServiceGetUnreferencedSymbolsFunc struct {
+	defaultHook func(context.Context, shared1.GetUnreferencedSymbolsOptions) ([]shared1.UnreferencedSymbol, int, error)
+	hooks       []func(context.Context, shared1.GetUnreferencedSymbolsOptions) ([]shared1.UnreferencedSymbol, int, error)
+	history     []UploadServiceGetUnreferencedSymbolsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUnreferencedSymbols delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockUploadService) GetUnreferencedSymbols(v0 context.Context, v1 shared1.GetUnreferencedSymbolsOptions) ([]shared1.UnreferencedSymbol, int, error) {
+	r0, r1, r2 := m.GetUnreferencedSymbolsFunc.nextHook()(v0, v1)
+	m.GetUnreferencedSymbolsFunc.appendCall(UploadServiceGetUnreferencedSymbolsFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// GetUnreferencedSymbols method of the parent MockUploadService instance is
+// invoked and the hook queue is empty.
+func (f *UploadServiceGetUnreferencedSymbolsFunc) SetDefaultHook(hook func(context.Context, shared1.GetUnreferencedSymbolsOptions) ([]shared1.UnreferencedSymbol, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUnreferencedSymbols method of the parent MockUploadService instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *UploadServiceGetUnreferencedSymbolsFunc) PushHook(hook func(context.Context, shared1.GetUnreferencedSymbolsOptions) ([]shared1.UnreferencedSymbol, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UploadServiceGetUnreferencedSymbolsFunc) SetDefaultReturn(r0 []shared1.UnreferencedSymbol, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, shared1.GetUnreferencedSymbolsOptions) ([]shared1.UnreferencedSymbol, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UploadServiceGetUnreferencedSymbolsFunc) PushReturn(r0 []shared1.UnreferencedSymbol, r1 int, r2 error) {
+	f.PushHook(func(context.Context, shared1.GetUnreferencedSymbolsOptions) ([]shared1.UnreferencedSymbol, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *UploadServiceGetUnreferencedSymbolsFunc) nextHook() func(context.Context, shared1.GetUnreferencedSymbolsOptions) ([]shared1.UnreferencedSymbol, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UploadServiceGetUnreferencedSymbolsFunc) appendCall(r0 UploadServiceGetUnreferencedSymbolsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UploadServiceGetUnreferencedSymbolsFuncCall
+// objects describing the invocations of this function.
+func (f *UploadServiceGetUnreferencedSymbolsFunc) History() []UploadServiceGetUnreferencedSymbolsFuncCall {
+	f.mutex.Lock()
+	history := make([]UploadServiceGetUnreferencedSymbolsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UploadServiceGetUnreferencedSymbolsFuncCall is an object that describes
+// an invocation of method GetUnreferencedSymbols on an instance of
+// MockUploadService.
+type UploadServiceGetUnreferencedSymbolsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 shared1.GetUnreferencedSymbolsOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared1.UnreferencedSymbol
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UploadServiceGetUnreferencedSymbolsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UploadServiceGetUnreferencedSymbolsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// UploadServiceGetUnreferencedSymbolsSummaryFunc describes the behavior
+// when the GetUnreferencedSymbolsSummary method of the parent
+// MockUploadService instance is invoked.
+type UploadServiceGetUnreferencedSymbolsSummaryFunc struct {
+	defaultHook func(context.Context, int) (shared1.UnreferencedSymbolsSummary, bool, error)
+	hooks       []func(context.Context, int) (shared1.UnreferencedSymbolsSummary, bool, error)
+	history     []UploadServiceGetUnreferencedSymbolsSummaryFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUnreferencedSymbolsSummary delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockUploadService) GetUnreferencedSymbolsSummary(v0 context.Context, v1 int) (shared1.UnreferencedSymbolsSummary, bool, error) {
+	r0, r1, r2 := m.GetUnreferencedSymbolsSummaryFunc.nextHook()(v0, v1)
+	m.GetUnreferencedSymbolsSummaryFunc.appendCall(UploadServiceGetUnreferencedSymbolsSummaryFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// GetUnreferencedSymbolsSummary method of the parent MockUploadService
+// instance is invoked and the hook queue is empty.
+func (f *UploadServiceGetUnreferencedSymbolsSummaryFunc) SetDefaultHook(hook func(context.Context, int) (shared1.UnreferencedSymbolsSummary, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUnreferencedSymbolsSummary method of the parent MockUploadService
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *UploadServiceGetUnreferencedSymbolsSummaryFunc) PushHook(hook func(context.Context, int) (shared1.UnreferencedSymbolsSummary, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UploadServiceGetUnreferencedSymbolsSummaryFunc) SetDefaultReturn(r0 shared1.UnreferencedSymbolsSummary, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) (shared1.UnreferencedSymbolsSummary, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UploadServiceGetUnreferencedSymbolsSummaryFunc) PushReturn(r0 shared1.UnreferencedSymbolsSummary, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) (shared1.UnreferencedSymbolsSummary, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *UploadServiceGetUnreferencedSymbolsSummaryFunc) nextHook() func(context.Context, int) (shared1.UnreferencedSymbolsSummary, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UploadServiceGetUnreferencedSymbolsSummaryFunc) appendCall(r0 UploadServiceGetUnreferencedSymbolsSummaryFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// UploadServiceGetUnreferencedSymbolsSummaryFuncCall objects describing the
+// invocations of this function.
+func (f *UploadServiceGetUnreferencedSymbolsSummaryFunc) History() []UploadServiceGetUnreferencedSymbolsSummaryFuncCall {
+	f.mutex.Lock()
+	history := make([]UploadServiceGetUnreferencedSymbolsSummaryFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UploadServiceGetUnreferencedSymbolsSummaryFuncCall is an object that
+// describes an invocation of method GetUnreferencedSymbolsSummary on an
+// instance of MockUploadService.
+type UploadServiceGetUnreferencedSymbolsSummaryFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 shared1.UnreferencedSymbolsSummary
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UploadServiceGetUnreferencedSymbolsSummaryFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UploadServiceGetUnreferencedSymbolsSummaryFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // UploadServiceGetUploadDocumentsForPathFunc describes the behavior when
 // the GetUploadDocumentsForPath method of the parent MockUploadService
 // instance is invoked.
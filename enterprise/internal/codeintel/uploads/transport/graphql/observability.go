@@ -16,6 +16,12 @@ type operations struct {
 
 	// Commit Graph
 	commitGraph *observation.Operation
+
+	// Unreferenced symbols
+	unreferencedSymbols *observation.Operation
+
+	// Coverage
+	codeIntelCoverage *observation.Operation
 }
 
 func newOperations(observationCtx *observation.Context) *operations {
@@ -43,5 +49,11 @@ func newOperations(observationCtx *observation.Context) *operations {
 
 		// Commit Graph
 		commitGraph: op("CommitGraph"),
+
+		// Unreferenced symbols
+		unreferencedSymbols: op("UnreferencedSymbols"),
+
+		// Coverage
+		codeIntelCoverage: op("CodeIntelCoverage"),
 	}
 }
@@ -2,15 +2,19 @@ package graphql
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/opentracing/opentracing-go/log"
 
 	sharedresolvers "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/resolvers"
+	uploadsshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/auth"
 	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 type rootResolver struct {
@@ -144,3 +148,175 @@ func (r *rootResolver) DeleteLSIFUploads(ctx context.Context, args *resolverstub
 
 	return &resolverstubs.EmptyResponse{}, nil
 }
+
+const (
+	DefaultUnreferencedSymbolsPageSize = 100
+	MaxUnreferencedSymbolsPageSize     = 1000
+)
+
+// 🚨 SECURITY: Only entrypoint is within the repository resolver so the user is already authenticated
+func (r *rootResolver) UnreferencedSymbols(ctx context.Context, repositoryID graphql.ID, args *resolverstubs.CodeIntelUnreferencedSymbolsArgs) (_ resolverstubs.CodeIntelUnreferencedSymbolConnectionResolver, err error) {
+	ctx, traceErrs, endObservation := r.operations.unreferencedSymbols.WithErrors(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("repoID", string(repositoryID)),
+	}})
+	endObservation.OnCancel(ctx, 1, observation.Args{})
+
+	id, err := resolveRepositoryID(repositoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := derefInt32(args.First, DefaultUnreferencedSymbolsPageSize)
+	if limit < 0 {
+		return nil, errors.Newf("expected non-negative 'first', got %d", limit)
+	}
+	if limit > MaxUnreferencedSymbolsPageSize {
+		limit = MaxUnreferencedSymbolsPageSize
+	}
+
+	offset, err := decodeIntCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+	if offset < 0 {
+		return nil, errors.Newf("expected non-negative offset, got %d", offset)
+	}
+
+	symbols, totalCount, err := r.uploadSvc.GetUnreferencedSymbols(ctx, uploadsshared.GetUnreferencedSymbolsOptions{
+		RepositoryID: id,
+		Language:     derefString(args.Language, ""),
+		Limit:        limit,
+		Offset:       offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	summary, _, err := r.uploadSvc.GetUnreferencedSymbolsSummary(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a new prefetcher here as we only want to cache upload and index records in
+	// the same graphQL request, not across different request.
+	prefetcher := sharedresolvers.NewPrefetcher(r.autoindexSvc, r.uploadSvc)
+	locationResolver := sharedresolvers.NewCachedLocationResolver(r.autoindexSvc.GetUnsafeDB(), gitserver.NewClient())
+
+	symbolResolvers := make([]resolverstubs.CodeIntelUnreferencedSymbolResolver, 0, len(symbols))
+	for _, symbol := range symbols {
+		prefetcher.MarkUpload(symbol.UploadID)
+
+		symbolResolvers = append(symbolResolvers, &unreferencedSymbolResolver{
+			symbol: symbol,
+			newUploadResolver: func(ctx context.Context, uploadID int) (resolverstubs.LSIFUploadResolver, error) {
+				upload, exists, err := prefetcher.GetUploadByID(ctx, uploadID)
+				if err != nil || !exists {
+					return nil, err
+				}
+
+				return sharedresolvers.NewUploadResolver(r.uploadSvc, r.autoindexSvc, r.policySvc, upload, prefetcher, locationResolver, traceErrs), nil
+			},
+		})
+	}
+
+	var endCursor *string
+	if nextOffset := offset + len(symbols); len(symbols) > 0 && nextOffset < totalCount {
+		cursor := strconv.Itoa(nextOffset)
+		endCursor = &cursor
+	}
+
+	return &unreferencedSymbolConnectionResolver{
+		symbols:    symbolResolvers,
+		totalCount: totalCount,
+		pageInfo:   EncodeCursor(endCursor),
+		summary:    summary,
+	}, nil
+}
+
+const (
+	DefaultCodeIntelCoveragePageSize = 50
+	MaxCodeIntelCoveragePageSize     = 500
+)
+
+// 🚨 SECURITY: Only site admins may view the coverage of all repositories
+func (r *rootResolver) CodeIntelCoverage(ctx context.Context, args *resolverstubs.CodeIntelCoverageArgs) (_ resolverstubs.CodeIntelCoverageConnectionResolver, err error) {
+	ctx, traceErrs, endObservation := r.operations.codeIntelCoverage.WithErrors(ctx, &err, observation.Args{})
+	endObservation.OnCancel(ctx, 1, observation.Args{})
+
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.autoindexSvc.GetUnsafeDB()); err != nil {
+		return nil, err
+	}
+
+	limit := derefInt32(args.First, DefaultCodeIntelCoveragePageSize)
+	if limit < 0 {
+		return nil, errors.Newf("expected non-negative 'first', got %d", limit)
+	}
+	if limit > MaxCodeIntelCoveragePageSize {
+		limit = MaxCodeIntelCoveragePageSize
+	}
+
+	offset, err := decodeIntCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+	if offset < 0 {
+		return nil, errors.Newf("expected non-negative offset, got %d", offset)
+	}
+
+	var maxCommitsBehind *int
+	if args.MaxCommitsBehind != nil {
+		value := int(*args.MaxCommitsBehind)
+		maxCommitsBehind = &value
+	}
+
+	coverage, totalCount, err := r.uploadSvc.GetCodeIntelCoverage(ctx, uploadsshared.GetCodeIntelCoverageOptions{
+		Term:             derefString(args.Query, ""),
+		Language:         derefString(args.Language, ""),
+		Covered:          args.Covered,
+		MaxCommitsBehind: maxCommitsBehind,
+		Limit:            limit,
+		Offset:           offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a new prefetcher here as we only want to cache upload and index records in
+	// the same graphQL request, not across different request.
+	prefetcher := sharedresolvers.NewPrefetcher(r.autoindexSvc, r.uploadSvc)
+	locationResolver := sharedresolvers.NewCachedLocationResolver(r.autoindexSvc.GetUnsafeDB(), gitserver.NewClient())
+
+	coverageResolvers := make([]resolverstubs.CodeIntelCoverageResolver, 0, len(coverage))
+	for _, c := range coverage {
+		if c.UploadID != nil {
+			prefetcher.MarkUpload(*c.UploadID)
+		}
+
+		coverageResolvers = append(coverageResolvers, &codeIntelCoverageResolver{
+			coverage: c,
+			newRepositoryResolver: func(ctx context.Context, repositoryID int) (resolverstubs.RepositoryResolver, error) {
+				return locationResolver.Repository(ctx, api.RepoID(repositoryID))
+			},
+			newUploadResolver: func(ctx context.Context, uploadID int) (resolverstubs.LSIFUploadResolver, error) {
+				upload, exists, err := prefetcher.GetUploadByID(ctx, uploadID)
+				if err != nil || !exists {
+					return nil, err
+				}
+
+				return sharedresolvers.NewUploadResolver(r.uploadSvc, r.autoindexSvc, r.policySvc, upload, prefetcher, locationResolver, traceErrs), nil
+			},
+		})
+	}
+
+	var endCursor *string
+	if nextOffset := offset + len(coverage); len(coverage) > 0 && nextOffset < totalCount {
+		cursor := strconv.Itoa(nextOffset)
+		endCursor = &cursor
+	}
+
+	return &codeIntelCoverageConnectionResolver{
+		coverage:   coverageResolvers,
+		totalCount: totalCount,
+		pageInfo:   EncodeCursor(endCursor),
+	}, nil
+}
@@ -4,11 +4,16 @@ import (
 	"context"
 	"encoding/base64"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/graph-gophers/graphql-go"
 	"github.com/sourcegraph/log/logtest"
 
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	uploadsshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
 	"github.com/sourcegraph/sourcegraph/internal/auth"
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -59,3 +64,246 @@ func TestDeleteLSIFUploadUnauthenticated(t *testing.T) {
 		t.Errorf("unexpected error. want=%q have=%q", auth.ErrNotAuthenticated, err)
 	}
 }
+
+func TestUnreferencedSymbols(t *testing.T) {
+	mockUploadService := NewMockUploadService()
+	mockPolicyService := NewMockPolicyService()
+	mockAutoIndexingService := NewMockAutoIndexingService()
+
+	computedAt := time.Unix(1587396557, 0).UTC()
+	mockUploadService.GetUnreferencedSymbolsFunc.SetDefaultReturn([]uploadsshared.UnreferencedSymbol{
+		{RepositoryID: 50, UploadID: 42, SymbolName: "sym-a", DocumentPath: "a.go", Language: "Go"},
+		{RepositoryID: 50, UploadID: 42, SymbolName: "sym-b", DocumentPath: "b.go", Language: "Go"},
+	}, 5, nil)
+	mockUploadService.GetUnreferencedSymbolsSummaryFunc.SetDefaultReturn(uploadsshared.UnreferencedSymbolsSummary{
+		ComputedAt: computedAt,
+		Languages:  []uploadsshared.UnreferencedSymbolsLanguageCount{{Language: "Go", Count: 5}},
+	}, true, nil)
+
+	rootResolver := NewRootResolver(&observation.TestContext, mockUploadService, mockAutoIndexingService, mockPolicyService)
+
+	language := "Go"
+	first := int32(3)
+	after := base64.StdEncoding.EncodeToString([]byte("2"))
+	resolver, err := rootResolver.UnreferencedSymbols(context.Background(), graphql.ID(base64.StdEncoding.EncodeToString([]byte("Repository:50"))), &resolverstubs.CodeIntelUnreferencedSymbolsArgs{
+		ConnectionArgs: graphqlutil.ConnectionArgs{First: &first},
+		Language:       &language,
+		After:          &after,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if history := mockUploadService.GetUnreferencedSymbolsFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(history))
+	} else {
+		expectedOpts := uploadsshared.GetUnreferencedSymbolsOptions{RepositoryID: 50, Language: "Go", Limit: 3, Offset: 2}
+		if diff := cmp.Diff(expectedOpts, history[0].Arg1); diff != "" {
+			t.Errorf("unexpected options (-want +got):\n%s", diff)
+		}
+	}
+
+	nodes, err := resolver.Nodes(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var symbols []string
+	for _, node := range nodes {
+		symbols = append(symbols, node.Symbol())
+	}
+	if diff := cmp.Diff([]string{"sym-a", "sym-b"}, symbols); diff != "" {
+		t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+	}
+
+	if totalCount := resolver.TotalCount(); totalCount != 5 {
+		t.Errorf("unexpected total count. want=%d have=%d", 5, totalCount)
+	}
+	if endCursor := resolver.PageInfo().EndCursor(); endCursor == nil || *endCursor != base64.StdEncoding.EncodeToString([]byte("4")) {
+		t.Errorf("unexpected end cursor. want=%q have=%v", "4", endCursor)
+	}
+	if languages := resolver.Languages(); len(languages) != 1 || languages[0].Language() != "Go" || languages[0].Count() != 5 {
+		t.Errorf("unexpected languages")
+	}
+	if value := resolver.ComputedAt(); value == nil || !value.Time.Equal(computedAt) {
+		t.Errorf("unexpected computed at. want=%s have=%v", computedAt, value)
+	}
+}
+
+func TestCodeIntelCoverage(t *testing.T) {
+	users := database.NewStrictMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{SiteAdmin: true}, nil)
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+
+	mockUploadService := NewMockUploadService()
+	mockPolicyService := NewMockPolicyService()
+	mockAutoIndexingService := NewMockAutoIndexingService()
+	mockAutoIndexingService.GetUnsafeDBFunc.SetDefaultReturn(db)
+
+	computedAt := time.Unix(1587396557, 0).UTC()
+	commitsBehind := 3
+	mockUploadService.GetCodeIntelCoverageFunc.SetDefaultReturn([]uploadsshared.CodeIntelCoverage{
+		{RepositoryID: 50, Language: "Go", Indexers: []string{"lsif-go"}, NumUploads: 2, CommitsBehind: &commitsBehind, ComputedAt: computedAt},
+		{RepositoryID: 51, Language: "Java", Indexers: []string{"sourcegraph/scip-java"}, ComputedAt: computedAt},
+		{RepositoryID: 52, Indexers: []string{}, ComputedAt: computedAt},
+	}, 5, nil)
+
+	rootResolver := NewRootResolver(&observation.TestContext, mockUploadService, mockAutoIndexingService, mockPolicyService)
+
+	query := "foo"
+	covered := true
+	maxCommitsBehind := int32(10)
+	first := int32(2)
+	after := base64.StdEncoding.EncodeToString([]byte("2"))
+	resolver, err := rootResolver.CodeIntelCoverage(context.Background(), &resolverstubs.CodeIntelCoverageArgs{
+		ConnectionArgs:   graphqlutil.ConnectionArgs{First: &first},
+		Query:            &query,
+		Covered:          &covered,
+		MaxCommitsBehind: &maxCommitsBehind,
+		After:            &after,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if history := mockUploadService.GetCodeIntelCoverageFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(history))
+	} else {
+		expectedMaxCommitsBehind := 10
+		expectedOpts := uploadsshared.GetCodeIntelCoverageOptions{Term: "foo", Covered: &covered, MaxCommitsBehind: &expectedMaxCommitsBehind, Limit: 2, Offset: 2}
+		if diff := cmp.Diff(expectedOpts, history[0].Arg1); diff != "" {
+			t.Errorf("unexpected options (-want +got):\n%s", diff)
+		}
+	}
+
+	nodes, err := resolver.Nodes(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var languages []*string
+	for _, node := range nodes {
+		languages = append(languages, node.Language())
+	}
+	if diff := cmp.Diff([]*string{strPtr("Go"), strPtr("Java"), nil}, languages); diff != "" {
+		t.Errorf("unexpected languages (-want +got):\n%s", diff)
+	}
+	if value := nodes[0].CommitsBehind(); value == nil || *value != 3 {
+		t.Errorf("unexpected commits behind. want=%d have=%v", 3, value)
+	}
+	if value := nodes[1].CommitsBehind(); value != nil {
+		t.Errorf("unexpected commits behind. want=nil have=%d", *value)
+	}
+	if upload, err := nodes[1].Upload(context.Background()); err != nil || upload != nil {
+		t.Errorf("unexpected upload. want=nil have=%v (err=%v)", upload, err)
+	}
+
+	if totalCount := resolver.TotalCount(); totalCount != 5 {
+		t.Errorf("unexpected total count. want=%d have=%d", 5, totalCount)
+	}
+	if endCursor := resolver.PageInfo().EndCursor(); resolver.PageInfo().HasNextPage() || endCursor != nil {
+		t.Errorf("unexpected end cursor. want=nil have=%v", endCursor)
+	}
+}
+
+func TestCodeIntelCoveragePageSize(t *testing.T) {
+	users := database.NewStrictMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{SiteAdmin: true}, nil)
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+
+	mockUploadService := NewMockUploadService()
+	mockPolicyService := NewMockPolicyService()
+	mockAutoIndexingService := NewMockAutoIndexingService()
+	mockAutoIndexingService.GetUnsafeDBFunc.SetDefaultReturn(db)
+
+	rootResolver := NewRootResolver(&observation.TestContext, mockUploadService, mockAutoIndexingService, mockPolicyService)
+
+	first := int32(MaxCodeIntelCoveragePageSize + 1)
+	if _, err := rootResolver.CodeIntelCoverage(context.Background(), &resolverstubs.CodeIntelCoverageArgs{
+		ConnectionArgs: graphqlutil.ConnectionArgs{First: &first},
+	}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if history := mockUploadService.GetCodeIntelCoverageFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(history))
+	} else if history[0].Arg1.Limit != MaxCodeIntelCoveragePageSize {
+		t.Errorf("unexpected limit. want=%d have=%d", MaxCodeIntelCoveragePageSize, history[0].Arg1.Limit)
+	}
+
+	first = -1
+	if _, err := rootResolver.CodeIntelCoverage(context.Background(), &resolverstubs.CodeIntelCoverageArgs{
+		ConnectionArgs: graphqlutil.ConnectionArgs{First: &first},
+	}); err == nil {
+		t.Errorf("expected an error for a negative page size")
+	}
+
+	after := base64.StdEncoding.EncodeToString([]byte("-1"))
+	if _, err := rootResolver.CodeIntelCoverage(context.Background(), &resolverstubs.CodeIntelCoverageArgs{
+		After: &after,
+	}); err == nil {
+		t.Errorf("expected an error for a negative offset")
+	}
+	if history := mockUploadService.GetCodeIntelCoverageFunc.History(); len(history) != 1 {
+		t.Errorf("unexpected call count. want=%d have=%d", 1, len(history))
+	}
+}
+
+func TestUnreferencedSymbolsPageSize(t *testing.T) {
+	mockUploadService := NewMockUploadService()
+	mockPolicyService := NewMockPolicyService()
+	mockAutoIndexingService := NewMockAutoIndexingService()
+	mockAutoIndexingService.GetUnsafeDBFunc.SetDefaultReturn(database.NewMockDB())
+
+	rootResolver := NewRootResolver(&observation.TestContext, mockUploadService, mockAutoIndexingService, mockPolicyService)
+	repositoryID := graphql.ID(base64.StdEncoding.EncodeToString([]byte("Repository:42")))
+
+	first := int32(MaxUnreferencedSymbolsPageSize + 1)
+	if _, err := rootResolver.UnreferencedSymbols(context.Background(), repositoryID, &resolverstubs.CodeIntelUnreferencedSymbolsArgs{
+		ConnectionArgs: graphqlutil.ConnectionArgs{First: &first},
+	}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if history := mockUploadService.GetUnreferencedSymbolsFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(history))
+	} else if history[0].Arg1.Limit != MaxUnreferencedSymbolsPageSize {
+		t.Errorf("unexpected limit. want=%d have=%d", MaxUnreferencedSymbolsPageSize, history[0].Arg1.Limit)
+	}
+
+	first = -1
+	if _, err := rootResolver.UnreferencedSymbols(context.Background(), repositoryID, &resolverstubs.CodeIntelUnreferencedSymbolsArgs{
+		ConnectionArgs: graphqlutil.ConnectionArgs{First: &first},
+	}); err == nil {
+		t.Errorf("expected an error for a negative page size")
+	}
+
+	after := base64.StdEncoding.EncodeToString([]byte("-1"))
+	if _, err := rootResolver.UnreferencedSymbols(context.Background(), repositoryID, &resolverstubs.CodeIntelUnreferencedSymbolsArgs{
+		After: &after,
+	}); err == nil {
+		t.Errorf("expected an error for a negative offset")
+	}
+	if history := mockUploadService.GetUnreferencedSymbolsFunc.History(); len(history) != 1 {
+		t.Errorf("unexpected call count. want=%d have=%d", 1, len(history))
+	}
+}
+
+func TestCodeIntelCoverageUnauthenticated(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, nil)
+
+	mockUploadService := NewMockUploadService()
+	mockPolicyService := NewMockPolicyService()
+	mockAutoIndexingService := NewMockAutoIndexingService()
+	mockAutoIndexingService.GetUnsafeDBFunc.SetDefaultReturn(db)
+
+	rootResolver := NewRootResolver(&observation.TestContext, mockUploadService, mockAutoIndexingService, mockPolicyService)
+
+	if _, err := rootResolver.CodeIntelCoverage(context.Background(), &resolverstubs.CodeIntelCoverageArgs{}); err != auth.ErrNotAuthenticated {
+		t.Errorf("unexpected error. want=%q have=%q", auth.ErrNotAuthenticated, err)
+	}
+	if len(mockUploadService.GetCodeIntelCoverageFunc.History()) != 0 {
+		t.Errorf("unexpected call to GetCodeIntelCoverage")
+	}
+}
@@ -0,0 +1,65 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+)
+
+type unreferencedSymbolConnectionResolver struct {
+	symbols    []resolverstubs.CodeIntelUnreferencedSymbolResolver
+	totalCount int
+	pageInfo   *PageInfo
+	summary    shared.UnreferencedSymbolsSummary
+}
+
+func (r *unreferencedSymbolConnectionResolver) Nodes(ctx context.Context) ([]resolverstubs.CodeIntelUnreferencedSymbolResolver, error) {
+	return r.symbols, nil
+}
+
+func (r *unreferencedSymbolConnectionResolver) TotalCount() int32 {
+	return int32(r.totalCount)
+}
+
+func (r *unreferencedSymbolConnectionResolver) PageInfo() resolverstubs.PageInfo {
+	return r.pageInfo
+}
+
+func (r *unreferencedSymbolConnectionResolver) Languages() []resolverstubs.CodeIntelUnreferencedSymbolLanguageCountResolver {
+	resolvers := make([]resolverstubs.CodeIntelUnreferencedSymbolLanguageCountResolver, 0, len(r.summary.Languages))
+	for _, languageCount := range r.summary.Languages {
+		resolvers = append(resolvers, &unreferencedSymbolLanguageCountResolver{languageCount: languageCount})
+	}
+
+	return resolvers
+}
+
+func (r *unreferencedSymbolConnectionResolver) ComputedAt() *gqlutil.DateTime {
+	if r.summary.ComputedAt.IsZero() {
+		return nil
+	}
+
+	return gqlutil.DateTimeOrNil(&r.summary.ComputedAt)
+}
+
+type unreferencedSymbolResolver struct {
+	symbol            shared.UnreferencedSymbol
+	newUploadResolver func(ctx context.Context, uploadID int) (resolverstubs.LSIFUploadResolver, error)
+}
+
+func (r *unreferencedSymbolResolver) Symbol() string   { return r.symbol.SymbolName }
+func (r *unreferencedSymbolResolver) Path() string     { return r.symbol.DocumentPath }
+func (r *unreferencedSymbolResolver) Language() string { return r.symbol.Language }
+
+func (r *unreferencedSymbolResolver) Upload(ctx context.Context) (resolverstubs.LSIFUploadResolver, error) {
+	return r.newUploadResolver(ctx, r.symbol.UploadID)
+}
+
+type unreferencedSymbolLanguageCountResolver struct {
+	languageCount shared.UnreferencedSymbolsLanguageCount
+}
+
+func (r *unreferencedSymbolLanguageCountResolver) Language() string { return r.languageCount.Language }
+func (r *unreferencedSymbolLanguageCountResolver) Count() int32     { return int32(r.languageCount.Count) }
@@ -0,0 +1,156 @@
+package http
+
+import (
+	"encoding/csv"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	uploadsshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// coverageExportPageSize is the number of coverage entries read from the database at once
+// while writing a coverage export.
+const coverageExportPageSize = 1000
+
+var coverageExportHeader = []string{
+	"repository",
+	"language",
+	"indexers",
+	"uploads_at_tip",
+	"upload_id",
+	"commits_behind",
+	"last_uploaded_at",
+	"last_failure_at",
+	"last_failure_reason",
+	"computed_at",
+}
+
+// 🚨 SECURITY: Only site admins may export the coverage of all repositories
+func newCoverageExportHandler(svc CoverageService, db database.DB, logger log.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := auth.CheckCurrentUserIsSiteAdmin(r.Context(), db); err != nil {
+			if errors.Is(err, auth.ErrNotAuthenticated) {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+			} else if errors.Is(err, auth.ErrMustBeSiteAdmin) {
+				http.Error(w, err.Error(), http.StatusForbidden)
+			} else {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+			return
+		}
+
+		opts, err := makeCoverageExportOptions(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Read the first page before writing any data so that errors can still be reported
+		// with an appropriate status code
+		coverage, totalCount, err := svc.GetCodeIntelCoverage(r.Context(), opts)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", "attachment; filename=\"codeintel-coverage.csv\"")
+
+		writer := csv.NewWriter(w)
+		if err := writer.Write(coverageExportHeader); err != nil {
+			return
+		}
+
+		for {
+			for _, c := range coverage {
+				if err := writer.Write(coverageExportRow(c)); err != nil {
+					return
+				}
+			}
+
+			opts.Offset += len(coverage)
+			if len(coverage) == 0 || opts.Offset >= totalCount {
+				break
+			}
+
+			if coverage, totalCount, err = svc.GetCodeIntelCoverage(r.Context(), opts); err != nil {
+				// We can't change the status code of a response that has already started,
+				// so the client will notice the truncated export instead.
+				logger.Error("failed to export code intel coverage", log.Error(err))
+				return
+			}
+		}
+
+		writer.Flush()
+	})
+}
+
+// makeCoverageExportOptions returns the filters for a coverage export from the query string of
+// the given request. The supported filters mirror the arguments of the codeIntelCoverage field
+// of the GraphQL API.
+func makeCoverageExportOptions(r *http.Request) (uploadsshared.GetCodeIntelCoverageOptions, error) {
+	opts := uploadsshared.GetCodeIntelCoverageOptions{
+		Term:     getQuery(r, "query"),
+		Language: getQuery(r, "language"),
+		Limit:    coverageExportPageSize,
+	}
+
+	if value := getQuery(r, "covered"); value != "" {
+		covered, err := strconv.ParseBool(value)
+		if err != nil {
+			return opts, errors.New("covered must be a boolean")
+		}
+		opts.Covered = &covered
+	}
+
+	if value := getQuery(r, "maxCommitsBehind"); value != "" {
+		maxCommitsBehind, err := strconv.Atoi(value)
+		if err != nil || maxCommitsBehind < 0 {
+			return opts, errors.New("maxCommitsBehind must be a non-negative integer")
+		}
+		opts.MaxCommitsBehind = &maxCommitsBehind
+	}
+
+	return opts, nil
+}
+
+func coverageExportRow(c uploadsshared.CodeIntelCoverage) []string {
+	formatInt := func(v *int) string {
+		if v == nil {
+			return ""
+		}
+		return strconv.Itoa(*v)
+	}
+	formatTime := func(v *time.Time) string {
+		if v == nil {
+			return ""
+		}
+		return v.UTC().Format(time.RFC3339)
+	}
+	formatString := func(v *string) string {
+		if v == nil {
+			return ""
+		}
+		return *v
+	}
+
+	return []string{
+		c.RepositoryName,
+		c.Language,
+		strings.Join(c.Indexers, " "),
+		strconv.Itoa(c.NumUploads),
+		formatInt(c.UploadID),
+		formatInt(c.CommitsBehind),
+		formatTime(c.LastUploadedAt),
+		formatTime(c.LastFailureAt),
+		formatString(c.LastFailureReason),
+		formatTime(&c.ComputedAt),
+	}
+}
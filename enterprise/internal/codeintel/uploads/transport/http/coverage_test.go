@@ -0,0 +1,114 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	uploadsshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestCoverageExportHandler(t *testing.T) {
+	users := database.NewStrictMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{SiteAdmin: true}, nil)
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+
+	computedAt := time.Unix(1587396557, 0).UTC()
+	uploadID := 42
+	commitsBehind := 3
+	failureReason := "no build tool, \"gradle\" not found"
+
+	// Serve two pages of results to check that the whole report is exported
+	pages := [][]uploadsshared.CodeIntelCoverage{
+		{
+			{RepositoryName: "github.com/foo/bar", Language: "Go", Indexers: []string{"lsif-go", "sourcegraph/lsif-go"}, NumUploads: 2, UploadID: &uploadID, CommitsBehind: &commitsBehind, LastUploadedAt: &computedAt, ComputedAt: computedAt},
+		},
+		{
+			{RepositoryName: "github.com/foo/bar", Language: "Java", Indexers: []string{"sourcegraph/scip-java"}, LastFailureAt: &computedAt, LastFailureReason: &failureReason, ComputedAt: computedAt},
+		},
+	}
+
+	mockSvc := NewMockCoverageService()
+	mockSvc.GetCodeIntelCoverageFunc.SetDefaultHook(func(ctx context.Context, opts uploadsshared.GetCodeIntelCoverageOptions) ([]uploadsshared.CodeIntelCoverage, int, error) {
+		if opts.Offset >= len(pages) {
+			return nil, len(pages), nil
+		}
+		return pages[opts.Offset], len(pages), nil
+	})
+
+	handler := newCoverageExportHandler(mockSvc, db, logtest.Scoped(t))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/.api/codeintel/coverage/export?query=foo&covered=false&maxCommitsBehind=10", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status code. want=%d have=%d (%s)", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	expectedBody := "" +
+		"repository,language,indexers,uploads_at_tip,upload_id,commits_behind,last_uploaded_at,last_failure_at,last_failure_reason,computed_at\n" +
+		"github.com/foo/bar,Go,lsif-go sourcegraph/lsif-go,2,42,3,2020-04-20T15:29:17Z,,,2020-04-20T15:29:17Z\n" +
+		"github.com/foo/bar,Java,sourcegraph/scip-java,0,,,,2020-04-20T15:29:17Z,\"no build tool, \"\"gradle\"\" not found\",2020-04-20T15:29:17Z\n"
+	if diff := cmp.Diff(expectedBody, w.Body.String()); diff != "" {
+		t.Errorf("unexpected body (-want +got):\n%s", diff)
+	}
+
+	history := mockSvc.GetCodeIntelCoverageFunc.History()
+	if len(history) != 2 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 2, len(history))
+	}
+	covered := false
+	maxCommitsBehind := 10
+	expectedOpts := uploadsshared.GetCodeIntelCoverageOptions{Term: "foo", Covered: &covered, MaxCommitsBehind: &maxCommitsBehind, Limit: coverageExportPageSize}
+	if diff := cmp.Diff(expectedOpts, history[0].Arg1); diff != "" {
+		t.Errorf("unexpected options (-want +got):\n%s", diff)
+	}
+}
+
+func TestCoverageExportHandlerErrors(t *testing.T) {
+	admins := database.NewStrictMockUserStore()
+	admins.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{SiteAdmin: true}, nil)
+	adminDB := database.NewMockDB()
+	adminDB.UsersFunc.SetDefaultReturn(admins)
+
+	users := database.NewStrictMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{}, nil)
+	userDB := database.NewMockDB()
+	userDB.UsersFunc.SetDefaultReturn(users)
+
+	testCases := []struct {
+		name           string
+		db             database.DB
+		url            string
+		expectedStatus int
+	}{
+		{name: "non-admin", db: userDB, url: "/", expectedStatus: http.StatusForbidden},
+		{name: "invalid covered", db: adminDB, url: "/?covered=maybe", expectedStatus: http.StatusBadRequest},
+		{name: "invalid maxCommitsBehind", db: adminDB, url: "/?maxCommitsBehind=-1", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockSvc := NewMockCoverageService()
+			handler := newCoverageExportHandler(mockSvc, testCase.db, logtest.Scoped(t))
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest("GET", testCase.url, nil))
+
+			if w.Code != testCase.expectedStatus {
+				t.Errorf("unexpected status code. want=%d have=%d", testCase.expectedStatus, w.Code)
+			}
+			if len(mockSvc.GetCodeIntelCoverageFunc.History()) != 0 {
+				t.Errorf("unexpected call to GetCodeIntelCoverage")
+			}
+		})
+	}
+}
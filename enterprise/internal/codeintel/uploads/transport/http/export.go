@@ -0,0 +1,78 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+)
+
+func newExportHandler(svc ExportService, logger log.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uploadID, ok := unmarshalUploadID(getQuery(r, "upload"))
+		if !ok {
+			http.Error(w, "upload must be an upload ID", http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/x-protobuf+scip")
+		w.Header().Set("Content-Disposition", "attachment; filename=\"upload-"+strconv.Itoa(uploadID)+".scip\"")
+
+		writer := &trackingWriter{w: w}
+		exists, err := svc.ExportSCIPIndex(r.Context(), authz.DefaultSubRepoPermsChecker, uploadID, writer)
+		if err != nil {
+			if writer.written {
+				// We can't change the status code of a response that has already started,
+				// so the client will notice the truncated index instead.
+				logger.Error("failed to export SCIP index", log.Int("uploadID", uploadID), log.Error(err))
+				return
+			}
+
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !exists {
+			http.Error(w, "upload not found or has no processed SCIP data", http.StatusNotFound)
+			return
+		}
+	})
+}
+
+// unmarshalUploadID parses an upload identifier, which is either the numeric identifier of an
+// upload or its GraphQL identifier.
+func unmarshalUploadID(s string) (int, bool) {
+	if id, err := strconv.Atoi(s); err == nil {
+		return id, true
+	}
+
+	if relay.UnmarshalKind(graphql.ID(s)) != "LSIFUpload" {
+		return 0, false
+	}
+
+	// Older identifiers encode the numeric identifier as a string
+	var idString string
+	if err := relay.UnmarshalSpec(graphql.ID(s), &idString); err == nil {
+		id, err := strconv.Atoi(idString)
+		return id, err == nil
+	}
+
+	var id int
+	err := relay.UnmarshalSpec(graphql.ID(s), &id)
+	return id, err == nil
+}
+
+// trackingWriter records whether any data has been written to the wrapped writer.
+type trackingWriter struct {
+	w       io.Writer
+	written bool
+}
+
+func (w *trackingWriter) Write(p []byte) (int, error) {
+	w.written = true
+	return w.w.Write(p)
+}
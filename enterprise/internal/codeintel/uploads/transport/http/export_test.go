@@ -0,0 +1,58 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestExportHandler(t *testing.T) {
+	mockSvc := NewMockExportService()
+	mockSvc.ExportSCIPIndexFunc.SetDefaultHook(func(ctx context.Context, checker authz.SubRepoPermissionChecker, uploadID int, w io.Writer) (bool, error) {
+		switch uploadID {
+		case 42:
+			_, err := w.Write([]byte("index"))
+			return true, err
+		case 43:
+			return false, errors.New("uh-oh")
+		}
+		return false, nil
+	})
+
+	handler := newExportHandler(mockSvc, logtest.Scoped(t))
+
+	testCases := []struct {
+		upload         string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{upload: "42", expectedStatus: http.StatusOK, expectedBody: "index"},
+		{upload: string(relay.MarshalID("LSIFUpload", 42)), expectedStatus: http.StatusOK, expectedBody: "index"},
+		{upload: string(relay.MarshalID("LSIFUpload", "42")), expectedStatus: http.StatusOK, expectedBody: "index"},
+		{upload: "43", expectedStatus: http.StatusInternalServerError},
+		{upload: "44", expectedStatus: http.StatusNotFound},
+		{upload: string(relay.MarshalID("Repository", 42)), expectedStatus: http.StatusBadRequest},
+		{upload: "", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, testCase := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/scip/export?upload="+testCase.upload, nil)
+		handler.ServeHTTP(w, r)
+
+		if w.Code != testCase.expectedStatus {
+			t.Errorf("unexpected status code for upload %q. want=%d have=%d", testCase.upload, testCase.expectedStatus, w.Code)
+		}
+		if testCase.expectedBody != "" && w.Body.String() != testCase.expectedBody {
+			t.Errorf("unexpected body for upload %q. want=%q have=%q", testCase.upload, testCase.expectedBody, w.Body.String())
+		}
+	}
+}
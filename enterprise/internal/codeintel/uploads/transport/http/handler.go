@@ -19,6 +19,8 @@ import (
 
 var revhashPattern = lazyregexp.New(`^[a-z0-9]{40}$`)
 
+const scipContentType = "application/x-protobuf+scip"
+
 func newHandler(
 	repoStore RepoStore,
 	uploadStore uploadstore.Store,
@@ -46,7 +48,7 @@ func newHandler(
 		}
 
 		// Populate state from request
-		return uploads.UploadMetadata{
+		metadata := uploads.UploadMetadata{
 			RepositoryID:      repositoryID,
 			Commit:            commit,
 			Root:              sanitizeRoot(getQuery(r, "root")),
@@ -54,7 +56,17 @@ func newHandler(
 			IndexerVersion:    getQuery(r, "indexerVersion"),
 			AssociatedIndexID: getQueryInt(r, "associatedIndexId"),
 			ContentType:       contentType,
-		}, 0, nil
+			Ephemeral:         getQueryBool(r, "ephemeral"),
+			BaseUploadID:      getQueryInt(r, "baseUploadId"),
+		}
+
+		if metadata.BaseUploadID != 0 {
+			if statusCode, err := ensureBaseUploadCompatible(ctx, dbStore, metadata); err != nil {
+				return uploads.UploadMetadata{}, statusCode, err
+			}
+		}
+
+		return metadata, 0, nil
 	}
 
 	handler := uploadhandler.NewUploadHandler(
@@ -68,6 +80,40 @@ func newHandler(
 	return handler
 }
 
+// ensureBaseUploadCompatible ensures that the base upload of an incremental upload exists and
+// indexes the same repository, root, and indexer as the incremental upload. Only SCIP indexes
+// can be uploaded incrementally.
+func ensureBaseUploadCompatible(ctx context.Context, dbStore uploadhandler.DBStore[uploads.UploadMetadata], metadata uploads.UploadMetadata) (int, error) {
+	if metadata.ContentType != scipContentType {
+		return http.StatusBadRequest, errors.Errorf("incremental uploads must have content type %q", scipContentType)
+	}
+
+	// 🚨 SECURITY: Bypass authz here; the base upload must belong to the same repository as
+	// the incremental upload, which the current request has already been authorized to view.
+	baseUpload, ok, err := dbStore.GetUploadByID(actor.WithInternalActor(ctx), metadata.BaseUploadID)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if !ok || baseUpload.Metadata.RepositoryID != metadata.RepositoryID {
+		return http.StatusNotFound, errors.Errorf("unknown base upload %d", metadata.BaseUploadID)
+	}
+
+	switch baseUpload.State {
+	case "queued", "processing", "completed":
+	default:
+		return http.StatusBadRequest, errors.Errorf("base upload %d is in state %q", metadata.BaseUploadID, baseUpload.State)
+	}
+
+	if baseUpload.Metadata.ContentType != scipContentType {
+		return http.StatusBadRequest, errors.Errorf("base upload %d is not a SCIP index", metadata.BaseUploadID)
+	}
+	if baseUpload.Metadata.Root != metadata.Root || baseUpload.Metadata.Indexer != metadata.Indexer {
+		return http.StatusBadRequest, errors.Errorf("base upload %d has a different root or indexer", metadata.BaseUploadID)
+	}
+
+	return 0, nil
+}
+
 func ensureRepoAndCommitExist(ctx context.Context, repoStore RepoStore, repoName, commit string, logger log.Logger) (int, int, error) {
 	// 🚨 SECURITY: Bypass authz here; we've already determined that the current request is
 	// authorized to view the target repository; they are either a site admin or the code
@@ -2,8 +2,11 @@ package http
 
 import (
 	"context"
+	"io"
 
+	uploadsshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
@@ -11,3 +14,11 @@ type RepoStore interface {
 	GetByName(ctx context.Context, name api.RepoName) (*types.Repo, error)
 	ResolveRev(ctx context.Context, repo *types.Repo, rev string) (api.CommitID, error)
 }
+
+type ExportService interface {
+	ExportSCIPIndex(ctx context.Context, checker authz.SubRepoPermissionChecker, uploadID int, w io.Writer) (bool, error)
+}
+
+type CoverageService interface {
+	GetCodeIntelCoverage(ctx context.Context, opts uploadsshared.GetCodeIntelCoverageOptions) (_ []uploadsshared.CodeIntelCoverage, _ int, err error)
+}
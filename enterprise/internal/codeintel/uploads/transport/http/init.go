@@ -56,3 +56,16 @@ func GetHandler(svc *uploads.Service, db database.DB, uploadStore uploadstore.St
 	}
 	return handler
 }
+
+// GetExportHandler returns a handler that streams the processed SCIP index of a completed upload.
+// Uploads are looked up as the current user, so uploads of repositories the user can't access
+// are not found.
+func GetExportHandler(svc *uploads.Service) http.Handler {
+	return newExportHandler(svc, log.Scoped("uploads.export", "codeintel uploads SCIP export http handler"))
+}
+
+// GetCoverageExportHandler returns a handler that writes the code intel coverage of all repositories
+// visible to the current user as CSV. Only site admins may export coverage.
+func GetCoverageExportHandler(svc *uploads.Service, db database.DB) http.Handler {
+	return newCoverageExportHandler(svc, db, log.Scoped("uploads.coverage", "codeintel uploads coverage export http handler"))
+}
@@ -8,8 +8,11 @@ package http
 
 import (
 	"context"
+	"io"
 	"sync"
 
+	shared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	authz "github.com/sourcegraph/sourcegraph/internal/authz"
 	uploadhandler "github.com/sourcegraph/sourcegraph/internal/uploadhandler"
 )
 
@@ -897,3 +900,323 @@ func (c DBStoreTransactFuncCall[T]) Args() []interface{} {
 func (c DBStoreTransactFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
+
+// MockCoverageService is a mock implementation of the CoverageService
+// interface (from the package
+// github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/transport/http)
+// used for unit testing.
+type MockCoverageService struct {
+	// GetCodeIntelCoverageFunc is an instance of a mock function object
+	// controlling the behavior of the method GetCodeIntelCoverage.
+	GetCodeIntelCoverageFunc *CoverageServiceGetCodeIntelCoverageFunc
+}
+
+// NewMockCoverageService creates a new mock of the CoverageService
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockCoverageService() *MockCoverageService {
+	return &MockCoverageService{
+		GetCodeIntelCoverageFunc: &CoverageServiceGetCodeIntelCoverageFunc{
+			defaultHook: func(context.Context, shared.GetCodeIntelCoverageOptions) (r0 []shared.CodeIntelCoverage, r1 int, r2 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockCoverageService creates a new mock of the CoverageService
+// interface. All methods panic on invocation, unless overwritten.
+func NewStrictMockCoverageService() *MockCoverageService {
+	return &MockCoverageService{
+		GetCodeIntelCoverageFunc: &CoverageServiceGetCodeIntelCoverageFunc{
+			defaultHook: func(context.Context, shared.GetCodeIntelCoverageOptions) ([]shared.CodeIntelCoverage, int, error) {
+				panic("unexpected invocation of MockCoverageService.GetCodeIntelCoverage")
+			},
+		},
+	}
+}
+
+// NewMockCoverageServiceFrom creates a new mock of the MockCoverageService
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockCoverageServiceFrom(i CoverageService) *MockCoverageService {
+	return &MockCoverageService{
+		GetCodeIntelCoverageFunc: &CoverageServiceGetCodeIntelCoverageFunc{
+			defaultHook: i.GetCodeIntelCoverage,
+		},
+	}
+}
+
+// CoverageServiceGetCodeIntelCoverageFunc describes the behavior when the
+// GetCodeIntelCoverage method of the parent MockCoverageService instance is
+// invoked.
+type CoverageServiceGetCodeIntelCoverageFunc struct {
+	defaultHook func(context.Context, shared.GetCodeIntelCoverageOptions) ([]shared.CodeIntelCoverage, int, error)
+	hooks       []func(context.Context, shared.GetCodeIntelCoverageOptions) ([]shared.CodeIntelCoverage, int, error)
+	history     []CoverageServiceGetCodeIntelCoverageFuncCall
+	mutex       sync.Mutex
+}
+
+// GetCodeIntelCoverage delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCoverageService) GetCodeIntelCoverage(v0 context.Context, v1 shared.GetCodeIntelCoverageOptions) ([]shared.CodeIntelCoverage, int, error) {
+	r0, r1, r2 := m.GetCodeIntelCoverageFunc.nextHook()(v0, v1)
+	m.GetCodeIntelCoverageFunc.appendCall(CoverageServiceGetCodeIntelCoverageFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the GetCodeIntelCoverage
+// method of the parent MockCoverageService instance is invoked and the hook
+// queue is empty.
+func (f *CoverageServiceGetCodeIntelCoverageFunc) SetDefaultHook(hook func(context.Context, shared.GetCodeIntelCoverageOptions) ([]shared.CodeIntelCoverage, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetCodeIntelCoverage method of the parent MockCoverageService instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CoverageServiceGetCodeIntelCoverageFunc) PushHook(hook func(context.Context, shared.GetCodeIntelCoverageOptions) ([]shared.CodeIntelCoverage, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CoverageServiceGetCodeIntelCoverageFunc) SetDefaultReturn(r0 []shared.CodeIntelCoverage, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, shared.GetCodeIntelCoverageOptions) ([]shared.CodeIntelCoverage, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CoverageServiceGetCodeIntelCoverageFunc) PushReturn(r0 []shared.CodeIntelCoverage, r1 int, r2 error) {
+	f.PushHook(func(context.Context, shared.GetCodeIntelCoverageOptions) ([]shared.CodeIntelCoverage, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *CoverageServiceGetCodeIntelCoverageFunc) nextHook() func(context.Context, shared.GetCodeIntelCoverageOptions) ([]shared.CodeIntelCoverage, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CoverageServiceGetCodeIntelCoverageFunc) appendCall(r0 CoverageServiceGetCodeIntelCoverageFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CoverageServiceGetCodeIntelCoverageFuncCall
+// objects describing the invocations of this function.
+func (f *CoverageServiceGetCodeIntelCoverageFunc) History() []CoverageServiceGetCodeIntelCoverageFuncCall {
+	f.mutex.Lock()
+	history := make([]CoverageServiceGetCodeIntelCoverageFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CoverageServiceGetCodeIntelCoverageFuncCall is an object that describes
+// an invocation of method GetCodeIntelCoverage on an instance of
+// MockCoverageService.
+type CoverageServiceGetCodeIntelCoverageFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 shared.GetCodeIntelCoverageOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.CodeIntelCoverage
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CoverageServiceGetCodeIntelCoverageFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CoverageServiceGetCodeIntelCoverageFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// MockExportService is a mock implementation of the ExportService interface
+// (from the package
+// github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/transport/http)
+// used for unit testing.
+type MockExportService struct {
+	// ExportSCIPIndexFunc is an instance of a mock function object
+	// controlling the behavior of the method ExportSCIPIndex.
+	ExportSCIPIndexFunc *ExportServiceExportSCIPIndexFunc
+}
+
+// NewMockExportService creates a new mock of the ExportService interface.
+// All methods return zero values for all results, unless overwritten.
+func NewMockExportService() *MockExportService {
+	return &MockExportService{
+		ExportSCIPIndexFunc: &ExportServiceExportSCIPIndexFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, int, io.Writer) (r0 bool, r1 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockExportService creates a new mock of the ExportService
+// interface. All methods panic on invocation, unless overwritten.
+func NewStrictMockExportService() *MockExportService {
+	return &MockExportService{
+		ExportSCIPIndexFunc: &ExportServiceExportSCIPIndexFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, int, io.Writer) (bool, error) {
+				panic("unexpected invocation of MockExportService.ExportSCIPIndex")
+			},
+		},
+	}
+}
+
+// NewMockExportServiceFrom creates a new mock of the MockExportService
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockExportServiceFrom(i ExportService) *MockExportService {
+	return &MockExportService{
+		ExportSCIPIndexFunc: &ExportServiceExportSCIPIndexFunc{
+			defaultHook: i.ExportSCIPIndex,
+		},
+	}
+}
+
+// ExportServiceExportSCIPIndexFunc describes the behavior when the
+// ExportSCIPIndex method of the parent MockExportService instance is
+// invoked.
+type ExportServiceExportSCIPIndexFunc struct {
+	defaultHook func(context.Context, authz.SubRepoPermissionChecker, int, io.Writer) (bool, error)
+	hooks       []func(context.Context, authz.SubRepoPermissionChecker, int, io.Writer) (bool, error)
+	history     []ExportServiceExportSCIPIndexFuncCall
+	mutex       sync.Mutex
+}
+
+// ExportSCIPIndex delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockExportService) ExportSCIPIndex(v0 context.Context, v1 authz.SubRepoPermissionChecker, v2 int, v3 io.Writer) (bool, error) {
+	r0, r1 := m.ExportSCIPIndexFunc.nextHook()(v0, v1, v2, v3)
+	m.ExportSCIPIndexFunc.appendCall(ExportServiceExportSCIPIndexFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ExportSCIPIndex
+// method of the parent MockExportService instance is invoked and the hook
+// queue is empty.
+func (f *ExportServiceExportSCIPIndexFunc) SetDefaultHook(hook func(context.Context, authz.SubRepoPermissionChecker, int, io.Writer) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ExportSCIPIndex method of the parent MockExportService instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ExportServiceExportSCIPIndexFunc) PushHook(hook func(context.Context, authz.SubRepoPermissionChecker, int, io.Writer) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ExportServiceExportSCIPIndexFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, authz.SubRepoPermissionChecker, int, io.Writer) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ExportServiceExportSCIPIndexFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, authz.SubRepoPermissionChecker, int, io.Writer) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *ExportServiceExportSCIPIndexFunc) nextHook() func(context.Context, authz.SubRepoPermissionChecker, int, io.Writer) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ExportServiceExportSCIPIndexFunc) appendCall(r0 ExportServiceExportSCIPIndexFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ExportServiceExportSCIPIndexFuncCall
+// objects describing the invocations of this function.
+func (f *ExportServiceExportSCIPIndexFunc) History() []ExportServiceExportSCIPIndexFuncCall {
+	f.mutex.Lock()
+	history := make([]ExportServiceExportSCIPIndexFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ExportServiceExportSCIPIndexFuncCall is an object that describes an
+// invocation of method ExportSCIPIndex on an instance of MockExportService.
+type ExportServiceExportSCIPIndexFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 authz.SubRepoPermissionChecker
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 io.Writer
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ExportServiceExportSCIPIndexFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ExportServiceExportSCIPIndexFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
@@ -15,6 +15,11 @@ func getQueryInt(r *http.Request, name string) int {
 	return value
 }
 
+func getQueryBool(r *http.Request, name string) bool {
+	value, _ := strconv.ParseBool(r.URL.Query().Get(name))
+	return value
+}
+
 func sanitizeRoot(s string) string {
 	if s == "" || s == "/" {
 		return ""
@@ -0,0 +1,237 @@
+package uploads
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/go-enry/go-enry/v2"
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/paths"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/internal/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// referencedSymbolNamesBatchSize is the maximum number of symbol names sent to the codeintel
+// database at once when searching for references from other uploads.
+const referencedSymbolNamesBatchSize = 1000
+
+// ComputeUnreferencedSymbolsReports recomputes the unreferenced symbols report of a batch of repositories
+// whose report is missing or older than the given process delay. A symbol is considered unreferenced if
+// it is defined in a SCIP upload visible at the tip of the repository's default branch, and is referenced
+// neither within that upload nor by any upload visible at the tip of the default branch of a repository
+// depending on the package it belongs to.
+func (s *Service) ComputeUnreferencedSymbolsReports(ctx context.Context, processDelay time.Duration, repositoryBatchSize int) (err error) {
+	ctx, _, endObservation := s.operations.computeUnreferencedSymbolsReports.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	now := s.clock.Now()
+
+	uploads, err := s.store.GetUploadsForUnreferencedSymbolsReport(ctx, processDelay, repositoryBatchSize, now)
+	if err != nil {
+		return err
+	}
+
+	repositoryIDs := []int{}
+	uploadsByRepositoryID := map[int][]store.UnreferencedSymbolsUpload{}
+	for _, upload := range uploads {
+		if _, ok := uploadsByRepositoryID[upload.RepositoryID]; !ok {
+			repositoryIDs = append(repositoryIDs, upload.RepositoryID)
+		}
+
+		uploadsByRepositoryID[upload.RepositoryID] = append(uploadsByRepositoryID[upload.RepositoryID], upload)
+	}
+
+	for _, repositoryID := range repositoryIDs {
+		var symbols []shared.UnreferencedSymbol
+		for _, upload := range uploadsByRepositoryID[repositoryID] {
+			uploadSymbols, err := s.getUnreferencedSymbolsForUpload(ctx, upload)
+			if err != nil {
+				return err
+			}
+
+			symbols = append(symbols, uploadSymbols...)
+		}
+
+		if err := s.store.UpdateUnreferencedSymbols(ctx, repositoryID, symbols, now); err != nil {
+			return err
+		}
+
+		s.logger.Info(
+			"Computed unreferenced symbols report",
+			log.Int("repositoryID", repositoryID),
+			log.Int("numSymbols", len(symbols)),
+		)
+	}
+
+	return nil
+}
+
+type unreferencedSymbolDefinition struct {
+	path     string
+	language string
+}
+
+// getUnreferencedSymbolsForUpload returns the symbols defined in the given upload that are not referenced
+// from within the upload or from any upload that depends on a package provided by the given upload.
+func (s *Service) getUnreferencedSymbolsForUpload(ctx context.Context, upload store.UnreferencedSymbolsUpload) ([]shared.UnreferencedSymbol, error) {
+	definitions := map[string]unreferencedSymbolDefinition{}
+	referenced := map[string]struct{}{}
+
+	if err := s.lsifstore.ScanDocuments(ctx, upload.ID, func(path string, document *scip.Document) error {
+		excluded := isPathExcludedFromUnreferencedSymbols(path)
+
+		for _, symbol := range document.Symbols {
+			for _, relationship := range symbol.Relationships {
+				if relationship.IsImplementation || relationship.IsReference {
+					// Implementations may be invoked through the symbol they implement,
+					// which we can't distinguish from a direct reference here.
+					referenced[symbol.Symbol] = struct{}{}
+				}
+			}
+		}
+
+		for _, occurrence := range document.Occurrences {
+			if occurrence.Symbol == "" || scip.IsLocalSymbol(occurrence.Symbol) {
+				continue
+			}
+
+			if !scip.SymbolRole_Definition.Matches(occurrence) {
+				referenced[occurrence.Symbol] = struct{}{}
+				continue
+			}
+
+			if _, ok := definitions[occurrence.Symbol]; ok || excluded || !isUnreferencedSymbolCandidate(occurrence.Symbol) {
+				continue
+			}
+
+			definitions[occurrence.Symbol] = unreferencedSymbolDefinition{
+				path:     path,
+				language: documentLanguage(path, document),
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	candidates := make([]string, 0, len(definitions))
+	for symbolName := range definitions {
+		if _, ok := referenced[symbolName]; !ok {
+			candidates = append(candidates, symbolName)
+		}
+	}
+	sort.Strings(candidates)
+
+	if len(candidates) > 0 {
+		referencingUploadIDs, err := s.store.GetReferencingUploadIDs(ctx, upload.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(referencingUploadIDs) > 0 {
+			for i := 0; i < len(candidates); i += referencedSymbolNamesBatchSize {
+				j := i + referencedSymbolNamesBatchSize
+				if j > len(candidates) {
+					j = len(candidates)
+				}
+
+				symbolNames, err := s.lsifstore.GetReferencedSymbolNames(ctx, referencingUploadIDs, candidates[i:j])
+				if err != nil {
+					return nil, err
+				}
+
+				for _, symbolName := range symbolNames {
+					referenced[symbolName] = struct{}{}
+				}
+			}
+		}
+	}
+
+	symbols := make([]shared.UnreferencedSymbol, 0, len(candidates))
+	for _, symbolName := range candidates {
+		if _, ok := referenced[symbolName]; ok {
+			continue
+		}
+
+		definition := definitions[symbolName]
+		symbols = append(symbols, shared.UnreferencedSymbol{
+			RepositoryID: upload.RepositoryID,
+			UploadID:     upload.ID,
+			SymbolName:   symbolName,
+			DocumentPath: filepath.Join(upload.Root, definition.path),
+			Language:     definition.language,
+		})
+	}
+
+	return symbols, nil
+}
+
+// isUnreferencedSymbolCandidate returns true if the given symbol denotes a declaration that
+// should be reported when it is not referenced. Parameters, namespaces, and well-known program
+// entrypoints are never reported.
+func isUnreferencedSymbolCandidate(symbolName string) bool {
+	symbol, err := scip.ParseSymbol(symbolName)
+	if err != nil || len(symbol.Descriptors) == 0 {
+		return false
+	}
+
+	descriptor := symbol.Descriptors[len(symbol.Descriptors)-1]
+
+	switch descriptor.Suffix {
+	case scip.Descriptor_Type, scip.Descriptor_Term, scip.Descriptor_Method, scip.Descriptor_Macro:
+	default:
+		return false
+	}
+
+	if descriptor.Suffix == scip.Descriptor_Method {
+		if _, ok := entrypointNames[descriptor.Name]; ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+var entrypointNames = map[string]struct{}{
+	"main": {},
+	"init": {},
+}
+
+func documentLanguage(path string, document *scip.Document) string {
+	if document.Language != "" {
+		return document.Language
+	}
+
+	language, _ := enry.GetLanguageByExtension(path)
+	return language
+}
+
+func isPathExcludedFromUnreferencedSymbols(path string) bool {
+	return paths.IsTest(path) || paths.IsGenerated(path) || paths.IsVendored(path)
+}
+
+func (s *Service) GetUnreferencedSymbols(ctx context.Context, opts shared.GetUnreferencedSymbolsOptions) (_ []shared.UnreferencedSymbol, _ int, err error) {
+	ctx, _, endObservation := s.operations.getUnreferencedSymbols.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.Int("repositoryID", opts.RepositoryID),
+		otlog.String("language", opts.Language),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.store.GetUnreferencedSymbols(ctx, opts)
+}
+
+func (s *Service) GetUnreferencedSymbolsSummary(ctx context.Context, repositoryID int) (_ shared.UnreferencedSymbolsSummary, _ bool, err error) {
+	ctx, _, endObservation := s.operations.getUnreferencedSymbolsSummary.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.Int("repositoryID", repositoryID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.store.GetUnreferencedSymbolsSummary(ctx, repositoryID)
+}
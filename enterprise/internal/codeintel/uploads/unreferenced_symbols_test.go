@@ -0,0 +1,131 @@
+package uploads
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/internal/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestComputeUnreferencedSymbolsReports(t *testing.T) {
+	mockStore := NewMockStore()
+	mockLsifStore := NewMockLsifStore()
+	svc := newService(&observation.TestContext, mockStore, nil, mockLsifStore, nil, nil, nil, nil, nil)
+
+	const (
+		goPrefix       = "scip-go gomod example v1 `example/lib`/"
+		usedSymbol     = goPrefix + "Used()."
+		unusedSymbol   = goPrefix + "Unused()."
+		exportedSymbol = goPrefix + "Exported()."
+		mainSymbol     = goPrefix + "main()."
+		implSymbol     = goPrefix + "Impl#Method()."
+		ifaceSymbol    = goPrefix + "Iface#Method."
+		paramSymbol    = goPrefix + "Used().(x)"
+		helperSymbol   = goPrefix + "Helper()."
+		tsSymbol       = "scip-typescript npm example 1.0.0 `bar.ts`/Thing#"
+	)
+
+	mockStore.GetUploadsForUnreferencedSymbolsReportFunc.SetDefaultReturn([]store.UnreferencedSymbolsUpload{
+		{ID: 42, RepositoryID: 50, Root: "lib/"},
+	}, nil)
+	mockStore.GetReferencingUploadIDsFunc.SetDefaultReturn([]int{43}, nil)
+	mockLsifStore.GetReferencedSymbolNamesFunc.SetDefaultReturn([]string{exportedSymbol}, nil)
+	mockLsifStore.ScanDocumentsFunc.SetDefaultHook(func(ctx context.Context, id int, f func(path string, document *scip.Document) error) error {
+		documents := map[string]*scip.Document{
+			"lib.go": {
+				Language: "Go",
+				Symbols: []*scip.SymbolInformation{
+					{Symbol: implSymbol, Relationships: []*scip.Relationship{{Symbol: ifaceSymbol, IsImplementation: true}}},
+				},
+				Occurrences: []*scip.Occurrence{
+					{Symbol: usedSymbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+					{Symbol: paramSymbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+					{Symbol: unusedSymbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+					{Symbol: exportedSymbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+					{Symbol: mainSymbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+					{Symbol: implSymbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+					{Symbol: "local 1", SymbolRoles: int32(scip.SymbolRole_Definition)},
+					{Symbol: usedSymbol},
+				},
+			},
+			"lib_test.go": {
+				Language: "Go",
+				Occurrences: []*scip.Occurrence{
+					{Symbol: helperSymbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+				},
+			},
+			"bar.ts": {
+				Occurrences: []*scip.Occurrence{
+					{Symbol: tsSymbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+				},
+			},
+		}
+
+		for _, path := range []string{"bar.ts", "lib.go", "lib_test.go"} {
+			if err := f(path, documents[path]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err := svc.ComputeUnreferencedSymbolsReports(context.Background(), time.Hour, 10); err != nil {
+		t.Fatalf("unexpected error computing unreferenced symbols reports: %s", err)
+	}
+
+	if history := mockLsifStore.GetReferencedSymbolNamesFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected number of calls to GetReferencedSymbolNames. want=%d have=%d", 1, len(history))
+	} else {
+		if diff := cmp.Diff([]int{43}, history[0].Arg1); diff != "" {
+			t.Errorf("unexpected upload ids (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]string{exportedSymbol, unusedSymbol, tsSymbol}, history[0].Arg2); diff != "" {
+			t.Errorf("unexpected symbol names (-want +got):\n%s", diff)
+		}
+	}
+
+	history := mockStore.UpdateUnreferencedSymbolsFunc.History()
+	if len(history) != 1 {
+		t.Fatalf("unexpected number of calls to UpdateUnreferencedSymbols. want=%d have=%d", 1, len(history))
+	}
+	if history[0].Arg1 != 50 {
+		t.Errorf("unexpected repository id. want=%d have=%d", 50, history[0].Arg1)
+	}
+
+	expectedSymbols := []shared.UnreferencedSymbol{
+		{RepositoryID: 50, UploadID: 42, SymbolName: unusedSymbol, DocumentPath: "lib/lib.go", Language: "Go"},
+		{RepositoryID: 50, UploadID: 42, SymbolName: tsSymbol, DocumentPath: "lib/bar.ts", Language: "TypeScript"},
+	}
+	if diff := cmp.Diff(expectedSymbols, history[0].Arg2); diff != "" {
+		t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+	}
+}
+
+func TestIsUnreferencedSymbolCandidate(t *testing.T) {
+	testCases := map[string]bool{
+		"scip-go gomod example v1 `example/lib`/Func().":      true,
+		"scip-go gomod example v1 `example/lib`/Type#":        true,
+		"scip-go gomod example v1 `example/lib`/Type#Field.":  true,
+		"scip-go gomod example v1 `example/lib`/Func().(x)":   false,
+		"scip-go gomod example v1 `example/lib`/Func().[T]":   false,
+		"scip-go gomod example v1 `example/lib`/":             false,
+		"scip-go gomod example v1 `example/cmd`/main().":      false,
+		"scip-go gomod example v1 `example/cmd`/init().":      false,
+		"scip-go gomod example v1 `example/cmd`/Type#main().": false,
+		"local 1": false,
+		"invalid": false,
+	}
+
+	for symbol, expected := range testCases {
+		if actual := isUnreferencedSymbolCandidate(symbol); actual != expected {
+			t.Errorf("unexpected result for %q. want=%v have=%v", symbol, expected, actual)
+		}
+	}
+}
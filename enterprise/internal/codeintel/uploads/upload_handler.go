@@ -16,6 +16,8 @@ type UploadMetadata struct {
 	IndexerVersion    string
 	AssociatedIndexID int
 	ContentType       string
+	Ephemeral         bool
+	BaseUploadID      int
 }
 
 type uploadHandlerShim struct {
@@ -41,6 +43,11 @@ func (s *uploadHandlerShim) InsertUpload(ctx context.Context, upload uploadhandl
 		associatedIndexID = &upload.Metadata.AssociatedIndexID
 	}
 
+	var baseUploadID *int
+	if upload.Metadata.BaseUploadID != 0 {
+		baseUploadID = &upload.Metadata.BaseUploadID
+	}
+
 	return s.Store.InsertUpload(ctx, types.Upload{
 		ID:                upload.ID,
 		State:             upload.State,
@@ -55,6 +62,8 @@ func (s *uploadHandlerShim) InsertUpload(ctx context.Context, upload uploadhandl
 		IndexerVersion:    upload.Metadata.IndexerVersion,
 		AssociatedIndexID: associatedIndexID,
 		ContentType:       upload.Metadata.ContentType,
+		Ephemeral:         upload.Metadata.Ephemeral,
+		BaseUploadID:      baseUploadID,
 	})
 }
 
@@ -80,12 +89,17 @@ func (s *uploadHandlerShim) GetUploadByID(ctx context.Context, uploadID int) (up
 			Root:           upload.Root,
 			Indexer:        upload.Indexer,
 			IndexerVersion: upload.IndexerVersion,
+			ContentType:    upload.ContentType,
+			Ephemeral:      upload.Ephemeral,
 		},
 	}
 
 	if upload.AssociatedIndexID != nil {
 		u.Metadata.AssociatedIndexID = *upload.AssociatedIndexID
 	}
+	if upload.BaseUploadID != nil {
+		u.Metadata.BaseUploadID = *upload.BaseUploadID
+	}
 
 	return u, true, nil
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/hexops/autogold"
 	"github.com/stretchr/testify/require"
 
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 )
 
@@ -66,6 +67,19 @@ func TestWebhook(t *testing.T) {
 		client := s.Client()
 		err := postWebhook(context.Background(), client, s.URL, generateWebhookPayload(action))
 		require.Error(t, err)
+		require.False(t, errcode.IsNonRetryable(err))
+	})
+
+	t.Run("client errors are not retried", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(404)
+		}))
+		defer s.Close()
+
+		client := s.Client()
+		err := postWebhook(context.Background(), client, s.URL, generateWebhookPayload(action))
+		require.Error(t, err)
+		require.True(t, errcode.IsNonRetryable(err))
 	})
 }
 
@@ -3,6 +3,7 @@ package background
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -137,9 +138,14 @@ func createDBWorkerStoreForActionJobs(observationCtx *observation.Context, s edb
 		ColumnExpressions: edb.ActionJobColumns,
 		Scan:              dbworkerstore.BuildWorkerScan(edb.ScanActionJob),
 		StalledMaxAge:     60 * time.Second,
-		RetryAfter:        10 * time.Second,
-		MaxNumRetries:     3,
-		OrderByExpression: sqlf.Sprintf("id"),
+		// Actions deliver notifications to external services, so back off to give
+		// those services time to recover rather than retrying all actions at once.
+		RetryAfter:             10 * time.Second,
+		RetryBackoffMultiplier: 3,
+		MaxRetryAfter:          5 * time.Minute,
+		RetryJitter:            0.2,
+		MaxNumRetries:          3,
+		OrderByExpression:      sqlf.Sprintf("id"),
 	})
 }
 
@@ -379,6 +385,13 @@ func (s StatusCodeError) Error() string {
 	return fmt.Sprintf("non-200 response %d %s with body %q", s.Code, s.Status, s.Body)
 }
 
+// NonRetryable returns true for client errors, e.g. for webhooks that no longer
+// exist, as sending the same request again would fail in the same way. Timeouts
+// and rate limits are retried.
+func (s StatusCodeError) NonRetryable() bool {
+	return s.Code >= 400 && s.Code < 500 && s.Code != http.StatusRequestTimeout && s.Code != http.StatusTooManyRequests
+}
+
 // newQueryWithAfterFilter constructs a new query which finds search results
 // introduced after the last time we queried.
 func newQueryWithAfterFilter(q *edb.QueryTrigger) string {
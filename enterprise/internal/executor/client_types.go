@@ -2,9 +2,12 @@ package executor
 
 import (
 	"encoding/json"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/executor"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // Job describes a series of steps to perform within an executor.
@@ -18,6 +21,10 @@ type Job struct {
 	// that different queues can share identifiers.
 	ID int `json:"id"`
 
+	// Queue is the name of the queue the job was dequeued from. It is only set for
+	// jobs handed out by the multi-queue dequeue endpoint.
+	Queue string `json:"queue,omitempty"`
+
 	// RepositoryName is the name of the repository to be cloned into the
 	// workspace prior to job execution.
 	RepositoryName string `json:"repositoryName"`
@@ -55,6 +62,11 @@ type Job struct {
 	// may be done inside or outside of a Firecracker virtual machine.
 	CliSteps []CliStep `json:"cliSteps"`
 
+	// Caches describe directories of the workspace that are restored before the
+	// first step runs and saved after all steps completed successfully. Caches
+	// are only sent to executors that understand version 2 of the payload.
+	Caches []CacheSpec `json:"caches,omitempty"`
+
 	// RedactedValues is a map from strings to replace to their replacement in the command
 	// output before sending it to the underlying job store. This should contain all worker
 	// environment variables, as well as secret values passed along with the dequeued job
@@ -73,6 +85,7 @@ func (j Job) MarshalJSON() ([]byte, error) {
 		v2 := v2Job{
 			Version:             j.Version,
 			ID:                  j.ID,
+			Queue:               j.Queue,
 			RepositoryName:      j.RepositoryName,
 			RepositoryDirectory: j.RepositoryDirectory,
 			Commit:              j.Commit,
@@ -81,6 +94,7 @@ func (j Job) MarshalJSON() ([]byte, error) {
 			SparseCheckout:      j.SparseCheckout,
 			DockerSteps:         j.DockerSteps,
 			CliSteps:            j.CliSteps,
+			Caches:              j.Caches,
 			RedactedValues:      j.RedactedValues,
 			DockerAuthConfig:    j.DockerAuthConfig,
 		}
@@ -126,6 +140,7 @@ func (j *Job) UnmarshalJSON(data []byte) error {
 		}
 		j.Version = v2.Version
 		j.ID = v2.ID
+		j.Queue = v2.Queue
 		j.RepositoryName = v2.RepositoryName
 		j.RepositoryDirectory = v2.RepositoryDirectory
 		j.Commit = v2.Commit
@@ -138,6 +153,7 @@ func (j *Job) UnmarshalJSON(data []byte) error {
 		}
 		j.DockerSteps = v2.DockerSteps
 		j.CliSteps = v2.CliSteps
+		j.Caches = v2.Caches
 		j.RedactedValues = v2.RedactedValues
 		j.DockerAuthConfig = v2.DockerAuthConfig
 		return nil
@@ -175,6 +191,7 @@ type versionJob struct {
 type v2Job struct {
 	Version             int                             `json:"version,omitempty"`
 	ID                  int                             `json:"id"`
+	Queue               string                          `json:"queue,omitempty"`
 	RepositoryName      string                          `json:"repositoryName"`
 	RepositoryDirectory string                          `json:"repositoryDirectory"`
 	Commit              string                          `json:"commit"`
@@ -184,6 +201,7 @@ type v2Job struct {
 	VirtualMachineFiles map[string]v2VirtualMachineFile `json:"files"`
 	DockerSteps         []DockerStep                    `json:"dockerSteps"`
 	CliSteps            []CliStep                       `json:"cliSteps"`
+	Caches              []CacheSpec                     `json:"caches,omitempty"`
 	RedactedValues      map[string]string               `json:"redactedValues"`
 	DockerAuthConfig    DockerAuthConfig                `json:"dockerAuthConfig,omitempty"`
 }
@@ -234,10 +252,46 @@ type v1VirtualMachineFile struct {
 	ModifiedAt time.Time `json:"modifiedAt,omitempty"`
 }
 
+// CacheSpec describes a set of directories of the workspace that are kept between
+// jobs. The contents of the key files are hashed into the cache key, so a cache is
+// only restored while the files it was built from are unchanged.
+type CacheSpec struct {
+	// Key identifies the cache. It must be unique among the jobs that share caches.
+	Key string `json:"key"`
+
+	// KeyFiles are the workspace-relative paths of the files whose contents are part
+	// of the cache key.
+	KeyFiles []string `json:"keyFiles,omitempty"`
+
+	// Paths are the workspace-relative directories that are saved and restored.
+	Paths []string `json:"paths"`
+}
+
 func (j Job) RecordID() int {
 	return j.ID
 }
 
+// RecordUID returns an identifier of the job that is unique across queues. Jobs
+// that were not dequeued through the multi-queue endpoint are identified by
+// their ID alone.
+func (j Job) RecordUID() string {
+	if j.Queue == "" {
+		return strconv.Itoa(j.ID)
+	}
+	return strconv.Itoa(j.ID) + "-" + j.Queue
+}
+
+// ParseJobUID parses an identifier returned by Job.RecordUID into the ID of the
+// job and the name of the queue it was dequeued from, if any.
+func ParseJobUID(uid string) (id int, queue string, err error) {
+	idPart, queue, _ := strings.Cut(uid, "-")
+	id, err = strconv.Atoi(idPart)
+	if err != nil {
+		return 0, "", errors.Wrapf(err, "invalid job identifier %q", uid)
+	}
+	return id, queue, nil
+}
+
 type DockerStep struct {
 	// Key is a unique identifier of the step. It can be used to retrieve the
 	// associated log entry.
@@ -279,6 +333,23 @@ type DequeueRequest struct {
 	DiskSpace    string `json:"diskSpace,omitempty"`
 }
 
+// MultiQueueDequeueRequest requests a job from one of several queues. The queue is
+// picked at random, proportionally to the weights of the queues that have a job
+// available.
+type MultiQueueDequeueRequest struct {
+	DequeueRequest
+	Queues []WeightedQueue `json:"queues"`
+	// RunningJobIDs are the IDs of the jobs the executor is currently processing,
+	// by the name of the queue they were dequeued from. These jobs are not
+	// dequeued again.
+	RunningJobIDs map[string][]int `json:"runningJobIds,omitempty"`
+}
+
+type WeightedQueue struct {
+	Name   string `json:"name"`
+	Weight int    `json:"weight"`
+}
+
 type AddExecutionLogEntryRequest struct {
 	ExecutorName string `json:"executorName"`
 	JobID        int    `json:"jobId"`
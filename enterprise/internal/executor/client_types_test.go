@@ -337,3 +337,41 @@ func TestJob_UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestJob_RecordUID(t *testing.T) {
+	tests := []struct {
+		name     string
+		job      Job
+		expected string
+	}{
+		{
+			name:     "Single queue",
+			job:      Job{ID: 42},
+			expected: "42",
+		},
+		{
+			name:     "Multiple queues",
+			job:      Job{ID: 42, Queue: "batches"},
+			expected: "42-batches",
+		},
+		{
+			name:     "Queue name with dashes",
+			job:      Job{ID: 42, Queue: "code-intel"},
+			expected: "42-code-intel",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			uid := test.job.RecordUID()
+			require.Equal(t, test.expected, uid)
+
+			id, queue, err := ParseJobUID(uid)
+			require.NoError(t, err)
+			require.Equal(t, test.job.ID, id)
+			require.Equal(t, test.job.Queue, queue)
+		})
+	}
+
+	_, _, err := ParseJobUID("batches-42")
+	require.Error(t, err)
+}
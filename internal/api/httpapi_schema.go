@@ -44,3 +44,28 @@ type ExternalServicesListRequest struct {
 	Limit   int      `json:"limit"`
 	AfterID int      `json:"after_id"`
 }
+
+// DocumentRankExplanation describes how the rank vector of a document within a repository was computed.
+type DocumentRankExplanation struct {
+	Path       string                  `json:"path"`
+	Rank       []float64               `json:"rank"`
+	Components []DocumentRankComponent `json:"components"`
+	Signals    []DocumentRankSignal    `json:"signals"`
+}
+
+// DocumentRankComponent describes a single component of a document's rank vector.
+type DocumentRankComponent struct {
+	Name   string  `json:"name"`
+	Value  float64 `json:"value"`
+	Reason string  `json:"reason"`
+}
+
+// DocumentRankSignal describes the contribution of a site-defined ranking signal to the
+// signals component of a document's rank vector. Value is in the range [0, 1] and the
+// contribution of the signal is its value multiplied by its weight.
+type DocumentRankSignal struct {
+	Name   string  `json:"name"`
+	Value  float64 `json:"value"`
+	Weight float64 `json:"weight"`
+	Reason string  `json:"reason"`
+}
@@ -59,6 +59,8 @@ type UploadsServiceResolver interface {
 	LSIFUploadsByRepo(ctx context.Context, args *LSIFRepositoryUploadsQueryArgs) (LSIFUploadConnectionResolver, error)
 	DeleteLSIFUpload(ctx context.Context, args *struct{ ID graphql.ID }) (*EmptyResponse, error)
 	DeleteLSIFUploads(ctx context.Context, args *DeleteLSIFUploadsArgs) (*EmptyResponse, error)
+	UnreferencedSymbols(ctx context.Context, repositoryID graphql.ID, args *CodeIntelUnreferencedSymbolsArgs) (CodeIntelUnreferencedSymbolConnectionResolver, error)
+	CodeIntelCoverage(ctx context.Context, args *CodeIntelCoverageArgs) (CodeIntelCoverageConnectionResolver, error)
 }
 
 type PoliciesServiceResolver interface {
@@ -269,6 +271,7 @@ type GitBlobLSIFDataResolver interface {
 	Ranges(ctx context.Context, args *LSIFRangesArgs) (CodeIntelligenceRangeConnectionResolver, error)
 	Definitions(ctx context.Context, args *LSIFQueryPositionArgs) (LocationConnectionResolver, error)
 	References(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
+	ReferencesAcrossVersions(ctx context.Context, args *LSIFReferencesAcrossVersionsArgs) ([]PackageVersionReferencesResolver, error)
 	Implementations(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
 	Hover(ctx context.Context, args *LSIFQueryPositionArgs) (HoverResolver, error)
 }
@@ -287,6 +290,11 @@ type LocationConnectionResolver interface {
 	PageInfo(ctx context.Context) (PageInfo, error)
 }
 
+type PackageVersionReferencesResolver interface {
+	Version() string
+	References() LocationConnectionResolver
+}
+
 type LSIFDiagnosticsArgs struct {
 	graphqlutil.ConnectionArgs
 }
@@ -303,6 +311,12 @@ type LSIFPagedQueryPositionArgs struct {
 	Filter *string
 }
 
+type LSIFReferencesAcrossVersionsArgs struct {
+	Line      int32
+	Character int32
+	First     *int32
+}
+
 type LSIFQueryPositionArgs struct {
 	Line      int32
 	Character int32
@@ -629,6 +643,60 @@ type LSIFRepositoryUploadsQueryArgs struct {
 	RepositoryID graphql.ID
 }
 
+type CodeIntelUnreferencedSymbolsArgs struct {
+	graphqlutil.ConnectionArgs
+	Language *string
+	After    *string
+}
+
+type CodeIntelUnreferencedSymbolConnectionResolver interface {
+	Nodes(ctx context.Context) ([]CodeIntelUnreferencedSymbolResolver, error)
+	TotalCount() int32
+	PageInfo() PageInfo
+	Languages() []CodeIntelUnreferencedSymbolLanguageCountResolver
+	ComputedAt() *gqlutil.DateTime
+}
+
+type CodeIntelUnreferencedSymbolResolver interface {
+	Symbol() string
+	Path() string
+	Language() string
+	Upload(ctx context.Context) (LSIFUploadResolver, error)
+}
+
+type CodeIntelUnreferencedSymbolLanguageCountResolver interface {
+	Language() string
+	Count() int32
+}
+
+type CodeIntelCoverageArgs struct {
+	graphqlutil.ConnectionArgs
+	Query            *string
+	Language         *string
+	Covered          *bool
+	MaxCommitsBehind *int32
+	After            *string
+}
+
+type CodeIntelCoverageConnectionResolver interface {
+	Nodes(ctx context.Context) ([]CodeIntelCoverageResolver, error)
+	TotalCount() int32
+	PageInfo() PageInfo
+}
+
+type CodeIntelCoverageResolver interface {
+	Repository(ctx context.Context) (RepositoryResolver, error)
+	Language() *string
+	Indexers() []string
+	UploadsAtTip() int32
+	Upload(ctx context.Context) (LSIFUploadResolver, error)
+	CommitsBehind() *int32
+	LastUploadedAt() *gqlutil.DateTime
+	LastFailureAt() *gqlutil.DateTime
+	LastFailureReason() *string
+	ComputedAt() gqlutil.DateTime
+}
+
 type DeleteLSIFUploadsArgs struct {
 	Query           *string
 	State           *string
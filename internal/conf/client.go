@@ -152,6 +152,20 @@ func Cached[T any](f func() T) (wrapped func() T) {
 	}
 }
 
+// lazyCached is like Cached, but only initializes the default client on the
+// first call to wrapped. It is used for package-level values of this package,
+// which must not initialize the default client when the package is imported.
+func lazyCached[T any](f func() T) (wrapped func() T) {
+	var once sync.Once
+	var cached func() T
+	return func() T {
+		once.Do(func() {
+			cached = Cached(f)
+		})
+		return cached()
+	}
+}
+
 // Watch calls the given function in a separate goroutine whenever the
 // configuration has changed. The new configuration can be received by calling
 // conf.Get.
@@ -7,12 +7,16 @@ import (
 	"strings"
 	"time"
 
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
 	"github.com/sourcegraph/sourcegraph/internal/conf/confdefaults"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/conf/deploy"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	srccli "github.com/sourcegraph/sourcegraph/internal/src-cli"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/version"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/schema"
@@ -348,6 +352,95 @@ func ExperimentalFeatures() schema.ExperimentalFeatures {
 	return *val
 }
 
+// virtualRepoIndex indexes the virtual repositories of the site configuration.
+type virtualRepoIndex struct {
+	byName   map[api.RepoName]*types.VirtualRepo
+	byParent map[api.RepoName][]*types.VirtualRepo
+}
+
+var virtualRepos = lazyCached(func() virtualRepoIndex {
+	vs := ExperimentalFeatures().VirtualRepos
+	idx := virtualRepoIndex{
+		byName:   make(map[api.RepoName]*types.VirtualRepo, len(vs)),
+		byParent: map[api.RepoName][]*types.VirtualRepo{},
+	}
+	for _, v := range vs {
+		vr := types.NewVirtualRepo(api.RepoName(v.Name), api.RepoName(v.Repo), v.Paths)
+		idx.byName[vr.Name] = vr
+		idx.byParent[vr.Parent] = append(idx.byParent[vr.Parent], vr)
+	}
+	return idx
+})
+
+// VirtualRepo returns the virtual repository with the given name, or nil if
+// name is not a virtual repository. The result is shared and must not be
+// modified.
+func VirtualRepo(name api.RepoName) *types.VirtualRepo {
+	return virtualRepos().byName[name]
+}
+
+// VirtualReposOf returns the virtual repositories defined on the repository
+// with the given name. The result is shared and must not be modified.
+func VirtualReposOf(parent api.RepoName) []*types.VirtualRepo {
+	return virtualRepos().byParent[parent]
+}
+
+// defaultSearchLFSMaxFileSize is the default for search.lfs maxFileSize.
+const defaultSearchLFSMaxFileSize = 2 << 20
+
+// SearchLFSRules are the compiled search.lfs rules of a site configuration.
+type SearchLFSRules []searchLFSRule
+
+type searchLFSRule struct {
+	namePattern *regexp.Regexp
+	maxFileSize int
+}
+
+// NewSearchLFSRules compiles the search.lfs rules in c. Rules with an invalid
+// name pattern are skipped.
+func NewSearchLFSRules(c *schema.SiteConfiguration) SearchLFSRules {
+	if c == nil || c.ExperimentalFeatures == nil {
+		return nil
+	}
+	rules := make(SearchLFSRules, 0, len(c.ExperimentalFeatures.SearchLfs))
+	for _, rule := range c.ExperimentalFeatures.SearchLfs {
+		namePattern, err := regexp.Compile(rule.Name)
+		if err != nil {
+			continue
+		}
+		rules = append(rules, searchLFSRule{namePattern: namePattern, maxFileSize: rule.MaxFileSize})
+	}
+	return rules
+}
+
+// MaxFileSize returns the maximum size in bytes of Git LFS objects to fetch
+// when searching or indexing the repository with the given name. It returns 0
+// if the contents of LFS files should not be searched.
+func (rules SearchLFSRules) MaxFileSize(name api.RepoName) int64 {
+	for _, rule := range rules {
+		if !rule.namePattern.MatchString(string(name)) {
+			continue
+		}
+		if rule.maxFileSize > 0 {
+			return int64(rule.maxFileSize)
+		}
+		return defaultSearchLFSMaxFileSize
+	}
+	return 0
+}
+
+var searchLFSRules = lazyCached(func() SearchLFSRules {
+	return NewSearchLFSRules(&Get().SiteConfiguration)
+})
+
+// SearchLFSMaxFileSize returns the maximum size in bytes of Git LFS objects to
+// fetch when searching the repository with the given name according to the
+// search.lfs rules of the current site configuration. It returns 0 if the
+// contents of LFS files should not be searched.
+func SearchLFSMaxFileSize(name api.RepoName) int64 {
+	return searchLFSRules().MaxFileSize(name)
+}
+
 func Tracer() string {
 	ot := Get().ObservabilityTracing
 	if ot == nil {
@@ -9,6 +9,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -148,6 +149,33 @@ func TestGitMaxConcurrentClones(t *testing.T) {
 	}
 }
 
+func TestSearchLFSRules(t *testing.T) {
+	rules := NewSearchLFSRules(&schema.SiteConfiguration{
+		ExperimentalFeatures: &schema.ExperimentalFeatures{
+			SearchLfs: []*schema.SearchLFSRule{
+				{Name: "^github.com/foo/fixtures$", MaxFileSize: 1024},
+				{Name: "("},
+				{Name: "^github.com/foo/"},
+			},
+		},
+	})
+
+	tests := map[api.RepoName]int64{
+		"github.com/foo/fixtures": 1024,
+		"github.com/foo/bar":      defaultSearchLFSMaxFileSize,
+		"github.com/bar/foo":      0,
+	}
+	for name, want := range tests {
+		if got := rules.MaxFileSize(name); got != want {
+			t.Errorf("MaxFileSize(%q) = %d, want %d", name, got, want)
+		}
+	}
+
+	if got := NewSearchLFSRules(&schema.SiteConfiguration{}).MaxFileSize("github.com/foo/bar"); got != 0 {
+		t.Errorf("MaxFileSize without rules = %d, want 0", got)
+	}
+}
+
 func TestAuthLockout(t *testing.T) {
 	defer Mock(nil)
 
@@ -79,7 +79,7 @@ func (d *MavenVersionedPackage) Less(other VersionedPackage) bool {
 	o := other.(*MavenVersionedPackage)
 
 	if d.MavenModule.Equal(o.MavenModule) {
-		return versionGreaterThan(d.Version, o.Version)
+		return VersionGreaterThan(d.Version, o.Version)
 	}
 
 	// TODO: This SortText method is quite inefficient and allocates.
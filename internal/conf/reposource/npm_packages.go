@@ -250,7 +250,7 @@ func (d *NpmVersionedPackage) Less(other VersionedPackage) bool {
 	o := other.(*NpmVersionedPackage)
 
 	if d.NpmPackageName.Equal(o.NpmPackageName) {
-		return versionGreaterThan(d.Version, o.Version)
+		return VersionGreaterThan(d.Version, o.Version)
 	}
 
 	if d.scope == o.scope {
@@ -82,7 +82,7 @@ func (p *PythonVersionedPackage) Less(other VersionedPackage) bool {
 
 	if p.Name == o.Name {
 		// TODO: validate once we add a dependency source for vcs syncer.
-		return versionGreaterThan(p.Version, o.Version)
+		return VersionGreaterThan(p.Version, o.Version)
 	}
 
 	return p.Name > o.Name
@@ -82,7 +82,7 @@ func (p *RubyVersionedPackage) Less(other VersionedPackage) bool {
 	o := other.(*RubyVersionedPackage)
 
 	if p.Name == o.Name {
-		return versionGreaterThan(p.Version, o.Version)
+		return VersionGreaterThan(p.Version, o.Version)
 	}
 
 	return p.Name > o.Name
@@ -81,7 +81,7 @@ func (p *RustVersionedPackage) Less(other VersionedPackage) bool {
 
 	if p.Name == o.Name {
 		// TODO: validate once we add a dependency source for vcs syncer.
-		return versionGreaterThan(p.Version, o.Version)
+		return VersionGreaterThan(p.Version, o.Version)
 	}
 
 	return p.Name > o.Name
@@ -71,6 +71,19 @@ func validateCustom(cfg Unified) (problems Problems) {
 		}
 	}
 
+	if cfg.ExperimentalFeatures != nil {
+		names := make(map[string]struct{}, len(cfg.ExperimentalFeatures.VirtualRepos))
+		for _, v := range cfg.ExperimentalFeatures.VirtualRepos {
+			if v.Name == v.Repo {
+				invalid(NewSiteProblem(fmt.Sprintf("virtual repository %q must not have the same name as its repository", v.Name)))
+			}
+			if _, ok := names[v.Name]; ok {
+				invalid(NewSiteProblem(fmt.Sprintf("virtual repository %q is defined more than once", v.Name)))
+			}
+			names[v.Name] = struct{}{}
+		}
+	}
+
 	for _, f := range contributedValidators {
 		problems = append(problems, f(cfg)...)
 	}
@@ -80,6 +80,17 @@ func TestValidateCustom(t *testing.T) {
 			raw:         `{"externalURL":"http://example.com/sourcegraph"}`,
 			wantProblem: "externalURL must not be a non-root URL",
 		},
+		"valid virtual repo": {
+			raw: `{"experimentalFeatures":{"virtualRepos":[{"name":"github.com/a/mono/svc","repo":"github.com/a/mono","paths":["svc"]}]}}`,
+		},
+		"virtual repo named like its repo": {
+			raw:         `{"experimentalFeatures":{"virtualRepos":[{"name":"github.com/a/mono","repo":"github.com/a/mono","paths":["svc"]}]}}`,
+			wantProblem: "must not have the same name as its repository",
+		},
+		"duplicate virtual repo": {
+			raw:         `{"experimentalFeatures":{"virtualRepos":[{"name":"github.com/a/mono/svc","repo":"github.com/a/mono","paths":["svc"]},{"name":"github.com/a/mono/svc","repo":"github.com/a/mono","paths":["lib"]}]}}`,
+			wantProblem: "is defined more than once",
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -64,6 +64,10 @@ type GitserverRepoStore interface {
 	ListReposWithoutSize(ctx context.Context) (map[api.RepoName]api.RepoID, error)
 	// UpdateRepoSizes sets repo sizes according to input map. Key is repoID, value is repo_size_bytes.
 	UpdateRepoSizes(ctx context.Context, shardID string, repos map[api.RepoID]int64) (int, error)
+	// SetShardMigrationState records the progress of copying a repo from the
+	// sourceShardID gitserver to its new owner. Setting the state to
+	// ShardMigrationStateNone clears the source shard as well.
+	SetShardMigrationState(ctx context.Context, name api.RepoName, state types.ShardMigrationState, sourceShardID string) error
 }
 
 var _ GitserverRepoStore = (*gitserverRepoStore)(nil)
@@ -320,7 +324,9 @@ SELECT
 	gr.repo_size_bytes,
 	gr.updated_at,
 	gr.corrupted_at,
-	gr.corruption_logs
+	gr.corruption_logs,
+	gr.shard_migration_state,
+	gr.shard_migration_source
 FROM gitserver_repos gr
 JOIN repo ON gr.repo_id = repo.id
 WHERE %s
@@ -352,7 +358,9 @@ SELECT
 	repo_size_bytes,
 	updated_at,
 	corrupted_at,
-	corruption_logs
+	corruption_logs,
+	shard_migration_state,
+	shard_migration_source
 FROM gitserver_repos
 WHERE repo_id = %s
 `
@@ -381,7 +389,9 @@ SELECT
 	gr.repo_size_bytes,
 	gr.updated_at,
 	gr.corrupted_at,
-	gr.corruption_logs
+	gr.corruption_logs,
+	gr.shard_migration_state,
+	gr.shard_migration_source
 FROM gitserver_repos gr
 JOIN repo r ON r.id = gr.repo_id
 WHERE r.name = %s
@@ -404,7 +414,9 @@ SELECT
 	gr.repo_size_bytes,
 	gr.updated_at,
 	gr.corrupted_at,
-	gr.corruption_logs
+	gr.corruption_logs,
+	gr.shard_migration_state,
+	gr.shard_migration_source
 FROM gitserver_repos gr
 JOIN repo r on r.id = gr.repo_id
 WHERE r.name = ANY (%s)
@@ -434,6 +446,7 @@ func scanGitserverRepo(scanner dbutil.Scanner) (*types.GitserverRepo, api.RepoNa
 	var gr types.GitserverRepo
 	var rawLogs []byte
 	var cloneStatus string
+	var shardMigrationState string
 	var repoName api.RepoName
 	err := scanner.Scan(
 		&gr.RepoID,
@@ -447,11 +460,14 @@ func scanGitserverRepo(scanner dbutil.Scanner) (*types.GitserverRepo, api.RepoNa
 		&gr.UpdatedAt,
 		&dbutil.NullTime{Time: &gr.CorruptedAt},
 		&rawLogs,
+		&dbutil.NullString{S: &shardMigrationState},
+		&dbutil.NullString{S: &gr.ShardMigrationSource},
 	)
 	if err != nil {
 		return nil, "", errors.Wrap(err, "scanning GitserverRepo")
 	}
 	gr.CloneStatus = types.ParseCloneStatus(cloneStatus)
+	gr.ShardMigrationState = types.ParseShardMigrationState(shardMigrationState)
 
 	err = json.Unmarshal(rawLogs, &gr.CorruptionLogs)
 	if err != nil {
@@ -562,6 +578,29 @@ AND
 	return nil
 }
 
+func (s *gitserverRepoStore) SetShardMigrationState(ctx context.Context, name api.RepoName, state types.ShardMigrationState, sourceShardID string) error {
+	if state == types.ShardMigrationStateNone {
+		sourceShardID = ""
+	}
+
+	err := s.Exec(ctx, sqlf.Sprintf(`
+UPDATE gitserver_repos
+SET
+	shard_migration_state = %s,
+	shard_migration_source = %s,
+	updated_at = NOW()
+WHERE
+	repo_id = (SELECT id FROM repo WHERE name = %s)
+	AND
+	(shard_migration_state IS DISTINCT FROM %s OR shard_migration_source IS DISTINCT FROM %s)
+`, dbutil.NewNullString(string(state)), dbutil.NewNullString(sourceShardID), name, dbutil.NewNullString(string(state)), dbutil.NewNullString(sourceShardID)))
+	if err != nil {
+		return errors.Wrap(err, "setting shard migration state")
+	}
+
+	return nil
+}
+
 // GitserverFetchData is the metadata associated with a fetch operation on
 // gitserver.
 type GitserverFetchData struct {
@@ -825,6 +825,66 @@ func TestSetRepoSize(t *testing.T) {
 	}
 }
 
+func TestSetShardMigrationState(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	// Create one test repo
+	repo, gitserverRepo := createTestRepo(ctx, t, db, &createTestRepoPayload{
+		Name:          "github.com/sourcegraph/repo",
+		CloneStatus:   types.CloneStatusNotCloned,
+		RepoSizeBytes: 100,
+	})
+
+	// Start copying the repo from another shard
+	if err := db.GitserverRepos().SetShardMigrationState(ctx, repo.Name, types.ShardMigrationStateCopying, "gitserver-0"); err != nil {
+		t.Fatal(err)
+	}
+
+	fromDB, err := db.GitserverRepos().GetByID(ctx, gitserverRepo.RepoID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gitserverRepo.ShardMigrationState = types.ShardMigrationStateCopying
+	gitserverRepo.ShardMigrationSource = "gitserver-0"
+	if diff := cmp.Diff(gitserverRepo, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs")); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// Setting the same state should not touch the row
+	if err := db.GitserverRepos().SetShardMigrationState(ctx, repo.Name, types.ShardMigrationStateCopying, "gitserver-0"); err != nil {
+		t.Fatal(err)
+	}
+	after, err := db.GitserverRepos().GetByID(ctx, gitserverRepo.RepoID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(fromDB, after); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// Clearing the state also clears the source shard
+	if err := db.GitserverRepos().SetShardMigrationState(ctx, repo.Name, types.ShardMigrationStateNone, "gitserver-0"); err != nil {
+		t.Fatal(err)
+	}
+	fromDB, err = db.GitserverRepos().GetByID(ctx, gitserverRepo.RepoID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gitserverRepo.ShardMigrationState = types.ShardMigrationStateNone
+	gitserverRepo.ShardMigrationSource = ""
+	if diff := cmp.Diff(gitserverRepo, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs")); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
 func TestGitserverRepo_Update(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
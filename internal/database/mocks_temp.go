@@ -23394,6 +23394,9 @@ type MockGitserverRepoStore struct {
 	// SetRepoSizeFunc is an instance of a mock function object controlling
 	// the behavior of the method SetRepoSize.
 	SetRepoSizeFunc *GitserverRepoStoreSetRepoSizeFunc
+	// SetShardMigrationStateFunc is an instance of a mock function object
+	// controlling the behavior of the method SetShardMigrationState.
+	SetShardMigrationStateFunc *GitserverRepoStoreSetShardMigrationStateFunc
 	// TotalErroredCloudDefaultReposFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// TotalErroredCloudDefaultRepos.
@@ -23479,6 +23482,11 @@ func NewMockGitserverRepoStore() *MockGitserverRepoStore {
 				return
 			},
 		},
+		SetShardMigrationStateFunc: &GitserverRepoStoreSetShardMigrationStateFunc{
+			defaultHook: func(context.Context, api.RepoName, types.ShardMigrationState, string) (r0 error) {
+				return
+			},
+		},
 		TotalErroredCloudDefaultReposFunc: &GitserverRepoStoreTotalErroredCloudDefaultReposFunc{
 			defaultHook: func(context.Context) (r0 int, r1 error) {
 				return
@@ -23572,6 +23580,11 @@ func NewStrictMockGitserverRepoStore() *MockGitserverRepoStore {
 				panic("unexpected invocation of MockGitserverRepoStore.SetRepoSize")
 			},
 		},
+		SetShardMigrationStateFunc: &GitserverRepoStoreSetShardMigrationStateFunc{
+			defaultHook: func(context.Context, api.RepoName, types.ShardMigrationState, string) error {
+				panic("unexpected invocation of MockGitserverRepoStore.SetShardMigrationState")
+			},
+		},
 		TotalErroredCloudDefaultReposFunc: &GitserverRepoStoreTotalErroredCloudDefaultReposFunc{
 			defaultHook: func(context.Context) (int, error) {
 				panic("unexpected invocation of MockGitserverRepoStore.TotalErroredCloudDefaultRepos")
@@ -23639,6 +23652,9 @@ func NewMockGitserverRepoStoreFrom(i GitserverRepoStore) *MockGitserverRepoStore
 		SetRepoSizeFunc: &GitserverRepoStoreSetRepoSizeFunc{
 			defaultHook: i.SetRepoSize,
 		},
+		SetShardMigrationStateFunc: &GitserverRepoStoreSetShardMigrationStateFunc{
+			defaultHook: i.SetShardMigrationState,
+		},
 		TotalErroredCloudDefaultReposFunc: &GitserverRepoStoreTotalErroredCloudDefaultReposFunc{
 			defaultHook: i.TotalErroredCloudDefaultRepos,
 		},
@@ -25095,6 +25111,117 @@ func (c GitserverRepoStoreSetRepoSizeFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// GitserverRepoStoreSetShardMigrationStateFunc describes the behavior when
+// the SetShardMigrationState method of the parent MockGitserverRepoStore
+// instance is invoked.
+type GitserverRepoStoreSetShardMigrationStateFunc struct {
+	defaultHook func(context.Context, api.RepoName, types.ShardMigrationState, string) error
+	hooks       []func(context.Context, api.RepoName, types.ShardMigrationState, string) error
+	history     []GitserverRepoStoreSetShardMigrationStateFuncCall
+	mutex       sync.Mutex
+}
+
+// SetShardMigrationState delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockGitserverRepoStore) SetShardMigrationState(v0 context.Context, v1 api.RepoName, v2 types.ShardMigrationState, v3 string) error {
+	r0 := m.SetShardMigrationStateFunc.nextHook()(v0, v1, v2, v3)
+	m.SetShardMigrationStateFunc.appendCall(GitserverRepoStoreSetShardMigrationStateFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// SetShardMigrationState method of the parent MockGitserverRepoStore
+// instance is invoked and the hook queue is empty.
+func (f *GitserverRepoStoreSetShardMigrationStateFunc) SetDefaultHook(hook func(context.Context, api.RepoName, types.ShardMigrationState, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetShardMigrationState method of the parent MockGitserverRepoStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *GitserverRepoStoreSetShardMigrationStateFunc) PushHook(hook func(context.Context, api.RepoName, types.ShardMigrationState, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverRepoStoreSetShardMigrationStateFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, types.ShardMigrationState, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverRepoStoreSetShardMigrationStateFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, api.RepoName, types.ShardMigrationState, string) error {
+		return r0
+	})
+}
+
+func (f *GitserverRepoStoreSetShardMigrationStateFunc) nextHook() func(context.Context, api.RepoName, types.ShardMigrationState, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverRepoStoreSetShardMigrationStateFunc) appendCall(r0 GitserverRepoStoreSetShardMigrationStateFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// GitserverRepoStoreSetShardMigrationStateFuncCall objects describing the
+// invocations of this function.
+func (f *GitserverRepoStoreSetShardMigrationStateFunc) History() []GitserverRepoStoreSetShardMigrationStateFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverRepoStoreSetShardMigrationStateFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverRepoStoreSetShardMigrationStateFuncCall is an object that
+// describes an invocation of method SetShardMigrationState on an instance
+// of MockGitserverRepoStore.
+type GitserverRepoStoreSetShardMigrationStateFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method invocation.
+	Arg1 api.RepoName
+	// Arg2 is the value of the 3rd argument passed to this method invocation.
+	Arg2 types.ShardMigrationState
+	// Arg3 is the value of the 4th argument passed to this method invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverRepoStoreSetShardMigrationStateFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverRepoStoreSetShardMigrationStateFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // GitserverRepoStoreTotalErroredCloudDefaultReposFunc describes the
 // behavior when the TotalErroredCloudDefaultRepos method of the parent
 // MockGitserverRepoStore instance is invoked.
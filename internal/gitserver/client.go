@@ -314,6 +314,11 @@ type Client interface {
 	// response.
 	Search(_ context.Context, _ *protocol.SearchRequest, onMatches func([]protocol.CommitMatch)) (limitHit bool, _ error)
 
+	// SearchHistory searches the contents of files across every commit of a
+	// revision range, streaming the results as it goes by calling onMatches
+	// with each set of results it receives in response.
+	SearchHistory(_ context.Context, _ *protocol.HistorySearchRequest, onMatches func([]protocol.HistoryMatch)) (limitHit bool, _ error)
+
 	// Stat returns a FileInfo describing the named file at commit.
 	Stat(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, commit api.CommitID, path string) (fs.FileInfo, error)
 
@@ -430,6 +435,10 @@ type Client interface {
 	// ContributorCount returns the number of commits grouped by contributor
 	ContributorCount(ctx context.Context, repo api.RepoName, opt ContributorOptions) ([]*gitdomain.ContributorCount, error)
 
+	// CommitLog returns the non-merge commits reachable from HEAD that were committed after the given
+	// time, most recent first, along with the paths changed by each commit.
+	CommitLog(ctx context.Context, repo api.RepoName, after time.Time) ([]*gitdomain.CommitLog, error)
+
 	// LogReverseEach runs git log in reverse order and calls the given callback for each entry.
 	LogReverseEach(ctx context.Context, repo string, commit string, n int, onLogEntry func(entry gitdomain.LogEntry) error) error
 
@@ -466,6 +475,10 @@ func AddrForRepo(ctx context.Context, userAgent string, repo api.RepoName, addre
 	addrForRepoInvoked.WithLabelValues(userAgent).Inc()
 
 	repo = protocol.NormalizeRepo(repo) // in case the caller didn't already normalize it
+	if v := conf.VirtualRepo(repo); v != nil {
+		// Virtual repositories are served by the shard of their parent.
+		repo = protocol.NormalizeRepo(v.Parent)
+	}
 	rs := string(repo)
 	if repoPinned, addr := getPinnedRepoAddr(rs, addresses.PinnedServers); repoPinned {
 		return addr, nil
@@ -671,6 +684,66 @@ func (c *clientImplementor) Search(ctx context.Context, args *protocol.SearchReq
 	return eventDone.LimitHit, eventDone.Err()
 }
 
+func (c *clientImplementor) SearchHistory(ctx context.Context, args *protocol.HistorySearchRequest, onMatches func([]protocol.HistoryMatch)) (limitHit bool, err error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "GitserverClient.SearchHistory") //nolint:staticcheck // OT is deprecated
+	span.SetTag("repo", string(args.Repo))
+	span.SetTag("range", args.Range)
+	span.SetTag("pattern", args.Pattern)
+	span.SetTag("limit", args.Limit)
+	defer func() {
+		if err != nil {
+			ext.Error.Set(span, true)
+			span.SetTag("err", err.Error())
+		}
+		span.Finish()
+	}()
+
+	repoName := protocol.NormalizeRepo(args.Repo)
+
+	body, err := json.Marshal(args)
+	if err != nil {
+		return false, err
+	}
+
+	addrForRepo, err := c.AddrForRepo(ctx, repoName)
+	if err != nil {
+		return false, err
+	}
+
+	uri := "http://" + addrForRepo + "/search-history"
+	resp, err := c.do(ctx, repoName, "POST", uri, body)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	var (
+		decodeErr error
+		eventDone protocol.SearchEventDone
+	)
+	dec := StreamHistorySearchDecoder{
+		OnMatches: func(e protocol.HistorySearchEventMatches) {
+			onMatches(e)
+		},
+		OnDone: func(e protocol.SearchEventDone) {
+			eventDone = e
+		},
+		OnUnknown: func(event, _ []byte) {
+			decodeErr = errors.Errorf("unknown event %s", event)
+		},
+	}
+
+	if err := dec.ReadAll(resp.Body); err != nil {
+		return false, err
+	}
+
+	if decodeErr != nil {
+		return false, decodeErr
+	}
+
+	return eventDone.LimitHit, eventDone.Err()
+}
+
 func (c *clientImplementor) P4Exec(ctx context.Context, host, user, password string, args ...string) (_ io.ReadCloser, _ http.Header, errRes error) {
 	span, ctx := ot.StartSpanFromContext(ctx, "Client.P4Exec") //nolint:staticcheck // OT is deprecated
 	defer func() {
@@ -1218,6 +1291,7 @@ func (c *clientImplementor) CreateCommitFromPatch(ctx context.Context, req proto
 			CommitInfo:   req.CommitInfo,
 			Push:         req.Push,
 			GitApplyArgs: req.GitApplyArgs,
+			DryRun:       req.DryRun,
 		})
 		if err != nil {
 			return "", err
@@ -75,7 +75,7 @@ func (c *clientImplementor) Diff(ctx context.Context, checker authz.SubRepoPermi
 		return nil, errors.Errorf("invalid diff range argument: %q", rangeSpec)
 	}
 
-	rdr, err := c.execReader(ctx, opts.Repo, append([]string{
+	rdr, err := c.execReader(ctx, opts.Repo, withVirtualPathspecs(opts.Repo, append([]string{
 		"diff",
 		"--find-renames",
 		// TODO(eseliger): Enable once we have support for copy detection in go-diff
@@ -87,7 +87,7 @@ func (c *clientImplementor) Diff(ctx context.Context, checker authz.SubRepoPermi
 		"--no-prefix",
 		rangeSpec,
 		"--",
-	}, opts.Paths...))
+	}, opts.Paths...)))
 	if err != nil {
 		return nil, errors.Wrap(err, "executing git diff")
 	}
@@ -185,6 +185,65 @@ func (c *clientImplementor) ContributorCount(ctx context.Context, repo api.RepoN
 	return parseShortLog(out)
 }
 
+func (c *clientImplementor) CommitLog(ctx context.Context, repo api.RepoName, after time.Time) ([]*gitdomain.CommitLog, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "Git: CommitLog") //nolint:staticcheck // OT is deprecated
+	span.SetTag("After", after)
+	defer span.Finish()
+
+	args := []string{
+		"log",
+		"--no-merges",
+		"--name-only",
+		"--format=format:" + commitLogSeparator + "%H %ct",
+		"--since=" + after.UTC().Format(time.RFC3339),
+		"HEAD",
+		"--",
+	}
+	cmd := c.gitCommand(repo, args...)
+	out, err := cmd.Output(ctx)
+	if err != nil {
+		return nil, errors.Errorf("exec `git log --name-only` failed: %v", err)
+	}
+	return parseCommitLog(out)
+}
+
+// commitLogSeparator prefixes the line of each commit in the output of the `git log` command
+// run by CommitLog, distinguishing it from the paths changed by the commit.
+const commitLogSeparator = "%x1e"
+
+func parseCommitLog(out []byte) ([]*gitdomain.CommitLog, error) {
+	var commits []*gitdomain.CommitLog
+	for _, line := range bytes.Split(out, []byte{'\n'}) {
+		if len(line) == 0 {
+			continue
+		}
+
+		if line[0] != '\x1e' {
+			if len(commits) == 0 {
+				return nil, errors.Errorf("invalid git log line: %q", line)
+			}
+			commit := commits[len(commits)-1]
+			commit.ChangedFiles = append(commit.ChangedFiles, string(line))
+			continue
+		}
+
+		// example line: "\x1e2d1e6d0b42d6d5d4e6e0ad1d0b2e0f8b2e5c1f3a 1676140321"
+		fields := strings.Fields(string(line[1:]))
+		if len(fields) != 2 {
+			return nil, errors.Errorf("invalid git log line: %q", line)
+		}
+		timestamp, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, &gitdomain.CommitLog{
+			ID:         api.CommitID(fields[0]),
+			CommitDate: time.Unix(timestamp, 0).UTC(),
+		})
+	}
+	return commits, nil
+}
+
 // execReader executes an arbitrary `git` command (`git [args...]`) and returns a
 // reader connected to its stdout.
 //
@@ -359,7 +418,7 @@ func (c *clientImplementor) DiffPath(ctx context.Context, checker authz.SubRepoP
 
 // DiffSymbols performs a diff command which is expected to be parsed by our symbols package
 func (c *clientImplementor) DiffSymbols(ctx context.Context, repo api.RepoName, commitA, commitB api.CommitID) ([]byte, error) {
-	command := c.gitCommand(repo, "diff", "-z", "--name-status", "--no-renames", string(commitA), string(commitB))
+	command := c.gitCommand(repo, withVirtualPathspecs(repo, []string{"diff", "-z", "--name-status", "--no-renames", string(commitA), string(commitB), "--"})...)
 	return command.Output(ctx)
 }
 
@@ -381,6 +440,7 @@ func (c *clientImplementor) ReadDir(ctx context.Context, checker authz.SubRepoPe
 		path = filepath.Clean(rel(path)) + "/"
 	}
 	files, err := c.lsTree(ctx, repo, commit, path, recurse)
+	files = filterVirtualFileInfos(repo, files)
 
 	if err != nil || !authz.SubRepoEnabled(checker) {
 		return files, err
@@ -474,6 +534,10 @@ func (c *clientImplementor) lStat(ctx context.Context, checker authz.SubRepoPerm
 		return &fileutil.FileInfo{Mode_: os.ModeDir, Sys_: objectInfo(obj.ID)}, nil
 	}
 
+	if v := virtualRepo(repo); v != nil && !v.Visible(path) {
+		return nil, &os.PathError{Op: "ls-tree", Path: path, Err: os.ErrNotExist}
+	}
+
 	fis, err := c.lsTree(ctx, repo, commit, path, false)
 	if err != nil {
 		return nil, err
@@ -719,6 +783,9 @@ func streamBlameFileCmd(ctx context.Context, checker authz.SubRepoPermissionChec
 	if !hasAccess {
 		return nil, errUnauthorizedStreamBlame{Repo: repo}
 	}
+	if v := virtualRepo(repo); v != nil && !v.Includes(path) {
+		return nil, &os.PathError{Op: "blame", Path: path, Err: os.ErrNotExist}
+	}
 	if opt == nil {
 		opt = &BlameOptions{}
 	}
@@ -755,6 +822,9 @@ func blameFileCmd(ctx context.Context, checker authz.SubRepoPermissionChecker, c
 	if hasAccess, err := authz.FilterActorPath(ctx, checker, a, repo, path); err != nil || !hasAccess {
 		return nil, err
 	}
+	if v := virtualRepo(repo); v != nil && !v.Includes(path) {
+		return nil, &os.PathError{Op: "blame", Path: path, Err: os.ErrNotExist}
+	}
 	if opt == nil {
 		opt = &BlameOptions{}
 	}
@@ -1019,7 +1089,7 @@ func (c *clientImplementor) LsFiles(ctx context.Context, checker authz.SubRepoPe
 	if len(files) > 0 && files[len(files)-1] == "" {
 		files = files[:len(files)-1]
 	}
-	return filterPaths(ctx, checker, repo, files)
+	return filterPaths(ctx, checker, repo, filterVirtualPaths(repo, files))
 }
 
 // ListFiles returns a list of root-relative file paths matching the given
@@ -1039,7 +1109,7 @@ func (c *clientImplementor) ListFiles(ctx context.Context, checker authz.SubRepo
 		}
 	}
 
-	return filterPaths(ctx, checker, repo, matching)
+	return filterPaths(ctx, checker, repo, filterVirtualPaths(repo, matching))
 }
 
 // 🚨 SECURITY: All git methods that deal with file or path access need to have
@@ -1373,6 +1443,9 @@ func (c *clientImplementor) NewFileReader(ctx context.Context, checker authz.Sub
 	} else if !hasAccess {
 		return nil, os.ErrNotExist
 	}
+	if v := virtualRepo(repo); v != nil && !v.Includes(name) {
+		return nil, os.ErrNotExist
+	}
 
 	span, ctx := ot.StartSpanFromContext(ctx, "Git: GetFileReader") //nolint:staticcheck // OT is deprecated
 	span.SetTag("Name", name)
@@ -1759,10 +1832,23 @@ func (c *clientImplementor) commitLog(ctx context.Context, repo api.RepoName, op
 }
 
 func (c *clientImplementor) getWrappedCommits(ctx context.Context, repo api.RepoName, opt CommitsOptions) ([]*wrappedCommit, error) {
+	var virtualPaths []string
+	if v := virtualRepo(repo); v != nil {
+		// Only select commits modifying the paths of the virtual repository.
+		virtualPaths = v.PathsBelow(opt.Path)
+		if len(virtualPaths) == 0 {
+			return nil, nil
+		}
+		opt.Path = ""
+	}
+
 	args, err := commitLogArgs([]string{"log", logFormatWithoutRefs}, opt)
 	if err != nil {
 		return nil, err
 	}
+	if len(virtualPaths) > 0 {
+		args = append(append(args, "--"), virtualPaths...)
+	}
 
 	cmd := c.gitCommand(repo, args...)
 	if !opt.NoEnsureRevision {
@@ -2444,6 +2530,10 @@ func (c *clientImplementor) ArchiveReader(
 		return nil, err
 	}
 
+	if options.Pathspecs, err = virtualArchivePathspecs(repo, options.Pathspecs); err != nil {
+		return nil, err
+	}
+
 	u, err := c.archiveURL(ctx, repo, options)
 	if err != nil {
 		return nil, err
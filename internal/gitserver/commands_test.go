@@ -89,6 +89,34 @@ func TestParseShortLog(t *testing.T) {
 	}
 }
 
+func TestParseCommitLog(t *testing.T) {
+	input := "\x1ea1b2c3 1676140321\n" +
+		"code/a.go\n" +
+		"code/b.go\n" +
+		"\n" +
+		"\x1ed4e5f6 1676053921\n" +
+		"\n" +
+		"\x1e0a1b2c 1675967521\n" +
+		"main.go"
+
+	got, err := parseCommitLog([]byte(input))
+	if err != nil {
+		t.Fatalf("unexpected error parsing commit log: %s", err)
+	}
+	want := []*gitdomain.CommitLog{
+		{ID: "a1b2c3", CommitDate: time.Unix(1676140321, 0).UTC(), ChangedFiles: []string{"code/a.go", "code/b.go"}},
+		{ID: "d4e5f6", CommitDate: time.Unix(1676053921, 0).UTC()},
+		{ID: "0a1b2c", CommitDate: time.Unix(1675967521, 0).UTC(), ChangedFiles: []string{"main.go"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected commit log (-want +got):\n%s", diff)
+	}
+
+	if _, err := parseCommitLog([]byte("main.go\n")); err == nil {
+		t.Errorf("expected an error parsing a path without a commit")
+	}
+}
+
 func TestDiffWithSubRepoFiltering(t *testing.T) {
 	ctx := context.Background()
 	ctx = actor.WithActor(ctx, &actor.Actor{
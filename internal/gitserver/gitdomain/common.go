@@ -158,6 +158,13 @@ func (p *ContributorCount) String() string {
 	return fmt.Sprintf("%d %s <%s>", p.Count, p.Name, p.Email)
 }
 
+// A CommitLog is a commit along with the paths it changed.
+type CommitLog struct {
+	ID           api.CommitID
+	CommitDate   time.Time
+	ChangedFiles []string
+}
+
 // A Tag is a VCS tag.
 type Tag struct {
 	Name         string `json:"Name,omitempty"`
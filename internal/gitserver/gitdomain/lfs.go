@@ -0,0 +1,62 @@
+package gitdomain
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+)
+
+// LFSPointerMaxSize is the size in bytes below which a file is considered to
+// be a possible Git LFS pointer. This is the same size used by git-lfs to
+// determine if it is worth parsing a file as a pointer.
+const LFSPointerMaxSize = 1024
+
+var (
+	// oid sha256:d4653571a605ece26e88b83cfcfa2697968ee4b8e97ecf37c9d2715e5f94f5ac
+	lfsOIDRe = lazyregexp.New(`oid sha256:([0-9a-f]{64})`)
+	// size 902
+	lfsSizeRe = lazyregexp.New(`size (\d+)`)
+)
+
+// LFSPointer is the content of a Git LFS pointer file, which is committed in
+// place of the file stored in LFS.
+type LFSPointer struct {
+	// OID is the sha256 of the LFS object in hex.
+	OID string
+
+	// Size is the size of the LFS object in bytes.
+	Size int64
+}
+
+// ParseLFSPointer returns the LFS pointer contained in b, or nil if b is not
+// an LFS pointer.
+func ParseLFSPointer(b []byte) *LFSPointer {
+	if len(b) >= LFSPointerMaxSize {
+		return nil
+	}
+
+	if !bytes.HasPrefix(b, []byte("version https://git-lfs.github.com/spec/v1")) {
+		return nil
+	}
+
+	oid := lfsOIDRe.FindSubmatch(b)
+	if len(oid) < 2 {
+		return nil
+	}
+
+	match := lfsSizeRe.FindSubmatch(b)
+	if len(match) < 2 {
+		return nil
+	}
+
+	size, err := strconv.ParseInt(string(match[1]), 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	return &LFSPointer{
+		OID:  string(oid[1]),
+		Size: size,
+	}
+}
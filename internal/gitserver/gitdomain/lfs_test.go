@@ -0,0 +1,28 @@
+package gitdomain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLFSPointer(t *testing.T) {
+	got := ParseLFSPointer([]byte(`version https://git-lfs.github.com/spec/v1
+oid sha256:d4653571a605ece26e88b83cfcfa2697968ee4b8e97ecf37c9d2715e5f94f5ac
+size 902
+`))
+	assert.Equal(t, &LFSPointer{
+		OID:  "d4653571a605ece26e88b83cfcfa2697968ee4b8e97ecf37c9d2715e5f94f5ac",
+		Size: 902,
+	}, got)
+
+	invalid := []string{
+		"",
+		"version https://git-lfs.github.com/spec/v1",
+		"version https://git-lfs.github.com/spec/v1\nsize 902",
+		"hello world",
+	}
+	for _, content := range invalid {
+		assert.Nil(t, ParseLFSPointer([]byte(content)), "incorrectly parsed %q as a LFS pointer", content)
+	}
+}
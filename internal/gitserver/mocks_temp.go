@@ -53,6 +53,9 @@ type MockClient struct {
 	// CommitGraphFunc is an instance of a mock function object controlling
 	// the behavior of the method CommitGraph.
 	CommitGraphFunc *ClientCommitGraphFunc
+	// CommitLogFunc is an instance of a mock function object controlling
+	// the behavior of the method CommitLog.
+	CommitLogFunc *ClientCommitLogFunc
 	// CommitsFunc is an instance of a mock function object controlling the
 	// behavior of the method Commits.
 	CommitsFunc *ClientCommitsFunc
@@ -176,6 +179,9 @@ type MockClient struct {
 	// SearchFunc is an instance of a mock function object controlling the
 	// behavior of the method Search.
 	SearchFunc *ClientSearchFunc
+	// SearchHistoryFunc is an instance of a mock function object
+	// controlling the behavior of the method SearchHistory.
+	SearchHistoryFunc *ClientSearchHistoryFunc
 	// StatFunc is an instance of a mock function object controlling the
 	// behavior of the method Stat.
 	StatFunc *ClientStatFunc
@@ -233,6 +239,11 @@ func NewMockClient() *MockClient {
 				return
 			},
 		},
+		CommitLogFunc: &ClientCommitLogFunc{
+			defaultHook: func(context.Context, api.RepoName, time.Time) (r0 []*gitdomain.CommitLog, r1 error) {
+				return
+			},
+		},
 		CommitsFunc: &ClientCommitsFunc{
 			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, CommitsOptions) (r0 []*gitdomain.Commit, r1 error) {
 				return
@@ -438,6 +449,11 @@ func NewMockClient() *MockClient {
 				return
 			},
 		},
+		SearchHistoryFunc: &ClientSearchHistoryFunc{
+			defaultHook: func(context.Context, *protocol.HistorySearchRequest, func([]protocol.HistoryMatch)) (r0 bool, r1 error) {
+				return
+			},
+		},
 		StatFunc: &ClientStatFunc{
 			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string) (r0 fs.FileInfo, r1 error) {
 				return
@@ -500,6 +516,11 @@ func NewStrictMockClient() *MockClient {
 				panic("unexpected invocation of MockClient.CommitGraph")
 			},
 		},
+		CommitLogFunc: &ClientCommitLogFunc{
+			defaultHook: func(context.Context, api.RepoName, time.Time) ([]*gitdomain.CommitLog, error) {
+				panic("unexpected invocation of MockClient.CommitLog")
+			},
+		},
 		CommitsFunc: &ClientCommitsFunc{
 			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, CommitsOptions) ([]*gitdomain.Commit, error) {
 				panic("unexpected invocation of MockClient.Commits")
@@ -705,6 +726,11 @@ func NewStrictMockClient() *MockClient {
 				panic("unexpected invocation of MockClient.Search")
 			},
 		},
+		SearchHistoryFunc: &ClientSearchHistoryFunc{
+			defaultHook: func(context.Context, *protocol.HistorySearchRequest, func([]protocol.HistoryMatch)) (bool, error) {
+				panic("unexpected invocation of MockClient.SearchHistory")
+			},
+		},
 		StatFunc: &ClientStatFunc{
 			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string) (fs.FileInfo, error) {
 				panic("unexpected invocation of MockClient.Stat")
@@ -749,6 +775,9 @@ func NewMockClientFrom(i Client) *MockClient {
 		CommitGraphFunc: &ClientCommitGraphFunc{
 			defaultHook: i.CommitGraph,
 		},
+		CommitLogFunc: &ClientCommitLogFunc{
+			defaultHook: i.CommitLog,
+		},
 		CommitsFunc: &ClientCommitsFunc{
 			defaultHook: i.Commits,
 		},
@@ -872,6 +901,9 @@ func NewMockClientFrom(i Client) *MockClient {
 		SearchFunc: &ClientSearchFunc{
 			defaultHook: i.Search,
 		},
+		SearchHistoryFunc: &ClientSearchHistoryFunc{
+			defaultHook: i.SearchHistory,
+		},
 		StatFunc: &ClientStatFunc{
 			defaultHook: i.Stat,
 		},
@@ -1878,6 +1910,116 @@ func (c ClientCommitGraphFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// ClientCommitLogFunc describes the behavior when the CommitLog method of
+// the parent MockClient instance is invoked.
+type ClientCommitLogFunc struct {
+	defaultHook func(context.Context, api.RepoName, time.Time) ([]*gitdomain.CommitLog, error)
+	hooks       []func(context.Context, api.RepoName, time.Time) ([]*gitdomain.CommitLog, error)
+	history     []ClientCommitLogFuncCall
+	mutex       sync.Mutex
+}
+
+// CommitLog delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockClient) CommitLog(v0 context.Context, v1 api.RepoName, v2 time.Time) ([]*gitdomain.CommitLog, error) {
+	r0, r1 := m.CommitLogFunc.nextHook()(v0, v1, v2)
+	m.CommitLogFunc.appendCall(ClientCommitLogFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CommitLog method of
+// the parent MockClient instance is invoked and the hook queue is empty.
+func (f *ClientCommitLogFunc) SetDefaultHook(hook func(context.Context, api.RepoName, time.Time) ([]*gitdomain.CommitLog, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CommitLog method of the parent MockClient instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ClientCommitLogFunc) PushHook(hook func(context.Context, api.RepoName, time.Time) ([]*gitdomain.CommitLog, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ClientCommitLogFunc) SetDefaultReturn(r0 []*gitdomain.CommitLog, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, time.Time) ([]*gitdomain.CommitLog, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ClientCommitLogFunc) PushReturn(r0 []*gitdomain.CommitLog, r1 error) {
+	f.PushHook(func(context.Context, api.RepoName, time.Time) ([]*gitdomain.CommitLog, error) {
+		return r0, r1
+	})
+}
+
+func (f *ClientCommitLogFunc) nextHook() func(context.Context, api.RepoName, time.Time) ([]*gitdomain.CommitLog, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ClientCommitLogFunc) appendCall(r0 ClientCommitLogFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ClientCommitLogFuncCall objects describing
+// the invocations of this function.
+func (f *ClientCommitLogFunc) History() []ClientCommitLogFuncCall {
+	f.mutex.Lock()
+	history := make([]ClientCommitLogFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ClientCommitLogFuncCall is an object that describes an invocation of
+// method CommitLog on an instance of MockClient.
+type ClientCommitLogFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*gitdomain.CommitLog
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ClientCommitLogFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ClientCommitLogFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // ClientCommitsFunc describes the behavior when the Commits method of the
 // parent MockClient instance is invoked.
 type ClientCommitsFunc struct {
@@ -6523,6 +6665,116 @@ func (c ClientSearchFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// ClientSearchHistoryFunc describes the behavior when the SearchHistory
+// method of the parent MockClient instance is invoked.
+type ClientSearchHistoryFunc struct {
+	defaultHook func(context.Context, *protocol.HistorySearchRequest, func([]protocol.HistoryMatch)) (bool, error)
+	hooks       []func(context.Context, *protocol.HistorySearchRequest, func([]protocol.HistoryMatch)) (bool, error)
+	history     []ClientSearchHistoryFuncCall
+	mutex       sync.Mutex
+}
+
+// SearchHistory delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockClient) SearchHistory(v0 context.Context, v1 *protocol.HistorySearchRequest, v2 func([]protocol.HistoryMatch)) (bool, error) {
+	r0, r1 := m.SearchHistoryFunc.nextHook()(v0, v1, v2)
+	m.SearchHistoryFunc.appendCall(ClientSearchHistoryFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the SearchHistory method
+// of the parent MockClient instance is invoked and the hook queue is empty.
+func (f *ClientSearchHistoryFunc) SetDefaultHook(hook func(context.Context, *protocol.HistorySearchRequest, func([]protocol.HistoryMatch)) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SearchHistory method of the parent MockClient instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ClientSearchHistoryFunc) PushHook(hook func(context.Context, *protocol.HistorySearchRequest, func([]protocol.HistoryMatch)) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ClientSearchHistoryFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, *protocol.HistorySearchRequest, func([]protocol.HistoryMatch)) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ClientSearchHistoryFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, *protocol.HistorySearchRequest, func([]protocol.HistoryMatch)) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *ClientSearchHistoryFunc) nextHook() func(context.Context, *protocol.HistorySearchRequest, func([]protocol.HistoryMatch)) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ClientSearchHistoryFunc) appendCall(r0 ClientSearchHistoryFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ClientSearchHistoryFuncCall objects
+// describing the invocations of this function.
+func (f *ClientSearchHistoryFunc) History() []ClientSearchHistoryFuncCall {
+	f.mutex.Lock()
+	history := make([]ClientSearchHistoryFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ClientSearchHistoryFuncCall is an object that describes an invocation of
+// method SearchHistory on an instance of MockClient.
+type ClientSearchHistoryFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *protocol.HistorySearchRequest
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 func([]protocol.HistoryMatch)
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ClientSearchHistoryFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ClientSearchHistoryFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // ClientStatFunc describes the behavior when the Stat method of the parent
 // MockClient instance is invoked.
 type ClientStatFunc struct {
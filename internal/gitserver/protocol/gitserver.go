@@ -87,6 +87,49 @@ type Signature struct {
 	Date  time.Time
 }
 
+// HistorySearchRequest is a request to search the contents of files across
+// every commit of a revision range.
+type HistorySearchRequest struct {
+	Repo api.RepoName
+
+	// Range is a revision range of the form "from..to". The files at from are
+	// searched first, followed by the files changed by each commit reachable
+	// from to (following first parents only) but not from from.
+	Range string
+
+	// Pattern is the regular expression to search for. It is matched against
+	// each line of the searched files.
+	Pattern    string
+	IgnoreCase bool
+
+	// IncludePatterns are regular expressions which the paths of searched
+	// files must all match.
+	IncludePatterns []string
+	// ExcludePattern is a regular expression which the paths of searched
+	// files must not match.
+	ExcludePattern string
+
+	Limit int
+}
+
+type HistorySearchEventMatches []HistoryMatch
+
+// HistoryMatch is a line matching a HistorySearchRequest.
+type HistoryMatch struct {
+	Path string
+
+	// LineNumber is the zero-based number of the line at Introduced.
+	LineNumber int
+	Line       result.MatchedString
+
+	// Introduced is the first commit of the range containing the line. It is
+	// the commit the range starts at if the line already existed there.
+	Introduced api.CommitID
+	// Removed is the first commit of the range no longer containing the
+	// line. It is empty if the line still exists at the end of the range.
+	Removed api.CommitID `json:",omitempty"`
+}
+
 // ExecRequest is a request to execute a command inside a git repository.
 //
 // Note that this request is deserialized by both gitserver and the frontend's
@@ -227,6 +270,10 @@ type RepoCloneProgress struct {
 	CloneInProgress bool   // whether the repository is currently being cloned
 	CloneProgress   string // a progress message from the running clone command.
 	Cloned          bool   // whether the repository has been cloned successfully
+
+	// MigratingFromShard is the gitserver the repository is being copied from
+	// after the set of gitserver shards changed, if any.
+	MigratingFromShard string `json:",omitempty"`
 }
 
 // RepoCloneProgressResponse is the response to a repository clone progress request
@@ -256,6 +303,10 @@ type CreateCommitFromPatchRequest struct {
 	// GitApplyArgs are the arguments that will be passed to `git apply` along
 	// with `--cached`.
 	GitApplyArgs []string
+	// DryRun specifies whether only the application of the patch onto
+	// BaseCommit should be checked. If set, no commit is created and nothing
+	// is pushed.
+	DryRun bool
 }
 
 // V1CreateCommitFromPatchRequest is the request information needed for creating
@@ -279,6 +330,10 @@ type V1CreateCommitFromPatchRequest struct {
 	// GitApplyArgs are the arguments that will be passed to `git apply` along
 	// with `--cached`.
 	GitApplyArgs []string
+	// DryRun specifies whether only the application of the patch onto
+	// BaseCommit should be checked. If set, no commit is created and nothing
+	// is pushed.
+	DryRun bool
 }
 
 // PatchCommitInfo will be used for commit information when creating a commit from a patch
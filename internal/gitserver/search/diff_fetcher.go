@@ -14,7 +14,8 @@ import (
 // DiffFetcher is a handle to the stdin and stdout of a git diff-tree subprocess
 // started with StartDiffFetcher
 type DiffFetcher struct {
-	dir string
+	dir   string
+	paths []string
 
 	startOnce sync.Once
 	stdin     io.Writer
@@ -25,10 +26,11 @@ type DiffFetcher struct {
 }
 
 // NewDiffFetcher starts a git diff-tree subprocess that waits, listening on stdin
-// for comimt hashes to generate patches for.
-func NewDiffFetcher(dir string) (*DiffFetcher, error) {
+// for comimt hashes to generate patches for. If paths are given, the patches only
+// include the changes to these pathspecs.
+func NewDiffFetcher(dir string, paths ...string) (*DiffFetcher, error) {
 
-	return &DiffFetcher{dir: dir}, nil
+	return &DiffFetcher{dir: dir, paths: paths}, nil
 }
 
 func (d *DiffFetcher) Stop() {
@@ -42,14 +44,18 @@ func (d *DiffFetcher) start() (err error) {
 	d.startOnce.Do(func() {
 		ctx := context.Background()
 		ctx, d.cancel = context.WithCancel(ctx)
-		d.cmd = exec.CommandContext(ctx, "git",
+		args := []string{
 			"diff-tree",
 			"--stdin",          // Read commit hashes from stdin
 			"--no-prefix",      // Do not prefix file names with a/ and b/
 			"-p",               // Output in patch format
 			"--format=format:", // Output only the patch, not any other commit metadata
 			"--root",           // Treat the root commit as a big creation event (otherwise the diff would be empty)
-		)
+		}
+		if len(d.paths) > 0 {
+			args = append(append(args, "--"), d.paths...)
+		}
+		d.cmd = exec.CommandContext(ctx, "git", args...)
 		d.cmd.Dir = d.dir
 
 		var stdoutReader io.ReadCloser
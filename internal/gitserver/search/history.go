@@ -0,0 +1,366 @@
+package search
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/search/casetransform"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// HistorySearcher searches the contents of files across every commit of a
+// revision range. Rather than searching every file at every commit, it
+// searches all files at the start of the range once and then only the files
+// changed by each commit, tracking which matching lines appear and disappear.
+type HistorySearcher struct {
+	RepoDir string
+
+	// Range is a revision range of the form "from..to".
+	Range string
+
+	Pattern      *casetransform.Regexp
+	IncludePaths []*regexp.Regexp
+	ExcludePath  *regexp.Regexp
+
+	// Limit is the maximum number of matching lines reported. Zero means no
+	// limit.
+	Limit int
+
+	lowerBuf []byte
+}
+
+// maxHistoryFileSize is the size of the largest file searched. Larger files
+// are treated as if they were empty.
+const maxHistoryFileSize = 1 << 20
+
+// openLine is a matching line which has not been removed yet.
+type openLine struct {
+	lineNumber int
+	content    string
+	introduced api.CommitID
+	matches    [][]int
+}
+
+// Search calls onMatch for each matching line. Lines removed within the range
+// are reported as soon as they are removed, lines still present at the end of
+// the range once the whole range was searched.
+//
+// Every tracked line is eventually reported, so once as many lines as the
+// limit have been reported or are tracked, new matching lines are dropped and
+// limitHit is returned. The lines already tracked are still followed until the
+// end of the range, as later commits may remove them.
+func (hs *HistorySearcher) Search(ctx context.Context, onMatch func(*protocol.HistoryMatch)) (limitHit bool, err error) {
+	from, _, ok := strings.Cut(hs.Range, "..")
+	if !ok || strings.Contains(hs.Range, "...") {
+		return false, errors.Errorf("invalid revision range %q: expected from..to", hs.Range)
+	}
+	if from == "" {
+		from = "HEAD"
+	}
+
+	base, err := hs.revParse(ctx, from)
+	if err != nil {
+		return false, err
+	}
+	commits, err := hs.revList(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	blobs, err := newBlobReader(ctx, hs.RepoDir)
+	if err != nil {
+		return false, err
+	}
+	defer blobs.Close()
+
+	// state contains the matching lines of every file, keyed by path. Each
+	// line of a file is a separate match, even if several lines have the same
+	// content.
+	state := make(map[string][]*openLine)
+	reported, tracked := 0, 0
+	update := func(commit api.CommitID, path string) error {
+		content, err := blobs.Read(string(commit) + ":" + path)
+		if err != nil {
+			return err
+		}
+
+		before := state[path]
+		after := hs.matchLines(content)
+
+		// A line keeps the commit which introduced it when it moves within the
+		// file, so lines are paired with the open lines of the same content in
+		// order rather than by line number.
+		byContent := make(map[string][]*openLine, len(before))
+		for _, open := range before {
+			byContent[open.content] = append(byContent[open.content], open)
+		}
+		paired := make([]bool, len(after))
+		for i, open := range after {
+			if prev := byContent[open.content]; len(prev) > 0 {
+				open.introduced = prev[0].introduced
+				byContent[open.content] = prev[1:]
+				paired[i] = true
+			}
+		}
+		for _, open := range before {
+			if remaining := byContent[open.content]; len(remaining) > 0 && remaining[0] == open {
+				onMatch(toHistoryMatch(path, open, commit))
+				reported++
+				byContent[open.content] = remaining[1:]
+			}
+		}
+
+		tracked -= len(before)
+		for _, ok := range paired {
+			if ok {
+				tracked++
+			}
+		}
+		kept := after[:0]
+		for i, open := range after {
+			if !paired[i] {
+				if hs.Limit > 0 && reported+tracked >= hs.Limit {
+					limitHit = true
+					continue
+				}
+				open.introduced = commit
+				tracked++
+			}
+			kept = append(kept, open)
+		}
+
+		if len(kept) == 0 {
+			delete(state, path)
+		} else {
+			state[path] = kept
+		}
+		return nil
+	}
+	// done is true once no more lines can be reported.
+	done := func() bool {
+		return limitHit && reported >= hs.Limit
+	}
+
+	paths, err := hs.listFiles(ctx, base)
+	if err != nil {
+		return false, err
+	}
+	for _, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		if err := update(base, path); err != nil {
+			return false, err
+		}
+	}
+
+	prev := base
+	for _, commit := range commits {
+		if done() {
+			return true, nil
+		}
+		paths, err := hs.changedFiles(ctx, prev, commit)
+		if err != nil {
+			return false, err
+		}
+		for _, path := range paths {
+			if err := ctx.Err(); err != nil {
+				return false, err
+			}
+			if err := update(commit, path); err != nil {
+				return false, err
+			}
+		}
+		prev = commit
+	}
+
+	for path, lines := range state {
+		for _, open := range lines {
+			onMatch(toHistoryMatch(path, open, ""))
+		}
+	}
+	return limitHit, nil
+}
+
+func toHistoryMatch(path string, open *openLine, removed api.CommitID) *protocol.HistoryMatch {
+	m := &protocol.HistoryMatch{
+		Path:       path,
+		LineNumber: open.lineNumber,
+		Introduced: open.introduced,
+		Removed:    removed,
+	}
+	m.Line.Content = open.content
+	m.Line.MatchedRanges = matchesToRanges([]byte(open.content), open.matches)
+	return m
+}
+
+// matchLines returns the lines of content matching the pattern in order.
+// Binary files never match.
+func (hs *HistorySearcher) matchLines(content []byte) []*openLine {
+	var lines []*openLine
+	head := content
+	if len(head) > 8000 {
+		head = head[:8000]
+	}
+	if bytes.IndexByte(head, 0) >= 0 {
+		return lines
+	}
+
+	for i, line := range bytes.Split(content, []byte("\n")) {
+		matches := hs.Pattern.FindAllIndex(line, -1, &hs.lowerBuf)
+		if len(matches) == 0 {
+			continue
+		}
+		lines = append(lines, &openLine{lineNumber: i, content: string(line), matches: matches})
+	}
+	return lines
+}
+
+func (hs *HistorySearcher) includePath(path string) bool {
+	for _, re := range hs.IncludePaths {
+		if !re.MatchString(path) {
+			return false
+		}
+	}
+	return hs.ExcludePath == nil || !hs.ExcludePath.MatchString(path)
+}
+
+func (hs *HistorySearcher) revParse(ctx context.Context, rev string) (api.CommitID, error) {
+	out, err := hs.git(ctx, "rev-parse", "--verify", "--end-of-options", rev+"^{commit}")
+	if err != nil {
+		return "", err
+	}
+	return api.CommitID(bytes.TrimSpace(out)), nil
+}
+
+// revList returns the commits of the range, oldest first.
+func (hs *HistorySearcher) revList(ctx context.Context) ([]api.CommitID, error) {
+	out, err := hs.git(ctx, "rev-list", "--reverse", "--first-parent", "--end-of-options", hs.Range)
+	if err != nil {
+		return nil, err
+	}
+	var commits []api.CommitID
+	for _, line := range strings.Fields(string(out)) {
+		commits = append(commits, api.CommitID(line))
+	}
+	return commits, nil
+}
+
+func (hs *HistorySearcher) listFiles(ctx context.Context, commit api.CommitID) ([]string, error) {
+	out, err := hs.git(ctx, "ls-tree", "-r", "-z", "--name-only", string(commit))
+	if err != nil {
+		return nil, err
+	}
+	return hs.filterPaths(out), nil
+}
+
+// changedFiles returns the files which differ between two commits. Since
+// the commits of the range are compared in order, a commit is compared with
+// its first parent except at the start of the range.
+func (hs *HistorySearcher) changedFiles(ctx context.Context, a, b api.CommitID) ([]string, error) {
+	out, err := hs.git(ctx, "diff-tree", "-r", "-z", "--name-only", "--no-renames", string(a), string(b))
+	if err != nil {
+		return nil, err
+	}
+	return hs.filterPaths(out), nil
+}
+
+// filterPaths returns the NUL separated paths of out which are searched.
+// Paths containing newlines can't be passed to git cat-file --batch, so they
+// are never searched.
+func (hs *HistorySearcher) filterPaths(out []byte) []string {
+	var paths []string
+	for _, path := range strings.Split(string(out), "\x00") {
+		if path != "" && !strings.Contains(path, "\n") && hs.includePath(path) {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+func (hs *HistorySearcher) git(ctx context.Context, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = hs.RepoDir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "git %s failed: %s", args[0], bytes.TrimSpace(stderr.Bytes()))
+	}
+	return out, nil
+}
+
+// blobReader reads blobs from a long running `git cat-file --batch` process.
+type blobReader struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Reader
+}
+
+func newBlobReader(ctx context.Context, dir string) (*blobReader, error) {
+	cmd := exec.CommandContext(ctx, "git", "cat-file", "--batch")
+	cmd.Dir = dir
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &blobReader{cmd: cmd, stdin: stdin, stdout: bufio.NewReader(stdout)}, nil
+}
+
+// Read returns the content of the blob named by spec. Missing blobs, e.g.
+// of files deleted by a commit, and blobs larger than maxHistoryFileSize are
+// returned as empty content.
+func (br *blobReader) Read(spec string) ([]byte, error) {
+	// 🚨 SECURITY: cat-file reads one object name per line, so a newline would
+	// let spec name additional objects.
+	if strings.Contains(spec, "\n") {
+		return nil, errors.Errorf("invalid object name %q", spec)
+	}
+	if _, err := io.WriteString(br.stdin, spec+"\n"); err != nil {
+		return nil, err
+	}
+	header, err := br.stdout.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	// The header is either "<spec> missing" or "<oid> <type> <size>".
+	fields := strings.Fields(header)
+	if len(fields) != 3 {
+		return nil, nil
+	}
+	size, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return nil, errors.Wrapf(err, "unexpected cat-file header %q", header)
+	}
+	// The content is followed by a newline.
+	if fields[1] != "blob" || size > maxHistoryFileSize {
+		_, err := br.stdout.Discard(size + 1)
+		return nil, err
+	}
+	content := make([]byte, size+1)
+	if _, err := io.ReadFull(br.stdout, content); err != nil {
+		return nil, err
+	}
+	return content[:size], nil
+}
+
+func (br *blobReader) Close() error {
+	br.stdin.Close()
+	return br.cmd.Wait()
+}
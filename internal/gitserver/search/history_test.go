@@ -0,0 +1,149 @@
+package search
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/search/casetransform"
+)
+
+// historyMatch is a protocol.HistoryMatch that is easy to compare.
+type historyMatch struct {
+	path, line, introduced, removed string
+	lineNumber                      int
+}
+
+// initHistoryRepository returns a repository with a revision range v1.0..v2.0
+// adding and removing matches of `level: \w+`, and a function resolving
+// revisions in it.
+func initHistoryRepository(t *testing.T) (string, func(string) string) {
+	commit := func(msg string) string {
+		return "GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_AUTHOR_NAME=a GIT_AUTHOR_EMAIL=a@a.com git commit -m " + msg
+	}
+	dir := initGitRepository(t,
+		"printf 'name: foo\\nlevel: debug\\n' > config.yaml",
+		"printf 'level: debug\\nlevel: debug\\n' > README.md",
+		"git add -A",
+		commit("v1"),
+		"git tag v1.0",
+		"printf 'name: foo\\nlevel: info\\n' > config.yaml",
+		"git add -A",
+		commit("info"),
+		"printf 'level: warn\\nname: foo\\nlevel: info\\n' > config.yaml",
+		"git add -A",
+		commit("warn"),
+		"git rm -q config.yaml",
+		commit("remove"),
+		"git tag v2.0",
+	)
+
+	revParse := func(rev string) string {
+		out, err := gitCommand(dir, "git", "rev-parse", rev).Output()
+		require.NoError(t, err)
+		return strings.TrimSpace(string(out))
+	}
+	return dir, revParse
+}
+
+// searchHistory returns the sorted matches of hs for `level: \w+`.
+func searchHistory(t *testing.T, hs *HistorySearcher) ([]historyMatch, bool) {
+	re, err := casetransform.CompileRegexp(`level: \w+`, false)
+	require.NoError(t, err)
+	hs.Pattern = re
+
+	var got []*protocol.HistoryMatch
+	limitHit, err := hs.Search(context.Background(), func(m *protocol.HistoryMatch) {
+		got = append(got, m)
+	})
+	require.NoError(t, err)
+
+	var matches []historyMatch
+	for _, m := range got {
+		require.Len(t, m.Line.MatchedRanges, 1)
+		matches = append(matches, historyMatch{m.Path, m.Line.Content, string(m.Introduced), string(m.Removed), m.LineNumber})
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].path != matches[j].path {
+			return matches[i].path < matches[j].path
+		}
+		if matches[i].line != matches[j].line {
+			return matches[i].line < matches[j].line
+		}
+		return matches[i].lineNumber < matches[j].lineNumber
+	})
+	return matches, limitHit
+}
+
+func TestHistorySearcher(t *testing.T) {
+	dir, revParse := initHistoryRepository(t)
+
+	matches, limitHit := searchHistory(t, &HistorySearcher{RepoDir: dir, Range: "v1.0..v2.0"})
+	require.False(t, limitHit)
+	require.Equal(t, []historyMatch{
+		{"README.md", "level: debug", revParse("v1.0"), "", 0},
+		{"README.md", "level: debug", revParse("v1.0"), "", 1},
+		{"config.yaml", "level: debug", revParse("v1.0"), revParse("v2.0~2"), 1},
+		{"config.yaml", "level: info", revParse("v2.0~2"), revParse("v2.0"), 2},
+		{"config.yaml", "level: warn", revParse("v2.0~1"), revParse("v2.0"), 0},
+	}, matches)
+}
+
+func TestHistorySearcherLimit(t *testing.T) {
+	dir, revParse := initHistoryRepository(t)
+
+	// The lines added after the limit was reached are not tracked, but the
+	// removal of the tracked lines is still reported.
+	matches, limitHit := searchHistory(t, &HistorySearcher{RepoDir: dir, Range: "v1.0..v2.0", Limit: 3})
+	require.True(t, limitHit)
+	require.Equal(t, []historyMatch{
+		{"README.md", "level: debug", revParse("v1.0"), "", 0},
+		{"README.md", "level: debug", revParse("v1.0"), "", 1},
+		{"config.yaml", "level: debug", revParse("v1.0"), revParse("v2.0~2"), 1},
+	}, matches)
+
+	matches, limitHit = searchHistory(t, &HistorySearcher{RepoDir: dir, Range: "v1.0..v2.0", Limit: 5})
+	require.False(t, limitHit)
+	require.Len(t, matches, 5)
+}
+
+func TestHistorySearcherInvalidRange(t *testing.T) {
+	dir := initGitRepository(t)
+	re, err := casetransform.CompileRegexp(`x`, false)
+	require.NoError(t, err)
+
+	for _, r := range []string{"v1.0", "v1.0...v2.0"} {
+		hs := &HistorySearcher{RepoDir: dir, Range: r, Pattern: re}
+		_, err := hs.Search(context.Background(), func(*protocol.HistoryMatch) {})
+		require.ErrorContains(t, err, "invalid revision range")
+	}
+}
+
+func TestBlobReader(t *testing.T) {
+	dir := initGitRepository(t,
+		"echo 'level: debug' > small.txt",
+		"head -c 2000000 /dev/zero | tr '\\0' 'x' > large.txt",
+		"git add -A",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_AUTHOR_NAME=a GIT_AUTHOR_EMAIL=a@a.com git commit -m initial",
+	)
+
+	blobs, err := newBlobReader(context.Background(), dir)
+	require.NoError(t, err)
+	defer blobs.Close()
+
+	content, err := blobs.Read("HEAD:large.txt")
+	require.NoError(t, err)
+	require.Empty(t, content)
+
+	// Reading continues after a skipped blob
+	content, err = blobs.Read("HEAD:small.txt")
+	require.NoError(t, err)
+	require.Equal(t, "level: debug\n", string(content))
+
+	_, err = blobs.Read("HEAD:small.txt\nHEAD:large.txt")
+	require.ErrorContains(t, err, "invalid object name")
+}
@@ -89,6 +89,9 @@ type CommitSearcher struct {
 	IncludeDiff          bool
 	IncludeModifiedFiles bool
 	RepoName             api.RepoName
+
+	// Paths, if set, restricts the search to the changes to these pathspecs.
+	Paths []string
 }
 
 // Search runs a search for commits matching the given predicate across the revisions passed in as revisionArgs.
@@ -140,6 +143,9 @@ func (cs *CommitSearcher) gitArgs() []string {
 	if cs.IncludeModifiedFiles {
 		args = append(args, "--name-status")
 	}
+	if len(cs.Paths) > 0 {
+		args = append(append(args, "--"), cs.Paths...)
+	}
 	return args
 }
 
@@ -219,7 +225,7 @@ func getSubRepoFilterFunc(ctx context.Context, checker authz.SubRepoPermissionCh
 
 func (cs *CommitSearcher) runJobs(ctx context.Context, jobs chan job) error {
 	// Create a new diff fetcher subprocess for each worker
-	diffFetcher, err := NewDiffFetcher(cs.RepoDir)
+	diffFetcher, err := NewDiffFetcher(cs.RepoDir, cs.Paths...)
 	if err != nil {
 		return err
 	}
@@ -229,6 +229,28 @@ func TestSearch(t *testing.T) {
 		require.Equal(t, []string{"file2", "file3"}, matches[1].ModifiedFiles)
 		require.Equal(t, []string{"file1"}, matches[2].ModifiedFiles)
 	})
+
+	t.Run("restricted to paths", func(t *testing.T) {
+		query := &protocol.DiffMatches{Expr: "elit"}
+		tree, err := ToMatchTree(query)
+		require.NoError(t, err)
+		searcher := &CommitSearcher{
+			RepoDir:              dir,
+			Query:                tree,
+			IncludeDiff:          true,
+			IncludeModifiedFiles: true,
+			Paths:                []string{":(literal)file2"},
+		}
+		var matches []*protocol.CommitMatch
+		err = searcher.Search(context.Background(), func(match *protocol.CommitMatch) {
+			matches = append(matches, match)
+		})
+		require.NoError(t, err)
+		require.Len(t, matches, 1)
+		require.Equal(t, matches[0].Author.Name, "camden2")
+		require.Equal(t, []string{"file2"}, matches[0].ModifiedFiles)
+		require.NotContains(t, matches[0].Diff.Content, "file3")
+	})
 }
 
 func TestCommitScanner(t *testing.T) {
@@ -43,3 +43,39 @@ func (s StreamSearchDecoder) ReadAll(r io.Reader) error {
 
 	return dec.Err()
 }
+
+type StreamHistorySearchDecoder struct {
+	OnMatches func(protocol.HistorySearchEventMatches)
+	OnDone    func(protocol.SearchEventDone)
+	OnUnknown func(event, data []byte)
+}
+
+func (s StreamHistorySearchDecoder) ReadAll(r io.Reader) error {
+	dec := http.NewDecoder(r)
+
+	for dec.Scan() {
+		event := dec.Event()
+		data := dec.Data()
+
+		if bytes.Equal(event, []byte("matches")) {
+			if s.OnMatches == nil {
+				continue
+			}
+			var e protocol.HistorySearchEventMatches
+			if err := json.Unmarshal(data, &e); err != nil {
+				return errors.Errorf("failed to decode matches payload: %w", err)
+			}
+			s.OnMatches(e)
+		} else if bytes.Equal(event, []byte("done")) {
+			var e protocol.SearchEventDone
+			if err := json.Unmarshal(data, &e); err != nil {
+				return errors.Errorf("failed to decode done payload: %w", err)
+			}
+			s.OnDone(e)
+		} else if s.OnUnknown != nil {
+			s.OnUnknown(event, data)
+		}
+	}
+
+	return dec.Err()
+}
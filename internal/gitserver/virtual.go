@@ -0,0 +1,99 @@
+package gitserver
+
+import (
+	"io/fs"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Virtual repositories share the clone of the repository they are defined on
+// (see types.VirtualRepo). Gitserver resolves them to that clone and rejects
+// commands which could read files outside of the paths of the virtual
+// repository, so the client restricts its commands to these paths and hides
+// the files outside of them from listings.
+
+// virtualRepo returns the definition of repo if it is a virtual repository,
+// nil otherwise.
+func virtualRepo(repo api.RepoName) *types.VirtualRepo {
+	return conf.VirtualRepo(repo)
+}
+
+// withVirtualPathspecs returns args followed by the pathspecs selecting the
+// paths of repo, if repo is a virtual repository and args don't end with
+// pathspecs of their own. args must end with "--".
+func withVirtualPathspecs(repo api.RepoName, args []string) []string {
+	v := virtualRepo(repo)
+	if v == nil || len(args) == 0 || args[len(args)-1] != "--" {
+		return args
+	}
+	for _, p := range v.Paths {
+		args = append(args, string(gitdomain.PathspecLiteral(p)))
+	}
+	return args
+}
+
+// filterVirtualPaths returns the paths which are included in repo.
+func filterVirtualPaths(repo api.RepoName, paths []string) []string {
+	v := virtualRepo(repo)
+	if v == nil {
+		return paths
+	}
+	filtered := paths[:0:0]
+	for _, p := range paths {
+		if v.Includes(p) {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
+
+// filterVirtualFileInfos returns the files which are visible in repo.
+// Directories leading to the paths of a virtual repository are kept so that
+// the tree can be navigated from the root.
+func filterVirtualFileInfos(repo api.RepoName, fis []fs.FileInfo) []fs.FileInfo {
+	v := virtualRepo(repo)
+	if v == nil {
+		return fis
+	}
+	filtered := fis[:0:0]
+	for _, fi := range fis {
+		if v.Includes(fi.Name()) || (fi.IsDir() && v.Visible(fi.Name())) {
+			filtered = append(filtered, fi)
+		}
+	}
+	return filtered
+}
+
+// virtualArchivePathspecs returns the pathspecs to archive repo with. git
+// cannot intersect pathspecs, so only literal pathspecs included in a virtual
+// repository are kept.
+func virtualArchivePathspecs(repo api.RepoName, pathspecs []gitdomain.Pathspec) ([]gitdomain.Pathspec, error) {
+	v := virtualRepo(repo)
+	if v == nil {
+		return pathspecs, nil
+	}
+	if len(pathspecs) == 0 {
+		pathspecs = make([]gitdomain.Pathspec, 0, len(v.Paths))
+		for _, p := range v.Paths {
+			pathspecs = append(pathspecs, gitdomain.PathspecLiteral(p))
+		}
+		return pathspecs, nil
+	}
+
+	const literal = ":(literal)"
+	var filtered []gitdomain.Pathspec
+	for _, ps := range pathspecs {
+		if strings.HasPrefix(string(ps), literal) && v.Includes(strings.TrimPrefix(string(ps), literal)) {
+			filtered = append(filtered, ps)
+		}
+	}
+	if len(filtered) == 0 {
+		return nil, errors.Newf("no pathspec is part of virtual repository %s", repo)
+	}
+	return filtered, nil
+}
@@ -13,7 +13,11 @@ type LuaLib interface {
 }
 
 var defaultAPIs = map[string]LuaLib{
-	"internal_path": libs.Path,
+	"internal_path":   libs.Path,
+	"internal_json":   libs.JSON,
+	"internal_yaml":   libs.YAML,
+	"internal_toml":   libs.TOML,
+	"internal_semver": libs.Semver,
 }
 
 var DefaultGoModules = memo.NewMemoizedConstructor(func() (map[string]lua.LGFunction, error) {
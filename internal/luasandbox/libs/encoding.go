@@ -0,0 +1,153 @@
+package libs
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	lua "github.com/yuin/gopher-lua"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sourcegraph/sourcegraph/internal/luasandbox/util"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var (
+	JSON = decodingAPI{name: "JSON", unmarshal: json.Unmarshal}
+	YAML = decodingAPI{name: "YAML", unmarshal: yaml.Unmarshal}
+	TOML = decodingAPI{name: "TOML", unmarshal: toml.Unmarshal}
+)
+
+const (
+	// maxDocumentSize is the maximum size (in bytes) of a document that can be decoded.
+	maxDocumentSize = 1024 * 1024
+
+	// maxDecodedValues is the maximum number of values (scalars and collections) that
+	// can be created when converting a decoded document into Lua values. This bounds
+	// the memory used by documents that expand on decoding (e.g., YAML aliases).
+	maxDecodedValues = 100_000
+
+	// maxDecodedDepth is the maximum nesting depth of a decoded document.
+	maxDecodedDepth = 64
+)
+
+type decodingAPI struct {
+	name      string
+	unmarshal func(data []byte, v any) error
+}
+
+func (api decodingAPI) LuaAPI() map[string]lua.LGFunction {
+	return map[string]lua.LGFunction{
+		"decode": util.WrapLuaFunction(func(state *lua.LState) error {
+			value, err := api.decode(state, state.CheckString(1))
+			if err != nil {
+				return err
+			}
+
+			state.Push(value)
+			return nil
+		}),
+	}
+}
+
+// decode unmarshals the given document and converts the result into Lua values.
+func (api decodingAPI) decode(state *lua.LState, document string) (lua.LValue, error) {
+	if len(document) > maxDocumentSize {
+		return nil, errors.Newf("%s document exceeds maximum size of %d bytes", api.name, maxDocumentSize)
+	}
+
+	var value any
+	if err := api.unmarshal([]byte(document), &value); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode %s", api.name)
+	}
+
+	return (&luaConverter{state: state}).convert(value, 0)
+}
+
+// luaConverter converts decoded Go values into Lua values while enforcing the limits
+// on the size of the decoded document.
+type luaConverter struct {
+	state  *lua.LState
+	values int
+}
+
+func (c *luaConverter) convert(value any, depth int) (lua.LValue, error) {
+	if depth >= maxDecodedDepth {
+		return nil, errors.Newf("decoded document exceeds maximum depth of %d", maxDecodedDepth)
+	}
+
+	c.values++
+	if c.values > maxDecodedValues {
+		return nil, errors.Newf("decoded document exceeds maximum of %d values", maxDecodedValues)
+	}
+
+	// Periodically check that the script has not exceeded its deadline
+	if c.values%1000 == 0 {
+		if ctx := c.state.Context(); ctx != nil && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return lua.LNil, nil
+	case bool:
+		return lua.LBool(v), nil
+	case string:
+		return lua.LString(v), nil
+	case time.Time:
+		return lua.LString(v.Format(time.RFC3339Nano)), nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return lua.LNumber(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return lua.LNumber(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return lua.LNumber(rv.Float()), nil
+
+	case reflect.Slice, reflect.Array:
+		table := c.state.NewTable()
+		for i := 0; i < rv.Len(); i++ {
+			element, err := c.convert(rv.Index(i).Interface(), depth+1)
+			if err != nil {
+				return nil, err
+			}
+
+			// Set by index (rather than appending) so that null elements are not skipped
+			table.RawSetInt(i+1, element)
+		}
+
+		return table, nil
+
+	case reflect.Map:
+		keys := make([]string, 0, rv.Len())
+		valuesByKey := make(map[string]reflect.Value, rv.Len())
+		for iter := rv.MapRange(); iter.Next(); {
+			key := fmt.Sprint(iter.Key().Interface())
+			keys = append(keys, key)
+			valuesByKey[key] = iter.Value()
+		}
+		// Convert in a deterministic order so that limits are hit consistently
+		sort.Strings(keys)
+
+		table := c.state.NewTable()
+		for _, key := range keys {
+			element, err := c.convert(valuesByKey[key].Interface(), depth+1)
+			if err != nil {
+				return nil, err
+			}
+
+			table.RawSetString(key, element)
+		}
+
+		return table, nil
+	}
+
+	return nil, errors.Newf("unsupported decoded value of type %T", value)
+}
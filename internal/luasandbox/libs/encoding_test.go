@@ -0,0 +1,116 @@
+package libs
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestDecode(t *testing.T) {
+	testCases := []struct {
+		api      decodingAPI
+		document string
+	}{
+		{JSON, `{"name": "foo", "version": 3, "workspaces": ["a", "b"], "private": true, "main": null}`},
+		{YAML, "name: foo\nversion: 3\nworkspaces:\n  - a\n  - b\nprivate: true\nmain: ~\n"},
+		{TOML, "name = \"foo\"\nversion = 3\nworkspaces = [\"a\", \"b\"]\nprivate = true\n"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.api.name, func(t *testing.T) {
+			state := lua.NewState()
+			defer state.Close()
+
+			value, err := testCase.api.decode(state, testCase.document)
+			if err != nil {
+				t.Fatalf("unexpected error decoding document: %s", err)
+			}
+
+			table, ok := value.(*lua.LTable)
+			if !ok {
+				t.Fatalf("unexpected value type: want=*lua.LTable got=%T", value)
+			}
+
+			expected := map[string]string{
+				"name":         "foo",
+				"version":      "3",
+				"workspaces.1": "a",
+				"workspaces.2": "b",
+				"private":      "true",
+				"main":         "nil",
+			}
+			actual := map[string]string{
+				"name":         table.RawGetString("name").String(),
+				"version":      table.RawGetString("version").String(),
+				"workspaces.1": table.RawGetString("workspaces").(*lua.LTable).RawGetInt(1).String(),
+				"workspaces.2": table.RawGetString("workspaces").(*lua.LTable).RawGetInt(2).String(),
+				"private":      table.RawGetString("private").String(),
+				"main":         table.RawGetString("main").String(),
+			}
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("unexpected decoded values (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeNullArrayElements(t *testing.T) {
+	state := lua.NewState()
+	defer state.Close()
+
+	value, err := JSON.decode(state, `[null, "a"]`)
+	if err != nil {
+		t.Fatalf("unexpected error decoding document: %s", err)
+	}
+
+	if element := value.(*lua.LTable).RawGetInt(2); element.String() != "a" {
+		t.Errorf("unexpected second element: want=%q got=%q", "a", element.String())
+	}
+}
+
+func TestDecodeLimits(t *testing.T) {
+	testCases := []struct {
+		name     string
+		document string
+		expected string
+	}{
+		{"invalid", `{`, "failed to decode JSON"},
+		{"size", `"` + strings.Repeat("a", maxDocumentSize) + `"`, "exceeds maximum size"},
+		{"depth", strings.Repeat("[", maxDecodedDepth+1) + strings.Repeat("]", maxDecodedDepth+1), "exceeds maximum depth"},
+		{"values", "[" + strings.Repeat("1,", maxDecodedValues) + "1]", "exceeds maximum of"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			state := lua.NewState()
+			defer state.Close()
+
+			if _, err := JSON.decode(state, testCase.document); err == nil || !strings.Contains(err.Error(), testCase.expected) {
+				t.Errorf("unexpected error: want=%q got=%v", testCase.expected, err)
+			}
+		})
+	}
+}
+
+func TestDecodeYAMLAliasExpansion(t *testing.T) {
+	// Each level references the previous level ten times, expanding to 10^7 values
+	var lines []string
+	lines = append(lines, `a0: &a0 ["x", "x", "x", "x", "x", "x", "x", "x", "x", "x"]`)
+	for i := 1; i < 7; i++ {
+		refs := make([]string, 0, 10)
+		for j := 0; j < 10; j++ {
+			refs = append(refs, fmt.Sprintf("*a%d", i-1))
+		}
+		lines = append(lines, fmt.Sprintf("a%d: &a%d [%s]", i, i, strings.Join(refs, ", ")))
+	}
+
+	state := lua.NewState()
+	defer state.Close()
+
+	if _, err := YAML.decode(state, strings.Join(lines, "\n")); err == nil {
+		t.Fatalf("expected error decoding document")
+	}
+}
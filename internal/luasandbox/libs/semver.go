@@ -0,0 +1,61 @@
+package libs
+
+import (
+	"github.com/Masterminds/semver"
+	lua "github.com/yuin/gopher-lua"
+
+	"github.com/sourcegraph/sourcegraph/internal/luasandbox/util"
+)
+
+var Semver = semverAPI{}
+
+type semverAPI struct{}
+
+func (api semverAPI) LuaAPI() map[string]lua.LGFunction {
+	return map[string]lua.LGFunction{
+		"parse": util.WrapLuaFunction(func(state *lua.LState) error {
+			version, err := semver.NewVersion(state.CheckString(1))
+			if err != nil {
+				return err
+			}
+
+			table := state.NewTable()
+			table.RawSetString("major", lua.LNumber(version.Major()))
+			table.RawSetString("minor", lua.LNumber(version.Minor()))
+			table.RawSetString("patch", lua.LNumber(version.Patch()))
+			table.RawSetString("prerelease", lua.LString(version.Prerelease()))
+			table.RawSetString("metadata", lua.LString(version.Metadata()))
+			table.RawSetString("version", lua.LString(version.String()))
+			state.Push(table)
+			return nil
+		}),
+
+		"compare": util.WrapLuaFunction(func(state *lua.LState) error {
+			v1, err := semver.NewVersion(state.CheckString(1))
+			if err != nil {
+				return err
+			}
+			v2, err := semver.NewVersion(state.CheckString(2))
+			if err != nil {
+				return err
+			}
+
+			state.Push(lua.LNumber(v1.Compare(v2)))
+			return nil
+		}),
+
+		"satisfies": util.WrapLuaFunction(func(state *lua.LState) error {
+			version, err := semver.NewVersion(state.CheckString(1))
+			if err != nil {
+				return err
+			}
+			constraint, err := semver.NewConstraint(state.CheckString(2))
+			if err != nil {
+				return err
+			}
+
+			state.Push(lua.LBool(constraint.Check(version)))
+			return nil
+		}),
+	}
+}
@@ -0,0 +1,42 @@
+package libs
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+
+	"github.com/sourcegraph/sourcegraph/internal/luasandbox/util"
+)
+
+func TestSemver(t *testing.T) {
+	state := lua.NewState()
+	defer state.Close()
+	state.PreloadModule("internal_semver", util.CreateModule(Semver.LuaAPI()))
+
+	testCases := []struct {
+		script   string
+		expected string
+	}{
+		{`return require("internal_semver").parse("v1.2.3-rc.1+build").minor`, "2"},
+		{`return require("internal_semver").parse("1.2.3-rc.1+build").prerelease`, "rc.1"},
+		{`return require("internal_semver").compare("1.10.0", "1.9.0")`, "1"},
+		{`return require("internal_semver").compare("1.0.0-alpha", "1.0.0")`, "-1"},
+		{`return require("internal_semver").satisfies("1.4.2", "^1.2")`, "true"},
+		{`return require("internal_semver").satisfies("2.0.0", ">= 1.0, < 2.0")`, "false"},
+	}
+
+	for _, testCase := range testCases {
+		if err := state.DoString(testCase.script); err != nil {
+			t.Fatalf("unexpected error running script %q: %s", testCase.script, err)
+		}
+
+		if value := state.Get(-1).String(); value != testCase.expected {
+			t.Errorf("unexpected value for script %q: want=%s got=%s", testCase.script, testCase.expected, value)
+		}
+		state.Pop(1)
+	}
+
+	if err := state.DoString(`return require("internal_semver").parse("not a version")`); err == nil {
+		t.Errorf("expected error parsing invalid version")
+	}
+}
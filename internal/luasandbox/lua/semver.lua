@@ -0,0 +1,17 @@
+local internal_semver = require "internal_semver"
+
+local M = {}
+
+M.parse = function(version)
+  return internal_semver.parse(version)
+end
+
+M.compare = function(v1, v2)
+  return internal_semver.compare(v1, v2)
+end
+
+M.satisfies = function(version, constraint)
+  return internal_semver.satisfies(version, constraint)
+end
+
+return M
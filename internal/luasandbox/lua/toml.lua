@@ -0,0 +1,9 @@
+local internal_toml = require "internal_toml"
+
+local M = {}
+
+M.decode = function(str)
+  return internal_toml.decode(str)
+end
+
+return M
@@ -0,0 +1,9 @@
+local internal_yaml = require "internal_yaml"
+
+local M = {}
+
+M.decode = function(str)
+  return internal_yaml.decode(str)
+end
+
+return M
@@ -265,3 +265,45 @@ func TestDefaultLuaModulesFilesLoad(t *testing.T) {
 		}
 	}
 }
+
+func TestDefaultModulesDecode(t *testing.T) {
+	ctx := context.Background()
+
+	modules, err := DefaultGoModules.Init()
+	if err != nil {
+		t.Fatalf("unexpected error loading modules: %s", err)
+	}
+	sandbox, err := newService(&observation.TestContext).CreateSandbox(ctx, CreateOptions{
+		GoModules: modules,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating sandbox: %s", err)
+	}
+	defer sandbox.Close()
+
+	script := `
+		local json = require("json")
+		local semver = require("semver")
+		local toml = require("toml")
+		local yaml = require("yaml")
+
+		local cargo = toml.decode('[workspace]\nmembers = ["crates/a", "crates/b"]\n')
+		local pnpm = yaml.decode("packages:\n  - 'packages/*'\n")
+		local pkg = json.decode('{"engines": {"node": ">= 16.0"}}')
+
+		return table.concat({
+			cargo.workspace.members[2],
+			pnpm.packages[1],
+			tostring(semver.satisfies("18.1.0", pkg.engines.node)),
+		}, ",")
+	`
+
+	retValue, err := sandbox.RunScript(ctx, RunOptions{}, script)
+	if err != nil {
+		t.Fatalf("unexpected error running script: %s", err)
+	}
+
+	if value := lua.LVAsString(retValue); value != "crates/b,packages/*,true" {
+		t.Errorf("unexpected value: want=%q got=%q", "crates/b,packages/*,true", value)
+	}
+}
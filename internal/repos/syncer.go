@@ -296,6 +296,15 @@ func (s *Syncer) SyncRepo(ctx context.Context, name api.RepoName, background boo
 		return nil, errors.Wrapf(err, "GetByName failed for %q", name)
 	}
 
+	// Virtual repositories don't exist on the code host. They are synced along
+	// with the repository they are defined on.
+	if conf.VirtualRepo(name) != nil {
+		if repo != nil {
+			return repo, nil
+		}
+		return nil, &database.RepoNotFoundErr{Name: name}
+	}
+
 	codehost := extsvc.CodeHostOf(name, extsvc.PublicCodeHosts...)
 	if codehost == nil {
 		if repo != nil {
@@ -602,28 +611,28 @@ func (s *Syncer) SyncExternalService(
 			continue
 		}
 
-		sourced := res.Repo
+		for _, sourced := range withVirtualRepos(res.Repo) {
+			var diff Diff
+			if diff, err = s.sync(ctx, svc, sourced); err != nil {
+				syncProgress.Errors++
+				logger.Error("failed to sync, skipping", log.String("repo", string(sourced.Name)), log.Error(err))
+				errs = errors.Append(errs, err)
 
-		var diff Diff
-		if diff, err = s.sync(ctx, svc, sourced); err != nil {
-			syncProgress.Errors++
-			logger.Error("failed to sync, skipping", log.String("repo", string(sourced.Name)), log.Error(err))
-			errs = errors.Append(errs, err)
+				continue
+			}
 
-			continue
-		}
+			syncProgress.Added += int32(diff.Added.Len())
+			syncProgress.Removed += int32(diff.Deleted.Len())
+			syncProgress.Modified += int32(diff.Modified.Repos().Len())
+			syncProgress.Unmodified += int32(diff.Unmodified.Len())
 
-		syncProgress.Added += int32(diff.Added.Len())
-		syncProgress.Removed += int32(diff.Deleted.Len())
-		syncProgress.Modified += int32(diff.Modified.Repos().Len())
-		syncProgress.Unmodified += int32(diff.Unmodified.Len())
+			for _, r := range diff.Repos() {
+				seen[r.ID] = struct{}{}
+			}
+			syncProgress.Synced = int32(len(seen))
 
-		for _, r := range diff.Repos() {
-			seen[r.ID] = struct{}{}
+			modified = modified || len(diff.Modified)+len(diff.Added) > 0
 		}
-		syncProgress.Synced = int32(len(seen))
-
-		modified = modified || len(diff.Modified)+len(diff.Added) > 0
 	}
 
 	// We don't delete any repos of site-level external services if there were any
@@ -0,0 +1,35 @@
+package repos
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// withVirtualRepos returns sourced followed by the virtual repositories
+// defined on it. Virtual repositories are synced along with the repository
+// they are defined on, so they are removed once it is no longer sourced.
+func withVirtualRepos(sourced *types.Repo) []*types.Repo {
+	vs := conf.VirtualReposOf(sourced.Name)
+	if len(vs) == 0 {
+		return []*types.Repo{sourced}
+	}
+
+	repos := make([]*types.Repo, 0, len(vs)+1)
+	repos = append(repos, sourced)
+	for _, v := range vs {
+		repos = append(repos, virtualRepo(sourced, v))
+	}
+	return repos
+}
+
+// virtualRepo returns the repository to store for the virtual repository v
+// of parent. It inherits the visibility, metadata and sources of its parent.
+// Its external ID is derived from the parent's, since the code host does not
+// know about virtual repositories.
+func virtualRepo(parent *types.Repo, v *types.VirtualRepo) *types.Repo {
+	r := parent.Clone()
+	r.Name = v.Name
+	r.URI = string(v.Name)
+	r.ExternalRepo.ID = parent.ExternalRepo.ID + "#" + string(v.Name)
+	return r
+}
@@ -0,0 +1,57 @@
+package repos
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestWithVirtualRepos(t *testing.T) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		ExperimentalFeatures: &schema.ExperimentalFeatures{
+			VirtualRepos: []*schema.VirtualRepo{{
+				Name:  "github.com/foo/monorepo-web",
+				Repo:  "github.com/foo/monorepo",
+				Paths: []string{"web"},
+			}},
+		},
+	}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	sourced := &types.Repo{
+		Name:    "github.com/foo/monorepo",
+		URI:     "github.com/foo/monorepo",
+		Private: true,
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          "MDEwOlJlcG9zaXRvcnkx",
+			ServiceType: extsvc.TypeGitHub,
+			ServiceID:   "https://github.com/",
+		},
+	}
+
+	got := withVirtualRepos(sourced)
+	want := []*types.Repo{sourced, {
+		Name:    "github.com/foo/monorepo-web",
+		URI:     "github.com/foo/monorepo-web",
+		Private: true,
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          "MDEwOlJlcG9zaXRvcnkx#github.com/foo/monorepo-web",
+			ServiceType: extsvc.TypeGitHub,
+			ServiceID:   "https://github.com/",
+		},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+
+	other := &types.Repo{Name: "github.com/foo/other"}
+	if got := withVirtualRepos(other); len(got) != 1 || got[0] != other {
+		t.Fatalf("unexpected repos for repository without virtual repositories: %v", got)
+	}
+}
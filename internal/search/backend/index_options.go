@@ -9,6 +9,9 @@ import (
 	"github.com/sourcegraph/zoekt"
 	"golang.org/x/exp/slices"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -53,6 +56,16 @@ type zoektIndexOptions struct {
 	// with new ranks.
 	DocumentRanksVersion string `json:",omitempty"`
 
+	// SparsePaths if non-empty restricts indexing to the files below these
+	// paths. It is set for virtual repositories, which are indexed from the
+	// clone of the repository they are defined on.
+	SparsePaths []string `json:",omitempty"`
+
+	// LFSMaxFileSize if non-zero makes the indexer fetch the content of files
+	// stored with Git LFS which are at most this many bytes, rather than
+	// indexing their LFS pointer files.
+	LFSMaxFileSize int64 `json:",omitempty"`
+
 	// Error if non-empty indicates the request failed for the repo.
 	Error string `json:",omitempty"`
 }
@@ -106,12 +119,13 @@ func GetIndexOptions(
 	sema := make(chan struct{}, 32)
 	results := make([][]byte, len(repos))
 	getSiteConfigRevisions := siteConfigRevisionsRuleFunc(c)
+	lfsRules := conf.NewSearchLFSRules(c)
 
 	for i := range repos {
 		sema <- struct{}{}
 		go func(i int) {
 			defer func() { <-sema }()
-			results[i] = getIndexOptions(c, repos[i], getRepoIndexOptions, getSearchContextRevisions, getSiteConfigRevisions)
+			results[i] = getIndexOptions(c, repos[i], getRepoIndexOptions, getSearchContextRevisions, getSiteConfigRevisions, lfsRules)
 		}(i)
 	}
 
@@ -129,6 +143,7 @@ func getIndexOptions(
 	getRepoIndexOptions func(repoID int32) (*RepoIndexOptions, error),
 	getSearchContextRevisions func(repoID int32) ([]string, error),
 	getSiteConfigRevisions revsRuleFunc,
+	lfsRules conf.SearchLFSRules,
 ) []byte {
 	opts, err := getRepoIndexOptions(repoID)
 	if err != nil {
@@ -146,6 +161,10 @@ func getIndexOptions(
 		Symbols:    getBoolPtr(c.SearchIndexSymbolsEnabled, true),
 
 		DocumentRanksVersion: opts.DocumentRanksVersion,
+
+		SparsePaths: virtualRepoPaths(c, opts.Name),
+
+		LFSMaxFileSize: lfsRules.MaxFileSize(api.RepoName(opts.Name)),
 	}
 
 	// Set of branch names. Always index HEAD
@@ -210,6 +229,19 @@ func getIndexOptions(
 	return marshal(o)
 }
 
+// virtualRepoPaths returns the paths of name if it is a virtual repository.
+func virtualRepoPaths(c *schema.SiteConfiguration, name string) []string {
+	if c == nil || c.ExperimentalFeatures == nil {
+		return nil
+	}
+	for _, v := range c.ExperimentalFeatures.VirtualRepos {
+		if v.Name == name {
+			return types.NewVirtualRepo(api.RepoName(v.Name), api.RepoName(v.Repo), v.Paths).Paths
+		}
+	}
+	return nil
+}
+
 type revsRuleFunc func(*RepoIndexOptions) (revs []string)
 
 func siteConfigRevisionsRuleFunc(c *schema.SiteConfiguration) revsRuleFunc {
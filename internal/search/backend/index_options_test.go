@@ -222,6 +222,43 @@ func TestGetIndexOptions(t *testing.T) {
 			},
 			DocumentRanksVersion: "ranked",
 		},
+	}, {
+		name: "virtual repo",
+		conf: schema.SiteConfiguration{ExperimentalFeatures: &schema.ExperimentalFeatures{
+			VirtualRepos: []*schema.VirtualRepo{{
+				Name:  "repo-01",
+				Repo:  "monorepo",
+				Paths: []string{"/services/foo/", "lib"},
+			}},
+		}},
+		repo: REPO,
+		want: zoektIndexOptions{
+			RepoID:  1,
+			Name:    "repo-01",
+			Symbols: true,
+			Branches: []zoekt.RepositoryBranch{
+				{Name: "HEAD", Version: "!HEAD"},
+			},
+			SparsePaths: []string{"services/foo", "lib"},
+		},
+	}, {
+		name: "lfs",
+		conf: schema.SiteConfiguration{ExperimentalFeatures: &schema.ExperimentalFeatures{
+			SearchLfs: []*schema.SearchLFSRule{{
+				Name:        "^repo-01$",
+				MaxFileSize: 1 << 20,
+			}},
+		}},
+		repo: REPO,
+		want: zoektIndexOptions{
+			RepoID:  1,
+			Name:    "repo-01",
+			Symbols: true,
+			Branches: []zoekt.RepositoryBranch{
+				{Name: "HEAD", Version: "!HEAD"},
+			},
+			LFSMaxFileSize: 1 << 20,
+		},
 	}}
 
 	{
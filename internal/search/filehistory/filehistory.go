@@ -0,0 +1,190 @@
+// Package filehistory implements searching the contents of files across every
+// commit of a revision range, e.g. `type:file rev:v1.0..v2.0`.
+package filehistory
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	searchrepos "github.com/sourcegraph/sourcegraph/internal/search/repos"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/group"
+)
+
+// SearchJob searches the contents of files across the revision ranges of the
+// repositories matched by RepoOpts. Revisions which are not ranges are
+// ignored.
+type SearchJob struct {
+	// Pattern is the regular expression to search for.
+	Pattern         string
+	IsCaseSensitive bool
+	IncludePatterns []string
+	ExcludePattern  string
+
+	RepoOpts    search.RepoOptions
+	Limit       int
+	Concurrency int
+}
+
+// IsRevisionRange returns true if rev is a revision range of the form
+// "from..to" which can be searched by SearchJob.
+func IsRevisionRange(rev string) bool {
+	return strings.Contains(rev, "..") && !strings.Contains(rev, "...")
+}
+
+func (j *SearchJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	searchRepoRev := func(ctx context.Context, repoRev *search.RepositoryRevisions) error {
+		for _, rev := range repoRev.Revs {
+			if !IsRevisionRange(rev) {
+				continue
+			}
+
+			args := &protocol.HistorySearchRequest{
+				Repo:            repoRev.Repo.Name,
+				Range:           rev,
+				Pattern:         j.Pattern,
+				IgnoreCase:      !j.IsCaseSensitive,
+				IncludePatterns: j.IncludePatterns,
+				ExcludePattern:  j.ExcludePattern,
+				Limit:           j.Limit,
+			}
+
+			onMatches := func(in []protocol.HistoryMatch) {
+				stream.Send(streaming.SearchEvent{
+					Results: historyMatchesToFileMatches(repoRev.Repo, in),
+				})
+			}
+
+			limitHit, err := clients.Gitserver.SearchHistory(ctx, args, onMatches)
+			statusMap, limitHit, err := search.HandleRepoSearchResult(repoRev.Repo.ID, repoRev.Revs, limitHit, false, err)
+			stream.Send(streaming.SearchEvent{
+				Stats: streaming.Stats{
+					IsLimitHit: limitHit,
+					Status:     statusMap,
+				},
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	repos := searchrepos.NewResolver(clients.Logger, clients.DB, clients.Gitserver, clients.SearcherURLs, clients.Zoekt)
+	it := repos.Iterator(ctx, j.RepoOpts)
+
+	g := group.New().WithContext(ctx).WithMaxConcurrency(j.Concurrency).WithFirstError()
+
+	for it.Next() {
+		page := it.Current()
+		page.MaybeSendStats(stream)
+
+		for _, repoRev := range page.RepoRevs {
+			repoRev := repoRev
+			g.Go(func(ctx context.Context) error {
+				return searchRepoRev(ctx, repoRev)
+			})
+		}
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return nil, it.Err()
+}
+
+func (j *SearchJob) Name() string {
+	return "FileHistorySearchJob"
+}
+
+func (j *SearchJob) Fields(v job.Verbosity) (res []log.Field) {
+	switch v {
+	case job.VerbosityMax:
+		res = append(res,
+			log.Int("concurrency", j.Concurrency),
+		)
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			log.String("pattern", j.Pattern),
+			log.Bool("isCaseSensitive", j.IsCaseSensitive),
+		)
+		if len(j.IncludePatterns) > 0 {
+			res = append(res, trace.Strings("includePatterns", j.IncludePatterns))
+		}
+		if j.ExcludePattern != "" {
+			res = append(res, log.String("excludePattern", j.ExcludePattern))
+		}
+		res = append(res,
+			trace.Scoped("repoOpts", j.RepoOpts.Tags()...),
+			log.Int("limit", j.Limit),
+		)
+	}
+	return res
+}
+
+func (j *SearchJob) Children() []job.Describer       { return nil }
+func (j *SearchJob) MapChildren(job.MapFunc) job.Job { return j }
+
+// historyMatchesToFileMatches groups the lines of a file which were
+// introduced and removed by the same commits into a single file match.
+func historyMatchesToFileMatches(repo types.MinimalRepo, in []protocol.HistoryMatch) []result.Match {
+	type key struct {
+		path                string
+		introduced, removed api.CommitID
+	}
+
+	var (
+		keys    []key
+		grouped = make(map[key]*result.FileMatch)
+	)
+	for _, m := range in {
+		k := key{m.Path, m.Introduced, m.Removed}
+		fm, ok := grouped[k]
+		if !ok {
+			fm = &result.FileMatch{
+				File: result.File{
+					Repo:     repo,
+					CommitID: m.Introduced,
+					Path:     m.Path,
+				},
+				History: &result.FileMatchHistory{
+					Introduced: m.Introduced,
+					Removed:    m.Removed,
+				},
+			}
+			grouped[k] = fm
+			keys = append(keys, k)
+		}
+
+		start := result.Location{Line: m.LineNumber}
+		fm.ChunkMatches = append(fm.ChunkMatches, result.ChunkMatch{
+			Content:      m.Line.Content,
+			ContentStart: start,
+			Ranges:       m.Line.MatchedRanges.Add(start),
+		})
+	}
+
+	matches := make([]result.Match, 0, len(keys))
+	for _, k := range keys {
+		fm := grouped[k]
+		sort.Slice(fm.ChunkMatches, func(i, j int) bool {
+			return fm.ChunkMatches[i].ContentStart.Line < fm.ChunkMatches[j].ContentStart.Line
+		})
+		matches = append(matches, fm)
+	}
+	return matches
+}
@@ -0,0 +1,77 @@
+package filehistory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestIsRevisionRange(t *testing.T) {
+	for rev, want := range map[string]bool{
+		"v1.0..v2.0":  true,
+		"..v2.0":      true,
+		"v1.0..":      true,
+		"v1.0":        false,
+		"v1.0...v2.0": false,
+		"":            false,
+	} {
+		require.Equal(t, want, IsRevisionRange(rev), rev)
+	}
+}
+
+func TestHistoryMatchesToFileMatches(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/foo/bar"}
+	line := func(content string, start, end int) result.MatchedString {
+		return result.MatchedString{
+			Content: content,
+			MatchedRanges: result.Ranges{{
+				Start: result.Location{Offset: start, Column: start},
+				End:   result.Location{Offset: end, Column: end},
+			}},
+		}
+	}
+
+	got := historyMatchesToFileMatches(repo, []protocol.HistoryMatch{
+		{Path: "a.yaml", LineNumber: 3, Line: line("level: info", 0, 5), Introduced: "c1", Removed: "c3"},
+		{Path: "a.yaml", LineNumber: 1, Line: line("level: warn", 0, 5), Introduced: "c1", Removed: "c3"},
+		{Path: "a.yaml", LineNumber: 1, Line: line("level: warn", 0, 5), Introduced: "c1"},
+	})
+
+	require.Equal(t, []result.Match{
+		&result.FileMatch{
+			File:    result.File{Repo: repo, CommitID: "c1", Path: "a.yaml"},
+			History: &result.FileMatchHistory{Introduced: "c1", Removed: "c3"},
+			ChunkMatches: result.ChunkMatches{{
+				Content:      "level: warn",
+				ContentStart: result.Location{Line: 1},
+				Ranges: result.Ranges{{
+					Start: result.Location{Line: 1},
+					End:   result.Location{Offset: 5, Line: 1, Column: 5},
+				}},
+			}, {
+				Content:      "level: info",
+				ContentStart: result.Location{Line: 3},
+				Ranges: result.Ranges{{
+					Start: result.Location{Line: 3},
+					End:   result.Location{Offset: 5, Line: 3, Column: 5},
+				}},
+			}},
+		},
+		&result.FileMatch{
+			File:    result.File{Repo: repo, CommitID: "c1", Path: "a.yaml"},
+			History: &result.FileMatchHistory{Introduced: "c1"},
+			ChunkMatches: result.ChunkMatches{{
+				Content:      "level: warn",
+				ContentStart: result.Location{Line: 1},
+				Ranges: result.Ranges{{
+					Start: result.Location{Line: 1},
+					End:   result.Location{Offset: 5, Line: 1, Column: 5},
+				}},
+			}},
+		},
+	}, got)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	codeownershipjob "github.com/sourcegraph/sourcegraph/internal/search/codeownership"
 	"github.com/sourcegraph/sourcegraph/internal/search/commit"
+	"github.com/sourcegraph/sourcegraph/internal/search/filehistory"
 	"github.com/sourcegraph/sourcegraph/internal/search/filter"
 	"github.com/sourcegraph/sourcegraph/internal/search/job"
 	"github.com/sourcegraph/sourcegraph/internal/search/keyword"
@@ -68,6 +69,12 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 		children = append(children, j)
 	}
 
+	// Revision ranges can only be searched for a content pattern by gitserver.
+	// fileHistorySearch is reset below if no file history job is built, e.g.
+	// for type:commit or type:diff, which search revision ranges like any
+	// other query.
+	fileHistorySearch := isFileHistorySearch(b)
+
 	// Modify the input query if the user specified `file:contains.content()`
 	fileContainsPatterns := b.FileContainsContent()
 	originalQuery := b
@@ -101,7 +108,21 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 			selector:       selector,
 		}
 
-		if resultTypes.Has(result.TypeFile | result.TypePath) {
+		if fileHistorySearch {
+			// Revision ranges can only be searched by gitserver, the indexes
+			// and searcher only know about single revisions.
+			historyJob, err := newFileHistoryJob(b, resultTypes, repoOptions, inputs.Protocol)
+			if err != nil {
+				return nil, err
+			}
+			if historyJob != nil {
+				addJob(historyJob)
+			} else {
+				fileHistorySearch = false
+			}
+		}
+
+		if !fileHistorySearch && resultTypes.Has(result.TypeFile|result.TypePath) {
 			// Create Global Text Search jobs.
 			if repoUniverseSearch {
 				searchJob, err := builder.newZoektGlobalSearch(search.TextRequest)
@@ -124,7 +145,7 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 			}
 		}
 
-		if resultTypes.Has(result.TypeSymbol) {
+		if !fileHistorySearch && resultTypes.Has(result.TypeSymbol) {
 			// Create Global Symbol Search jobs.
 			if repoUniverseSearch {
 				searchJob, err := builder.newZoektGlobalSearch(search.SymbolRequest)
@@ -166,7 +187,7 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 		})
 	}
 
-	{
+	if !fileHistorySearch {
 		// This block generates a job for all the backend types that cannot
 		// directly use a query.Basic and need to be split into query.Flat
 		// first.
@@ -240,6 +261,57 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 	return basicJob, nil
 }
 
+// isFileHistorySearch returns true if b searches file contents across a
+// revision range like rev:v1.0..v2.0. Queries without a pattern, such as
+// repo:foo rev:v1.0..v2.0, are searched like any other query.
+func isFileHistorySearch(b query.Basic) bool {
+	return b.Pattern != nil && containsRevisionRange(b)
+}
+
+// containsRevisionRange returns true if b searches a revision range like
+// rev:v1.0..v2.0.
+func containsRevisionRange(b query.Basic) bool {
+	repoFilters, _ := b.Repositories()
+	for _, repoFilter := range repoFilters {
+		for _, rev := range repoFilter.Revs {
+			if filehistory.IsRevisionRange(rev.RevSpec) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// newFileHistoryJob returns the job searching file contents across the
+// revision ranges of b. It returns nil if b does not search file contents.
+func newFileHistoryJob(b query.Basic, resultTypes result.Types, repoOptions search.RepoOptions, p search.Protocol) (job.Job, error) {
+	if !resultTypes.Has(result.TypeFile) {
+		if resultTypes.Has(result.TypeCommit) || resultTypes.Has(result.TypeDiff) {
+			return nil, nil
+		}
+		return nil, errors.New("revision ranges can only be searched with type:file, type:commit or type:diff")
+	}
+	if pattern, ok := b.Pattern.(query.Pattern); !ok || pattern.Value == "" {
+		return nil, errors.New("revision ranges can only be searched for a single pattern")
+	}
+
+	patternInfo := toTextPatternInfo(b, resultTypes, p)
+	if patternInfo.IsNegated || patternInfo.IsStructuralPat {
+		return nil, errors.New("revision ranges can only be searched for a regular expression or literal pattern")
+	}
+
+	repoOptions.OnlyCloned = true
+	return &filehistory.SearchJob{
+		Pattern:         patternInfo.Pattern,
+		IsCaseSensitive: patternInfo.IsCaseSensitive,
+		IncludePatterns: patternInfo.IncludePatterns,
+		ExcludePattern:  patternInfo.ExcludePattern,
+		RepoOpts:        repoOptions,
+		Limit:           int(patternInfo.FileMatchLimit),
+		Concurrency:     4,
+	}, nil
+}
+
 // orderRacingJobs ensures that searcher and repo search jobs only ever run
 // sequentially after a Zoekt search has returned all its results.
 func orderRacingJobs(j job.Job) job.Job {
@@ -357,6 +357,94 @@ func TestNewPlanJob(t *testing.T) {
           (REPOSCOMPUTEEXCLUDED
             )
           NoopJob)))))`),
+	}, {
+		query:      `repo:sourcegraph/sourcegraph rev:v1.0..v2.0 type:file test`,
+		protocol:   search.Streaming,
+		searchType: query.SearchTypeRegex,
+		want: autogold.Want("file history", `
+(LOG
+  (ALERT
+    (query . )
+    (originalQuery . )
+    (patternType . regex)
+    (TIMEOUT
+      (timeout . 20s)
+      (LIMIT
+        (limit . 500)
+        (PARALLEL
+          (FILEHISTORYSEARCH
+            (pattern . test)
+            (isCaseSensitive . false)
+            (repoOpts.repoFilters . [sourcegraph/sourcegraph@v1.0..v2.0])(repoOpts.onlyCloned . true)
+            (limit . 500))
+          (REPOSCOMPUTEEXCLUDED
+            (repoOpts.repoFilters . [sourcegraph/sourcegraph@v1.0..v2.0])))))))`),
+	}, {
+		query:      `repo:sourcegraph/sourcegraph rev:v1.0..v2.0 type:diff test`,
+		protocol:   search.Streaming,
+		searchType: query.SearchTypeRegex,
+		want: autogold.Want("diff over revision range", `
+(LOG
+  (ALERT
+    (query . )
+    (originalQuery . )
+    (patternType . regex)
+    (TIMEOUT
+      (timeout . 20s)
+      (LIMIT
+        (limit . 500)
+        (PARALLEL
+          (DIFFSEARCH
+            (query . *protocol.DiffMatches(test))
+            (repoOpts.repoFilters . [sourcegraph/sourcegraph@v1.0..v2.0])(repoOpts.onlyCloned . true)
+            (diff . true)
+            (limit . 500))
+          (REPOSCOMPUTEEXCLUDED
+            (repoOpts.repoFilters . [sourcegraph/sourcegraph@v1.0..v2.0]))
+          NoopJob)))))`),
+	}, {
+		query:      `repo:sourcegraph/sourcegraph rev:v1.0..v2.0 type:commit test`,
+		protocol:   search.Streaming,
+		searchType: query.SearchTypeRegex,
+		want: autogold.Want("commit over revision range", `
+(LOG
+  (ALERT
+    (query . )
+    (originalQuery . )
+    (patternType . regex)
+    (TIMEOUT
+      (timeout . 20s)
+      (LIMIT
+        (limit . 500)
+        (PARALLEL
+          (COMMITSEARCH
+            (query . *protocol.MessageMatches(test))
+            (repoOpts.repoFilters . [sourcegraph/sourcegraph@v1.0..v2.0])(repoOpts.onlyCloned . true)
+            (diff . false)
+            (limit . 500))
+          (REPOSCOMPUTEEXCLUDED
+            (repoOpts.repoFilters . [sourcegraph/sourcegraph@v1.0..v2.0]))
+          NoopJob)))))`),
+	}, {
+		query:      `repo:sourcegraph/sourcegraph rev:v1.0..v2.0`,
+		protocol:   search.Streaming,
+		searchType: query.SearchTypeRegex,
+		want: autogold.Want("revision range without pattern", `
+(LOG
+  (ALERT
+    (query . )
+    (originalQuery . )
+    (patternType . regex)
+    (TIMEOUT
+      (timeout . 20s)
+      (LIMIT
+        (limit . 500)
+        (PARALLEL
+          (REPOSCOMPUTEEXCLUDED
+            (repoOpts.repoFilters . [sourcegraph/sourcegraph@v1.0..v2.0]))
+          (REPOSEARCH
+            (repoOpts.repoFilters . [sourcegraph/sourcegraph@v1.0..v2.0])
+            (repoNamePatterns . [(?i)sourcegraph/sourcegraph])))))))`),
 	}, {
 		query:      `type:diff test`,
 		protocol:   search.Streaming,
@@ -406,12 +406,27 @@ func (r *Resolver) normalizeRepoRefs(
 			// so we could avoid resolving later.
 			revs = append(revs, rev.RevSpec)
 		case rev.RevSpec != "":
-			trimmedRev := strings.TrimPrefix(rev.RevSpec, "^")
-			_, err := r.gitserver.ResolveRevision(ctx, repo.Name, trimmedRev, gitserver.ResolveRevisionOptions{NoEnsureRevision: true})
-			if err != nil {
-				if errors.Is(err, context.DeadlineExceeded) || errors.HasType(err, &gitdomain.BadCommitError{}) {
-					return nil, err
+			// Revision ranges are passed on as-is, but both of their ends
+			// have to exist.
+			trimmedRevs := []string{strings.TrimPrefix(rev.RevSpec, "^")}
+			if from, to, ok := strings.Cut(rev.RevSpec, ".."); ok {
+				trimmedRevs = []string{from, strings.TrimPrefix(to, ".")}
+			}
+			missing := false
+			for _, trimmedRev := range trimmedRevs {
+				if trimmedRev == "" {
+					continue // an empty end of a range is HEAD
+				}
+				_, err := r.gitserver.ResolveRevision(ctx, repo.Name, trimmedRev, gitserver.ResolveRevisionOptions{NoEnsureRevision: true})
+				if err != nil {
+					if errors.Is(err, context.DeadlineExceeded) || errors.HasType(err, &gitdomain.BadCommitError{}) {
+						return nil, err
+					}
+					missing = true
+					break
 				}
+			}
+			if missing {
 				reportMissing(RepoRevSpecs{Repo: repo, Revs: []query.RevisionSpecifier{rev}})
 				continue
 			}
@@ -54,6 +54,21 @@ type FileMatch struct {
 	// Note: this is a pointer since usually this is unset. Pointer is 8 bytes
 	// vs an empty string which is 16 bytes.
 	Debug *string `json:"-"`
+
+	// History is set for matches of a search over a revision range. The
+	// match is against the file at History.Introduced, which is also
+	// CommitID.
+	History *FileMatchHistory `json:"-"`
+}
+
+// FileMatchHistory describes when the lines of a FileMatch were part of a
+// revision range.
+type FileMatchHistory struct {
+	// Introduced is the first commit of the range containing the lines.
+	Introduced api.CommitID
+	// Removed is the first commit of the range no longer containing the
+	// lines. It is empty if they still exist at the end of the range.
+	Removed api.CommitID
 }
 
 func (fm *FileMatch) RepoName() types.MinimalRepo {
@@ -171,6 +186,12 @@ func (fm *FileMatch) Key() Key {
 		k.Rev = *fm.InputRev
 	}
 
+	if fm.History != nil {
+		// Lines introduced by the same commit are different matches if
+		// they were removed by different commits.
+		k.Rev = string(fm.History.Removed)
+	}
+
 	return k
 }
 
@@ -31,6 +31,11 @@ type EventContentMatch struct {
 	LineMatches     []EventLineMatch `json:"lineMatches,omitempty"`
 	ChunkMatches    []ChunkMatch     `json:"chunkMatches,omitempty"`
 	Debug           string           `json:"debug,omitempty"`
+
+	// IntroducedCommit and RemovedCommit are set for matches of a search
+	// over a revision range. See result.FileMatchHistory.
+	IntroducedCommit string `json:"introducedCommit,omitempty"`
+	RemovedCommit    string `json:"removedCommit,omitempty"`
 }
 
 func (e *EventContentMatch) eventMatch() {}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	pathpkg "path"
 	"reflect"
 	"sort"
 	"strings"
@@ -499,6 +500,81 @@ func (rs MinimalRepos) Len() int           { return len(rs) }
 func (rs MinimalRepos) Less(i, j int) bool { return rs[i].ID < rs[j].ID }
 func (rs MinimalRepos) Swap(i, j int)      { rs[i], rs[j] = rs[j], rs[i] }
 
+// VirtualRepo is a repository backed by a set of paths inside another
+// repository, e.g. a single service of a monorepo. It shares the gitserver
+// clone, commits and refs of its parent, but only contains the files below
+// Paths.
+type VirtualRepo struct {
+	// Name is the name of the virtual repository.
+	Name api.RepoName
+	// Parent is the name of the repository the virtual repository is defined
+	// on.
+	Parent api.RepoName
+	// Paths are the cleaned, slash-separated paths relative to the root of
+	// Parent which are part of the virtual repository.
+	Paths []string
+}
+
+// NewVirtualRepo returns a VirtualRepo with normalized paths.
+func NewVirtualRepo(name, parent api.RepoName, paths []string) *VirtualRepo {
+	v := &VirtualRepo{Name: name, Parent: parent, Paths: make([]string, 0, len(paths))}
+	for _, p := range paths {
+		if p = cleanVirtualRepoPath(p); p != "" {
+			v.Paths = append(v.Paths, p)
+		}
+	}
+	return v
+}
+
+// Includes returns true if path is one of the paths of the virtual
+// repository or below one of them.
+func (v *VirtualRepo) Includes(path string) bool {
+	path = cleanVirtualRepoPath(path)
+	for _, p := range v.Paths {
+		if path == p || strings.HasPrefix(path, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// Visible returns true if path is included in the virtual repository or is
+// a directory leading to one of its paths. The repository root is always
+// visible.
+func (v *VirtualRepo) Visible(path string) bool {
+	path = cleanVirtualRepoPath(path)
+	if path == "" || v.Includes(path) {
+		return true
+	}
+	for _, p := range v.Paths {
+		if strings.HasPrefix(p, path+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// PathsBelow returns the paths of the virtual repository which are visible
+// below dir. If dir is included in the virtual repository, it is returned
+// as-is; if it is not visible, nil is returned.
+func (v *VirtualRepo) PathsBelow(dir string) []string {
+	dir = cleanVirtualRepoPath(dir)
+	if dir != "" && v.Includes(dir) {
+		return []string{dir}
+	}
+	var paths []string
+	for _, p := range v.Paths {
+		if dir == "" || strings.HasPrefix(p, dir+"/") {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+func cleanVirtualRepoPath(p string) string {
+	return strings.Trim(pathpkg.Clean("/"+strings.TrimSpace(p)), "/")
+}
+
 type CodeHostRepository struct {
 	Name       string
 	CodeHostID int64
@@ -563,6 +639,51 @@ type GitserverRepo struct {
 	// A log of the different types of corruption that was detected on this repo. The order of the log entries are
 	// stored from most recent to least recent and capped at 10 entries. See LogCorruption on Gitserverrepo store.
 	CorruptionLogs []RepoCorruptionLog
+	// The state of an in-flight copy of this repo between gitserver shards after
+	// the set of shards changed.
+	ShardMigrationState ShardMigrationState
+	// The shard the repo is being copied from, when ShardMigrationState is set.
+	ShardMigrationSource string
+}
+
+// ShardMigrationState tracks a repo being copied from its previous gitserver
+// shard to its new owner after the set of gitserver shards changed.
+type ShardMigrationState string
+
+const (
+	// ShardMigrationStateNone means the repo is not being moved between shards.
+	ShardMigrationStateNone ShardMigrationState = ""
+	// ShardMigrationStatePending means the new owner has queued a copy of the repo
+	// from the previous owner.
+	ShardMigrationStatePending ShardMigrationState = "pending"
+	// ShardMigrationStateCopying means the new owner is fetching the repo from the
+	// previous owner.
+	ShardMigrationStateCopying ShardMigrationState = "copying"
+	// ShardMigrationStateCopied means the new owner has confirmed it holds a full
+	// copy of the repo. The previous owner may now remove its copy.
+	ShardMigrationStateCopied ShardMigrationState = "copied"
+	// ShardMigrationStateFailed means the new owner gave up copying the repo from
+	// the previous owner and will clone it from the code host instead. The previous
+	// owner may now remove its copy.
+	ShardMigrationStateFailed ShardMigrationState = "failed"
+)
+
+// ParseShardMigrationState converts a database value into a ShardMigrationState.
+// Unknown values are treated as ShardMigrationStateNone.
+func ParseShardMigrationState(s string) ShardMigrationState {
+	state := ShardMigrationState(s)
+	switch state {
+	case ShardMigrationStatePending, ShardMigrationStateCopying, ShardMigrationStateCopied, ShardMigrationStateFailed:
+		return state
+	default:
+		return ShardMigrationStateNone
+	}
+}
+
+// InProgress returns true if the new owner still needs the previous owner's copy
+// of the repo.
+func (s ShardMigrationState) InProgress() bool {
+	return s == ShardMigrationStatePending || s == ShardMigrationStateCopying
 }
 
 // RepoCorruptionLog represents a corruption event that has been detected on a repo.
@@ -0,0 +1,43 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVirtualRepo(t *testing.T) {
+	v := NewVirtualRepo("monorepo-foo", "monorepo", []string{"/services/foo/", "lib/../libs/common", " "})
+	assert.Equal(t, []string{"services/foo", "libs/common"}, v.Paths)
+
+	for path, want := range map[string]bool{
+		"":                   false,
+		"services":           false,
+		"services/foo":       true,
+		"/services/foo/":     true,
+		"services/foo/a.go":  true,
+		"services/foobar":    false,
+		"libs/common/x/y.go": true,
+		"README.md":          false,
+	} {
+		assert.Equal(t, want, v.Includes(path), "Includes(%q)", path)
+	}
+
+	for path, want := range map[string]bool{
+		"":                  true,
+		"/":                 true,
+		"services":          true,
+		"services/foo/a.go": true,
+		"services/bar":      false,
+		"libs":              true,
+		"lib":               false,
+		"README.md":         false,
+	} {
+		assert.Equal(t, want, v.Visible(path), "Visible(%q)", path)
+	}
+
+	assert.Equal(t, []string{"services/foo", "libs/common"}, v.PathsBelow(""))
+	assert.Equal(t, []string{"services/foo"}, v.PathsBelow("services"))
+	assert.Equal(t, []string{"services/foo/a.go"}, v.PathsBelow("services/foo/a.go"))
+	assert.Nil(t, v.PathsBelow("services/bar"))
+}
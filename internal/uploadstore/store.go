@@ -5,6 +5,9 @@ import (
 	"io"
 	"time"
 
+	"cloud.google.com/go/storage"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -33,6 +36,14 @@ type Store interface {
 	ExpireObjects(ctx context.Context, prefix string, maxAge time.Duration) error
 }
 
+// IsNotFound returns true if the given error was returned by a store because the
+// requested object does not exist. Depending on the backend, this error is either
+// returned by Get or by the reader it returns.
+func IsNotFound(err error) bool {
+	var noSuchKey *s3types.NoSuchKey
+	return errors.As(err, &noSuchKey) || errors.Is(err, storage.ErrObjectNotExist)
+}
+
 var storeConstructors = map[string]func(ctx context.Context, config Config, operations *Operations) (Store, error){
 	"s3":        newS3FromConfig,
 	"blobstore": newS3FromConfig,
@@ -5,7 +5,11 @@ import (
 	"os"
 	"testing"
 
+	"cloud.google.com/go/storage"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func TestMain(m *testing.M) {
@@ -15,3 +19,16 @@ func TestMain(m *testing.M) {
 	}
 	os.Exit(m.Run())
 }
+
+func TestIsNotFound(t *testing.T) {
+	for err, want := range map[error]bool{
+		errors.Wrap(&s3types.NoSuchKey{}, "failed to get object"):      true,
+		errors.Wrap(storage.ErrObjectNotExist, "failed to get object"): true,
+		errors.Wrap(storage.ErrBucketNotExist, "failed to get object"): false,
+		errors.New("connection reset by peer"):                         false,
+	} {
+		if have := IsNotFound(err); have != want {
+			t.Errorf("unexpected result for %q. want=%v have=%v", err, want, have)
+		}
+	}
+}
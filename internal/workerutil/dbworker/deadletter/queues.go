@@ -0,0 +1,35 @@
+package deadletter
+
+// Queue describes the table backing a dbworker queue whose failed records can be
+// inspected, requeued, and discarded.
+type Queue struct {
+	// Name is the name under which the queue is exposed to site admins.
+	Name string
+
+	// TableName is the name of the table holding the records of the queue. The table must
+	// have the columns expected by the dbworker store.
+	TableName string
+}
+
+// Queues are the dbworker queues whose failed records can be managed by site admins.
+var Queues = []Queue{
+	{Name: "codeintel_upload", TableName: "lsif_uploads"},
+	{Name: "codeintel_index", TableName: "lsif_indexes"},
+	{Name: "batch_spec_resolution", TableName: "batch_spec_resolution_jobs"},
+	{Name: "batch_spec_workspace_execution", TableName: "batch_spec_workspace_execution_jobs"},
+	{Name: "bulk_operation", TableName: "changeset_jobs"},
+	{Name: "code_monitor_trigger", TableName: "cm_trigger_jobs"},
+	{Name: "code_monitor_action", TableName: "cm_action_jobs"},
+	{Name: "permission_sync", TableName: "permission_sync_jobs"},
+}
+
+// QueueByName returns the queue with the given name.
+func QueueByName(name string) (Queue, bool) {
+	for _, queue := range Queues {
+		if queue.Name == name {
+			return queue, true
+		}
+	}
+
+	return Queue{}, false
+}
@@ -0,0 +1,197 @@
+package deadletter
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// Record is a record of a dbworker queue that failed permanently, either because it ran out
+// of retries or because it failed with a non-retryable error.
+type Record struct {
+	ID             int
+	FailureMessage *string
+	NumFailures    int
+	NumResets      int
+	QueuedAt       *time.Time
+	StartedAt      *time.Time
+	FinishedAt     *time.Time
+}
+
+// ListOptions paginates the failed records of a queue.
+type ListOptions struct {
+	Limit  int
+	Offset int
+}
+
+// Store lists, requeues, and discards the failed records of dbworker queues. Discarded
+// records are kept in their tables, but are no longer listed.
+type Store struct {
+	*basestore.Store
+}
+
+// NewStore creates a new dead-letter store with the given database handle.
+func NewStore(handle basestore.TransactableHandle) *Store {
+	return &Store{Store: basestore.NewWithHandle(handle)}
+}
+
+// CountFailed returns the number of failed records of the given queue that have not been
+// discarded.
+func (s *Store) CountFailed(ctx context.Context, queue Queue) (int, error) {
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(
+		countFailedQuery,
+		quote(queue.TableName),
+		queue.TableName,
+	)))
+	return count, err
+}
+
+const countFailedQuery = `
+SELECT COUNT(*)
+FROM %s t
+WHERE
+	t.state = 'failed' AND
+	NOT EXISTS (SELECT 1 FROM workerutil_dead_letter_discards d WHERE d.table_name = %s AND d.record_id = t.id)
+`
+
+// List returns a page of the failed records of the given queue that have not been discarded,
+// most recently failed first, along with the total number of such records.
+func (s *Store) List(ctx context.Context, queue Queue, opts ListOptions) ([]Record, int, error) {
+	return scanRecordsWithCount(s.Query(ctx, sqlf.Sprintf(
+		listQuery,
+		quote(queue.TableName),
+		queue.TableName,
+		opts.Limit,
+		opts.Offset,
+	)))
+}
+
+const listQuery = `
+SELECT
+	t.id,
+	t.failure_message,
+	t.num_failures,
+	t.num_resets,
+	t.queued_at,
+	t.started_at,
+	t.finished_at,
+	COUNT(*) OVER () AS count
+FROM %s t
+WHERE
+	t.state = 'failed' AND
+	NOT EXISTS (SELECT 1 FROM workerutil_dead_letter_discards d WHERE d.table_name = %s AND d.record_id = t.id)
+ORDER BY t.finished_at DESC NULLS LAST, t.id DESC
+LIMIT %s OFFSET %s
+`
+
+// GetByID returns the failed record of the given queue with the given identifier, even if it
+// has been discarded. The boolean flag is false if the record does not exist or did not fail.
+func (s *Store) GetByID(ctx context.Context, queue Queue, id int) (Record, bool, error) {
+	return scanFirstRecord(s.Query(ctx, sqlf.Sprintf(getByIDQuery, quote(queue.TableName), id)))
+}
+
+const getByIDQuery = `
+SELECT
+	id,
+	failure_message,
+	num_failures,
+	num_resets,
+	queued_at,
+	started_at,
+	finished_at
+FROM %s
+WHERE state = 'failed' AND id = %s
+`
+
+// Requeue moves the given failed records of the queue back into the queued state and resets
+// their failure counters, so that they are processed again with a full set of retries. Requeued
+// records are listed again should they fail again, even if they had been discarded. The
+// identifiers of the requeued records are returned; records that did not fail are ignored.
+func (s *Store) Requeue(ctx context.Context, queue Queue, ids []int) ([]int, error) {
+	return basestore.ScanInts(s.Query(ctx, sqlf.Sprintf(
+		requeueQuery,
+		quote(queue.TableName),
+		pq.Array(ids),
+		queue.TableName,
+	)))
+}
+
+const requeueQuery = `
+WITH requeued AS (
+	UPDATE %s
+	SET
+		state = 'queued',
+		queued_at = NOW(),
+		started_at = NULL,
+		finished_at = NULL,
+		process_after = NULL,
+		failure_message = NULL,
+		num_failures = 0,
+		num_resets = 0
+	WHERE state = 'failed' AND id = ANY(%s)
+	RETURNING id
+),
+undiscarded AS (
+	DELETE FROM workerutil_dead_letter_discards
+	WHERE table_name = %s AND record_id IN (SELECT id FROM requeued)
+)
+SELECT id FROM requeued
+`
+
+// Discard hides the given failed records of the queue from the dead-letter view. The records
+// themselves are not modified, so that their owners can still inspect and clean them up. The
+// identifiers of the discarded records are returned; records that did not fail are ignored.
+func (s *Store) Discard(ctx context.Context, queue Queue, ids []int) ([]int, error) {
+	return basestore.ScanInts(s.Query(ctx, sqlf.Sprintf(
+		discardQuery,
+		queue.TableName,
+		quote(queue.TableName),
+		pq.Array(ids),
+	)))
+}
+
+const discardQuery = `
+INSERT INTO workerutil_dead_letter_discards (table_name, record_id)
+SELECT %s, id FROM %s WHERE state = 'failed' AND id = ANY(%s)
+ON CONFLICT DO NOTHING
+RETURNING record_id
+`
+
+func scanRecord(s dbutil.Scanner) (record Record, _ error) {
+	return record, s.Scan(
+		&record.ID,
+		&record.FailureMessage,
+		&record.NumFailures,
+		&record.NumResets,
+		&record.QueuedAt,
+		&record.StartedAt,
+		&record.FinishedAt,
+	)
+}
+
+func scanRecordWithCount(s dbutil.Scanner) (record Record, count int, _ error) {
+	return record, count, s.Scan(
+		&record.ID,
+		&record.FailureMessage,
+		&record.NumFailures,
+		&record.NumResets,
+		&record.QueuedAt,
+		&record.StartedAt,
+		&record.FinishedAt,
+		&count,
+	)
+}
+
+var (
+	scanFirstRecord      = basestore.NewFirstScanner(scanRecord)
+	scanRecordsWithCount = basestore.NewSliceWithCountScanner(scanRecordWithCount)
+)
+
+// quote wraps the given table name in a query fragment. Table names come from the static
+// list of queues, never from user input.
+func quote(s string) *sqlf.Query { return sqlf.Sprintf(s) }
@@ -0,0 +1,196 @@
+package deadletter
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+var testQueue = Queue{Name: "test", TableName: "deadletter_test"}
+
+func TestList(t *testing.T) {
+	db := setupStoreTest(t)
+	store := testStore(db)
+
+	records, totalCount, err := store.List(context.Background(), testQueue, ListOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error listing records: %s", err)
+	}
+	if totalCount != 3 {
+		t.Errorf("unexpected total count. want=%d have=%d", 3, totalCount)
+	}
+	if diff := cmp.Diff([]int{4, 2}, recordIDs(records)); diff != "" {
+		t.Errorf("unexpected records (-want +got):\n%s", diff)
+	}
+
+	records, _, err = store.List(context.Background(), testQueue, ListOptions{Limit: 2, Offset: 2})
+	if err != nil {
+		t.Fatalf("unexpected error listing records: %s", err)
+	}
+	if diff := cmp.Diff([]int{1}, recordIDs(records)); diff != "" {
+		t.Errorf("unexpected records (-want +got):\n%s", diff)
+	}
+
+	count, err := store.CountFailed(context.Background(), testQueue)
+	if err != nil {
+		t.Fatalf("unexpected error counting records: %s", err)
+	}
+	if count != 3 {
+		t.Errorf("unexpected count. want=%d have=%d", 3, count)
+	}
+}
+
+func TestGetByID(t *testing.T) {
+	db := setupStoreTest(t)
+	store := testStore(db)
+
+	record, ok, err := store.GetByID(context.Background(), testQueue, 2)
+	if err != nil {
+		t.Fatalf("unexpected error getting record: %s", err)
+	}
+	if !ok {
+		t.Fatalf("expected record to exist")
+	}
+	if record.FailureMessage == nil || *record.FailureMessage != "oops 2" || record.NumFailures != 3 {
+		t.Errorf("unexpected record: %+v", record)
+	}
+
+	// Records that did not fail are not dead letters
+	if _, ok, err := store.GetByID(context.Background(), testQueue, 3); err != nil {
+		t.Fatalf("unexpected error getting record: %s", err)
+	} else if ok {
+		t.Fatalf("did not expect record to exist")
+	}
+}
+
+func TestRequeue(t *testing.T) {
+	db := setupStoreTest(t)
+	store := testStore(db)
+
+	ids, err := store.Requeue(context.Background(), testQueue, []int{1, 2, 3})
+	if err != nil {
+		t.Fatalf("unexpected error requeueing records: %s", err)
+	}
+	sort.Ints(ids)
+	if diff := cmp.Diff([]int{1, 2}, ids); diff != "" {
+		t.Errorf("unexpected requeued records (-want +got):\n%s", diff)
+	}
+
+	var state string
+	var numFailures int
+	var failureMessage *string
+	if err := db.QueryRow(`SELECT state, num_failures, failure_message FROM deadletter_test WHERE id = 2`).Scan(&state, &numFailures, &failureMessage); err != nil {
+		t.Fatalf("unexpected error querying record: %s", err)
+	}
+	if state != "queued" || numFailures != 0 || failureMessage != nil {
+		t.Errorf("unexpected record. state=%q numFailures=%d failureMessage=%v", state, numFailures, failureMessage)
+	}
+}
+
+func TestDiscard(t *testing.T) {
+	db := setupStoreTest(t)
+	store := testStore(db)
+	ctx := context.Background()
+
+	ids, err := store.Discard(ctx, testQueue, []int{1, 2, 3})
+	if err != nil {
+		t.Fatalf("unexpected error discarding records: %s", err)
+	}
+	sort.Ints(ids)
+	if diff := cmp.Diff([]int{1, 2}, ids); diff != "" {
+		t.Errorf("unexpected discarded records (-want +got):\n%s", diff)
+	}
+
+	// Discarded records are no longer listed
+	records, totalCount, err := store.List(ctx, testQueue, ListOptions{Limit: 10})
+	if err != nil {
+		t.Fatalf("unexpected error listing records: %s", err)
+	}
+	if diff := cmp.Diff([]int{4}, recordIDs(records)); diff != "" {
+		t.Errorf("unexpected records (-want +got):\n%s", diff)
+	}
+	if totalCount != 1 {
+		t.Errorf("unexpected total count. want=%d have=%d", 1, totalCount)
+	}
+	if count, err := store.CountFailed(ctx, testQueue); err != nil {
+		t.Fatalf("unexpected error counting records: %s", err)
+	} else if count != 1 {
+		t.Errorf("unexpected count. want=%d have=%d", 1, count)
+	}
+
+	// Discarded records are kept as they are
+	if _, ok, err := store.GetByID(ctx, testQueue, 1); err != nil {
+		t.Fatalf("unexpected error getting record: %s", err)
+	} else if !ok {
+		t.Fatalf("expected discarded record to exist")
+	}
+
+	// Requeued records are listed again once they fail again
+	if _, err := store.Requeue(ctx, testQueue, []int{2}); err != nil {
+		t.Fatalf("unexpected error requeueing records: %s", err)
+	}
+	if _, err := db.Exec(`UPDATE deadletter_test SET state = 'failed', finished_at = NOW() WHERE id = 2`); err != nil {
+		t.Fatalf("unexpected error failing record: %s", err)
+	}
+	records, _, err = store.List(ctx, testQueue, ListOptions{Limit: 10})
+	if err != nil {
+		t.Fatalf("unexpected error listing records: %s", err)
+	}
+	if diff := cmp.Diff([]int{2, 4}, recordIDs(records)); diff != "" {
+		t.Errorf("unexpected records (-want +got):\n%s", diff)
+	}
+}
+
+func testStore(db *sql.DB) *Store {
+	return NewStore(basestore.NewHandleWithDB(log.NoOp(), db, sql.TxOptions{}))
+}
+
+func setupStoreTest(t *testing.T) *sql.DB {
+	logger := logtest.Scoped(t)
+	db := dbtest.NewDB(logger, t)
+
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS deadletter_test (
+			id              integer NOT NULL,
+			state           text NOT NULL,
+			failure_message text,
+			queued_at       timestamp with time zone,
+			started_at      timestamp with time zone,
+			finished_at     timestamp with time zone,
+			process_after   timestamp with time zone,
+			num_resets      integer NOT NULL default 0,
+			num_failures    integer NOT NULL default 0
+		)
+	`); err != nil {
+		t.Fatalf("unexpected error creating test table: %s", err)
+	}
+
+	if _, err := db.Exec(`
+		INSERT INTO deadletter_test (id, state, failure_message, finished_at, num_failures)
+		VALUES
+			(1, 'failed', 'oops 1', NOW() - '3 minutes'::interval, 1),
+			(2, 'failed', 'oops 2', NOW() - '2 minutes'::interval, 3),
+			(3, 'queued', NULL, NULL, 0),
+			(4, 'failed', 'oops 4', NOW() - '1 minutes'::interval, 3)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	return db
+}
+
+func recordIDs(records []Record) []int {
+	ids := make([]int, 0, len(records))
+	for _, record := range records {
+		ids = append(ids, record.ID)
+	}
+	return ids
+}
@@ -0,0 +1,99 @@
+package jobgraph
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// dependencyRetention is how long the dependencies of finished records are kept.
+const dependencyRetention = 24 * time.Hour
+
+// NewScheduler returns a background routine that periodically resolves the dependencies between
+// dbworker records. Once all dependencies of a record have completed, the store of the record will
+// dequeue it. If any dependency failed, the record is failed as well. The dependencies of records
+// that finished a while ago are deleted.
+func NewScheduler(observationCtx *observation.Context, store *Store, interval time.Duration) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(
+		context.Background(),
+		"workerutil.job-dependency-scheduler",
+		"resolves dependencies between background jobs and fails jobs whose dependencies failed",
+		interval,
+		&scheduler{
+			store:  store,
+			logger: observationCtx.Logger.Scoped("jobDependencyScheduler", "resolves dependencies between background jobs"),
+		},
+	)
+}
+
+type scheduler struct {
+	store  *Store
+	logger log.Logger
+}
+
+var (
+	_ goroutine.Handler      = &scheduler{}
+	_ goroutine.ErrorHandler = &scheduler{}
+)
+
+func (s *scheduler) Handle(ctx context.Context) (err error) {
+	tables, err := s.store.Tables(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		count, resolveErr := s.store.ResolveDependencies(ctx, table)
+		if resolveErr != nil {
+			err = errors.Append(err, errors.Wrapf(resolveErr, "resolving dependencies on %s", table))
+			continue
+		}
+		if count > 0 {
+			s.logger.Debug("Resolved job dependencies", log.String("table", table), log.Int("count", count))
+		}
+	}
+
+	// Re-read the tables, so that jobs whose dependencies failed just now are failed in this run
+	tables, tablesErr := s.store.Tables(ctx)
+	if tablesErr != nil {
+		return errors.Append(err, tablesErr)
+	}
+
+	for _, table := range tables {
+		count, cascadeErr := s.store.CascadeFailures(ctx, table)
+		if cascadeErr != nil {
+			err = errors.Append(err, errors.Wrapf(cascadeErr, "failing dependents in %s", table))
+			continue
+		}
+		if count > 0 {
+			s.logger.Info("Failed jobs with failed dependencies", log.String("table", table), log.Int("count", count))
+		}
+	}
+
+	tables, tablesErr = s.store.JobTables(ctx)
+	if tablesErr != nil {
+		return errors.Append(err, tablesErr)
+	}
+
+	for _, table := range tables {
+		count, deleteErr := s.store.DeleteFinishedDependencies(ctx, table, dependencyRetention)
+		if deleteErr != nil {
+			err = errors.Append(err, errors.Wrapf(deleteErr, "deleting finished dependencies of %s", table))
+			continue
+		}
+		if count > 0 {
+			s.logger.Debug("Deleted finished job dependencies", log.String("table", table), log.Int("count", count))
+		}
+	}
+
+	return err
+}
+
+func (s *scheduler) HandleError(err error) {
+	s.logger.Error("Failed to schedule dependent jobs", log.Error(err))
+}
@@ -0,0 +1,353 @@
+package jobgraph
+
+import (
+	"context"
+	"time"
+
+	"github.com/grafana/regexp"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Job references a record of a dbworker table. Tables taking part in job dependencies must
+// use the default dbworker column names `id`, `state`, `failure_message` and `finished_at`.
+type Job struct {
+	Table string
+	ID    int
+}
+
+const (
+	// StatePending is the state of a dependency that has not finished yet.
+	StatePending = "pending"
+	// StateCompleted is the state of a dependency that completed successfully.
+	StateCompleted = "completed"
+	// StateFailed is the state of a dependency that failed or has been deleted. Jobs with a
+	// failed dependency fail as well.
+	StateFailed = "failed"
+)
+
+// Dependency declares that Job may only be processed once DependsOn has completed.
+type Dependency struct {
+	Job       Job
+	DependsOn Job
+	State     string
+	CreatedAt time.Time
+}
+
+// ErrCycle is returned when adding a dependency would make a job (transitively) depend on
+// itself, in which case none of the involved jobs could ever be processed.
+var ErrCycle = errors.New("job dependency would create a cycle")
+
+// Store manages the dependencies between records of dbworker tables.
+type Store struct {
+	*basestore.Store
+}
+
+// NewStore creates a new job dependency store with the given database handle.
+func NewStore(handle basestore.TransactableHandle) *Store {
+	return &Store{Store: basestore.NewWithHandle(handle)}
+}
+
+func (s *Store) transact(ctx context.Context) (*Store, error) {
+	tx, err := s.Store.Transact(ctx)
+	return &Store{Store: tx}, err
+}
+
+// AddDependencies declares that the given job may only be processed once all of the given
+// dependencies have completed. The job is expected to be enqueued in the same transaction (or
+// after the dependencies were added), and its store must be configured to wait for
+// dependencies. Adding an existing dependency is a no-op.
+func (s *Store) AddDependencies(ctx context.Context, job Job, dependencies ...Job) (err error) {
+	for _, j := range append([]Job{job}, dependencies...) {
+		if err := validateTableName(j.Table); err != nil {
+			return err
+		}
+	}
+
+	tx, err := s.transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	// Serialize concurrent writers so that two transactions cannot each add one half of a cycle
+	if err := tx.Exec(ctx, sqlf.Sprintf(lockDependenciesQuery)); err != nil {
+		return err
+	}
+
+	for _, dependency := range dependencies {
+		if dependency == job {
+			return ErrCycle
+		}
+
+		cycle, _, err := basestore.ScanFirstBool(tx.Query(ctx, sqlf.Sprintf(
+			dependsOnQuery,
+			dependency.Table,
+			dependency.ID,
+			job.Table,
+			job.ID,
+		)))
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return ErrCycle
+		}
+
+		if err := tx.Exec(ctx, sqlf.Sprintf(
+			addDependencyQuery,
+			job.Table,
+			job.ID,
+			dependency.Table,
+			dependency.ID,
+		)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const lockDependenciesQuery = `
+LOCK TABLE workerutil_job_dependencies IN SHARE ROW EXCLUSIVE MODE
+`
+
+// dependsOnQuery determines whether the first job transitively depends on the second job.
+const dependsOnQuery = `
+WITH RECURSIVE ancestors(job_table, job_id) AS (
+	SELECT %s::text, %s::integer
+	UNION
+	SELECT d.dependency_table, d.dependency_id
+	FROM ancestors a
+	JOIN workerutil_job_dependencies d ON d.job_table = a.job_table AND d.job_id = a.job_id
+)
+SELECT EXISTS (SELECT 1 FROM ancestors WHERE job_table = %s AND job_id = %s)
+`
+
+const addDependencyQuery = `
+INSERT INTO workerutil_job_dependencies (job_table, job_id, dependency_table, dependency_id)
+VALUES (%s, %s, %s, %s)
+ON CONFLICT DO NOTHING
+`
+
+// maxGraphSize bounds the number of dependencies returned by Graph.
+const maxGraphSize = 1000
+
+// Graph returns the dependencies of the given job and of its dependents, transitively in both
+// directions, so that the pipeline the job is part of can be visualized.
+func (s *Store) Graph(ctx context.Context, job Job) ([]Dependency, error) {
+	return scanDependencies(s.Query(ctx, sqlf.Sprintf(graphQuery, job.Table, job.ID, job.Table, job.ID, maxGraphSize)))
+}
+
+const graphQuery = `
+WITH RECURSIVE
+ancestors(job_table, job_id) AS (
+	SELECT %s::text, %s::integer
+	UNION
+	SELECT d.dependency_table, d.dependency_id
+	FROM ancestors a
+	JOIN workerutil_job_dependencies d ON d.job_table = a.job_table AND d.job_id = a.job_id
+),
+descendants(job_table, job_id) AS (
+	SELECT %s::text, %s::integer
+	UNION
+	SELECT d.job_table, d.job_id
+	FROM descendants a
+	JOIN workerutil_job_dependencies d ON d.dependency_table = a.job_table AND d.dependency_id = a.job_id
+)
+SELECT d.job_table, d.job_id, d.dependency_table, d.dependency_id, d.dependency_state, d.created_at
+FROM workerutil_job_dependencies d
+WHERE
+	(d.job_table, d.job_id) IN (SELECT job_table, job_id FROM ancestors) OR
+	(d.dependency_table, d.dependency_id) IN (SELECT job_table, job_id FROM descendants)
+ORDER BY d.created_at, d.job_table, d.job_id, d.dependency_table, d.dependency_id
+LIMIT %s
+`
+
+// Tables returns the names of the tables that have records with unresolved dependencies, or that
+// are unresolved dependencies of other records.
+func (s *Store) Tables(ctx context.Context) ([]string, error) {
+	tables, err := basestore.ScanStrings(s.Query(ctx, sqlf.Sprintf(tablesQuery)))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, table := range tables {
+		if err := validateTableName(table); err != nil {
+			return nil, err
+		}
+	}
+
+	return tables, nil
+}
+
+const tablesQuery = `
+SELECT dependency_table FROM workerutil_job_dependencies WHERE dependency_state = 'pending'
+UNION
+SELECT job_table FROM workerutil_job_dependencies WHERE dependency_state = 'failed'
+ORDER BY 1
+`
+
+// ResolveDependencies updates the state of the pending dependencies on records of the given table
+// that have completed or failed. Records that no longer exist are considered failed, while records
+// in any other state (e.g. states specific to the table) remain pending. This method returns the
+// number of resolved dependencies.
+func (s *Store) ResolveDependencies(ctx context.Context, table string) (int, error) {
+	if err := validateTableName(table); err != nil {
+		return 0, err
+	}
+
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(
+		resolveDependenciesQuery,
+		sqlf.Sprintf(table),
+		table,
+		table,
+		sqlf.Sprintf(table),
+	)))
+	return count, err
+}
+
+const resolveDependenciesQuery = `
+WITH
+finished AS (
+	UPDATE workerutil_job_dependencies d
+	SET dependency_state = t.state
+	FROM %s t
+	WHERE
+		d.dependency_table = %s AND
+		d.dependency_id = t.id AND
+		d.dependency_state = 'pending' AND
+		t.state IN ('completed', 'failed')
+	RETURNING 1
+),
+missing AS (
+	UPDATE workerutil_job_dependencies d
+	SET dependency_state = 'failed'
+	WHERE
+		d.dependency_table = %s AND
+		d.dependency_state = 'pending' AND
+		NOT EXISTS (SELECT 1 FROM %s t WHERE t.id = d.dependency_id)
+	RETURNING 1
+)
+SELECT (SELECT COUNT(*) FROM finished) + (SELECT COUNT(*) FROM missing)
+`
+
+// CascadeFailures marks the queued and errored records of the given table that have a failed
+// dependency as failed. Records failed this way fail their own dependents once their dependencies
+// are resolved. This method returns the number of records that were failed.
+func (s *Store) CascadeFailures(ctx context.Context, table string) (int, error) {
+	if err := validateTableName(table); err != nil {
+		return 0, err
+	}
+
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(
+		cascadeFailuresQuery,
+		sqlf.Sprintf(table),
+		table,
+	)))
+	return count, err
+}
+
+const cascadeFailuresQuery = `
+WITH failed AS (
+	UPDATE %s t
+	SET
+		state = 'failed',
+		finished_at = NOW(),
+		failure_message = 'dependency ' || d.dependency_table || ' ' || d.dependency_id || ' failed'
+	FROM workerutil_job_dependencies d
+	WHERE
+		d.job_table = %s AND
+		d.job_id = t.id AND
+		d.dependency_state = 'failed' AND
+		t.state IN ('queued', 'errored')
+	RETURNING 1
+)
+SELECT COUNT(*) FROM failed
+`
+
+// JobTables returns the names of the tables that have records with dependencies.
+func (s *Store) JobTables(ctx context.Context) ([]string, error) {
+	tables, err := basestore.ScanStrings(s.Query(ctx, sqlf.Sprintf(jobTablesQuery)))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, table := range tables {
+		if err := validateTableName(table); err != nil {
+			return nil, err
+		}
+	}
+
+	return tables, nil
+}
+
+const jobTablesQuery = `
+SELECT DISTINCT job_table FROM workerutil_job_dependencies ORDER BY 1
+`
+
+// DeleteFinishedDependencies deletes the dependencies of records of the given table that have
+// completed or failed more than the given duration ago, as well as the dependencies of records that
+// no longer exist. The dependencies of finished records are kept for a while so that the pipeline
+// a record was part of can still be inspected. This method returns the number of deleted dependencies.
+func (s *Store) DeleteFinishedDependencies(ctx context.Context, table string, retention time.Duration) (int, error) {
+	if err := validateTableName(table); err != nil {
+		return 0, err
+	}
+
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(
+		deleteFinishedDependenciesQuery,
+		table,
+		sqlf.Sprintf(table),
+		int(retention/time.Second),
+		sqlf.Sprintf(table),
+	)))
+	return count, err
+}
+
+const deleteFinishedDependenciesQuery = `
+WITH deleted AS (
+	DELETE FROM workerutil_job_dependencies d
+	WHERE
+		d.job_table = %s AND
+		(
+			EXISTS (
+				SELECT 1
+				FROM %s t
+				WHERE
+					t.id = d.job_id AND
+					t.state IN ('completed', 'failed') AND
+					t.finished_at < NOW() - (%s * '1 second'::interval)
+			) OR
+			NOT EXISTS (SELECT 1 FROM %s t WHERE t.id = d.job_id)
+		)
+	RETURNING 1
+)
+SELECT COUNT(*) FROM deleted
+`
+
+var tableNamePattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// validateTableName ensures that the given table name can be safely interpolated into a query.
+func validateTableName(table string) error {
+	if !tableNamePattern.MatchString(table) {
+		return errors.Newf("invalid table name %q", table)
+	}
+	return nil
+}
+
+func scanDependency(s dbutil.Scanner) (dependency Dependency, _ error) {
+	return dependency, s.Scan(
+		&dependency.Job.Table,
+		&dependency.Job.ID,
+		&dependency.DependsOn.Table,
+		&dependency.DependsOn.ID,
+		&dependency.State,
+		&dependency.CreatedAt,
+	)
+}
+
+var scanDependencies = basestore.NewSliceScanner(scanDependency)
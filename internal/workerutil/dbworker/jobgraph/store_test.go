@@ -0,0 +1,200 @@
+package jobgraph
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestAddDependencies(t *testing.T) {
+	db := setupStoreTest(t)
+	store := testStore(db)
+	ctx := context.Background()
+
+	a := Job{Table: "jobgraph_test_a", ID: 1}
+	b := Job{Table: "jobgraph_test_b", ID: 1}
+	c := Job{Table: "jobgraph_test_b", ID: 2}
+
+	if err := store.AddDependencies(ctx, b, a); err != nil {
+		t.Fatalf("unexpected error adding dependencies: %s", err)
+	}
+	if err := store.AddDependencies(ctx, c, a, b); err != nil {
+		t.Fatalf("unexpected error adding dependencies: %s", err)
+	}
+	// Adding an existing dependency is a no-op
+	if err := store.AddDependencies(ctx, c, b); err != nil {
+		t.Fatalf("unexpected error adding dependencies: %s", err)
+	}
+
+	for _, dependency := range []Job{a, c} {
+		if err := store.AddDependencies(ctx, a, dependency); !errors.Is(err, ErrCycle) {
+			t.Errorf("unexpected error adding dependency on %v. want=%q have=%v", dependency, ErrCycle, err)
+		}
+	}
+
+	if err := store.AddDependencies(ctx, Job{Table: "jobs; DROP TABLE repo", ID: 1}, a); err == nil {
+		t.Errorf("expected error adding dependency to invalid table")
+	}
+
+	dependencies, err := store.Graph(ctx, b)
+	if err != nil {
+		t.Fatalf("unexpected error getting graph: %s", err)
+	}
+	if diff := cmp.Diff([][2]Job{{b, a}, {c, a}, {c, b}}, edges(dependencies)); diff != "" {
+		t.Errorf("unexpected graph (-want +got):\n%s", diff)
+	}
+}
+
+func TestResolveDependenciesAndCascadeFailures(t *testing.T) {
+	db := setupStoreTest(t)
+	store := testStore(db)
+	ctx := context.Background()
+
+	if _, err := db.Exec(`
+		INSERT INTO jobgraph_test_a (id, state) VALUES (1, 'completed'), (2, 'failed'), (3, 'processing'), (5, 'deleting');
+		INSERT INTO jobgraph_test_b (id, state) VALUES (1, 'queued'), (2, 'queued'), (3, 'queued'), (4, 'queued'), (5, 'queued');
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	for jobID, dependencyID := range map[int]int{1: 1, 2: 2, 3: 3, 4: 4, 5: 5} {
+		if err := store.AddDependencies(ctx, Job{Table: "jobgraph_test_b", ID: jobID}, Job{Table: "jobgraph_test_a", ID: dependencyID}); err != nil {
+			t.Fatalf("unexpected error adding dependencies: %s", err)
+		}
+	}
+
+	tables, err := store.Tables(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error listing tables: %s", err)
+	}
+	if diff := cmp.Diff([]string{"jobgraph_test_a"}, tables); diff != "" {
+		t.Errorf("unexpected tables (-want +got):\n%s", diff)
+	}
+
+	// Job 1 completed, job 2 failed, job 3 is running, job 4 no longer exists, and job 5 is in a
+	// state that is neither completed nor failed
+	if count, err := store.ResolveDependencies(ctx, "jobgraph_test_a"); err != nil {
+		t.Fatalf("unexpected error resolving dependencies: %s", err)
+	} else if count != 3 {
+		t.Errorf("unexpected number of resolved dependencies. want=%d have=%d", 3, count)
+	}
+
+	if count, err := store.CascadeFailures(ctx, "jobgraph_test_b"); err != nil {
+		t.Fatalf("unexpected error cascading failures: %s", err)
+	} else if count != 2 {
+		t.Errorf("unexpected number of failed jobs. want=%d have=%d", 2, count)
+	}
+
+	states := map[int]string{}
+	rows, err := db.Query(`SELECT id, state FROM jobgraph_test_b`)
+	if err != nil {
+		t.Fatalf("unexpected error querying records: %s", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int
+		var state string
+		if err := rows.Scan(&id, &state); err != nil {
+			t.Fatalf("unexpected error scanning record: %s", err)
+		}
+		states[id] = state
+	}
+
+	expected := map[int]string{1: "queued", 2: "failed", 3: "queued", 4: "failed", 5: "queued"}
+	if diff := cmp.Diff(expected, states); diff != "" {
+		t.Errorf("unexpected states (-want +got):\n%s", diff)
+	}
+}
+
+func TestDeleteFinishedDependencies(t *testing.T) {
+	db := setupStoreTest(t)
+	store := testStore(db)
+	ctx := context.Background()
+
+	if _, err := db.Exec(`
+		INSERT INTO jobgraph_test_b (id, state, finished_at) VALUES
+			(1, 'completed', NOW() - '2 hours'::interval),
+			(2, 'failed', NOW() - '2 hours'::interval),
+			(3, 'completed', NOW() - '10 minutes'::interval),
+			(4, 'queued', NULL),
+			(5, 'errored', NOW() - '2 hours'::interval);
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	dependency := Job{Table: "jobgraph_test_a", ID: 1}
+	for id := 1; id <= 6; id++ {
+		if err := store.AddDependencies(ctx, Job{Table: "jobgraph_test_b", ID: id}, dependency); err != nil {
+			t.Fatalf("unexpected error adding dependencies: %s", err)
+		}
+	}
+
+	tables, err := store.JobTables(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error listing tables: %s", err)
+	}
+	if diff := cmp.Diff([]string{"jobgraph_test_b"}, tables); diff != "" {
+		t.Errorf("unexpected tables (-want +got):\n%s", diff)
+	}
+
+	// Jobs 1 and 2 finished before the retention period, and job 6 no longer exists
+	if count, err := store.DeleteFinishedDependencies(ctx, "jobgraph_test_b", time.Hour); err != nil {
+		t.Fatalf("unexpected error deleting dependencies: %s", err)
+	} else if count != 3 {
+		t.Errorf("unexpected number of deleted dependencies. want=%d have=%d", 3, count)
+	}
+
+	dependencies, err := store.Graph(ctx, dependency)
+	if err != nil {
+		t.Fatalf("unexpected error getting graph: %s", err)
+	}
+	expected := [][2]Job{
+		{{Table: "jobgraph_test_b", ID: 3}, dependency},
+		{{Table: "jobgraph_test_b", ID: 4}, dependency},
+		{{Table: "jobgraph_test_b", ID: 5}, dependency},
+	}
+	if diff := cmp.Diff(expected, edges(dependencies)); diff != "" {
+		t.Errorf("unexpected graph (-want +got):\n%s", diff)
+	}
+}
+
+func testStore(db *sql.DB) *Store {
+	return NewStore(basestore.NewHandleWithDB(log.NoOp(), db, sql.TxOptions{}))
+}
+
+func setupStoreTest(t *testing.T) *sql.DB {
+	logger := logtest.Scoped(t)
+	db := dbtest.NewDB(logger, t)
+
+	for _, table := range []string{"jobgraph_test_a", "jobgraph_test_b"} {
+		if _, err := db.Exec(`
+			CREATE TABLE IF NOT EXISTS ` + table + ` (
+				id              integer NOT NULL,
+				state           text NOT NULL,
+				failure_message text,
+				finished_at     timestamp with time zone
+			)
+		`); err != nil {
+			t.Fatalf("unexpected error creating test table: %s", err)
+		}
+	}
+
+	return db
+}
+
+func edges(dependencies []Dependency) [][2]Job {
+	edges := make([][2]Job, 0, len(dependencies))
+	for _, dependency := range dependencies {
+		edges = append(edges, [2]Job{dependency.Job, dependency.DependsOn})
+	}
+	return edges
+}
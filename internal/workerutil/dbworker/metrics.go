@@ -47,4 +47,44 @@ func InitPrometheusMetric[T workerutil.Record](observationCtx *observation.Conte
 
 		return float64(age) / float64(time.Second)
 	}))
+
+	observationCtx.Registerer.MustRegister(&fairnessKeyCollector[T]{
+		desc: prometheus.NewDesc(
+			fmt.Sprintf("src_%s_queued_by_key_total", teamAndResource),
+			fmt.Sprintf("Number of %s records in the queued state for each fairness key.", resource),
+			[]string{"key"},
+			constLabels,
+		),
+		workerStore: workerStore,
+		logger:      logger,
+	})
+}
+
+// maxFairnessKeysInMetrics is the maximum number of fairness keys reported by the per-key
+// queue depth metric. Only the keys with the deepest queues are reported, which bounds the
+// cardinality of the metric.
+const maxFairnessKeysInMetrics = 20
+
+// fairnessKeyCollector reports the queue depth of each fairness key of a store. Stores
+// without a fairness key do not report any values.
+type fairnessKeyCollector[T workerutil.Record] struct {
+	desc        *prometheus.Desc
+	workerStore store.Store[T]
+	logger      log.Logger
+}
+
+func (c *fairnessKeyCollector[T]) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *fairnessKeyCollector[T]) Collect(ch chan<- prometheus.Metric) {
+	counts, err := c.workerStore.QueuedCountByFairnessKey(context.Background(), maxFairnessKeysInMetrics)
+	if err != nil {
+		c.logger.Error("Failed to determine queue size by fairness key", log.Error(err))
+		return
+	}
+
+	for key, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(count), key)
+	}
 }
@@ -50,6 +50,9 @@ type MockStore[T workerutil.Record] struct {
 	// QueuedCountFunc is an instance of a mock function object controlling
 	// the behavior of the method QueuedCount.
 	QueuedCountFunc *StoreQueuedCountFunc[T]
+	// QueuedCountByFairnessKeyFunc is an instance of a mock function object
+	// controlling the behavior of the method QueuedCountByFairnessKey.
+	QueuedCountByFairnessKeyFunc *StoreQueuedCountByFairnessKeyFunc[T]
 	// RequeueFunc is an instance of a mock function object controlling the
 	// behavior of the method Requeue.
 	RequeueFunc *StoreRequeueFunc[T]
@@ -113,6 +116,11 @@ func NewMockStore[T workerutil.Record]() *MockStore[T] {
 				return
 			},
 		},
+		QueuedCountByFairnessKeyFunc: &StoreQueuedCountByFairnessKeyFunc[T]{
+			defaultHook: func(context.Context, int) (r0 map[string]int, r1 error) {
+				return
+			},
+		},
 		RequeueFunc: &StoreRequeueFunc[T]{
 			defaultHook: func(context.Context, int, time.Time) (r0 error) {
 				return
@@ -185,6 +193,11 @@ func NewStrictMockStore[T workerutil.Record]() *MockStore[T] {
 				panic("unexpected invocation of MockStore.QueuedCount")
 			},
 		},
+		QueuedCountByFairnessKeyFunc: &StoreQueuedCountByFairnessKeyFunc[T]{
+			defaultHook: func(context.Context, int) (map[string]int, error) {
+				panic("unexpected invocation of MockStore.QueuedCountByFairnessKey")
+			},
+		},
 		RequeueFunc: &StoreRequeueFunc[T]{
 			defaultHook: func(context.Context, int, time.Time) error {
 				panic("unexpected invocation of MockStore.Requeue")
@@ -239,6 +252,9 @@ func NewMockStoreFrom[T workerutil.Record](i store.Store[T]) *MockStore[T] {
 		QueuedCountFunc: &StoreQueuedCountFunc[T]{
 			defaultHook: i.QueuedCount,
 		},
+		QueuedCountByFairnessKeyFunc: &StoreQueuedCountByFairnessKeyFunc[T]{
+			defaultHook: i.QueuedCountByFairnessKey,
+		},
 		RequeueFunc: &StoreRequeueFunc[T]{
 			defaultHook: i.Requeue,
 		},
@@ -1240,6 +1256,116 @@ func (c StoreQueuedCountFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreQueuedCountByFairnessKeyFunc describes the behavior when the
+// QueuedCountByFairnessKey method of the parent MockStore instance is
+// invoked.
+type StoreQueuedCountByFairnessKeyFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, int) (map[string]int, error)
+	hooks       []func(context.Context, int) (map[string]int, error)
+	history     []StoreQueuedCountByFairnessKeyFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// QueuedCountByFairnessKey delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore[T]) QueuedCountByFairnessKey(v0 context.Context, v1 int) (map[string]int, error) {
+	r0, r1 := m.QueuedCountByFairnessKeyFunc.nextHook()(v0, v1)
+	m.QueuedCountByFairnessKeyFunc.appendCall(StoreQueuedCountByFairnessKeyFuncCall[T]{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// QueuedCountByFairnessKey method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreQueuedCountByFairnessKeyFunc[T]) SetDefaultHook(hook func(context.Context, int) (map[string]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// QueuedCountByFairnessKey method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreQueuedCountByFairnessKeyFunc[T]) PushHook(hook func(context.Context, int) (map[string]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreQueuedCountByFairnessKeyFunc[T]) SetDefaultReturn(r0 map[string]int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (map[string]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreQueuedCountByFairnessKeyFunc[T]) PushReturn(r0 map[string]int, r1 error) {
+	f.PushHook(func(context.Context, int) (map[string]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreQueuedCountByFairnessKeyFunc[T]) nextHook() func(context.Context, int) (map[string]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreQueuedCountByFairnessKeyFunc[T]) appendCall(r0 StoreQueuedCountByFairnessKeyFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreQueuedCountByFairnessKeyFuncCall
+// objects describing the invocations of this function.
+func (f *StoreQueuedCountByFairnessKeyFunc[T]) History() []StoreQueuedCountByFairnessKeyFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]StoreQueuedCountByFairnessKeyFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreQueuedCountByFairnessKeyFuncCall is an object that describes an
+// invocation of method QueuedCountByFairnessKey on an instance of
+// MockStore.
+type StoreQueuedCountByFairnessKeyFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[string]int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreQueuedCountByFairnessKeyFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreQueuedCountByFairnessKeyFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreRequeueFunc describes the behavior when the Requeue method of the
 // parent MockStore instance is invoked.
 type StoreRequeueFunc[T workerutil.Record] struct {
@@ -9,18 +9,19 @@ import (
 )
 
 type operations struct {
-	addExecutionLogEntry    *observation.Operation
-	dequeue                 *observation.Operation
-	heartbeat               *observation.Operation
-	markComplete            *observation.Operation
-	markErrored             *observation.Operation
-	markFailed              *observation.Operation
-	maxDurationInQueue      *observation.Operation
-	queuedCount             *observation.Operation
-	requeue                 *observation.Operation
-	resetStalled            *observation.Operation
-	updateExecutionLogEntry *observation.Operation
-	canceledJobs            *observation.Operation
+	addExecutionLogEntry     *observation.Operation
+	dequeue                  *observation.Operation
+	heartbeat                *observation.Operation
+	markComplete             *observation.Operation
+	markErrored              *observation.Operation
+	markFailed               *observation.Operation
+	maxDurationInQueue       *observation.Operation
+	queuedCount              *observation.Operation
+	queuedCountByFairnessKey *observation.Operation
+	requeue                  *observation.Operation
+	resetStalled             *observation.Operation
+	updateExecutionLogEntry  *observation.Operation
+	canceledJobs             *observation.Operation
 }
 
 // as newOperations changes based on the store name passed in, and a dbworker store
@@ -58,17 +59,18 @@ func newOperations(observationCtx *observation.Context, storeName string) *opera
 	}
 
 	return &operations{
-		addExecutionLogEntry:    op("AddExecutionLogEntry"),
-		dequeue:                 op("Dequeue"),
-		heartbeat:               op("Heartbeat"),
-		markComplete:            op("MarkComplete"),
-		markErrored:             op("MarkErrored"),
-		markFailed:              op("MarkFailed"),
-		maxDurationInQueue:      op("MaxDurationInQueue"),
-		queuedCount:             op("QueuedCount"),
-		requeue:                 op("Requeue"),
-		resetStalled:            op("ResetStalled"),
-		updateExecutionLogEntry: op("UpdateExecutionLogEntry"),
-		canceledJobs:            op("CanceledJobs"),
+		addExecutionLogEntry:     op("AddExecutionLogEntry"),
+		dequeue:                  op("Dequeue"),
+		heartbeat:                op("Heartbeat"),
+		markComplete:             op("MarkComplete"),
+		markErrored:              op("MarkErrored"),
+		markFailed:               op("MarkFailed"),
+		maxDurationInQueue:       op("MaxDurationInQueue"),
+		queuedCount:              op("QueuedCount"),
+		queuedCountByFairnessKey: op("QueuedCountByFairnessKey"),
+		requeue:                  op("Requeue"),
+		resetStalled:             op("ResetStalled"),
+		updateExecutionLogEntry:  op("UpdateExecutionLogEntry"),
+		canceledJobs:             op("CanceledJobs"),
 	}
 }
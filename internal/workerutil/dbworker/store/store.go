@@ -88,6 +88,11 @@ type Store[T workerutil.Record] interface {
 	// MaxDurationInQueue returns the maximum age of queued records in this store. Returns 0 if there are no queued records.
 	MaxDurationInQueue(ctx context.Context) (time.Duration, error)
 
+	// QueuedCountByFairnessKey returns the number of queued and errored records for each value of the
+	// fairness key, limited to the given number of keys with the most records. Returns nil if the store
+	// was not configured with a fairness key.
+	QueuedCountByFairnessKey(ctx context.Context, limit int) (map[string]int, error)
+
 	// Dequeue selects the first queued record matching the given conditions and updates the state to processing. If there
 	// is such a record, it is returned. If there is no such unclaimed record, a nil record and a nil cancel function
 	// will be returned along with a false-valued flag. This method must not be called from within a transaction.
@@ -232,12 +237,53 @@ type Options[T workerutil.Record] struct {
 	//   - the state is 'errored'
 	//   - the failed attempts counter hasn't reached MaxNumRetries
 	//   - the finished_at timestamp was more than RetryAfter ago
+	//   - the process_after timestamp, if set, has passed
 	RetryAfter time.Duration
 
+	// RetryBackoffMultiplier, if greater than one, grows the delay between retries of a record exponentially
+	// rather than retrying it every RetryAfter. The nth retry of a record is attempted no sooner than
+	// RetryAfter * RetryBackoffMultiplier^(n-1) after it errored. This has no effect unless RetryAfter is set.
+	RetryBackoffMultiplier float64
+
+	// MaxRetryAfter caps the delay between retries of a record grown by RetryBackoffMultiplier. Setting
+	// this value to zero leaves the delay uncapped.
+	MaxRetryAfter time.Duration
+
+	// RetryJitter is the fraction by which the delay between retries of a record is randomly extended, so
+	// that records that errored at the same time (e.g. during an outage of a dependency) are not all retried
+	// at the same time. A value of 0.2 extends each delay by up to 20%. This has no effect unless RetryAfter
+	// is set.
+	RetryJitter float64
+
 	// MaxNumRetries is the maximum number of times a record can be retried after an explicit failure.
 	// Setting this value to zero will disable retries entirely.
 	MaxNumRetries int
 
+	// FairnessKeyExpression is an optional SQL expression that groups records by the tenant they belong
+	// to, such as a user, repository or namespace. This expression may use the alias provided in
+	// `ViewName`, if one was supplied.
+	//
+	// When set, Dequeue alternates between keys rather than strictly following `OrderByExpression`: the
+	// next record is taken from the key with the fewest records currently being processed, so that a
+	// single key with many queued records cannot starve all others. `OrderByExpression` still determines
+	// the order of records within a key, and breaks ties between keys.
+	FairnessKeyExpression *sqlf.Query
+
+	// MaxConcurrencyPerKey is the maximum number of records with the same fairness key that are processed
+	// at the same time. Setting this value to zero disables the limit. This has no effect when no
+	// `FairnessKeyExpression` is supplied.
+	//
+	// The limit is enforced at dequeue time without locking the other records of a key, so concurrent
+	// dequeues may briefly exceed it. Records of keys at their limit are skipped before the oldest
+	// records of the remaining keys are ranked, so setting a limit also keeps a key with a deep queue
+	// from crowding out the records of other keys.
+	MaxConcurrencyPerKey int
+
+	// WaitForDependencies, if set, prevents Dequeue from selecting records that have dependencies that
+	// have not completed yet. Dependencies between records are declared in the workerutil_job_dependencies
+	// table via the jobgraph package, whose scheduler also fails records whose dependencies failed.
+	WaitForDependencies bool
+
 	// clock is used to mock out the wall clock used for heartbeat updates.
 	clock glock.Clock
 }
@@ -398,6 +444,7 @@ func (s *store[T]) MaxDurationInQueue(ctx context.Context) (_ time.Duration, err
 		retryAfter,
 		now,
 		retryAfter,
+		now,
 	)))
 	if err != nil {
 		return 0, err
@@ -423,12 +470,13 @@ oldest_queued AS (
 oldest_retryable AS (
 	SELECT
 		-- Select when the record was most recently dequeueable
-		{finished_at} + (%s * '1 second'::interval) AS last_queued_at
+		GREATEST({finished_at} + (%s * '1 second'::interval), {process_after}) AS last_queued_at
 	FROM %s
 	WHERE
 		%s > 0 AND
 		{state} = 'errored' AND
-		%s - {finished_at} > (%s * '1 second'::interval)
+		%s - {finished_at} > (%s * '1 second'::interval) AND
+		({process_after} IS NULL OR {process_after} <= %s)
 ),
 oldest_record AS (
 	(
@@ -442,6 +490,40 @@ oldest_record AS (
 SELECT EXTRACT(EPOCH FROM NOW() - last_queued_at)::integer AS age FROM oldest_record
 `
 
+// QueuedCountByFairnessKey returns the number of queued and errored records for each value of the
+// fairness key, limited to the given number of keys with the most records. Returns nil if the store
+// was not configured with a fairness key.
+func (s *store[T]) QueuedCountByFairnessKey(ctx context.Context, limit int) (_ map[string]int, err error) {
+	ctx, _, endObservation := s.operations.queuedCountByFairnessKey.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	if s.options.FairnessKeyExpression == nil {
+		return nil, nil
+	}
+
+	return basestore.NewMapScanner(func(s dbutil.Scanner) (key string, count int, _ error) {
+		err := s.Scan(&key, &count)
+		return key, count, err
+	})(s.Query(ctx, s.formatQuery(
+		queuedCountByFairnessKeyQuery,
+		s.options.FairnessKeyExpression,
+		quote(s.options.ViewName),
+		limit,
+	)))
+}
+
+const queuedCountByFairnessKeyQuery = `
+SELECT
+	COALESCE((%s)::text, '') AS fairness_key,
+	COUNT(*)
+FROM %s
+WHERE
+	{state} IN ('queued', 'errored')
+GROUP BY 1
+ORDER BY 2 DESC, 1
+LIMIT %s
+`
+
 // columnsUpdatedByDequeue are the unmapped column names modified by the dequeue method.
 var columnsUpdatedByDequeue = []string{
 	"state",
@@ -473,6 +555,10 @@ func (s *store[T]) Dequeue(ctx context.Context, workerHostname string, condition
 	now := s.now()
 	retryAfter := int(s.options.RetryAfter / time.Second)
 
+	if s.options.WaitForDependencies {
+		conditions = append(conditions[:len(conditions):len(conditions)], s.formatQuery(waitForDependenciesCondition, s.options.TableName))
+	}
+
 	var (
 		processingExpr     = sqlf.Sprintf("%s", "processing")
 		nowTimestampExpr   = sqlf.Sprintf("%s::timestamp", now)
@@ -494,14 +580,7 @@ func (s *store[T]) Dequeue(ctx context.Context, workerHostname string, condition
 
 	records, err := s.options.Scan(s.Query(ctx, s.formatQuery(
 		dequeueQuery,
-		s.options.OrderByExpression,
-		quote(s.options.ViewName),
-		now,
-		retryAfter,
-		now,
-		retryAfter,
-		makeConditionSuffix(conditions),
-		s.options.OrderByExpression,
+		s.makePotentialCandidatesQuery(now, retryAfter, conditions),
 		quote(s.options.TableName),
 		quote(s.options.TableName),
 		quote(s.options.TableName),
@@ -524,26 +603,7 @@ func (s *store[T]) Dequeue(ctx context.Context, workerHostname string, condition
 }
 
 const dequeueQuery = `
-WITH potential_candidates AS (
-	SELECT
-		{id} AS candidate_id,
-		ROW_NUMBER() OVER (ORDER BY %s) AS order
-	FROM %s
-	WHERE
-		(
-			(
-				{state} = 'queued' AND
-				({process_after} IS NULL OR {process_after} <= %s)
-			) OR (
-				%s > 0 AND
-				{state} = 'errored' AND
-				%s - {finished_at} > (%s * '1 second'::interval)
-			)
-		)
-		%s
-	ORDER BY %s
-	LIMIT 50
-),
+WITH %s,
 candidate AS (
 	SELECT
 		{id} FROM %s
@@ -571,6 +631,159 @@ WHERE
 	{id} IN (SELECT {id} FROM candidate)
 `
 
+// makePotentialCandidatesQuery constructs the CTEs of the dequeue query that select the
+// ordered set of records that may be dequeued next, named potential_candidates.
+func (s *store[T]) makePotentialCandidatesQuery(now time.Time, retryAfter int, conditions []*sqlf.Query) *sqlf.Query {
+	if s.options.FairnessKeyExpression == nil {
+		return s.formatQuery(
+			potentialCandidatesQuery,
+			s.options.OrderByExpression,
+			quote(s.options.ViewName),
+			now,
+			retryAfter,
+			now,
+			retryAfter,
+			now,
+			makeConditionSuffix(conditions),
+			s.options.OrderByExpression,
+		)
+	}
+
+	return s.formatQuery(
+		fairPotentialCandidatesQuery,
+		s.options.FairnessKeyExpression,
+		quote(s.options.ViewName),
+		s.options.FairnessKeyExpression,
+		quote(s.options.ViewName),
+		now,
+		retryAfter,
+		now,
+		retryAfter,
+		now,
+		s.options.MaxConcurrencyPerKey,
+		s.options.FairnessKeyExpression,
+		s.options.MaxConcurrencyPerKey,
+		makeConditionSuffix(conditions),
+		s.options.OrderByExpression,
+		fairCandidateScanLimit,
+		s.options.OrderByExpression,
+		s.options.OrderByExpression,
+		quote(s.options.ViewName),
+		s.options.MaxConcurrencyPerKey,
+		s.options.MaxConcurrencyPerKey,
+	)
+}
+
+const potentialCandidatesQuery = `
+potential_candidates AS (
+	SELECT
+		{id} AS candidate_id,
+		ROW_NUMBER() OVER (ORDER BY %s) AS order
+	FROM %s
+	WHERE
+		(
+			(
+				{state} = 'queued' AND
+				({process_after} IS NULL OR {process_after} <= %s)
+			) OR (
+				%s > 0 AND
+				{state} = 'errored' AND
+				%s - {finished_at} > (%s * '1 second'::interval) AND
+				({process_after} IS NULL OR {process_after} <= %s)
+			)
+		)
+		%s
+	ORDER BY %s
+	LIMIT 50
+)
+`
+
+// fairCandidateScanLimit is the maximum number of queued records of keys below their concurrency
+// limit that are ranked against each other on each fair dequeue.
+const fairCandidateScanLimit = 500
+
+// fairPotentialCandidatesQuery orders records by the number of records of the same fairness
+// key that would be processing once the record is dequeued. This takes the oldest record of
+// the least busy key first, and alternates between keys from there on.
+//
+// To keep the cost of a dequeue independent of the size of the queue, only the oldest queued
+// records of keys that are below their concurrency limit are ranked. Records of keys at their
+// limit are skipped before the scan is bounded, so they cannot fill the scanned window.
+const fairPotentialCandidatesQuery = `
+processing_by_key AS (
+	SELECT
+		%s AS fairness_key,
+		COUNT(*) AS processing
+	FROM %s
+	WHERE {state} = 'processing'
+	GROUP BY 1
+),
+scanned_candidates AS (
+	SELECT
+		{id} AS candidate_id,
+		%s AS fairness_key
+	FROM %s
+	WHERE
+		(
+			(
+				{state} = 'queued' AND
+				({process_after} IS NULL OR {process_after} <= %s)
+			) OR (
+				%s > 0 AND
+				{state} = 'errored' AND
+				%s - {finished_at} > (%s * '1 second'::interval) AND
+				({process_after} IS NULL OR {process_after} <= %s)
+			)
+		) AND
+		(
+			%s = 0 OR
+			NOT EXISTS (
+				SELECT 1
+				FROM processing_by_key pk
+				WHERE
+					pk.fairness_key IS NOT DISTINCT FROM %s AND
+					pk.processing >= %s
+			)
+		)
+		%s
+	ORDER BY %s
+	LIMIT %s
+),
+ranked_candidates AS (
+	SELECT
+		sc.candidate_id,
+		sc.fairness_key,
+		ROW_NUMBER() OVER (PARTITION BY sc.fairness_key ORDER BY %s) AS key_rank,
+		ROW_NUMBER() OVER (ORDER BY %s) AS global_rank
+	FROM scanned_candidates sc
+	JOIN %s ON {id} = sc.candidate_id
+),
+potential_candidates AS (
+	SELECT
+		rc.candidate_id,
+		ROW_NUMBER() OVER (ORDER BY COALESCE(pk.processing, 0) + rc.key_rank, rc.global_rank) AS order
+	FROM ranked_candidates rc
+	LEFT JOIN processing_by_key pk ON pk.fairness_key IS NOT DISTINCT FROM rc.fairness_key
+	WHERE
+		%s = 0 OR
+		COALESCE(pk.processing, 0) + rc.key_rank <= %s
+	ORDER BY 2
+	LIMIT 50
+)
+`
+
+// waitForDependenciesCondition excludes records that have a dependency that has not completed.
+const waitForDependenciesCondition = `
+NOT EXISTS (
+	SELECT 1
+	FROM workerutil_job_dependencies jd
+	WHERE
+		jd.job_table = %s AND
+		jd.job_id = {id} AND
+		jd.dependency_state <> 'completed'
+)
+`
+
 // makeDequeueSelectExpressions constructs the ordered set of SQL expressions that are returned
 // from the dequeue query. This method returns a copy of the configured column expressions slice
 // where expressions referencing one of the column updated by dequeue are replaced by the updated
@@ -862,7 +1075,14 @@ func (s *store[T]) MarkErrored(ctx context.Context, id int, failureMessage strin
 	}
 	conds = append(conds, options.ToSQLConds(s.formatQuery)...)
 
-	q := s.formatQuery(markErroredQuery, quote(s.options.TableName), s.options.MaxNumRetries, failureMessage, sqlf.Join(conds, "AND"))
+	q := s.formatQuery(
+		markErroredQuery,
+		quote(s.options.TableName),
+		s.options.MaxNumRetries,
+		failureMessage,
+		s.makeRetryProcessAfterExpression(),
+		sqlf.Join(conds, "AND"),
+	)
 	_, ok, err := basestore.ScanFirstInt(s.Query(ctx, q))
 	return ok, err
 }
@@ -872,11 +1092,52 @@ UPDATE %s
 SET {state} = CASE WHEN {cancel} THEN 'canceled' WHEN {num_failures} + 1 >= %d THEN 'failed' ELSE 'errored' END,
 	{finished_at} = clock_timestamp(),
 	{failure_message} = %s,
-	{num_failures} = CASE WHEN {cancel} THEN {num_failures} ELSE {num_failures} + 1 END
+	{num_failures} = CASE WHEN {cancel} THEN {num_failures} ELSE {num_failures} + 1 END,
+	{process_after} = %s
 WHERE %s
 RETURNING {id}
 `
 
+// makeRetryProcessAfterExpression returns the expression assigned to the process_after column of a record
+// that errored. This delays the next retry of the record by the backoff configured for this store on top
+// of the fixed RetryAfter delay. Without a backoff, the column is left unchanged.
+func (s *store[T]) makeRetryProcessAfterExpression() *sqlf.Query {
+	if s.options.RetryAfter <= 0 || (s.options.RetryBackoffMultiplier <= 1 && s.options.RetryJitter <= 0) {
+		return s.formatQuery("{process_after}")
+	}
+
+	multiplier := s.options.RetryBackoffMultiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	// LEAST ignores NULL values, so a zero MaxRetryAfter leaves the delay uncapped
+	var maxRetryAfter *float64
+	if s.options.MaxRetryAfter > 0 {
+		seconds := s.options.MaxRetryAfter.Seconds()
+		maxRetryAfter = &seconds
+	}
+
+	return s.formatQuery(
+		retryProcessAfterExpression,
+		maxRetryAfter,
+		s.options.RetryAfter.Seconds(),
+		multiplier,
+		s.options.RetryJitter,
+	)
+}
+
+// retryProcessAfterExpression computes the time after which an errored record may be retried. The number
+// of failures is read before it's incremented, so the first retry is delayed by exactly RetryAfter (plus
+// jitter).
+const retryProcessAfterExpression = `
+clock_timestamp() + (
+	LEAST(%s::double precision, %s * POWER(%s::double precision, {num_failures})) *
+	(1 + %s * random()) *
+	'1 second'::interval
+)
+`
+
 // MarkFailed attempts to update the state of the record to failed. This method will only have an effect
 // if the current state of the record is processing. A requeued record or a record already marked with an
 // error will not be updated. This method returns a boolean flag indicating if the record was updated.
@@ -90,6 +90,49 @@ func TestStoreQueuedCountFailed(t *testing.T) {
 	}
 }
 
+func TestStoreQueuedCountByFairnessKey(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state)
+		VALUES
+			(11, 'queued'),
+			(12, 'queued'),
+			(13, 'processing'),
+			(21, 'queued'),
+			(22, 'errored'),
+			(23, 'queued'),
+			(31, 'queued'),
+			(41, 'completed')
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	options.FairnessKeyExpression = sqlf.Sprintf("workerutil_test.id / 10")
+	counts, err := testStore(db, options).QueuedCountByFairnessKey(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("unexpected error getting queued count: %s", err)
+	}
+
+	expected := map[string]int{"2": 3, "1": 2}
+	if diff := cmp.Diff(expected, counts); diff != "" {
+		t.Errorf("unexpected counts (-want +got):\n%s", diff)
+	}
+}
+
+func TestStoreQueuedCountByFairnessKeyNoKey(t *testing.T) {
+	db := setupStoreTest(t)
+
+	counts, err := testStore(db, defaultTestStoreOptions(nil, testScanRecord)).QueuedCountByFairnessKey(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("unexpected error getting queued count: %s", err)
+	}
+	if counts != nil {
+		t.Errorf("unexpected counts. want=%v have=%v", nil, counts)
+	}
+}
+
 func TestStoreMaxDurationInQueue(t *testing.T) {
 	db := setupStoreTest(t)
 
@@ -236,6 +279,129 @@ func TestStoreDequeueConditions(t *testing.T) {
 	assertDequeueRecordResult(t, 3, record, ok, err)
 }
 
+func TestStoreDequeueFairnessKey(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, created_at)
+		VALUES
+			(11, 'queued', NOW() - '6 minute'::interval),
+			(12, 'queued', NOW() - '5 minute'::interval),
+			(13, 'queued', NOW() - '4 minute'::interval),
+			(21, 'queued', NOW() - '3 minute'::interval),
+			(22, 'queued', NOW() - '2 minute'::interval),
+			(31, 'queued', NOW() - '1 minute'::interval)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	options.FairnessKeyExpression = sqlf.Sprintf("workerutil_test.id / 10")
+	store := testStore(db, options)
+
+	// The oldest record of each key is dequeued before the second record of any key
+	for _, expectedID := range []int{11, 21, 31, 12, 22, 13} {
+		record, ok, err := store.Dequeue(context.Background(), "test", nil)
+		assertDequeueRecordResult(t, expectedID, record, ok, err)
+	}
+}
+
+func TestStoreDequeueMaxConcurrencyPerKey(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, created_at)
+		VALUES
+			(11, 'processing', NOW() - '5 minute'::interval),
+			(12, 'queued', NOW() - '4 minute'::interval),
+			(13, 'queued', NOW() - '3 minute'::interval),
+			(21, 'queued', NOW() - '2 minute'::interval)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	options.FairnessKeyExpression = sqlf.Sprintf("workerutil_test.id / 10")
+	options.MaxConcurrencyPerKey = 2
+	store := testStore(db, options)
+
+	for _, expectedID := range []int{21, 12} {
+		record, ok, err := store.Dequeue(context.Background(), "test", nil)
+		assertDequeueRecordResult(t, expectedID, record, ok, err)
+	}
+
+	// Both records of the first key are processing
+	_, ok, err := store.Dequeue(context.Background(), "test", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if ok {
+		t.Fatalf("did not expect a record to be dequeued")
+	}
+}
+
+func TestStoreDequeueMaxConcurrencyPerKeyDeepQueue(t *testing.T) {
+	db := setupStoreTest(t)
+
+	// The first key has more queued records than are ranked on each dequeue, all of which are
+	// older than the only record of the second key
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, created_at)
+		SELECT id, CASE WHEN id <= 2 THEN 'processing' ELSE 'queued' END, NOW() - '1 day'::interval + (id * '1 second'::interval)
+		FROM generate_series(1, $1) id
+	`, fairCandidateScanLimit*2); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+	if _, err := db.ExecContext(context.Background(), `INSERT INTO workerutil_test (id, state, created_at) VALUES (100001, 'queued', NOW())`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	options.FairnessKeyExpression = sqlf.Sprintf("workerutil_test.id / 100000")
+	options.MaxConcurrencyPerKey = 2
+	store := testStore(db, options)
+
+	record, ok, err := store.Dequeue(context.Background(), "test", nil)
+	assertDequeueRecordResult(t, 100001, record, ok, err)
+}
+
+func TestStoreDequeueWaitForDependencies(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, created_at)
+		VALUES
+			(1, 'queued', NOW() - '3 minute'::interval),
+			(2, 'queued', NOW() - '2 minute'::interval),
+			(3, 'queued', NOW() - '1 minute'::interval)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_job_dependencies (job_table, job_id, dependency_table, dependency_id, dependency_state)
+		VALUES
+			('workerutil_test', 1, 'other_jobs', 10, 'pending'),
+			('workerutil_test', 2, 'other_jobs', 11, 'completed'),
+			('workerutil_test', 2, 'other_jobs', 12, 'completed')
+	`); err != nil {
+		t.Fatalf("unexpected error inserting dependencies: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	options.WaitForDependencies = true
+	store := testStore(db, options)
+
+	// Record 1 is waiting for its dependency
+	for _, expectedID := range []int{2, 3} {
+		record, ok, err := store.Dequeue(context.Background(), "test", nil)
+		assertDequeueRecordResult(t, expectedID, record, ok, err)
+	}
+	if _, ok, _ := store.Dequeue(context.Background(), "test", nil); ok {
+		t.Fatalf("did not expect a third dequeueable record")
+	}
+}
+
 func TestStoreDequeueResetExecutionLogs(t *testing.T) {
 	db := setupStoreTest(t)
 
@@ -422,6 +588,38 @@ func TestStoreDequeueRetryAfterDisabled(t *testing.T) {
 	}
 }
 
+func TestStoreDequeueRetryAfterBackoff(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, finished_at, process_after, failure_message, num_failures, created_at)
+		VALUES
+			(1, 'errored', NOW() - '6 minute'::interval, NOW() + '4 minute'::interval, 'error', 3, NOW() - '2 minutes'::interval),
+			(2, 'errored', NOW() - '6 minute'::interval, NOW() - '1 minute'::interval, 'error', 1, NOW() - '3 minutes'::interval)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecordRetry)
+	options.MaxNumRetries = 5
+	options.RetryAfter = 5 * time.Minute
+	options.ColumnExpressions = []*sqlf.Query{
+		sqlf.Sprintf("workerutil_test.id"),
+		sqlf.Sprintf("workerutil_test.state"),
+		sqlf.Sprintf("workerutil_test.num_resets"),
+	}
+	store := testStore(db, options)
+
+	// Dequeue errored record whose backoff has passed
+	record, ok, err := store.Dequeue(context.Background(), "test", nil)
+	assertDequeueRecordRetryResult(t, 2, record, ok, err)
+
+	// Does not dequeue errored record still backing off
+	if _, ok, _ := store.Dequeue(context.Background(), "test", nil); ok {
+		t.Fatalf("did not expect a second dequeueable record")
+	}
+}
+
 func TestStoreRequeue(t *testing.T) {
 	db := setupStoreTest(t)
 
@@ -743,6 +941,47 @@ func TestStoreMarkErrored(t *testing.T) {
 	}
 }
 
+func TestStoreMarkErroredBackoff(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, num_failures)
+		VALUES
+			(1, 'processing', 0),
+			(2, 'processing', 2),
+			(3, 'processing', 8)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	options.MaxNumRetries = 10
+	options.RetryAfter = time.Minute
+	options.RetryBackoffMultiplier = 2
+	options.MaxRetryAfter = time.Hour
+	options.RetryJitter = 0.5
+	store := testStore(db, options)
+
+	for _, id := range []int{1, 2, 3} {
+		if _, err := store.MarkErrored(context.Background(), id, "new message", MarkFinalOptions{}); err != nil {
+			t.Fatalf("unexpected error marking record as errored: %s", err)
+		}
+	}
+
+	delays, err := basestore.ScanInts(db.QueryContext(context.Background(), `
+		SELECT EXTRACT(EPOCH FROM process_after - finished_at)::integer FROM workerutil_test ORDER BY id
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error querying records: %s", err)
+	}
+
+	for i, minDelay := range []time.Duration{time.Minute, 4 * time.Minute, time.Hour} {
+		if delay := time.Duration(delays[i]) * time.Second; delay < minDelay-time.Second || delay > minDelay*3/2+time.Second {
+			t.Errorf("unexpected delay for record %d. want=[%s, %s] have=%s", i+1, minDelay, minDelay*3/2, delay)
+		}
+	}
+}
+
 func TestStoreMarkFailed(t *testing.T) {
 	db := setupStoreTest(t)
 
@@ -2,6 +2,7 @@ package dbworker
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/keegancsmith/sqlf"
 
@@ -41,20 +42,38 @@ func (s *storeShim[T]) Dequeue(ctx context.Context, workerHostname string, extra
 	return s.Store.Dequeue(ctx, workerHostname, conditions)
 }
 
-func (s *storeShim[T]) Heartbeat(ctx context.Context, ids []int) (knownIDs, cancelIDs []int, err error) {
-	return s.Store.Heartbeat(ctx, ids, store.HeartbeatOptions{})
+func (s *storeShim[T]) Heartbeat(ctx context.Context, jobIDs []string) (knownIDs, cancelIDs []string, err error) {
+	ids := make([]int, 0, len(jobIDs))
+	for _, jobID := range jobIDs {
+		id, err := strconv.Atoi(jobID)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "invalid record identifier %q", jobID)
+		}
+		ids = append(ids, id)
+	}
+
+	knownIntIDs, cancelIntIDs, err := s.Store.Heartbeat(ctx, ids, store.HeartbeatOptions{})
+	return intsToStrings(knownIntIDs), intsToStrings(cancelIntIDs), err
 }
 
-func (s *storeShim[T]) MarkComplete(ctx context.Context, id int) (bool, error) {
-	return s.Store.MarkComplete(ctx, id, store.MarkFinalOptions{})
+func (s *storeShim[T]) MarkComplete(ctx context.Context, record T) (bool, error) {
+	return s.Store.MarkComplete(ctx, record.RecordID(), store.MarkFinalOptions{})
 }
 
-func (s *storeShim[T]) MarkFailed(ctx context.Context, id int, failureMessage string) (bool, error) {
-	return s.Store.MarkFailed(ctx, id, failureMessage, store.MarkFinalOptions{})
+func (s *storeShim[T]) MarkFailed(ctx context.Context, record T, failureMessage string) (bool, error) {
+	return s.Store.MarkFailed(ctx, record.RecordID(), failureMessage, store.MarkFinalOptions{})
 }
 
-func (s *storeShim[T]) MarkErrored(ctx context.Context, id int, errorMessage string) (bool, error) {
-	return s.Store.MarkErrored(ctx, id, errorMessage, store.MarkFinalOptions{})
+func (s *storeShim[T]) MarkErrored(ctx context.Context, record T, errorMessage string) (bool, error) {
+	return s.Store.MarkErrored(ctx, record.RecordID(), errorMessage, store.MarkFinalOptions{})
+}
+
+func intsToStrings(ints []int) []string {
+	strs := make([]string, 0, len(ints))
+	for _, i := range ints {
+		strs = append(strs, strconv.Itoa(i))
+	}
+	return strs
 }
 
 // ErrNotConditions occurs when a PreDequeue handler returns non-sql query extra arguments.
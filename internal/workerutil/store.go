@@ -2,6 +2,7 @@ package workerutil
 
 import (
 	"context"
+	"strconv"
 )
 
 // Record is a generic interface for record conforming to the requirements of the store.
@@ -10,6 +11,25 @@ type Record interface {
 	RecordID() int
 }
 
+// UIDRecord is implemented by records whose integer primary key is not unique among the records
+// processed by a single worker, such as executor jobs dequeued from several queues.
+type UIDRecord interface {
+	Record
+
+	// RecordUID returns an identifier of the record that is unique among the records processed
+	// by a single worker.
+	RecordUID() string
+}
+
+// RecordUID returns the identifier the worker tracks the given record by. This is the integer
+// primary key of the record unless the record implements UIDRecord.
+func RecordUID(record Record) string {
+	if r, ok := record.(UIDRecord); ok {
+		return r.RecordUID()
+	}
+	return strconv.Itoa(record.RecordID())
+}
+
 // Store is the persistence layer for the workerutil package that handles worker-side operations.
 type Store[T Record] interface {
 	// QueuedCount returns the number of records in the queued state.
@@ -20,19 +40,20 @@ type Store[T Record] interface {
 	// flag indicating the existence of a processable record.
 	Dequeue(ctx context.Context, workerHostname string, extraArguments any) (T, bool, error)
 
-	// Heartbeat updates last_heartbeat_at of all the given jobs, when they're processing. All IDs of records that were
-	// touched are returned. Additionally, jobs in the working set that are flagged as to be canceled are returned.
-	Heartbeat(ctx context.Context, jobIDs []int) (knownIDs, cancelIDs []int, err error)
+	// Heartbeat updates last_heartbeat_at of all the given jobs, when they're processing. The jobs are identified by
+	// their RecordUID. All identifiers of records that were touched are returned. Additionally, jobs in the working set
+	// that are flagged as to be canceled are returned.
+	Heartbeat(ctx context.Context, jobIDs []string) (knownIDs, cancelIDs []string, err error)
 
 	// MarkComplete attempts to update the state of the record to complete. This method returns a boolean flag indicating
 	// if the record was updated.
-	MarkComplete(ctx context.Context, id int) (bool, error)
+	MarkComplete(ctx context.Context, record T) (bool, error)
 
 	// MarkErrored attempts to update the state of the record to errored. This method returns a boolean flag indicating
 	// if the record was updated.
-	MarkErrored(ctx context.Context, id int, failureMessage string) (bool, error)
+	MarkErrored(ctx context.Context, record T, failureMessage string) (bool, error)
 
 	// MarkFailed attempts to update the state of the record to failed. This method returns a boolean flag indicating
 	// if the record was updated.
-	MarkFailed(ctx context.Context, id int, failureMessage string) (bool, error)
+	MarkFailed(ctx context.Context, record T, failureMessage string) (bool, error)
 }
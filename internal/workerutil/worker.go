@@ -37,7 +37,7 @@ type Worker[T Record] struct {
 	dequeueCancel    func()          // cancels the dequeue context
 	wg               sync.WaitGroup  // tracks active handler routines
 	finished         chan struct{}   // signals that Start has finished
-	runningIDSet     *IDSet          // tracks the running job IDs to heartbeat
+	runningIDSet     *IDSet          // tracks the UIDs of the running jobs to heartbeat
 	jobName          string
 	recorder         *recorder.Recorder
 }
@@ -160,12 +160,12 @@ func (w *Worker[T]) Start() {
 			knownIDs, canceledIDs, err := w.store.Heartbeat(w.rootCtx, ids)
 			if err != nil {
 				w.options.Metrics.logger.Error("Failed to refresh heartbeats",
-					log.Ints("ids", ids),
+					log.Strings("ids", ids),
 					log.Error(err))
 				// Bail out and restart the for loop.
 				continue
 			}
-			knownIDsMap := map[int]struct{}{}
+			knownIDsMap := map[string]struct{}{}
 			for _, id := range knownIDs {
 				knownIDsMap[id] = struct{}{}
 			}
@@ -174,13 +174,13 @@ func (w *Worker[T]) Start() {
 				if _, ok := knownIDsMap[id]; !ok {
 					if w.runningIDSet.Remove(id) {
 						w.options.Metrics.logger.Error("Removed unknown job from running set",
-							log.Int("id", id))
+							log.String("id", id))
 					}
 				}
 			}
 
 			if len(canceledIDs) > 0 {
-				w.options.Metrics.logger.Info("Found jobs to cancel", log.Ints("IDs", canceledIDs))
+				w.options.Metrics.logger.Info("Found jobs to cancel", log.Strings("IDs", canceledIDs))
 			}
 
 			for _, id := range canceledIDs {
@@ -314,7 +314,7 @@ func (w *Worker[T]) dequeueAndHandle() (dequeued bool, err error) {
 	processLog := trace.Logger(workerCtxWithSpan, w.options.Metrics.logger)
 
 	// Register the record as running so it is included in heartbeat updates.
-	if !w.runningIDSet.Add(record.RecordID(), cancel) {
+	if !w.runningIDSet.Add(RecordUID(record), cancel) {
 		workerSpan.LogFields(otlog.Error(ErrJobAlreadyExists))
 		workerSpan.Finish()
 		return false, ErrJobAlreadyExists
@@ -351,7 +351,7 @@ func (w *Worker[T]) dequeueAndHandle() (dequeued bool, err error) {
 
 			// Remove the record from the set of running jobs, so it is not included
 			// in heartbeat updates anymore.
-			defer w.runningIDSet.Remove(record.RecordID())
+			defer w.runningIDSet.Remove(RecordUID(record))
 			w.options.Metrics.numJobs.Dec()
 			w.handlerSemaphore <- struct{}{}
 			w.wg.Done()
@@ -398,20 +398,20 @@ func (w *Worker[T]) handle(ctx, workerContext context.Context, record T) (err er
 		go w.recorder.LogRun(w, duration, handleErr)
 	}
 
-	if errcode.IsNonRetryable(handleErr) || handleErr != nil && w.isJobCanceled(record.RecordID(), handleErr, ctx.Err()) {
-		if marked, markErr := w.store.MarkFailed(workerContext, record.RecordID(), handleErr.Error()); markErr != nil {
+	if errcode.IsNonRetryable(handleErr) || handleErr != nil && w.isJobCanceled(RecordUID(record), handleErr, ctx.Err()) {
+		if marked, markErr := w.store.MarkFailed(workerContext, record, handleErr.Error()); markErr != nil {
 			return errors.Wrap(markErr, "store.MarkFailed")
 		} else if marked {
 			handleLog.Warn("Marked record as failed", log.Error(handleErr))
 		}
 	} else if handleErr != nil {
-		if marked, markErr := w.store.MarkErrored(workerContext, record.RecordID(), handleErr.Error()); markErr != nil {
+		if marked, markErr := w.store.MarkErrored(workerContext, record, handleErr.Error()); markErr != nil {
 			return errors.Wrap(markErr, "store.MarkErrored")
 		} else if marked {
 			handleLog.Warn("Marked record as errored", log.Error(handleErr))
 		}
 	} else {
-		if marked, markErr := w.store.MarkComplete(workerContext, record.RecordID()); markErr != nil {
+		if marked, markErr := w.store.MarkComplete(workerContext, record); markErr != nil {
 			return errors.Wrap(markErr, "store.MarkComplete")
 		} else if marked {
 			handleLog.Debug("Marked record as complete")
@@ -425,7 +425,7 @@ func (w *Worker[T]) handle(ctx, workerContext context.Context, record T) (err er
 // isJobCanceled returns true if the job has been canceled through the Cancel interface.
 // If the context is canceled, and the job is still part of the running ID set,
 // we know that it has been canceled for that reason.
-func (w *Worker[T]) isJobCanceled(id int, handleErr, ctxErr error) bool {
+func (w *Worker[T]) isJobCanceled(id string, handleErr, ctxErr error) bool {
 	return errors.Is(handleErr, ctxErr) && w.runningIDSet.Has(id) && !errors.Is(handleErr, context.DeadlineExceeded)
 }
 
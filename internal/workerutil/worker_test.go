@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/derision-test/glock"
+	"github.com/google/go-cmp/cmp"
 
 	"github.com/sourcegraph/log"
 
@@ -56,7 +57,7 @@ func TestWorkerHandlerSuccess(t *testing.T) {
 
 	if callCount := len(store.MarkCompleteFunc.History()); callCount != 1 {
 		t.Errorf("unexpected mark complete call count. want=%d have=%d", 1, callCount)
-	} else if id := store.MarkCompleteFunc.History()[0].Arg1; id != 42 {
+	} else if id := store.MarkCompleteFunc.History()[0].Arg1.RecordID(); id != 42 {
 		t.Errorf("unexpected id argument to mark complete. want=%v have=%v", 42, id)
 	}
 }
@@ -93,7 +94,7 @@ func TestWorkerHandlerFailure(t *testing.T) {
 
 	if callCount := len(store.MarkErroredFunc.History()); callCount != 1 {
 		t.Errorf("unexpected mark errored call count. want=%d have=%d", 1, callCount)
-	} else if id := store.MarkErroredFunc.History()[0].Arg1; id != 42 {
+	} else if id := store.MarkErroredFunc.History()[0].Arg1.RecordID(); id != 42 {
 		t.Errorf("unexpected id argument to mark errored. want=%v have=%v", 42, id)
 	} else if failureMessage := store.MarkErroredFunc.History()[0].Arg2; failureMessage != "oops" {
 		t.Errorf("unexpected failure message argument to mark errored. want=%q have=%q", "oops", failureMessage)
@@ -139,7 +140,7 @@ func TestWorkerHandlerNonRetryableFailure(t *testing.T) {
 
 	if callCount := len(store.MarkFailedFunc.History()); callCount != 1 {
 		t.Errorf("unexpected mark failed call count. want=%d have=%d", 1, callCount)
-	} else if id := store.MarkFailedFunc.History()[0].Arg1; id != 42 {
+	} else if id := store.MarkFailedFunc.History()[0].Arg1.RecordID(); id != 42 {
 		t.Errorf("unexpected id argument to mark failed. want=%v have=%v", 42, id)
 	} else if failureMessage := store.MarkFailedFunc.History()[0].Arg2; failureMessage != testErr.Error() {
 		t.Errorf("unexpected failure message argument to mark failed. want=%q have=%q", testErr.Error(), failureMessage)
@@ -372,7 +373,7 @@ func TestWorkerDequeueHeartbeat(t *testing.T) {
 	}
 
 	heartbeats := make(chan struct{})
-	store.HeartbeatFunc.SetDefaultHook(func(c context.Context, i []int) ([]int, []int, error) {
+	store.HeartbeatFunc.SetDefaultHook(func(c context.Context, i []string) ([]string, []string, error) {
 		heartbeats <- struct{}{}
 		return i, nil, nil
 	})
@@ -395,6 +396,81 @@ func TestWorkerDequeueHeartbeat(t *testing.T) {
 	}
 }
 
+type QueuedTestRecord struct {
+	ID    int
+	Queue string
+}
+
+func (v QueuedTestRecord) RecordID() int {
+	return v.ID
+}
+
+func (v QueuedTestRecord) RecordUID() string {
+	return fmt.Sprintf("%d-%s", v.ID, v.Queue)
+}
+
+func TestWorkerDequeueHeartbeatRecordUID(t *testing.T) {
+	store := NewMockStore[*QueuedTestRecord]()
+	store.DequeueFunc.PushReturn(&QueuedTestRecord{ID: 42, Queue: "a"}, true, nil)
+	store.DequeueFunc.PushReturn(&QueuedTestRecord{ID: 42, Queue: "b"}, true, nil)
+	store.DequeueFunc.SetDefaultReturn(nil, false, nil)
+	store.MarkCompleteFunc.SetDefaultReturn(true, nil)
+
+	handler := NewMockHandler[*QueuedTestRecord]()
+	dequeueClock := glock.NewMockClock()
+	heartbeatClock := glock.NewMockClock()
+	shutdownClock := glock.NewMockClock()
+	heartbeatInterval := time.Second
+	options := WorkerOptions{
+		Name:              "test",
+		WorkerHostname:    "test",
+		NumHandlers:       2,
+		HeartbeatInterval: heartbeatInterval,
+		Interval:          time.Second,
+		Metrics:           NewMetrics(&observation.TestContext, ""),
+	}
+
+	dequeued := make(chan struct{})
+	doneHandling := make(chan struct{})
+	handler.HandleFunc.SetDefaultHook(func(c context.Context, l log.Logger, r *QueuedTestRecord) error {
+		dequeued <- struct{}{}
+		<-doneHandling
+		return nil
+	})
+
+	heartbeats := make(chan []string)
+	store.HeartbeatFunc.SetDefaultHook(func(c context.Context, i []string) ([]string, []string, error) {
+		heartbeats <- i
+		return i, nil, nil
+	})
+
+	worker := newWorker(context.Background(), Store[*QueuedTestRecord](store), Handler[*QueuedTestRecord](handler), options, dequeueClock, heartbeatClock, shutdownClock)
+	go func() { worker.Start() }()
+	t.Cleanup(func() {
+		close(doneHandling)
+		worker.Stop()
+	})
+
+	// Records sharing an ID but not a UID are processed concurrently.
+	for i := 0; i < 2; i++ {
+		select {
+		case <-dequeued:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for dequeue")
+		}
+	}
+
+	heartbeatClock.BlockingAdvance(heartbeatInterval)
+	select {
+	case ids := <-heartbeats:
+		if diff := cmp.Diff([]string{"42-a", "42-b"}, ids); diff != "" {
+			t.Errorf("unexpected heartbeat ids (-want +got):\n%s", diff)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for heartbeat")
+	}
+}
+
 func TestWorkerNumTotalJobs(t *testing.T) {
 	store := NewMockStore[*TestRecord]()
 	handler := NewMockHandler[*TestRecord]()
@@ -496,7 +572,7 @@ func TestWorkerCancelJobs(t *testing.T) {
 
 	// Record when markFailed is called.
 	markedFailedCalled := make(chan struct{})
-	store.MarkFailedFunc.SetDefaultHook(func(c context.Context, i int, s string) (bool, error) {
+	store.MarkFailedFunc.SetDefaultHook(func(c context.Context, r *TestRecord, s string) (bool, error) {
 		close(markedFailedCalled)
 		return true, nil
 	})
@@ -524,7 +600,7 @@ func TestWorkerCancelJobs(t *testing.T) {
 	}
 
 	canceledJobsCalled := make(chan struct{})
-	store.HeartbeatFunc.SetDefaultHook(func(c context.Context, i []int) ([]int, []int, error) {
+	store.HeartbeatFunc.SetDefaultHook(func(c context.Context, i []string) ([]string, []string, error) {
 		close(canceledJobsCalled)
 		// Cancel all jobs.
 		return i, i, nil
@@ -571,7 +647,7 @@ func TestWorkerDeadline(t *testing.T) {
 
 	// Record when markErrored is called.
 	markedErroredCalled := make(chan struct{})
-	store.MarkErroredFunc.SetDefaultHook(func(c context.Context, i int, s string) (bool, error) {
+	store.MarkErroredFunc.SetDefaultHook(func(c context.Context, r *TestRecord, s string) (bool, error) {
 		if !strings.Contains(s, "job exceeded maximum execution time of 10ms") {
 			t.Fatal("incorrect error message")
 		}
@@ -603,7 +679,7 @@ func TestWorkerDeadline(t *testing.T) {
 	}
 
 	heartbeats := make(chan struct{})
-	store.HeartbeatFunc.SetDefaultHook(func(c context.Context, i []int) ([]int, []int, error) {
+	store.HeartbeatFunc.SetDefaultHook(func(c context.Context, i []string) ([]string, []string, error) {
 		heartbeats <- struct{}{}
 		return i, nil, nil
 	})
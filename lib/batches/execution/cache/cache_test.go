@@ -2,6 +2,7 @@ package cache
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,6 +11,8 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/batches/env"
+	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -187,6 +190,76 @@ func TestKeyer_Key(t *testing.T) {
 	}
 }
 
+func TestStepKey_Key(t *testing.T) {
+	key := func(step batches.Step, previous execution.AfterStepResult) string {
+		t.Helper()
+		k, err := KeyForStep(nil, repo, "", nil, false, step, previous, nil).Key()
+		require.NoError(t, err)
+		return k
+	}
+
+	base := key(batches.Step{Run: "foo", Container: "alpine:3"}, execution.AfterStepResult{})
+	assert.True(t, strings.HasPrefix(base, StepKeyPrefix))
+
+	t.Run("stable", func(t *testing.T) {
+		assert.Equal(t, base, key(batches.Step{Run: "foo", Container: "alpine:3"}, execution.AfterStepResult{StepIndex: 4}))
+	})
+
+	t.Run("run script", func(t *testing.T) {
+		assert.NotEqual(t, base, key(batches.Step{Run: "bar", Container: "alpine:3"}, execution.AfterStepResult{}))
+	})
+
+	t.Run("previous diff", func(t *testing.T) {
+		assert.NotEqual(t, base, key(batches.Step{Run: "foo", Container: "alpine:3"}, execution.AfterStepResult{Diff: []byte("diff")}))
+	})
+
+	t.Run("image digest", func(t *testing.T) {
+		assert.Equal(t,
+			key(batches.Step{Run: "foo", Container: "alpine@sha256:1234"}, execution.AfterStepResult{}),
+			key(batches.Step{Run: "foo", Container: "alpine:3@sha256:1234"}, execution.AfterStepResult{}),
+		)
+	})
+
+	t.Run("previous output", func(t *testing.T) {
+		previous := execution.AfterStepResult{Stdout: "hello"}
+		assert.Equal(t, base, key(batches.Step{Run: "foo", Container: "alpine:3"}, previous))
+
+		step := batches.Step{Run: "echo ${{ previous_step.stdout }}", Container: "alpine:3"}
+		assert.NotEqual(t, key(step, execution.AfterStepResult{}), key(step, previous))
+
+		step = batches.Step{Run: "echo previous_step.stdout", Container: "alpine:3"}
+		assert.Equal(t, key(step, execution.AfterStepResult{}), key(step, previous))
+	})
+
+	t.Run("batch change attributes", func(t *testing.T) {
+		keyWithAttributes := func(step batches.Step, name string) string {
+			t.Helper()
+			attributes := &template.BatchChangeAttributes{Name: name, Description: "description"}
+			k, err := KeyForStep(attributes, repo, "", nil, false, step, execution.AfterStepResult{}, nil).Key()
+			require.NoError(t, err)
+			return k
+		}
+
+		step := batches.Step{Run: "foo", Container: "alpine:3"}
+		assert.Equal(t, keyWithAttributes(step, "a"), keyWithAttributes(step, "b"))
+
+		step = batches.Step{Run: "foo", Container: "alpine:3", Files: map[string]string{"README": "${{ batch_change.name }}"}}
+		assert.NotEqual(t, keyWithAttributes(step, "a"), keyWithAttributes(step, "b"))
+	})
+
+	t.Run("environment", func(t *testing.T) {
+		var stepEnv env.Environment
+		require.NoError(t, json.Unmarshal([]byte(`["SECRET"]`), &stepEnv))
+		step := batches.Step{Run: "foo", Container: "alpine:3", Env: stepEnv}
+
+		k1, err := KeyForStep(nil, repo, "", []string{"SECRET=a"}, false, step, execution.AfterStepResult{}, nil).Key()
+		require.NoError(t, err)
+		k2, err := KeyForStep(nil, repo, "", []string{"SECRET=b"}, false, step, execution.AfterStepResult{}, nil).Key()
+		require.NoError(t, err)
+		assert.NotEqual(t, k1, k2)
+	})
+}
+
 type testM struct {
 	m   []MountMetadata
 	err error
@@ -0,0 +1,168 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
+)
+
+// StepKeyPrefix is the prefix of all keys generated by StepKey.
+const StepKeyPrefix = "content-"
+
+// StepKey implements the Keyer interface for the execution of a single step. Unlike CacheKey,
+// which addresses the result of all steps up to a given index of a batch spec, StepKey is derived
+// only from the content that determines the result of the step: the repository tree, the step
+// itself including its container image and resolved environment, the result of the step executed
+// before it, and the batch change attributes if the step renders them. Changes to other steps of a batch spec therefore don't invalidate the key,
+// and identical steps in different batch specs share the same key.
+type StepKey struct {
+	Repository            batches.Repository
+	Path                  string
+	OnlyFetchWorkspace    bool
+	BatchChangeAttributes *template.BatchChangeAttributes
+	Step                  batches.Step
+
+	// PreviousResult is the result of the step that was executed before Step. It is the zero value
+	// for the first step that is executed in a workspace.
+	PreviousResult execution.AfterStepResult
+
+	MetadataRetriever MetadataRetriever
+	GlobalEnv         []string
+}
+
+// KeyForStep returns the content addressed key of the given step.
+func KeyForStep(batchChangeAttributes *template.BatchChangeAttributes, r batches.Repository, path string, globalEnv []string, onlyFetchWorkspace bool, step batches.Step, previousResult execution.AfterStepResult, retriever MetadataRetriever) Keyer {
+	sort.Strings(r.FileMatches)
+
+	return StepKey{
+		Repository:            r,
+		Path:                  path,
+		OnlyFetchWorkspace:    onlyFetchWorkspace,
+		BatchChangeAttributes: batchChangeAttributes,
+		Step:                  step,
+		PreviousResult:        previousResult,
+		MetadataRetriever:     retriever,
+		GlobalEnv:             globalEnv,
+	}
+}
+
+// Key hashes the content the result of the step depends on.
+func (key StepKey) Key() (string, error) {
+	envs, err := resolveStepsEnvironment(key.GlobalEnv, []batches.Step{key.Step})
+	if err != nil {
+		return "", err
+	}
+
+	var metadata []MountMetadata
+	if key.MetadataRetriever != nil {
+		if metadata, err = key.MetadataRetriever.Get([]batches.Step{key.Step}); err != nil {
+			return "", err
+		}
+	}
+
+	step := key.Step
+	step.Container = imageReference(step.Container)
+
+	previous := struct {
+		Diff    []byte
+		Outputs map[string]any
+		Stdout  string `json:",omitempty"`
+		Stderr  string `json:",omitempty"`
+	}{
+		Diff:    key.PreviousResult.Diff,
+		Outputs: key.PreviousResult.Outputs,
+	}
+	// The standard output and error of a step are rarely deterministic, so they are only part of
+	// the key when the step can actually observe them.
+	if stepReferences(key.Step, "previous_step") {
+		previous.Stdout = key.PreviousResult.Stdout
+		previous.Stderr = key.PreviousResult.Stderr
+	}
+
+	// Likewise, the name and description of the batch change only affect the result of steps that
+	// render them, so editing them doesn't invalidate the results of other steps.
+	var batchChangeAttributes *template.BatchChangeAttributes
+	if stepReferences(key.Step, "batch_change") {
+		batchChangeAttributes = key.BatchChangeAttributes
+	}
+
+	raw, err := json.Marshal(struct {
+		Repository            batches.Repository
+		Path                  string
+		OnlyFetchWorkspace    bool
+		BatchChangeAttributes *template.BatchChangeAttributes
+		Step                  batches.Step
+		Environment           map[string]string
+		MountsMetadata        []MountMetadata
+		Previous              any
+	}{
+		Repository:            key.Repository,
+		Path:                  key.Path,
+		OnlyFetchWorkspace:    key.OnlyFetchWorkspace,
+		BatchChangeAttributes: batchChangeAttributes,
+		Step:                  step,
+		Environment:           envs[0],
+		MountsMetadata:        metadata,
+		Previous:              previous,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(raw)
+	return StepKeyPrefix + base64.RawURLEncoding.EncodeToString(hash[:]), nil
+}
+
+func (key StepKey) Slug() string {
+	return SlugForRepo(key.Repository.Name, key.Repository.BaseRev)
+}
+
+// imageReference returns the digest of the given container image if the image is pinned to one, so
+// that references to the same image by different tags produce the same key.
+func imageReference(container string) string {
+	if i := strings.LastIndex(container, "@"); i >= 0 {
+		return container[i+1:]
+	}
+	return container
+}
+
+// stepReferences returns true if any template of the given step calls one of the given template
+// functions.
+func stepReferences(step batches.Step, names ...string) bool {
+	templates := []string{step.Run, step.IfCondition()}
+	for _, content := range step.Files {
+		templates = append(templates, content)
+	}
+	for _, output := range step.Outputs {
+		templates = append(templates, output.Value)
+	}
+	// Values taken from the global environment are not rendered, so they are left out.
+	environment, err := step.Env.Resolve(nil)
+	if err != nil {
+		return true
+	}
+	outer := make(map[string]struct{})
+	for _, name := range step.Env.OuterVars() {
+		outer[name] = struct{}{}
+	}
+	for name, value := range environment {
+		if _, ok := outer[name]; !ok {
+			templates = append(templates, value)
+		}
+	}
+
+	for _, tmpl := range templates {
+		ok, err := template.References(tmpl, names...)
+		// Err on the side of including the content in the key if the template can't be parsed.
+		if err != nil || ok {
+			return true
+		}
+	}
+	return false
+}
@@ -7,6 +7,7 @@ import (
 	"sort"
 	"strings"
 	"text/template"
+	"text/template/parse"
 
 	"github.com/gobwas/glob"
 	"github.com/grafana/regexp"
@@ -135,6 +136,72 @@ func RenderStepTemplate(name, tmpl string, out io.Writer, stepCtx *StepContext)
 	return t.Execute(out, stepCtx)
 }
 
+// References returns true if the given step template calls any of the given template
+// functions, such as "previous_step" or "batch_change". Mentions of the names outside of
+// template actions, e.g. in the text of a script, are not references.
+func References(tmpl string, names ...string) (bool, error) {
+	t, err := New("references", tmpl, "", (&StepContext{}).ToFuncMap())
+	if err != nil {
+		return false, errors.Wrap(err, "parsing step template")
+	}
+
+	wanted := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		wanted[name] = struct{}{}
+	}
+
+	return referencesNode(t.Tree.Root, wanted), nil
+}
+
+func referencesNode(n parse.Node, names map[string]struct{}) bool {
+	switch n := n.(type) {
+	case *parse.IdentifierNode:
+		_, ok := names[n.Ident]
+		return ok
+	case *parse.ListNode:
+		if n == nil {
+			return false
+		}
+		for _, child := range n.Nodes {
+			if referencesNode(child, names) {
+				return true
+			}
+		}
+	case *parse.ActionNode:
+		return referencesNode(n.Pipe, names)
+	case *parse.PipeNode:
+		if n == nil {
+			return false
+		}
+		for _, cmd := range n.Cmds {
+			if referencesNode(cmd, names) {
+				return true
+			}
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			if referencesNode(arg, names) {
+				return true
+			}
+		}
+	case *parse.ChainNode:
+		return referencesNode(n.Node, names)
+	case *parse.IfNode:
+		return referencesBranch(&n.BranchNode, names)
+	case *parse.RangeNode:
+		return referencesBranch(&n.BranchNode, names)
+	case *parse.WithNode:
+		return referencesBranch(&n.BranchNode, names)
+	case *parse.TemplateNode:
+		return referencesNode(n.Pipe, names)
+	}
+	return false
+}
+
+func referencesBranch(n *parse.BranchNode, names map[string]struct{}) bool {
+	return referencesNode(n.Pipe, names) || referencesNode(n.List, names) || referencesNode(n.ElseList, names)
+}
+
 func RenderStepMap(m map[string]string, stepCtx *StepContext) (map[string]string, error) {
 	rendered := make(map[string]string, len(m))
 
@@ -398,6 +398,39 @@ func TestRenderStepMap(t *testing.T) {
 	}
 }
 
+func TestReferences(t *testing.T) {
+	tests := []struct {
+		name string
+		tmpl string
+		want bool
+	}{
+		{name: "no template", tmpl: `echo previous_step.stdout`, want: false},
+		{name: "other function", tmpl: `echo ${{ repository.name }}`, want: false},
+		{name: "field", tmpl: `echo ${{ previous_step.stdout }}`, want: true},
+		{name: "argument", tmpl: `echo ${{ join previous_step.modified_files " " }}`, want: true},
+		{name: "if", tmpl: `${{ if eq repository.name "foo" }}${{ previous_step.stdout }}${{ end }}`, want: true},
+		{name: "else", tmpl: `${{ if eq repository.name "foo" }}foo${{ else }}${{ previous_step.stdout }}${{ end }}`, want: true},
+		{name: "range", tmpl: `${{ range previous_step.modified_files }}${{ . }}${{ end }}`, want: true},
+		{name: "string literal", tmpl: `${{ "previous_step" }}`, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have, err := References(tc.tmpl, "previous_step")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if have != tc.want {
+				t.Fatalf("wrong result: have=%t want=%t", have, tc.want)
+			}
+		})
+	}
+
+	if _, err := References(`${{ previous_step.stdout`, "previous_step"); err == nil {
+		t.Fatal("expected error for invalid template")
+	}
+}
+
 func TestRenderChangesetTemplateField(t *testing.T) {
 	// To avoid bugs due to differences between test setup and actual code, we
 	// do the actual parsing of YAML here to get an interface{} which we'll put
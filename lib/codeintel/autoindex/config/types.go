@@ -15,6 +15,7 @@ type IndexJob struct {
 	IndexerArgs      []string     `json:"indexer_args" yaml:"indexer_args"`
 	Outfile          string       `json:"outfile" yaml:"outfile"`
 	RequestedEnvVars []string     `json:"requestedEnvVars" yaml:"requestedEnvVars"`
+	Caches           []Cache      `json:"caches,omitempty" yaml:"caches,omitempty"`
 }
 
 func (j IndexJob) GetRoot() string {
@@ -35,6 +36,17 @@ type DockerStep struct {
 	Commands []string `json:"commands" yaml:"commands"`
 }
 
+// Cache declares directories that are restored before the steps of an index job run,
+// and saved after the job succeeded. Paths and key files are relative to the root of
+// the index job.
+type Cache struct {
+	// Key names the cache. The content of the key files is hashed into the final key,
+	// so that a cache is only reused while the key files are unchanged.
+	Key      string   `json:"key" yaml:"key"`
+	KeyFiles []string `json:"key_files" yaml:"key_files"`
+	Paths    []string `json:"paths" yaml:"paths"`
+}
+
 type HintConfidence int
 
 const (
@@ -22,6 +22,10 @@ index_jobs:
     indexer: lsif-go
     indexer_args:
       - --no-animation
+    caches:
+      - key: go-mod
+        key_files: [go.sum]
+        paths: [.cache/go-mod]
   -
     root: web/
     indexer: scip-typescript
@@ -54,6 +58,9 @@ func TestUnmarshalYAML(t *testing.T) {
 				},
 				Indexer:     "lsif-go",
 				IndexerArgs: []string{"--no-animation"},
+				Caches: []Cache{
+					{Key: "go-mod", KeyFiles: []string{"go.sum"}, Paths: []string{".cache/go-mod"}},
+				},
 			},
 			{
 				Steps:       nil,
@@ -0,0 +1,2 @@
+ALTER TABLE gitserver_repos DROP COLUMN IF EXISTS shard_migration_state;
+ALTER TABLE gitserver_repos DROP COLUMN IF EXISTS shard_migration_source;
@@ -0,0 +1,5 @@
+ALTER TABLE gitserver_repos ADD COLUMN IF NOT EXISTS shard_migration_state TEXT;
+ALTER TABLE gitserver_repos ADD COLUMN IF NOT EXISTS shard_migration_source TEXT;
+
+COMMENT ON COLUMN gitserver_repos.shard_migration_state IS 'The state of copying this repo from its previous gitserver shard after the set of shards changed: pending, copying, copied or failed.';
+COMMENT ON COLUMN gitserver_repos.shard_migration_source IS 'The gitserver shard this repo is being copied from.';
@@ -0,0 +1,33 @@
+ALTER TABLE lsif_indexes ADD COLUMN IF NOT EXISTS caches jsonb[];
+
+COMMENT ON COLUMN lsif_indexes.caches IS 'Directories that are restored before the index job runs and saved after it succeeded, keyed by a hash of the declared key files.';
+
+DROP VIEW IF EXISTS lsif_indexes_with_repository_name;
+
+CREATE VIEW lsif_indexes_with_repository_name AS
+    SELECT u.id,
+        u.commit,
+        u.queued_at,
+        u.state,
+        u.failure_message,
+        u.started_at,
+        u.finished_at,
+        u.repository_id,
+        u.process_after,
+        u.num_resets,
+        u.num_failures,
+        u.docker_steps,
+        u.root,
+        u.indexer,
+        u.indexer_args,
+        u.outfile,
+        u.log_contents,
+        u.execution_logs,
+        u.local_steps,
+        u.should_reindex,
+        u.requested_envvars,
+        u.caches,
+        r.name AS repository_name
+    FROM (lsif_indexes u
+        JOIN repo r ON ((r.id = u.repository_id)))
+    WHERE (r.deleted_at IS NULL);
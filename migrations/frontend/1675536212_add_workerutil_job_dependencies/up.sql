@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS workerutil_job_dependencies (
+    job_table text NOT NULL,
+    job_id integer NOT NULL,
+    dependency_table text NOT NULL,
+    dependency_id integer NOT NULL,
+    dependency_state text DEFAULT 'pending'::text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    PRIMARY KEY (job_table, job_id, dependency_table, dependency_id)
+);
+
+CREATE INDEX IF NOT EXISTS workerutil_job_dependencies_dependency ON workerutil_job_dependencies (dependency_table, dependency_id);
+CREATE INDEX IF NOT EXISTS workerutil_job_dependencies_dependency_state ON workerutil_job_dependencies (dependency_state) WHERE dependency_state <> 'completed';
+
+COMMENT ON TABLE workerutil_job_dependencies IS 'Declares that the dbworker record job_table.job_id may only be processed once the dbworker record dependency_table.dependency_id has completed.';
+COMMENT ON COLUMN workerutil_job_dependencies.dependency_state IS 'The last known outcome of the dependency: pending, completed or failed. Updated by the job dependency scheduler.';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS batch_spec_execution_cache_entries_key;
+
+DROP TABLE IF EXISTS batch_spec_execution_cache_stats;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS batch_spec_execution_cache_stats (
+    batch_spec_id bigint PRIMARY KEY,
+    steps integer NOT NULL DEFAULT 0,
+    cached_steps integer NOT NULL DEFAULT 0,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE batch_spec_execution_cache_stats IS 'Tracks how many steps of a batch spec could be served from the execution cache. Rows are kept after the batch spec is deleted.';
+COMMENT ON COLUMN batch_spec_execution_cache_stats.steps IS 'The number of steps to run over all workspaces of the batch spec.';
+COMMENT ON COLUMN batch_spec_execution_cache_stats.cached_steps IS 'The number of steps for which a cached result was found.';
+
+CREATE INDEX IF NOT EXISTS batch_spec_execution_cache_stats_created_at ON batch_spec_execution_cache_stats (created_at);
+
+-- Content addressed cache entries are looked up regardless of the user that created them.
+CREATE INDEX IF NOT EXISTS batch_spec_execution_cache_entries_key ON batch_spec_execution_cache_entries (key);
@@ -0,0 +1,71 @@
+ALTER TABLE changesets
+    ADD COLUMN IF NOT EXISTS conflict_state text DEFAULT 'UNKNOWN'::text NOT NULL,
+    ADD COLUMN IF NOT EXISTS conflict_base_rev text,
+    ADD COLUMN IF NOT EXISTS conflict_spec_id bigint,
+    ADD COLUMN IF NOT EXISTS conflict_checked_at timestamp with time zone,
+    ADD COLUMN IF NOT EXISTS rebase_base_rev text,
+    ADD COLUMN IF NOT EXISTS rebased_base_rev text;
+
+COMMENT ON COLUMN changesets.conflict_state IS 'Whether the diff of the changeset spec conflict_spec_id applies to the latest revision of the base branch.';
+COMMENT ON COLUMN changesets.conflict_base_rev IS 'The revision of the base branch the diff was last checked against.';
+COMMENT ON COLUMN changesets.rebase_base_rev IS 'The revision of the base branch the changeset should be rebased onto the next time it is reconciled.';
+COMMENT ON COLUMN changesets.rebased_base_rev IS 'The revision of the base branch the last pushed commit was based on, if the changeset was rebased.';
+
+CREATE INDEX IF NOT EXISTS changesets_conflict_checked_at ON changesets (conflict_checked_at);
+
+DROP VIEW IF EXISTS reconciler_changesets;
+
+CREATE VIEW reconciler_changesets AS
+ SELECT c.id,
+    c.batch_change_ids,
+    c.repo_id,
+    c.queued_at,
+    c.created_at,
+    c.updated_at,
+    c.metadata,
+    c.external_id,
+    c.external_service_type,
+    c.external_deleted_at,
+    c.external_branch,
+    c.external_updated_at,
+    c.external_state,
+    c.external_review_state,
+    c.external_check_state,
+    c.diff_stat_added,
+    c.diff_stat_deleted,
+    c.sync_state,
+    c.current_spec_id,
+    c.previous_spec_id,
+    c.publication_state,
+    c.owned_by_batch_change_id,
+    c.reconciler_state,
+    c.computed_state,
+    c.failure_message,
+    c.started_at,
+    c.finished_at,
+    c.process_after,
+    c.num_resets,
+    c.closing,
+    c.num_failures,
+    c.log_contents,
+    c.execution_logs,
+    c.syncer_error,
+    c.external_title,
+    c.worker_hostname,
+    c.ui_publication_state,
+    c.last_heartbeat_at,
+    c.external_fork_namespace,
+    c.detached_at,
+    c.conflict_state,
+    c.conflict_base_rev,
+    c.conflict_spec_id,
+    c.conflict_checked_at,
+    c.rebase_base_rev,
+    c.rebased_base_rev
+   FROM (changesets c
+     JOIN repo r ON ((r.id = c.repo_id)))
+  WHERE ((r.deleted_at IS NULL) AND (EXISTS ( SELECT 1
+           FROM ((batch_changes
+             LEFT JOIN users namespace_user ON ((batch_changes.namespace_user_id = namespace_user.id)))
+             LEFT JOIN orgs namespace_org ON ((batch_changes.namespace_org_id = namespace_org.id)))
+          WHERE ((c.batch_change_ids ? (batch_changes.id)::text) AND (namespace_user.deleted_at IS NULL) AND (namespace_org.deleted_at IS NULL)))));
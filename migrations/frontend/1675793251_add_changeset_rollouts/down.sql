@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS changeset_rollout_changesets;
+DROP TABLE IF EXISTS changeset_rollouts;
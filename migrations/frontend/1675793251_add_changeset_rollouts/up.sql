@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS changeset_rollouts (
+    id bigserial PRIMARY KEY,
+    batch_change_id bigint NOT NULL REFERENCES batch_changes(id) ON DELETE CASCADE DEFERRABLE,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    state text DEFAULT 'RUNNING'::text NOT NULL,
+    squash boolean DEFAULT false NOT NULL,
+    soak_time_seconds integer DEFAULT 0 NOT NULL,
+    current_wave integer DEFAULT 0 NOT NULL,
+    current_bulk_group text,
+    wave_started_at timestamp with time zone,
+    wave_merged_at timestamp with time zone,
+    failure_message text,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS changeset_rollouts_state ON changeset_rollouts (state);
+CREATE UNIQUE INDEX IF NOT EXISTS changeset_rollouts_active_batch_change_id ON changeset_rollouts (batch_change_id) WHERE state IN ('RUNNING', 'PAUSED');
+
+COMMENT ON TABLE changeset_rollouts IS 'Staged merges of the changesets of a batch change. The changesets of a rollout are merged wave by wave.';
+COMMENT ON COLUMN changeset_rollouts.current_wave IS 'The zero-based index of the wave that is currently being merged.';
+COMMENT ON COLUMN changeset_rollouts.current_bulk_group IS 'The bulk group of the changeset jobs that merge the current wave.';
+COMMENT ON COLUMN changeset_rollouts.wave_merged_at IS 'The time at which all changesets of the current wave were merged. The next wave is started once soak_time_seconds have passed since.';
+
+CREATE TABLE IF NOT EXISTS changeset_rollout_changesets (
+    rollout_id bigint NOT NULL REFERENCES changeset_rollouts(id) ON DELETE CASCADE DEFERRABLE,
+    changeset_id bigint NOT NULL REFERENCES changesets(id) ON DELETE CASCADE DEFERRABLE,
+    wave integer NOT NULL,
+    PRIMARY KEY (rollout_id, changeset_id)
+);
+
+CREATE INDEX IF NOT EXISTS changeset_rollout_changesets_changeset_id ON changeset_rollout_changesets (changeset_id);
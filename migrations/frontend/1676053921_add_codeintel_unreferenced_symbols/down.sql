@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS codeintel_unreferenced_symbols_reports;
+DROP TABLE IF EXISTS codeintel_unreferenced_symbols;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS codeintel_unreferenced_symbols (
+    id bigserial PRIMARY KEY,
+    repository_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    upload_id integer NOT NULL REFERENCES lsif_uploads(id) ON DELETE CASCADE,
+    symbol_name text NOT NULL,
+    document_path text NOT NULL,
+    language text NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS codeintel_unreferenced_symbols_repository_id_language ON codeintel_unreferenced_symbols (repository_id, language);
+
+COMMENT ON TABLE codeintel_unreferenced_symbols IS 'Symbols defined in a precise index visible at the tip of the default branch of a repository that are not referenced from any other index.';
+COMMENT ON COLUMN codeintel_unreferenced_symbols.symbol_name IS 'The SCIP symbol name of the unreferenced symbol.';
+COMMENT ON COLUMN codeintel_unreferenced_symbols.document_path IS 'The path (relative to the repository root) of the document defining the unreferenced symbol.';
+
+CREATE TABLE IF NOT EXISTS codeintel_unreferenced_symbols_reports (
+    repository_id integer PRIMARY KEY REFERENCES repo(id) ON DELETE CASCADE,
+    computed_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE codeintel_unreferenced_symbols_reports IS 'Tracks when the unreferenced symbols of a repository were last computed.';
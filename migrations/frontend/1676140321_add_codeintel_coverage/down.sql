@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS codeintel_coverage_reports;
+DROP TABLE IF EXISTS codeintel_coverage;
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS codeintel_coverage (
+    repository_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    language text NOT NULL,
+    indexers text[] NOT NULL,
+    num_uploads integer NOT NULL,
+    upload_id integer REFERENCES lsif_uploads(id) ON DELETE SET NULL,
+    commits_behind integer,
+    last_uploaded_at timestamp with time zone,
+    last_failure_at timestamp with time zone,
+    last_failure_reason text,
+    PRIMARY KEY (repository_id, language)
+);
+
+CREATE INDEX IF NOT EXISTS codeintel_coverage_language ON codeintel_coverage (language);
+
+COMMENT ON TABLE codeintel_coverage IS 'The precise code intelligence coverage of the tip of the default branch of a repository per language.';
+COMMENT ON COLUMN codeintel_coverage.indexers IS 'The indexers of the uploads and index jobs of the language.';
+COMMENT ON COLUMN codeintel_coverage.num_uploads IS 'The number of uploads of the language visible at the tip of the default branch.';
+COMMENT ON COLUMN codeintel_coverage.upload_id IS 'The visible upload of the language closest to the tip of the default branch.';
+COMMENT ON COLUMN codeintel_coverage.commits_behind IS 'The number of commits between the tip of the default branch and the commit of the closest visible upload. Null if unknown.';
+COMMENT ON COLUMN codeintel_coverage.last_failure_reason IS 'The failure message of the most recently failed upload or index job of the language.';
+
+CREATE TABLE IF NOT EXISTS codeintel_coverage_reports (
+    repository_id integer PRIMARY KEY REFERENCES repo(id) ON DELETE CASCADE,
+    head_commit text,
+    computed_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE codeintel_coverage_reports IS 'Tracks when the precise code intelligence coverage of a repository was last computed.';
+COMMENT ON COLUMN codeintel_coverage_reports.head_commit IS 'The tip of the default branch at the time the coverage was computed.';
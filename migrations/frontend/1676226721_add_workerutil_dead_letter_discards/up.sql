@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS workerutil_dead_letter_discards (
+    table_name text NOT NULL,
+    record_id integer NOT NULL,
+    discarded_at timestamp with time zone DEFAULT now() NOT NULL,
+    PRIMARY KEY (table_name, record_id)
+);
+
+COMMENT ON TABLE workerutil_dead_letter_discards IS 'Failed dbworker records that a site admin discarded from the dead-letter view. The records themselves are kept.';
@@ -530,6 +530,24 @@ type DebugLog struct {
 	// ExtsvcGitlab description: Log GitLab API requests.
 	ExtsvcGitlab bool `json:"extsvc.gitlab,omitempty"`
 }
+type DocumentRankingPathWeight struct {
+	// Pattern description: A glob pattern matched against the repository-relative path of a document (e.g. "**/internal/**" or "*.md").
+	Pattern string `json:"pattern"`
+	// Weight description: The weight of documents matching the pattern.
+	Weight float64 `json:"weight"`
+}
+
+// DocumentRankingSignals description: Site-defined signals contributing to the rank of documents within indexed repositories. Each weight multiplies a signal valued in [0, 1]; the weighted sum is ranked after the generated, vendor, and test heuristics but before the global document rank. Use the document rank explanation endpoint to debug the resulting rank of a file.
+type DocumentRankingSignals struct {
+	// CodeownersWeight description: The weight of documents that have at least one owner according to the CODEOWNERS file of the repository.
+	CodeownersWeight float64 `json:"codeownersWeight,omitempty"`
+	// PathWeights description: Weights applied to documents whose repository-relative path matches a glob pattern. The weights of all matching patterns are summed. Negative weights rank matching documents lower.
+	PathWeights []*DocumentRankingPathWeight `json:"pathWeights,omitempty"`
+	// RecencyHalfLifeDays description: The number of days after which the recency signal of a document's last change is halved.
+	RecencyHalfLifeDays int `json:"recencyHalfLifeDays,omitempty"`
+	// RecencyWeight description: The weight of the recency of the last commit changing a document on the repository's default branch. The signal halves every recencyHalfLifeDays days.
+	RecencyWeight float64 `json:"recencyWeight,omitempty"`
+}
 
 // Dotcom description: Configuration options for Sourcegraph.com only.
 type Dotcom struct {
@@ -701,6 +719,8 @@ type ExperimentalFeatures struct {
 	EnablePostSignupFlow bool `json:"enablePostSignupFlow,omitempty"`
 	// EventLogging description: Enables user event logging inside of the Sourcegraph instance. This will allow admins to have greater visibility of user activity, such as frequently viewed pages, frequent searches, and more. These event logs (and any specific user actions) are only stored locally, and never leave this Sourcegraph instance.
 	EventLogging string `json:"eventLogging,omitempty"`
+	// GitPartialClone description: JSON array of repo name patterns and partial clone filters. Repositories matching a pattern are cloned without the file contents excluded by the filter, which are fetched from the code host on demand when they are first read. Pattern matches are attempted in the order they are provided. Only applies to newly cloned Git repositories.
+	GitPartialClone []*PartialCloneRule `json:"gitPartialClone,omitempty"`
 	// GitServerPinnedRepos description: List of repositories pinned to specific gitserver instances. The specified repositories will remain at their pinned servers on scaling the cluster. If the specified pinned server differs from the current server that stores the repository, then it must be re-cloned to the specified server.
 	GitServerPinnedRepos map[string]string `json:"gitServerPinnedRepos,omitempty"`
 	// GoPackages description: Allow adding Go package host connections
@@ -737,14 +757,18 @@ type ExperimentalFeatures struct {
 	SearchIndexQueryContexts bool `json:"search.index.query.contexts,omitempty"`
 	// SearchIndexRevisions description: An array of objects describing rules for extra revisions (branch, ref, tag, commit sha, etc) to be indexed for all repositories that match them. We always index the default branch ("HEAD") and revisions in version contexts. This allows specifying additional revisions. Sourcegraph can index up to 64 branches per repository.
 	SearchIndexRevisions []*SearchIndexRevisionsRule `json:"search.index.revisions,omitempty"`
+	// SearchLfs description: An array of rules enabling search of files stored with Git LFS for the repositories matching them. By default only the LFS pointer files are searched. For matching repositories, LFS objects are fetched when preparing archives for unindexed search and when indexing.
+	SearchLfs []*SearchLFSRule `json:"search.lfs,omitempty"`
 	// SearchSanitization description: Allows site admins to specify a list of regular expressions representing matched content that should be omitted from search results. Also allows admins to specify the name of an organization within their Sourcegraph instance whose members are trusted and will not have their search results sanitized. Enable this feature by adding at least one valid regular expression to the value of the `sanitizePatterns` field on this object. Site admins will not have their searches sanitized.
 	SearchSanitization *SearchSanitization `json:"search.sanitization,omitempty"`
 	// StructuralSearch description: Enables structural search.
 	StructuralSearch   string              `json:"structuralSearch,omitempty"`
 	SubRepoPermissions *SubRepoPermissions `json:"subRepoPermissions,omitempty"`
 	// TlsExternal description: Global TLS/SSL settings for Sourcegraph to use when communicating with code hosts.
-	TlsExternal *TlsExternal   `json:"tls.external,omitempty"`
-	Additional  map[string]any `json:"-"` // additionalProperties not explicitly defined in the schema
+	TlsExternal *TlsExternal `json:"tls.external,omitempty"`
+	// VirtualRepos description: JSON array of virtual repositories. A virtual repository is backed by a set of paths inside another repository, e.g. a single service of a monorepo. It is searched, indexed and analysed as a repository of its own, but only contains the configured paths. Virtual repositories share the clone of the repository they are defined on.
+	VirtualRepos []*VirtualRepo `json:"virtualRepos,omitempty"`
+	Additional   map[string]any `json:"-"` // additionalProperties not explicitly defined in the schema
 }
 
 func (v ExperimentalFeatures) MarshalJSON() ([]byte, error) {
@@ -786,6 +810,7 @@ func (v *ExperimentalFeatures) UnmarshalJSON(data []byte) error {
 	delete(m, "enablePermissionsWebhooks")
 	delete(m, "enablePostSignupFlow")
 	delete(m, "eventLogging")
+	delete(m, "gitPartialClone")
 	delete(m, "gitServerPinnedRepos")
 	delete(m, "goPackages")
 	delete(m, "insightsAlternateLoadingStrategy")
@@ -804,10 +829,12 @@ func (v *ExperimentalFeatures) UnmarshalJSON(data []byte) error {
 	delete(m, "search.index.branches")
 	delete(m, "search.index.query.contexts")
 	delete(m, "search.index.revisions")
+	delete(m, "search.lfs")
 	delete(m, "search.sanitization")
 	delete(m, "structuralSearch")
 	delete(m, "subRepoPermissions")
 	delete(m, "tls.external")
+	delete(m, "virtualRepos")
 	if len(m) > 0 {
 		v.Additional = make(map[string]any, len(m))
 	}
@@ -1619,6 +1646,12 @@ type PagureRateLimit struct {
 type ParentSourcegraph struct {
 	Url string `json:"url,omitempty"`
 }
+type PartialCloneRule struct {
+	// Filter description: The git object filter to clone with. Either "blob:none" to omit all file contents or "blob:limit=<n>[kmg]" to omit files larger than the given size.
+	Filter string `json:"filter"`
+	// Pattern description: Regular expression which matches against the name of a repository (e.g. "^github\.com/owner/name$" for a single repository or "^github\.example\.com/" for all repositories of a code host).
+	Pattern string `json:"pattern"`
+}
 
 // PasswordPolicy description: DEPRECATED: this is now a standard feature see: auth.passwordPolicy
 type PasswordPolicy struct {
@@ -1732,6 +1765,8 @@ type QuickLink struct {
 type Ranking struct {
 	// DocumentRanksWeight description: Controls the impact of document ranks on the final ranking when the 'search-ranking' feature is enabled. This is intended for internal testing purposes only, it's not recommended for users to change this.
 	DocumentRanksWeight *float64 `json:"documentRanksWeight,omitempty"`
+	// DocumentSignals description: Site-defined signals contributing to the rank of documents within indexed repositories. Each weight multiplies a signal valued in [0, 1]; the weighted sum is ranked after the generated, vendor, and test heuristics but before the global document rank. Use the document rank explanation endpoint to debug the resulting rank of a file.
+	DocumentSignals *DocumentRankingSignals `json:"documentSignals,omitempty"`
 	// MaxQueueMatchCount description: The maximum number of matches that can be buffered to sort results. The default is -1 (unbounded). Setting this to a positive integer protects frontend against OOMs for queries with extremely high count of matches per repository.
 	MaxQueueMatchCount *int `json:"maxQueueMatchCount,omitempty"`
 	// MaxQueueSizeBytes description: The maximum number of bytes that can be buffered to sort results. The default is -1 (unbounded). Setting this to a positive integer protects frontend against OOMs.
@@ -1868,6 +1903,12 @@ type SearchIndexRevisionsRule struct {
 	// Revisions description: Revisions to index
 	Revisions []string `json:"revisions"`
 }
+type SearchLFSRule struct {
+	// MaxFileSize description: The maximum size in bytes of LFS objects to fetch. Files with larger LFS objects are searched as pointer files. Fetched files are subject to the same size limits as other files, see search.largeFiles. Defaults to 2097152 (2 MiB).
+	MaxFileSize int `json:"maxFileSize,omitempty"`
+	// Name description: Regular expression which matches against the name of a repository (e.g. "^github\.com/owner/name$").
+	Name string `json:"name"`
+}
 
 // SearchLimits description: Limits that search applies for number of repositories searched and timeouts.
 type SearchLimits struct {
@@ -2347,6 +2388,8 @@ type SiteConfiguration struct {
 	AuthzRefreshInterval int `json:"authz.refreshInterval,omitempty"`
 	// AuthzSyncJobsRecordsLimit description: EXPERIMENTAL: Number of sync job records to retain. Set to a negative value to disable sync jobs records entirely.
 	AuthzSyncJobsRecordsLimit int `json:"authz.syncJobsRecordsLimit,omitempty"`
+	// BatchChangesAutoRebase description: When enabled, open changesets whose diff still applies to the latest revision of their base branch are rebased onto it automatically.
+	BatchChangesAutoRebase bool `json:"batchChanges.autoRebase,omitempty"`
 	// BatchChangesChangesetsRetention description: How long changesets will be retained after they have been detached from a batch change.
 	BatchChangesChangesetsRetention string `json:"batchChanges.changesetsRetention,omitempty"`
 	// BatchChangesDisableWebhooksWarning description: Hides Batch Changes warnings about webhooks not being configured.
@@ -2582,6 +2625,7 @@ func (v *SiteConfiguration) UnmarshalJSON(data []byte) error {
 	delete(m, "authz.enforceForSiteAdmins")
 	delete(m, "authz.refreshInterval")
 	delete(m, "authz.syncJobsRecordsLimit")
+	delete(m, "batchChanges.autoRebase")
 	delete(m, "batchChanges.changesetsRetention")
 	delete(m, "batchChanges.disableWebhooksWarning")
 	delete(m, "batchChanges.enabled")
@@ -2773,6 +2817,14 @@ type UpdateIntervalRule struct {
 type UsernameIdentity struct {
 	Type string `json:"type"`
 }
+type VirtualRepo struct {
+	// Name description: The name of the virtual repository (e.g. "github.com/owner/monorepo/services/payments"). It must not be the name of another repository.
+	Name string `json:"name"`
+	// Paths description: The directories or files of the repository which are part of the virtual repository, relative to the repository root.
+	Paths []string `json:"paths"`
+	// Repo description: The name of the repository the virtual repository is defined on (e.g. "github.com/owner/monorepo").
+	Repo string `json:"repo"`
+}
 
 // WebhookLogging description: Configuration for logging incoming webhooks.
 type WebhookLogging struct {